@@ -2,12 +2,17 @@
 package analyzer
 
 import (
+	"iter"
+	"slices"
+	"sync"
+
 	"github.com/ahmadramadhannn/tsgoast/ast"
 )
 
 // Analyzer provides high-level AST analysis capabilities.
 type Analyzer struct {
-	root *ast.BaseNode
+	root  *ast.BaseNode
+	index *nodeIndex // set by Index
 }
 
 // New creates a new analyzer for the given AST root node.
@@ -24,33 +29,201 @@ func (a *Analyzer) Root() *ast.BaseNode {
 
 // Visit traverses the AST and calls the visitor function for each node.
 // If the visitor returns false, traversal of that subtree is stopped.
-func (a *Analyzer) Visit(visitor func(node ast.Node) bool) {
+// Nodes are visited in pre-order unless another order is selected with
+// WithOrder.
+func (a *Analyzer) Visit(visitor func(node ast.Node) bool, opts ...VisitOption) {
 	if a.root == nil {
 		return
 	}
-	a.visitNode(a.root, visitor)
+	newVisitConfig(opts).traverse(a.root, visitor)
+}
+
+// visitSubtree traverses the subtree rooted at node the same way Visit does.
+func visitSubtree(node ast.Node, visitor func(ast.Node) bool) {
+	(&Analyzer{}).visitNode(node, visitor)
 }
 
+// visitNode traverses the subtree rooted at node in pre-order. It uses an
+// explicit stack rather than recursion so that deeply nested trees, as found
+// in generated code, cannot exhaust the goroutine stack.
 func (a *Analyzer) visitNode(node ast.Node, visitor func(ast.Node) bool) {
 	if node == nil {
 		return
 	}
 
-	// Call visitor, if it returns false, stop traversing this subtree
-	if !visitor(node) {
+	stack := []ast.Node{node}
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		// Call visitor, if it returns false, stop traversing this subtree
+		if !visitor(current) {
+			continue
+		}
+
+		// Push children in reverse so they are visited in source order
+		children := current.Children()
+		for i := len(children) - 1; i >= 0; i-- {
+			if children[i] != nil {
+				stack = append(stack, children[i])
+			}
+		}
+	}
+}
+
+// VisitWithPath traverses the AST like Visit, also passing the ancestors of
+// each node ordered from the root to the parent. The ancestors slice is
+// reused between calls and must be copied if retained.
+func (a *Analyzer) VisitWithPath(visitor func(node ast.Node, ancestors []ast.Node) bool) {
+	if a.root == nil {
+		return
+	}
+
+	// Each frame holds a node and the index of its next child to visit;
+	// the nodes on the stack are the ancestors of the next child visited.
+	type frame struct {
+		node ast.Node
+		next int
+	}
+	var ancestors []ast.Node
+	var stack []frame
+
+	if !visitor(a.root, ancestors) {
 		return
 	}
+	stack = append(stack, frame{node: a.root})
+	ancestors = append(ancestors, a.root)
+	for len(stack) > 0 {
+		top := &stack[len(stack)-1]
+		children := top.node.Children()
+		if top.next >= len(children) {
+			stack = stack[:len(stack)-1]
+			ancestors = ancestors[:len(ancestors)-1]
+			continue
+		}
+		child := children[top.next]
+		top.next++
+		if child == nil || !visitor(child, ancestors) {
+			continue
+		}
+		stack = append(stack, frame{node: child})
+		ancestors = append(ancestors, child)
+	}
+}
 
-	// Visit children
-	for _, child := range node.Children() {
-		a.visitNode(child, visitor)
+// All returns an iterator over every node of the AST in depth-first
+// pre-order. Unlike FindNodes, it allocates no result slice and stops as
+// soon as the loop breaks.
+func (a *Analyzer) All() iter.Seq[ast.Node] {
+	if a.root == nil {
+		return func(func(ast.Node) bool) {}
 	}
+	return ast.Preorder(a.root)
 }
 
-// FindNodes finds all nodes matching the given predicate.
-func (a *Analyzer) FindNodes(predicate func(node ast.Node) bool) []ast.Node {
-	var results []ast.Node
-	a.Visit(func(node ast.Node) bool {
+// ByType returns an iterator over the nodes of the given type.
+func (a *Analyzer) ByType(nodeType ast.NodeType) iter.Seq[ast.Node] {
+	if a.index != nil {
+		return slices.Values(a.index.typeNodes(nodeType))
+	}
+	return a.filter(func(node ast.Node) bool {
+		return node.Type() == nodeType
+	})
+}
+
+// ByKind returns an iterator over the nodes of the given tree-sitter kind.
+func (a *Analyzer) ByKind(kind string) iter.Seq[ast.Node] {
+	if a.index != nil {
+		return slices.Values(a.index.kindNodes(kind))
+	}
+	return a.filter(func(node ast.Node) bool {
+		return node.SyntaxKind() == kind
+	})
+}
+
+// filter returns an iterator over the nodes matching predicate.
+func (a *Analyzer) filter(predicate func(ast.Node) bool) iter.Seq[ast.Node] {
+	return func(yield func(ast.Node) bool) {
+		for node := range a.All() {
+			if predicate(node) && !yield(node) {
+				return
+			}
+		}
+	}
+}
+
+// FindNodes finds all nodes matching the given predicate, in pre-order
+// unless another order is selected with WithOrder.
+func (a *Analyzer) FindNodes(predicate func(node ast.Node) bool, opts ...VisitOption) NodeList {
+	if a.root == nil {
+		return nil
+	}
+	return FindNodesIn(a.root, predicate, opts...)
+}
+
+// FindNodesIn finds the nodes matching predicate within the subtree rooted
+// at subtree, such as a single function body. Options are applied relative
+// to subtree, so WithMaxDepth(1) searches only its direct children.
+func FindNodesIn(subtree ast.Node, predicate func(node ast.Node) bool, opts ...VisitOption) NodeList {
+	c := newVisitConfig(opts)
+	var results NodeList
+	if c.capacity > 0 {
+		results = make(NodeList, 0, c.capacity)
+	}
+	return c.find(results, subtree, predicate)
+}
+
+// FindNodesInto appends the nodes matching predicate to dst and returns
+// the extended list, like FindNodes. Passing the result of an earlier call
+// truncated to zero length, as in buf = a.FindNodesInto(buf[:0], pred),
+// reuses its memory, so that repeated queries allocate nothing once the
+// buffer is large enough.
+func (a *Analyzer) FindNodesInto(dst NodeList, predicate func(node ast.Node) bool, opts ...VisitOption) NodeList {
+	if a.root == nil {
+		return dst
+	}
+	return newVisitConfig(opts).find(dst, a.root, predicate)
+}
+
+// stackPool holds the stacks of find, so that repeated queries do not
+// allocate one each.
+var stackPool = sync.Pool{
+	New: func() any {
+		stack := make([]ast.Node, 0, 64)
+		return &stack
+	},
+}
+
+// find appends the nodes of the subtree rooted at node matching predicate
+// to results.
+func (c visitConfig) find(results NodeList, node ast.Node, predicate func(node ast.Node) bool) NodeList {
+	if c.order == PreOrder && c.maxDepth < 0 {
+		if node == nil {
+			return results
+		}
+		pooled := stackPool.Get().(*[]ast.Node)
+		stack := append((*pooled)[:0], node)
+		for len(stack) > 0 {
+			current := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if predicate(current) {
+				results = append(results, current)
+			}
+			children := current.Children()
+			for i := len(children) - 1; i >= 0; i-- {
+				if children[i] != nil {
+					stack = append(stack, children[i])
+				}
+			}
+		}
+		// Drop the nodes left in the stack, not to keep the tree alive
+		clear(stack[:cap(stack)])
+		*pooled = stack
+		stackPool.Put(pooled)
+		return results
+	}
+
+	c.traverse(node, func(node ast.Node) bool {
 		if predicate(node) {
 			results = append(results, node)
 		}
@@ -59,11 +232,16 @@ func (a *Analyzer) FindNodes(predicate func(node ast.Node) bool) []ast.Node {
 	return results
 }
 
-// FindNodesByType finds all nodes of the given type.
-func (a *Analyzer) FindNodesByType(nodeType ast.NodeType) []ast.Node {
+// FindNodesByType finds all nodes of the given type. Once the analyzer is
+// indexed, in pre-order without a depth limit, it costs the number of
+// matches rather than a traversal of the tree.
+func (a *Analyzer) FindNodesByType(nodeType ast.NodeType, opts ...VisitOption) NodeList {
+	if nodes, ok := a.indexed(opts, a.index.typeNodes(nodeType)); ok {
+		return nodes
+	}
 	return a.FindNodes(func(node ast.Node) bool {
 		return node.Type() == nodeType
-	})
+	}, opts...)
 }
 
 // CountNodes counts all nodes matching the given predicate.
@@ -80,7 +258,19 @@ func (a *Analyzer) CountNodes(predicate func(node ast.Node) bool) int {
 
 // CountNodesByType counts all nodes of the given type.
 func (a *Analyzer) CountNodesByType(nodeType ast.NodeType) int {
+	if a.index != nil {
+		return len(a.index.typeNodes(nodeType))
+	}
 	return a.CountNodes(func(node ast.Node) bool {
 		return node.Type() == nodeType
 	})
 }
+
+// Walk traverses the AST with a visitor that is notified both when entering
+// and when leaving each node. See ast.Walk.
+func (a *Analyzer) Walk(v ast.Visitor) {
+	if a.root == nil {
+		return
+	}
+	ast.Walk(a.root, v)
+}
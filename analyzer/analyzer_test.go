@@ -2,6 +2,7 @@ package analyzer
 
 import (
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/ahmadramadhannn/tsgoast"
@@ -105,6 +106,36 @@ func TestFindNodes(t *testing.T) {
 	}
 }
 
+func TestFindNodesInto(t *testing.T) {
+	root := &ast.BaseNode{NodeType: ast.NodeTypeFunction}
+	for i := 0; i < 10; i++ {
+		root.ChildNodes = append(root.ChildNodes, &ast.BaseNode{NodeType: ast.NodeTypeIdentifier})
+	}
+	analyzer := New(root)
+	isIdentifier := func(node ast.Node) bool {
+		return node.Type() == ast.NodeTypeIdentifier
+	}
+
+	found := analyzer.FindNodes(isIdentifier, WithCapacity(16))
+	if len(found) != 10 || cap(found) != 16 {
+		t.Errorf("FindNodes() with capacity 16 = len %d, cap %d", len(found), cap(found))
+	}
+
+	buf := analyzer.FindNodesInto(nil, isIdentifier)
+	allocs := testing.AllocsPerRun(100, func() {
+		buf = analyzer.FindNodesInto(buf[:0], isIdentifier)
+	})
+	if len(buf) != 10 {
+		t.Errorf("FindNodesInto() found %d nodes, want 10", len(buf))
+	}
+	if allocs > 0 {
+		t.Errorf("FindNodesInto() into a buffer allocated %v times", allocs)
+	}
+	if got := analyzer.FindNodesInto(NodeList{root}, isIdentifier); len(got) != 11 || got[0] != root {
+		t.Error("FindNodesInto() did not append to dst")
+	}
+}
+
 func TestFindNodesByType(t *testing.T) {
 	root := &ast.BaseNode{
 		NodeType: ast.NodeTypeFunction,
@@ -281,6 +312,158 @@ func TestVisitNilRoot(t *testing.T) {
 	}
 }
 
+func TestAnalyzerWalk(t *testing.T) {
+	root := parseSource(t, `function outer() {
+	const a = 1;
+	function inner() {
+		const b = 2;
+	}
+}`)
+
+	// Track the enclosing function of each declaration
+	var stack []string
+	declared := make(map[string]string)
+	New(root).Walk(ast.VisitorFuncs{
+		EnterFunc: func(node ast.Node) bool {
+			switch node.SyntaxKind() {
+			case "function_declaration":
+				stack = append(stack, declarationName(node))
+			case "variable_declarator":
+				declared[declarationName(node)] = stack[len(stack)-1]
+			}
+			return true
+		},
+		ExitFunc: func(node ast.Node) {
+			if node.SyntaxKind() == "function_declaration" {
+				stack = stack[:len(stack)-1]
+			}
+		},
+	})
+
+	if declared["a"] != "outer" || declared["b"] != "inner" || len(stack) != 0 {
+		t.Errorf("declared = %v, stack = %v", declared, stack)
+	}
+
+	// Walking an empty analyzer is a no-op
+	New(nil).Walk(ast.VisitorFuncs{})
+}
+
+func TestVisitWithPath(t *testing.T) {
+	root := parseSource(t, `describe("api", () => {
+	it("loads", () => { load(); });
+});
+load();`)
+
+	var inDescribe, outside int
+	New(root).VisitWithPath(func(node ast.Node, ancestors []ast.Node) bool {
+		if node.SyntaxKind() != "call_expression" || calleeName(node) != "load" {
+			return true
+		}
+		if ancestors[0] != root {
+			t.Errorf("first ancestor is not the root")
+		}
+		if node.Parent() != ancestors[len(ancestors)-1] {
+			t.Errorf("last ancestor is not the parent")
+		}
+		for _, anc := range ancestors {
+			if anc.SyntaxKind() == "call_expression" && calleeName(anc) == "describe" {
+				inDescribe++
+				return true
+			}
+		}
+		outside++
+		return true
+	})
+
+	if inDescribe != 1 || outside != 1 {
+		t.Errorf("inDescribe = %d, outside = %d, want 1 and 1", inDescribe, outside)
+	}
+}
+
+// deepSource returns a statement nesting depth parenthesized expressions,
+// as found in generated code.
+func deepSource(depth int) string {
+	return "x = " + strings.Repeat("(", depth) + "1" + strings.Repeat(")", depth) + ";\n"
+}
+
+func TestVisitDeepTree(t *testing.T) {
+	const depth = 50000
+	a := New(parseSource(t, deepSource(depth)))
+
+	count, parens := 0, 0
+	a.Visit(func(node ast.Node) bool {
+		count++
+		if node.SyntaxKind() == "parenthesized_expression" {
+			parens++
+		}
+		return true
+	})
+	if parens != depth {
+		t.Errorf("Visit() visited %d parenthesized expressions, want %d", parens, depth)
+	}
+
+	if got := len(a.FindNodes(func(node ast.Node) bool { return node.SyntaxKind() == "number" })); got != 1 {
+		t.Errorf("FindNodes() found %d numbers, want 1", got)
+	}
+
+	// The number is below the program, the statement, the assignment and
+	// the parentheses
+	maxAncestors := 0
+	a.VisitWithPath(func(node ast.Node, ancestors []ast.Node) bool {
+		if len(ancestors) > maxAncestors {
+			maxAncestors = len(ancestors)
+		}
+		return true
+	})
+	if maxAncestors != depth+3 {
+		t.Errorf("VisitWithPath() max ancestors = %d, want %d", maxAncestors, depth+3)
+	}
+
+	exits := 0
+	a.Walk(ast.VisitorFuncs{ExitFunc: func(ast.Node) { exits++ }})
+	if exits != count {
+		t.Errorf("Walk() exited %d nodes, want %d", exits, count)
+	}
+}
+
+func TestIterators(t *testing.T) {
+	a := New(parseSource(t, `function a() {}
+const b = () => 1;
+function c() {}`))
+
+	var names []string
+	for fn := range a.ByType(ast.NodeTypeFunction) {
+		names = append(names, GetFunctionName(fn))
+	}
+	if len(names) != 2 || names[0] != "a" || names[1] != "c" {
+		t.Errorf("ByType(function) = %v, want [a c]", names)
+	}
+
+	count := 0
+	for range a.ByKind("arrow_function") {
+		count++
+	}
+	if count != 1 {
+		t.Errorf("ByKind(arrow_function) yielded %d nodes, want 1", count)
+	}
+
+	// Breaking stops the traversal early
+	visited := 0
+	for node := range a.All() {
+		visited++
+		if node.SyntaxKind() == "function_declaration" {
+			break
+		}
+	}
+	if visited != 2 {
+		t.Errorf("All() visited %d nodes before break, want 2", visited)
+	}
+
+	for range New(nil).All() {
+		t.Error("All() on an empty analyzer yielded a node")
+	}
+}
+
 func BenchmarkVisit(b *testing.B) {
 	// Create a tree with some depth
 	root := &ast.BaseNode{NodeType: ast.NodeTypeFunction}
@@ -0,0 +1,146 @@
+package analyzer
+
+import (
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+// NonNullAssertion represents a non-null assertion such as `user!.name`.
+type NonNullAssertion struct {
+	Node       ast.Node // the whole `x!` expression
+	Expression ast.Node // the asserted expression `x`
+	Range      ast.Range
+}
+
+// FindNonNullAssertions finds every non-null assertion (`x!`) in the AST.
+func (a *Analyzer) FindNonNullAssertions() []NonNullAssertion {
+	var assertions []NonNullAssertion
+	a.Visit(func(node ast.Node) bool {
+		if node.SyntaxKind() != "non_null_expression" {
+			return true
+		}
+
+		var expr ast.Node
+		if children := node.Children(); len(children) > 0 {
+			expr = children[0]
+		}
+
+		assertions = append(assertions, NonNullAssertion{
+			Node:       node,
+			Expression: expr,
+			Range:      node.Range(),
+		})
+		return true
+	})
+	return assertions
+}
+
+// TypeAssertion represents a type assertion, either `x as T` or `<T>x`.
+type TypeAssertion struct {
+	Node           ast.Node // the whole assertion expression
+	Expression     ast.Node // the asserted expression
+	TypeNode       ast.Node // the target type
+	Type           string   // text of the target type, e.g. "any" or "const"
+	IsAngleBracket bool     // true for the `<T>x` form
+	Range          ast.Range
+}
+
+// FindTypeAssertions finds every `as T` and `<T>x` expression accepted by
+// filter. A nil filter matches all assertions.
+func (a *Analyzer) FindTypeAssertions(filter func(TypeAssertion) bool) []TypeAssertion {
+	var assertions []TypeAssertion
+	a.Visit(func(node ast.Node) bool {
+		assertion, ok := newTypeAssertion(node)
+		if ok && (filter == nil || filter(assertion)) {
+			assertions = append(assertions, assertion)
+		}
+		return true
+	})
+	return assertions
+}
+
+// newTypeAssertion builds a TypeAssertion from an as_expression or
+// type_assertion node.
+func newTypeAssertion(node ast.Node) (TypeAssertion, bool) {
+	assertion := TypeAssertion{
+		Node:  node,
+		Range: node.Range(),
+	}
+
+	children := node.Children()
+	switch node.SyntaxKind() {
+	case "as_expression":
+		// expression "as" type
+		if len(children) < 3 || children[0] == nil || children[len(children)-1] == nil {
+			return assertion, false
+		}
+		assertion.Expression = children[0]
+		assertion.TypeNode = children[len(children)-1]
+	case "type_assertion":
+		// type_arguments expression
+		if len(children) < 2 || children[0] == nil || children[len(children)-1] == nil {
+			return assertion, false
+		}
+		assertion.IsAngleBracket = true
+		assertion.Expression = children[len(children)-1]
+		for _, child := range children[0].Children() {
+			if k := child.SyntaxKind(); k != "<" && k != ">" {
+				assertion.TypeNode = child
+				break
+			}
+		}
+		if assertion.TypeNode == nil {
+			return assertion, false
+		}
+	default:
+		return assertion, false
+	}
+
+	assertion.Type = assertion.TypeNode.Text()
+	return assertion, true
+}
+
+// IsAnyAssertion reports whether the assertion targets `any`
+// (`x as any` or `<any>x`).
+func IsAnyAssertion(assertion TypeAssertion) bool {
+	return assertion.Type == "any"
+}
+
+// IsDoubleAssertion reports whether the assertion launders a value through
+// `unknown` or `any` first, as in `x as unknown as T`.
+func IsDoubleAssertion(assertion TypeAssertion) bool {
+	expr := unwrapParentheses(assertion.Expression)
+	if expr == nil {
+		return false
+	}
+	inner, ok := newTypeAssertion(expr)
+	if !ok {
+		return false
+	}
+	return inner.Type == "unknown" || inner.Type == "any"
+}
+
+// IsUnsafeAssertion reports whether the assertion is an `any` cast or a
+// double cast.
+func IsUnsafeAssertion(assertion TypeAssertion) bool {
+	return IsAnyAssertion(assertion) || IsDoubleAssertion(assertion)
+}
+
+// unwrapParentheses returns the expression inside any number of
+// parenthesized_expression wrappers, or nil if an incomplete wrapper has
+// none.
+func unwrapParentheses(node ast.Node) ast.Node {
+	for node != nil && node.SyntaxKind() == "parenthesized_expression" {
+		var inner ast.Node
+		for _, child := range node.Children() {
+			if child == nil {
+				continue
+			}
+			if k := child.SyntaxKind(); k != "(" && k != ")" {
+				inner = child
+				break
+			}
+		}
+		node = inner
+	}
+	return node
+}
@@ -0,0 +1,98 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+func TestFindNonNullAssertions(t *testing.T) {
+	root := parseSource(t, `
+		const name = user!.profile!.name;
+		document.getElementById("app")!.focus();
+		const safe = user?.name;
+	`)
+
+	assertions := New(root).FindNonNullAssertions()
+
+	want := []string{"user!.profile", "user", `document.getElementById("app")`}
+	if len(assertions) != len(want) {
+		t.Fatalf("FindNonNullAssertions() found %d, want %d", len(assertions), len(want))
+	}
+
+	for i, expr := range want {
+		if got := assertions[i].Expression.Text(); got != expr {
+			t.Errorf("assertion %d expression = %q, want %q", i, got, expr)
+		}
+		if assertions[i].Range != assertions[i].Node.Range() {
+			t.Errorf("assertion %d range does not match node range", i)
+		}
+	}
+
+	if line := assertions[2].Range.Start.Line; line != 2 {
+		t.Errorf("third assertion line = %d, want 2", line)
+	}
+}
+
+func TestFindTypeAssertions(t *testing.T) {
+	root := parseSource(t, `
+		const a = x as unknown as User;
+		const b = <any>y;
+		const c = z as const;
+		const d = (w as any) as Config;
+		const e = v as string;
+	`)
+
+	a := New(root)
+
+	all := a.FindTypeAssertions(nil)
+	// includes the inner "x as unknown" and "w as any"
+	if len(all) != 7 {
+		t.Fatalf("FindTypeAssertions(nil) found %d, want 7", len(all))
+	}
+
+	tests := []struct {
+		name   string
+		filter func(TypeAssertion) bool
+		want   []string
+	}{
+		{"any", IsAnyAssertion, []string{"<any>y", "w as any"}},
+		{"double", IsDoubleAssertion, []string{"x as unknown as User", "(w as any) as Config"}},
+		{"unsafe", IsUnsafeAssertion, []string{"x as unknown as User", "<any>y", "(w as any) as Config", "w as any"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := a.FindTypeAssertions(tt.filter)
+			if len(got) != len(tt.want) {
+				t.Fatalf("found %d assertions, want %d", len(got), len(tt.want))
+			}
+			for i, text := range tt.want {
+				if got[i].Node.Text() != text {
+					t.Errorf("assertion %d = %q, want %q", i, got[i].Node.Text(), text)
+				}
+			}
+		})
+	}
+
+	angle := a.FindTypeAssertions(func(ta TypeAssertion) bool { return ta.IsAngleBracket })
+	if len(angle) != 1 || angle[0].Expression.Text() != "y" || angle[0].Type != "any" {
+		t.Errorf("angle-bracket assertion not extracted correctly: %+v", angle)
+	}
+}
+
+func TestTypeAssertionsIncomplete(t *testing.T) {
+	// Error recovery can leave wrappers without their inner expression
+	empty := &ast.BaseNode{TreeSitterKind: "parenthesized_expression", ChildNodes: []ast.Node{
+		&ast.BaseNode{TreeSitterKind: "(", Content: "("},
+		&ast.BaseNode{TreeSitterKind: ")", Content: ")"},
+	}}
+	partial := &ast.BaseNode{TreeSitterKind: "as_expression", ChildNodes: []ast.Node{
+		nil, &ast.BaseNode{TreeSitterKind: "as", Content: "as"}, nil,
+	}}
+	for _, expr := range []ast.Node{nil, empty, partial} {
+		if IsDoubleAssertion(TypeAssertion{Expression: expr}) {
+			t.Errorf("IsDoubleAssertion() of %v = true, want false", expr)
+		}
+	}
+}
@@ -0,0 +1,193 @@
+package analyzer
+
+import (
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+// Promise constructor anti-pattern reasons.
+const (
+	ReasonAsyncExecutor = "async executor"         // new Promise(async (resolve) => ...)
+	ReasonWrapsPromise  = "wraps existing promise" // new Promise((resolve) => fetch(x).then(resolve))
+)
+
+// promiseChainMethods are the methods that continue a promise chain.
+var promiseChainMethods = map[string]bool{
+	"then":    true,
+	"catch":   true,
+	"finally": true,
+}
+
+// knownAsyncAPIs are global functions known to return a promise.
+var knownAsyncAPIs = map[string]bool{
+	"fetch": true,
+}
+
+// AsyncStyleStats compares promise chain usage with async/await in a file.
+type AsyncStyleStats struct {
+	ThenCalls    int
+	CatchCalls   int
+	FinallyCalls int
+
+	// PromiseChains counts whole chains: a.then(f).catch(g) is one chain.
+	PromiseChains int
+
+	AwaitExpressions int
+	AsyncFunctions   int
+	NewPromises      int
+
+	// AntiPatterns holds the `new Promise` constructions that are unneeded
+	// around already-async code.
+	AntiPatterns []PromiseAntiPattern
+}
+
+// AwaitRatio returns the share of await expressions among await
+// expressions and promise chains, from 0 (chains only) to 1 (await only).
+// It returns 1 for files using neither.
+func (s AsyncStyleStats) AwaitRatio() float64 {
+	total := s.AwaitExpressions + s.PromiseChains
+	if total == 0 {
+		return 1
+	}
+	return float64(s.AwaitExpressions) / float64(total)
+}
+
+// PromiseAntiPattern is a `new Promise(...)` construction around code that
+// already produces a promise.
+type PromiseAntiPattern struct {
+	Node   ast.Node // the new_expression
+	Reason string
+	Range  ast.Range
+}
+
+// AsyncStyle computes promise chain and async/await statistics for the AST.
+func (a *Analyzer) AsyncStyle() AsyncStyleStats {
+	var stats AsyncStyleStats
+	a.Visit(func(node ast.Node) bool {
+		switch node.SyntaxKind() {
+		case "call_expression":
+			method := promiseChainMethod(node)
+			switch method {
+			case "then":
+				stats.ThenCalls++
+			case "catch":
+				stats.CatchCalls++
+			case "finally":
+				stats.FinallyCalls++
+			}
+			if method != "" && !continuesChain(node) {
+				stats.PromiseChains++
+			}
+		case "await_expression":
+			stats.AwaitExpressions++
+		case "new_expression":
+			if isPromiseConstructor(node) {
+				stats.NewPromises++
+			}
+		}
+		if IsAsyncFunction(node) {
+			stats.AsyncFunctions++
+		}
+		return true
+	})
+	stats.AntiPatterns = a.FindPromiseConstructorAntiPatterns()
+	return stats
+}
+
+// FindPromiseConstructorAntiPatterns finds `new Promise` constructions whose
+// executor is async, or which wrap a promise chain, an await, or a call to a
+// function known to return a promise (fetch, or an async function declared
+// in the same file).
+func (a *Analyzer) FindPromiseConstructorAntiPatterns() []PromiseAntiPattern {
+	asyncNames := a.asyncFunctionNames()
+
+	var patterns []PromiseAntiPattern
+	a.Visit(func(node ast.Node) bool {
+		if node.SyntaxKind() != "new_expression" || !isPromiseConstructor(node) {
+			return true
+		}
+		executor := promiseExecutor(node)
+		if executor == nil {
+			return true
+		}
+
+		reason := ""
+		if hasModifier(executor, "async") {
+			reason = ReasonAsyncExecutor
+		} else if wrapsPromise(executor, asyncNames) {
+			reason = ReasonWrapsPromise
+		}
+		if reason != "" {
+			patterns = append(patterns, PromiseAntiPattern{
+				Node:   node,
+				Reason: reason,
+				Range:  node.Range(),
+			})
+		}
+		return true
+	})
+	return patterns
+}
+
+// promiseChainMethod returns "then", "catch" or "finally" for a call to one
+// of those methods, or an empty string.
+func promiseChainMethod(call ast.Node) string {
+	children := call.Children()
+	if len(children) == 0 || children[0].SyntaxKind() != "member_expression" {
+		return ""
+	}
+	if name := calleeName(call); promiseChainMethods[name] {
+		return name
+	}
+	return ""
+}
+
+// continuesChain checks if a chain call is itself the receiver of another
+// chain call, as a.then(f) is in a.then(f).catch(g).
+func continuesChain(call ast.Node) bool {
+	member := call.Parent()
+	if member == nil || member.SyntaxKind() != "member_expression" || member.Children()[0] != call {
+		return false
+	}
+	outer := member.Parent()
+	return outer != nil && outer.SyntaxKind() == "call_expression" && promiseChainMethod(outer) != ""
+}
+
+// isPromiseConstructor checks if a new_expression constructs a Promise.
+func isPromiseConstructor(node ast.Node) bool {
+	constructor := ast.FirstChildOfKind(node, "identifier")
+	return constructor != nil && constructor.Text() == "Promise"
+}
+
+// promiseExecutor returns the function passed to a Promise constructor.
+func promiseExecutor(node ast.Node) ast.Node {
+	args := ast.FirstChildOfKind(node, "arguments")
+	if args == nil {
+		return nil
+	}
+	for _, arg := range args.Children() {
+		switch arg.SyntaxKind() {
+		case "arrow_function", "function_expression":
+			return arg
+		}
+	}
+	return nil
+}
+
+// wrapsPromise checks if an executor awaits, chains or calls a function
+// known to return a promise.
+func wrapsPromise(executor ast.Node, asyncNames map[string]bool) bool {
+	found := false
+	visitSubtree(executor, func(node ast.Node) bool {
+		switch node.SyntaxKind() {
+		case "await_expression":
+			found = true
+		case "call_expression":
+			name := calleeName(node)
+			if promiseChainMethod(node) != "" || asyncNames[name] || knownAsyncAPIs[name] {
+				found = true
+			}
+		}
+		return !found
+	})
+	return found
+}
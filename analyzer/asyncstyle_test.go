@@ -0,0 +1,96 @@
+package analyzer
+
+import (
+	"testing"
+)
+
+const asyncStyleSource = `
+async function loadUser(id: string) {
+	const res = await fetch("/users/" + id);
+	return await res.json();
+}
+
+function loadPosts() {
+	return fetch("/posts")
+		.then(res => res.json())
+		.then(posts => posts.length)
+		.catch(() => 0)
+		.finally(() => done());
+}
+
+function legacy() {
+	return new Promise((resolve, reject) => {
+		fetch("/legacy").then(resolve).catch(reject);
+	});
+}
+
+function wrapped(id: string) {
+	return new Promise((resolve) => resolve(loadUser(id)));
+}
+
+function asyncExecutor() {
+	return new Promise(async (resolve) => {
+		resolve(await loadPosts());
+	});
+}
+
+function timer(ms: number) {
+	return new Promise(resolve => setTimeout(resolve, ms));
+}
+`
+
+func TestAsyncStyle(t *testing.T) {
+	a := New(parseSource(t, asyncStyleSource))
+
+	stats := a.AsyncStyle()
+
+	checks := []struct {
+		name string
+		got  int
+		want int
+	}{
+		{"ThenCalls", stats.ThenCalls, 3},
+		{"CatchCalls", stats.CatchCalls, 2},
+		{"FinallyCalls", stats.FinallyCalls, 1},
+		{"PromiseChains", stats.PromiseChains, 2},
+		{"AwaitExpressions", stats.AwaitExpressions, 3},
+		{"AsyncFunctions", stats.AsyncFunctions, 2},
+		{"NewPromises", stats.NewPromises, 4},
+		{"AntiPatterns", len(stats.AntiPatterns), 3},
+	}
+	for _, c := range checks {
+		if c.got != c.want {
+			t.Errorf("%s = %d, want %d", c.name, c.got, c.want)
+		}
+	}
+
+	if got := stats.AwaitRatio(); got != 0.6 {
+		t.Errorf("AwaitRatio() = %v, want 0.6", got)
+	}
+	if got := (AsyncStyleStats{}).AwaitRatio(); got != 1 {
+		t.Errorf("AwaitRatio() of empty stats = %v, want 1", got)
+	}
+}
+
+func TestFindPromiseConstructorAntiPatterns(t *testing.T) {
+	a := New(parseSource(t, asyncStyleSource))
+
+	patterns := a.FindPromiseConstructorAntiPatterns()
+	want := []struct {
+		line   uint32
+		reason string
+	}{
+		{15, ReasonWrapsPromise},
+		{21, ReasonWrapsPromise},
+		{25, ReasonAsyncExecutor},
+	}
+	if len(patterns) != len(want) {
+		t.Fatalf("FindPromiseConstructorAntiPatterns() found %d, want %d", len(patterns), len(want))
+	}
+	for i, w := range want {
+		if patterns[i].Range.Start.Line != w.line || patterns[i].Reason != w.reason {
+			t.Errorf("pattern %d = line %d %q, want line %d %q",
+				i, patterns[i].Range.Start.Line, patterns[i].Reason, w.line, w.reason)
+		}
+	}
+}
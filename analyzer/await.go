@@ -0,0 +1,65 @@
+package analyzer
+
+import (
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+// MisplacedAwait represents an await that is not allowed where it appears.
+type MisplacedAwait struct {
+	Node     ast.Node // the await_expression or `for await` statement
+	Function ast.Node // nearest enclosing function, nil at the top level
+	Range    ast.Range
+}
+
+// MisplacedAwaits finds await expressions and `for await` loops whose
+// nearest enclosing function is not async.
+//
+// Top-level await is only legal in ES modules, so awaits outside of any
+// function are reported when the file has no import or export statements
+// (i.e. it would be treated as a script).
+func (a *Analyzer) MisplacedAwaits() []MisplacedAwait {
+	isModule := a.isModule()
+
+	var misplaced []MisplacedAwait
+	a.Visit(func(node ast.Node) bool {
+		switch node.SyntaxKind() {
+		case "await_expression":
+		case "for_in_statement":
+			if ast.FirstChildOfKind(node, "await") == nil {
+				return true
+			}
+		default:
+			return true
+		}
+
+		fn := enclosingFunction(node)
+		if fn == nil && isModule {
+			return true
+		}
+		if fn != nil && hasModifier(fn, "async") {
+			return true
+		}
+
+		misplaced = append(misplaced, MisplacedAwait{
+			Node:     node,
+			Function: fn,
+			Range:    node.Range(),
+		})
+		return true
+	})
+	return misplaced
+}
+
+// isModule checks if the AST has a top-level import or export statement.
+func (a *Analyzer) isModule() bool {
+	if a.root == nil {
+		return false
+	}
+	for _, child := range a.root.Children() {
+		switch child.SyntaxKind() {
+		case "import_statement", "export_statement":
+			return true
+		}
+	}
+	return false
+}
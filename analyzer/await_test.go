@@ -0,0 +1,63 @@
+package analyzer
+
+import (
+	"testing"
+)
+
+func TestMisplacedAwaits(t *testing.T) {
+	root := parseSource(t, `
+		await init();
+
+		function load() {
+			await fetchUser();
+			const nested = async () => {
+				await fetchPosts();
+			};
+		}
+
+		async function ok() {
+			await fetchUser();
+			for await (const chunk of stream) {}
+		}
+
+		class Service {
+			run() {
+				for await (const item of items) {}
+			}
+			async start() {
+				await this.run();
+			}
+		}
+	`)
+
+	misplaced := New(root).MisplacedAwaits()
+
+	want := []string{"await init()", "await fetchUser()", "for await (const item of items) {}"}
+	if len(misplaced) != len(want) {
+		t.Fatalf("MisplacedAwaits() found %d, want %d", len(misplaced), len(want))
+	}
+
+	for i, text := range want {
+		if got := misplaced[i].Node.Text(); got != text {
+			t.Errorf("misplaced %d = %q, want %q", i, got, text)
+		}
+	}
+
+	if misplaced[0].Function != nil {
+		t.Errorf("top-level await should have no enclosing function")
+	}
+	if name := GetFunctionName(misplaced[1].Function); name != "load" {
+		t.Errorf("enclosing function = %q, want load", name)
+	}
+}
+
+func TestMisplacedAwaitsTopLevelInModule(t *testing.T) {
+	root := parseSource(t, `
+		import { connect } from "./db";
+		await connect();
+	`)
+
+	if got := New(root).MisplacedAwaits(); len(got) != 0 {
+		t.Errorf("MisplacedAwaits() found %d in a module, want 0", len(got))
+	}
+}
@@ -0,0 +1,300 @@
+package analyzer
+
+import (
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+// DefaultBarrelMaxDepth is the re-export chain depth FindBarrels reports
+// when no limit is given.
+const DefaultBarrelMaxDepth = 2
+
+// barrelBaseNames are the file names recognized as barrel files.
+var barrelBaseNames = map[string]bool{
+	"index.ts":  true,
+	"index.tsx": true,
+	"index.mts": true,
+	"index.js":  true,
+	"index.jsx": true,
+	"index.mjs": true,
+}
+
+// ExportedSymbol is a name exported by a module, traced back to the file
+// that declares it.
+type ExportedSymbol struct {
+	// Name is the exported name. It is "*" for an `export * from` of an
+	// external module, whose names cannot be expanded.
+	Name string
+
+	// File is the declaring file, or the specifier of an external module.
+	File string
+
+	// DeclaredAs is the name the declaring file exports the symbol as,
+	// which differs from Name for a re-export such as export { a as b }.
+	// It is empty for namespace exports and the "*" of external modules.
+	DeclaredAs string
+
+	// Chain lists the files the export passes through, from the queried
+	// file to the declaring file.
+	Chain []string
+
+	// External reports that the symbol comes from a module outside the graph.
+	External bool
+}
+
+// Depth returns the number of re-export hops between the queried file and
+// the declaration.
+func (s ExportedSymbol) Depth() int {
+	return len(s.Chain) - 1
+}
+
+// Barrel is a barrel file and the symbols it ultimately exports.
+type Barrel struct {
+	File    string
+	Exports []ExportedSymbol
+
+	// DeepChains holds the exports whose re-export chain is deeper than the
+	// configured limit.
+	DeepChains []ExportedSymbol
+}
+
+// moduleExports records the names a single file exports.
+type moduleExports struct {
+	local     []string        // names declared or re-bound in the file itself
+	named     []namedReExport // export { a as b } from "..."
+	wildcards []string        // export * from "..."
+	isBarrel  bool
+}
+
+// namedReExport is a single re-exported binding.
+type namedReExport struct {
+	name      string // exported name
+	imported  string // name in the source module, "*" for export * as ns
+	specifier string
+}
+
+// IsBarrelFile checks if a file is a barrel: an index file that contains
+// re-exports and nothing but import and export statements.
+func IsBarrelFile(filePath string, root *ast.BaseNode) bool {
+	if root == nil {
+		return false
+	}
+	return collectExports(filePath, root).isBarrel
+}
+
+// ExportedSymbols returns the symbols exported by a file, following
+// re-export chains through the files registered in the graph.
+func (g *ImportGraph) ExportedSymbols(filePath string) []ExportedSymbol {
+	filePath = path.Clean(filePath)
+	symbols := g.expandExports(filePath, map[string]bool{})
+	sort.Slice(symbols, func(i, j int) bool {
+		return symbols[i].Name < symbols[j].Name
+	})
+	return symbols
+}
+
+// FindBarrels returns the barrel files in the graph with their expanded
+// exports, in sorted order. Exports whose re-export chain is deeper than
+// maxDepth hops are reported in DeepChains; a maxDepth of zero or less uses
+// DefaultBarrelMaxDepth.
+func (g *ImportGraph) FindBarrels(maxDepth int) []Barrel {
+	if maxDepth <= 0 {
+		maxDepth = DefaultBarrelMaxDepth
+	}
+
+	var barrels []Barrel
+	for _, f := range g.Files() {
+		if exports := g.exports[f]; exports == nil || !exports.isBarrel {
+			continue
+		}
+		barrel := Barrel{File: f, Exports: g.ExportedSymbols(f)}
+		for _, sym := range barrel.Exports {
+			if sym.Depth() > maxDepth {
+				barrel.DeepChains = append(barrel.DeepChains, sym)
+			}
+		}
+		barrels = append(barrels, barrel)
+	}
+	return barrels
+}
+
+// expandExports resolves the exports of a file. visiting guards against
+// cyclic re-exports.
+func (g *ImportGraph) expandExports(filePath string, visiting map[string]bool) []ExportedSymbol {
+	exports := g.exports[filePath]
+	if exports == nil || visiting[filePath] {
+		return nil
+	}
+	visiting[filePath] = true
+	defer delete(visiting, filePath)
+
+	seen := make(map[string]bool)
+	var symbols []ExportedSymbol
+	add := func(sym ExportedSymbol) {
+		if !seen[sym.Name] {
+			seen[sym.Name] = true
+			symbols = append(symbols, sym)
+		}
+	}
+
+	for _, name := range exports.local {
+		add(ExportedSymbol{Name: name, File: filePath, DeclaredAs: name, Chain: []string{filePath}})
+	}
+
+	for _, re := range exports.named {
+		target := g.Resolve(filePath, re.specifier)
+		switch {
+		case target == "":
+			add(ExportedSymbol{Name: re.name, File: re.specifier, DeclaredAs: re.imported, Chain: []string{filePath}, External: true})
+		case re.imported == "*":
+			// export * as ns is a namespace object declared by the barrel
+			add(ExportedSymbol{Name: re.name, File: target, Chain: []string{filePath, target}})
+		default:
+			sym := ExportedSymbol{Name: re.name, File: target, DeclaredAs: re.imported, Chain: []string{filePath, target}}
+			for _, inner := range g.expandExports(target, visiting) {
+				if inner.Name == re.imported {
+					sym.File = inner.File
+					sym.DeclaredAs = inner.DeclaredAs
+					sym.External = inner.External
+					sym.Chain = append([]string{filePath}, inner.Chain...)
+					break
+				}
+			}
+			add(sym)
+		}
+	}
+
+	for _, specifier := range exports.wildcards {
+		target := g.Resolve(filePath, specifier)
+		if target == "" {
+			add(ExportedSymbol{Name: "*", File: specifier, Chain: []string{filePath}, External: true})
+			continue
+		}
+		for _, inner := range g.expandExports(target, visiting) {
+			if inner.Name == "default" {
+				// export * never re-exports the default export
+				continue
+			}
+			inner.Chain = append([]string{filePath}, inner.Chain...)
+			add(inner)
+		}
+	}
+
+	return symbols
+}
+
+// collectExports records the exports of a parsed file.
+func collectExports(filePath string, root *ast.BaseNode) *moduleExports {
+	exports := &moduleExports{}
+	if root == nil {
+		return exports
+	}
+
+	onlyModuleStatements := true
+	for _, stmt := range root.Children() {
+		switch stmt.SyntaxKind() {
+		case "comment", "import_statement", "empty_statement":
+			continue
+		case "export_statement":
+		default:
+			onlyModuleStatements = false
+			continue
+		}
+
+		source := ast.FirstChildOfKind(stmt, "string")
+		if source == nil {
+			exports.local = append(exports.local, localExportNames(stmt)...)
+			if ast.FirstChildOfKind(stmt, "export_clause") == nil {
+				onlyModuleStatements = false
+			}
+			continue
+		}
+
+		specifier := stringLiteralValue(source)
+		switch {
+		case ast.FirstChildOfKind(stmt, "namespace_export") != nil:
+			ns := ast.FirstChildOfKind(stmt, "namespace_export")
+			if name := ast.FirstChildOfKind(ns, "identifier"); name != nil {
+				exports.named = append(exports.named, namedReExport{name: name.Text(), imported: "*", specifier: specifier})
+			}
+		case ast.FirstChildOfKind(stmt, "export_clause") != nil:
+			for _, spec := range ast.FirstChildOfKind(stmt, "export_clause").Children() {
+				if spec.SyntaxKind() != "export_specifier" {
+					continue
+				}
+				imported, exported := exportSpecifierNames(spec)
+				exports.named = append(exports.named, namedReExport{name: exported, imported: imported, specifier: specifier})
+			}
+		default:
+			exports.wildcards = append(exports.wildcards, specifier)
+		}
+	}
+
+	hasReExports := len(exports.named) > 0 || len(exports.wildcards) > 0
+	exports.isBarrel = barrelBaseNames[path.Base(filePath)] && hasReExports && onlyModuleStatements
+	return exports
+}
+
+// localExportNames returns the names exported by an export statement
+// without a source module.
+func localExportNames(stmt ast.Node) []string {
+	if ast.FirstChildOfKind(stmt, "default") != nil {
+		return []string{"default"}
+	}
+	if clause := ast.FirstChildOfKind(stmt, "export_clause"); clause != nil {
+		var names []string
+		for _, spec := range clause.Children() {
+			if spec.SyntaxKind() == "export_specifier" {
+				_, exported := exportSpecifierNames(spec)
+				names = append(names, exported)
+			}
+		}
+		return names
+	}
+
+	for _, decl := range stmt.Children() {
+		switch decl.SyntaxKind() {
+		case "lexical_declaration", "variable_declaration":
+			var names []string
+			for _, declarator := range decl.Children() {
+				if declarator.SyntaxKind() == "variable_declarator" {
+					if name := ast.FirstChildOfKind(declarator, "identifier"); name != nil {
+						names = append(names, name.Text())
+					}
+				}
+			}
+			return names
+		case "export", "comment", "declare":
+			continue
+		default:
+			if strings.HasSuffix(decl.SyntaxKind(), "declaration") || decl.SyntaxKind() == "internal_module" {
+				if name := declarationName(decl); name != "" {
+					return []string{name}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// exportSpecifierNames returns the local and exported names of an export
+// specifier such as `a as b`.
+func exportSpecifierNames(spec ast.Node) (imported, exported string) {
+	var names []string
+	for _, child := range spec.Children() {
+		switch child.SyntaxKind() {
+		case "identifier", "string":
+			names = append(names, stringLiteralValue(child))
+		}
+	}
+	switch len(names) {
+	case 0:
+		return "", ""
+	case 1:
+		return names[0], names[0]
+	}
+	return names[0], names[len(names)-1]
+}
@@ -0,0 +1,132 @@
+package analyzer
+
+import (
+	"testing"
+)
+
+func newBarrelGraph(t *testing.T) *ImportGraph {
+	t.Helper()
+
+	g := NewImportGraph()
+	files := map[string]string{
+		"src/index.ts": `export * from "./models";
+export { login as signIn } from "./auth/login";
+export * as utils from "./utils";
+export * from "lodash";`,
+		"src/models/index.ts": `// Models
+export * from "./user";
+export { Order } from "./order";`,
+		"src/models/user.ts": `export interface User { id: string }
+export const ADMIN = "admin", GUEST = "guest";
+export default class UserModel {}`,
+		"src/models/order.ts": `export class Order {}
+export type OrderID = string;`,
+		"src/auth/login.ts": `export function login() {}`,
+		"src/utils.ts":      `export const noop = () => {};`,
+		"src/app/index.ts": `import { signIn } from "..";
+export { signIn };
+signIn();`,
+	}
+	for name, src := range files {
+		g.AddFile(name, parseSource(t, src))
+	}
+	return g
+}
+
+func TestExportedSymbols(t *testing.T) {
+	g := newBarrelGraph(t)
+
+	symbols := g.ExportedSymbols("src/index.ts")
+	want := map[string]struct {
+		file  string
+		depth int
+	}{
+		"*":      {"lodash", 0},
+		"ADMIN":  {"src/models/user.ts", 2},
+		"GUEST":  {"src/models/user.ts", 2},
+		"Order":  {"src/models/order.ts", 2},
+		"User":   {"src/models/user.ts", 2},
+		"signIn": {"src/auth/login.ts", 1},
+		"utils":  {"src/utils.ts", 1},
+	}
+	if len(symbols) != len(want) {
+		for _, s := range symbols {
+			t.Logf("%s from %s via %v", s.Name, s.File, s.Chain)
+		}
+		t.Fatalf("ExportedSymbols() returned %d symbols, want %d", len(symbols), len(want))
+	}
+	for _, s := range symbols {
+		w, ok := want[s.Name]
+		if !ok {
+			t.Errorf("unexpected symbol %s", s.Name)
+			continue
+		}
+		if s.File != w.file || s.Depth() != w.depth {
+			t.Errorf("%s = %s at depth %d, want %s at depth %d", s.Name, s.File, s.Depth(), w.file, w.depth)
+		}
+	}
+
+	for _, s := range symbols {
+		if s.Name == "signIn" && s.DeclaredAs != "login" {
+			t.Errorf("signIn declared as %q, want login", s.DeclaredAs)
+		}
+	}
+
+	// The default export of user.ts is not re-exported by export *
+	for _, s := range g.ExportedSymbols("src/models/index.ts") {
+		if s.Name == "default" {
+			t.Error("export * should not re-export default")
+		}
+	}
+}
+
+func TestFindBarrels(t *testing.T) {
+	g := newBarrelGraph(t)
+
+	barrels := g.FindBarrels(1)
+	if len(barrels) != 2 {
+		t.Fatalf("FindBarrels() found %d barrels, want 2", len(barrels))
+	}
+	if barrels[0].File != "src/index.ts" || barrels[1].File != "src/models/index.ts" {
+		t.Errorf("barrels = %s, %s", barrels[0].File, barrels[1].File)
+	}
+	if got := len(barrels[0].DeepChains); got != 4 {
+		t.Errorf("src/index.ts has %d deep chains, want 4", got)
+	}
+	if got := len(barrels[1].DeepChains); got != 0 {
+		t.Errorf("src/models/index.ts has %d deep chains, want 0", got)
+	}
+
+	if got := len(g.FindBarrels(0)[0].DeepChains); got != 0 {
+		t.Errorf("default limit reported %d deep chains, want 0", got)
+	}
+}
+
+func TestIsBarrelFile(t *testing.T) {
+	tests := []struct {
+		path   string
+		source string
+		want   bool
+	}{
+		{"index.ts", `export * from "./a";`, true},
+		{"index.ts", `import "./polyfill"; export { a } from "./a";`, true},
+		{"a.ts", `export * from "./a";`, false},
+		{"index.ts", `export const a = 1;`, false},
+		{"index.ts", `export * from "./a"; run();`, false},
+	}
+	for _, tt := range tests {
+		if got := IsBarrelFile(tt.path, parseSource(t, tt.source)); got != tt.want {
+			t.Errorf("IsBarrelFile(%s, %q) = %v, want %v", tt.path, tt.source, got, tt.want)
+		}
+	}
+}
+
+func TestExportedSymbolsCycle(t *testing.T) {
+	g := NewImportGraph()
+	g.AddFile("a/index.ts", parseSource(t, `export * from "../b"; export const a = 1;`))
+	g.AddFile("b/index.ts", parseSource(t, `export * from "../a"; export const b = 1;`))
+
+	if got := len(g.ExportedSymbols("a/index.ts")); got != 2 {
+		t.Errorf("ExportedSymbols() with a cycle returned %d symbols, want 2", got)
+	}
+}
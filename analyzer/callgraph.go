@@ -0,0 +1,168 @@
+package analyzer
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/ahmadramadhannn/tsgoast/ast"
+	"github.com/ahmadramadhannn/tsgoast/internal/syntax"
+)
+
+// CallGraph is a graph of the calls between the named functions and methods
+// declared in one or more files. Functions are identified by name, with
+// methods qualified by their class, e.g. "Service.fetch".
+//
+// Calls are resolved by name only: a call is an edge when its callee path
+// (see CalleePath) names a declared function, or is this.method inside a
+// class declaring that method. Calls to anything else, including imported
+// functions and methods of other objects, are not part of the graph.
+type CallGraph struct {
+	functions map[string][]ast.Node
+	callees   map[string][]string
+	callers   map[string][]string
+}
+
+// BuildCallGraph constructs the call graph of the functions declared in the
+// given ASTs. Calls in nested callbacks are attributed to the enclosing
+// named function; calls outside any named function are ignored.
+func BuildCallGraph(roots ...*ast.BaseNode) *CallGraph {
+	g := &CallGraph{
+		functions: make(map[string][]ast.Node),
+		callees:   make(map[string][]string),
+		callers:   make(map[string][]string),
+	}
+
+	names := make(map[ast.Node]string)
+	for _, root := range roots {
+		New(root).Visit(func(node ast.Node) bool {
+			if name := callGraphName(node); name != "" {
+				names[node] = name
+				g.functions[name] = append(g.functions[name], node)
+			}
+			return true
+		})
+	}
+
+	for _, root := range roots {
+		New(root).Visit(func(node ast.Node) bool {
+			if node.SyntaxKind() != "call_expression" {
+				return true
+			}
+			caller, fn := enclosingNamedFunction(node, names)
+			if caller == "" {
+				return true
+			}
+			callee := g.resolveCallee(node, fn)
+			if callee != "" {
+				g.callees[caller] = appendUnique(g.callees[caller], callee)
+				g.callers[callee] = appendUnique(g.callers[callee], caller)
+			}
+			return true
+		})
+	}
+
+	for _, edges := range []map[string][]string{g.callees, g.callers} {
+		for name := range edges {
+			sort.Strings(edges[name])
+		}
+	}
+	return g
+}
+
+// callGraphName returns the name under which a function node is recorded,
+// or "" for nodes that are not named functions.
+func callGraphName(node ast.Node) string {
+	switch node.SyntaxKind() {
+	case "function_declaration", "generator_function_declaration":
+		return syntax.FieldText(node, "name")
+	case "method_definition":
+		if class := enclosingClassName(node); class != "" {
+			return class + "." + syntax.FieldText(node, "name")
+		}
+	case "arrow_function", "function_expression", "generator_function":
+		parent := node.Parent()
+		if parent == nil {
+			return ""
+		}
+		switch parent.SyntaxKind() {
+		case "variable_declarator":
+			return syntax.FieldText(parent, "name")
+		case "public_field_definition":
+			if class := enclosingClassName(parent); class != "" {
+				return class + "." + syntax.FieldText(parent, "name")
+			}
+		}
+	}
+	return ""
+}
+
+// enclosingClassName returns the name of the class declaring a member, or
+// "" when the member's class is anonymous.
+func enclosingClassName(member ast.Node) string {
+	body := member.Parent()
+	if body == nil || body.SyntaxKind() != "class_body" || body.Parent() == nil {
+		return ""
+	}
+	return syntax.FieldText(body.Parent(), "name")
+}
+
+// enclosingNamedFunction returns the name and node of the nearest enclosing
+// function recorded in names.
+func enclosingNamedFunction(node ast.Node, names map[ast.Node]string) (string, ast.Node) {
+	for current := node.Parent(); current != nil; current = current.Parent() {
+		if name, ok := names[current]; ok {
+			return name, current
+		}
+	}
+	return "", nil
+}
+
+// resolveCallee returns the declared function called by call from within
+// fn, or "" if the callee is not a declared function.
+func (g *CallGraph) resolveCallee(call, fn ast.Node) string {
+	callee := ast.ChildByField(call, "function")
+	if callee == nil {
+		return ""
+	}
+	path := CalleePath(callee)
+	if rest, ok := strings.CutPrefix(path, "this."); ok {
+		member := fn
+		if member.SyntaxKind() != "method_definition" {
+			member = member.Parent() // arrow function class field
+		}
+		if class := enclosingClassName(member); class != "" {
+			path = class + "." + rest
+		}
+	}
+	if _, ok := g.functions[path]; ok {
+		return path
+	}
+	return ""
+}
+
+// Functions returns the names of all functions in the graph in sorted order.
+func (g *CallGraph) Functions() []string {
+	names := make([]string, 0, len(g.functions))
+	for name := range g.functions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Declarations returns the declarations of the named function; overloads
+// and same-named functions in different files are all included.
+func (g *CallGraph) Declarations(name string) []ast.Node {
+	return g.functions[name]
+}
+
+// Callees returns the functions called by the named function in sorted
+// order.
+func (g *CallGraph) Callees(name string) []string {
+	return g.callees[name]
+}
+
+// Callers returns the functions calling the named function in sorted order.
+func (g *CallGraph) Callers(name string) []string {
+	return g.callers[name]
+}
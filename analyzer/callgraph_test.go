@@ -0,0 +1,57 @@
+package analyzer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBuildCallGraph(t *testing.T) {
+	root := parseSource(t, `
+		function main() {
+			const svc = new Service();
+			svc.run();
+			helper();
+			[1, 2].forEach(() => log("x"));
+		}
+		function helper() { return format(1); }
+		const format = (n: number) => String(n);
+		function log(msg: string) { console.log(msg); }
+
+		class Service {
+			run() { this.fetch(); helper(); }
+			fetch() { return this.parse(); }
+			parse = () => format(2);
+		}
+	`)
+
+	g := BuildCallGraph(root)
+
+	if got, want := g.Functions(), []string{"Service.fetch", "Service.parse", "Service.run", "format", "helper", "log", "main"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Functions() = %v, want %v", got, want)
+	}
+
+	tests := []struct {
+		name    string
+		callees []string
+		callers []string
+	}{
+		{"main", []string{"helper", "log"}, nil},
+		{"helper", []string{"format"}, []string{"Service.run", "main"}},
+		{"format", nil, []string{"Service.parse", "helper"}},
+		{"Service.run", []string{"Service.fetch", "helper"}, nil},
+		{"Service.fetch", []string{"Service.parse"}, []string{"Service.run"}},
+		{"Service.parse", []string{"format"}, []string{"Service.fetch"}},
+	}
+	for _, tt := range tests {
+		if got := g.Callees(tt.name); !reflect.DeepEqual(got, tt.callees) {
+			t.Errorf("Callees(%s) = %v, want %v", tt.name, got, tt.callees)
+		}
+		if got := g.Callers(tt.name); !reflect.DeepEqual(got, tt.callers) {
+			t.Errorf("Callers(%s) = %v, want %v", tt.name, got, tt.callers)
+		}
+	}
+
+	if decls := g.Declarations("Service.fetch"); len(decls) != 1 || decls[0].SyntaxKind() != "method_definition" {
+		t.Errorf("Declarations(Service.fetch) = %v", decls)
+	}
+}
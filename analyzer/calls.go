@@ -0,0 +1,137 @@
+package analyzer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+// BannedCallConfig configures FindBannedCalls.
+type BannedCallConfig struct {
+	// Patterns lists the banned callees as dotted paths such as "alert" or
+	// "console.log". A trailing ".*" bans every member of an object,
+	// e.g. "console.*".
+	Patterns []string
+
+	// Debugger reports debugger statements when true.
+	Debugger bool
+}
+
+// DefaultBannedCalls returns the default configuration banning console
+// methods, alert and debugger statements.
+func DefaultBannedCalls() BannedCallConfig {
+	return BannedCallConfig{
+		Patterns: []string{"console.*", "alert", "window.alert"},
+		Debugger: true,
+	}
+}
+
+// Rule IDs reported by FindBannedCalls.
+const (
+	RuleBannedCall = "banned-call"
+	RuleDebugger   = "no-debugger"
+)
+
+// BannedCall represents a call (or debugger statement) matched by the
+// banned call detector.
+type BannedCall struct {
+	RuleID  string
+	Message string
+	Callee  string // dotted callee path, empty for debugger statements
+	Pattern string // the pattern that matched, empty for debugger statements
+	Node    ast.Node
+	Range   ast.Range
+}
+
+// FindBannedCalls finds every call whose callee matches one of the
+// configured patterns, plus debugger statements when enabled.
+func (a *Analyzer) FindBannedCalls(config BannedCallConfig) []BannedCall {
+	var calls []BannedCall
+	a.Visit(func(node ast.Node) bool {
+		switch node.SyntaxKind() {
+		case "debugger_statement":
+			if config.Debugger {
+				calls = append(calls, BannedCall{
+					RuleID:  RuleDebugger,
+					Message: "unexpected debugger statement",
+					Node:    node,
+					Range:   node.Range(),
+				})
+			}
+		case "call_expression":
+			children := node.Children()
+			if len(children) == 0 {
+				return true
+			}
+			callee := CalleePath(children[0])
+			if callee == "" {
+				return true
+			}
+			for _, pattern := range config.Patterns {
+				if matchCalleePattern(pattern, callee) {
+					calls = append(calls, BannedCall{
+						RuleID:  RuleBannedCall,
+						Message: fmt.Sprintf("call to banned function %s", callee),
+						Callee:  callee,
+						Pattern: pattern,
+						Node:    node,
+						Range:   node.Range(),
+					})
+					break
+				}
+			}
+		}
+		return true
+	})
+	return calls
+}
+
+// CalleePath returns the dotted path of a callee expression, such as
+// "console.log" for `console.log` or `console["log"]`. It returns an empty
+// string for callees that are not plain identifiers or member accesses.
+func CalleePath(node ast.Node) string {
+	switch node.SyntaxKind() {
+	case "identifier", "this", "super":
+		return node.Text()
+	case "member_expression":
+		children := node.Children()
+		if len(children) == 0 {
+			return ""
+		}
+		object := CalleePath(children[0])
+		property := ast.FirstChildOfKind(node, "property_identifier")
+		if object == "" || property == nil {
+			return ""
+		}
+		return object + "." + property.Text()
+	case "subscript_expression":
+		children := node.Children()
+		if len(children) == 0 {
+			return ""
+		}
+		object := CalleePath(children[0])
+		index := ast.FirstChildOfKind(node, "string")
+		if object == "" || index == nil {
+			return ""
+		}
+		return object + "." + stringLiteralValue(index)
+	case "parenthesized_expression":
+		if inner := unwrapParentheses(node); inner != nil {
+			return CalleePath(inner)
+		}
+	case "non_null_expression":
+		if children := node.Children(); len(children) > 0 {
+			return CalleePath(children[0])
+		}
+	}
+	return ""
+}
+
+// matchCalleePattern checks if a dotted callee path matches a pattern.
+func matchCalleePattern(pattern, callee string) bool {
+	if prefix, ok := strings.CutSuffix(pattern, ".*"); ok {
+		return strings.HasPrefix(callee, prefix+".")
+	}
+	return pattern == callee
+}
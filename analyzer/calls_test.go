@@ -0,0 +1,82 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+func TestFindBannedCalls(t *testing.T) {
+	root := parseSource(t, `
+		console.log("debug");
+		console["warn"]("careful");
+		window.alert("hi");
+		alert("hello");
+		logger.info("fine");
+		debugger;
+	`)
+
+	calls := New(root).FindBannedCalls(DefaultBannedCalls())
+
+	tests := []struct {
+		ruleID string
+		callee string
+	}{
+		{RuleBannedCall, "console.log"},
+		{RuleBannedCall, "console.warn"},
+		{RuleBannedCall, "window.alert"},
+		{RuleBannedCall, "alert"},
+		{RuleDebugger, ""},
+	}
+
+	if len(calls) != len(tests) {
+		t.Fatalf("FindBannedCalls() found %d, want %d", len(calls), len(tests))
+	}
+
+	for i, tt := range tests {
+		if calls[i].RuleID != tt.ruleID || calls[i].Callee != tt.callee {
+			t.Errorf("call %d = (%s, %s), want (%s, %s)", i, calls[i].RuleID, calls[i].Callee, tt.ruleID, tt.callee)
+		}
+		if calls[i].Message == "" {
+			t.Errorf("call %d has no message", i)
+		}
+	}
+}
+
+func TestFindBannedCallsCustomConfig(t *testing.T) {
+	root := parseSource(t, `
+		console.log("kept");
+		logger.debug("banned");
+		debugger;
+	`)
+
+	calls := New(root).FindBannedCalls(BannedCallConfig{
+		Patterns: []string{"logger.debug"},
+	})
+
+	if len(calls) != 1 || calls[0].Callee != "logger.debug" {
+		t.Errorf("FindBannedCalls() = %+v, want only logger.debug", calls)
+	}
+}
+
+func TestCalleePath(t *testing.T) {
+	root := parseSource(t, `a.b.c(); this.run(); (obj)!.method(); getFn()();`)
+
+	var paths []string
+	New(root).Visit(func(node ast.Node) bool {
+		if node.SyntaxKind() == "call_expression" {
+			paths = append(paths, CalleePath(node.Children()[0]))
+		}
+		return true
+	})
+
+	want := []string{"a.b.c", "this.run", "obj.method", "", "getFn"}
+	if len(paths) != len(want) {
+		t.Fatalf("found %d calls, want %d", len(paths), len(want))
+	}
+	for i := range want {
+		if paths[i] != want[i] {
+			t.Errorf("CalleePath %d = %q, want %q", i, paths[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package analyzer
+
+import (
+	"hash/fnv"
+	"sort"
+
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+// defaultCloneMinNodes is the subtree size threshold used when
+// CloneOptions.MinNodes is zero.
+const defaultCloneMinNodes = 20
+
+// identifierKinds is the set of tree-sitter kinds normalized away when
+// clones are detected with IgnoreIdentifiers.
+var identifierKinds = map[string]bool{
+	"identifier":                            true,
+	"property_identifier":                   true,
+	"private_property_identifier":           true,
+	"shorthand_property_identifier":         true,
+	"shorthand_property_identifier_pattern": true,
+	"type_identifier":                       true,
+}
+
+// literalKinds is the set of tree-sitter kinds normalized away when clones
+// are detected with IgnoreLiterals.
+var literalKinds = map[string]bool{
+	"string":          true,
+	"template_string": true,
+	"number":          true,
+	"regex":           true,
+	"true":            true,
+	"false":           true,
+}
+
+// CloneOptions configures FindClones.
+type CloneOptions struct {
+	// MinNodes is the minimum number of nodes a subtree must have to be
+	// reported. Defaults to 20 when zero.
+	MinNodes int
+
+	// IgnoreIdentifiers treats subtrees differing only in identifier names
+	// as clones.
+	IgnoreIdentifiers bool
+
+	// IgnoreLiterals treats subtrees differing only in literal values as
+	// clones.
+	IgnoreLiterals bool
+}
+
+// ClonePair is a pair of structurally identical subtrees.
+type ClonePair struct {
+	First       ast.Node
+	Second      ast.Node
+	FirstRange  ast.Range
+	SecondRange ast.Range
+	Size        int    // number of nodes in each subtree
+	Hash        uint64 // structural hash shared by both subtrees
+}
+
+// FindClones reports pairs of structurally identical subtrees with at least
+// MinNodes nodes. Only maximal clones are reported: when two subtrees are
+// clones, their corresponding children are not reported again. Comments
+// are ignored.
+func (a *Analyzer) FindClones(opts CloneOptions) []ClonePair {
+	if a.root == nil {
+		return nil
+	}
+	if opts.MinNodes <= 0 {
+		opts.MinNodes = defaultCloneMinNodes
+	}
+
+	type fingerprint struct {
+		hash uint64
+		size int
+	}
+
+	prints := make(map[ast.Node]fingerprint)
+	var order []ast.Node
+
+	var compute func(ast.Node) fingerprint
+	compute = func(node ast.Node) fingerprint {
+		h := fnv.New64a()
+		h.Write([]byte(node.SyntaxKind()))
+		h.Write([]byte{0})
+
+		children := node.Children()
+		switch {
+		case opts.IgnoreIdentifiers && identifierKinds[node.SyntaxKind()]:
+		case opts.IgnoreLiterals && literalKinds[node.SyntaxKind()]:
+			children = nil
+		case len(children) == 0:
+			h.Write([]byte(node.Text()))
+		}
+
+		size := 1
+		for _, child := range children {
+			if child.SyntaxKind() == "comment" {
+				continue
+			}
+			fp := compute(child)
+			size += fp.size
+			var buf [8]byte
+			for i := range buf {
+				buf[i] = byte(fp.hash >> (8 * i))
+			}
+			h.Write(buf[:])
+		}
+
+		fp := fingerprint{hash: h.Sum64(), size: size}
+		prints[node] = fp
+		order = append(order, node)
+		return fp
+	}
+	compute(a.root)
+
+	groups := make(map[fingerprint][]ast.Node)
+	for _, node := range order {
+		fp := prints[node]
+		if fp.size >= opts.MinNodes {
+			groups[fp] = append(groups[fp], node)
+		}
+	}
+
+	// A node whose parent is itself part of a clone group is covered by the
+	// parent's report.
+	covered := func(node ast.Node) bool {
+		parent := node.Parent()
+		if parent == nil {
+			return false
+		}
+		fp, ok := prints[parent]
+		return ok && len(groups[fp]) > 1
+	}
+
+	var pairs []ClonePair
+	for fp, nodes := range groups {
+		var candidates []ast.Node
+		for _, node := range nodes {
+			if !covered(node) {
+				candidates = append(candidates, node)
+			}
+		}
+		sort.Slice(candidates, func(i, j int) bool {
+			return candidates[i].Range().Start.Offset < candidates[j].Range().Start.Offset
+		})
+		for i := 0; i < len(candidates); i++ {
+			for j := i + 1; j < len(candidates); j++ {
+				pairs = append(pairs, ClonePair{
+					First:       candidates[i],
+					Second:      candidates[j],
+					FirstRange:  candidates[i].Range(),
+					SecondRange: candidates[j].Range(),
+					Size:        fp.size,
+					Hash:        fp.hash,
+				})
+			}
+		}
+	}
+
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i].FirstRange.Start.Offset != pairs[j].FirstRange.Start.Offset {
+			return pairs[i].FirstRange.Start.Offset < pairs[j].FirstRange.Start.Offset
+		}
+		return pairs[i].SecondRange.Start.Offset < pairs[j].SecondRange.Start.Offset
+	})
+	return pairs
+}
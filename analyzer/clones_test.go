@@ -0,0 +1,72 @@
+package analyzer
+
+import (
+	"testing"
+)
+
+const cloneSource = `
+function sumPrices(items) {
+	let total = 0;
+	for (const item of items) {
+		if (item.price > 0) {
+			total += item.price * item.quantity;
+		}
+	}
+	return total;
+}
+
+function sumWeights(parcels) {
+	let sum = 0;
+	for (const parcel of parcels) {
+		if (parcel.weight > 0) {
+			sum += parcel.weight * parcel.count;
+		}
+	}
+	return sum;
+}
+
+function sumPricesAgain(items) {
+	let total = 0;
+	for (const item of items) {
+		if (item.price > 0) {
+			total += item.price * item.quantity;
+		}
+	}
+	return total;
+}
+`
+
+func TestFindClones(t *testing.T) {
+	a := New(parseSource(t, cloneSource))
+
+	exact := a.FindClones(CloneOptions{})
+	if len(exact) != 1 {
+		for _, p := range exact {
+			t.Logf("pair: %q / %q", p.First.Text(), p.Second.Text())
+		}
+		t.Fatalf("FindClones() found %d pairs, want 1", len(exact))
+	}
+	// The function names differ, so only the bodies are exact clones
+	pair := exact[0]
+	if pair.First.SyntaxKind() != "statement_block" || pair.FirstRange.Start.Line != 1 || pair.SecondRange.Start.Line != 21 {
+		t.Errorf("exact clone = %s at lines %d/%d", pair.First.SyntaxKind(), pair.FirstRange.Start.Line, pair.SecondRange.Start.Line)
+	}
+
+	renamed := a.FindClones(CloneOptions{IgnoreIdentifiers: true})
+	if len(renamed) != 3 {
+		t.Fatalf("FindClones(IgnoreIdentifiers) found %d pairs, want 3", len(renamed))
+	}
+	for _, p := range renamed {
+		if p.First.SyntaxKind() != "function_declaration" || p.Size < defaultCloneMinNodes {
+			t.Errorf("renamed clone = %s (size %d), want maximal function clones", p.First.SyntaxKind(), p.Size)
+		}
+	}
+}
+
+func TestFindClonesThreshold(t *testing.T) {
+	a := New(parseSource(t, cloneSource))
+
+	if got := a.FindClones(CloneOptions{MinNodes: 10000}); len(got) != 0 {
+		t.Errorf("FindClones(MinNodes: 10000) found %d pairs, want 0", len(got))
+	}
+}
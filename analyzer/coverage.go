@@ -0,0 +1,111 @@
+package analyzer
+
+import (
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+// TypeCoverage summarizes how much of a file is explicitly typed.
+//
+// A typing slot is a parameter, a class field, or a variable declared
+// without an initializer; these are the places where the compiler falls back
+// to an implicit any when no annotation is given. A slot counts as typed when
+// it carries an annotation other than `any`.
+type TypeCoverage struct {
+	// ExplicitAny holds every `any` keyword used in a type position
+	// (annotations, assertions, type arguments, ...).
+	ExplicitAny []ast.Node
+
+	// Untyped holds the slots that have no type annotation at all.
+	Untyped []ast.Node
+
+	// TypedSlots is the number of slots annotated with a type other than any.
+	TypedSlots int
+
+	// TotalSlots is the number of slots found.
+	TotalSlots int
+}
+
+// Percent returns the percentage of typed slots, or 100 when the file has
+// no slots at all.
+func (c TypeCoverage) Percent() float64 {
+	if c.TotalSlots == 0 {
+		return 100
+	}
+	return float64(c.TypedSlots) * 100 / float64(c.TotalSlots)
+}
+
+// FindAnyUsages finds every explicit `any` type in the AST.
+func (a *Analyzer) FindAnyUsages() NodeList {
+	return a.FindNodes(isAnyType)
+}
+
+// TypeCoverage computes the explicit any usage and type coverage of the AST.
+func (a *Analyzer) TypeCoverage() TypeCoverage {
+	var coverage TypeCoverage
+
+	addSlot := func(slot, annotation ast.Node) {
+		coverage.TotalSlots++
+		switch {
+		case annotation == nil:
+			coverage.Untyped = append(coverage.Untyped, slot)
+		case !isAnyAnnotation(annotation):
+			coverage.TypedSlots++
+		}
+	}
+
+	a.Visit(func(node ast.Node) bool {
+		if isAnyType(node) {
+			coverage.ExplicitAny = append(coverage.ExplicitAny, node)
+		}
+
+		switch node.SyntaxKind() {
+		case "required_parameter", "optional_parameter":
+			addSlot(node, ast.FirstChildOfKind(node, "type_annotation"))
+		case "public_field_definition":
+			if ast.FirstChildOfKind(node, "=") == nil {
+				addSlot(node, ast.FirstChildOfKind(node, "type_annotation"))
+			}
+		case "variable_declarator":
+			if ast.FirstChildOfKind(node, "=") == nil {
+				addSlot(node, ast.FirstChildOfKind(node, "type_annotation"))
+			}
+		case "arrow_function":
+			// A bare parameter (x => ...) can never carry an annotation
+			if param := arrowBareParameter(node); param != nil {
+				addSlot(param, nil)
+			}
+		}
+		return true
+	})
+
+	return coverage
+}
+
+// isAnyType checks if a node is the `any` type keyword.
+func isAnyType(node ast.Node) bool {
+	return node.SyntaxKind() == "predefined_type" && node.Text() == "any"
+}
+
+// isAnyAnnotation checks if a type annotation is exactly `: any`.
+func isAnyAnnotation(annotation ast.Node) bool {
+	for _, child := range annotation.Children() {
+		if isAnyType(child) {
+			return true
+		}
+	}
+	return false
+}
+
+// arrowBareParameter returns the unparenthesized parameter of an arrow
+// function such as `x => x * 2`, or nil if the parameters are parenthesized.
+func arrowBareParameter(node ast.Node) ast.Node {
+	for _, child := range node.Children() {
+		switch child.SyntaxKind() {
+		case "identifier":
+			return child
+		case "=>", "formal_parameters":
+			return nil
+		}
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package analyzer
+
+import (
+	"testing"
+)
+
+func TestTypeCoverage(t *testing.T) {
+	root := parseSource(t, `
+		function f(a, b: any, c?: string, ...d): any {
+			const x: any[] = [];
+			let y;
+			let z: number;
+			return a as any;
+		}
+		const g = (p: number) => p;
+		const h = q => q;
+		class K {
+			prop: any;
+			count = 0;
+		}
+	`)
+
+	coverage := New(root).TypeCoverage()
+
+	// b: any, return type any, any[], as any, prop: any
+	if got := len(coverage.ExplicitAny); got != 5 {
+		t.Errorf("ExplicitAny = %d, want 5", got)
+	}
+
+	// a, ...d, y, q
+	if got := len(coverage.Untyped); got != 4 {
+		t.Errorf("Untyped = %d, want 4", got)
+	}
+
+	// slots: a, b, c, d, y, z, p, q, prop
+	if coverage.TotalSlots != 9 {
+		t.Errorf("TotalSlots = %d, want 9", coverage.TotalSlots)
+	}
+
+	// c, z, p
+	if coverage.TypedSlots != 3 {
+		t.Errorf("TypedSlots = %d, want 3", coverage.TypedSlots)
+	}
+
+	if got, want := coverage.Percent(), float64(3)*100/9; got != want {
+		t.Errorf("Percent() = %v, want %v", got, want)
+	}
+}
+
+func TestTypeCoverageEmpty(t *testing.T) {
+	root := parseSource(t, `const answer = 42;`)
+
+	coverage := New(root).TypeCoverage()
+	if coverage.Percent() != 100 {
+		t.Errorf("Percent() = %v, want 100", coverage.Percent())
+	}
+	if got := len(New(root).FindAnyUsages()); got != 0 {
+		t.Errorf("FindAnyUsages() = %d, want 0", got)
+	}
+}
@@ -0,0 +1,291 @@
+package analyzer
+
+import (
+	"strings"
+
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+// DecoratorTarget is the kind of declaration a decorator is applied to.
+type DecoratorTarget string
+
+// Decorator target constants.
+const (
+	DecoratorTargetClass     DecoratorTarget = "class"
+	DecoratorTargetMethod    DecoratorTarget = "method"
+	DecoratorTargetProperty  DecoratorTarget = "property"
+	DecoratorTargetParameter DecoratorTarget = "parameter"
+)
+
+// httpMethodDecorators maps route decorators to HTTP methods.
+var httpMethodDecorators = map[string]string{
+	"Get":     "GET",
+	"Post":    "POST",
+	"Put":     "PUT",
+	"Patch":   "PATCH",
+	"Delete":  "DELETE",
+	"Options": "OPTIONS",
+	"Head":    "HEAD",
+	"All":     "ALL",
+}
+
+// Decorator represents a decorator applied to a declaration.
+type Decorator struct {
+	Name       string   // dotted name, e.g. "Injectable" or "Reflect.metadata"
+	Arguments  []string // source text of each call argument
+	Target     ast.Node // the decorated declaration
+	TargetKind DecoratorTarget
+	TargetName string
+	Node       ast.Node
+	Range      ast.Range
+}
+
+// StringArgument returns the value of the i-th argument if it is a string
+// literal.
+func (d Decorator) StringArgument(i int) (string, bool) {
+	if i < 0 || i >= len(d.Arguments) {
+		return "", false
+	}
+	arg := d.Arguments[i]
+	if len(arg) >= 2 && strings.ContainsRune(`"'`+"`", rune(arg[0])) && arg[len(arg)-1] == arg[0] {
+		return arg[1 : len(arg)-1], true
+	}
+	return "", false
+}
+
+// FindDecorators finds every decorator with the given name. An empty name
+// matches all decorators.
+func (a *Analyzer) FindDecorators(name string) []Decorator {
+	var decorators []Decorator
+	a.Visit(func(node ast.Node) bool {
+		if node.SyntaxKind() != "decorator" {
+			return true
+		}
+		if d, ok := newDecorator(node); ok && (name == "" || d.Name == name) {
+			decorators = append(decorators, d)
+		}
+		return true
+	})
+	return decorators
+}
+
+// FindDecoratedClasses finds every class carrying a decorator with the
+// given name, e.g. all @Injectable() classes.
+func (a *Analyzer) FindDecoratedClasses(name string) NodeList {
+	var classes []ast.Node
+	for _, d := range a.FindDecorators(name) {
+		if d.TargetKind == DecoratorTargetClass {
+			classes = append(classes, d.Target)
+		}
+	}
+	return classes
+}
+
+// GetDecorators returns the decorators applied to a class, method,
+// property or parameter declaration, in source order.
+func GetDecorators(node ast.Node) []Decorator {
+	if node == nil {
+		return nil
+	}
+
+	var candidates []ast.Node
+	switch node.SyntaxKind() {
+	case "class_declaration", "abstract_class_declaration":
+		if parent := node.Parent(); parent != nil && parent.SyntaxKind() == "export_statement" {
+			candidates = append(candidates, parent.Children()...)
+		}
+		candidates = append(candidates, node.Children()...)
+	case "method_definition", "abstract_method_signature":
+		// Method decorators are the siblings directly preceding the method
+		if parent := node.Parent(); parent != nil {
+			var run []ast.Node
+			for _, sibling := range parent.Children() {
+				if sibling == node {
+					break
+				}
+				switch sibling.SyntaxKind() {
+				case "decorator":
+					run = append(run, sibling)
+				case "comment":
+				default:
+					run = nil
+				}
+			}
+			candidates = run
+		}
+	default:
+		candidates = node.Children()
+	}
+
+	var decorators []Decorator
+	for _, child := range candidates {
+		if child.SyntaxKind() != "decorator" {
+			continue
+		}
+		if d, ok := newDecorator(child); ok {
+			decorators = append(decorators, d)
+		}
+	}
+	return decorators
+}
+
+// newDecorator builds a Decorator from a decorator node.
+func newDecorator(node ast.Node) (Decorator, bool) {
+	d := Decorator{
+		Node:  node,
+		Range: node.Range(),
+	}
+
+	for _, child := range node.Children() {
+		if child.SyntaxKind() == "@" {
+			continue
+		}
+		if child.SyntaxKind() == "call_expression" {
+			children := child.Children()
+			if len(children) > 0 {
+				d.Name = CalleePath(children[0])
+			}
+			if args := ast.FirstChildOfKind(child, "arguments"); args != nil {
+				for _, arg := range args.Children() {
+					switch arg.SyntaxKind() {
+					case "(", ")", ",":
+					default:
+						d.Arguments = append(d.Arguments, arg.Text())
+					}
+				}
+			}
+		} else {
+			d.Name = CalleePath(child)
+		}
+		break
+	}
+
+	d.Target, d.TargetKind = decoratorTarget(node)
+	if d.Target == nil {
+		return d, false
+	}
+	d.TargetName = declarationName(d.Target)
+
+	return d, true
+}
+
+// decoratorTarget resolves the declaration a decorator node applies to.
+func decoratorTarget(node ast.Node) (ast.Node, DecoratorTarget) {
+	parent := node.Parent()
+	if parent == nil {
+		return nil, ""
+	}
+
+	switch parent.SyntaxKind() {
+	case "class_declaration", "abstract_class_declaration":
+		return parent, DecoratorTargetClass
+	case "export_statement":
+		for _, child := range parent.Children() {
+			switch child.SyntaxKind() {
+			case "class_declaration", "abstract_class_declaration":
+				return child, DecoratorTargetClass
+			}
+		}
+	case "class_body":
+		// Method decorators precede the method inside the class body
+		seen := false
+		for _, sibling := range parent.Children() {
+			if sibling == node {
+				seen = true
+				continue
+			}
+			if !seen {
+				continue
+			}
+			switch sibling.SyntaxKind() {
+			case "decorator", "comment":
+			case "method_definition", "abstract_method_signature":
+				return sibling, DecoratorTargetMethod
+			default:
+				return sibling, DecoratorTargetProperty
+			}
+		}
+	case "public_field_definition":
+		return parent, DecoratorTargetProperty
+	case "required_parameter", "optional_parameter":
+		return parent, DecoratorTargetParameter
+	}
+
+	return nil, ""
+}
+
+// declarationName returns the declared name of a class, member or parameter.
+func declarationName(node ast.Node) string {
+	for _, kind := range []string{"type_identifier", "property_identifier", "private_property_identifier", "identifier"} {
+		if name := ast.FirstChildOfKind(node, kind); name != nil {
+			return name.Text()
+		}
+	}
+	return ""
+}
+
+// RouteHandler represents a controller method mapped to an HTTP route by
+// NestJS-style decorators such as @Controller("users") and @Get(":id").
+type RouteHandler struct {
+	HTTPMethod string // "GET", "POST", ...
+	Path       string // joined controller and method paths, e.g. "/users/:id"
+	Controller string
+	Handler    string
+	Decorator  Decorator
+}
+
+// FindRouteHandlers finds methods decorated with HTTP route decorators
+// (@Get, @Post, @Put, @Patch, @Delete, @Options, @Head, @All), combining
+// their path with the path of an enclosing @Controller.
+func (a *Analyzer) FindRouteHandlers() []RouteHandler {
+	var handlers []RouteHandler
+	for _, d := range a.FindDecorators("") {
+		httpMethod, ok := httpMethodDecorators[d.Name]
+		if !ok || d.TargetKind != DecoratorTargetMethod {
+			continue
+		}
+
+		handler := RouteHandler{
+			HTTPMethod: httpMethod,
+			Handler:    d.TargetName,
+			Decorator:  d,
+		}
+
+		var prefix string
+		if class := enclosingClass(d.Target); class != nil {
+			handler.Controller = declarationName(class)
+			for _, cd := range GetDecorators(class) {
+				if cd.Name == "Controller" {
+					prefix, _ = cd.StringArgument(0)
+				}
+			}
+		}
+		path, _ := d.StringArgument(0)
+		handler.Path = joinRoutePath(prefix, path)
+
+		handlers = append(handlers, handler)
+	}
+	return handlers
+}
+
+// enclosingClass returns the nearest class declaration containing node.
+func enclosingClass(node ast.Node) ast.Node {
+	for current := node.Parent(); current != nil; current = current.Parent() {
+		switch current.SyntaxKind() {
+		case "class_declaration", "abstract_class_declaration", "class":
+			return current
+		}
+	}
+	return nil
+}
+
+// joinRoutePath joins route path segments with single slashes.
+func joinRoutePath(segments ...string) string {
+	var parts []string
+	for _, s := range segments {
+		if s = strings.Trim(s, "/"); s != "" {
+			parts = append(parts, s)
+		}
+	}
+	return "/" + strings.Join(parts, "/")
+}
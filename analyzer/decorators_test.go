@@ -0,0 +1,136 @@
+package analyzer
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+func TestFindDecorators(t *testing.T) {
+	root := parseSource(t, `
+		@Injectable()
+		export class UserService {}
+
+		@Component({ selector: "app-root" })
+		class AppComponent {
+			@Input() name: string;
+
+			@HostListener("click", ["$event"])
+			onClick(event) {}
+		}
+
+		@Controller("users")
+		export class UsersController {
+			@Get(":id")
+			find(@Param("id") id: string) {}
+
+			@Post()
+			create(@Body() body: CreateUser) {}
+		}
+	`)
+
+	a := New(root)
+
+	all := a.FindDecorators("")
+	if len(all) != 9 {
+		t.Fatalf("FindDecorators(\"\") found %d, want 9", len(all))
+	}
+
+	injectables := a.FindDecoratedClasses("Injectable")
+	if len(injectables) != 1 || declarationName(injectables[0]) != "UserService" {
+		t.Errorf("FindDecoratedClasses(Injectable) = %v", injectables)
+	}
+
+	listeners := a.FindDecorators("HostListener")
+	if len(listeners) != 1 {
+		t.Fatalf("FindDecorators(HostListener) found %d, want 1", len(listeners))
+	}
+	l := listeners[0]
+	if l.TargetKind != DecoratorTargetMethod || l.TargetName != "onClick" {
+		t.Errorf("HostListener target = (%s, %s), want (method, onClick)", l.TargetKind, l.TargetName)
+	}
+	if want := []string{`"click"`, `["$event"]`}; !reflect.DeepEqual(l.Arguments, want) {
+		t.Errorf("HostListener arguments = %v, want %v", l.Arguments, want)
+	}
+
+	params := a.FindDecorators("Param")
+	if len(params) != 1 || params[0].TargetKind != DecoratorTargetParameter || params[0].TargetName != "id" {
+		t.Errorf("FindDecorators(Param) = %+v", params)
+	}
+
+	inputs := a.FindDecorators("Input")
+	if len(inputs) != 1 || inputs[0].TargetKind != DecoratorTargetProperty || inputs[0].TargetName != "name" {
+		t.Errorf("FindDecorators(Input) = %+v", inputs)
+	}
+}
+
+func TestGetDecorators(t *testing.T) {
+	root := parseSource(t, `
+		@A() @B.c
+		class Service {
+			@Log()
+			@Retry(3)
+			run() {}
+
+			stop() {}
+		}
+	`)
+
+	a := New(root)
+
+	classes := a.FindNodes(func(node ast.Node) bool { return node.SyntaxKind() == "class_declaration" })
+	var names []string
+	for _, d := range GetDecorators(classes[0]) {
+		names = append(names, d.Name)
+	}
+	if want := []string{"A", "B.c"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("GetDecorators(class) = %v, want %v", names, want)
+	}
+
+	methods := a.FindNodes(func(node ast.Node) bool { return node.SyntaxKind() == "method_definition" })
+	if len(methods) != 2 {
+		t.Fatalf("found %d methods, want 2", len(methods))
+	}
+	if got := GetDecorators(methods[0]); len(got) != 2 || got[1].Name != "Retry" {
+		t.Errorf("GetDecorators(run) = %+v", got)
+	}
+	if got := GetDecorators(methods[1]); len(got) != 0 {
+		t.Errorf("GetDecorators(stop) = %+v, want none", got)
+	}
+}
+
+func TestFindRouteHandlers(t *testing.T) {
+	root := parseSource(t, `
+		@Controller("/users")
+		export class UsersController {
+			@Get()
+			list() {}
+
+			@Get(":id")
+			find() {}
+
+			@Delete(":id/")
+			remove() {}
+		}
+	`)
+
+	handlers := New(root).FindRouteHandlers()
+
+	want := []RouteHandler{
+		{HTTPMethod: "GET", Path: "/users", Controller: "UsersController", Handler: "list"},
+		{HTTPMethod: "GET", Path: "/users/:id", Controller: "UsersController", Handler: "find"},
+		{HTTPMethod: "DELETE", Path: "/users/:id", Controller: "UsersController", Handler: "remove"},
+	}
+	if len(handlers) != len(want) {
+		t.Fatalf("FindRouteHandlers() found %d, want %d", len(handlers), len(want))
+	}
+	for i, w := range want {
+		h := handlers[i]
+		if h.HTTPMethod != w.HTTPMethod || h.Path != w.Path || h.Controller != w.Controller || h.Handler != w.Handler {
+			t.Errorf("handler %d = %s %s %s.%s, want %s %s %s.%s", i,
+				h.HTTPMethod, h.Path, h.Controller, h.Handler,
+				w.HTTPMethod, w.Path, w.Controller, w.Handler)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package analyzer
+
+import (
+	"github.com/ahmadramadhannn/tsgoast"
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+// Dispatcher runs many independent handlers over a tree in a single
+// traversal. Handlers are registered per NodeType, per tree-sitter kind, or
+// per typed top-level statement, and are invoked in document order; handlers
+// for the same node run in registration order.
+//
+//	d := analyzer.NewDispatcher()
+//	d.OnFunctionDeclaration(func(fn *ast.FunctionDeclaration) { ... })
+//	d.OnKind("call_expression", func(call ast.Node) { ... })
+//	d.Run(tree)
+type Dispatcher struct {
+	byType     map[ast.NodeType][]func(ast.Node)
+	byKind     map[string][]func(ast.Node)
+	statements []func(ast.Statement)
+}
+
+// NewDispatcher creates a dispatcher with no handlers.
+func NewDispatcher() *Dispatcher {
+	return &Dispatcher{
+		byType: make(map[ast.NodeType][]func(ast.Node)),
+		byKind: make(map[string][]func(ast.Node)),
+	}
+}
+
+// On registers a handler for nodes of the given type.
+func (d *Dispatcher) On(nodeType ast.NodeType, handler func(node ast.Node)) {
+	d.byType[nodeType] = append(d.byType[nodeType], handler)
+}
+
+// OnKind registers a handler for nodes of the given tree-sitter kind, such
+// as "call_expression".
+func (d *Dispatcher) OnKind(kind string, handler func(node ast.Node)) {
+	d.byKind[kind] = append(d.byKind[kind], handler)
+}
+
+// OnStatement registers a handler for every typed top-level statement.
+func (d *Dispatcher) OnStatement(handler func(stmt ast.Statement)) {
+	d.statements = append(d.statements, handler)
+}
+
+// onStatement registers a handler for typed statements of type T.
+func onStatement[T ast.Statement](d *Dispatcher, handler func(T)) {
+	d.OnStatement(func(stmt ast.Statement) {
+		if s, ok := stmt.(T); ok {
+			handler(s)
+		}
+	})
+}
+
+// OnVariableStatement registers a handler for top-level variable statements.
+func (d *Dispatcher) OnVariableStatement(handler func(*ast.VariableStatement)) {
+	onStatement(d, handler)
+}
+
+// OnFunctionDeclaration registers a handler for top-level function
+// declarations.
+func (d *Dispatcher) OnFunctionDeclaration(handler func(*ast.FunctionDeclaration)) {
+	onStatement(d, handler)
+}
+
+// OnClassDeclaration registers a handler for top-level class declarations.
+func (d *Dispatcher) OnClassDeclaration(handler func(*ast.ClassDeclaration)) {
+	onStatement(d, handler)
+}
+
+// OnImportDeclaration registers a handler for import statements.
+func (d *Dispatcher) OnImportDeclaration(handler func(*ast.ImportDeclaration)) {
+	onStatement(d, handler)
+}
+
+// OnExportDeclaration registers a handler for top-level export statements.
+func (d *Dispatcher) OnExportDeclaration(handler func(*ast.ExportDeclaration)) {
+	onStatement(d, handler)
+}
+
+// OnEnumDeclaration registers a handler for top-level enum declarations.
+func (d *Dispatcher) OnEnumDeclaration(handler func(*ast.EnumDeclaration)) {
+	onStatement(d, handler)
+}
+
+// OnSwitchStatement registers a handler for top-level switch statements.
+func (d *Dispatcher) OnSwitchStatement(handler func(*ast.SwitchStatement)) {
+	onStatement(d, handler)
+}
+
+// OnExpressionStatement registers a handler for top-level expression
+// statements.
+func (d *Dispatcher) OnExpressionStatement(handler func(*ast.ExpressionStatement)) {
+	onStatement(d, handler)
+}
+
+// Run traverses tree once, invoking the handlers registered for each node.
+// Typed statement handlers are called for tree.Statements just before the
+// node handlers of the corresponding syntax node.
+func (d *Dispatcher) Run(tree *tsgoast.Tree) {
+	if tree == nil || tree.Root == nil {
+		return
+	}
+
+	var statements map[ast.Range]ast.Statement
+	if len(d.statements) > 0 {
+		statements = make(map[ast.Range]ast.Statement, len(tree.Statements))
+		for _, stmt := range tree.Statements {
+			statements[stmt.Range()] = stmt
+		}
+	}
+
+	root := tree.Root
+	visitSubtree(root, func(node ast.Node) bool {
+		if node.Parent() == ast.Node(root) {
+			if stmt, ok := statements[node.Range()]; ok {
+				for _, handler := range d.statements {
+					handler(stmt)
+				}
+			}
+		}
+		d.dispatch(node)
+		return true
+	})
+}
+
+// RunNode traverses the subtree rooted at root, invoking the NodeType and
+// kind handlers. Typed statement handlers are not called, since plain
+// nodes carry no typed statements.
+func (d *Dispatcher) RunNode(root ast.Node) {
+	visitSubtree(root, func(node ast.Node) bool {
+		d.dispatch(node)
+		return true
+	})
+}
+
+// dispatch invokes the node handlers registered for node.
+func (d *Dispatcher) dispatch(node ast.Node) {
+	for _, handler := range d.byType[node.Type()] {
+		handler(node)
+	}
+	for _, handler := range d.byKind[node.SyntaxKind()] {
+		handler(node)
+	}
+}
@@ -0,0 +1,63 @@
+package analyzer
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ahmadramadhannn/tsgoast"
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+func TestDispatcher(t *testing.T) {
+	parser, err := tsgoast.New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+	defer parser.Close()
+
+	tree, err := parser.ParseTree([]byte(`import { a } from "./a";
+function first() { log(1); }
+class Service {}
+function second() { log(2); }
+`))
+	if err != nil {
+		t.Fatalf("ParseTree() error = %v", err)
+	}
+
+	var events []string
+	d := NewDispatcher()
+	d.OnFunctionDeclaration(func(fn *ast.FunctionDeclaration) {
+		events = append(events, "func:"+fn.Name)
+	})
+	d.OnClassDeclaration(func(c *ast.ClassDeclaration) {
+		events = append(events, "class:"+c.Name)
+	})
+	d.OnImportDeclaration(func(*ast.ImportDeclaration) {
+		events = append(events, "import")
+	})
+	d.OnKind("call_expression", func(node ast.Node) {
+		events = append(events, "call:"+node.Text())
+	})
+	d.On(ast.NodeTypeFunction, func(node ast.Node) {
+		events = append(events, "node:"+declarationName(node))
+	})
+	d.Run(tree)
+
+	want := []string{
+		"import",
+		"func:first", "node:first", "call:log(1)",
+		"class:Service",
+		"func:second", "node:second", "call:log(2)",
+	}
+	if !reflect.DeepEqual(events, want) {
+		t.Errorf("events = %v, want %v", events, want)
+	}
+
+	events = nil
+	d.RunNode(tree.Statements[1].(*ast.FunctionDeclaration).ChildByField("body"))
+	if want := []string{"call:log(1)"}; !reflect.DeepEqual(events, want) {
+		t.Errorf("RunNode events = %v, want %v", events, want)
+	}
+
+	d.Run(nil)
+}
@@ -0,0 +1,77 @@
+package analyzer
+
+import (
+	"github.com/ahmadramadhannn/tsgoast/ast"
+	"github.com/ahmadramadhannn/tsgoast/docparser"
+)
+
+// GetDoc returns the parsed JSDoc/TSDoc comment attached to a declaration,
+// or nil if the declaration is undocumented.
+//
+// The comment must immediately precede the declaration. For arrow functions
+// and function expressions the surrounding variable declaration is used, and
+// an enclosing export statement is looked through.
+func GetDoc(node ast.Node) *docparser.Doc {
+	comment := GetDocComment(node)
+	if comment == nil {
+		return nil
+	}
+	return docparser.Parse(comment.Text())
+}
+
+// GetDocComment returns the raw /** ... */ comment node attached to a
+// declaration, or nil if there is none.
+func GetDocComment(node ast.Node) ast.Node {
+	if node == nil {
+		return nil
+	}
+
+	target := docTarget(node)
+	parent := target.Parent()
+	if parent == nil {
+		return nil
+	}
+
+	// Typed statements hold a copy of the node among the children of their
+	// parent, so the target is found by its range rather than by identity.
+	var previous ast.Node
+	for _, child := range parent.Children() {
+		if child.Range() == target.Range() {
+			break
+		}
+		previous = child
+	}
+
+	if previous == nil || previous.SyntaxKind() != "comment" || !docparser.IsDocComment(previous.Text()) {
+		return nil
+	}
+	return previous
+}
+
+// docTarget returns the statement-level node a doc comment precedes.
+func docTarget(node ast.Node) ast.Node {
+	target := node
+
+	switch node.SyntaxKind() {
+	case "arrow_function", "function_expression", "generator_function", "class":
+		// const name = () => {} is documented on the declaration
+		if parent := node.Parent(); parent != nil && parent.SyntaxKind() == "variable_declarator" {
+			target = parent
+		}
+	}
+
+	if target.SyntaxKind() == "variable_declarator" {
+		if parent := target.Parent(); parent != nil {
+			switch parent.SyntaxKind() {
+			case "lexical_declaration", "variable_declaration":
+				target = parent
+			}
+		}
+	}
+
+	if parent := target.Parent(); parent != nil && parent.SyntaxKind() == "export_statement" {
+		target = parent
+	}
+
+	return target
+}
@@ -0,0 +1,103 @@
+package analyzer
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ahmadramadhannn/tsgoast"
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+func TestGetDoc(t *testing.T) {
+	root := parseSource(t, `
+		/** Adds two numbers. */
+		export function add(a: number, b: number) { return a + b; }
+
+		/**
+		 * Doubles a value.
+		 * @param x - the value
+		 */
+		export const double = (x: number) => x * 2;
+
+		// not a doc comment
+		function plain() {}
+
+		/** Detached comment */
+		const unrelated = 1;
+		function undocumented() {}
+
+		class Store {
+			/** @deprecated use save */
+			persist() {}
+		}
+	`)
+
+	a := New(root)
+
+	docs := make(map[string]string)
+	for _, fn := range a.FindFunctions() {
+		if doc := GetDoc(fn); doc != nil {
+			docs[GetFunctionName(fn)] = doc.Summary
+		} else {
+			docs[GetFunctionName(fn)] = "<none>"
+		}
+	}
+
+	want := map[string]string{
+		"add":          "Adds two numbers.",
+		"double":       "Doubles a value.",
+		"plain":        "<none>",
+		"undocumented": "<none>",
+	}
+	for name, summary := range want {
+		if docs[name] != summary {
+			t.Errorf("GetDoc(%s) summary = %q, want %q", name, docs[name], summary)
+		}
+	}
+
+	methods := a.FindNodes(func(node ast.Node) bool {
+		return node.SyntaxKind() == "method_definition"
+	})
+	if len(methods) != 1 {
+		t.Fatalf("found %d methods, want 1", len(methods))
+	}
+	if doc := GetDoc(methods[0]); !doc.IsDeprecated() {
+		t.Errorf("GetDoc(persist) should be deprecated, got %+v", doc)
+	}
+}
+
+func TestGetDocTypedStatements(t *testing.T) {
+	parser, err := tsgoast.New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+	defer parser.Close()
+
+	tree, err := parser.ParseTree([]byte(`/** Adds two numbers. */
+function add(a: number, b: number) { return a + b; }
+
+/** A store. */
+export class Store {}
+
+function undocumented() {}
+`))
+	if err != nil {
+		t.Fatalf("ParseTree() error = %v", err)
+	}
+
+	var got []string
+	for _, stmt := range tree.Statements {
+		if stmt.SyntaxKind() == "comment" {
+			continue
+		}
+		summary := "<none>"
+		if doc := GetDoc(stmt); doc != nil {
+			summary = doc.Summary
+		}
+		got = append(got, summary)
+	}
+	want := []string{"Adds two numbers.", "A store.", "<none>"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetDoc() summaries = %q, want %q", got, want)
+	}
+}
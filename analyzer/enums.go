@@ -0,0 +1,242 @@
+package analyzer
+
+import (
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+// EnumMember is a single member of an enum declaration.
+type EnumMember struct {
+	Name        string
+	Initializer string // initializer source, empty for implicit values
+	IsNumeric   bool   // implicit or numeric initializer
+	Node        ast.Node
+	Range       ast.Range
+}
+
+// Enum is an enum declaration with its members.
+type Enum struct {
+	Name      string
+	Members   []EnumMember
+	IsConst   bool // const enum
+	IsDeclare bool // declare enum
+	Node      ast.Node
+	Range     ast.Range
+}
+
+// IsNumeric reports whether every member of the enum has a numeric value.
+func (e Enum) IsNumeric() bool {
+	for _, m := range e.Members {
+		if !m.IsNumeric {
+			return false
+		}
+	}
+	return len(e.Members) > 0
+}
+
+// Member returns the member with the given name, or nil.
+func (e Enum) Member(name string) *EnumMember {
+	for i := range e.Members {
+		if e.Members[i].Name == name {
+			return &e.Members[i]
+		}
+	}
+	return nil
+}
+
+// EnumAccess is a reference to an enum in an expression.
+type EnumAccess struct {
+	Enum string
+
+	// Member is the accessed member, or empty when the enum is used as a
+	// whole (Object.values(E)) or indexed dynamically (E[key], E[0]).
+	Member string
+
+	Node  ast.Node
+	Range ast.Range
+}
+
+// FindEnums finds all enum declarations in the AST.
+func (a *Analyzer) FindEnums() []Enum {
+	var enums []Enum
+	a.Visit(func(node ast.Node) bool {
+		if node.SyntaxKind() == "enum_declaration" {
+			enums = append(enums, newEnum(node))
+		}
+		return true
+	})
+	return enums
+}
+
+// newEnum builds an Enum from an enum_declaration node.
+func newEnum(node ast.Node) Enum {
+	e := Enum{
+		Name:    declarationName(node),
+		IsConst: ast.FirstChildOfKind(node, "const") != nil,
+		Node:    node,
+		Range:   node.Range(),
+	}
+	if parent := node.Parent(); parent != nil && parent.SyntaxKind() == "ambient_declaration" {
+		e.IsDeclare = true
+	}
+
+	body := ast.FirstChildOfKind(node, "enum_body")
+	if body == nil {
+		return e
+	}
+	for _, child := range body.Children() {
+		member := EnumMember{Node: child, Range: child.Range(), IsNumeric: true}
+		switch child.SyntaxKind() {
+		case "property_identifier", "string":
+			member.Name = stringLiteralValue(child)
+		case "enum_assignment":
+			children := child.Children()
+			if len(children) == 0 {
+				continue
+			}
+			member.Name = stringLiteralValue(children[0])
+			value := children[len(children)-1]
+			member.Initializer = value.Text()
+			member.IsNumeric = isNumericEnumValue(value)
+		default:
+			continue
+		}
+		e.Members = append(e.Members, member)
+	}
+	return e
+}
+
+// isNumericEnumValue checks if an enum initializer evaluates to a number:
+// a numeric literal, or arithmetic over numbers and other members.
+func isNumericEnumValue(node ast.Node) bool {
+	switch node.SyntaxKind() {
+	case "number", "identifier", "member_expression":
+		return true
+	case "string", "template_string":
+		return false
+	case "unary_expression", "binary_expression", "parenthesized_expression":
+		numeric := true
+		visitSubtree(node, func(n ast.Node) bool {
+			switch n.SyntaxKind() {
+			case "string", "template_string":
+				numeric = false
+			}
+			return numeric
+		})
+		return numeric
+	}
+	return false
+}
+
+// FindEnumAccesses finds all references to the named enum in expressions.
+func (a *Analyzer) FindEnumAccesses(enumName string) []EnumAccess {
+	var accesses []EnumAccess
+	a.Visit(func(node ast.Node) bool {
+		if node.SyntaxKind() != "identifier" || node.Text() != enumName {
+			return true
+		}
+		parent := node.Parent()
+		if parent == nil || parent.SyntaxKind() == "enum_declaration" {
+			return true
+		}
+
+		access := EnumAccess{Enum: enumName, Node: node, Range: node.Range()}
+		children := parent.Children()
+		isObject := len(children) > 0 && children[0] == node
+		switch {
+		case parent.SyntaxKind() == "member_expression" && isObject:
+			if property := ast.FirstChildOfKind(parent, "property_identifier"); property != nil {
+				access.Member = property.Text()
+			}
+			access.Node, access.Range = parent, parent.Range()
+		case parent.SyntaxKind() == "subscript_expression" && isObject:
+			if index := ast.FirstChildOfKind(parent, "string"); index != nil {
+				access.Member = stringLiteralValue(index)
+			}
+			access.Node, access.Range = parent, parent.Range()
+		case parent.SyntaxKind() == "member_expression":
+			// x.E is a property of some other object
+			return true
+		}
+		accesses = append(accesses, access)
+		return true
+	})
+	return accesses
+}
+
+// UnusedEnumMembers returns the members of e that are never referenced by
+// accesses, which may be gathered from any number of files. Members used by
+// other members' initializers count as used. When the enum is used as a
+// whole or indexed dynamically, all members are considered used.
+func UnusedEnumMembers(e Enum, accesses []EnumAccess) []EnumMember {
+	used := make(map[string]bool)
+	for _, access := range accesses {
+		if access.Enum != e.Name {
+			continue
+		}
+		if access.Member == "" {
+			return nil
+		}
+		used[access.Member] = true
+	}
+	for _, m := range e.Members {
+		if m.Initializer == "" {
+			continue
+		}
+		visitSubtree(m.Node, func(node ast.Node) bool {
+			if node.SyntaxKind() == "identifier" {
+				used[node.Text()] = true
+			}
+			return true
+		})
+	}
+
+	var unused []EnumMember
+	for _, m := range e.Members {
+		if !used[m.Name] {
+			unused = append(unused, m)
+		}
+	}
+	return unused
+}
+
+// FindUnusedEnumMembers returns the members of the enums declared in the AST
+// that are never referenced within it.
+func (a *Analyzer) FindUnusedEnumMembers() []EnumMember {
+	var unused []EnumMember
+	for _, e := range a.FindEnums() {
+		unused = append(unused, UnusedEnumMembers(e, a.FindEnumAccesses(e.Name))...)
+	}
+	return unused
+}
+
+// FindNumericEnums finds numeric enums that could be string enums. Enums
+// whose members are combined with bitwise operators are treated as flags and
+// skipped, as are ambient declarations.
+func (a *Analyzer) FindNumericEnums() []Enum {
+	var enums []Enum
+	for _, e := range a.FindEnums() {
+		if e.IsDeclare || !e.IsNumeric() || isFlagEnum(e) {
+			continue
+		}
+		enums = append(enums, e)
+	}
+	return enums
+}
+
+// isFlagEnum checks if any member initializer uses a bitwise operator.
+func isFlagEnum(e Enum) bool {
+	for _, m := range e.Members {
+		flag := false
+		visitSubtree(m.Node, func(node ast.Node) bool {
+			switch node.SyntaxKind() {
+			case "<<", ">>", "|", "&", "^", "~":
+				flag = true
+			}
+			return !flag
+		})
+		if flag {
+			return true
+		}
+	}
+	return false
+}
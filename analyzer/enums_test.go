@@ -0,0 +1,102 @@
+package analyzer
+
+import (
+	"testing"
+)
+
+const enumSource = `
+enum Status { Active, Inactive, Banned }
+enum Color { Red = "red", Green = "green" }
+enum Flags { None = 0, Read = 1 << 0, Write = 1 << 1, All = Read | Write }
+const enum Direction { Up = 1, Down }
+declare enum External { A }
+enum Dynamic { X, Y }
+
+const s = Status.Active;
+const c = Color["Green"];
+if (user.status === Status.Banned) {}
+const names = Object.keys(Dynamic);
+const other = config.Status;
+`
+
+func TestFindEnums(t *testing.T) {
+	a := New(parseSource(t, enumSource))
+
+	enums := a.FindEnums()
+	if len(enums) != 6 {
+		t.Fatalf("FindEnums() found %d enums, want 6", len(enums))
+	}
+
+	status := enums[0]
+	if status.Name != "Status" || len(status.Members) != 3 || !status.IsNumeric() {
+		t.Errorf("Status = %s with %d members, numeric %v", status.Name, len(status.Members), status.IsNumeric())
+	}
+	if color := enums[1]; color.IsNumeric() || color.Member("Green").Initializer != `"green"` {
+		t.Errorf("Color should be a string enum with Green = \"green\"")
+	}
+	if dir := enums[3]; !dir.IsConst || dir.Member("Down") == nil {
+		t.Errorf("Direction should be a const enum with member Down")
+	}
+	if ext := enums[4]; !ext.IsDeclare {
+		t.Errorf("External should be a declare enum")
+	}
+}
+
+func TestFindEnumAccesses(t *testing.T) {
+	a := New(parseSource(t, enumSource))
+
+	accesses := a.FindEnumAccesses("Status")
+	if len(accesses) != 2 {
+		t.Fatalf("FindEnumAccesses(Status) found %d accesses, want 2", len(accesses))
+	}
+	if accesses[0].Member != "Active" || accesses[1].Member != "Banned" {
+		t.Errorf("members = %s, %s, want Active, Banned", accesses[0].Member, accesses[1].Member)
+	}
+	if accesses[0].Node.Text() != "Status.Active" {
+		t.Errorf("access node = %q, want Status.Active", accesses[0].Node.Text())
+	}
+
+	if got := a.FindEnumAccesses("Color"); len(got) != 1 || got[0].Member != "Green" {
+		t.Errorf("FindEnumAccesses(Color) = %+v, want Green", got)
+	}
+	if got := a.FindEnumAccesses("Dynamic"); len(got) != 1 || got[0].Member != "" {
+		t.Errorf("FindEnumAccesses(Dynamic) should report a whole-enum use")
+	}
+}
+
+func TestFindUnusedEnumMembers(t *testing.T) {
+	a := New(parseSource(t, enumSource))
+
+	var names []string
+	for _, m := range a.FindUnusedEnumMembers() {
+		names = append(names, m.Name)
+	}
+	// Read and Write are used by All; Dynamic is used as a whole
+	want := []string{"Inactive", "Red", "None", "All", "Up", "Down", "A"}
+	if len(names) != len(want) {
+		t.Fatalf("FindUnusedEnumMembers() = %v, want %v", names, want)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Errorf("unused[%d] = %s, want %s", i, names[i], want[i])
+		}
+	}
+}
+
+func TestFindNumericEnums(t *testing.T) {
+	a := New(parseSource(t, enumSource))
+
+	var names []string
+	for _, e := range a.FindNumericEnums() {
+		names = append(names, e.Name)
+	}
+	want := []string{"Status", "Direction", "Dynamic"}
+	if len(names) != len(want) {
+		t.Fatalf("FindNumericEnums() = %v, want %v", names, want)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Errorf("numeric[%d] = %s, want %s", i, names[i], want[i])
+		}
+	}
+}
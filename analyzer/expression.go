@@ -5,17 +5,17 @@ import (
 )
 
 // FindExpressions finds all expression nodes in the AST.
-func (a *Analyzer) FindExpressions() []ast.Node {
+func (a *Analyzer) FindExpressions() NodeList {
 	return a.FindNodesByType(ast.NodeTypeExpression)
 }
 
 // FindIdentifiers finds all identifier nodes in the AST.
-func (a *Analyzer) FindIdentifiers() []ast.Node {
+func (a *Analyzer) FindIdentifiers() NodeList {
 	return a.FindNodesByType(ast.NodeTypeIdentifier)
 }
 
 // FindLiterals finds all literal nodes in the AST.
-func (a *Analyzer) FindLiterals() []ast.Node {
+func (a *Analyzer) FindLiterals() NodeList {
 	return a.FindNodesByType(ast.NodeTypeLiteral)
 }
 
@@ -0,0 +1,228 @@
+package analyzer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+// Severity is the severity of a Finding. The values match the SARIF result
+// levels.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityNote    Severity = "note"
+)
+
+// Rule IDs reported by the detection analyses, in addition to
+// RuleBannedCall and RuleDebugger.
+const (
+	RuleFloatingPromise        = "floating-promise"
+	RulePromiseConstructor     = "promise-constructor"
+	RuleMisplacedAwait         = "misplaced-await"
+	RuleNonExhaustiveSwitch    = "non-exhaustive-switch"
+	RuleUnusedEnumMember       = "unused-enum-member"
+	RuleUnusedTypeParameter    = "unused-type-parameter"
+	RuleSingleUseTypeParameter = "single-use-type-parameter"
+	RuleNonNullAssertion       = "non-null-assertion"
+	RuleDuplicateCode          = "duplicate-code"
+	RuleUnusedImport           = "unused-import"
+	RuleUnusedVariable         = "unused-variable"
+)
+
+// RuleDescriptions maps every rule ID reported by the analyzer to a short
+// description of the rule.
+var RuleDescriptions = map[string]string{
+	RuleBannedCall:             "Calls to banned functions",
+	RuleDebugger:               "Debugger statements",
+	RuleFloatingPromise:        "Promises that are neither awaited nor handled",
+	RulePromiseConstructor:     "Unneeded new Promise around code that already returns a promise",
+	RuleMisplacedAwait:         "await outside of an async function",
+	RuleNonExhaustiveSwitch:    "switch statements missing cases of a union or enum",
+	RuleUnusedEnumMember:       "Enum members that are never referenced",
+	RuleUnusedTypeParameter:    "Type parameters that are never referenced",
+	RuleSingleUseTypeParameter: "Type parameters referenced only once",
+	RuleNonNullAssertion:       "Non-null assertions",
+	RuleDuplicateCode:          "Structurally identical code",
+	RuleUnusedImport:           "Imports that are never referenced",
+	RuleUnusedVariable:         "Variables that are never referenced",
+}
+
+// Finding is a problem reported by one of the detection analyses, in a
+// common shape suitable for reporting (see the sarif package).
+type Finding struct {
+	RuleID   string
+	Message  string
+	Severity Severity
+	Node     ast.Node
+	Range    ast.Range
+
+	// File is the path of the analyzed file. Analyses leave it empty;
+	// callers set it when combining findings from several files.
+	File string
+}
+
+// Finding returns the call as a Finding.
+func (c BannedCall) Finding() Finding {
+	return Finding{RuleID: c.RuleID, Message: c.Message, Severity: SeverityWarning, Node: c.Node, Range: c.Range}
+}
+
+// Finding returns the floating promise as a Finding.
+func (p FloatingPromise) Finding() Finding {
+	return Finding{
+		RuleID:   RuleFloatingPromise,
+		Message:  fmt.Sprintf("promise returned by %s is not awaited or handled", p.Callee),
+		Severity: SeverityWarning,
+		Node:     p.Call,
+		Range:    p.Range,
+	}
+}
+
+// Finding returns the anti-pattern as a Finding.
+func (p PromiseAntiPattern) Finding() Finding {
+	return Finding{
+		RuleID:   RulePromiseConstructor,
+		Message:  "unneeded new Promise: " + p.Reason,
+		Severity: SeverityWarning,
+		Node:     p.Node,
+		Range:    p.Range,
+	}
+}
+
+// Finding returns the misplaced await as a Finding.
+func (m MisplacedAwait) Finding() Finding {
+	message := "await outside of an async function"
+	if m.Function == nil {
+		message = "top-level await outside of a module"
+	}
+	return Finding{RuleID: RuleMisplacedAwait, Message: message, Severity: SeverityError, Node: m.Node, Range: m.Range}
+}
+
+// Finding returns the switch coverage as a Finding reporting its missing
+// cases.
+func (c SwitchCoverage) Finding() Finding {
+	return Finding{
+		RuleID:   RuleNonExhaustiveSwitch,
+		Message:  fmt.Sprintf("switch on %s is missing cases: %s", c.TypeName, strings.Join(c.Missing, ", ")),
+		Severity: SeverityWarning,
+		Node:     c.Switch,
+		Range:    c.Range,
+	}
+}
+
+// Finding returns the enum member as an unused member Finding.
+func (m EnumMember) Finding() Finding {
+	return Finding{
+		RuleID:   RuleUnusedEnumMember,
+		Message:  fmt.Sprintf("enum member %s is never used", m.Name),
+		Severity: SeverityNote,
+		Node:     m.Node,
+		Range:    m.Range,
+	}
+}
+
+// Finding returns the type parameter as an unused or single-use Finding,
+// depending on its number of uses.
+func (u TypeParameterUsage) Finding() Finding {
+	f := Finding{
+		RuleID:   RuleUnusedTypeParameter,
+		Message:  fmt.Sprintf("type parameter %s of %s is never used", u.Name, u.DeclarationName),
+		Severity: SeverityWarning,
+		Node:     u.Node,
+		Range:    u.Range,
+	}
+	if u.Uses > 0 {
+		f.RuleID = RuleSingleUseTypeParameter
+		f.Message = fmt.Sprintf("type parameter %s of %s is used only once", u.Name, u.DeclarationName)
+		f.Severity = SeverityNote
+	}
+	return f
+}
+
+// Finding returns the import as an unused import Finding.
+func (u UnusedImport) Finding() Finding {
+	return Finding{
+		RuleID:   RuleUnusedImport,
+		Message:  fmt.Sprintf("%s is imported from %s but never used", u.Name, u.Specifier),
+		Severity: SeverityWarning,
+		Node:     u.Node,
+		Range:    u.Range,
+	}
+}
+
+// Finding returns the variable as an unused variable Finding.
+func (u UnusedVariable) Finding() Finding {
+	return Finding{
+		RuleID:   RuleUnusedVariable,
+		Message:  fmt.Sprintf("%s is declared but never used", u.Name),
+		Severity: SeverityWarning,
+		Node:     u.Node,
+		Range:    u.Range,
+	}
+}
+
+// Finding returns the assertion as a Finding.
+func (n NonNullAssertion) Finding() Finding {
+	return Finding{
+		RuleID:   RuleNonNullAssertion,
+		Message:  fmt.Sprintf("non-null assertion on %s", n.Expression.Text()),
+		Severity: SeverityNote,
+		Node:     n.Node,
+		Range:    n.Range,
+	}
+}
+
+// Finding returns the clone pair as a Finding on its second subtree.
+func (p ClonePair) Finding() Finding {
+	return Finding{
+		RuleID:   RuleDuplicateCode,
+		Message:  fmt.Sprintf("duplicate of code at line %d", p.FirstRange.Start.Line+1),
+		Severity: SeverityNote,
+		Node:     p.Second,
+		Range:    p.SecondRange,
+	}
+}
+
+// Findings runs the detection analyses with their default settings and
+// returns their results as findings, ordered by position: banned calls
+// (DefaultBannedCalls), floating promises, promise constructor
+// anti-patterns, misplaced awaits, non-exhaustive switches, unused enum
+// members, unused type parameters and unused imports. Style-only analyses (non-null
+// assertions, single-use type parameters, clones) are not included; call
+// their Finding methods directly to report them.
+func (a *Analyzer) Findings() []Finding {
+	var findings []Finding
+	for _, c := range a.FindBannedCalls(DefaultBannedCalls()) {
+		findings = append(findings, c.Finding())
+	}
+	for _, p := range a.FindFloatingPromises() {
+		findings = append(findings, p.Finding())
+	}
+	for _, p := range a.FindPromiseConstructorAntiPatterns() {
+		findings = append(findings, p.Finding())
+	}
+	for _, m := range a.MisplacedAwaits() {
+		findings = append(findings, m.Finding())
+	}
+	for _, c := range a.FindNonExhaustiveSwitches() {
+		findings = append(findings, c.Finding())
+	}
+	for _, m := range a.FindUnusedEnumMembers() {
+		findings = append(findings, m.Finding())
+	}
+	for _, u := range a.FindUnusedTypeParameters() {
+		findings = append(findings, u.Finding())
+	}
+	for _, u := range a.FindUnusedImports() {
+		findings = append(findings, u.Finding())
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		return findings[i].Range.Start.Offset < findings[j].Range.Start.Offset
+	})
+	return findings
+}
@@ -0,0 +1,70 @@
+package analyzer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFindings(t *testing.T) {
+	root := parseSource(t, `
+		import { readFile, writeFile } from "fs";
+		enum Color { Red, Green }
+		async function load(): Promise<void> {}
+		function run<T>(x: number) {
+			load();
+			console.log(Color.Red, readFile);
+			debugger;
+		}
+		function sync() { await load(); }
+	`)
+
+	findings := New(root).Findings()
+
+	var got []string
+	for _, f := range findings {
+		got = append(got, string(f.Severity)+" "+f.RuleID+": "+f.Message)
+	}
+	want := []string{
+		"warning unused-import: writeFile is imported from fs but never used",
+		"note unused-enum-member: enum member Green is never used",
+		"warning unused-type-parameter: type parameter T of run is never used",
+		"warning floating-promise: promise returned by load is not awaited or handled",
+		"warning banned-call: call to banned function console.log",
+		"warning no-debugger: unexpected debugger statement",
+		"error misplaced-await: await outside of an async function",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Findings() =\n%q\nwant\n%q", got, want)
+	}
+
+	for _, f := range findings {
+		if f.Node == nil || f.Range != f.Node.Range() {
+			t.Errorf("finding %s has range %v, want its node's range", f.RuleID, f.Range)
+		}
+		if RuleDescriptions[f.RuleID] == "" {
+			t.Errorf("rule %s has no description", f.RuleID)
+		}
+	}
+}
+
+func TestTypeParameterUsageFinding(t *testing.T) {
+	root := parseSource(t, `function id<T>(x: T): void {}`)
+
+	single := New(root).FindSingleUseTypeParameters()
+	if len(single) != 1 {
+		t.Fatalf("FindSingleUseTypeParameters() returned %d results, want 1", len(single))
+	}
+	f := single[0].Finding()
+	if f.RuleID != RuleSingleUseTypeParameter || f.Severity != SeverityNote {
+		t.Errorf("Finding() = %s/%s, want %s/note", f.RuleID, f.Severity, RuleSingleUseTypeParameter)
+	}
+}
+
+// finder is implemented by the result of every detection analysis.
+type finder interface{ Finding() Finding }
+
+var _ = []finder{
+	BannedCall{}, FloatingPromise{}, PromiseAntiPattern{}, MisplacedAwait{},
+	SwitchCoverage{}, EnumMember{}, TypeParameterUsage{}, UnusedImport{},
+	UnusedVariable{}, NonNullAssertion{}, ClonePair{},
+}
@@ -15,8 +15,31 @@ const (
 	maxParentTraversalDepth = 3
 )
 
+// functionKinds is the set of tree-sitter kinds that introduce a function
+// body, including methods and class static blocks.
+var functionKinds = map[string]bool{
+	"function_declaration":           true,
+	"function_expression":            true,
+	"generator_function_declaration": true,
+	"generator_function":             true,
+	"arrow_function":                 true,
+	"method_definition":              true,
+	"class_static_block":             true,
+}
+
+// enclosingFunction returns the nearest ancestor of node that introduces a
+// function body, or nil if the node is at the top level.
+func enclosingFunction(node ast.Node) ast.Node {
+	for current := node.Parent(); current != nil; current = current.Parent() {
+		if functionKinds[current.SyntaxKind()] {
+			return current
+		}
+	}
+	return nil
+}
+
 // FindFunctions finds all function declarations in the AST.
-func (a *Analyzer) FindFunctions() []ast.Node {
+func (a *Analyzer) FindFunctions() NodeList {
 	return a.FindNodes(func(node ast.Node) bool {
 		t := node.Type()
 		return t == ast.NodeTypeFunction || t == ast.NodeTypeArrowFunction
@@ -24,12 +47,68 @@ func (a *Analyzer) FindFunctions() []ast.Node {
 }
 
 // FindMethods finds all method definitions in the AST.
-func (a *Analyzer) FindMethods() []ast.Node {
+func (a *Analyzer) FindMethods() NodeList {
 	return a.FindNodesByType(ast.NodeTypeMethod)
 }
 
+// FindAsyncFunctions finds all async functions, arrow functions, function
+// expressions and methods, based on their async modifier.
+func (a *Analyzer) FindAsyncFunctions() NodeList {
+	return a.FindNodes(IsAsyncFunction)
+}
+
+// FindGenerators finds all generator functions and generator methods,
+// including async generators.
+func (a *Analyzer) FindGenerators() NodeList {
+	return a.FindNodes(IsGeneratorFunction)
+}
+
+// IsAsyncFunction checks if a function, arrow function or method node
+// carries the async modifier. Unlike IsAsync, nested async functions and
+// identifiers containing "async" do not affect the result.
+func IsAsyncFunction(node ast.Node) bool {
+	if node == nil || !functionKinds[node.SyntaxKind()] {
+		return false
+	}
+	return hasModifier(node, "async")
+}
+
+// IsGeneratorFunction checks if a function or method node is a generator,
+// i.e. declared with `function*` or `*method()`.
+func IsGeneratorFunction(node ast.Node) bool {
+	if node == nil {
+		return false
+	}
+
+	switch node.SyntaxKind() {
+	case "generator_function_declaration", "generator_function":
+		return true
+	case "method_definition":
+		return hasModifier(node, "*")
+	}
+	return false
+}
+
+// hasModifier checks if a declaration has the given modifier keyword
+// (e.g. "async", "static") as a direct child token.
+func hasModifier(node ast.Node, modifier string) bool {
+	for _, child := range node.Children() {
+		switch child.SyntaxKind() {
+		case modifier:
+			return true
+		case "formal_parameters", "statement_block", "=>":
+			// Modifiers always precede the parameters and body
+			return false
+		}
+	}
+	return false
+}
+
 // IsAsync checks if a function node represents an async function.
 // This is a simplified check based on the node's text content.
+//
+// Deprecated: IsAsync matches "async " anywhere in the function text,
+// including nested functions. Use IsAsyncFunction instead.
 func IsAsync(node ast.Node) bool {
 	if node == nil {
 		return false
@@ -78,6 +157,9 @@ func IsExported(node ast.Node) bool {
 }
 
 // IsGenerator checks if a function node is a generator function.
+//
+// Deprecated: IsGenerator matches "function*" anywhere in the function text
+// and ignores generator methods. Use IsGeneratorFunction instead.
 func IsGenerator(node ast.Node) bool {
 	if node == nil {
 		return false
@@ -118,10 +200,8 @@ func GetFunctionName(node ast.Node) string {
 
 // findIdentifierInChildren searches for an identifier among direct children.
 func findIdentifierInChildren(node ast.Node) string {
-	for _, child := range node.Children() {
-		if child.Type() == ast.NodeTypeIdentifier {
-			return child.Text()
-		}
+	if id := ast.FirstChildOfType(node, ast.NodeTypeIdentifier); id != nil {
+		return id.Text()
 	}
 	return ""
 }
@@ -180,13 +260,7 @@ func HasParameters(node ast.Node) bool {
 		return false
 	}
 
-	for _, child := range node.Children() {
-		if child.Type() == ast.NodeTypeParameter {
-			return true
-		}
-	}
-
-	return false
+	return ast.FirstChildOfType(node, ast.NodeTypeParameter) != nil
 }
 
 // CountParameters counts the number of parameters in a function.
@@ -0,0 +1,80 @@
+package analyzer
+
+import (
+	"testing"
+)
+
+func TestFindAsyncFunctions(t *testing.T) {
+	root := parseSource(t, `
+		async function load() {}
+		function outer() {
+			const inner = async () => {};
+		}
+		const asyncHelper = () => {};
+		const expr = async function named() {};
+		class Service {
+			async start() {}
+			static async create() {}
+			stop() {}
+			async *stream() {}
+		}
+	`)
+
+	a := New(root)
+
+	var names []string
+	for _, fn := range a.FindAsyncFunctions() {
+		names = append(names, fn.SyntaxKind())
+	}
+
+	want := []string{
+		"function_declaration",
+		"arrow_function",
+		"function_expression",
+		"method_definition",
+		"method_definition",
+		"method_definition",
+	}
+	if len(names) != len(want) {
+		t.Fatalf("FindAsyncFunctions() found %v, want %v", names, want)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Errorf("async function %d kind = %s, want %s", i, names[i], want[i])
+		}
+	}
+
+	for _, fn := range a.FindFunctions() {
+		if GetFunctionName(fn) == "outer" && IsAsyncFunction(fn) {
+			t.Error("IsAsyncFunction(outer) = true, want false for a nested async arrow")
+		}
+	}
+}
+
+func TestFindGenerators(t *testing.T) {
+	root := parseSource(t, `
+		function* ids() {}
+		async function* pages() {}
+		const gen = function* () {};
+		function plain() { const s = "function*"; }
+		class Tree {
+			*[Symbol.iterator]() {}
+			async *walk() {}
+			size() {}
+		}
+	`)
+
+	generators := New(root).FindGenerators()
+	if len(generators) != 5 {
+		for _, g := range generators {
+			t.Logf("generator: %s", g.Text())
+		}
+		t.Fatalf("FindGenerators() found %d, want 5", len(generators))
+	}
+
+	for _, g := range generators {
+		if g.SyntaxKind() == "function_declaration" {
+			t.Errorf("plain function reported as generator: %s", g.Text())
+		}
+	}
+}
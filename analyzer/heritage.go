@@ -0,0 +1,142 @@
+package analyzer
+
+import (
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+// declarationKinds is the set of tree-sitter kinds that declare a named type.
+var declarationKinds = map[string]bool{
+	"class_declaration":          true,
+	"abstract_class_declaration": true,
+	"interface_declaration":      true,
+	"type_alias_declaration":     true,
+	"enum_declaration":           true,
+}
+
+// HeritageRef is a reference to a supertype in an extends or implements
+// clause.
+type HeritageRef struct {
+	Name          string // e.g. "Base" or "ns.Base"
+	TypeArguments []*TypeExpr
+	Text          string // full source text, e.g. "Base<T>"
+
+	// Declaration is the declaration of the referenced type in the same
+	// file, or nil if it is declared elsewhere (or not resolved).
+	Declaration ast.Node
+}
+
+// Heritage describes the supertypes of a class or interface.
+type Heritage struct {
+	// Extends holds the extended class of a class (at most one entry) or
+	// the extended interfaces of an interface.
+	Extends []*HeritageRef
+
+	// Implements holds the interfaces implemented by a class.
+	Implements []*HeritageRef
+}
+
+// GetHeritage returns the extends and implements clauses of a class or
+// interface declaration, or nil for any other node. References are not
+// resolved; see Analyzer.ResolveHeritage.
+func GetHeritage(node ast.Node) *Heritage {
+	if node == nil {
+		return nil
+	}
+
+	heritage := &Heritage{}
+	switch node.SyntaxKind() {
+	case "class_declaration", "abstract_class_declaration", "class":
+		clauses := ast.FirstChildOfKind(node, "class_heritage")
+		if clauses == nil {
+			return heritage
+		}
+		if clause := ast.FirstChildOfKind(clauses, "extends_clause"); clause != nil {
+			heritage.Extends = extendsClauseRefs(clause)
+		}
+		if clause := ast.FirstChildOfKind(clauses, "implements_clause"); clause != nil {
+			heritage.Implements = typeRefs(clause)
+		}
+	case "interface_declaration":
+		if clause := ast.FirstChildOfKind(node, "extends_type_clause"); clause != nil {
+			heritage.Extends = typeRefs(clause)
+		}
+	default:
+		return nil
+	}
+
+	return heritage
+}
+
+// ResolveHeritage returns the heritage of a class or interface with each
+// reference linked to its declaration in the analyzed file when possible.
+func (a *Analyzer) ResolveHeritage(node ast.Node) *Heritage {
+	heritage := GetHeritage(node)
+	if heritage == nil {
+		return nil
+	}
+
+	for _, refs := range [][]*HeritageRef{heritage.Extends, heritage.Implements} {
+		for _, ref := range refs {
+			ref.Declaration = a.FindDeclaration(ref.Name)
+		}
+	}
+	return heritage
+}
+
+// FindDeclaration finds the first class, interface, type alias or enum
+// declared with the given name, or nil if there is none.
+func (a *Analyzer) FindDeclaration(name string) ast.Node {
+	var found ast.Node
+	a.Visit(func(node ast.Node) bool {
+		if found != nil {
+			return false
+		}
+		if declarationKinds[node.SyntaxKind()] && declarationName(node) == name {
+			found = node
+			return false
+		}
+		return true
+	})
+	return found
+}
+
+// extendsClauseRefs returns the references of a class extends clause, where
+// the superclass is an expression followed by optional type arguments.
+func extendsClauseRefs(clause ast.Node) []*HeritageRef {
+	var refs []*HeritageRef
+	for _, child := range clause.Children() {
+		switch child.SyntaxKind() {
+		case "extends", ",":
+		case "type_arguments":
+			if len(refs) > 0 {
+				ref := refs[len(refs)-1]
+				for _, arg := range namedTypeChildren(child) {
+					ref.TypeArguments = append(ref.TypeArguments, ParseTypeExpr(arg))
+				}
+				ref.Text += child.Text()
+			}
+		default:
+			refs = append(refs, &HeritageRef{Name: child.Text(), Text: child.Text()})
+		}
+	}
+	return refs
+}
+
+// typeRefs returns the type references listed in an implements or
+// interface extends clause.
+func typeRefs(clause ast.Node) []*HeritageRef {
+	var refs []*HeritageRef
+	for _, child := range clause.Children() {
+		switch child.SyntaxKind() {
+		case "extends", "implements", ",":
+		default:
+			t := ParseTypeExpr(child)
+			refs = append(refs, &HeritageRef{
+				Name:          t.Name,
+				TypeArguments: t.TypeArguments,
+				Text:          child.Text(),
+			})
+		}
+	}
+	return refs
+}
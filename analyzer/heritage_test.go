@@ -0,0 +1,69 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+func TestResolveHeritage(t *testing.T) {
+	root := parseSource(t, `
+		interface Entity { id: string }
+		interface Named<T> { name: T }
+		interface User extends Entity, Named<string> {}
+
+		abstract class Base<T> {}
+		export class UserRepo extends Base<User> implements Repository<User>, Disposable {}
+		class Plain {}
+	`)
+
+	a := New(root)
+
+	user := a.FindDeclaration("User")
+	if user == nil || user.SyntaxKind() != "interface_declaration" {
+		t.Fatalf("FindDeclaration(User) = %v", user)
+	}
+
+	h := a.ResolveHeritage(user)
+	if len(h.Extends) != 2 || len(h.Implements) != 0 {
+		t.Fatalf("User heritage = %+v", h)
+	}
+	if h.Extends[0].Name != "Entity" || h.Extends[0].Declaration != a.FindDeclaration("Entity") {
+		t.Errorf("User extends[0] = %+v", h.Extends[0])
+	}
+	named := h.Extends[1]
+	if named.Name != "Named" || named.Text != "Named<string>" || len(named.TypeArguments) != 1 ||
+		named.TypeArguments[0].Name != "string" || named.Declaration == nil {
+		t.Errorf("User extends[1] = %+v", named)
+	}
+
+	repo := a.ResolveHeritage(a.FindDeclaration("UserRepo"))
+	if len(repo.Extends) != 1 || len(repo.Implements) != 2 {
+		t.Fatalf("UserRepo heritage = %+v", repo)
+	}
+	base := repo.Extends[0]
+	if base.Name != "Base" || base.Text != "Base<User>" || base.Declaration == nil ||
+		base.Declaration.SyntaxKind() != "abstract_class_declaration" {
+		t.Errorf("UserRepo extends = %+v", base)
+	}
+	if len(base.TypeArguments) != 1 || base.TypeArguments[0].Name != "User" {
+		t.Errorf("UserRepo extends type arguments = %+v", base.TypeArguments)
+	}
+	if impl := repo.Implements[0]; impl.Name != "Repository" || impl.Declaration != nil {
+		t.Errorf("UserRepo implements[0] = %+v, want unresolved Repository", impl)
+	}
+
+	plain := GetHeritage(a.FindDeclaration("Plain"))
+	if plain == nil || len(plain.Extends) != 0 || len(plain.Implements) != 0 {
+		t.Errorf("Plain heritage = %+v, want empty", plain)
+	}
+
+	if GetHeritage(root) != nil {
+		t.Error("GetHeritage(program) should be nil")
+	}
+
+	var nilNode ast.Node
+	if GetHeritage(nilNode) != nil {
+		t.Error("GetHeritage(nil) should be nil")
+	}
+}
@@ -0,0 +1,236 @@
+package analyzer
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+// HierarchyTypeKind is the kind of a type in an inheritance hierarchy.
+type HierarchyTypeKind string
+
+// Hierarchy type kind constants.
+const (
+	HierarchyClass     HierarchyTypeKind = "class"
+	HierarchyInterface HierarchyTypeKind = "interface"
+	// HierarchyExternal marks a supertype that is referenced but not
+	// declared in any of the analyzed files.
+	HierarchyExternal HierarchyTypeKind = "external"
+)
+
+// HierarchyType is a class or interface in an inheritance hierarchy.
+type HierarchyType struct {
+	Name         string
+	Kind         HierarchyTypeKind
+	Declarations []ast.Node // one per file declaring the type (interfaces may merge)
+	Extends      []string
+	Implements   []string
+}
+
+// TypeHierarchy is a class/interface inheritance graph spanning one or
+// more files. Types are identified by name.
+type TypeHierarchy struct {
+	types    map[string]*HierarchyType
+	subtypes map[string][]string
+}
+
+// BuildHierarchy constructs the inheritance hierarchy of the classes and
+// interfaces declared in the given ASTs.
+func BuildHierarchy(roots ...*ast.BaseNode) *TypeHierarchy {
+	h := &TypeHierarchy{
+		types:    make(map[string]*HierarchyType),
+		subtypes: make(map[string][]string),
+	}
+
+	for _, root := range roots {
+		New(root).Visit(func(node ast.Node) bool {
+			var kind HierarchyTypeKind
+			switch node.SyntaxKind() {
+			case "class_declaration", "abstract_class_declaration":
+				kind = HierarchyClass
+			case "interface_declaration":
+				kind = HierarchyInterface
+			default:
+				return true
+			}
+
+			t := h.add(declarationName(node), kind)
+			t.Declarations = append(t.Declarations, node)
+
+			heritage := GetHeritage(node)
+			for _, ref := range heritage.Extends {
+				t.Extends = appendUnique(t.Extends, ref.Name)
+			}
+			for _, ref := range heritage.Implements {
+				t.Implements = appendUnique(t.Implements, ref.Name)
+			}
+			return true
+		})
+	}
+
+	// Register referenced supertypes and the reverse edges
+	for _, name := range h.Names() {
+		t := h.types[name]
+		for _, super := range t.Supertypes() {
+			h.add(super, HierarchyExternal)
+			h.subtypes[super] = appendUnique(h.subtypes[super], name)
+		}
+	}
+	for super := range h.subtypes {
+		sort.Strings(h.subtypes[super])
+	}
+
+	return h
+}
+
+// add returns the type with the given name, registering it if needed.
+// A declared kind replaces HierarchyExternal.
+func (h *TypeHierarchy) add(name string, kind HierarchyTypeKind) *HierarchyType {
+	t, ok := h.types[name]
+	if !ok {
+		t = &HierarchyType{Name: name, Kind: kind}
+		h.types[name] = t
+	} else if t.Kind == HierarchyExternal {
+		t.Kind = kind
+	}
+	return t
+}
+
+// Supertypes returns the direct supertypes of t: extended types followed by
+// implemented interfaces.
+func (t *HierarchyType) Supertypes() []string {
+	supers := make([]string, 0, len(t.Extends)+len(t.Implements))
+	supers = append(supers, t.Extends...)
+	return append(supers, t.Implements...)
+}
+
+// Type returns the type with the given name, or nil if it is unknown.
+func (h *TypeHierarchy) Type(name string) *HierarchyType {
+	return h.types[name]
+}
+
+// Names returns the names of all types in the hierarchy in sorted order.
+func (h *TypeHierarchy) Names() []string {
+	names := make([]string, 0, len(h.types))
+	for name := range h.types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Supertypes returns the direct supertypes of the named type.
+func (h *TypeHierarchy) Supertypes(name string) []string {
+	if t := h.types[name]; t != nil {
+		return t.Supertypes()
+	}
+	return nil
+}
+
+// Subtypes returns the direct subtypes of the named type in sorted order.
+func (h *TypeHierarchy) Subtypes(name string) []string {
+	return h.subtypes[name]
+}
+
+// AllSupertypes returns every transitive supertype of the named type in
+// sorted order.
+func (h *TypeHierarchy) AllSupertypes(name string) []string {
+	return h.closure(name, h.Supertypes)
+}
+
+// AllSubtypes returns every transitive subtype of the named type in sorted
+// order.
+func (h *TypeHierarchy) AllSubtypes(name string) []string {
+	return h.closure(name, h.Subtypes)
+}
+
+// IsSubtype reports whether sub directly or transitively extends or
+// implements super.
+func (h *TypeHierarchy) IsSubtype(sub, super string) bool {
+	for _, name := range h.AllSupertypes(sub) {
+		if name == super {
+			return true
+		}
+	}
+	return false
+}
+
+// Roots returns the types without supertypes in sorted order.
+func (h *TypeHierarchy) Roots() []string {
+	var roots []string
+	for _, name := range h.Names() {
+		if len(h.Supertypes(name)) == 0 {
+			roots = append(roots, name)
+		}
+	}
+	return roots
+}
+
+// closure collects the names reachable from name through next, guarding
+// against inheritance cycles.
+func (h *TypeHierarchy) closure(name string, next func(string) []string) []string {
+	seen := map[string]bool{name: true}
+	var result []string
+	queue := next(name)
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if seen[current] {
+			continue
+		}
+		seen[current] = true
+		result = append(result, current)
+		queue = append(queue, next(current)...)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// WriteDOT writes the hierarchy as a Graphviz DOT digraph. Edges point from
+// subtype to supertype; implements edges are dashed and external types are
+// drawn with a dotted outline.
+func (h *TypeHierarchy) WriteDOT(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintln(bw, "digraph hierarchy {")
+	fmt.Fprintln(bw, "  rankdir=BT;")
+	fmt.Fprintln(bw, "  node [shape=box];")
+
+	for _, name := range h.Names() {
+		t := h.types[name]
+		switch t.Kind {
+		case HierarchyInterface:
+			fmt.Fprintf(bw, "  %q [style=rounded];\n", name)
+		case HierarchyExternal:
+			fmt.Fprintf(bw, "  %q [style=dotted];\n", name)
+		default:
+			fmt.Fprintf(bw, "  %q;\n", name)
+		}
+	}
+
+	for _, name := range h.Names() {
+		t := h.types[name]
+		for _, super := range t.Extends {
+			fmt.Fprintf(bw, "  %q -> %q;\n", name, super)
+		}
+		for _, super := range t.Implements {
+			fmt.Fprintf(bw, "  %q -> %q [style=dashed];\n", name, super)
+		}
+	}
+
+	fmt.Fprintln(bw, "}")
+	return bw.Flush()
+}
+
+// appendUnique appends s to list unless it is already present.
+func appendUnique(list []string, s string) []string {
+	for _, existing := range list {
+		if existing == s {
+			return list
+		}
+	}
+	return append(list, s)
+}
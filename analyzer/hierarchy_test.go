@@ -0,0 +1,79 @@
+package analyzer
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestBuildHierarchy(t *testing.T) {
+	models := parseSource(t, `
+		interface Entity { id: string }
+		interface Auditable extends Entity { createdAt: Date }
+		abstract class Model implements Auditable {}
+	`)
+	users := parseSource(t, `
+		class User extends Model implements Serializable {}
+		class Admin extends User {}
+	`)
+
+	h := BuildHierarchy(models, users)
+
+	if got, want := h.Names(), []string{"Admin", "Auditable", "Entity", "Model", "Serializable", "User"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Names() = %v, want %v", got, want)
+	}
+
+	if kind := h.Type("Serializable").Kind; kind != HierarchyExternal {
+		t.Errorf("Serializable kind = %s, want external", kind)
+	}
+	if kind := h.Type("Auditable").Kind; kind != HierarchyInterface {
+		t.Errorf("Auditable kind = %s, want interface", kind)
+	}
+
+	if got, want := h.Supertypes("User"), []string{"Model", "Serializable"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Supertypes(User) = %v, want %v", got, want)
+	}
+	if got, want := h.AllSupertypes("Admin"), []string{"Auditable", "Entity", "Model", "Serializable", "User"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("AllSupertypes(Admin) = %v, want %v", got, want)
+	}
+	if got, want := h.Subtypes("Model"), []string{"User"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Subtypes(Model) = %v, want %v", got, want)
+	}
+	if got, want := h.AllSubtypes("Entity"), []string{"Admin", "Auditable", "Model", "User"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("AllSubtypes(Entity) = %v, want %v", got, want)
+	}
+	if got, want := h.Roots(), []string{"Entity", "Serializable"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Roots() = %v, want %v", got, want)
+	}
+
+	if !h.IsSubtype("Admin", "Entity") || h.IsSubtype("Entity", "Admin") {
+		t.Error("IsSubtype() returned wrong result")
+	}
+
+	var dot strings.Builder
+	if err := h.WriteDOT(&dot); err != nil {
+		t.Fatalf("WriteDOT() error = %v", err)
+	}
+	for _, want := range []string{
+		"digraph hierarchy {",
+		`"Admin" -> "User";`,
+		`"User" -> "Serializable" [style=dashed];`,
+		`"Serializable" [style=dotted];`,
+	} {
+		if !strings.Contains(dot.String(), want) {
+			t.Errorf("WriteDOT() output missing %q:\n%s", want, dot.String())
+		}
+	}
+}
+
+func TestBuildHierarchyCycle(t *testing.T) {
+	root := parseSource(t, `
+		interface A extends B {}
+		interface B extends A {}
+	`)
+
+	h := BuildHierarchy(root)
+	if got, want := h.AllSupertypes("A"), []string{"B"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("AllSupertypes(A) = %v, want %v", got, want)
+	}
+}
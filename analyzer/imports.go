@@ -0,0 +1,339 @@
+package analyzer
+
+import (
+	"path"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+// ImportKind classifies a module specifier.
+type ImportKind string
+
+// Import kind constants.
+const (
+	ImportKindRelative ImportKind = "relative"
+	ImportKindExternal ImportKind = "external"
+)
+
+// resolveExtensions are the suffixes tried when resolving a relative
+// specifier against the files registered in an ImportGraph.
+var resolveExtensions = []string{
+	"", ".ts", ".tsx", ".d.ts", ".js", ".jsx",
+	"/index.ts", "/index.tsx", "/index.js",
+}
+
+// Import represents a module specifier referenced by a file.
+type Import struct {
+	Specifier  string
+	Kind       ImportKind
+	Range      ast.Range
+	IsTypeOnly bool // import type { ... } from "..."
+	IsReExport bool // export ... from "..."
+	IsDynamic  bool // import("...")
+}
+
+// FindImports finds all static imports, re-exports and dynamic import()
+// calls with a string literal specifier.
+func (a *Analyzer) FindImports() []Import {
+	var imports []Import
+	a.Visit(func(node ast.Node) bool {
+		switch node.SyntaxKind() {
+		case "import_statement", "export_statement":
+			// export default "foo" holds a string too, but no source field
+			source := ast.ChildByField(node, "source")
+			if source == nil {
+				return true
+			}
+			imports = append(imports, newImport(source, Import{
+				IsTypeOnly: ast.FirstChildOfKind(node, "type") != nil,
+				IsReExport: node.SyntaxKind() == "export_statement",
+			}))
+		case "call_expression":
+			if ast.FirstChildOfKind(node, "import") == nil {
+				return true
+			}
+			args := ast.FirstChildOfKind(node, "arguments")
+			if args == nil {
+				return true
+			}
+			if source := ast.FirstChildOfKind(args, "string"); source != nil {
+				imports = append(imports, newImport(source, Import{IsDynamic: true}))
+			}
+		}
+		return true
+	})
+	return imports
+}
+
+// newImport completes imp with the specifier held by the source string node.
+func newImport(source ast.Node, imp Import) Import {
+	imp.Specifier = stringLiteralValue(source)
+	imp.Kind = ClassifySpecifier(imp.Specifier)
+	imp.Range = source.Range()
+	return imp
+}
+
+// ClassifySpecifier reports whether a module specifier refers to a file
+// relative to the importer or to an external package.
+func ClassifySpecifier(specifier string) ImportKind {
+	if specifier == "." || specifier == ".." ||
+		strings.HasPrefix(specifier, "./") ||
+		strings.HasPrefix(specifier, "../") ||
+		strings.HasPrefix(specifier, "/") {
+		return ImportKindRelative
+	}
+	return ImportKindExternal
+}
+
+// ImportGraph maps each parsed file to the module specifiers it imports.
+// Relative specifiers are resolved against the other files in the graph.
+type ImportGraph struct {
+	files   map[string][]Import
+	exports map[string]*moduleExports
+	aliases AliasResolver
+}
+
+// AliasResolver returns the paths, without extension, that a non-relative
+// specifier imported by a file may refer to, in the order to try them,
+// such as those of the paths mapping of a tsconfig.json. It returns nil
+// for specifiers of external packages.
+type AliasResolver func(filePath, specifier string) []string
+
+// SetAliasResolver sets the resolver of the non-relative specifiers that
+// refer to files of the graph, such as "@app/models". Without one, such
+// specifiers are external.
+func (g *ImportGraph) SetAliasResolver(r AliasResolver) {
+	g.aliases = r
+}
+
+// NewImportGraph creates an empty import graph.
+func NewImportGraph() *ImportGraph {
+	return &ImportGraph{
+		files:   make(map[string][]Import),
+		exports: make(map[string]*moduleExports),
+	}
+}
+
+// AddFile registers a parsed file with its imports and exports. Adding the
+// same path again replaces what was previously recorded for it.
+func (g *ImportGraph) AddFile(filePath string, root *ast.BaseNode) {
+	filePath = path.Clean(filePath)
+	g.files[filePath] = New(root).FindImports()
+	g.exports[filePath] = collectExports(filePath, root)
+}
+
+// Files returns the registered file paths in sorted order.
+func (g *ImportGraph) Files() []string {
+	files := make([]string, 0, len(g.files))
+	for f := range g.files {
+		files = append(files, f)
+	}
+	sort.Strings(files)
+	return files
+}
+
+// Imports returns the imports of the given file.
+func (g *ImportGraph) Imports(filePath string) []Import {
+	return g.files[path.Clean(filePath)]
+}
+
+// Specifiers returns the distinct module specifiers imported by the given
+// file, in source order.
+func (g *ImportGraph) Specifiers(filePath string) []string {
+	seen := make(map[string]bool)
+	var specifiers []string
+	for _, imp := range g.Imports(filePath) {
+		if !seen[imp.Specifier] {
+			seen[imp.Specifier] = true
+			specifiers = append(specifiers, imp.Specifier)
+		}
+	}
+	return specifiers
+}
+
+// Resolve resolves a relative specifier imported by the given file, or
+// one mapped by the alias resolver, to a registered file path. It returns
+// an empty string for external specifiers and for files that are not part
+// of the graph.
+func (g *ImportGraph) Resolve(filePath, specifier string) string {
+	if ClassifySpecifier(specifier) != ImportKindRelative {
+		if g.aliases == nil {
+			return ""
+		}
+		for _, base := range g.aliases(path.Clean(filePath), specifier) {
+			if target := g.resolveBase(path.Clean(base)); target != "" {
+				return target
+			}
+		}
+		return ""
+	}
+
+	base := specifier
+	if !strings.HasPrefix(specifier, "/") {
+		base = path.Join(path.Dir(path.Clean(filePath)), specifier)
+	}
+	return g.resolveBase(base)
+}
+
+// resolveBase returns the registered file a specifier resolved to base
+// refers to, trying the extensions of resolveExtensions.
+func (g *ImportGraph) resolveBase(base string) string {
+	for _, ext := range resolveExtensions {
+		candidate := base + ext
+		// ESM-style imports reference the emitted .js file
+		if ext != "" && strings.HasSuffix(base, ".js") {
+			candidate = strings.TrimSuffix(base, ".js") + ext
+		}
+		if _, ok := g.files[candidate]; ok {
+			return candidate
+		}
+	}
+
+	return ""
+}
+
+// Dependencies returns the registered files the given file imports,
+// in sorted order.
+func (g *ImportGraph) Dependencies(filePath string) []string {
+	seen := make(map[string]bool)
+	var deps []string
+	for _, imp := range g.Imports(filePath) {
+		if target := g.Resolve(filePath, imp.Specifier); target != "" && !seen[target] {
+			seen[target] = true
+			deps = append(deps, target)
+		}
+	}
+	sort.Strings(deps)
+	return deps
+}
+
+// Dependents returns the registered files that import the given file,
+// in sorted order.
+func (g *ImportGraph) Dependents(filePath string) []string {
+	target := path.Clean(filePath)
+	var dependents []string
+	for _, f := range g.Files() {
+		for _, dep := range g.Dependencies(f) {
+			if dep == target {
+				dependents = append(dependents, f)
+				break
+			}
+		}
+	}
+	return dependents
+}
+
+// Importers returns the registered files that import the given external
+// module specifier, in sorted order.
+func (g *ImportGraph) Importers(specifier string) []string {
+	var importers []string
+	for _, f := range g.Files() {
+		for _, imp := range g.files[f] {
+			if imp.Specifier == specifier {
+				importers = append(importers, f)
+				break
+			}
+		}
+	}
+	return importers
+}
+
+// ExternalModules returns the distinct external specifiers imported by any
+// file in the graph, in sorted order.
+func (g *ImportGraph) ExternalModules() []string {
+	seen := make(map[string]bool)
+	var modules []string
+	for _, imports := range g.files {
+		for _, imp := range imports {
+			if imp.Kind == ImportKindExternal && !seen[imp.Specifier] {
+				seen[imp.Specifier] = true
+				modules = append(modules, imp.Specifier)
+			}
+		}
+	}
+	sort.Strings(modules)
+	return modules
+}
+
+// Cycles returns the import cycles of the graph: the groups of registered
+// files that import each other, directly or through the others, including
+// files that import themselves. Each cycle lists its files in sorted
+// order, and the cycles are sorted by their first file.
+func (g *ImportGraph) Cycles() [][]string {
+	// Tarjan's strongly connected components, with an explicit stack of
+	// the dependencies left to visit
+	type frame struct {
+		file string
+		deps []string
+	}
+	index := make(map[string]int)
+	low := make(map[string]int)
+	onStack := make(map[string]bool)
+	var stack []string
+	var cycles [][]string
+	for _, root := range g.Files() {
+		if _, ok := index[root]; ok {
+			continue
+		}
+		visit := func(file string) frame {
+			index[file], low[file] = len(index), len(index)
+			stack = append(stack, file)
+			onStack[file] = true
+			return frame{file, g.Dependencies(file)}
+		}
+		frames := []frame{visit(root)}
+		for len(frames) > 0 {
+			top := &frames[len(frames)-1]
+			if len(top.deps) > 0 {
+				dep := top.deps[0]
+				top.deps = top.deps[1:]
+				if _, ok := index[dep]; !ok {
+					frames = append(frames, visit(dep))
+				} else if onStack[dep] {
+					low[top.file] = min(low[top.file], index[dep])
+				}
+				continue
+			}
+			file := top.file
+			frames = frames[:len(frames)-1]
+			if len(frames) > 0 {
+				parent := frames[len(frames)-1].file
+				low[parent] = min(low[parent], low[file])
+			}
+			if low[file] != index[file] {
+				continue
+			}
+			i := len(stack) - 1
+			for stack[i] != file {
+				i--
+			}
+			component := append([]string(nil), stack[i:]...)
+			for _, f := range component {
+				onStack[f] = false
+			}
+			stack = stack[:i]
+			if len(component) > 1 || slices.Contains(g.Dependencies(file), file) {
+				sort.Strings(component)
+				cycles = append(cycles, component)
+			}
+		}
+	}
+	sort.Slice(cycles, func(i, j int) bool { return cycles[i][0] < cycles[j][0] })
+	return cycles
+}
+
+// stringLiteralValue returns the contents of a string literal node
+// without its surrounding quotes.
+func stringLiteralValue(node ast.Node) string {
+	text := node.Text()
+	if len(text) >= 2 {
+		quote := text[0]
+		if (quote == '"' || quote == '\'' || quote == '`') && text[len(text)-1] == quote {
+			return text[1 : len(text)-1]
+		}
+	}
+	return text
+}
@@ -0,0 +1,153 @@
+package analyzer
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ahmadramadhannn/tsgoast"
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+func parseSource(t *testing.T, source string) *ast.BaseNode {
+	t.Helper()
+
+	parser, err := tsgoast.New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+	defer parser.Close()
+
+	root, err := parser.Parse([]byte(source))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	return root
+}
+
+func TestFindImports(t *testing.T) {
+	root := parseSource(t, `
+		import { readFile } from "node:fs";
+		import type { User } from "./types";
+		import "./polyfill";
+		export { helper } from "../shared/helper";
+		const lazy = await import("./lazy");
+		export default "not-an-import";
+	`)
+
+	imports := New(root).FindImports()
+
+	tests := []struct {
+		specifier string
+		kind      ImportKind
+		typeOnly  bool
+		reExport  bool
+		dynamic   bool
+	}{
+		{"node:fs", ImportKindExternal, false, false, false},
+		{"./types", ImportKindRelative, true, false, false},
+		{"./polyfill", ImportKindRelative, false, false, false},
+		{"../shared/helper", ImportKindRelative, false, true, false},
+		{"./lazy", ImportKindRelative, false, false, true},
+	}
+
+	if len(imports) != len(tests) {
+		t.Fatalf("FindImports() found %d imports, want %d", len(imports), len(tests))
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.specifier, func(t *testing.T) {
+			imp := imports[i]
+			if imp.Specifier != tt.specifier {
+				t.Errorf("Specifier = %q, want %q", imp.Specifier, tt.specifier)
+			}
+			if imp.Kind != tt.kind {
+				t.Errorf("Kind = %v, want %v", imp.Kind, tt.kind)
+			}
+			if imp.IsTypeOnly != tt.typeOnly || imp.IsReExport != tt.reExport || imp.IsDynamic != tt.dynamic {
+				t.Errorf("flags = (%v, %v, %v), want (%v, %v, %v)",
+					imp.IsTypeOnly, imp.IsReExport, imp.IsDynamic, tt.typeOnly, tt.reExport, tt.dynamic)
+			}
+		})
+	}
+}
+
+func TestImportGraph(t *testing.T) {
+	g := NewImportGraph()
+	g.AddFile("src/index.ts", parseSource(t, `
+		import { App } from "./app";
+		import React from "react";
+	`))
+	g.AddFile("src/app.tsx", parseSource(t, `
+		import React from "react";
+		import { format } from "./utils/format.js";
+	`))
+	g.AddFile("src/utils/format.ts", parseSource(t, `
+		import dayjs from "dayjs";
+	`))
+
+	if got, want := g.Dependencies("src/index.ts"), []string{"src/app.tsx"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Dependencies(index) = %v, want %v", got, want)
+	}
+	if got, want := g.Dependencies("src/app.tsx"), []string{"src/utils/format.ts"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Dependencies(app) = %v, want %v", got, want)
+	}
+	if got, want := g.Dependents("src/app.tsx"), []string{"src/index.ts"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Dependents(app) = %v, want %v", got, want)
+	}
+	if got, want := g.Importers("react"), []string{"src/app.tsx", "src/index.ts"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Importers(react) = %v, want %v", got, want)
+	}
+	if got, want := g.ExternalModules(), []string{"dayjs", "react"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ExternalModules() = %v, want %v", got, want)
+	}
+	if got, want := g.Specifiers("src/index.ts"), []string{"./app", "react"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Specifiers(index) = %v, want %v", got, want)
+	}
+}
+
+func TestImportGraphCycles(t *testing.T) {
+	g := NewImportGraph()
+	g.AddFile("a.ts", parseSource(t, `import { b } from "./b";`))
+	g.AddFile("b.ts", parseSource(t, `import { c } from "./c"; import "react";`))
+	g.AddFile("c.ts", parseSource(t, `export * from "./a";`))
+	g.AddFile("d.ts", parseSource(t, `import { a } from "./a"; import { e } from "./e";`))
+	g.AddFile("e.ts", parseSource(t, `import { d } from "./d";`))
+	g.AddFile("f.ts", parseSource(t, `import { self } from "./f";`))
+	g.AddFile("g.ts", parseSource(t, `import { a } from "./a";`))
+
+	want := [][]string{{"a.ts", "b.ts", "c.ts"}, {"d.ts", "e.ts"}, {"f.ts"}}
+	if got := g.Cycles(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Cycles() = %v, want %v", got, want)
+	}
+	if got := NewImportGraph().Cycles(); got != nil {
+		t.Errorf("Cycles() of an empty graph = %v, want nil", got)
+	}
+}
+
+func TestImportGraphAliases(t *testing.T) {
+	g := NewImportGraph()
+	g.AddFile("src/index.ts", parseSource(t, `
+		import { User } from "@app/models";
+		import { log } from "~/log";
+		import React from "react";
+	`))
+	g.AddFile("src/models/index.ts", parseSource(t, `export class User {}`))
+	g.AddFile("src/log.ts", parseSource(t, `export function log() {}`))
+	g.SetAliasResolver(func(filePath, specifier string) []string {
+		switch {
+		case strings.HasPrefix(specifier, "@app/"):
+			return []string{"lib/" + specifier[5:], "src/" + specifier[5:]}
+		case strings.HasPrefix(specifier, "~/"):
+			return []string{"src/" + specifier[2:]}
+		}
+		return nil
+	})
+
+	if got, want := g.Dependencies("src/index.ts"), []string{"src/log.ts", "src/models/index.ts"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Dependencies(index) = %v, want %v", got, want)
+	}
+	if got := g.Resolve("src/index.ts", "react"); got != "" {
+		t.Errorf("Resolve(react) = %q, want none", got)
+	}
+}
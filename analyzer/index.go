@@ -0,0 +1,65 @@
+package analyzer
+
+import (
+	"slices"
+
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+// nodeIndex lists the nodes of a tree by type and kind, in pre-order.
+type nodeIndex struct {
+	byType map[ast.NodeType]NodeList
+	byKind map[string]NodeList
+}
+
+// Index indexes the nodes of the tree by type and tree-sitter kind, in one
+// traversal. FindNodesByType, CountNodesByType, ByType and ByKind then
+// answer from the index, at a cost proportional to the number of matches
+// rather than to the size of the tree, which pays off for analyzers
+// running many queries over the same tree. It returns a for chaining:
+//
+//	a := analyzer.New(root).Index()
+//
+// The index is not updated when the tree is modified: call Index again
+// afterwards.
+func (a *Analyzer) Index() *Analyzer {
+	index := &nodeIndex{
+		byType: make(map[ast.NodeType]NodeList),
+		byKind: make(map[string]NodeList),
+	}
+	for node := range a.All() {
+		index.byType[node.Type()] = append(index.byType[node.Type()], node)
+		index.byKind[node.SyntaxKind()] = append(index.byKind[node.SyntaxKind()], node)
+	}
+	a.index = index
+	return a
+}
+
+// typeNodes returns the indexed nodes of type nodeType.
+func (x *nodeIndex) typeNodes(nodeType ast.NodeType) NodeList {
+	if x == nil {
+		return nil
+	}
+	return x.byType[nodeType]
+}
+
+// kindNodes returns the indexed nodes of the given kind.
+func (x *nodeIndex) kindNodes(kind string) NodeList {
+	if x == nil {
+		return nil
+	}
+	return x.byKind[kind]
+}
+
+// indexed returns a copy of nodes, found in the index, if the analyzer is
+// indexed and opts select the order of the index.
+func (a *Analyzer) indexed(opts []VisitOption, nodes NodeList) (NodeList, bool) {
+	c := newVisitConfig(opts)
+	if a.index == nil || c.order != PreOrder || c.maxDepth >= 0 {
+		return nil, false
+	}
+	if len(nodes) == 0 {
+		return nil, true
+	}
+	return slices.Grow(slices.Clone(nodes), max(0, c.capacity-len(nodes))), true
+}
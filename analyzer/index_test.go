@@ -0,0 +1,67 @@
+package analyzer
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+func TestIndex(t *testing.T) {
+	root := parseSource(t, `
+function a(x: number) { return x; }
+const b = (y: number) => y * 2;
+class C { m() { return a(1); } }
+`)
+	plain := New(root)
+	indexed := New(root).Index()
+
+	for _, nodeType := range []ast.NodeType{ast.NodeTypeFunction, ast.NodeTypeArrowFunction, ast.NodeTypeMethod, ast.NodeTypeIdentifier, ast.NodeTypeInterface} {
+		want := plain.FindNodesByType(nodeType)
+		got := indexed.FindNodesByType(nodeType)
+		if !slices.Equal(got, want) {
+			t.Errorf("indexed FindNodesByType(%s) = %d nodes, want %d", nodeType, len(got), len(want))
+		}
+		if n := indexed.CountNodesByType(nodeType); n != len(want) {
+			t.Errorf("indexed CountNodesByType(%s) = %d, want %d", nodeType, n, len(want))
+		}
+		if got := slices.Collect(indexed.ByType(nodeType)); !slices.Equal(got, slices.Collect(plain.ByType(nodeType))) {
+			t.Errorf("indexed ByType(%s) differs", nodeType)
+		}
+	}
+	if got, want := slices.Collect(indexed.ByKind("call_expression")), slices.Collect(plain.ByKind("call_expression")); len(got) != 1 || !slices.Equal(got, want) {
+		t.Errorf("indexed ByKind(call_expression) = %d nodes, want 1", len(got))
+	}
+
+	// The result is a copy, and options the index does not cover traverse
+	got := indexed.FindNodesByType(ast.NodeTypeIdentifier)
+	got[0] = nil
+	if indexed.FindNodesByType(ast.NodeTypeIdentifier)[0] == nil {
+		t.Error("FindNodesByType() returned the index itself")
+	}
+	post := indexed.FindNodesByType(ast.NodeTypeIdentifier, WithOrder(PostOrder))
+	if want := plain.FindNodesByType(ast.NodeTypeIdentifier, WithOrder(PostOrder)); !slices.Equal(post, want) {
+		t.Error("indexed FindNodesByType() in post-order differs")
+	}
+	if shallow := indexed.FindNodesByType(ast.NodeTypeFunction, WithMaxDepth(0)); len(shallow) != 0 {
+		t.Errorf("indexed FindNodesByType() with depth 0 = %d nodes", len(shallow))
+	}
+}
+
+func BenchmarkFindNodesByTypeIndexed(b *testing.B) {
+	root := &ast.BaseNode{NodeType: ast.NodeTypeFunction}
+	for i := 0; i < 1000; i++ {
+		nodeType := ast.NodeTypeExpression
+		if i%100 == 0 {
+			nodeType = ast.NodeTypeIdentifier
+		}
+		root.ChildNodes = append(root.ChildNodes, &ast.BaseNode{NodeType: nodeType})
+	}
+	analyzer := New(root).Index()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = analyzer.FindNodesByType(ast.NodeTypeIdentifier)
+	}
+}
@@ -0,0 +1,59 @@
+package analyzer
+
+import (
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+// GetInterfaceMembers returns the property and method signatures declared in
+// an interface, or in the object type of a type alias, with optional and
+// readonly flags taken from the parse tree.
+func GetInterfaceMembers(node ast.Node) ([]*ast.PropertySignature, []*ast.MethodSignature) {
+	body := memberBody(node)
+	if body == nil {
+		return nil, nil
+	}
+
+	var properties []*ast.PropertySignature
+	var methods []*ast.MethodSignature
+	for _, member := range body.Children() {
+		switch member.SyntaxKind() {
+		case "property_signature":
+			prop := &ast.PropertySignature{
+				Name:       declarationName(member),
+				IsOptional: ast.FirstChildOfKind(member, "?") != nil,
+				IsReadonly: ast.FirstChildOfKind(member, "readonly") != nil,
+			}
+			if annotation := ast.FirstChildOfKind(member, "type_annotation"); annotation != nil {
+				prop.Type = annotationType(annotation)
+			}
+			properties = append(properties, prop)
+		case "method_signature":
+			methods = append(methods, &ast.MethodSignature{
+				Name:       declarationName(member),
+				Parameters: GetParameters(member),
+				ReturnType: returnTypeOf(member),
+				IsOptional: ast.FirstChildOfKind(member, "?") != nil,
+			})
+		}
+	}
+
+	return properties, methods
+}
+
+// memberBody returns the node holding the members of an interface or of a
+// type alias whose right-hand side is an object type.
+func memberBody(node ast.Node) ast.Node {
+	if node == nil {
+		return nil
+	}
+
+	switch node.SyntaxKind() {
+	case "interface_body", "object_type":
+		return node
+	case "interface_declaration":
+		return ast.FirstChildOfKind(node, "interface_body")
+	case "type_alias_declaration":
+		return ast.FirstChildOfKind(node, "object_type")
+	}
+	return nil
+}
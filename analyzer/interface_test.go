@@ -0,0 +1,65 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+func TestGetInterfaceMembers(t *testing.T) {
+	root := parseSource(t, `
+		interface User extends Base {
+			readonly id: string;
+			nickname?: string;
+			callback: (err?: Error) => void;
+			save?(force: boolean): Promise<void>;
+			load<T>(): T;
+		}
+
+		type Point = { x: number; readonly y?: number };
+	`)
+
+	a := New(root)
+	interfaces := a.FindNodes(func(node ast.Node) bool { return node.SyntaxKind() == "interface_declaration" })
+	aliases := a.FindNodes(func(node ast.Node) bool { return node.SyntaxKind() == "type_alias_declaration" })
+
+	props, methods := GetInterfaceMembers(interfaces[0])
+
+	wantProps := []ast.PropertySignature{
+		{Name: "id", Type: "string", IsReadonly: true},
+		{Name: "nickname", Type: "string", IsOptional: true},
+		{Name: "callback", Type: "(err?: Error) => void"},
+	}
+	if len(props) != len(wantProps) {
+		t.Fatalf("properties = %d, want %d", len(props), len(wantProps))
+	}
+	for i, want := range wantProps {
+		if *props[i] != want {
+			t.Errorf("property %d = %+v, want %+v", i, *props[i], want)
+		}
+	}
+
+	if len(methods) != 2 {
+		t.Fatalf("methods = %d, want 2", len(methods))
+	}
+	save := methods[0]
+	if save.Name != "save" || !save.IsOptional || save.ReturnType != "Promise<void>" ||
+		len(save.Parameters) != 1 || save.Parameters[0].Type != "boolean" {
+		t.Errorf("save = %+v", save)
+	}
+	if load := methods[1]; load.Name != "load" || load.IsOptional || load.ReturnType != "T" {
+		t.Errorf("load = %+v", load)
+	}
+
+	aliasProps, aliasMethods := GetInterfaceMembers(aliases[0])
+	if len(aliasProps) != 2 || len(aliasMethods) != 0 {
+		t.Fatalf("alias members = (%d, %d), want (2, 0)", len(aliasProps), len(aliasMethods))
+	}
+	if y := aliasProps[1]; y.Name != "y" || !y.IsOptional || !y.IsReadonly {
+		t.Errorf("y = %+v", y)
+	}
+
+	if props, methods := GetInterfaceMembers(root); props != nil || methods != nil {
+		t.Error("GetInterfaceMembers(program) should return nil slices")
+	}
+}
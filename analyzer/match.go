@@ -0,0 +1,324 @@
+package analyzer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ahmadramadhannn/tsgoast"
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+// Pattern is a compiled code template used for structural matching.
+//
+// A pattern is TypeScript source in which metavariables stand for arbitrary
+// subtrees:
+//
+//	$NAME     matches a single node and binds it to NAME
+//	$_        matches a single node without binding it
+//	$$$NAME   matches zero or more sibling nodes and binds them to NAME
+//
+// Metavariables are uppercase identifiers, so they parse like any other
+// identifier. A metavariable used twice must match identical code both times.
+type Pattern struct {
+	source string
+	root   ast.Node
+}
+
+// PatternMatch is a node matched by a Pattern together with the nodes bound
+// to its metavariables.
+type PatternMatch struct {
+	Node     ast.Node
+	Range    ast.Range
+	Bindings map[string]ast.Node   // $NAME bindings
+	Lists    map[string][]ast.Node // $$$NAME bindings, without separators
+}
+
+// CompilePattern parses a code template into a Pattern. The template must
+// parse as a single statement or expression. An expression without a
+// trailing semicolon matches wherever the expression occurs; with the
+// semicolon it only matches expression statements.
+func CompilePattern(pattern string) (*Pattern, error) {
+	parser, err := tsgoast.New()
+	if err != nil {
+		return nil, err
+	}
+	defer parser.Close()
+
+	root, err := parser.Parse([]byte(pattern))
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+	}
+
+	var statements []ast.Node
+	for _, child := range root.Children() {
+		if child.SyntaxKind() != "comment" {
+			statements = append(statements, child)
+		}
+	}
+	if len(statements) != 1 {
+		return nil, fmt.Errorf("invalid pattern %q: expected a single statement or expression, got %d", pattern, len(statements))
+	}
+	if hasSyntaxError(root) {
+		return nil, fmt.Errorf("invalid pattern %q: syntax error", pattern)
+	}
+
+	node := statements[0]
+	// An expression pattern matches the expression wherever it occurs,
+	// not only as a statement
+	if node.SyntaxKind() == "expression_statement" && !strings.HasSuffix(strings.TrimSpace(pattern), ";") {
+		if named := significantChildren(node); len(named) == 1 {
+			node = named[0]
+		}
+	}
+
+	return &Pattern{source: pattern, root: node}, nil
+}
+
+// String returns the source of the pattern.
+func (p *Pattern) String() string {
+	return p.source
+}
+
+// Match returns every node in the subtree rooted at root that matches the
+// pattern, in document order.
+func (p *Pattern) Match(root ast.Node) []PatternMatch {
+	var matches []PatternMatch
+	visitSubtree(root, func(node ast.Node) bool {
+		if match, ok := p.MatchNode(node); ok {
+			matches = append(matches, match)
+		}
+		return true
+	})
+	return matches
+}
+
+// MatchNode reports whether node itself matches the pattern, and returns
+// the match with its bindings if so.
+func (p *Pattern) MatchNode(node ast.Node) (PatternMatch, bool) {
+	m := &matcher{bindings: make(map[string]ast.Node), lists: make(map[string][]ast.Node)}
+	if !m.match(p.root, node) {
+		return PatternMatch{}, false
+	}
+	return PatternMatch{
+		Node:     node,
+		Range:    node.Range(),
+		Bindings: m.bindings,
+		Lists:    m.lists,
+	}, true
+}
+
+// Match compiles pattern and returns every node of the tree rooted at root
+// that matches it.
+func Match(root ast.Node, pattern string) ([]PatternMatch, error) {
+	p, err := CompilePattern(pattern)
+	if err != nil {
+		return nil, err
+	}
+	return p.Match(root), nil
+}
+
+// Match returns every node of the analyzed AST that matches pattern.
+func (a *Analyzer) Match(pattern string) ([]PatternMatch, error) {
+	if a.root == nil {
+		return nil, nil
+	}
+	return Match(a.root, pattern)
+}
+
+// matcher holds the bindings accumulated while matching one candidate.
+type matcher struct {
+	bindings map[string]ast.Node
+	lists    map[string][]ast.Node
+}
+
+// match reports whether node matches the pattern node p.
+func (m *matcher) match(p, node ast.Node) bool {
+	if name, ok := metavariable(p); ok {
+		if name == "_" {
+			return true
+		}
+		if bound, ok := m.bindings[name]; ok {
+			return sameCode(bound, node)
+		}
+		m.bindings[name] = node
+		return true
+	}
+
+	// A lone metavariable statement ($BODY;) matches any statement
+	if p.SyntaxKind() == "expression_statement" {
+		if named := significantChildren(p); len(named) == 1 {
+			if _, ok := metavariable(named[0]); ok && isStatement(node) {
+				return m.match(named[0], node)
+			}
+		}
+	}
+
+	if p.SyntaxKind() != node.SyntaxKind() {
+		return false
+	}
+
+	pChildren, nChildren := significantChildren(p), significantChildren(node)
+	if len(pChildren) == 0 && len(nChildren) == 0 {
+		return p.Text() == node.Text()
+	}
+	return m.matchList(pChildren, nChildren)
+}
+
+// matchList matches a sequence of pattern siblings against node siblings,
+// expanding $$$NAME metavariables with backtracking.
+func (m *matcher) matchList(patterns, nodes []ast.Node) bool {
+	if len(patterns) == 0 {
+		return len(nodes) == 0
+	}
+
+	if name, ok := listMetavariable(patterns[0]); ok {
+		for n := 0; n <= len(nodes); n++ {
+			saved := m.snapshot()
+			if m.matchList(patterns[1:], nodes[n:]) {
+				if name != "_" {
+					m.lists[name] = withoutSeparators(nodes[:n])
+				}
+				return true
+			}
+			m.restore(saved)
+		}
+		return false
+	}
+
+	if len(nodes) == 0 {
+		return false
+	}
+	saved := m.snapshot()
+	if m.match(patterns[0], nodes[0]) && m.matchList(patterns[1:], nodes[1:]) {
+		return true
+	}
+	m.restore(saved)
+	return false
+}
+
+// snapshot copies the current bindings so a failed branch can be undone.
+func (m *matcher) snapshot() matcher {
+	saved := matcher{
+		bindings: make(map[string]ast.Node, len(m.bindings)),
+		lists:    make(map[string][]ast.Node, len(m.lists)),
+	}
+	for k, v := range m.bindings {
+		saved.bindings[k] = v
+	}
+	for k, v := range m.lists {
+		saved.lists[k] = v
+	}
+	return saved
+}
+
+// restore resets the bindings to a snapshot.
+func (m *matcher) restore(saved matcher) {
+	m.bindings = saved.bindings
+	m.lists = saved.lists
+}
+
+// metavariable returns the name of a single-node metavariable ($NAME or $_).
+func metavariable(node ast.Node) (string, bool) {
+	if !identifierKinds[node.SyntaxKind()] {
+		return "", false
+	}
+	text := node.Text()
+	if !strings.HasPrefix(text, "$") || strings.HasPrefix(text, "$$") {
+		return "", false
+	}
+	name := text[1:]
+	return name, isMetavariableName(name)
+}
+
+// listMetavariable returns the name of a multi-node metavariable ($$$NAME).
+// It also recognizes the expression statement wrapping one in a block.
+func listMetavariable(node ast.Node) (string, bool) {
+	if node.SyntaxKind() == "expression_statement" {
+		if named := significantChildren(node); len(named) == 1 {
+			node = named[0]
+		}
+	}
+	if !identifierKinds[node.SyntaxKind()] || !strings.HasPrefix(node.Text(), "$$$") {
+		return "", false
+	}
+	name := strings.TrimPrefix(node.Text(), "$$$")
+	return name, isMetavariableName(name)
+}
+
+// isMetavariableName checks if name is "_" or consists of uppercase letters,
+// digits and underscores.
+func isMetavariableName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		if !(r >= 'A' && r <= 'Z') && !(r >= '0' && r <= '9') && r != '_' {
+			return false
+		}
+	}
+	return true
+}
+
+// isStatement checks if a node is a statement or declaration.
+func isStatement(node ast.Node) bool {
+	kind := node.SyntaxKind()
+	return strings.HasSuffix(kind, "_statement") || strings.HasSuffix(kind, "_declaration")
+}
+
+// significantChildren returns the children of node, skipping comments and
+// semicolons, which do not affect a match.
+func significantChildren(node ast.Node) []ast.Node {
+	var children []ast.Node
+	for _, child := range node.Children() {
+		switch child.SyntaxKind() {
+		case "comment", ";":
+			continue
+		}
+		children = append(children, child)
+	}
+	return children
+}
+
+// withoutSeparators drops comma tokens from a list binding.
+func withoutSeparators(nodes []ast.Node) []ast.Node {
+	var result []ast.Node
+	for _, node := range nodes {
+		if node.SyntaxKind() != "," {
+			result = append(result, node)
+		}
+	}
+	return result
+}
+
+// sameCode checks if two subtrees have the same tokens.
+func sameCode(a, b ast.Node) bool {
+	if a.SyntaxKind() != b.SyntaxKind() {
+		return false
+	}
+	ac, bc := significantChildren(a), significantChildren(b)
+	if len(ac) != len(bc) {
+		return false
+	}
+	if len(ac) == 0 {
+		return a.Text() == b.Text()
+	}
+	for i := range ac {
+		if !sameCode(ac[i], bc[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// hasSyntaxError checks if a subtree contains an ERROR node.
+func hasSyntaxError(node ast.Node) bool {
+	if node.SyntaxKind() == "ERROR" {
+		return true
+	}
+	for _, child := range node.Children() {
+		if hasSyntaxError(child) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,98 @@
+package analyzer
+
+import (
+	"testing"
+)
+
+const matchSource = `
+async function load() {
+	const a = await fetch("/users", { method: "GET" });
+	const b = await fetch("/posts");
+	fetch(url, opts);
+	assertEqual(total, total);
+	assertEqual(total, count);
+	log("a", 1, true);
+	if (ready) {
+		start();
+		stop();
+	}
+}
+`
+
+func TestMatch(t *testing.T) {
+	root := parseSource(t, matchSource)
+
+	matches, err := Match(root, "fetch($URL, $OPTS)")
+	if err != nil {
+		t.Fatalf("Match() error = %v", err)
+	}
+	if len(matches) != 2 {
+		t.Fatalf("Match(fetch($URL, $OPTS)) found %d matches, want 2", len(matches))
+	}
+	if got := matches[0].Bindings["URL"].Text(); got != `"/users"` {
+		t.Errorf("URL = %s, want \"/users\"", got)
+	}
+	if got := matches[0].Bindings["OPTS"].SyntaxKind(); got != "object" {
+		t.Errorf("OPTS kind = %s, want object", got)
+	}
+	if got := matches[1].Bindings["URL"].Text(); got != "url" {
+		t.Errorf("URL = %s, want url", got)
+	}
+	if matches[0].Range.Start.Line != 2 {
+		t.Errorf("first match line = %d, want 2", matches[0].Range.Start.Line)
+	}
+}
+
+func TestMatchRepeatedMetavariable(t *testing.T) {
+	a := New(parseSource(t, matchSource))
+
+	matches, err := a.Match("assertEqual($X, $X)")
+	if err != nil {
+		t.Fatalf("Match() error = %v", err)
+	}
+	if len(matches) != 1 || matches[0].Bindings["X"].Text() != "total" {
+		t.Fatalf("Match(assertEqual($X, $X)) = %d matches, want 1 binding total", len(matches))
+	}
+}
+
+func TestMatchListMetavariable(t *testing.T) {
+	a := New(parseSource(t, matchSource))
+
+	tests := []struct {
+		pattern string
+		want    int
+		list    string
+		size    int
+	}{
+		{pattern: "fetch($$$ARGS)", want: 3, list: "ARGS", size: 2},
+		{pattern: `log("a", $$$REST)`, want: 1, list: "REST", size: 2},
+		{pattern: "if ($COND) { $$$BODY }", want: 1, list: "BODY", size: 2},
+		{pattern: "await $_", want: 2},
+		{pattern: "stop();", want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			matches, err := a.Match(tt.pattern)
+			if err != nil {
+				t.Fatalf("Match() error = %v", err)
+			}
+			if len(matches) != tt.want {
+				t.Fatalf("Match() found %d matches, want %d", len(matches), tt.want)
+			}
+			if tt.list != "" {
+				if got := len(matches[0].Lists[tt.list]); got != tt.size {
+					t.Errorf("%s has %d nodes, want %d", tt.list, got, tt.size)
+				}
+			}
+		})
+	}
+}
+
+func TestCompilePatternErrors(t *testing.T) {
+	for _, pattern := range []string{"", "a(); b();", "fetch(("} {
+		if _, err := CompilePattern(pattern); err == nil {
+			t.Errorf("CompilePattern(%q) expected error", pattern)
+		}
+	}
+}
@@ -0,0 +1,209 @@
+package analyzer
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ahmadramadhannn/tsgoast/ast"
+	"github.com/ahmadramadhannn/tsgoast/internal/syntax"
+)
+
+// WriteMermaid writes the hierarchy as a Mermaid classDiagram, for
+// embedding in Markdown. Each declared class and interface is listed with
+// its fields and methods; extends edges are drawn as inheritance and
+// implements edges as realization. External types appear without members.
+func (h *TypeHierarchy) WriteMermaid(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "classDiagram")
+
+	for _, name := range h.Names() {
+		t := h.types[name]
+		id := mermaidID(name)
+		members, generics, abstract := mermaidMembers(t)
+
+		fmt.Fprintf(bw, "  class %s%s", id, generics)
+		if len(members) == 0 && t.Kind == HierarchyClass && !abstract {
+			fmt.Fprintln(bw)
+			continue
+		}
+		fmt.Fprintln(bw, " {")
+		switch {
+		case t.Kind == HierarchyInterface:
+			fmt.Fprintln(bw, "    <<interface>>")
+		case t.Kind == HierarchyExternal:
+			fmt.Fprintln(bw, "    <<external>>")
+		case abstract:
+			fmt.Fprintln(bw, "    <<abstract>>")
+		}
+		for _, member := range members {
+			fmt.Fprintf(bw, "    %s\n", member)
+		}
+		fmt.Fprintln(bw, "  }")
+	}
+
+	for _, name := range h.Names() {
+		t := h.types[name]
+		for _, super := range t.Extends {
+			fmt.Fprintf(bw, "  %s <|-- %s\n", mermaidID(super), mermaidID(name))
+		}
+		for _, super := range t.Implements {
+			fmt.Fprintf(bw, "  %s <|.. %s\n", mermaidID(super), mermaidID(name))
+		}
+	}
+
+	return bw.Flush()
+}
+
+// WriteMermaid writes the call graph as a Mermaid flowchart with an edge
+// from each caller to each function it calls.
+func (g *CallGraph) WriteMermaid(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "flowchart LR")
+
+	ids := make(map[string]string)
+	for i, name := range g.Functions() {
+		ids[name] = fmt.Sprintf("f%d", i)
+		fmt.Fprintf(bw, "  %s[%s]\n", ids[name], mermaidLabel(name))
+	}
+	for _, caller := range g.Functions() {
+		for _, callee := range g.Callees(caller) {
+			fmt.Fprintf(bw, "  %s --> %s\n", ids[caller], ids[callee])
+		}
+	}
+
+	return bw.Flush()
+}
+
+// mermaidMembers returns the member lines of a class or interface, its
+// generic suffix (e.g. "~T~") and whether it is an abstract class.
+func mermaidMembers(t *HierarchyType) ([]string, string, bool) {
+	var members []string
+	generics := ""
+	abstract := false
+	for _, decl := range t.Declarations {
+		if params := typeParametersOf(decl); len(params) > 0 && generics == "" {
+			names := make([]string, len(params))
+			for i, param := range params {
+				names[i] = strings.Fields(param)[0]
+			}
+			generics = "~" + strings.Join(names, ", ") + "~"
+		}
+		if decl.SyntaxKind() == "abstract_class_declaration" {
+			abstract = true
+		}
+
+		if decl.SyntaxKind() == "interface_declaration" {
+			properties, methods := GetInterfaceMembers(decl)
+			for _, p := range properties {
+				members = append(members, mermaidField("+", p.Type, p.Name, ""))
+			}
+			for _, m := range methods {
+				members = append(members, mermaidMethod("+", m.Name, m.Parameters, m.ReturnType, ""))
+			}
+			continue
+		}
+
+		body := ast.ChildByField(decl, "body")
+		if body == nil {
+			continue
+		}
+		for _, member := range body.Children() {
+			switch member.SyntaxKind() {
+			case "public_field_definition":
+				name := syntax.FieldText(member, "name")
+				typ := ""
+				if annotation := ast.ChildByField(member, "type"); annotation != nil {
+					typ = annotationType(annotation)
+				}
+				members = append(members, mermaidField(mermaidVisibility(member, name), typ, name, mermaidClassifier(member)))
+			case "method_definition", "abstract_method_signature":
+				sig := GetFunctionSignature(member)
+				name := syntax.FieldText(member, "name")
+				members = append(members, mermaidMethod(mermaidVisibility(member, name), name, sig.Parameters, sig.ReturnType, mermaidClassifier(member)))
+			}
+		}
+	}
+	return members, generics, abstract
+}
+
+// mermaidVisibility returns the Mermaid visibility marker of a class member.
+func mermaidVisibility(member ast.Node, name string) string {
+	if strings.HasPrefix(name, "#") {
+		return "-"
+	}
+	if modifier := ast.FirstChildOfKind(member, "accessibility_modifier"); modifier != nil {
+		switch modifier.Text() {
+		case "private":
+			return "-"
+		case "protected":
+			return "#"
+		}
+	}
+	return "+"
+}
+
+// mermaidClassifier returns "$" for static members and "*" for abstract
+// ones.
+func mermaidClassifier(member ast.Node) string {
+	switch {
+	case hasModifier(member, "static"):
+		return "$"
+	case member.SyntaxKind() == "abstract_method_signature", hasModifier(member, "abstract"):
+		return "*"
+	}
+	return ""
+}
+
+// mermaidField formats a field as "+Type name".
+func mermaidField(visibility, typ, name, classifier string) string {
+	name = strings.TrimPrefix(name, "#")
+	if typ = mermaidType(typ); typ != "" {
+		return visibility + typ + " " + name + classifier
+	}
+	return visibility + name + classifier
+}
+
+// mermaidMethod formats a method as "+name(Type param) ReturnType".
+func mermaidMethod(visibility, name string, params []*ast.Parameter, returnType, classifier string) string {
+	list := make([]string, len(params))
+	for i, p := range params {
+		list[i] = p.Name
+		if typ := mermaidType(p.Type); typ != "" {
+			list[i] = typ + " " + p.Name
+		}
+	}
+	line := visibility + strings.TrimPrefix(name, "#") + "(" + strings.Join(list, ", ") + ")"
+	if typ := mermaidType(returnType); typ != "" {
+		line += " " + typ
+	}
+	return line + classifier
+}
+
+// mermaidType converts a TypeScript type to Mermaid's notation, which
+// writes generics as List~T~. Types Mermaid cannot display in a member line,
+// such as object literal types, are dropped.
+func mermaidType(typ string) string {
+	typ = strings.Join(strings.Fields(typ), " ")
+	if strings.ContainsAny(typ, "{}()\"") {
+		return ""
+	}
+	return strings.NewReplacer("<", "~", ">", "~").Replace(typ)
+}
+
+// mermaidID returns a Mermaid identifier for a type name, replacing
+// characters Mermaid does not accept in class names.
+func mermaidID(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '_' || r == '-' || r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r > 127 {
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+// mermaidLabel quotes a flowchart node label.
+func mermaidLabel(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, "#quot;") + `"`
+}
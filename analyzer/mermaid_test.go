@@ -0,0 +1,71 @@
+package analyzer
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTypeHierarchyWriteMermaid(t *testing.T) {
+	root := parseSource(t, `
+		interface Repository<T> {
+			readonly name: string;
+			find(id: string): Promise<T>;
+		}
+		abstract class Base {
+			protected id: number;
+			abstract save(): void;
+		}
+		class UserRepo extends Base implements Repository<User> {
+			name = "users";
+			#cache: Map<string, User>;
+			private static instances: number = 0;
+			find(id: string, force?: boolean): Promise<User> { return null as any; }
+		}
+	`)
+
+	var out strings.Builder
+	if err := BuildHierarchy(root).WriteMermaid(&out); err != nil {
+		t.Fatalf("WriteMermaid() error = %v", err)
+	}
+	got := out.String()
+
+	for _, want := range []string{
+		"classDiagram\n",
+		"  class Base {\n    <<abstract>>\n    #number id\n    +save() void*\n  }\n",
+		"  class Repository~T~ {\n    <<interface>>\n    +string name\n    +find(string id) Promise~T~\n  }\n",
+		"    +name\n",
+		"    -Map~string, User~ cache\n",
+		"    -number instances$\n",
+		"    +find(string id, boolean force) Promise~User~\n",
+		"  Base <|-- UserRepo\n",
+		"  Repository <|.. UserRepo\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("WriteMermaid() output missing %q\n%s", want, got)
+		}
+	}
+}
+
+func TestCallGraphWriteMermaid(t *testing.T) {
+	root := parseSource(t, `
+		function main() { greet("world"); }
+		function greet(name: string) { format(name); }
+		function format(s: string) { return s; }
+	`)
+
+	var out strings.Builder
+	if err := BuildCallGraph(root).WriteMermaid(&out); err != nil {
+		t.Fatalf("WriteMermaid() error = %v", err)
+	}
+
+	want := `flowchart LR
+  f0["format"]
+  f1["greet"]
+  f2["main"]
+  f1 --> f0
+  f2 --> f1
+`
+	if got := out.String(); got != want {
+		t.Errorf("WriteMermaid() =\n%s\nwant\n%s", got, want)
+	}
+}
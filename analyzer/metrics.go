@@ -0,0 +1,201 @@
+package analyzer
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+// FunctionMetrics holds size and complexity metrics of a function or
+// method.
+type FunctionMetrics struct {
+	// File is the path of the analyzed file. FunctionMetrics leaves it
+	// empty; callers set it when combining metrics from several files.
+	File string
+
+	// Name is the function name as in the call graph (e.g. "Service.fetch"),
+	// or "(anonymous)".
+	Name string
+	Kind string // tree-sitter kind, e.g. "arrow_function"
+
+	Line       int // 1-based first line
+	EndLine    int // 1-based last line
+	LOC        int // lines spanned by the function
+	Parameters int
+	Complexity int // cyclomatic complexity, see CyclomaticComplexity
+
+	IsAsync    bool
+	IsExported bool
+
+	Node  ast.Node
+	Range ast.Range
+}
+
+// FileMetrics holds the metrics of a file, aggregated over its functions.
+type FileMetrics struct {
+	// File is the path of the analyzed file, set by the caller.
+	File string
+
+	LOC        int // lines spanned by the source
+	Functions  int // functions and methods, including anonymous ones
+	Classes    int
+	Interfaces int
+	Imports    int
+	Exports    int
+
+	Complexity    int // sum of the complexity of every function
+	MaxComplexity int
+}
+
+// CyclomaticComplexity returns the cyclomatic complexity of a function: one
+// plus the number of decision points in its body. Decision points are if
+// statements, loops, case clauses, catch clauses, conditional expressions
+// and the short-circuit operators &&, || and ?? (including their
+// assignment forms). Nested functions are not counted; they have their own
+// complexity.
+func CyclomaticComplexity(fn ast.Node) int {
+	complexity := 1
+	visitSubtree(fn, func(node ast.Node) bool {
+		if node != fn && functionKinds[node.SyntaxKind()] {
+			return false
+		}
+		switch node.SyntaxKind() {
+		case "if_statement", "for_statement", "for_in_statement", "while_statement",
+			"do_statement", "switch_case", "catch_clause", "ternary_expression":
+			complexity++
+		case "binary_expression", "augmented_assignment_expression":
+			if operator := ast.ChildByField(node, "operator"); operator != nil {
+				switch operator.Text() {
+				case "&&", "||", "??", "&&=", "||=", "??=":
+					complexity++
+				}
+			}
+		}
+		return true
+	})
+	return complexity
+}
+
+// FunctionMetrics computes the metrics of every function, method and arrow
+// function in the AST, in source order. Class static blocks are not
+// included.
+func (a *Analyzer) FunctionMetrics() []FunctionMetrics {
+	var metrics []FunctionMetrics
+	a.Visit(func(node ast.Node) bool {
+		if !functionKinds[node.SyntaxKind()] || node.SyntaxKind() == "class_static_block" {
+			return true
+		}
+		name := callGraphName(node)
+		if name == "" {
+			name = "(anonymous)"
+		}
+		r := node.Range()
+		metrics = append(metrics, FunctionMetrics{
+			Name:       name,
+			Kind:       node.SyntaxKind(),
+			Line:       int(r.Start.Line) + 1,
+			EndLine:    int(r.End.Line) + 1,
+			LOC:        int(r.End.Line-r.Start.Line) + 1,
+			Parameters: len(GetParameters(node)),
+			Complexity: CyclomaticComplexity(node),
+			IsAsync:    hasModifier(node, "async"),
+			IsExported: isExportedFunction(node),
+			Node:       node,
+			Range:      r,
+		})
+		return true
+	})
+	return metrics
+}
+
+// FileMetrics computes the metrics of the file.
+func (a *Analyzer) FileMetrics() FileMetrics {
+	summary := a.Summary()
+	metrics := FileMetrics{
+		LOC:        summary.Lines,
+		Classes:    summary.Classes,
+		Interfaces: summary.Interfaces,
+		Imports:    summary.Imports,
+		Exports:    summary.Exports,
+	}
+	for _, fn := range a.FunctionMetrics() {
+		metrics.Functions++
+		metrics.Complexity += fn.Complexity
+		metrics.MaxComplexity = max(metrics.MaxComplexity, fn.Complexity)
+	}
+	return metrics
+}
+
+// isExportedFunction reports whether a function is exported directly, as
+// the value of an exported variable, or as a method of an exported class.
+func isExportedFunction(fn ast.Node) bool {
+	decl := fn
+	switch parent := fn.Parent(); {
+	case parent == nil:
+		return false
+	case parent.SyntaxKind() == "variable_declarator":
+		decl = parent.Parent()
+	case parent.SyntaxKind() == "class_body":
+		decl = parent.Parent()
+	}
+	if decl == nil || decl.Parent() == nil {
+		return false
+	}
+	return decl.Parent().SyntaxKind() == "export_statement"
+}
+
+// WriteFunctionMetricsCSV writes one row per function, preceded by a header
+// row. comma is the field separator: ',' (or 0) for CSV, '\t' for TSV.
+func WriteFunctionMetricsCSV(w io.Writer, metrics []FunctionMetrics, comma rune) error {
+	cw := newMetricsWriter(w, comma)
+	cw.Write([]string{"file", "name", "kind", "line", "end_line", "loc", "params", "complexity", "async", "exported"})
+	for _, m := range metrics {
+		cw.Write([]string{
+			m.File,
+			m.Name,
+			m.Kind,
+			strconv.Itoa(m.Line),
+			strconv.Itoa(m.EndLine),
+			strconv.Itoa(m.LOC),
+			strconv.Itoa(m.Parameters),
+			strconv.Itoa(m.Complexity),
+			strconv.FormatBool(m.IsAsync),
+			strconv.FormatBool(m.IsExported),
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteFileMetricsCSV writes one row per file, preceded by a header row.
+// comma is the field separator: ',' (or 0) for CSV, '\t' for TSV.
+func WriteFileMetricsCSV(w io.Writer, metrics []FileMetrics, comma rune) error {
+	cw := newMetricsWriter(w, comma)
+	cw.Write([]string{"file", "loc", "functions", "classes", "interfaces", "imports", "exports", "complexity", "max_complexity"})
+	for _, m := range metrics {
+		cw.Write([]string{
+			m.File,
+			strconv.Itoa(m.LOC),
+			strconv.Itoa(m.Functions),
+			strconv.Itoa(m.Classes),
+			strconv.Itoa(m.Interfaces),
+			strconv.Itoa(m.Imports),
+			strconv.Itoa(m.Exports),
+			strconv.Itoa(m.Complexity),
+			strconv.Itoa(m.MaxComplexity),
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// newMetricsWriter returns a CSV writer using comma as separator.
+func newMetricsWriter(w io.Writer, comma rune) *csv.Writer {
+	cw := csv.NewWriter(w)
+	if comma != 0 {
+		cw.Comma = comma
+	}
+	return cw
+}
@@ -0,0 +1,90 @@
+package analyzer
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFunctionMetrics(t *testing.T) {
+	root := parseSource(t, `import { db } from "./db";
+
+export async function load(id: string, force = false) {
+	if (!id || force) {
+		return null;
+	}
+	for (const row of db.rows) {
+		try { check(row); } catch (e) { return e; }
+	}
+	return db.items.map((x) => x ?? 0);
+}
+
+export class Store {
+	get(key: string) {
+		switch (key) {
+		case "a": return 1;
+		case "b": return 2;
+		default: return key ? 3 : 4;
+		}
+	}
+}
+`)
+
+	metrics := New(root).FunctionMetrics()
+
+	want := []struct {
+		name       string
+		line, loc  int
+		params     int
+		complexity int
+		async      bool
+		exported   bool
+	}{
+		{"load", 3, 9, 2, 5, true, true},
+		{"(anonymous)", 10, 1, 1, 2, false, false},
+		{"Store.get", 14, 7, 1, 4, false, true},
+	}
+	if len(metrics) != len(want) {
+		t.Fatalf("FunctionMetrics() returned %d functions, want %d", len(metrics), len(want))
+	}
+	for i, w := range want {
+		m := metrics[i]
+		if m.Name != w.name || m.Line != w.line || m.LOC != w.loc || m.Parameters != w.params ||
+			m.Complexity != w.complexity || m.IsAsync != w.async || m.IsExported != w.exported {
+			t.Errorf("metrics[%d] = %+v, want %+v", i, m, w)
+		}
+	}
+
+	file := New(root).FileMetrics()
+	if file.Functions != 3 || file.Complexity != 11 || file.MaxComplexity != 5 || file.Imports != 1 || file.Exports != 2 || file.Classes != 1 {
+		t.Errorf("FileMetrics() = %+v", file)
+	}
+}
+
+func TestWriteMetricsCSV(t *testing.T) {
+	functions := []FunctionMetrics{
+		{File: "a.ts", Name: "load", Kind: "function_declaration", Line: 1, EndLine: 3, LOC: 3, Parameters: 1, Complexity: 2, IsExported: true},
+		{File: "a,b.ts", Name: "(anonymous)", Kind: "arrow_function", Line: 2, EndLine: 2, LOC: 1, Complexity: 1},
+	}
+
+	var csvOut strings.Builder
+	if err := WriteFunctionMetricsCSV(&csvOut, functions, 0); err != nil {
+		t.Fatalf("WriteFunctionMetricsCSV() error = %v", err)
+	}
+	want := `file,name,kind,line,end_line,loc,params,complexity,async,exported
+a.ts,load,function_declaration,1,3,3,1,2,false,true
+"a,b.ts",(anonymous),arrow_function,2,2,1,0,1,false,false
+`
+	if got := csvOut.String(); got != want {
+		t.Errorf("WriteFunctionMetricsCSV() =\n%s\nwant\n%s", got, want)
+	}
+
+	var tsvOut strings.Builder
+	if err := WriteFileMetricsCSV(&tsvOut, []FileMetrics{{File: "a.ts", LOC: 10, Functions: 2, Complexity: 3, MaxComplexity: 2}}, '\t'); err != nil {
+		t.Fatalf("WriteFileMetricsCSV() error = %v", err)
+	}
+	want = "file\tloc\tfunctions\tclasses\tinterfaces\timports\texports\tcomplexity\tmax_complexity\n" +
+		"a.ts\t10\t2\t0\t0\t0\t0\t3\t2\n"
+	if got := tsvOut.String(); got != want {
+		t.Errorf("WriteFileMetricsCSV() =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,89 @@
+package analyzer
+
+import (
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+// NodeList is a list of nodes returned by the Find* methods. Its methods
+// can be chained so that common queries read as one expression:
+//
+//	names := a.FindAsyncFunctions().Filter(IsExported).Strings(GetFunctionName)
+//
+// A NodeList is an ordinary slice and can be ranged over, indexed and
+// passed wherever a []ast.Node is expected.
+type NodeList []ast.Node
+
+// Len returns the number of nodes in the list.
+func (l NodeList) Len() int {
+	return len(l)
+}
+
+// IsEmpty reports whether the list contains no nodes.
+func (l NodeList) IsEmpty() bool {
+	return len(l) == 0
+}
+
+// Filter returns the nodes matching predicate, preserving their order.
+func (l NodeList) Filter(predicate func(node ast.Node) bool) NodeList {
+	var result NodeList
+	for _, node := range l {
+		if predicate(node) {
+			result = append(result, node)
+		}
+	}
+	return result
+}
+
+// Map replaces each node with the node returned by fn, for example its
+// name or body. Nil results are dropped.
+func (l NodeList) Map(fn func(node ast.Node) ast.Node) NodeList {
+	var result NodeList
+	for _, node := range l {
+		if mapped := fn(node); mapped != nil {
+			result = append(result, mapped)
+		}
+	}
+	return result
+}
+
+// First returns the first node in the list, or nil if it is empty.
+func (l NodeList) First() ast.Node {
+	if len(l) == 0 {
+		return nil
+	}
+	return l[0]
+}
+
+// Last returns the last node in the list, or nil if it is empty.
+func (l NodeList) Last() ast.Node {
+	if len(l) == 0 {
+		return nil
+	}
+	return l[len(l)-1]
+}
+
+// Texts returns the source text of each node.
+func (l NodeList) Texts() []string {
+	return l.Strings(ast.Node.Text)
+}
+
+// Strings returns the result of fn for each node, skipping empty strings.
+// It is typically used with helpers such as GetFunctionName.
+func (l NodeList) Strings(fn func(node ast.Node) string) []string {
+	var result []string
+	for _, node := range l {
+		if s := fn(node); s != "" {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+// Ranges returns the source range of each node.
+func (l NodeList) Ranges() []ast.Range {
+	ranges := make([]ast.Range, len(l))
+	for i, node := range l {
+		ranges[i] = node.Range()
+	}
+	return ranges
+}
@@ -0,0 +1,40 @@
+package analyzer
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+func TestNodeList(t *testing.T) {
+	a := New(parseSource(t, `
+async function helper() {}
+export async function load() {}
+export function sync() {}
+export const fetchAll = async () => {};
+`))
+
+	names := a.FindAsyncFunctions().Filter(IsExported).Strings(GetFunctionName)
+	if want := []string{"load", "fetchAll"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("exported async names = %v, want %v", names, want)
+	}
+
+	fnNames := a.FindNodesByType(ast.NodeTypeFunction).Map(func(n ast.Node) ast.Node {
+		return ast.ChildByField(n, "name")
+	})
+	if want := []string{"helper", "load", "sync"}; !reflect.DeepEqual(fnNames.Texts(), want) {
+		t.Errorf("Map(name).Texts() = %v, want %v", fnNames.Texts(), want)
+	}
+	if got := fnNames.Ranges(); len(got) != 3 || got[1].Start.Line != 2 {
+		t.Errorf("Ranges() = %v", got)
+	}
+	if fnNames.First().Text() != "helper" || fnNames.Last().Text() != "sync" || fnNames.Len() != 3 {
+		t.Errorf("First/Last/Len = %v, %v, %d", fnNames.First(), fnNames.Last(), fnNames.Len())
+	}
+
+	empty := a.FindInterfaces()
+	if !empty.IsEmpty() || empty.First() != nil || empty.Last() != nil || empty.Filter(IsExported) != nil {
+		t.Errorf("empty list = %v", empty)
+	}
+}
@@ -0,0 +1,168 @@
+package analyzer
+
+import (
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+// Order is a traversal order for Visit and FindNodes.
+type Order int
+
+// Traversal orders.
+const (
+	// PreOrder visits a node before its children (the default).
+	PreOrder Order = iota
+
+	// PostOrder visits the children of a node before the node itself, as
+	// needed to compute metrics bottom-up. The visitor's return value is
+	// ignored, since the children have already been visited.
+	PostOrder
+
+	// BreadthFirst visits nodes level by level. Returning false from the
+	// visitor skips the node's children.
+	BreadthFirst
+)
+
+// VisitOption configures a traversal.
+type VisitOption func(*visitConfig)
+
+type visitConfig struct {
+	order    Order
+	maxDepth int // negative for no limit
+	capacity int // expected number of results
+}
+
+// WithOrder selects the traversal order.
+func WithOrder(order Order) VisitOption {
+	return func(c *visitConfig) {
+		c.order = order
+	}
+}
+
+// WithMaxDepth limits the traversal to nodes at most depth levels below
+// the node the traversal starts from: 0 visits only that node, 1 also its
+// children, and so on. Deeper nodes are not visited at all, so expensive
+// predicates are never evaluated on them.
+func WithMaxDepth(depth int) VisitOption {
+	return func(c *visitConfig) {
+		c.maxDepth = depth
+	}
+}
+
+// WithCapacity sizes the result of FindNodes and its variants for n
+// nodes up front, saving the reallocations of growing it when the number
+// of matches can be estimated, such as from an earlier run.
+func WithCapacity(n int) VisitOption {
+	return func(c *visitConfig) {
+		c.capacity = n
+	}
+}
+
+// newVisitConfig applies opts to the default configuration.
+func newVisitConfig(opts []VisitOption) visitConfig {
+	if len(opts) == 0 {
+		// Spare the allocation of c, which escapes to the options
+		return visitConfig{maxDepth: -1}
+	}
+	c := visitConfig{maxDepth: -1}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return c
+}
+
+// traverse visits the subtree rooted at node in the configured order.
+func (c visitConfig) traverse(node ast.Node, visitor func(ast.Node) bool) {
+	switch c.order {
+	case PostOrder:
+		visitPostOrder(node, c.maxDepth, visitor)
+	case BreadthFirst:
+		visitBreadthFirst(node, c.maxDepth, visitor)
+	default:
+		if c.maxDepth < 0 {
+			visitSubtree(node, visitor)
+			return
+		}
+		visitPreOrderLimited(node, c.maxDepth, visitor)
+	}
+}
+
+// visitPreOrderLimited visits the subtree rooted at node in pre-order,
+// skipping nodes more than maxDepth levels below it.
+func visitPreOrderLimited(node ast.Node, maxDepth int, visitor func(ast.Node) bool) {
+	if node == nil {
+		return
+	}
+
+	type frame struct {
+		node  ast.Node
+		depth int
+	}
+	stack := []frame{{node: node}}
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if !visitor(current.node) || current.depth == maxDepth {
+			continue
+		}
+
+		children := current.node.Children()
+		for i := len(children) - 1; i >= 0; i-- {
+			if children[i] != nil {
+				stack = append(stack, frame{node: children[i], depth: current.depth + 1})
+			}
+		}
+	}
+}
+
+// visitPostOrder visits children before their parent, using an explicit
+// stack. Nodes more than maxDepth levels below node are skipped unless
+// maxDepth is negative.
+func visitPostOrder(node ast.Node, maxDepth int, visitor func(ast.Node) bool) {
+	if node == nil {
+		return
+	}
+
+	type frame struct {
+		node ast.Node
+		next int // index of the next child to visit
+	}
+	stack := []frame{{node: node}}
+	for len(stack) > 0 {
+		top := &stack[len(stack)-1]
+		children := top.node.Children()
+		if top.next < len(children) && (maxDepth < 0 || len(stack) <= maxDepth) {
+			child := children[top.next]
+			top.next++
+			if child != nil {
+				stack = append(stack, frame{node: child})
+			}
+			continue
+		}
+		visitor(top.node)
+		stack = stack[:len(stack)-1]
+	}
+}
+
+// visitBreadthFirst visits nodes level by level, stopping after level
+// maxDepth unless it is negative.
+func visitBreadthFirst(node ast.Node, maxDepth int, visitor func(ast.Node) bool) {
+	if node == nil {
+		return
+	}
+
+	level := []ast.Node{node}
+	for depth := 0; len(level) > 0; depth++ {
+		var next []ast.Node
+		for _, current := range level {
+			if !visitor(current) || depth == maxDepth {
+				continue
+			}
+			for _, child := range current.Children() {
+				if child != nil {
+					next = append(next, child)
+				}
+			}
+		}
+		level = next
+	}
+}
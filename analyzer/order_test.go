@@ -0,0 +1,110 @@
+package analyzer
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+// orderTree builds: root -> (a -> (a1, a2), b -> (b1))
+func orderTree() *ast.BaseNode {
+	node := func(name string, children ...*ast.BaseNode) *ast.BaseNode {
+		n := &ast.BaseNode{Content: name}
+		for _, child := range children {
+			child.ParentNode = n
+			n.ChildNodes = append(n.ChildNodes, child)
+		}
+		return n
+	}
+	return node("root",
+		node("a", node("a1"), node("a2")),
+		node("b", node("b1")),
+	)
+}
+
+func TestVisitOrders(t *testing.T) {
+	a := New(orderTree())
+
+	tests := []struct {
+		name string
+		opts []VisitOption
+		want []string
+	}{
+		{"default", nil, []string{"root", "a", "a1", "a2", "b", "b1"}},
+		{"pre-order", []VisitOption{WithOrder(PreOrder)}, []string{"root", "a", "a1", "a2", "b", "b1"}},
+		{"post-order", []VisitOption{WithOrder(PostOrder)}, []string{"a1", "a2", "a", "b1", "b", "root"}},
+		{"breadth-first", []VisitOption{WithOrder(BreadthFirst)}, []string{"root", "a", "b", "a1", "a2", "b1"}},
+		{"max depth 0", []VisitOption{WithMaxDepth(0)}, []string{"root"}},
+		{"max depth 1", []VisitOption{WithMaxDepth(1)}, []string{"root", "a", "b"}},
+		{"post-order max depth 1", []VisitOption{WithOrder(PostOrder), WithMaxDepth(1)}, []string{"a", "b", "root"}},
+		{"breadth-first max depth 1", []VisitOption{WithOrder(BreadthFirst), WithMaxDepth(1)}, []string{"root", "a", "b"}},
+		{"max depth 5", []VisitOption{WithMaxDepth(5)}, []string{"root", "a", "a1", "a2", "b", "b1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			a.Visit(func(node ast.Node) bool {
+				got = append(got, node.Text())
+				return true
+			}, tt.opts...)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Visit() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVisitBreadthFirstSkip(t *testing.T) {
+	var got []string
+	New(orderTree()).Visit(func(node ast.Node) bool {
+		got = append(got, node.Text())
+		return node.Text() != "a"
+	}, WithOrder(BreadthFirst))
+
+	want := []string{"root", "a", "b", "b1"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Visit() = %v, want %v", got, want)
+	}
+}
+
+func TestFindNodesPostOrder(t *testing.T) {
+	a := New(parseSource(t, `function outer() { function inner() {} }`))
+
+	fns := a.FindNodes(func(node ast.Node) bool {
+		return node.SyntaxKind() == "function_declaration"
+	}, WithOrder(PostOrder))
+	if len(fns) != 2 || declarationName(fns[0]) != "inner" || declarationName(fns[1]) != "outer" {
+		t.Errorf("FindNodes(PostOrder) should return inner before outer")
+	}
+}
+
+func TestFindNodesIn(t *testing.T) {
+	a := New(parseSource(t, `
+function first() { const x = call(); }
+function second() { if (ok) { other(); } }
+`))
+
+	isCall := func(node ast.Node) bool { return node.SyntaxKind() == "call_expression" }
+	second := a.FindNodes(func(node ast.Node) bool {
+		return node.SyntaxKind() == "function_declaration" && declarationName(node) == "second"
+	}).First()
+	body := ast.ChildByField(second, "body")
+
+	if got := FindNodesIn(body, isCall).Texts(); !reflect.DeepEqual(got, []string{"other()"}) {
+		t.Errorf("FindNodesIn(second body) = %v, want [other()]", got)
+	}
+	if got := FindNodesIn(body, isCall, WithMaxDepth(2)); len(got) != 0 {
+		t.Errorf("FindNodesIn(WithMaxDepth(2)) = %v, want none", got.Texts())
+	}
+
+	statements := a.FindNodes(func(node ast.Node) bool {
+		return node.SyntaxKind() == "function_declaration"
+	}, WithMaxDepth(1))
+	if len(statements) != 2 {
+		t.Errorf("FindNodes(WithMaxDepth(1)) found %d functions, want 2", len(statements))
+	}
+	if got := a.FindNodesByType(ast.NodeTypeIdentifier, WithMaxDepth(1)); len(got) != 0 {
+		t.Errorf("FindNodesByType(WithMaxDepth(1)) = %v, want none", got.Texts())
+	}
+}
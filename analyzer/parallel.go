@@ -0,0 +1,81 @@
+package analyzer
+
+import (
+	"runtime"
+	"sync"
+
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+// VisitParallel traverses the AST like Visit, distributing the top-level
+// subtrees (the children of the root) across workers goroutines. The root
+// itself is visited first, on the calling goroutine. fn must be safe for
+// concurrent use; nodes of one subtree are visited in pre-order, but
+// subtrees are visited in no particular order. A workers value of zero or
+// less uses GOMAXPROCS.
+func (a *Analyzer) VisitParallel(workers int, fn func(node ast.Node) bool) {
+	MapParallel(a, workers, func(node ast.Node) (struct{}, bool, bool) {
+		return struct{}{}, false, fn(node)
+	})
+}
+
+// FindNodesParallel finds all nodes matching predicate using VisitParallel.
+// The result is in the same order FindNodes would return.
+func (a *Analyzer) FindNodesParallel(workers int, predicate func(node ast.Node) bool) NodeList {
+	return MapParallel(a, workers, func(node ast.Node) (ast.Node, bool, bool) {
+		return node, predicate(node), true
+	})
+}
+
+// MapParallel traverses the AST in parallel like VisitParallel and merges
+// the values produced by fn in document order, as if the tree had been
+// visited sequentially. fn returns the value, whether to keep it, and
+// whether to descend into the node's children.
+func MapParallel[T any](a *Analyzer, workers int, fn func(node ast.Node) (value T, keep, descend bool)) []T {
+	if a.root == nil {
+		return nil
+	}
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	var results []T
+	value, keep, descend := fn(a.root)
+	if keep {
+		results = append(results, value)
+	}
+	if !descend {
+		return results
+	}
+
+	subtrees := a.root.Children()
+	partial := make([][]T, len(subtrees))
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers && w < len(subtrees); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				visitSubtree(subtrees[i], func(node ast.Node) bool {
+					value, keep, descend := fn(node)
+					if keep {
+						partial[i] = append(partial[i], value)
+					}
+					return descend
+				})
+			}
+		}()
+	}
+	for i := range subtrees {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	for _, p := range partial {
+		results = append(results, p...)
+	}
+	return results
+}
@@ -0,0 +1,82 @@
+package analyzer
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+// parallelSource generates a file with many top-level functions.
+func parallelSource(n int) string {
+	var b strings.Builder
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&b, "function f%d(a: number) { return g(a) + %d; }\n", i, i)
+	}
+	return b.String()
+}
+
+func TestVisitParallel(t *testing.T) {
+	a := New(parseSource(t, parallelSource(200)))
+
+	sequential := 0
+	a.Visit(func(ast.Node) bool {
+		sequential++
+		return true
+	})
+
+	var parallel int64
+	a.VisitParallel(4, func(ast.Node) bool {
+		atomic.AddInt64(&parallel, 1)
+		return true
+	})
+	if int(parallel) != sequential {
+		t.Errorf("VisitParallel() visited %d nodes, Visit visited %d", parallel, sequential)
+	}
+
+	// Returning false skips subtrees
+	var functions int64
+	a.VisitParallel(0, func(node ast.Node) bool {
+		if node.SyntaxKind() == "function_declaration" {
+			atomic.AddInt64(&functions, 1)
+			return false
+		}
+		return node.SyntaxKind() == "program"
+	})
+	if functions != 200 {
+		t.Errorf("VisitParallel() saw %d functions, want 200", functions)
+	}
+}
+
+func TestFindNodesParallelOrder(t *testing.T) {
+	a := New(parseSource(t, parallelSource(100)))
+
+	isCall := func(node ast.Node) bool { return node.SyntaxKind() == "call_expression" }
+	want := a.FindNodes(isCall)
+	got := a.FindNodesParallel(8, isCall)
+
+	if len(got) != len(want) {
+		t.Fatalf("FindNodesParallel() found %d nodes, FindNodes found %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("node %d differs: %v vs %v", i, got[i].Range(), want[i].Range())
+		}
+	}
+
+	names := MapParallel(a, 3, func(node ast.Node) (string, bool, bool) {
+		if node.SyntaxKind() == "function_declaration" {
+			return declarationName(node), true, false
+		}
+		return "", false, true
+	})
+	if len(names) != 100 || names[0] != "f0" || names[99] != "f99" {
+		t.Errorf("MapParallel() returned %d names starting %v", len(names), names[:1])
+	}
+
+	if got := New(nil).FindNodesParallel(2, isCall); got != nil {
+		t.Errorf("FindNodesParallel() on an empty analyzer = %v, want nil", got)
+	}
+}
@@ -0,0 +1,115 @@
+package analyzer
+
+import (
+	"strings"
+
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+// FloatingPromise represents a call to a known-async function whose result
+// is discarded: it is neither awaited, returned, assigned, nor chained with
+// .then/.catch.
+type FloatingPromise struct {
+	Call   ast.Node // the call_expression
+	Callee string   // name of the called function or method
+	Range  ast.Range
+}
+
+// FindFloatingPromises finds calls to functions known to return a promise
+// whose result is dropped on the floor.
+//
+// A function is known to be async when it is declared in the same file
+// with the async modifier or with a Promise<...> return type. Calls are
+// matched by name, so methods are matched by their property name
+// regardless of the receiver. Results explicitly discarded with `void`
+// are not reported.
+func (a *Analyzer) FindFloatingPromises() []FloatingPromise {
+	asyncNames := a.asyncFunctionNames()
+	if len(asyncNames) == 0 {
+		return nil
+	}
+
+	var floating []FloatingPromise
+	a.Visit(func(node ast.Node) bool {
+		if node.SyntaxKind() != "call_expression" {
+			return true
+		}
+
+		callee := calleeName(node)
+		if !asyncNames[callee] || !isDiscarded(node) {
+			return true
+		}
+
+		floating = append(floating, FloatingPromise{
+			Call:   node,
+			Callee: callee,
+			Range:  node.Range(),
+		})
+		return true
+	})
+	return floating
+}
+
+// asyncFunctionNames collects the names of functions and methods declared
+// async or returning a Promise.
+func (a *Analyzer) asyncFunctionNames() map[string]bool {
+	names := make(map[string]bool)
+	a.Visit(func(node ast.Node) bool {
+		var name string
+		switch node.SyntaxKind() {
+		case "function_declaration", "arrow_function", "function_expression":
+			name = GetFunctionName(node)
+		case "method_definition":
+			if nameNode := ast.FirstChildOfKind(node, "property_identifier"); nameNode != nil {
+				name = nameNode.Text()
+			}
+		default:
+			return true
+		}
+
+		if name != "" && (hasModifier(node, "async") || returnsPromise(node)) {
+			names[name] = true
+		}
+		return true
+	})
+	return names
+}
+
+// returnsPromise checks if a function's return type annotation is a Promise.
+func returnsPromise(node ast.Node) bool {
+	annotation := ast.FirstChildOfKind(node, "type_annotation")
+	if annotation == nil {
+		return false
+	}
+	returnType := strings.TrimSpace(strings.TrimPrefix(annotation.Text(), ":"))
+	return strings.HasPrefix(returnType, "Promise<")
+}
+
+// calleeName returns the name of the function invoked by a call expression:
+// the identifier for `f()` or the property name for `obj.f()`.
+func calleeName(call ast.Node) string {
+	callee := ast.ChildByField(call, "function")
+	if callee == nil {
+		return ""
+	}
+
+	switch callee.SyntaxKind() {
+	case "identifier":
+		return callee.Text()
+	case "member_expression":
+		if property := ast.ChildByField(callee, "property"); property != nil {
+			return property.Text()
+		}
+	}
+	return ""
+}
+
+// isDiscarded checks if the value of an expression is thrown away, i.e. the
+// expression (ignoring parentheses) forms a whole expression statement.
+func isDiscarded(node ast.Node) bool {
+	parent := node.Parent()
+	for parent != nil && parent.SyntaxKind() == "parenthesized_expression" {
+		parent = parent.Parent()
+	}
+	return parent != nil && parent.SyntaxKind() == "expression_statement"
+}
@@ -0,0 +1,60 @@
+package analyzer
+
+import (
+	"testing"
+)
+
+func TestFindFloatingPromises(t *testing.T) {
+	root := parseSource(t, `
+		async function save() {}
+		function load(): Promise<string> { return fetch("/"); }
+		const refresh = async () => {};
+		function sync() {}
+
+		class Store {
+			async flush() {}
+			run() {
+				this.flush();
+				void this.flush();
+			}
+		}
+
+		async function main() {
+			save();
+			await save();
+			(load());
+			load().then(console.log);
+			const pending = refresh();
+			refresh();
+			sync();
+			return load();
+		}
+	`)
+
+	floating := New(root).FindFloatingPromises()
+
+	want := []string{"flush", "save", "load", "refresh"}
+	if len(floating) != len(want) {
+		for _, f := range floating {
+			t.Logf("floating: %s", f.Call.Text())
+		}
+		t.Fatalf("FindFloatingPromises() found %d, want %d", len(floating), len(want))
+	}
+
+	for i, callee := range want {
+		if floating[i].Callee != callee {
+			t.Errorf("floating %d callee = %q, want %q", i, floating[i].Callee, callee)
+		}
+	}
+}
+
+func TestFindFloatingPromisesNoAsync(t *testing.T) {
+	root := parseSource(t, `
+		function work() {}
+		work();
+	`)
+
+	if got := New(root).FindFloatingPromises(); len(got) != 0 {
+		t.Errorf("FindFloatingPromises() found %d, want 0", len(got))
+	}
+}
@@ -0,0 +1,231 @@
+package analyzer
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+// ReactComponentKind distinguishes function and class components.
+type ReactComponentKind string
+
+// React component kind constants.
+const (
+	ReactFunctionComponent ReactComponentKind = "function"
+	ReactClassComponent    ReactComponentKind = "class"
+)
+
+// reactComponentBases are the superclasses of React class components.
+var reactComponentBases = map[string]bool{
+	"React.Component":     true,
+	"React.PureComponent": true,
+	"Component":           true,
+	"PureComponent":       true,
+}
+
+// reactComponentWrappers are the higher-order functions whose argument is
+// still considered the component itself.
+var reactComponentWrappers = map[string]bool{
+	"React.memo":       true,
+	"React.forwardRef": true,
+	"memo":             true,
+	"forwardRef":       true,
+}
+
+// ReactComponent represents a React component declaration.
+type ReactComponent struct {
+	Name      string
+	Kind      ReactComponentKind
+	PropsType string // empty when the props are untyped
+	Node      ast.Node
+	Range     ast.Range
+}
+
+// FindReactComponents finds React function components (PascalCase functions
+// and arrow functions returning JSX) and class components (classes extending
+// React.Component or React.PureComponent).
+//
+// JSX is only recognized in trees produced by a TSX parser (tsgoast.NewTSX).
+func (a *Analyzer) FindReactComponents() []ReactComponent {
+	var components []ReactComponent
+	a.Visit(func(node ast.Node) bool {
+		switch node.SyntaxKind() {
+		case "function_declaration", "function_expression", "arrow_function":
+			name := componentName(node)
+			if !isPascalCase(name) || !returnsJSX(node) {
+				return true
+			}
+			components = append(components, ReactComponent{
+				Name:      name,
+				Kind:      ReactFunctionComponent,
+				PropsType: functionPropsType(node),
+				Node:      node,
+				Range:     node.Range(),
+			})
+		case "class_declaration":
+			heritage := GetHeritage(node)
+			if len(heritage.Extends) == 0 || !reactComponentBases[heritage.Extends[0].Name] {
+				return true
+			}
+			component := ReactComponent{
+				Kind:  ReactClassComponent,
+				Node:  node,
+				Range: node.Range(),
+			}
+			if name := ast.FirstChildOfKind(node, "type_identifier"); name != nil {
+				component.Name = name.Text()
+			}
+			if args := heritage.Extends[0].TypeArguments; len(args) > 0 {
+				component.PropsType = args[0].Text
+			}
+			components = append(components, component)
+		}
+		return true
+	})
+	return components
+}
+
+// componentName returns the name a function component is declared under:
+// its own name, the variable it is assigned to, or the variable a
+// memo/forwardRef wrapper around it is assigned to.
+func componentName(fn ast.Node) string {
+	if name := ast.FirstChildOfKind(fn, "identifier"); name != nil && fn.SyntaxKind() != "arrow_function" {
+		return name.Text()
+	}
+
+	parent := fn.Parent()
+	if parent != nil && parent.SyntaxKind() == "arguments" {
+		call := parent.Parent()
+		if call == nil || call.SyntaxKind() != "call_expression" ||
+			!reactComponentWrappers[CalleePath(call.Children()[0])] {
+			return ""
+		}
+		parent = call.Parent()
+	}
+
+	if parent != nil && parent.SyntaxKind() == "variable_declarator" {
+		if name := ast.FirstChildOfKind(parent, "identifier"); name != nil {
+			return name.Text()
+		}
+	}
+	return ""
+}
+
+// returnsJSX checks if a function has a JSX expression body or returns JSX
+// from its own body (nested functions are ignored).
+func returnsJSX(fn ast.Node) bool {
+	children := fn.Children()
+	if len(children) == 0 {
+		return false
+	}
+
+	body := children[len(children)-1]
+	if body.SyntaxKind() != "statement_block" {
+		return isJSX(unwrapParentheses(body))
+	}
+
+	found := false
+	visitSubtree(body, func(node ast.Node) bool {
+		if found || functionKinds[node.SyntaxKind()] {
+			return false
+		}
+		if node.SyntaxKind() == "return_statement" {
+			for _, child := range node.Children() {
+				if isJSX(unwrapParentheses(child)) {
+					found = true
+				}
+			}
+		}
+		return true
+	})
+	return found
+}
+
+// isJSX checks if a node is a JSX element or fragment.
+func isJSX(node ast.Node) bool {
+	if node == nil {
+		return false
+	}
+	switch node.SyntaxKind() {
+	case "jsx_element", "jsx_self_closing_element", "jsx_fragment":
+		return true
+	}
+	return false
+}
+
+// functionPropsType returns the props type of a function component: the
+// annotation of its first parameter or, failing that, the type argument
+// of a React.FC<Props>-style variable annotation.
+func functionPropsType(fn ast.Node) string {
+	if param := firstParameter(fn); param != nil {
+		if annotation := ast.FirstChildOfKind(param, "type_annotation"); annotation != nil {
+			return annotationType(annotation)
+		}
+	}
+
+	if declarator := fn.Parent(); declarator != nil && declarator.SyntaxKind() == "variable_declarator" {
+		if annotation := ast.FirstChildOfKind(declarator, "type_annotation"); annotation != nil {
+			if args := typeArguments(annotation); len(args) > 0 {
+				return args[0]
+			}
+		}
+	}
+	return ""
+}
+
+// firstParameter returns the first declared parameter of a function,
+// or nil if it has none.
+func firstParameter(fn ast.Node) ast.Node {
+	params := ast.FirstChildOfKind(fn, "formal_parameters")
+	if params == nil {
+		return nil
+	}
+	for _, param := range params.Children() {
+		switch param.SyntaxKind() {
+		case "required_parameter", "optional_parameter":
+			return param
+		}
+	}
+	return nil
+}
+
+// annotationType returns the type text of a type_annotation node.
+func annotationType(annotation ast.Node) string {
+	return strings.TrimSpace(strings.TrimPrefix(annotation.Text(), ":"))
+}
+
+// typeArguments returns the type arguments of the first generic type found
+// in node, e.g. ["Props"] for `React.FC<Props>`.
+func typeArguments(node ast.Node) []string {
+	for _, child := range node.Children() {
+		if child.SyntaxKind() == "type_arguments" {
+			return typeArgumentList(child)
+		}
+		if args := typeArguments(child); args != nil {
+			return args
+		}
+	}
+	return nil
+}
+
+// typeArgumentList returns the texts of the types in a type_arguments node.
+func typeArgumentList(node ast.Node) []string {
+	var args []string
+	for _, child := range node.Children() {
+		switch child.SyntaxKind() {
+		case "<", ">", ",":
+		default:
+			args = append(args, child.Text())
+		}
+	}
+	return args
+}
+
+// isPascalCase checks if a name starts with an uppercase letter.
+func isPascalCase(name string) bool {
+	for _, r := range name {
+		return unicode.IsUpper(r)
+	}
+	return false
+}
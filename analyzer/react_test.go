@@ -0,0 +1,85 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/ahmadramadhannn/tsgoast"
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+func parseTSXSource(t *testing.T, source string) *ast.BaseNode {
+	t.Helper()
+
+	parser, err := tsgoast.NewTSX()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+	defer parser.Close()
+
+	root, err := parser.Parse([]byte(source))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	return root
+}
+
+func TestFindReactComponents(t *testing.T) {
+	root := parseTSXSource(t, `
+		function App(): JSX.Element {
+			const items = list.map(item => <li>{item}</li>);
+			return <ul>{items}</ul>;
+		}
+
+		const Button: React.FC<ButtonProps> = ({ label }) => <button>{label}</button>;
+
+		export default function Page({ id }: PageProps) {
+			if (!id) {
+				return null;
+			}
+			return (<>{id}</>);
+		}
+
+		const Memo = React.memo((props: MemoProps) => <i />);
+
+		class Legacy extends React.Component<LegacyProps, LegacyState> {
+			render() { return <div />; }
+		}
+
+		function helper() { return <div />; }
+		function Capitalized() { return 42; }
+		class Store extends Base {}
+	`)
+
+	components := New(root).FindReactComponents()
+
+	tests := []struct {
+		name  string
+		kind  ReactComponentKind
+		props string
+	}{
+		{"App", ReactFunctionComponent, ""},
+		{"Button", ReactFunctionComponent, "ButtonProps"},
+		{"Page", ReactFunctionComponent, "PageProps"},
+		{"Memo", ReactFunctionComponent, "MemoProps"},
+		{"Legacy", ReactClassComponent, "LegacyProps"},
+	}
+
+	if len(components) != len(tests) {
+		for _, c := range components {
+			t.Logf("component: %s", c.Name)
+		}
+		t.Fatalf("FindReactComponents() found %d, want %d", len(components), len(tests))
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := components[i]
+			if c.Name != tt.name || c.Kind != tt.kind || c.PropsType != tt.props {
+				t.Errorf("component = (%s, %s, %q), want (%s, %s, %q)", c.Name, c.Kind, c.PropsType, tt.name, tt.kind, tt.props)
+			}
+			if c.Range != c.Node.Range() {
+				t.Errorf("component range does not match node range")
+			}
+		})
+	}
+}
@@ -0,0 +1,339 @@
+package analyzer
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+// Selector is a compiled node selector.
+//
+// The selector language is modeled on CSS:
+//
+//	kind                 nodes with the given tree-sitter kind (* for any)
+//	a b                  b nodes nested anywhere inside an a node
+//	a > b                b nodes that are direct children of an a node
+//	a, b                 nodes matching either selector
+//	[attr]               nodes where attr is non-empty
+//	[attr="value"]       nodes where attr equals value (also != ^= $= *=)
+//	[attr=/regexp/]      nodes where attr matches the regular expression
+//
+// Supported attributes are name (the declared name, or the callee path of a
+// call), callee (the callee path of a call or new expression), text, kind
+// and type (the ast.NodeType).
+//
+// For example:
+//
+//	class_declaration > class_body > method_definition[name="render"]
+//	call_expression[callee=/^axios\./]
+type Selector struct {
+	source       string
+	alternatives [][]selectorStep
+}
+
+// selectorStep is one compound selector and the combinator linking it to
+// the step on its left.
+type selectorStep struct {
+	kind       string // empty or "*" matches any kind
+	attributes []selectorAttribute
+	child      bool // true for ">", false for descendant
+}
+
+// selectorAttribute is a compiled [attr op value] filter.
+type selectorAttribute struct {
+	name  string
+	op    string // "" for existence, or one of = != ^= $= *=
+	value string
+	regex *regexp.Regexp
+}
+
+// selectorAttributes resolves the attributes available in selectors.
+var selectorAttributes = map[string]func(ast.Node) string{
+	"name": func(node ast.Node) string {
+		switch node.SyntaxKind() {
+		case "call_expression", "new_expression":
+			return selectorCallee(node)
+		}
+		return declarationName(node)
+	},
+	"callee": selectorCallee,
+	"text":   func(node ast.Node) string { return node.Text() },
+	"kind":   func(node ast.Node) string { return node.SyntaxKind() },
+	"type":   func(node ast.Node) string { return string(node.Type()) },
+}
+
+// CompileSelector parses a selector expression.
+func CompileSelector(selector string) (*Selector, error) {
+	p := &selectorParser{input: selector}
+	alternatives, err := p.parse()
+	if err != nil {
+		return nil, fmt.Errorf("invalid selector %q: %w", selector, err)
+	}
+	return &Selector{source: selector, alternatives: alternatives}, nil
+}
+
+// String returns the source of the selector.
+func (s *Selector) String() string {
+	return s.source
+}
+
+// Matches reports whether node matches the selector.
+func (s *Selector) Matches(node ast.Node) bool {
+	for _, steps := range s.alternatives {
+		if matchSteps(steps, node) {
+			return true
+		}
+	}
+	return false
+}
+
+// Query returns every node of the analyzed AST that matches the selector, in
+// document order.
+func (a *Analyzer) Query(selector string) ([]ast.Node, error) {
+	s, err := CompileSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+	return a.FindNodes(s.Matches), nil
+}
+
+// matchSteps matches the last step against node and the preceding steps
+// against its ancestors.
+func matchSteps(steps []selectorStep, node ast.Node) bool {
+	last := steps[len(steps)-1]
+	if !last.matches(node) {
+		return false
+	}
+	if len(steps) == 1 {
+		return true
+	}
+
+	rest := steps[:len(steps)-1]
+	if last.child {
+		parent := node.Parent()
+		return parent != nil && matchSteps(rest, parent)
+	}
+	for ancestor := node.Parent(); ancestor != nil; ancestor = ancestor.Parent() {
+		if matchSteps(rest, ancestor) {
+			return true
+		}
+	}
+	return false
+}
+
+// matches checks the kind and attributes of a single node.
+func (s selectorStep) matches(node ast.Node) bool {
+	if s.kind != "" && s.kind != "*" && s.kind != node.SyntaxKind() {
+		return false
+	}
+	for _, attr := range s.attributes {
+		if !attr.matches(node) {
+			return false
+		}
+	}
+	return true
+}
+
+// matches applies the attribute filter to node.
+func (a selectorAttribute) matches(node ast.Node) bool {
+	value := selectorAttributes[a.name](node)
+	if a.regex != nil {
+		return a.regex.MatchString(value) == (a.op == "=")
+	}
+	switch a.op {
+	case "":
+		return value != ""
+	case "=":
+		return value == a.value
+	case "!=":
+		return value != a.value
+	case "^=":
+		return strings.HasPrefix(value, a.value)
+	case "$=":
+		return strings.HasSuffix(value, a.value)
+	case "*=":
+		return strings.Contains(value, a.value)
+	}
+	return false
+}
+
+// selectorCallee returns the callee path of a call or new expression.
+func selectorCallee(node ast.Node) string {
+	switch node.SyntaxKind() {
+	case "call_expression", "new_expression":
+		for _, child := range node.Children() {
+			if child.SyntaxKind() != "new" {
+				return CalleePath(child)
+			}
+		}
+	}
+	return ""
+}
+
+// selectorParser is a recursive descent parser for selectors.
+type selectorParser struct {
+	input string
+	pos   int
+}
+
+func (p *selectorParser) parse() ([][]selectorStep, error) {
+	var alternatives [][]selectorStep
+	for {
+		steps, err := p.parseSteps()
+		if err != nil {
+			return nil, err
+		}
+		alternatives = append(alternatives, steps)
+
+		p.skipSpace()
+		if p.pos >= len(p.input) {
+			return alternatives, nil
+		}
+		if p.input[p.pos] != ',' {
+			return nil, fmt.Errorf("unexpected %q at offset %d", p.input[p.pos], p.pos)
+		}
+		p.pos++
+	}
+}
+
+// parseSteps parses compound selectors joined by combinators.
+func (p *selectorParser) parseSteps() ([]selectorStep, error) {
+	var steps []selectorStep
+	child := false
+	for {
+		p.skipSpace()
+		step, err := p.parseCompound()
+		if err != nil {
+			return nil, err
+		}
+		step.child = child
+		steps = append(steps, step)
+
+		p.skipSpace()
+		if p.pos >= len(p.input) || p.input[p.pos] == ',' {
+			return steps, nil
+		}
+		child = p.input[p.pos] == '>'
+		if child {
+			p.pos++
+		}
+	}
+}
+
+// parseCompound parses a kind followed by attribute filters.
+func (p *selectorParser) parseCompound() (selectorStep, error) {
+	var step selectorStep
+	if p.consume("*") {
+		step.kind = "*"
+	} else {
+		step.kind = p.scanName()
+	}
+
+	for p.pos < len(p.input) && p.input[p.pos] == '[' {
+		attr, err := p.parseAttribute()
+		if err != nil {
+			return step, err
+		}
+		step.attributes = append(step.attributes, attr)
+	}
+
+	if step.kind == "" && len(step.attributes) == 0 {
+		if p.pos >= len(p.input) {
+			return step, fmt.Errorf("unexpected end of selector")
+		}
+		return step, fmt.Errorf("unexpected %q at offset %d", p.input[p.pos], p.pos)
+	}
+	return step, nil
+}
+
+// parseAttribute parses a bracketed attribute filter.
+func (p *selectorParser) parseAttribute() (selectorAttribute, error) {
+	var attr selectorAttribute
+	p.pos++ // [
+	p.skipSpace()
+
+	attr.name = p.scanName()
+	if _, ok := selectorAttributes[attr.name]; !ok {
+		return attr, fmt.Errorf("unknown attribute %q", attr.name)
+	}
+
+	p.skipSpace()
+	if p.consume("]") {
+		return attr, nil
+	}
+	for _, op := range []string{"!=", "^=", "$=", "*=", "="} {
+		if p.consume(op) {
+			attr.op = op
+			break
+		}
+	}
+	if attr.op == "" {
+		return attr, fmt.Errorf("expected operator after attribute %q", attr.name)
+	}
+
+	p.skipSpace()
+	if p.pos >= len(p.input) {
+		return attr, fmt.Errorf("unterminated attribute %q", attr.name)
+	}
+	switch quote := p.input[p.pos]; quote {
+	case '"', '\'', '/':
+		end := strings.IndexByte(p.input[p.pos+1:], quote)
+		if end < 0 {
+			return attr, fmt.Errorf("unterminated value for attribute %q", attr.name)
+		}
+		attr.value = p.input[p.pos+1 : p.pos+1+end]
+		p.pos += end + 2
+		if quote == '/' {
+			if attr.op != "=" && attr.op != "!=" {
+				return attr, fmt.Errorf("operator %s cannot be used with a regular expression", attr.op)
+			}
+			re, err := regexp.Compile(attr.value)
+			if err != nil {
+				return attr, err
+			}
+			attr.regex = re
+		}
+	default:
+		start := p.pos
+		for p.pos < len(p.input) && p.input[p.pos] != ']' && p.input[p.pos] != ' ' {
+			p.pos++
+		}
+		attr.value = p.input[start:p.pos]
+	}
+
+	p.skipSpace()
+	if !p.consume("]") {
+		return attr, fmt.Errorf("expected ] after attribute %q", attr.name)
+	}
+	return attr, nil
+}
+
+// scanName consumes a kind or attribute name.
+func (p *selectorParser) scanName() string {
+	start := p.pos
+	for p.pos < len(p.input) && isSelectorNameByte(p.input[p.pos]) {
+		p.pos++
+	}
+	return p.input[start:p.pos]
+}
+
+func (p *selectorParser) consume(s string) bool {
+	if strings.HasPrefix(p.input[p.pos:], s) {
+		p.pos += len(s)
+		return true
+	}
+	return false
+}
+
+func (p *selectorParser) skipSpace() {
+	for p.pos < len(p.input) && (p.input[p.pos] == ' ' || p.input[p.pos] == '\t' || p.input[p.pos] == '\n') {
+		p.pos++
+	}
+}
+
+// isSelectorNameByte checks if b may appear in a kind or attribute name.
+func isSelectorNameByte(b byte) bool {
+	return b == '_' || (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z') || (b >= '0' && b <= '9')
+}
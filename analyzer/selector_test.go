@@ -0,0 +1,72 @@
+package analyzer
+
+import (
+	"testing"
+)
+
+const selectorSource = `
+import axios from "axios";
+
+class Widget {
+	render() { return axios.get("/w"); }
+	update() {}
+}
+
+function render() {
+	axios.post("/x", {});
+	fetch("/y");
+	new Map();
+}
+`
+
+func TestQuery(t *testing.T) {
+	a := New(parseSource(t, selectorSource))
+
+	tests := []struct {
+		selector string
+		want     []string
+	}{
+		{selector: `class_declaration method_definition[name="render"]`, want: []string{`render() { return axios.get("/w"); }`}},
+		{selector: `class_declaration > class_body > method_definition`, want: []string{`render() { return axios.get("/w"); }`, "update() {}"}},
+		{selector: `class_declaration > method_definition`, want: nil},
+		{selector: `call_expression[callee=/^axios\./]`, want: []string{`axios.get("/w")`, `axios.post("/x", {})`}},
+		{selector: `function_declaration call_expression[callee!=/^axios/]`, want: []string{`fetch("/y")`}},
+		{selector: `new_expression[name="Map"], import_statement`, want: []string{`import axios from "axios";`, "new Map()"}},
+		{selector: `*[name^="upd"]`, want: []string{"update() {}"}},
+		{selector: `string[text*='/x']`, want: []string{`"/x"`}},
+		{selector: `[type=method]`, want: []string{`render() { return axios.get("/w"); }`, "update() {}"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			nodes, err := a.Query(tt.selector)
+			if err != nil {
+				t.Fatalf("Query() error = %v", err)
+			}
+			if len(nodes) != len(tt.want) {
+				t.Fatalf("Query() found %d nodes, want %d", len(nodes), len(tt.want))
+			}
+			for i, node := range nodes {
+				if node.Text() != tt.want[i] {
+					t.Errorf("node %d = %q, want %q", i, node.Text(), tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestCompileSelectorErrors(t *testing.T) {
+	for _, selector := range []string{
+		"",
+		"call_expression[",
+		"call_expression[unknown]",
+		"call_expression[name=/(/]",
+		"call_expression[name^=/a/]",
+		"a >",
+		"a, ",
+	} {
+		if _, err := CompileSelector(selector); err == nil {
+			t.Errorf("CompileSelector(%q) expected error", selector)
+		}
+	}
+}
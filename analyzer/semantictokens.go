@@ -0,0 +1,373 @@
+package analyzer
+
+import (
+	"sort"
+
+	"github.com/ahmadramadhannn/tsgoast"
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+// SemanticTokenType is the type of a SemanticToken, an index into
+// SemanticTokenTypes.
+type SemanticTokenType uint32
+
+const (
+	TokenNamespace SemanticTokenType = iota
+	TokenType
+	TokenClass
+	TokenEnum
+	TokenInterface
+	TokenTypeParameter
+	TokenParameter
+	TokenVariable
+	TokenProperty
+	TokenEnumMember
+	TokenFunction
+	TokenMethod
+	TokenDecorator
+)
+
+// SemanticTokenTypes is the token type legend to register with the LSP
+// client, in SemanticTokenType order. The names are the standard LSP ones.
+var SemanticTokenTypes = []string{
+	"namespace",
+	"type",
+	"class",
+	"enum",
+	"interface",
+	"typeParameter",
+	"parameter",
+	"variable",
+	"property",
+	"enumMember",
+	"function",
+	"method",
+	"decorator",
+}
+
+// SemanticTokenModifier is a bit set of token modifiers; bit i corresponds
+// to SemanticTokenModifiers[i].
+type SemanticTokenModifier uint32
+
+const (
+	ModifierDeclaration SemanticTokenModifier = 1 << iota
+	ModifierReadonly
+	ModifierStatic
+	ModifierAsync
+	ModifierAbstract
+)
+
+// SemanticTokenModifiers is the token modifier legend to register with the
+// LSP client, in bit order.
+var SemanticTokenModifiers = []string{
+	"declaration",
+	"readonly",
+	"static",
+	"async",
+	"abstract",
+}
+
+// SemanticToken is a classified identifier. Line and Character are
+// zero-based; Character and Length are counted in UTF-16 code units.
+type SemanticToken struct {
+	Line      uint32
+	Character uint32
+	Length    uint32
+	Type      SemanticTokenType
+	Modifiers SemanticTokenModifier
+	Node      ast.Node
+}
+
+// SemanticTokens classifies the identifiers of a file for semantic
+// highlighting, in source order.
+//
+// Declarations are classified by their syntax. References are resolved by
+// name: parameters and type parameters of the enclosing functions and
+// declarations first, then any declaration in the file. Unresolved names
+// are classified as functions when called, types in type positions and
+// variables otherwise.
+func SemanticTokens(tree *tsgoast.Tree) []SemanticToken {
+	if tree == nil || tree.Root == nil {
+		return nil
+	}
+
+	c := &tokenClassifier{
+		index:      ast.NewUTF16Index(tree.Root),
+		global:     make(map[string]classifiedName),
+		parameters: make(map[ast.Node]map[string]bool),
+	}
+	var names []ast.Node
+	for node := range ast.Preorder(tree.Root) {
+		switch node.SyntaxKind() {
+		case "identifier", "type_identifier", "property_identifier", "private_property_identifier",
+			"shorthand_property_identifier", "shorthand_property_identifier_pattern":
+			names = append(names, node)
+			if t, mods, ok := declaredToken(node); ok && t != TokenParameter && t != TokenTypeParameter {
+				if _, seen := c.global[node.Text()]; !seen {
+					c.global[node.Text()] = classifiedName{t, mods &^ ModifierDeclaration}
+				}
+			}
+		}
+	}
+
+	tokens := make([]SemanticToken, 0, len(names))
+	for _, node := range names {
+		t, mods, ok := declaredToken(node)
+		if !ok {
+			t, mods, ok = c.reference(node)
+		}
+		if !ok {
+			continue
+		}
+		r := node.Range()
+		start := c.index.Column(r.Start)
+		tokens = append(tokens, SemanticToken{
+			Line:      r.Start.Line,
+			Character: start,
+			Length:    c.index.Column(r.End) - start,
+			Type:      t,
+			Modifiers: mods,
+			Node:      node,
+		})
+	}
+	return tokens
+}
+
+// EncodeSemanticTokens encodes tokens as the LSP SemanticTokens data array:
+// five integers per token holding the line delta, the start character
+// (relative to the previous token on the same line), the length, the type
+// and the modifier bits.
+func EncodeSemanticTokens(tokens []SemanticToken) []uint32 {
+	sorted := append([]SemanticToken(nil), tokens...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Line != sorted[j].Line {
+			return sorted[i].Line < sorted[j].Line
+		}
+		return sorted[i].Character < sorted[j].Character
+	})
+
+	data := make([]uint32, 0, 5*len(sorted))
+	var line, character uint32
+	for _, t := range sorted {
+		if t.Line != line {
+			character = 0
+		}
+		data = append(data, t.Line-line, t.Character-character, t.Length, uint32(t.Type), uint32(t.Modifiers))
+		line, character = t.Line, t.Character
+	}
+	return data
+}
+
+// classifiedName is the classification of a declared name.
+type classifiedName struct {
+	Type      SemanticTokenType
+	Modifiers SemanticTokenModifier
+}
+
+// tokenClassifier resolves references for SemanticTokens.
+type tokenClassifier struct {
+	index *ast.UTF16Index
+
+	// global holds the first declaration of each name in the file.
+	global map[string]classifiedName
+
+	// parameters caches the parameter names of each function.
+	parameters map[ast.Node]map[string]bool
+}
+
+// declaredToken classifies an identifier declaring a name.
+func declaredToken(node ast.Node) (SemanticTokenType, SemanticTokenModifier, bool) {
+	parent := node.Parent()
+	if parent == nil {
+		return 0, 0, false
+	}
+	if isParameterBinding(node) {
+		return TokenParameter, ModifierDeclaration, true
+	}
+	if parent.SyntaxKind() == "nested_identifier" { // namespace A.B
+		if module := parent.Parent(); module != nil && module.SyntaxKind() == "internal_module" {
+			return TokenNamespace, ModifierDeclaration, true
+		}
+	}
+	if ast.FieldOf(node) != "name" {
+		return 0, 0, false
+	}
+
+	mods := ModifierDeclaration
+	switch parent.SyntaxKind() {
+	case "class_declaration", "abstract_class_declaration", "class":
+		if parent.SyntaxKind() == "abstract_class_declaration" {
+			mods |= ModifierAbstract
+		}
+		return TokenClass, mods, true
+	case "interface_declaration":
+		return TokenInterface, mods, true
+	case "type_alias_declaration":
+		return TokenType, mods, true
+	case "enum_declaration":
+		return TokenEnum, mods, true
+	case "enum_body", "enum_assignment":
+		return TokenEnumMember, mods | ModifierReadonly, true
+	case "internal_module", "module":
+		return TokenNamespace, mods, true
+	case "type_parameter":
+		return TokenTypeParameter, mods, true
+	case "function_declaration", "generator_function_declaration", "function_signature",
+		"function_expression", "generator_function":
+		if hasModifier(parent, "async") {
+			mods |= ModifierAsync
+		}
+		return TokenFunction, mods, true
+	case "method_definition", "method_signature", "abstract_method_signature":
+		return TokenMethod, mods | memberModifiers(parent), true
+	case "public_field_definition", "property_signature":
+		return TokenProperty, mods | memberModifiers(parent), true
+	case "variable_declarator":
+		if declaration := parent.Parent(); declaration != nil && ast.FirstChildOfKind(declaration, "const") != nil {
+			mods |= ModifierReadonly
+		}
+		if value := ast.ChildByField(parent, "value"); value != nil {
+			switch value.SyntaxKind() {
+			case "arrow_function", "function_expression", "generator_function":
+				if hasModifier(value, "async") {
+					mods |= ModifierAsync
+				}
+				return TokenFunction, mods &^ ModifierReadonly, true
+			case "class":
+				return TokenClass, mods &^ ModifierReadonly, true
+			}
+		}
+		return TokenVariable, mods, true
+	}
+	return 0, 0, false
+}
+
+// memberModifiers returns the static, readonly, async and abstract
+// modifiers of a class or interface member.
+func memberModifiers(member ast.Node) SemanticTokenModifier {
+	var mods SemanticTokenModifier
+	for _, child := range member.Children() {
+		switch child.SyntaxKind() {
+		case "static":
+			mods |= ModifierStatic
+		case "readonly":
+			mods |= ModifierReadonly
+		case "async":
+			mods |= ModifierAsync
+		case "abstract":
+			mods |= ModifierAbstract
+		}
+	}
+	if member.SyntaxKind() == "abstract_method_signature" {
+		mods |= ModifierAbstract
+	}
+	return mods
+}
+
+// isParameterBinding reports whether node is a name bound by a function
+// parameter, including names in destructuring patterns.
+func isParameterBinding(node ast.Node) bool {
+	switch node.SyntaxKind() {
+	case "identifier", "shorthand_property_identifier_pattern":
+	default:
+		return false
+	}
+	for current := node; current.Parent() != nil; current = current.Parent() {
+		parent := current.Parent()
+		switch parent.SyntaxKind() {
+		case "required_parameter", "optional_parameter":
+			return ast.FieldOf(current) == "pattern"
+		case "arrow_function":
+			return ast.FieldOf(current) == "parameter"
+		case "object_pattern", "array_pattern", "rest_pattern":
+		case "pair_pattern", "object_assignment_pattern", "assignment_pattern":
+			if field := ast.FieldOf(current); field != "value" && field != "left" {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return false
+}
+
+// reference classifies an identifier that does not declare a name.
+func (c *tokenClassifier) reference(node ast.Node) (SemanticTokenType, SemanticTokenModifier, bool) {
+	parent := node.Parent()
+	if parent == nil {
+		return 0, 0, false
+	}
+
+	switch node.SyntaxKind() {
+	case "property_identifier", "private_property_identifier":
+		if parent.SyntaxKind() == "member_expression" {
+			if object := ast.ChildByField(parent, "object"); object != nil && c.global[object.Text()].Type == TokenEnum {
+				return TokenEnumMember, ModifierReadonly, true // Color.Red
+			}
+			if call := parent.Parent(); call != nil && call.SyntaxKind() == "call_expression" && ast.ChildByField(call, "function") == parent {
+				return TokenMethod, 0, true
+			}
+		}
+		return TokenProperty, 0, true
+	case "identifier":
+		if parent.SyntaxKind() == "nested_type_identifier" || parent.SyntaxKind() == "nested_identifier" {
+			return TokenNamespace, 0, true
+		}
+		if parent.SyntaxKind() == "decorator" || isDecoratorCall(parent, node) {
+			return TokenDecorator, 0, true
+		}
+	}
+
+	name := node.Text()
+	isType := node.SyntaxKind() == "type_identifier"
+	for current := parent; current != nil; current = current.Parent() {
+		if isType && declaresTypeParameter(current, name) {
+			return TokenTypeParameter, 0, true
+		}
+		if !isType && functionKinds[current.SyntaxKind()] && c.parameterNames(current)[name] {
+			return TokenParameter, 0, true
+		}
+	}
+
+	if declared, ok := c.global[name]; ok {
+		return declared.Type, declared.Modifiers, true
+	}
+	switch {
+	case isType:
+		return TokenType, 0, true
+	case parent.SyntaxKind() == "call_expression" && ast.ChildByField(parent, "function") == node:
+		return TokenFunction, 0, true
+	}
+	return TokenVariable, 0, true
+}
+
+// isDecoratorCall reports whether node is the callee of a decorator
+// factory call such as @Component({...}).
+func isDecoratorCall(parent, node ast.Node) bool {
+	if parent.SyntaxKind() != "call_expression" || ast.ChildByField(parent, "function") != node {
+		return false
+	}
+	decorator := parent.Parent()
+	return decorator != nil && decorator.SyntaxKind() == "decorator"
+}
+
+// parameterNames returns the names bound by the parameters of fn.
+func (c *tokenClassifier) parameterNames(fn ast.Node) map[string]bool {
+	if names, ok := c.parameters[fn]; ok {
+		return names
+	}
+	names := make(map[string]bool)
+	params := ast.ChildByField(fn, "parameters")
+	if params == nil {
+		params = ast.ChildByField(fn, "parameter")
+	}
+	if params != nil {
+		for node := range ast.Preorder(params) {
+			if isParameterBinding(node) {
+				names[node.Text()] = true
+			}
+		}
+	}
+	c.parameters[fn] = names
+	return names
+}
@@ -0,0 +1,89 @@
+package analyzer
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ahmadramadhannn/tsgoast"
+)
+
+func TestSemanticTokens(t *testing.T) {
+	parser, err := tsgoast.New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+	defer parser.Close()
+
+	tree, err := parser.ParseTree([]byte(`@Injectable()
+class Repo<T> {
+	static readonly limit = 10;
+	async find(id: string): Promise<T> {
+		return this.load(id, Color.Red);
+	}
+}
+enum Color { Red }
+const save = ({ name }: User) => log(name);
+`))
+	if err != nil {
+		t.Fatalf("ParseTree() error = %v", err)
+	}
+
+	var got []string
+	for _, tok := range SemanticTokens(tree) {
+		line := fmt.Sprintf("%d:%d %s %s", tok.Line, tok.Character, tok.Node.Text(), SemanticTokenTypes[tok.Type])
+		for i, name := range SemanticTokenModifiers {
+			if tok.Modifiers&(1<<i) != 0 {
+				line += " " + name
+			}
+		}
+		got = append(got, line)
+	}
+
+	want := []string{
+		"0:1 Injectable decorator",
+		"1:6 Repo class declaration",
+		"1:11 T typeParameter declaration",
+		"2:17 limit property declaration readonly static",
+		"3:7 find method declaration async",
+		"3:12 id parameter declaration",
+		"3:25 Promise type",
+		"3:33 T typeParameter",
+		"4:14 load method",
+		"4:19 id parameter",
+		"4:23 Color enum",
+		"4:29 Red enumMember readonly",
+		"7:5 Color enum declaration",
+		"7:13 Red enumMember declaration readonly",
+		"8:6 save function declaration",
+		"8:16 name parameter declaration",
+		"8:24 User type",
+		"8:33 log function",
+		"8:37 name parameter",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SemanticTokens() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestEncodeSemanticTokens(t *testing.T) {
+	tokens := []SemanticToken{
+		{Line: 2, Character: 4, Length: 3, Type: TokenVariable},
+		{Line: 0, Character: 6, Length: 4, Type: TokenClass, Modifiers: ModifierDeclaration},
+		{Line: 2, Character: 10, Length: 5, Type: TokenMethod, Modifiers: ModifierStatic | ModifierAsync},
+	}
+
+	got := EncodeSemanticTokens(tokens)
+	want := []uint32{
+		0, 6, 4, uint32(TokenClass), 1,
+		2, 4, 3, uint32(TokenVariable), 0,
+		0, 6, 5, uint32(TokenMethod), 12,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("EncodeSemanticTokens() = %v, want %v", got, want)
+	}
+	if len(SemanticTokenTypes) != int(TokenDecorator)+1 {
+		t.Errorf("SemanticTokenTypes has %d entries, want %d", len(SemanticTokenTypes), TokenDecorator+1)
+	}
+}
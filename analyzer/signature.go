@@ -0,0 +1,151 @@
+package analyzer
+
+import (
+	"strings"
+
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+// Signature describes the declared shape of a function, arrow function or
+// method.
+type Signature struct {
+	Name           string
+	Parameters     []*ast.Parameter
+	ReturnType     string   // empty when not annotated
+	TypeParameters []string // e.g. ["T", "K extends keyof T"]
+	IsAsync        bool
+	IsGenerator    bool
+	IsExported     bool
+	IsArrow        bool
+	IsMethod       bool
+	IsStatic       bool
+}
+
+// GetFunctionSignature returns the signature of a function, arrow function,
+// function expression or method node, or nil for any other node.
+func GetFunctionSignature(fn ast.Node) *Signature {
+	if fn == nil {
+		return nil
+	}
+
+	kind := fn.SyntaxKind()
+	if !functionKinds[kind] && kind != "method_signature" && kind != "abstract_method_signature" {
+		return nil
+	}
+
+	sig := &Signature{
+		Name:           GetFunctionName(fn),
+		Parameters:     GetParameters(fn),
+		ReturnType:     returnTypeOf(fn),
+		TypeParameters: typeParametersOf(fn),
+		IsAsync:        IsAsyncFunction(fn),
+		IsGenerator:    IsGeneratorFunction(fn),
+		IsArrow:        kind == "arrow_function",
+		IsMethod:       kind == "method_definition" || kind == "method_signature" || kind == "abstract_method_signature",
+	}
+
+	if sig.IsMethod {
+		sig.Name = declarationName(fn)
+		sig.IsStatic = hasModifier(fn, "static")
+	} else {
+		sig.IsExported = IsExported(fn)
+	}
+
+	return sig
+}
+
+// GetParameters returns the declared parameters of a function or method.
+func GetParameters(fn ast.Node) []*ast.Parameter {
+	if fn == nil {
+		return nil
+	}
+
+	// x => ... has a single bare parameter
+	if fn.SyntaxKind() == "arrow_function" {
+		if param := arrowBareParameter(fn); param != nil {
+			return []*ast.Parameter{{Name: param.Text()}}
+		}
+	}
+
+	params := ast.FirstChildOfKind(fn, "formal_parameters")
+	if params == nil {
+		return nil
+	}
+
+	var parameters []*ast.Parameter
+	for _, child := range params.Children() {
+		switch child.SyntaxKind() {
+		case "required_parameter", "optional_parameter":
+			parameters = append(parameters, parseParameter(child))
+		}
+	}
+	return parameters
+}
+
+// parseParameter builds an ast.Parameter from a required_parameter or
+// optional_parameter node.
+func parseParameter(node ast.Node) *ast.Parameter {
+	param := &ast.Parameter{
+		IsOptional: node.SyntaxKind() == "optional_parameter",
+	}
+
+	afterEquals := false
+	for _, child := range node.Children() {
+		switch kind := child.SyntaxKind(); {
+		case afterEquals:
+			param.DefaultValue = child.Text()
+			param.IsOptional = true
+			afterEquals = false
+		case kind == "=":
+			afterEquals = true
+		case kind == "type_annotation":
+			param.Type = annotationType(child)
+		case kind == "rest_pattern":
+			param.IsRest = true
+			param.Name = strings.TrimPrefix(child.Text(), "...")
+		case kind == "decorator", kind == "accessibility_modifier", kind == "override_modifier",
+			kind == "readonly", kind == "?":
+		default:
+			if param.Name == "" {
+				param.Name = child.Text()
+			}
+		}
+	}
+
+	return param
+}
+
+// returnTypeOf returns the return type annotation of a function, which
+// follows its parameter list.
+func returnTypeOf(fn ast.Node) string {
+	seenParams := false
+	for _, child := range fn.Children() {
+		switch child.SyntaxKind() {
+		case "formal_parameters":
+			seenParams = true
+		case "type_annotation", "type_predicate_annotation", "asserts_annotation":
+			if seenParams {
+				return annotationType(child)
+			}
+		case "statement_block", "=>":
+			return ""
+		}
+	}
+	return ""
+}
+
+// typeParametersOf returns the declared type parameters of a declaration.
+func typeParametersOf(node ast.Node) []string {
+	params := ast.FirstChildOfKind(node, "type_parameters")
+	if params == nil {
+		return nil
+	}
+
+	var typeParams []string
+	for _, child := range params.Children() {
+		if child.SyntaxKind() == "type_parameter" {
+			typeParams = append(typeParams, child.Text())
+		}
+	}
+	return typeParams
+}
@@ -0,0 +1,89 @@
+package analyzer
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+func TestGetFunctionSignature(t *testing.T) {
+	root := parseSource(t, `
+		export async function find<T extends object, K = string>(
+			id: T,
+			key?: K,
+			limit = 10,
+			...rest: number[]
+		): Promise<T | null> {}
+
+		const double = x => x * 2;
+
+		class Repo {
+			static *scan(@Inject() private readonly db: Db): Iterable<Row> {}
+		}
+	`)
+
+	a := New(root)
+
+	var fn, arrow, method ast.Node
+	a.Visit(func(node ast.Node) bool {
+		switch node.SyntaxKind() {
+		case "function_declaration":
+			fn = node
+		case "arrow_function":
+			arrow = node
+		case "method_definition":
+			method = node
+		}
+		return true
+	})
+
+	sig := GetFunctionSignature(fn)
+	if sig == nil {
+		t.Fatal("GetFunctionSignature(find) = nil")
+	}
+	if sig.Name != "find" || !sig.IsAsync || !sig.IsExported || sig.IsGenerator || sig.IsArrow {
+		t.Errorf("find flags = %+v", sig)
+	}
+	if sig.ReturnType != "Promise<T | null>" {
+		t.Errorf("ReturnType = %q", sig.ReturnType)
+	}
+	if want := []string{"T extends object", "K = string"}; !reflect.DeepEqual(sig.TypeParameters, want) {
+		t.Errorf("TypeParameters = %v, want %v", sig.TypeParameters, want)
+	}
+
+	wantParams := []ast.Parameter{
+		{Name: "id", Type: "T"},
+		{Name: "key", Type: "K", IsOptional: true},
+		{Name: "limit", IsOptional: true, DefaultValue: "10"},
+		{Name: "rest", Type: "number[]", IsRest: true},
+	}
+	if len(sig.Parameters) != len(wantParams) {
+		t.Fatalf("Parameters = %d, want %d", len(sig.Parameters), len(wantParams))
+	}
+	for i, want := range wantParams {
+		if *sig.Parameters[i] != want {
+			t.Errorf("Parameters[%d] = %+v, want %+v", i, *sig.Parameters[i], want)
+		}
+	}
+
+	arrowSig := GetFunctionSignature(arrow)
+	if arrowSig.Name != "double" || !arrowSig.IsArrow || len(arrowSig.Parameters) != 1 || arrowSig.Parameters[0].Name != "x" {
+		t.Errorf("double signature = %+v", arrowSig)
+	}
+
+	methodSig := GetFunctionSignature(method)
+	if methodSig.Name != "scan" || !methodSig.IsMethod || !methodSig.IsStatic || !methodSig.IsGenerator {
+		t.Errorf("scan flags = %+v", methodSig)
+	}
+	if methodSig.ReturnType != "Iterable<Row>" {
+		t.Errorf("scan ReturnType = %q", methodSig.ReturnType)
+	}
+	if len(methodSig.Parameters) != 1 || *methodSig.Parameters[0] != (ast.Parameter{Name: "db", Type: "Db"}) {
+		t.Errorf("scan Parameters = %+v", methodSig.Parameters)
+	}
+
+	if GetFunctionSignature(root) != nil {
+		t.Error("GetFunctionSignature(program) should be nil")
+	}
+}
@@ -0,0 +1,128 @@
+package analyzer
+
+import (
+	"strings"
+
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+// StringLiteral represents a string value found in the source: a string
+// literal, a template literal, or a `+` concatenation involving them.
+type StringLiteral struct {
+	// Value is the literal content without quotes. Template substitutions
+	// and non-literal concatenation operands appear as ${expr}; escape
+	// sequences are kept as written.
+	Value           string
+	IsTemplate      bool
+	IsConcatenation bool
+	Node            ast.Node
+	Range           ast.Range
+}
+
+// FindStringLiterals finds every string literal, template literal and
+// string concatenation whose value is accepted by matcher. A nil matcher
+// matches all strings. A concatenation is reported once as a whole rather
+// than as its individual literal parts; strings nested in its other
+// operands, e.g. the "b" of "a" + f("b"), are reported on their own.
+func (a *Analyzer) FindStringLiterals(matcher func(string) bool) []StringLiteral {
+	var literals []StringLiteral
+	var visit func(ast.Node) bool
+	visit = func(node ast.Node) bool {
+		var literal StringLiteral
+		switch node.SyntaxKind() {
+		case "string":
+			literal.Value = stringLiteralValue(node)
+		case "template_string":
+			literal.Value = stringLiteralValue(node)
+			literal.IsTemplate = true
+		case "binary_expression":
+			if !isStringConcatenation(node) {
+				return true
+			}
+			literal.Value = concatenationValue(node)
+			literal.IsConcatenation = true
+		default:
+			return true
+		}
+
+		literal.Node = node
+		literal.Range = node.Range()
+		if matcher == nil || matcher(literal.Value) {
+			literals = append(literals, literal)
+		}
+
+		// The literal parts of a concatenation are covered by it, but its
+		// other operands may hold further strings, as may substitutions.
+		if literal.IsConcatenation {
+			for _, operand := range concatenationOperands(node) {
+				visitSubtree(operand, visit)
+			}
+			return false
+		}
+		return literal.IsTemplate
+	}
+	a.Visit(visit)
+	return literals
+}
+
+// isStringConcatenation checks if a binary expression is a `+` chain with
+// at least one string or template literal operand.
+func isStringConcatenation(node ast.Node) bool {
+	if ast.FirstChildOfKind(node, "+") == nil {
+		return false
+	}
+	for _, operand := range node.Children() {
+		switch operand.SyntaxKind() {
+		case "string", "template_string":
+			return true
+		case "binary_expression":
+			if isStringConcatenation(operand) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// concatenationValue flattens a `+` chain into a single template-like value.
+func concatenationValue(node ast.Node) string {
+	var b strings.Builder
+	for _, operand := range node.Children() {
+		switch operand.SyntaxKind() {
+		case "+":
+		case "string", "template_string":
+			b.WriteString(stringLiteralValue(operand))
+		case "binary_expression":
+			if ast.FirstChildOfKind(operand, "+") != nil {
+				b.WriteString(concatenationValue(operand))
+				continue
+			}
+			b.WriteString("${" + operand.Text() + "}")
+		default:
+			b.WriteString("${" + operand.Text() + "}")
+		}
+	}
+	return b.String()
+}
+
+// concatenationOperands returns the parts of a `+` chain that are not string
+// literals, with the children of its template literals standing for them.
+func concatenationOperands(node ast.Node) []ast.Node {
+	var operands []ast.Node
+	for _, operand := range node.Children() {
+		switch operand.SyntaxKind() {
+		case "+", "string":
+		case "template_string":
+			operands = append(operands, operand.Children()...)
+		case "binary_expression":
+			if ast.FirstChildOfKind(operand, "+") != nil {
+				operands = append(operands, concatenationOperands(operand)...)
+				continue
+			}
+			operands = append(operands, operand)
+		default:
+			operands = append(operands, operand)
+		}
+	}
+	return operands
+}
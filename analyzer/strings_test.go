@@ -0,0 +1,76 @@
+package analyzer
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFindStringLiterals(t *testing.T) {
+	root := parseSource(t, strings.Join([]string{
+		`const base = "https://api.example.com";`,
+		`const url = "https://api.example.com/users/" + id + "/posts";`,
+		"const greeting = `Hello ${name ?? \"stranger\"}`;",
+		`const key = 'sk_live_123456';`,
+		`const total = a + b;`,
+	}, "\n"))
+
+	a := New(root)
+
+	all := a.FindStringLiterals(nil)
+	var values []string
+	for _, lit := range all {
+		values = append(values, lit.Value)
+	}
+
+	want := []string{
+		"https://api.example.com",
+		"https://api.example.com/users/${id}/posts",
+		`Hello ${name ?? "stranger"}`,
+		"stranger",
+		"sk_live_123456",
+	}
+	if strings.Join(values, "|") != strings.Join(want, "|") {
+		t.Fatalf("FindStringLiterals(nil) = %q, want %q", values, want)
+	}
+
+	if !all[1].IsConcatenation || !all[2].IsTemplate {
+		t.Errorf("literal flags not set: %+v, %+v", all[1], all[2])
+	}
+
+	urls := a.FindStringLiterals(func(s string) bool {
+		return strings.HasPrefix(s, "https://")
+	})
+	if len(urls) != 2 {
+		t.Errorf("URL matcher found %d, want 2", len(urls))
+	}
+
+	secrets := a.FindStringLiterals(func(s string) bool {
+		return strings.HasPrefix(s, "sk_live_")
+	})
+	if len(secrets) != 1 || secrets[0].Range.Start.Line != 3 {
+		t.Errorf("secret matcher = %+v", secrets)
+	}
+}
+
+func TestFindStringLiteralsInConcatenationOperands(t *testing.T) {
+	root := parseSource(t, strings.Join([]string{
+		`const a = "a" + f("b");`,
+		`const c = "p" + x + (c ? "d" : "e");`,
+	}, "\n"))
+
+	var values []string
+	for _, lit := range New(root).FindStringLiterals(nil) {
+		values = append(values, lit.Value)
+	}
+
+	want := []string{
+		`a${f("b")}`,
+		"b",
+		`p${x}${(c ? "d" : "e")}`,
+		"d",
+		"e",
+	}
+	if strings.Join(values, "|") != strings.Join(want, "|") {
+		t.Errorf("FindStringLiterals(nil) = %q, want %q", values, want)
+	}
+}
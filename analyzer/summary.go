@@ -0,0 +1,104 @@
+package analyzer
+
+import (
+	"strings"
+
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+// Summary holds per-kind histograms and totals for a file, computed in a
+// single traversal.
+type Summary struct {
+	// Statements counts statement and declaration nodes by tree-sitter kind
+	// (e.g. "if_statement", "lexical_declaration").
+	Statements map[string]int
+
+	// Expressions counts expression nodes by tree-sitter kind
+	// (e.g. "call_expression", "arrow_function").
+	Expressions map[string]int
+
+	Functions   int // function declarations, function expressions and arrow functions
+	Methods     int
+	Classes     int
+	Interfaces  int
+	TypeAliases int
+	Enums       int
+	Imports     int
+	Exports     int
+	Comments    int
+
+	Lines    int // lines spanned by the source
+	Nodes    int // total number of nodes, including tokens
+	MaxDepth int // depth of the deepest node, the root being at depth 0
+}
+
+// expressionKinds holds expression kinds that do not end in "_expression".
+var expressionKinds = map[string]bool{
+	"arrow_function":      true,
+	"function_expression": true,
+	"generator_function":  true,
+	"class":               true,
+}
+
+// Summary computes statement and expression histograms, declaration totals
+// and file-level metrics for the AST.
+func (a *Analyzer) Summary() Summary {
+	summary := Summary{
+		Statements:  make(map[string]int),
+		Expressions: make(map[string]int),
+	}
+	if a.root == nil {
+		return summary
+	}
+
+	summary.Lines = strings.Count(a.root.Text(), "\n") + 1
+
+	var walk func(node ast.Node, depth int)
+	walk = func(node ast.Node, depth int) {
+		summary.Nodes++
+		if depth > summary.MaxDepth {
+			summary.MaxDepth = depth
+		}
+
+		kind := node.SyntaxKind()
+		if kind == "class" && len(node.Children()) == 0 {
+			// The class keyword shares its kind with class expressions
+			kind = "class keyword"
+		}
+		switch {
+		case strings.HasSuffix(kind, "_statement") || strings.HasSuffix(kind, "_declaration"):
+			summary.Statements[kind]++
+		case strings.HasSuffix(kind, "_expression") || expressionKinds[kind]:
+			summary.Expressions[kind]++
+		}
+
+		switch kind {
+		case "function_declaration", "generator_function_declaration",
+			"function_expression", "generator_function", "arrow_function":
+			summary.Functions++
+		case "method_definition":
+			summary.Methods++
+		case "class_declaration", "abstract_class_declaration", "class":
+			summary.Classes++
+		case "interface_declaration":
+			summary.Interfaces++
+		case "type_alias_declaration":
+			summary.TypeAliases++
+		case "enum_declaration":
+			summary.Enums++
+		case "import_statement":
+			summary.Imports++
+		case "export_statement":
+			summary.Exports++
+		case "comment":
+			summary.Comments++
+		}
+
+		for _, child := range node.Children() {
+			walk(child, depth+1)
+		}
+	}
+	walk(a.root, 0)
+
+	return summary
+}
@@ -0,0 +1,65 @@
+package analyzer
+
+import (
+	"testing"
+)
+
+func TestSummary(t *testing.T) {
+	a := New(parseSource(t, `import { a } from "./a";
+
+// A comment
+export interface User { name: string }
+type ID = string;
+enum Color { Red }
+
+export class Service {
+	load() { return fetch("/x").then(r => r.json()); }
+}
+
+function main() {
+	if (a) {
+		console.log(a);
+	}
+	const f = function () {};
+}`))
+
+	s := a.Summary()
+
+	checks := []struct {
+		name string
+		got  int
+		want int
+	}{
+		{"Functions", s.Functions, 3},
+		{"Methods", s.Methods, 1},
+		{"Classes", s.Classes, 1},
+		{"Interfaces", s.Interfaces, 1},
+		{"TypeAliases", s.TypeAliases, 1},
+		{"Enums", s.Enums, 1},
+		{"Imports", s.Imports, 1},
+		{"Exports", s.Exports, 2},
+		{"Comments", s.Comments, 1},
+		{"Lines", s.Lines, 17},
+		{"Statements[if_statement]", s.Statements["if_statement"], 1},
+		{"Statements[lexical_declaration]", s.Statements["lexical_declaration"], 1},
+		{"Expressions[call_expression]", s.Expressions["call_expression"], 4},
+		{"Expressions[arrow_function]", s.Expressions["arrow_function"], 1},
+		{"Expressions[function_expression]", s.Expressions["function_expression"], 1},
+	}
+	for _, c := range checks {
+		if c.got != c.want {
+			t.Errorf("%s = %d, want %d", c.name, c.got, c.want)
+		}
+	}
+
+	if s.Nodes == 0 || s.MaxDepth == 0 {
+		t.Errorf("Nodes = %d, MaxDepth = %d, want both non-zero", s.Nodes, s.MaxDepth)
+	}
+}
+
+func TestSummaryEmpty(t *testing.T) {
+	s := New(nil).Summary()
+	if s.Nodes != 0 || s.Statements == nil || s.Expressions == nil {
+		t.Errorf("Summary() of empty analyzer = %+v", s)
+	}
+}
@@ -0,0 +1,292 @@
+package analyzer
+
+import (
+	"strconv"
+
+	"github.com/ahmadramadhannn/tsgoast"
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+// SwitchCoverage describes how a switch statement covers the values of its
+// discriminant's type.
+type SwitchCoverage struct {
+	Switch       ast.Node
+	Discriminant ast.Node
+
+	// TypeName is the locally declared union type or enum the discriminant
+	// was resolved to.
+	TypeName string
+
+	// Values lists every value of the type, e.g. `"circle"` or `Color.Red`.
+	Values []string
+
+	// Missing lists the values without a case clause.
+	Missing []string
+
+	HasDefault bool
+	Range      ast.Range
+}
+
+// IsExhaustive reports whether every value is handled, either by a case
+// clause or by a default branch.
+func (c SwitchCoverage) IsExhaustive() bool {
+	return len(c.Missing) == 0 || c.HasDefault
+}
+
+// CheckSwitch resolves the type of a switch statement's discriminant and
+// compares its values with the case clauses. The discriminant may be a
+// variable or parameter annotated with a locally declared union of literals
+// or enum, or a property of a variable whose type is a union of object types
+// sharing a literal-typed property (a discriminated union). It returns nil
+// when the type cannot be resolved.
+func (a *Analyzer) CheckSwitch(sw *ast.SwitchStatement) *SwitchCoverage {
+	if sw == nil || sw.Discriminant == nil {
+		return nil
+	}
+
+	typeName, values := a.discriminantValues(unwrapParentheses(sw.Discriminant))
+	if len(values) == 0 {
+		return nil
+	}
+
+	coverage := &SwitchCoverage{
+		Switch:       sw,
+		Discriminant: sw.Discriminant,
+		TypeName:     typeName,
+		Values:       values,
+		Range:        sw.Range(),
+	}
+
+	handled := make(map[string]bool)
+	for _, c := range sw.Cases {
+		if c.Test == nil {
+			coverage.HasDefault = true
+			continue
+		}
+		handled[normalizeLiteral(c.Test)] = true
+	}
+	for _, v := range values {
+		if !handled[v] {
+			coverage.Missing = append(coverage.Missing, v)
+		}
+	}
+	return coverage
+}
+
+// SwitchCoverages checks every switch statement whose discriminant type can
+// be resolved.
+func (a *Analyzer) SwitchCoverages() []*SwitchCoverage {
+	var coverages []*SwitchCoverage
+	a.Visit(func(node ast.Node) bool {
+		if node.SyntaxKind() != "switch_statement" {
+			return true
+		}
+		base, ok := node.(*ast.BaseNode)
+		if !ok {
+			return true
+		}
+		if c := a.CheckSwitch(tsgoast.BuildSwitchStatement(base)); c != nil {
+			coverages = append(coverages, c)
+		}
+		return true
+	})
+	return coverages
+}
+
+// FindNonExhaustiveSwitches finds switch statements that miss values of
+// their discriminant's type and have no default branch.
+func (a *Analyzer) FindNonExhaustiveSwitches() []*SwitchCoverage {
+	var result []*SwitchCoverage
+	for _, c := range a.SwitchCoverages() {
+		if !c.IsExhaustive() {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+// discriminantValues resolves the type of a switch discriminant to its
+// possible values.
+func (a *Analyzer) discriminantValues(expr ast.Node) (string, []string) {
+	switch expr.SyntaxKind() {
+	case "identifier":
+		t := lookupVariableType(expr, expr.Text())
+		if t == nil {
+			return "", nil
+		}
+		return a.literalValues(t, map[string]bool{})
+	case "member_expression":
+		children := expr.Children()
+		property := ast.FirstChildOfKind(expr, "property_identifier")
+		if len(children) == 0 || children[0].SyntaxKind() != "identifier" || property == nil {
+			return "", nil
+		}
+		t := lookupVariableType(expr, children[0].Text())
+		if t == nil {
+			return "", nil
+		}
+		name, objects := a.objectVariants(t, map[string]bool{})
+		var values []string
+		for _, object := range objects {
+			for _, m := range object {
+				if m.Name != property.Text() {
+					continue
+				}
+				_, vs := a.literalValues(m.Type, map[string]bool{})
+				for _, v := range vs {
+					values = appendUnique(values, v)
+				}
+			}
+		}
+		return name + "." + property.Text(), values
+	}
+	return "", nil
+}
+
+// literalValues returns the values of a union of literal types, following
+// references to local type aliases and enums.
+func (a *Analyzer) literalValues(t *TypeExpr, seen map[string]bool) (string, []string) {
+	if t == nil {
+		return "", nil
+	}
+	switch t.Kind {
+	case TypeKindLiteral:
+		return "", []string{normalizeLiteralText(t.Text)}
+	case TypeKindUnion:
+		var values []string
+		for _, member := range t.Types {
+			_, vs := a.literalValues(member, seen)
+			if vs == nil {
+				return "", nil
+			}
+			for _, v := range vs {
+				values = appendUnique(values, v)
+			}
+		}
+		return "", values
+	case TypeKindReference:
+		if seen[t.Name] {
+			return "", nil
+		}
+		seen[t.Name] = true
+		decl := a.FindDeclaration(t.Name)
+		if decl == nil {
+			return "", nil
+		}
+		switch decl.SyntaxKind() {
+		case "enum_declaration":
+			e := newEnum(decl)
+			var values []string
+			for _, m := range e.Members {
+				values = append(values, e.Name+"."+m.Name)
+			}
+			return t.Name, values
+		case "type_alias_declaration":
+			_, values := a.literalValues(GetTypeAliasDefinition(decl).Type, seen)
+			return t.Name, values
+		}
+	}
+	return "", nil
+}
+
+// objectVariants returns the members of each object type in a union,
+// following references to local type aliases and interfaces.
+func (a *Analyzer) objectVariants(t *TypeExpr, seen map[string]bool) (string, [][]*TypeMember) {
+	if t == nil {
+		return "", nil
+	}
+	switch t.Kind {
+	case TypeKindObject:
+		return "", [][]*TypeMember{t.Members}
+	case TypeKindUnion:
+		var variants [][]*TypeMember
+		for _, member := range t.Types {
+			_, vs := a.objectVariants(member, seen)
+			variants = append(variants, vs...)
+		}
+		return "", variants
+	case TypeKindReference:
+		if seen[t.Name] {
+			return "", nil
+		}
+		seen[t.Name] = true
+		decl := a.FindDeclaration(t.Name)
+		if decl == nil {
+			return "", nil
+		}
+		switch decl.SyntaxKind() {
+		case "type_alias_declaration":
+			_, variants := a.objectVariants(GetTypeAliasDefinition(decl).Type, seen)
+			return t.Name, variants
+		case "interface_declaration":
+			var members []*TypeMember
+			if body := ast.FirstChildOfKind(decl, "interface_body"); body != nil {
+				for _, child := range body.Children() {
+					if child.SyntaxKind() == "property_signature" {
+						members = append(members, &TypeMember{
+							Name: declarationName(child),
+							Type: ParseTypeExpr(ast.FirstChildOfKind(child, "type_annotation")),
+						})
+					}
+				}
+			}
+			return t.Name, [][]*TypeMember{members}
+		}
+	}
+	return "", nil
+}
+
+// lookupVariableType finds the annotated type of the parameter or variable
+// called name that is visible from node.
+func lookupVariableType(node ast.Node, name string) *TypeExpr {
+	for scope := node.Parent(); scope != nil; scope = scope.Parent() {
+		if params := ast.FirstChildOfKind(scope, "formal_parameters"); params != nil && functionKinds[scope.SyntaxKind()] {
+			for _, param := range params.Children() {
+				if id := ast.FirstChildOfKind(param, "identifier"); id != nil && id.Text() == name {
+					return ParseTypeExpr(ast.FirstChildOfKind(param, "type_annotation"))
+				}
+			}
+		}
+		for _, stmt := range scope.Children() {
+			switch stmt.SyntaxKind() {
+			case "lexical_declaration", "variable_declaration":
+				for _, declarator := range stmt.Children() {
+					if declarator.SyntaxKind() != "variable_declarator" {
+						continue
+					}
+					if id := ast.FirstChildOfKind(declarator, "identifier"); id != nil && id.Text() == name {
+						return ParseTypeExpr(ast.FirstChildOfKind(declarator, "type_annotation"))
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// normalizeLiteral returns the canonical form of a case test: string
+// literals are double-quoted, enum members are written Enum.Member.
+func normalizeLiteral(node ast.Node) string {
+	switch node.SyntaxKind() {
+	case "member_expression":
+		if path := CalleePath(node); path != "" {
+			return path
+		}
+	case "parenthesized_expression":
+		return normalizeLiteral(unwrapParentheses(node))
+	}
+	return normalizeLiteralText(node.Text())
+}
+
+// normalizeLiteralText double-quotes a string literal written with any quote.
+func normalizeLiteralText(text string) string {
+	if len(text) >= 2 {
+		switch text[0] {
+		case '"', '\'', '`':
+			if text[len(text)-1] == text[0] {
+				return strconv.Quote(text[1 : len(text)-1])
+			}
+		}
+	}
+	return text
+}
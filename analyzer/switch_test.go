@@ -0,0 +1,130 @@
+package analyzer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ahmadramadhannn/tsgoast"
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+const switchSource = `
+type Status = "active" | "inactive" | "banned";
+enum Color { Red, Green, Blue }
+interface Circle { kind: "circle"; radius: number }
+interface Square { kind: "square"; size: number }
+type Shape = Circle | Square | { kind: 'triangle' };
+
+function status(s: Status) {
+	switch (s) {
+		case "active":
+		case 'inactive':
+			return 1;
+	}
+}
+
+function color(c: Color) {
+	switch (c) {
+		case Color.Red:
+			break;
+		default:
+			break;
+	}
+}
+
+function area(shape: Shape) {
+	switch (shape.kind) {
+		case "circle": return 1;
+		case "square": return 2;
+		case "triangle": return 3;
+	}
+}
+
+function inline(mode: "a" | "b") {
+	const local: Color = Color.Red;
+	switch (local) {
+		case Color.Green:
+	}
+	switch (mode) {
+		case "a":
+	}
+	switch (unknown) {
+		case 1:
+	}
+}
+`
+
+func TestSwitchCoverages(t *testing.T) {
+	a := New(parseSource(t, switchSource))
+
+	coverages := a.SwitchCoverages()
+	want := []struct {
+		typeName   string
+		missing    string
+		hasDefault bool
+	}{
+		{"Status", `"banned"`, false},
+		{"Color", "Color.Green,Color.Blue", true},
+		{"Shape.kind", "", false},
+		{"Color", "Color.Red,Color.Blue", false},
+		{"", `"b"`, false},
+	}
+	if len(coverages) != len(want) {
+		t.Fatalf("SwitchCoverages() returned %d switches, want %d", len(coverages), len(want))
+	}
+	for i, w := range want {
+		c := coverages[i]
+		if c.TypeName != w.typeName || strings.Join(c.Missing, ",") != w.missing || c.HasDefault != w.hasDefault {
+			t.Errorf("switch %d = %s missing [%s] default %v, want %s missing [%s] default %v",
+				i, c.TypeName, strings.Join(c.Missing, ","), c.HasDefault, w.typeName, w.missing, w.hasDefault)
+		}
+	}
+
+	if got := len(coverages[2].Values); got != 3 {
+		t.Errorf("Shape.kind has %d values, want 3", got)
+	}
+}
+
+func TestFindNonExhaustiveSwitches(t *testing.T) {
+	a := New(parseSource(t, switchSource))
+
+	var lines []uint32
+	for _, c := range a.FindNonExhaustiveSwitches() {
+		lines = append(lines, c.Range.Start.Line)
+	}
+	if len(lines) != 3 || lines[0] != 8 || lines[1] != 34 || lines[2] != 37 {
+		t.Errorf("FindNonExhaustiveSwitches() at lines %v, want [8 34 37]", lines)
+	}
+}
+
+func TestCheckSwitchStructured(t *testing.T) {
+	parser, err := tsgoast.New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+	defer parser.Close()
+
+	tree, err := parser.ParseTree([]byte(`enum Dir { Up, Down }
+const d: Dir = Dir.Up;
+switch (d) {
+	case Dir.Up:
+}`))
+	if err != nil {
+		t.Fatalf("ParseTree() error = %v", err)
+	}
+
+	var sw *ast.SwitchStatement
+	for _, stmt := range tree.Statements {
+		if s, ok := stmt.(*ast.SwitchStatement); ok {
+			sw = s
+		}
+	}
+	if sw == nil {
+		t.Fatal("no switch statement in tree")
+	}
+
+	c := New(tree.Root).CheckSwitch(sw)
+	if c == nil || c.IsExhaustive() || len(c.Missing) != 1 || c.Missing[0] != "Dir.Down" {
+		t.Errorf("CheckSwitch() = %+v, want Dir.Down missing", c)
+	}
+}
@@ -0,0 +1,220 @@
+package analyzer
+
+import (
+	"strings"
+
+	"github.com/ahmadramadhannn/tsgoast"
+	"github.com/ahmadramadhannn/tsgoast/ast"
+	"github.com/ahmadramadhannn/tsgoast/internal/syntax"
+)
+
+// SymbolKind is the kind of a DocumentSymbol, numbered as in the Language
+// Server Protocol.
+type SymbolKind int
+
+const (
+	SymbolKindFile SymbolKind = iota + 1
+	SymbolKindModule
+	SymbolKindNamespace
+	SymbolKindPackage
+	SymbolKindClass
+	SymbolKindMethod
+	SymbolKindProperty
+	SymbolKindField
+	SymbolKindConstructor
+	SymbolKindEnum
+	SymbolKindInterface
+	SymbolKindFunction
+	SymbolKindVariable
+	SymbolKindConstant
+	SymbolKindString
+	SymbolKindNumber
+	SymbolKindBoolean
+	SymbolKindArray
+	SymbolKindObject
+	SymbolKindKey
+	SymbolKindNull
+	SymbolKindEnumMember
+	SymbolKindStruct
+	SymbolKindEvent
+	SymbolKindOperator
+	SymbolKindTypeParameter
+)
+
+// LSPPosition is a zero-based line and UTF-16 character offset, as used by
+// the Language Server Protocol.
+type LSPPosition struct {
+	Line      uint32 `json:"line"`
+	Character uint32 `json:"character"`
+}
+
+// LSPRange is a range of LSP positions; End is exclusive.
+type LSPRange struct {
+	Start LSPPosition `json:"start"`
+	End   LSPPosition `json:"end"`
+}
+
+// DocumentSymbol is an entry of a document outline, encoded like the LSP
+// DocumentSymbol type.
+type DocumentSymbol struct {
+	Name string `json:"name"`
+
+	// Detail is the signature of functions and methods, e.g.
+	// "(id: string): User", or the type annotation of variables and
+	// properties.
+	Detail string     `json:"detail,omitempty"`
+	Kind   SymbolKind `json:"kind"`
+
+	// Range spans the whole declaration, including an export keyword;
+	// SelectionRange spans its name.
+	Range          LSPRange `json:"range"`
+	SelectionRange LSPRange `json:"selectionRange"`
+
+	Children []DocumentSymbol `json:"children,omitempty"`
+}
+
+// DocumentSymbols returns the outline of a file as a tree of symbols:
+// classes containing their members, interfaces their members, enums their
+// members and namespaces their declarations. Functions declared inside
+// functions are nested under them; local variables are omitted.
+func DocumentSymbols(tree *tsgoast.Tree) []DocumentSymbol {
+	if tree == nil || tree.Root == nil {
+		return nil
+	}
+	s := &symbolBuilder{index: ast.NewUTF16Index(tree.Root)}
+	return s.children(tree.Root, false)
+}
+
+// symbolBuilder converts declarations to document symbols.
+type symbolBuilder struct {
+	index *ast.UTF16Index
+}
+
+// children returns the symbols declared below node. inFunction is set
+// within function bodies, where variables are not reported.
+func (s *symbolBuilder) children(node ast.Node, inFunction bool) []DocumentSymbol {
+	var symbols []DocumentSymbol
+	for _, child := range node.Children() {
+		nested := inFunction || functionKinds[child.SyntaxKind()]
+		if symbol, ok := s.symbol(child, inFunction); ok {
+			symbol.Children = s.children(child, nested)
+			symbols = append(symbols, symbol)
+		} else {
+			symbols = append(symbols, s.children(child, nested)...)
+		}
+	}
+	return symbols
+}
+
+// symbol returns the document symbol declared by node, if any.
+func (s *symbolBuilder) symbol(node ast.Node, inFunction bool) (DocumentSymbol, bool) {
+	var kind SymbolKind
+	name := ast.ChildByField(node, "name")
+	detail := ""
+	extent := node
+
+	switch node.SyntaxKind() {
+	case "class_declaration", "abstract_class_declaration":
+		kind = SymbolKindClass
+	case "interface_declaration", "type_alias_declaration":
+		kind = SymbolKindInterface
+	case "enum_declaration":
+		kind = SymbolKindEnum
+	case "enum_assignment":
+		kind = SymbolKindEnumMember
+	case "property_identifier", "string":
+		if parent := node.Parent(); parent != nil && parent.SyntaxKind() == "enum_body" {
+			kind, name = SymbolKindEnumMember, node
+		}
+	case "internal_module":
+		kind = SymbolKindNamespace
+	case "module":
+		kind = SymbolKindModule
+	case "function_declaration", "generator_function_declaration", "function_signature":
+		kind, detail = SymbolKindFunction, signatureDetail(node)
+	case "method_definition":
+		kind, detail = SymbolKindMethod, signatureDetail(node)
+		switch {
+		case name != nil && name.Text() == "constructor":
+			kind = SymbolKindConstructor
+		case ast.FirstChildOfKind(node, "get") != nil, ast.FirstChildOfKind(node, "set") != nil:
+			kind = SymbolKindProperty
+		}
+	case "abstract_method_signature", "method_signature":
+		kind, detail = SymbolKindMethod, signatureDetail(node)
+	case "public_field_definition", "property_signature":
+		kind, detail = SymbolKindProperty, typeDetail(node)
+	case "variable_declarator":
+		if inFunction {
+			return DocumentSymbol{}, false
+		}
+		kind, detail = SymbolKindVariable, typeDetail(node)
+		if declaration := node.Parent(); declaration != nil {
+			if ast.FirstChildOfKind(declaration, "const") != nil {
+				kind = SymbolKindConstant
+			}
+			if len(ast.ChildrenOfKind(declaration, "variable_declarator")) == 1 {
+				extent = declaration
+			}
+		}
+		if value := ast.ChildByField(node, "value"); value != nil {
+			switch value.SyntaxKind() {
+			case "arrow_function", "function_expression", "generator_function":
+				kind, detail = SymbolKindFunction, signatureDetail(value)
+			case "class":
+				kind = SymbolKindClass
+			}
+		}
+	}
+	if kind == 0 || name == nil {
+		return DocumentSymbol{}, false
+	}
+
+	for parent := extent.Parent(); parent != nil; parent = extent.Parent() {
+		switch parent.SyntaxKind() {
+		case "export_statement", "ambient_declaration":
+		case "expression_statement": // namespace N {} is parsed as an expression
+			if node.SyntaxKind() != "internal_module" {
+				parent = nil
+			}
+		default:
+			parent = nil
+		}
+		if parent == nil {
+			break
+		}
+		extent = parent
+	}
+
+	return DocumentSymbol{
+		Name:           strings.Trim(name.Text(), `"'`),
+		Detail:         detail,
+		Kind:           kind,
+		Range:          s.lspRange(extent.Range()),
+		SelectionRange: s.lspRange(name.Range()),
+	}, true
+}
+
+// lspRange converts r to an LSP range.
+func (s *symbolBuilder) lspRange(r ast.Range) LSPRange {
+	return LSPRange{
+		Start: LSPPosition{Line: r.Start.Line, Character: s.index.Column(r.Start)},
+		End:   LSPPosition{Line: r.End.Line, Character: s.index.Column(r.End)},
+	}
+}
+
+// signatureDetail returns the parameter list and return type of a function,
+// e.g. "(id: string): User".
+func signatureDetail(fn ast.Node) string {
+	detail := syntax.FieldText(fn, "parameters")
+	if detail == "" {
+		detail = syntax.FieldText(fn, "parameter") // x => x
+	}
+	return detail + syntax.FieldText(fn, "return_type")
+}
+
+// typeDetail returns the type annotation of a variable or property without
+// its colon.
+func typeDetail(node ast.Node) string {
+	return strings.TrimSpace(strings.TrimPrefix(syntax.FieldText(node, "type"), ":"))
+}
@@ -0,0 +1,114 @@
+package analyzer
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ahmadramadhannn/tsgoast"
+)
+
+// outline flattens symbols into indented "kind name detail" lines.
+func outline(symbols []DocumentSymbol, indent string) []string {
+	var lines []string
+	for _, s := range symbols {
+		line := fmt.Sprintf("%s%d %s", indent, s.Kind, s.Name)
+		if s.Detail != "" {
+			line += " " + s.Detail
+		}
+		lines = append(lines, line)
+		lines = append(lines, outline(s.Children, indent+"  ")...)
+	}
+	return lines
+}
+
+func TestDocumentSymbols(t *testing.T) {
+	parser, err := tsgoast.New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+	defer parser.Close()
+
+	tree, err := parser.ParseTree([]byte(`export class Service {
+	private cache: Map<string, User>;
+	constructor() {}
+	get size() { return 0; }
+	fetch(id: string): User {
+		const local = 1;
+		function helper() {}
+		return null;
+	}
+}
+interface User { id: string; save(): void }
+type ID = string;
+enum Color { Red, Green = "g" }
+namespace Utils { export function clamp(x: number) { return x; } }
+export const handler = (e: Event) => {}, limit = 10;
+let count: number;
+declare module "lib" { function f(): void }
+`))
+	if err != nil {
+		t.Fatalf("ParseTree() error = %v", err)
+	}
+
+	symbols := DocumentSymbols(tree)
+
+	want := []string{
+		"5 Service",
+		"  7 cache Map<string, User>",
+		"  9 constructor ()",
+		"  7 size ()",
+		"  6 fetch (id: string): User",
+		"    12 helper ()",
+		"11 User",
+		"  7 id string",
+		"  6 save (): void",
+		"11 ID",
+		"10 Color",
+		"  22 Red",
+		"  22 Green",
+		"3 Utils",
+		"  12 clamp (x: number)",
+		"12 handler (e: Event)",
+		"14 limit",
+		"13 count number",
+		"2 lib",
+		"  12 f (): void",
+	}
+	if got := outline(symbols, ""); !reflect.DeepEqual(got, want) {
+		t.Errorf("DocumentSymbols() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	service := symbols[0]
+	if want := (LSPRange{Start: LSPPosition{0, 0}, End: LSPPosition{9, 1}}); service.Range != want {
+		t.Errorf("Service range = %v, want %v (including export)", service.Range, want)
+	}
+	if want := (LSPRange{Start: LSPPosition{0, 13}, End: LSPPosition{0, 20}}); service.SelectionRange != want {
+		t.Errorf("Service selection range = %v, want %v", service.SelectionRange, want)
+	}
+	if got := symbols[7].Range; got.Start != (LSPPosition{15, 0}) {
+		t.Errorf("count range starts at %v, want the let statement", got.Start)
+	}
+}
+
+func TestDocumentSymbolsUTF16(t *testing.T) {
+	parser, err := tsgoast.New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+	defer parser.Close()
+
+	tree, err := parser.ParseTree([]byte(`/* 😀 */ function greet() {}`))
+	if err != nil {
+		t.Fatalf("ParseTree() error = %v", err)
+	}
+
+	symbols := DocumentSymbols(tree)
+	if len(symbols) != 1 {
+		t.Fatalf("got %d symbols, want 1", len(symbols))
+	}
+	if want := (LSPRange{Start: LSPPosition{0, 18}, End: LSPPosition{0, 23}}); symbols[0].SelectionRange != want {
+		t.Errorf("selection range = %v, want %v", symbols[0].SelectionRange, want)
+	}
+}
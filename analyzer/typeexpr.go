@@ -0,0 +1,324 @@
+package analyzer
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+// TypeKind classifies a type expression.
+type TypeKind string
+
+// Type kind constants.
+const (
+	TypeKindPrimitive    TypeKind = "primitive"    // string, number, any, ...
+	TypeKindLiteral      TypeKind = "literal"      // "a", 42, true, null, undefined
+	TypeKindReference    TypeKind = "reference"    // Foo, ns.Foo, Foo<T>
+	TypeKindUnion        TypeKind = "union"        // A | B
+	TypeKindIntersection TypeKind = "intersection" // A & B
+	TypeKindObject       TypeKind = "object"       // { a: string }
+	TypeKindArray        TypeKind = "array"        // T[]
+	TypeKindTuple        TypeKind = "tuple"        // [A, B]
+	TypeKindFunction     TypeKind = "function"     // (a: A) => B
+	TypeKindOther        TypeKind = "other"        // keyof, conditional, mapped, ...
+)
+
+// TypeExpr is a structured representation of a type expression.
+type TypeExpr struct {
+	Kind TypeKind
+	Text string // source text of the type
+
+	// Name is the primitive name ("string") or the referenced type name
+	// ("Foo", "ns.Foo").
+	Name string
+
+	// TypeArguments holds the arguments of a generic reference.
+	TypeArguments []*TypeExpr
+
+	// Types holds union and intersection members and tuple elements.
+	Types []*TypeExpr
+
+	// Element is the element type of an array.
+	Element *TypeExpr
+
+	// Members holds the members of an object type.
+	Members []*TypeMember
+
+	// Parameters and ReturnType describe a function type.
+	Parameters []*ast.Parameter
+	ReturnType *TypeExpr
+
+	// IsOptional marks an optional tuple element (`[A, B?]`).
+	IsOptional bool
+
+	// IsReadonly marks a readonly array or tuple.
+	IsReadonly bool
+}
+
+// TypeMember is a property or method of an object type.
+type TypeMember struct {
+	Name       string
+	Type       *TypeExpr // function type for methods
+	IsOptional bool
+	IsReadonly bool
+	IsMethod   bool
+}
+
+// TypeAliasDefinition is the structured form of a type alias declaration.
+type TypeAliasDefinition struct {
+	Name           string
+	TypeParameters []string
+	Type           *TypeExpr
+}
+
+// GetTypeAliasDefinition returns the structured right-hand side of a type
+// alias declaration, or nil if node is not a type alias.
+func GetTypeAliasDefinition(node ast.Node) *TypeAliasDefinition {
+	if node == nil || node.SyntaxKind() != "type_alias_declaration" {
+		return nil
+	}
+
+	def := &TypeAliasDefinition{
+		Name:           declarationName(node),
+		TypeParameters: typeParametersOf(node),
+	}
+
+	// The aliased type follows the "=" token
+	afterEquals := false
+	for _, child := range node.Children() {
+		if afterEquals && child.SyntaxKind() != ";" {
+			def.Type = ParseTypeExpr(child)
+			break
+		}
+		afterEquals = child.SyntaxKind() == "="
+	}
+
+	return def
+}
+
+// ParseTypeExpr builds a TypeExpr from a type node. A type_annotation node
+// is unwrapped to the annotated type, and the body of an interface is
+// parsed as an object type.
+func ParseTypeExpr(node ast.Node) *TypeExpr {
+	if node == nil {
+		return nil
+	}
+
+	t := &TypeExpr{Kind: TypeKindOther, Text: node.Text()}
+	children := namedTypeChildren(node)
+
+	switch node.SyntaxKind() {
+	case "type_annotation", "parenthesized_type":
+		if len(children) == 1 {
+			return ParseTypeExpr(children[0])
+		}
+	case "predefined_type":
+		t.Kind = TypeKindPrimitive
+		t.Name = node.Text()
+	case "literal_type", "string", "number", "true", "false", "null", "undefined", "template_literal_type":
+		t.Kind = TypeKindLiteral
+	case "type_identifier", "nested_type_identifier", "identifier":
+		t.Kind = TypeKindReference
+		t.Name = node.Text()
+	case "generic_type":
+		t.Kind = TypeKindReference
+		for _, child := range children {
+			if child.SyntaxKind() == "type_arguments" {
+				for _, arg := range namedTypeChildren(child) {
+					t.TypeArguments = append(t.TypeArguments, ParseTypeExpr(arg))
+				}
+			} else if t.Name == "" {
+				t.Name = child.Text()
+			}
+		}
+	case "union_type", "intersection_type":
+		t.Kind = TypeKindUnion
+		if node.SyntaxKind() == "intersection_type" {
+			t.Kind = TypeKindIntersection
+		}
+		for _, child := range children {
+			member := ParseTypeExpr(child)
+			// A | B | C is parsed as (A | B) | C
+			if child.SyntaxKind() == node.SyntaxKind() {
+				t.Types = append(t.Types, member.Types...)
+			} else {
+				t.Types = append(t.Types, member)
+			}
+		}
+	case "array_type":
+		t.Kind = TypeKindArray
+		if len(children) > 0 {
+			t.Element = ParseTypeExpr(children[0])
+		}
+	case "tuple_type":
+		t.Kind = TypeKindTuple
+		for _, child := range children {
+			t.Types = append(t.Types, ParseTypeExpr(child))
+		}
+	case "optional_type":
+		if len(children) == 1 {
+			inner := ParseTypeExpr(children[0])
+			inner.IsOptional = true
+			inner.Text = node.Text()
+			return inner
+		}
+	case "readonly_type":
+		if len(children) == 1 {
+			inner := ParseTypeExpr(children[0])
+			inner.IsReadonly = true
+			inner.Text = node.Text()
+			return inner
+		}
+	case "object_type", "interface_body":
+		t.Kind = TypeKindObject
+		t.Members = objectTypeMembers(node)
+	case "function_type":
+		t.Kind = TypeKindFunction
+		t.Parameters = GetParameters(node)
+		for _, child := range children {
+			switch child.SyntaxKind() {
+			case "formal_parameters", "type_parameters":
+			default:
+				t.ReturnType = ParseTypeExpr(child)
+			}
+		}
+	}
+
+	return t
+}
+
+// objectTypeMembers returns the members of an object type.
+func objectTypeMembers(node ast.Node) []*TypeMember {
+	var members []*TypeMember
+	for _, member := range node.Children() {
+		switch member.SyntaxKind() {
+		case "property_signature":
+			m := &TypeMember{
+				Name:       declarationName(member),
+				IsOptional: ast.FirstChildOfKind(member, "?") != nil,
+				IsReadonly: ast.FirstChildOfKind(member, "readonly") != nil,
+			}
+			m.Type = ParseTypeExpr(ast.FirstChildOfKind(member, "type_annotation"))
+			members = append(members, m)
+		case "method_signature":
+			fn := &TypeExpr{
+				Kind:       TypeKindFunction,
+				Text:       member.Text(),
+				Parameters: GetParameters(member),
+				ReturnType: ParseTypeExpr(returnTypeNode(member)),
+			}
+			members = append(members, &TypeMember{
+				Name:       declarationName(member),
+				Type:       fn,
+				IsOptional: ast.FirstChildOfKind(member, "?") != nil,
+				IsMethod:   true,
+			})
+		}
+	}
+	return members
+}
+
+// returnTypeNode returns the return type annotation node of a function or
+// method signature.
+func returnTypeNode(fn ast.Node) ast.Node {
+	seenParams := false
+	for _, child := range fn.Children() {
+		switch child.SyntaxKind() {
+		case "formal_parameters":
+			seenParams = true
+		case "type_annotation":
+			if seenParams {
+				return child
+			}
+		}
+	}
+	return nil
+}
+
+// namedTypeChildren returns the children of a type node, skipping
+// punctuation and keyword tokens.
+func namedTypeChildren(node ast.Node) []ast.Node {
+	var children []ast.Node
+	for _, child := range node.Children() {
+		switch child.SyntaxKind() {
+		case "|", "&", "[", "]", "(", ")", "<", ">", ",", ":", "?", "=>", "readonly", "{", "}", ";":
+		default:
+			children = append(children, child)
+		}
+	}
+	return children
+}
+
+// Equal reports whether two type expressions are semantically equal:
+// whitespace is ignored and union/intersection members may appear in any
+// order.
+func (t *TypeExpr) Equal(other *TypeExpr) bool {
+	if t == nil || other == nil {
+		return t == other
+	}
+	return t.canonical() == other.canonical()
+}
+
+// canonical returns a normalized string form of the type.
+func (t *TypeExpr) canonical() string {
+	if t == nil {
+		return ""
+	}
+
+	var s string
+	switch t.Kind {
+	case TypeKindUnion, TypeKindIntersection:
+		parts := make([]string, len(t.Types))
+		for i, member := range t.Types {
+			parts[i] = member.canonical()
+		}
+		sort.Strings(parts)
+		sep := "|"
+		if t.Kind == TypeKindIntersection {
+			sep = "&"
+		}
+		s = "(" + strings.Join(parts, sep) + ")"
+	case TypeKindReference:
+		args := make([]string, len(t.TypeArguments))
+		for i, arg := range t.TypeArguments {
+			args[i] = arg.canonical()
+		}
+		s = t.Name
+		if len(args) > 0 {
+			s += "<" + strings.Join(args, ",") + ">"
+		}
+	case TypeKindArray:
+		s = t.Element.canonical() + "[]"
+	case TypeKindTuple:
+		parts := make([]string, len(t.Types))
+		for i, element := range t.Types {
+			parts[i] = element.canonical()
+		}
+		s = "[" + strings.Join(parts, ",") + "]"
+	case TypeKindObject:
+		parts := make([]string, len(t.Members))
+		for i, m := range t.Members {
+			part := m.Name
+			if m.IsReadonly {
+				part = "readonly " + part
+			}
+			if m.IsOptional {
+				part += "?"
+			}
+			parts[i] = part + ":" + m.Type.canonical()
+		}
+		sort.Strings(parts)
+		s = "{" + strings.Join(parts, ";") + "}"
+	default:
+		s = strings.Join(strings.Fields(t.Text), "")
+	}
+
+	if t.IsReadonly {
+		s = "readonly " + s
+	}
+	if t.IsOptional {
+		s += "?"
+	}
+	return s
+}
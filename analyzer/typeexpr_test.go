@@ -0,0 +1,121 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+func findTypeAliases(t *testing.T, source string) map[string]*TypeAliasDefinition {
+	t.Helper()
+
+	defs := make(map[string]*TypeAliasDefinition)
+	New(parseSource(t, source)).Visit(func(node ast.Node) bool {
+		if def := GetTypeAliasDefinition(node); def != nil {
+			defs[def.Name] = def
+		}
+		return true
+	})
+	return defs
+}
+
+func TestGetTypeAliasDefinition(t *testing.T) {
+	defs := findTypeAliases(t, `
+		type Status = "active" | "inactive" | null;
+		type Page<T> = { items: T[]; readonly total: number; next?(): Page<T> };
+		type Pair = Map<string, [number, boolean?]>;
+		type Handler = (event: Event) => void;
+		type Both = A & B;
+	`)
+
+	status := defs["Status"]
+	if status == nil || status.Type.Kind != TypeKindUnion || len(status.Type.Types) != 3 {
+		t.Fatalf("Status = %+v", status)
+	}
+	for _, member := range status.Type.Types {
+		if member.Kind != TypeKindLiteral {
+			t.Errorf("Status member %q kind = %s, want literal", member.Text, member.Kind)
+		}
+	}
+
+	page := defs["Page"]
+	if page == nil || page.Type.Kind != TypeKindObject || len(page.TypeParameters) != 1 {
+		t.Fatalf("Page = %+v", page)
+	}
+	if len(page.Type.Members) != 3 {
+		t.Fatalf("Page members = %d, want 3", len(page.Type.Members))
+	}
+	items, total, next := page.Type.Members[0], page.Type.Members[1], page.Type.Members[2]
+	if items.Type.Kind != TypeKindArray || items.Type.Element.Name != "T" {
+		t.Errorf("items = %+v", items.Type)
+	}
+	if !total.IsReadonly || total.Type.Name != "number" {
+		t.Errorf("total = %+v", total)
+	}
+	if !next.IsMethod || !next.IsOptional || next.Type.ReturnType.Name != "Page" {
+		t.Errorf("next = %+v", next)
+	}
+
+	pair := defs["Pair"].Type
+	if pair.Kind != TypeKindReference || pair.Name != "Map" || len(pair.TypeArguments) != 2 {
+		t.Fatalf("Pair = %+v", pair)
+	}
+	if tuple := pair.TypeArguments[1]; tuple.Kind != TypeKindTuple || len(tuple.Types) != 2 || !tuple.Types[1].IsOptional {
+		t.Errorf("Pair tuple = %+v", tuple)
+	}
+
+	handler := defs["Handler"].Type
+	if handler.Kind != TypeKindFunction || len(handler.Parameters) != 1 || handler.ReturnType.Name != "void" {
+		t.Errorf("Handler = %+v", handler)
+	}
+
+	if both := defs["Both"].Type; both.Kind != TypeKindIntersection || len(both.Types) != 2 {
+		t.Errorf("Both = %+v", both)
+	}
+}
+
+func TestTypeExprEqual(t *testing.T) {
+	defs := findTypeAliases(t, `
+		type A = "x" | "y" | Foo<string>;
+		type B = Foo< string > | "y" | "x";
+		type C = "x" | "z";
+		type D = { a: string; b?: number };
+		type E = { b?: number; a: string };
+		type F = { a: string; b: number };
+	`)
+
+	tests := []struct {
+		left, right string
+		want        bool
+	}{
+		{"A", "B", true},
+		{"A", "C", false},
+		{"D", "E", true},
+		{"D", "F", false},
+	}
+
+	for _, tt := range tests {
+		if got := defs[tt.left].Type.Equal(defs[tt.right].Type); got != tt.want {
+			t.Errorf("%s.Equal(%s) = %v, want %v", tt.left, tt.right, got, tt.want)
+		}
+	}
+}
+
+func TestParseTypeExprInterfaceBody(t *testing.T) {
+	root := parseSource(t, "interface User { readonly id: number; name?: string; greet(): void }")
+	body := ast.FindDescendant(root, func(n ast.Node) bool { return n.SyntaxKind() == "interface_body" })
+
+	typ := ParseTypeExpr(body)
+	if typ.Kind != TypeKindObject || len(typ.Members) != 3 {
+		t.Fatalf("ParseTypeExpr(interface_body) = %+v", typ)
+	}
+	if m := typ.Members[0]; m.Name != "id" || !m.IsReadonly || m.Type.Name != "number" {
+		t.Errorf("member 0 = %+v", m)
+	}
+	if m := typ.Members[1]; m.Name != "name" || !m.IsOptional {
+		t.Errorf("member 1 = %+v", m)
+	}
+	if m := typ.Members[2]; !m.IsMethod {
+		t.Errorf("member 2 = %+v", m)
+	}
+}
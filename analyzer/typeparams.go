@@ -0,0 +1,126 @@
+package analyzer
+
+import (
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+// TypeParameterUsage describes how often a declared type parameter is
+// referenced within its declaration.
+type TypeParameterUsage struct {
+	Name string
+
+	// Declaration is the generic function, method, class, interface or type
+	// alias declaring the parameter.
+	Declaration     ast.Node
+	DeclarationName string
+
+	// Uses counts references in parameters, return types, constraints of
+	// other type parameters and bodies.
+	Uses int
+
+	Node  ast.Node // the type_parameter node
+	Range ast.Range
+}
+
+// IsFunctionLike reports whether the type parameter belongs to a function,
+// method or call signature rather than a type declaration.
+func (u TypeParameterUsage) IsFunctionLike() bool {
+	switch u.Declaration.SyntaxKind() {
+	case "class_declaration", "abstract_class_declaration", "class",
+		"interface_declaration", "type_alias_declaration":
+		return false
+	}
+	return true
+}
+
+// TypeParameterUsages returns the usage of every type parameter declared in
+// the AST, in source order. References inside nested declarations that
+// redeclare the same name are not counted.
+func (a *Analyzer) TypeParameterUsages() []TypeParameterUsage {
+	var usages []TypeParameterUsage
+	a.Visit(func(node ast.Node) bool {
+		if node.SyntaxKind() != "type_parameters" || node.Parent() == nil {
+			return true
+		}
+		decl := node.Parent()
+		for _, param := range node.Children() {
+			if param.SyntaxKind() != "type_parameter" {
+				continue
+			}
+			name := ast.FirstChildOfKind(param, "type_identifier")
+			if name == nil {
+				continue
+			}
+			usages = append(usages, TypeParameterUsage{
+				Name:            name.Text(),
+				Declaration:     decl,
+				DeclarationName: declarationName(decl),
+				Uses:            countTypeReferences(decl, name.Text(), name),
+				Node:            param,
+				Range:           param.Range(),
+			})
+		}
+		return true
+	})
+	return usages
+}
+
+// FindUnusedTypeParameters finds type parameters that are never referenced,
+// such as T in `function f<T>(x: number)`.
+func (a *Analyzer) FindUnusedTypeParameters() []TypeParameterUsage {
+	var unused []TypeParameterUsage
+	for _, u := range a.TypeParameterUsages() {
+		if u.Uses == 0 {
+			unused = append(unused, u)
+		}
+	}
+	return unused
+}
+
+// FindSingleUseTypeParameters finds type parameters of functions and methods
+// that are referenced exactly once, such as T in `function f<T>(x: T): void`.
+// These can usually be replaced by their constraint (or unknown). Type
+// parameters of classes, interfaces and type aliases are not reported, since
+// they are part of the type's public shape.
+func (a *Analyzer) FindSingleUseTypeParameters() []TypeParameterUsage {
+	var single []TypeParameterUsage
+	for _, u := range a.TypeParameterUsages() {
+		if u.Uses == 1 && u.IsFunctionLike() {
+			single = append(single, u)
+		}
+	}
+	return single
+}
+
+// countTypeReferences counts type_identifier references to name within decl,
+// excluding the declaring identifier and nested declarations that shadow it.
+func countTypeReferences(decl ast.Node, name string, declaring ast.Node) int {
+	count := 0
+	visitSubtree(decl, func(node ast.Node) bool {
+		if node != decl && declaresTypeParameter(node, name) {
+			return false
+		}
+		if node != declaring && node.SyntaxKind() == "type_identifier" && node.Text() == name {
+			count++
+		}
+		return true
+	})
+	return count
+}
+
+// declaresTypeParameter checks if node has its own type parameter called name.
+func declaresTypeParameter(node ast.Node, name string) bool {
+	params := ast.FirstChildOfKind(node, "type_parameters")
+	if params == nil {
+		return false
+	}
+	for _, param := range params.Children() {
+		if param.SyntaxKind() != "type_parameter" {
+			continue
+		}
+		if id := ast.FirstChildOfKind(param, "type_identifier"); id != nil && id.Text() == name {
+			return true
+		}
+	}
+	return false
+}
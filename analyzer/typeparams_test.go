@@ -0,0 +1,94 @@
+package analyzer
+
+import (
+	"testing"
+)
+
+const typeParamSource = `
+function unused<T>(x: number): number { return x; }
+function single<T>(x: T): void {}
+function identity<T>(x: T): T { return x; }
+function keyed<T, K extends keyof T>(obj: T, key: K) { return obj[key]; }
+function cast<T>(x: unknown) { return x as T; }
+const arrow = <U>(items: U[]) => items.length;
+function shadowed<T>(x: number) {
+	function inner<T>(y: T): T { return y; }
+}
+class Box<T> { value: T; }
+interface Empty<T> {}
+type Wrapper<T> = { value: T };
+class Repo {
+	find<E>(id: string): Promise<E[]> { return db.find(id); }
+}
+`
+
+func TestTypeParameterUsages(t *testing.T) {
+	a := New(parseSource(t, typeParamSource))
+
+	usages := a.TypeParameterUsages()
+	want := []struct {
+		decl string
+		name string
+		uses int
+	}{
+		{"unused", "T", 0},
+		{"single", "T", 1},
+		{"identity", "T", 2},
+		{"keyed", "T", 2},
+		{"keyed", "K", 1},
+		{"cast", "T", 1},
+		{"", "U", 1},
+		{"shadowed", "T", 0},
+		{"inner", "T", 2},
+		{"Box", "T", 1},
+		{"Empty", "T", 0},
+		{"Wrapper", "T", 1},
+		{"find", "E", 1},
+	}
+	if len(usages) != len(want) {
+		t.Fatalf("TypeParameterUsages() returned %d usages, want %d", len(usages), len(want))
+	}
+	for i, w := range want {
+		u := usages[i]
+		if u.DeclarationName != w.decl || u.Name != w.name || u.Uses != w.uses {
+			t.Errorf("usage %d = %s<%s> used %d times, want %s<%s> used %d times",
+				i, u.DeclarationName, u.Name, u.Uses, w.decl, w.name, w.uses)
+		}
+	}
+}
+
+func TestFindUnusedTypeParameters(t *testing.T) {
+	a := New(parseSource(t, typeParamSource))
+
+	var decls []string
+	for _, u := range a.FindUnusedTypeParameters() {
+		decls = append(decls, u.DeclarationName)
+	}
+	want := []string{"unused", "shadowed", "Empty"}
+	if len(decls) != len(want) {
+		t.Fatalf("FindUnusedTypeParameters() = %v, want %v", decls, want)
+	}
+	for i := range want {
+		if decls[i] != want[i] {
+			t.Errorf("unused[%d] = %s, want %s", i, decls[i], want[i])
+		}
+	}
+}
+
+func TestFindSingleUseTypeParameters(t *testing.T) {
+	a := New(parseSource(t, typeParamSource))
+
+	var names []string
+	for _, u := range a.FindSingleUseTypeParameters() {
+		names = append(names, u.DeclarationName+"<"+u.Name+">")
+	}
+	want := []string{"single<T>", "keyed<K>", "cast<T>", "<U>", "find<E>"}
+	if len(names) != len(want) {
+		t.Fatalf("FindSingleUseTypeParameters() = %v, want %v", names, want)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Errorf("single[%d] = %s, want %s", i, names[i], want[i])
+		}
+	}
+}
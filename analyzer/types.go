@@ -7,12 +7,12 @@ import (
 )
 
 // FindInterfaces finds all interface declarations in the AST.
-func (a *Analyzer) FindInterfaces() []ast.Node {
+func (a *Analyzer) FindInterfaces() NodeList {
 	return a.FindNodesByType(ast.NodeTypeInterface)
 }
 
 // FindTypeAliases finds all type alias declarations in the AST.
-func (a *Analyzer) FindTypeAliases() []ast.Node {
+func (a *Analyzer) FindTypeAliases() NodeList {
 	return a.FindNodesByType(ast.NodeTypeTypeAlias)
 }
 
@@ -23,13 +23,7 @@ func GetInterfaceName(node ast.Node) string {
 	}
 
 	// Look for identifier child nodes
-	for _, child := range node.Children() {
-		if child.Type() == ast.NodeTypeIdentifier {
-			return child.Text()
-		}
-	}
-
-	return ""
+	return findIdentifierInChildren(node)
 }
 
 // GetTypeAliasName attempts to extract the type alias name.
@@ -39,16 +33,14 @@ func GetTypeAliasName(node ast.Node) string {
 	}
 
 	// Look for identifier child nodes
-	for _, child := range node.Children() {
-		if child.Type() == ast.NodeTypeIdentifier {
-			return child.Text()
-		}
-	}
-
-	return ""
+	return findIdentifierInChildren(node)
 }
 
 // HasExtends checks if an interface extends another interface.
+//
+// Deprecated: HasExtends matches " extends " anywhere in the interface text,
+// including generic constraints and member types. Inspect the
+// extends_type_clause of the declaration instead.
 func HasExtends(node ast.Node) bool {
 	if node == nil || node.Type() != ast.NodeTypeInterface {
 		return false
@@ -69,6 +61,10 @@ func IsReadonly(node ast.Node) bool {
 }
 
 // IsOptionalProperty checks if a property is optional.
+//
+// Deprecated: IsOptionalProperty matches "?:" anywhere in the node text.
+// Use GetInterfaceMembers, which reports PropertySignature.IsOptional from
+// the parse tree.
 func IsOptionalProperty(node ast.Node) bool {
 	if node == nil {
 		return false
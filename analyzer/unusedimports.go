@@ -0,0 +1,104 @@
+package analyzer
+
+import (
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+// UnusedImport is a binding introduced by an import declaration that is
+// never referenced in the file.
+type UnusedImport struct {
+	Name      string // local name of the binding
+	Specifier string // module specifier of the import
+
+	// Statement is the import declaration; Node is the identifier of a
+	// default import, the namespace_import or the import_specifier.
+	Statement ast.Node
+	Node      ast.Node
+	Range     ast.Range
+}
+
+// ImportBinding is a binding introduced by an import declaration.
+type ImportBinding struct {
+	Name string   // local name
+	Node ast.Node // identifier, namespace_import or import_specifier
+}
+
+// ImportBindings returns the bindings introduced by an import declaration,
+// in source order: the default import, the namespace import and the named
+// imports. Side-effect imports and import = require declarations have
+// none.
+func ImportBindings(stmt ast.Node) []ImportBinding {
+	clause := ast.FirstChildOfKind(stmt, "import_clause")
+	if clause == nil {
+		return nil
+	}
+	var bindings []ImportBinding
+	for _, child := range clause.Children() {
+		switch child.SyntaxKind() {
+		case "identifier":
+			bindings = append(bindings, ImportBinding{Name: child.Text(), Node: child})
+		case "namespace_import":
+			if id := ast.FirstChildOfKind(child, "identifier"); id != nil {
+				bindings = append(bindings, ImportBinding{Name: id.Text(), Node: child})
+			}
+		case "named_imports":
+			for _, spec := range ast.ChildrenOfKind(child, "import_specifier") {
+				name := ast.ChildByField(spec, "alias")
+				if name == nil {
+					name = ast.ChildByField(spec, "name")
+				}
+				if name != nil {
+					bindings = append(bindings, ImportBinding{Name: name.Text(), Node: spec})
+				}
+			}
+		}
+	}
+	return bindings
+}
+
+// FindUnusedImports finds the imported bindings that are never referenced
+// outside of import declarations, in source order. References are matched
+// by name, so a binding shadowed by a local declaration of the same name
+// counts as used. With JSX in the file, React counts as used, as the
+// classic JSX runtime refers to it.
+func (a *Analyzer) FindUnusedImports() []UnusedImport {
+	used := make(map[string]bool)
+	var imports []ast.Node
+	a.Visit(func(node ast.Node) bool {
+		switch node.SyntaxKind() {
+		case "import_statement":
+			imports = append(imports, node)
+			return false
+		case "export_statement":
+			// Names re-exported from another module are not local
+			if ast.ChildByField(node, "source") != nil {
+				return false
+			}
+		case "identifier", "type_identifier", "shorthand_property_identifier":
+			used[node.Text()] = true
+		case "jsx_element", "jsx_self_closing_element", "jsx_fragment":
+			used["React"] = true
+		}
+		return true
+	})
+
+	var unused []UnusedImport
+	for _, stmt := range imports {
+		var specifier string
+		if source := ast.ChildByField(stmt, "source"); source != nil {
+			specifier = stringLiteralValue(source)
+		}
+		for _, b := range ImportBindings(stmt) {
+			if !used[b.Name] {
+				unused = append(unused, UnusedImport{
+					Name:      b.Name,
+					Specifier: specifier,
+					Statement: stmt,
+					Node:      b.Node,
+					Range:     b.Node.Range(),
+				})
+			}
+		}
+	}
+	return unused
+}
@@ -0,0 +1,38 @@
+package analyzer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFindUnusedImports(t *testing.T) {
+	root := parseTSXSource(t, `
+import React, { useState, useEffect as effect } from "react";
+import * as path from "path";
+import * as fs from "fs";
+import type { Props, State } from "./types";
+import { helper, other } from "./util";
+import "./polyfill";
+export { helper as default } from "./util";
+export { other };
+
+function App(props: Props) {
+	const [x] = useState(path.join("a"));
+	return <div />;
+}
+`)
+
+	var got []string
+	for _, u := range New(root).FindUnusedImports() {
+		got = append(got, u.Name+" "+u.Specifier+" "+u.Node.SyntaxKind())
+	}
+	want := []string{
+		"effect react import_specifier",
+		"fs fs namespace_import",
+		"State ./types import_specifier",
+		"helper ./util import_specifier",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FindUnusedImports() =\n%q\nwant\n%q", got, want)
+	}
+}
@@ -0,0 +1,94 @@
+package analyzer
+
+import (
+	"strings"
+
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+// UnusedVariable is a variable declared with var, let or const that is
+// never referenced.
+type UnusedVariable struct {
+	Name string
+
+	// Declaration is the variable_declarator; Node is the identifier
+	// binding the name, possibly nested in a destructuring pattern.
+	Declaration ast.Node
+	Node        ast.Node
+	Range       ast.Range
+}
+
+// FindUnusedVariables finds the variables that are never referenced in
+// their scope, the enclosing function or the file, in source order.
+// Destructuring patterns bind each of their names. References are matched
+// by name, like FindUnusedImports, so a variable shadowed by another of
+// the same name counts as used. Exported variables, and names starting
+// with an underscore, by convention unused on purpose, are not reported.
+func (a *Analyzer) FindUnusedVariables() []UnusedVariable {
+	var declarators []ast.Node
+	a.Visit(func(node ast.Node) bool {
+		if node.SyntaxKind() == "variable_declarator" {
+			declarators = append(declarators, node)
+		}
+		return true
+	})
+
+	counts := make(map[ast.Node]map[string]int) // names referenced, by scope
+	var unused []UnusedVariable
+	for _, decl := range declarators {
+		if stmt := decl.Parent(); stmt != nil && stmt.Parent() != nil && stmt.Parent().SyntaxKind() == "export_statement" {
+			continue
+		}
+		scope := enclosingFunction(decl)
+		if scope == nil {
+			scope = a.root
+		}
+		if counts[scope] == nil {
+			counts[scope] = identifierCounts(scope)
+		}
+		for _, binding := range bindingIdentifiers(ast.ChildByField(decl, "name")) {
+			name := binding.Text()
+			if strings.HasPrefix(name, "_") || counts[scope][name] > 1 {
+				continue
+			}
+			unused = append(unused, UnusedVariable{Name: name, Declaration: decl, Node: binding, Range: binding.Range()})
+		}
+	}
+	return unused
+}
+
+// identifierCounts returns the number of identifiers of each name in the
+// subtree of node, bindings included.
+func identifierCounts(node ast.Node) map[string]int {
+	counts := make(map[string]int)
+	visitSubtree(node, func(n ast.Node) bool {
+		switch n.SyntaxKind() {
+		case "identifier", "shorthand_property_identifier", "shorthand_property_identifier_pattern":
+			counts[n.Text()]++
+		}
+		return true
+	})
+	return counts
+}
+
+// bindingIdentifiers returns the identifiers bound by a declarator name:
+// the name itself, or the names of a destructuring pattern, leaving out
+// their property keys and default values.
+func bindingIdentifiers(name ast.Node) []ast.Node {
+	if name == nil {
+		return nil
+	}
+	switch name.SyntaxKind() {
+	case "identifier", "shorthand_property_identifier_pattern":
+		return []ast.Node{name}
+	case "pair_pattern":
+		return bindingIdentifiers(ast.ChildByField(name, "value"))
+	case "assignment_pattern", "object_assignment_pattern":
+		return bindingIdentifiers(ast.ChildByField(name, "left"))
+	}
+	var bindings []ast.Node
+	for _, child := range name.Children() {
+		bindings = append(bindings, bindingIdentifiers(child)...)
+	}
+	return bindings
+}
@@ -0,0 +1,44 @@
+package analyzer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFindUnusedVariables(t *testing.T) {
+	root := parseSource(t, `
+const used = 1;
+const unused = 2;
+let _ignored = 3;
+export const exported = 4;
+var { a, b: renamed, c = used, ...rest } = obj;
+const [first, , third] = list;
+
+function f(x) {
+	const local = x;
+	const shadow = 1;
+	const inner = () => local + rest;
+	return inner() + a + third;
+}
+
+function g() {
+	const shadow = 2;
+	return { shadow };
+}
+`)
+
+	var got []string
+	for _, u := range New(root).FindUnusedVariables() {
+		got = append(got, u.Name+" "+u.Node.SyntaxKind())
+	}
+	want := []string{
+		"unused identifier",
+		"renamed identifier",
+		"c shorthand_property_identifier_pattern",
+		"first identifier",
+		"shadow identifier",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FindUnusedVariables() =\n%q\nwant\n%q", got, want)
+	}
+}
@@ -0,0 +1,81 @@
+package tsgoast
+
+import (
+	"sync"
+
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+// SetArena sets whether the parser allocates the nodes of each tree from
+// an arena: one slab holding every node and one holding every child list,
+// rather than two allocations per node. Services parsing thousands of
+// files a minute spend much less time in the garbage collector, which
+// scans a few objects per tree rather than two per node, and Tree.Release
+// hands the slabs over to later parses.
+//
+// The slabs are freed together, once no node of the tree is reachable: a
+// single node kept from a large tree keeps all of them. Lazy parsers do
+// not use arenas.
+func (p *Parser) SetArena(arena bool) {
+	p.arena = arena
+}
+
+// Release makes the memory of a tree parsed with an arena available to
+// later parses, rather than waiting for the garbage collector to free it,
+// and clears t. Neither t nor any node or statement taken from it may be
+// used afterwards. Release does nothing but clear t for other trees.
+func (t *Tree) Release() {
+	if t.slab != nil {
+		t.slab.release()
+	}
+	*t = Tree{}
+}
+
+// slab holds the nodes of a tree and their child lists.
+type slab struct {
+	nodes []ast.BaseNode
+	lists []ast.Node // child lists of the nodes, one after another
+}
+
+// slabs holds released slabs for reuse.
+var slabs sync.Pool
+
+// newSlab returns a slab for a tree of n nodes, which have n-1 children
+// between them.
+func newSlab(n int) *slab {
+	s, _ := slabs.Get().(*slab)
+	if s == nil || cap(s.nodes) < n {
+		return &slab{nodes: make([]ast.BaseNode, 0, n), lists: make([]ast.Node, 0, n)}
+	}
+	return s
+}
+
+// node returns a new node of the slab. Should the slab be full, because
+// the tree has more nodes than counted, the node is allocated on its own.
+func (s *slab) node() *ast.BaseNode {
+	if len(s.nodes) == cap(s.nodes) {
+		return new(ast.BaseNode)
+	}
+	s.nodes = s.nodes[:len(s.nodes)+1]
+	return &s.nodes[len(s.nodes)-1]
+}
+
+// children returns an empty child list with room for n children. Appending
+// more than n children moves the list out of the slab.
+func (s *slab) children(n int) []ast.Node {
+	start := len(s.lists)
+	if start+n > cap(s.lists) {
+		return make([]ast.Node, 0, n)
+	}
+	s.lists = s.lists[:start+n]
+	return s.lists[start : start : start+n]
+}
+
+// release clears the slab, not to keep the strings and nodes it refers to
+// alive, and puts it in the pool.
+func (s *slab) release() {
+	clear(s.nodes)
+	clear(s.lists)
+	s.nodes, s.lists = s.nodes[:0], s.lists[:0]
+	slabs.Put(s)
+}
@@ -0,0 +1,100 @@
+package tsgoast
+
+import (
+	"encoding/json"
+	"runtime"
+	"testing"
+)
+
+func TestParseArena(t *testing.T) {
+	source := largeSource(t, 16<<10)
+	parser, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer parser.Close()
+	want, err := parser.ParseTree(source)
+	if err != nil {
+		t.Fatalf("ParseTree() error = %v", err)
+	}
+	wantJSON, err := json.Marshal(want)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	parser.SetArena(true)
+	for i := 0; i < 3; i++ {
+		tree, err := parser.ParseTree(source)
+		if err != nil {
+			t.Fatalf("ParseTree() error = %v", err)
+		}
+		got, err := json.Marshal(tree)
+		if err != nil {
+			t.Fatalf("Marshal() error = %v", err)
+		}
+		if string(got) != string(wantJSON) {
+			t.Fatalf("ParseTree() with an arena differs from ParseTree(), run %d", i)
+		}
+		if len(tree.Statements) != len(want.Statements) {
+			t.Errorf("ParseTree() with an arena = %d statements, want %d", len(tree.Statements), len(want.Statements))
+		}
+		tree.Release()
+		if tree.Root != nil || tree.Statements != nil {
+			t.Error("Release() did not clear the tree")
+		}
+	}
+
+	// The garbage collector has a few objects to scan per tree, rather
+	// than two per node
+	arenaObjects := retainedObjects(t, parser, source)
+	parser.SetArena(false)
+	heapObjects := retainedObjects(t, parser, source)
+	if arenaObjects*10 > heapObjects {
+		t.Errorf("ParseTree() with an arena retains %d objects, without %d", arenaObjects, heapObjects)
+	}
+}
+
+// retainedObjects returns the number of heap objects retained by the tree
+// of source.
+func retainedObjects(t *testing.T, parser *Parser, source []byte) int {
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	tree, err := parser.ParseTree(source)
+	if err != nil {
+		t.Fatalf("ParseTree() error = %v", err)
+	}
+	runtime.GC()
+	runtime.ReadMemStats(&after)
+	runtime.KeepAlive(tree)
+	return int(after.HeapObjects) - int(before.HeapObjects)
+}
+
+// BenchmarkParseArena parses a 1MB file with and without an arena,
+// releasing each tree before the next parse.
+func BenchmarkParseArena(b *testing.B) {
+	source := largeSource(b, 1<<20)
+	parser, err := New()
+	if err != nil {
+		b.Fatalf("Failed to create parser: %v", err)
+	}
+	defer parser.Close()
+
+	for _, arena := range []bool{false, true} {
+		name := "heap"
+		if arena {
+			name = "arena"
+		}
+		b.Run(name, func(b *testing.B) {
+			parser.SetArena(arena)
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				tree, err := parser.ParseTree(source)
+				if err != nil {
+					b.Fatalf("ParseTree error: %v", err)
+				}
+				tree.Release()
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package ast
+
+// FirstChildOfType returns the first direct child of node with the given
+// type, or nil.
+func FirstChildOfType(node Node, t NodeType) Node {
+	return FirstChild(node, func(child Node) bool { return child.Type() == t })
+}
+
+// ChildrenOfType returns the direct children of node with the given type.
+func ChildrenOfType(node Node, t NodeType) []Node {
+	return Children(node, func(child Node) bool { return child.Type() == t })
+}
+
+// FirstChildOfKind returns the first direct child of node with the given
+// tree-sitter kind (e.g. "formal_parameters"), or nil.
+func FirstChildOfKind(node Node, kind string) Node {
+	return FirstChild(node, func(child Node) bool { return child.SyntaxKind() == kind })
+}
+
+// ChildrenOfKind returns the direct children of node with the given
+// tree-sitter kind.
+func ChildrenOfKind(node Node, kind string) []Node {
+	return Children(node, func(child Node) bool { return child.SyntaxKind() == kind })
+}
+
+// FirstChild returns the first direct child of node matching predicate, or
+// nil.
+func FirstChild(node Node, predicate func(Node) bool) Node {
+	if node == nil {
+		return nil
+	}
+	for _, child := range node.Children() {
+		if child != nil && predicate(child) {
+			return child
+		}
+	}
+	return nil
+}
+
+// Children returns the direct children of node matching predicate.
+func Children(node Node, predicate func(Node) bool) []Node {
+	if node == nil {
+		return nil
+	}
+	var result []Node
+	for _, child := range node.Children() {
+		if child != nil && predicate(child) {
+			result = append(result, child)
+		}
+	}
+	return result
+}
+
+// FindDescendant returns the first descendant of node, in depth-first
+// pre-order, matching predicate, or nil. The node itself is not considered.
+func FindDescendant(node Node, predicate func(Node) bool) Node {
+	for n := range Descendants(node) {
+		if predicate(n) {
+			return n
+		}
+	}
+	return nil
+}
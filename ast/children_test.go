@@ -0,0 +1,44 @@
+package ast
+
+import (
+	"testing"
+)
+
+func TestChildAccessors(t *testing.T) {
+	fn := &BaseNode{NodeType: NodeTypeFunction, TreeSitterKind: "function_declaration"}
+	name := &BaseNode{NodeType: NodeTypeIdentifier, TreeSitterKind: "identifier", Content: "f", ParentNode: fn}
+	params := &BaseNode{NodeType: NodeTypeParameter, TreeSitterKind: "formal_parameters", ParentNode: fn}
+	a := &BaseNode{NodeType: NodeTypeParameter, TreeSitterKind: "required_parameter", Content: "a", ParentNode: params}
+	b := &BaseNode{NodeType: NodeTypeParameter, TreeSitterKind: "required_parameter", Content: "b", ParentNode: params}
+	params.ChildNodes = []Node{a, b}
+	fn.ChildNodes = []Node{name, params}
+
+	if got := FirstChildOfType(fn, NodeTypeIdentifier); got != name {
+		t.Errorf("FirstChildOfType(identifier) = %v, want name", got)
+	}
+	if got := FirstChildOfType(fn, NodeTypeLiteral); got != nil {
+		t.Errorf("FirstChildOfType(literal) = %v, want nil", got)
+	}
+	if got := ChildrenOfType(params, NodeTypeParameter); len(got) != 2 {
+		t.Errorf("ChildrenOfType(parameter) returned %d nodes, want 2", len(got))
+	}
+
+	if got := FirstChildOfKind(fn, "formal_parameters"); got != params {
+		t.Errorf("FirstChildOfKind(formal_parameters) = %v, want params", got)
+	}
+	if got := ChildrenOfKind(params, "required_parameter"); len(got) != 2 || got[1] != b {
+		t.Errorf("ChildrenOfKind(required_parameter) = %v", got)
+	}
+
+	found := FindDescendant(fn, func(n Node) bool { return n.Text() == "b" })
+	if found != b {
+		t.Errorf("FindDescendant() = %v, want b", found)
+	}
+	if FindDescendant(fn, func(n Node) bool { return n == fn }) != nil {
+		t.Error("FindDescendant() should not consider the node itself")
+	}
+
+	if FirstChildOfKind(nil, "x") != nil || ChildrenOfType(nil, NodeTypeLiteral) != nil {
+		t.Error("accessors on nil nodes should return nil")
+	}
+}
@@ -0,0 +1,51 @@
+package ast
+
+// fielder is implemented by nodes that know the tree-sitter field under
+// which they are stored in their parent.
+type fielder interface {
+	Field() string
+}
+
+// Field returns the tree-sitter field under which the node is stored in its
+// parent (e.g. "name", "body", "parameters"), or an empty string.
+func (n *BaseNode) Field() string {
+	return n.FieldName
+}
+
+// FieldOf returns the tree-sitter field under which node is stored in its
+// parent, or an empty string if it has none or node does not know it.
+func FieldOf(node Node) string {
+	if f, ok := node.(fielder); ok {
+		return f.Field()
+	}
+	return ""
+}
+
+// ChildByField returns the first child of node stored in the given
+// tree-sitter field, or nil. For example, ChildByField(fn, "body") returns
+// the statement block of a function declaration.
+func ChildByField(node Node, field string) Node {
+	return FirstChild(node, func(child Node) bool {
+		f, ok := child.(fielder)
+		return ok && f.Field() == field
+	})
+}
+
+// ChildrenByField returns the children of node stored in the given
+// tree-sitter field, such as the "decorator" children of a class.
+func ChildrenByField(node Node, field string) []Node {
+	return Children(node, func(child Node) bool {
+		f, ok := child.(fielder)
+		return ok && f.Field() == field
+	})
+}
+
+// ChildByField returns the first child stored in the given field, or nil.
+func (n *BaseNode) ChildByField(field string) Node {
+	return ChildByField(n, field)
+}
+
+// ChildrenByField returns the children stored in the given field.
+func (n *BaseNode) ChildrenByField(field string) []Node {
+	return ChildrenByField(n, field)
+}
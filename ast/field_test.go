@@ -0,0 +1,38 @@
+package ast
+
+import (
+	"testing"
+)
+
+func TestChildByField(t *testing.T) {
+	fn := &BaseNode{TreeSitterKind: "function_declaration"}
+	keyword := &BaseNode{TreeSitterKind: "function", ParentNode: fn}
+	name := &BaseNode{TreeSitterKind: "identifier", FieldName: "name", Content: "f", ParentNode: fn}
+	params := &BaseNode{TreeSitterKind: "formal_parameters", FieldName: "parameters", ParentNode: fn}
+	body := &BaseNode{TreeSitterKind: "statement_block", FieldName: "body", ParentNode: fn}
+	fn.ChildNodes = []Node{keyword, name, params, body}
+
+	if got := fn.ChildByField("name"); got != name {
+		t.Errorf("ChildByField(name) = %v, want name", got)
+	}
+	if got := ChildByField(fn, "body"); got != body {
+		t.Errorf("ChildByField(body) = %v, want body", got)
+	}
+	if got := fn.ChildByField("return_type"); got != nil {
+		t.Errorf("ChildByField(return_type) = %v, want nil", got)
+	}
+	if name.Field() != "name" || keyword.Field() != "" {
+		t.Errorf("Field() = %q, %q", name.Field(), keyword.Field())
+	}
+	if FieldOf(name) != "name" || FieldOf(keyword) != "" || FieldOf(nil) != "" {
+		t.Errorf("FieldOf() = %q, %q, %q", FieldOf(name), FieldOf(keyword), FieldOf(nil))
+	}
+
+	class := &BaseNode{TreeSitterKind: "class_declaration"}
+	d1 := &BaseNode{FieldName: "decorator", ParentNode: class}
+	d2 := &BaseNode{FieldName: "decorator", ParentNode: class}
+	class.ChildNodes = []Node{d1, d2, &BaseNode{FieldName: "name", ParentNode: class}}
+	if got := class.ChildrenByField("decorator"); len(got) != 2 || got[1] != d2 {
+		t.Errorf("ChildrenByField(decorator) = %v", got)
+	}
+}
@@ -0,0 +1,68 @@
+package ast
+
+import "encoding/gob"
+
+// init registers the concrete node types with encoding/gob, so values of
+// the Node and Statement interfaces can be gob-encoded.
+func init() {
+	for _, node := range []any{
+		&BaseNode{},
+		&ExpressionNode{},
+		&IdentifierNode{},
+		&LiteralNode{},
+		&FunctionNode{},
+		&ArrowFunctionNode{},
+		&MethodNode{},
+		&VariableStatement{},
+		&VariableDeclarator{},
+		&FunctionDeclaration{},
+		&ClassDeclaration{},
+		&ClassBody{},
+		&ExpressionStatement{},
+		&IfStatement{},
+		&WhileStatement{},
+		&ForStatement{},
+		&ForInStatement{},
+		&ForOfStatement{},
+		&SwitchStatement{},
+		&SwitchCase{},
+		&TryStatement{},
+		&CatchClause{},
+		&ThrowStatement{},
+		&ReturnStatement{},
+		&BreakStatement{},
+		&ContinueStatement{},
+		&BlockStatement{},
+		&EmptyStatement{},
+		&LabeledStatement{},
+		&WithStatement{},
+		&DebuggerStatement{},
+		&ImportDeclaration{},
+		&ExportDeclaration{},
+		&EnumDeclaration{},
+		&EnumMember{},
+		&NamespaceDeclaration{},
+		&InterfaceNode{},
+		&TypeAliasNode{},
+	} {
+		gob.Register(node)
+	}
+}
+
+// GobEncode encodes the node and its subtree for encoding/gob, using the
+// MessagePack encoding of MarshalMsgpack. Parent pointers, which gob cannot
+// represent, are restored by GobDecode.
+//
+// The typed node types embed BaseNode and share this encoding, so only
+// their syntax tree is stored: a decoded *FunctionDeclaration has its
+// children, text and range but not its Name or Parameters. Use
+// tsgoast.EncodeTree and DecodeTree to persist trees with their typed
+// statements.
+func (n *BaseNode) GobEncode() ([]byte, error) {
+	return n.MarshalMsgpack()
+}
+
+// GobDecode decodes a subtree encoded by GobEncode.
+func (n *BaseNode) GobDecode(data []byte) error {
+	return n.UnmarshalMsgpack(data)
+}
@@ -0,0 +1,35 @@
+package ast
+
+import (
+	"bytes"
+	"encoding/gob"
+	"testing"
+)
+
+func TestGobNodeInterface(t *testing.T) {
+	root := newTestTree()
+	stmt := &ExpressionStatement{BaseNode: BaseNode{NodeType: NodeTypeExpression, Content: "x;"}}
+
+	// Registered types can be stored in Node-typed fields
+	type entry struct{ Nodes []Node }
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(entry{Nodes: []Node{root, stmt}}); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	var decoded entry
+	if err := gob.NewDecoder(&buf).Decode(&decoded); err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if len(decoded.Nodes) != 2 {
+		t.Fatalf("decoded %d nodes, want 2", len(decoded.Nodes))
+	}
+
+	got := decoded.Nodes[0]
+	a := got.Children()[0]
+	if got.Text() != "root" || a.Text() != "a" || a.Children()[1].Text() != "a2" || a.Parent() != got {
+		t.Errorf("decoded tree = %+v", got)
+	}
+	if s, ok := decoded.Nodes[1].(*ExpressionStatement); !ok || s.Text() != "x;" || s.Type() != NodeTypeExpression {
+		t.Errorf("decoded statement = %#v", decoded.Nodes[1])
+	}
+}
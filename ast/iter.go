@@ -0,0 +1,45 @@
+package ast
+
+import "iter"
+
+// Preorder returns an iterator over node and all of its descendants in
+// depth-first pre-order. Breaking out of the loop stops the traversal.
+func Preorder(node Node) iter.Seq[Node] {
+	return func(yield func(Node) bool) {
+		if node == nil {
+			return
+		}
+		stack := []Node{node}
+		for len(stack) > 0 {
+			current := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if !yield(current) {
+				return
+			}
+			children := current.Children()
+			for i := len(children) - 1; i >= 0; i-- {
+				if children[i] != nil {
+					stack = append(stack, children[i])
+				}
+			}
+		}
+	}
+}
+
+// Descendants returns an iterator over the descendants of node, excluding
+// node itself, in depth-first pre-order.
+func Descendants(node Node) iter.Seq[Node] {
+	return func(yield func(Node) bool) {
+		for n := range Preorder(node) {
+			if n != node && !yield(n) {
+				return
+			}
+		}
+	}
+}
+
+// Descendants returns an iterator over the descendants of the node in
+// depth-first pre-order.
+func (n *BaseNode) Descendants() iter.Seq[Node] {
+	return Descendants(n)
+}
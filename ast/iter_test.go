@@ -0,0 +1,46 @@
+package ast
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPreorder(t *testing.T) {
+	var got []string
+	for n := range Preorder(newTestTree()) {
+		got = append(got, n.Text())
+	}
+	want := []string{"root", "a", "a1", "a2", "b"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Preorder() = %v, want %v", got, want)
+	}
+
+	for range Preorder(nil) {
+		t.Error("Preorder(nil) yielded a node")
+	}
+}
+
+func TestDescendants(t *testing.T) {
+	root := newTestTree()
+
+	var got []string
+	for n := range root.Descendants() {
+		got = append(got, n.Text())
+		if n.Text() == "a2" {
+			break
+		}
+	}
+	want := []string{"a", "a1", "a2"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Descendants() with break = %v, want %v", got, want)
+	}
+
+	a := root.Children()[0]
+	got = nil
+	for n := range Descendants(a) {
+		got = append(got, n.Text())
+	}
+	if want := []string{"a1", "a2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Descendants(a) = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,99 @@
+package ast
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// MaxJSONDepth is the depth of the deepest node MarshalJSON encodes, the
+// root being at depth 0. Each level of nodes nests two JSON values, an
+// object and its children array, and encoding/json decodes at most 10000
+// nested values, so deeper trees would encode but not decode. The margin
+// leaves room for the documents embedding a tree.
+const MaxJSONDepth = 4096
+
+// jsonNode is the serialized form of a node. Parent pointers are not
+// stored; they are restored from the nesting when decoding.
+type jsonNode struct {
+	Type     NodeType   `json:"type"`
+	Kind     string     `json:"kind,omitempty"`
+	Field    string     `json:"field,omitempty"`
+	Text     string     `json:"text"`
+	Range    Range      `json:"range"`
+	Children []jsonNode `json:"children,omitempty"`
+}
+
+// MarshalJSON encodes the node and its subtree as nested JSON objects with
+// "type", "kind", "field", "text", "range" and "children" keys. Nodes other
+// than *BaseNode are encoded through the Node interface, so typed
+// statements are serialized as plain syntax nodes. Trees nested deeper
+// than MaxJSONDepth are refused with an error.
+func (n *BaseNode) MarshalJSON() ([]byte, error) {
+	j, err := toJSONNode(n, 0)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(j)
+}
+
+// UnmarshalJSON decodes a subtree produced by MarshalJSON, rebuilding
+// parent pointers. The receiver becomes the root of the decoded subtree
+// and has no parent.
+func (n *BaseNode) UnmarshalJSON(data []byte) error {
+	var j jsonNode
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+	*n = BaseNode{}
+	n.fromJSONNode(&j)
+	return nil
+}
+
+// toJSONNode converts a node at depth and its subtree to the serialized
+// form.
+func toJSONNode(node Node, depth int) (jsonNode, error) {
+	if depth > MaxJSONDepth {
+		return jsonNode{}, fmt.Errorf("json: node nested deeper than %d levels at offset %d", MaxJSONDepth, node.Range().Start.Offset)
+	}
+	j := jsonNode{
+		Type:  node.Type(),
+		Kind:  node.SyntaxKind(),
+		Text:  node.Text(),
+		Range: node.Range(),
+	}
+	if f, ok := node.(fielder); ok {
+		j.Field = f.Field()
+	}
+	if children := node.Children(); len(children) > 0 {
+		j.Children = make([]jsonNode, 0, len(children))
+		for _, child := range children {
+			if child == nil {
+				continue
+			}
+			c, err := toJSONNode(child, depth+1)
+			if err != nil {
+				return jsonNode{}, err
+			}
+			j.Children = append(j.Children, c)
+		}
+	}
+	return j, nil
+}
+
+// fromJSONNode fills n from the serialized form, creating its children.
+func (n *BaseNode) fromJSONNode(j *jsonNode) {
+	n.NodeType = j.Type
+	n.KindID, n.TreeSitterKind = InternKind(j.Kind)
+	n.FieldName = j.Field
+	n.Content = j.Text
+	n.SourceRange = j.Range
+	if len(j.Children) == 0 {
+		return
+	}
+	n.ChildNodes = make([]Node, len(j.Children))
+	for i := range j.Children {
+		child := &BaseNode{ParentNode: n}
+		child.fromJSONNode(&j.Children[i])
+		n.ChildNodes[i] = child
+	}
+}
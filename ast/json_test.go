@@ -0,0 +1,98 @@
+package ast
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestBaseNodeJSONRoundTrip(t *testing.T) {
+	root := newTestTree()
+
+	data, err := json.Marshal(root)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	var decoded BaseNode
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	var want, got []Node
+	for n := range Preorder(root) {
+		want = append(want, n)
+	}
+	for n := range Preorder(&decoded) {
+		got = append(got, n)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("decoded %d nodes, want %d", len(got), len(want))
+	}
+	for i := range want {
+		w, g := want[i], got[i]
+		if g.Type() != w.Type() || g.SyntaxKind() != w.SyntaxKind() || g.Text() != w.Text() ||
+			!reflect.DeepEqual(g.Range(), w.Range()) {
+			t.Errorf("node %d = %+v, want %+v", i, g, w)
+		}
+		if i > 0 && g.Parent() == nil {
+			t.Errorf("node %d has no parent", i)
+		}
+		for _, child := range g.Children() {
+			if child.Parent() != g {
+				t.Errorf("child of node %d has wrong parent", i)
+			}
+		}
+	}
+	if decoded.Parent() != nil {
+		t.Errorf("decoded root has parent %v", decoded.Parent())
+	}
+}
+
+func TestBaseNodeJSONField(t *testing.T) {
+	parent := &BaseNode{TreeSitterKind: "function_declaration"}
+	name := &BaseNode{TreeSitterKind: "identifier", FieldName: "name", Content: "f", ParentNode: parent}
+	parent.ChildNodes = []Node{name}
+
+	data, err := json.Marshal(parent)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	var decoded BaseNode
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if got := decoded.ChildByField("name"); got == nil || got.Text() != "f" {
+		t.Errorf("ChildByField(name) = %v, want f", got)
+	}
+
+	if err := json.Unmarshal([]byte(`{"children": 1}`), &decoded); err == nil {
+		t.Error("Unmarshal() of invalid input should fail")
+	}
+}
+
+func TestBaseNodeJSONDepth(t *testing.T) {
+	chain := func(depth int) *BaseNode {
+		root := &BaseNode{TreeSitterKind: "program"}
+		node := root
+		for range depth {
+			child := &BaseNode{TreeSitterKind: "parenthesized_expression", ParentNode: node}
+			node.ChildNodes = []Node{child}
+			node = child
+		}
+		return root
+	}
+
+	data, err := json.Marshal(chain(MaxJSONDepth))
+	if err != nil {
+		t.Fatalf("Marshal() of %d levels error = %v", MaxJSONDepth, err)
+	}
+	var decoded BaseNode
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal() of %d levels error = %v", MaxJSONDepth, err)
+	}
+
+	if _, err := json.Marshal(chain(MaxJSONDepth + 1)); err == nil {
+		t.Errorf("Marshal() of %d levels should fail", MaxJSONDepth+1)
+	}
+}
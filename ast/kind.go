@@ -0,0 +1,180 @@
+package ast
+
+import (
+	"sync"
+)
+
+// Kind is an interned tree-sitter kind: a small integer standing for a
+// kind name such as "call_expression", the same for every grammar. The
+// kinds of common nodes are constants, for switch-based dispatch; the
+// others are numbered as they are met, so their values may differ between
+// runs of a program.
+type Kind uint16
+
+// Kind constants of common nodes.
+const (
+	KindUnknown Kind = iota // no kind, or too many kinds to intern
+	KindError               // "ERROR", a syntax error
+	KindProgram
+	KindComment
+	KindIdentifier
+	KindPropertyIdentifier
+	KindShorthandPropertyIdentifier
+	KindTypeIdentifier
+	KindString
+	KindTemplateString
+	KindNumber
+	KindTrue
+	KindFalse
+	KindNull
+	KindUndefined
+	KindThis
+	KindSuper
+	KindFunctionDeclaration
+	KindGeneratorFunctionDeclaration
+	KindFunctionExpression
+	KindArrowFunction
+	KindMethodDefinition
+	KindClassDeclaration
+	KindAbstractClassDeclaration
+	KindClass
+	KindClassBody
+	KindPublicFieldDefinition
+	KindInterfaceDeclaration
+	KindTypeAliasDeclaration
+	KindEnumDeclaration
+	KindLexicalDeclaration
+	KindVariableDeclaration
+	KindVariableDeclarator
+	KindImportStatement
+	KindExportStatement
+	KindExpressionStatement
+	KindStatementBlock
+	KindIfStatement
+	KindForStatement
+	KindForInStatement
+	KindWhileStatement
+	KindDoStatement
+	KindSwitchStatement
+	KindTryStatement
+	KindReturnStatement
+	KindThrowStatement
+	KindCallExpression
+	KindNewExpression
+	KindMemberExpression
+	KindSubscriptExpression
+	KindAssignmentExpression
+	KindBinaryExpression
+	KindUnaryExpression
+	KindTernaryExpression
+	KindAwaitExpression
+	KindObject
+	KindArray
+	KindPair
+	KindDecorator
+	KindFormalParameters
+	KindRequiredParameter
+	KindOptionalParameter
+	KindTypeAnnotation
+
+	numKindConstants
+)
+
+// kindConstantNames are the names of the Kind constants, in order.
+var kindConstantNames = [numKindConstants]string{
+	"", "ERROR", "program", "comment", "identifier", "property_identifier",
+	"shorthand_property_identifier", "type_identifier", "string",
+	"template_string", "number", "true", "false", "null", "undefined", "this",
+	"super", "function_declaration", "generator_function_declaration",
+	"function_expression", "arrow_function", "method_definition",
+	"class_declaration", "abstract_class_declaration", "class", "class_body",
+	"public_field_definition", "interface_declaration",
+	"type_alias_declaration", "enum_declaration", "lexical_declaration",
+	"variable_declaration", "variable_declarator", "import_statement",
+	"export_statement", "expression_statement", "statement_block",
+	"if_statement", "for_statement", "for_in_statement", "while_statement",
+	"do_statement", "switch_statement", "try_statement", "return_statement",
+	"throw_statement", "call_expression", "new_expression",
+	"member_expression", "subscript_expression", "assignment_expression",
+	"binary_expression", "unary_expression", "ternary_expression",
+	"await_expression", "object", "array", "pair", "decorator",
+	"formal_parameters", "required_parameter", "optional_parameter",
+	"type_annotation",
+}
+
+// kinds is the table of interned kinds.
+var kinds = struct {
+	sync.RWMutex
+	ids   map[string]Kind
+	names []string
+}{ids: make(map[string]Kind)}
+
+func init() {
+	for i, name := range kindConstantNames {
+		kinds.ids[name] = Kind(i)
+		kinds.names = append(kinds.names, name)
+	}
+}
+
+// InternKind returns the Kind of a tree-sitter kind name and its canonical
+// string, interning the name if it is new. Nodes holding the canonical
+// string share its bytes. Once 65535 kinds are interned, new names are
+// returned as they are with KindUnknown.
+func InternKind(name string) (Kind, string) {
+	kinds.RLock()
+	k, ok := kinds.ids[name]
+	if ok {
+		name = kinds.names[k]
+	}
+	kinds.RUnlock()
+	if ok {
+		return k, name
+	}
+
+	kinds.Lock()
+	defer kinds.Unlock()
+	if k, ok := kinds.ids[name]; ok {
+		return k, kinds.names[k]
+	}
+	if len(kinds.names) > 0xFFFF {
+		return KindUnknown, name
+	}
+	k = Kind(len(kinds.names))
+	kinds.ids[name] = k
+	kinds.names = append(kinds.names, name)
+	return k, name
+}
+
+// kindName returns the name of k.
+func kindName(k Kind) string {
+	kinds.RLock()
+	defer kinds.RUnlock()
+	if int(k) < len(kinds.names) {
+		return kinds.names[k]
+	}
+	return ""
+}
+
+// String returns the tree-sitter kind name of k, or an empty string for
+// KindUnknown and kinds never interned.
+func (k Kind) String() string {
+	if k < numKindConstants {
+		return kindConstantNames[k]
+	}
+	return kindName(k)
+}
+
+// KindOf returns the interned kind of node: the KindID of its BaseNode, or
+// else the Kind of its SyntaxKind.
+func KindOf(node Node) Kind {
+	if node == nil {
+		return KindUnknown
+	}
+	if b, ok := node.(interface{ Base() *BaseNode }); ok {
+		if id := b.Base().KindID; id != KindUnknown {
+			return id
+		}
+	}
+	k, _ := InternKind(node.SyntaxKind())
+	return k
+}
@@ -0,0 +1,50 @@
+package ast
+
+import (
+	"testing"
+	"unsafe"
+)
+
+func TestInternKind(t *testing.T) {
+	for k := KindUnknown; k < numKindConstants; k++ {
+		if got, name := InternKind(k.String()); got != k || name != k.String() {
+			t.Errorf("InternKind(%q) = %d, %q, want %d", k.String(), got, name, k)
+		}
+	}
+
+	id, name := InternKind("satisfies_expression")
+	if id < numKindConstants {
+		t.Errorf("InternKind() = %d, want a new kind", id)
+	}
+	again, canonical := InternKind(string([]byte("satisfies_expression")))
+	if again != id || unsafe.StringData(canonical) != unsafe.StringData(name) {
+		t.Error("InternKind() of the same name did not return the canonical kind")
+	}
+	if id.String() != "satisfies_expression" {
+		t.Errorf("String() = %q", id.String())
+	}
+	if Kind(0xFFFF).String() != "" {
+		t.Error("String() of a kind never interned is not empty")
+	}
+}
+
+func TestKindOf(t *testing.T) {
+	parsed := &BaseNode{TreeSitterKind: "identifier", KindID: KindIdentifier}
+	built := &BaseNode{TreeSitterKind: "call_expression"}
+	typed := &FunctionDeclaration{BaseNode: BaseNode{TreeSitterKind: "function_declaration"}}
+
+	tests := []struct {
+		node Node
+		want Kind
+	}{
+		{parsed, KindIdentifier},
+		{built, KindCallExpression},
+		{typed, KindFunctionDeclaration},
+		{nil, KindUnknown},
+	}
+	for _, tt := range tests {
+		if got := KindOf(tt.node); got != tt.want {
+			t.Errorf("KindOf(%v) = %v, want %v", tt.node, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package ast
+
+import (
+	"fmt"
+
+	"github.com/ahmadramadhannn/tsgoast/internal/msgpack"
+)
+
+// msgpackNodeLen is the number of elements of an encoded node.
+const msgpackNodeLen = 11
+
+// MarshalMsgpack encodes the node and its subtree in MessagePack, a compact
+// binary alternative to JSON suited to on-disk caches and message queues.
+//
+// The encoding is the array [source, strings, root]: the source text of the
+// node, a table of node types, kinds and field names (entry 0 is ""), and
+// the root node. Each node is the array
+//
+//	[type, kind, field, startLine, startColumn, startOffset,
+//	 endLine, endColumn, endOffset, text, children]
+//
+// where type, kind and field index the string table, text is nil when it
+// equals the node's slice of the source, and children is an array of
+// nodes. Nodes other than *BaseNode are encoded through the Node interface.
+func (n *BaseNode) MarshalMsgpack() ([]byte, error) {
+	table := newStringTable()
+	for node := range Preorder(n) {
+		table.intern(string(node.Type()))
+		table.intern(node.SyntaxKind())
+		table.intern(FieldOf(node))
+	}
+
+	e := &msgpackEncoder{source: n.Content, base: n.SourceRange.Start.Offset, strings: table}
+	buf := msgpack.AppendArray(nil, 3)
+	buf = msgpack.AppendString(buf, e.source)
+	buf = msgpack.AppendArray(buf, len(table.strings))
+	for _, s := range table.strings {
+		buf = msgpack.AppendString(buf, s)
+	}
+	return e.appendNode(buf, n), nil
+}
+
+// UnmarshalMsgpack decodes data produced by MarshalMsgpack, rebuilding
+// parent pointers. The receiver becomes the root of the decoded subtree and
+// has no parent.
+func (n *BaseNode) UnmarshalMsgpack(data []byte) error {
+	r := &msgpack.Reader{B: data}
+	if err := r.ExpectArray(3); err != nil {
+		return err
+	}
+	d := &msgpackDecoder{}
+	var err error
+	if d.source, err = r.Str(); err != nil {
+		return err
+	}
+	count, err := r.Array()
+	if err != nil {
+		return err
+	}
+	for range count {
+		s, err := r.Str()
+		if err != nil {
+			return err
+		}
+		d.strings = append(d.strings, s)
+	}
+
+	*n = BaseNode{}
+	if err := d.node(r, n); err != nil {
+		return err
+	}
+	if len(r.B) > 0 {
+		return fmt.Errorf("msgpack: %d trailing bytes", len(r.B))
+	}
+	if err := fillSourceText(n, d.source, n.SourceRange.Start.Offset, d.explicit); err != nil {
+		return fmt.Errorf("msgpack: %w", err)
+	}
+	return nil
+}
+
+// msgpackEncoder holds the state of MarshalMsgpack.
+type msgpackEncoder struct {
+	source  string
+	base    uint32
+	strings *stringTable
+}
+
+// appendNode appends the encoding of node and its subtree.
+func (e *msgpackEncoder) appendNode(buf []byte, node Node) []byte {
+	r := node.Range()
+	buf = msgpack.AppendArray(buf, msgpackNodeLen)
+	for _, v := range []uint64{
+		e.strings.index[string(node.Type())],
+		e.strings.index[node.SyntaxKind()],
+		e.strings.index[FieldOf(node)],
+		uint64(r.Start.Line), uint64(r.Start.Column), uint64(r.Start.Offset),
+		uint64(r.End.Line), uint64(r.End.Column), uint64(r.End.Offset),
+	} {
+		buf = msgpack.AppendUint(buf, v)
+	}
+	if text, ok := sourceSlice(e.source, e.base, r); ok && text == node.Text() {
+		buf = append(buf, msgpack.Nil)
+	} else {
+		buf = msgpack.AppendString(buf, node.Text())
+	}
+
+	children := 0
+	for _, child := range node.Children() {
+		if child != nil {
+			children++
+		}
+	}
+	buf = msgpack.AppendArray(buf, children)
+	for _, child := range node.Children() {
+		if child != nil {
+			buf = e.appendNode(buf, child)
+		}
+	}
+	return buf
+}
+
+// msgpackDecoder holds the state of UnmarshalMsgpack.
+type msgpackDecoder struct {
+	source  string
+	strings []string
+
+	// explicit records the nodes whose text was encoded explicitly.
+	explicit map[*BaseNode]bool
+}
+
+// node decodes a node into n, creating its children.
+func (d *msgpackDecoder) node(r *msgpack.Reader, n *BaseNode) error {
+	if err := r.ExpectArray(msgpackNodeLen); err != nil {
+		return err
+	}
+	var values [9]uint32
+	for i := range values {
+		v, err := r.Unsigned()
+		if err != nil {
+			return err
+		}
+		if v > 1<<32-1 || i < 3 && v >= uint64(len(d.strings)) {
+			return fmt.Errorf("msgpack: value %d out of range", v)
+		}
+		values[i] = uint32(v)
+	}
+	n.NodeType = NodeType(d.strings[values[0]])
+	n.KindID, n.TreeSitterKind = InternKind(d.strings[values[1]])
+	n.FieldName = d.strings[values[2]]
+	n.SourceRange = Range{
+		Start: Position{Line: values[3], Column: values[4], Offset: values[5]},
+		End:   Position{Line: values[6], Column: values[7], Offset: values[8]},
+	}
+
+	if !r.SkipNil() {
+		text, err := r.Str()
+		if err != nil {
+			return err
+		}
+		n.Content = text
+		if d.explicit == nil {
+			d.explicit = make(map[*BaseNode]bool)
+		}
+		d.explicit[n] = true
+	}
+
+	count, err := r.Array()
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		n.ChildNodes = make([]Node, 0, min(count, len(r.B)))
+	}
+	for range count {
+		child := &BaseNode{ParentNode: n}
+		if err := d.node(r, child); err != nil {
+			return err
+		}
+		n.ChildNodes = append(n.ChildNodes, child)
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package ast
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestBaseNodeMsgpackRoundTrip(t *testing.T) {
+	root := newTestTree()
+	root.ChildNodes[1].(*BaseNode).FieldName = "right"
+	root.ChildNodes[1].(*BaseNode).Content = strings.Repeat("long text ", 40)
+	root.SourceRange = Range{End: Position{Line: 70000, Column: 300, Offset: 1 << 33 >> 2}}
+
+	data, err := root.MarshalMsgpack()
+	if err != nil {
+		t.Fatalf("MarshalMsgpack() error = %v", err)
+	}
+
+	var decoded BaseNode
+	if err := decoded.UnmarshalMsgpack(data); err != nil {
+		t.Fatalf("UnmarshalMsgpack() error = %v", err)
+	}
+
+	var want, got []Node
+	for n := range Preorder(root) {
+		want = append(want, n)
+	}
+	for n := range Preorder(&decoded) {
+		got = append(got, n)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("decoded %d nodes, want %d", len(got), len(want))
+	}
+	for i := range want {
+		w, g := want[i], got[i]
+		if g.Type() != w.Type() || g.SyntaxKind() != w.SyntaxKind() || g.Text() != w.Text() ||
+			!reflect.DeepEqual(g.Range(), w.Range()) || FieldOf(g) != FieldOf(w) {
+			t.Errorf("node %d = %+v, want %+v", i, g, w)
+		}
+		if i > 0 && g.Parent() == nil {
+			t.Errorf("node %d has no parent", i)
+		}
+	}
+
+	for _, bad := range [][]byte{nil, data[:len(data)-1], append(data, 0)} {
+		if err := decoded.UnmarshalMsgpack(bad); err == nil {
+			t.Errorf("UnmarshalMsgpack(%d bytes): expected error", len(bad))
+		}
+	}
+}
@@ -21,15 +21,15 @@ const (
 
 // Position represents a position in the source code.
 type Position struct {
-	Line   uint32
-	Column uint32
-	Offset uint32
+	Line   uint32 `json:"line"`
+	Column uint32 `json:"column"`
+	Offset uint32 `json:"offset"`
 }
 
 // Range represents a range in the source code.
 type Range struct {
-	Start Position
-	End   Position
+	Start Position `json:"start"`
+	End   Position `json:"end"`
 }
 
 // Node is the interface that all AST nodes implement.
@@ -37,6 +37,10 @@ type Node interface {
 	// Type returns the type of the node.
 	Type() NodeType
 
+	// SyntaxKind returns the original tree-sitter kind of the node
+	// (e.g. "import_statement"), or an empty string if unknown.
+	SyntaxKind() string
+
 	// Text returns the text content of the node.
 	Text() string
 
@@ -52,11 +56,32 @@ type Node interface {
 
 // BaseNode provides common functionality for all AST nodes.
 type BaseNode struct {
-	NodeType    NodeType
-	Content     string
-	ChildNodes  []Node
-	SourceRange Range
-	ParentNode  Node
+	NodeType       NodeType
+	TreeSitterKind string
+	KindID         Kind   // interned TreeSitterKind, set by the parser (see KindOf)
+	FieldName      string // field of the parent holding this node, e.g. "name"
+	Content        string // text; parsed nodes share one copy of the source
+	ChildNodes     []Node // nil until Children is called, for nodes converted lazily
+	SourceRange    Range
+	ParentNode     Node
+
+	lazy *lazyChildren
+}
+
+// lazyChildren loads the children of a node on first access. It is shared
+// by the copies of the node, such as the typed statements built from it.
+type lazyChildren struct {
+	load     func() []Node
+	children []Node
+}
+
+// SetChildLoader makes the node load its children with load the first
+// time Children is called, replacing ChildNodes. Parsers use it to convert
+// trees lazily (see tsgoast.Parser.SetLazy). Loading is not synchronized:
+// a node with a child loader must not be accessed concurrently before its
+// children are loaded.
+func (n *BaseNode) SetChildLoader(load func() []Node) {
+	n.lazy = &lazyChildren{load: load}
 }
 
 // Type returns the type of the node.
@@ -64,6 +89,11 @@ func (n *BaseNode) Type() NodeType {
 	return n.NodeType
 }
 
+// SyntaxKind returns the original tree-sitter kind of the node.
+func (n *BaseNode) SyntaxKind() string {
+	return n.TreeSitterKind
+}
+
 // Text returns the text content of the node.
 func (n *BaseNode) Text() string {
 	return n.Content
@@ -71,6 +101,14 @@ func (n *BaseNode) Text() string {
 
 // Children returns the child nodes.
 func (n *BaseNode) Children() []Node {
+	if n.lazy != nil {
+		if n.lazy.load != nil {
+			n.lazy.children = n.lazy.load()
+			n.lazy.load = nil
+		}
+		n.ChildNodes = n.lazy.children
+		n.lazy = nil
+	}
 	return n.ChildNodes
 }
 
@@ -83,3 +121,10 @@ func (n *BaseNode) Range() Range {
 func (n *BaseNode) Parent() Node {
 	return n.ParentNode
 }
+
+// Base returns the node itself. Typed nodes inherit it from their embedded
+// BaseNode, which gives generic code access to the common fields of any
+// node, e.g. to rewire children and parents during a transformation.
+func (n *BaseNode) Base() *BaseNode {
+	return n
+}
@@ -36,6 +36,15 @@ func TestBaseNode(t *testing.T) {
 		}
 	})
 
+	t.Run("SyntaxKind", func(t *testing.T) {
+		node := &BaseNode{
+			TreeSitterKind: "function_declaration",
+		}
+		if got := node.SyntaxKind(); got != "function_declaration" {
+			t.Errorf("SyntaxKind() = %v, want function_declaration", got)
+		}
+	})
+
 	t.Run("Text", func(t *testing.T) {
 		content := "function test() {}"
 		node := &BaseNode{
@@ -150,3 +159,27 @@ func TestNodeHierarchy(t *testing.T) {
 		t.Error("Child2 parent is incorrect")
 	}
 }
+
+func TestSetChildLoader(t *testing.T) {
+	loads := 0
+	node := &BaseNode{TreeSitterKind: "program"}
+	node.SetChildLoader(func() []Node {
+		loads++
+		return []Node{&BaseNode{TreeSitterKind: "comment", ParentNode: node}}
+	})
+	copied := *node // as typed statements copy their node
+
+	if node.ChildNodes != nil {
+		t.Fatal("SetChildLoader() loaded the children")
+	}
+	if got := node.Children(); len(got) != 1 || got[0].SyntaxKind() != "comment" {
+		t.Fatalf("Children() = %v, want one comment", got)
+	}
+	node.Children()
+	if got := copied.Children(); len(got) != 1 || got[0] != node.ChildNodes[0] {
+		t.Errorf("Children() of the copy = %v, want the children of the node", got)
+	}
+	if loads != 1 {
+		t.Errorf("loader called %d times, want 1", loads)
+	}
+}
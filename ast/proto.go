@@ -0,0 +1,336 @@
+package ast
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/ahmadramadhannn/tsgoast/internal/protowire"
+)
+
+// Field numbers of the messages in ast.proto.
+const (
+	protoSyntaxSource  = 1
+	protoSyntaxStrings = 2
+	protoSyntaxRoot    = 3
+
+	protoNodeType     = 1
+	protoNodeKind     = 2
+	protoNodeField    = 3
+	protoNodeStart    = 4
+	protoNodeEnd      = 5
+	protoNodeText     = 6
+	protoNodeChildren = 7
+)
+
+// MarshalProto encodes the node and its subtree as a Syntax message in the
+// Protocol Buffers wire format (see ast.proto). The source text is stored
+// once, and node types, kinds and fields once each in a string table, so
+// the encoding is much smaller than JSON. Nodes other than *BaseNode are
+// encoded through the Node interface.
+func (n *BaseNode) MarshalProto() ([]byte, error) {
+	e := &protoEncoder{
+		source:  n.Content,
+		base:    n.SourceRange.Start.Offset,
+		strings: newStringTable(),
+	}
+	rootSize := e.size(n)
+
+	size := protowire.BytesSize(protoSyntaxSource, len(e.source))
+	for _, s := range e.strings.strings {
+		size += protowire.BytesSize(protoSyntaxStrings, len(s))
+	}
+	size += protowire.BytesSize(protoSyntaxRoot, rootSize)
+
+	buf := make([]byte, 0, size)
+	buf = protowire.AppendString(buf, protoSyntaxSource, e.source)
+	for _, s := range e.strings.strings {
+		buf = protowire.AppendTag(buf, protoSyntaxStrings, protowire.Bytes)
+		buf = binary.AppendUvarint(buf, uint64(len(s)))
+		buf = append(buf, s...)
+	}
+	buf = protowire.AppendTag(buf, protoSyntaxRoot, protowire.Bytes)
+	buf = binary.AppendUvarint(buf, uint64(rootSize))
+	buf = e.appendNode(buf, n)
+	return buf, nil
+}
+
+// UnmarshalProto decodes a Syntax message produced by MarshalProto,
+// rebuilding parent pointers. The receiver becomes the root of the decoded
+// subtree and has no parent.
+func (n *BaseNode) UnmarshalProto(data []byte) error {
+	d := &protoDecoder{}
+	var root []byte
+	hasRoot := false
+
+	r := protowire.Reader{B: data}
+	for !r.Done() {
+		field, wire, err := r.Tag()
+		if err != nil {
+			return err
+		}
+		switch {
+		case field == protoSyntaxSource && wire == protowire.Bytes:
+			b, err := r.Bytes()
+			if err != nil {
+				return err
+			}
+			d.source = string(b)
+		case field == protoSyntaxStrings && wire == protowire.Bytes:
+			b, err := r.Bytes()
+			if err != nil {
+				return err
+			}
+			d.strings = append(d.strings, string(b))
+		case field == protoSyntaxRoot && wire == protowire.Bytes:
+			if root, err = r.Bytes(); err != nil {
+				return err
+			}
+			hasRoot = true
+		default:
+			if err := r.Skip(wire); err != nil {
+				return err
+			}
+		}
+	}
+	if !hasRoot {
+		return fmt.Errorf("proto: syntax has no root node")
+	}
+
+	*n = BaseNode{}
+	if err := d.node(n, root); err != nil {
+		return err
+	}
+	d.base = n.SourceRange.Start.Offset
+	return d.fillText(n)
+}
+
+// protoEncoder holds the state of MarshalProto.
+type protoEncoder struct {
+	source string
+	base   uint32
+
+	strings *stringTable
+
+	// sizes holds the encoded size of each node in preorder, computed by
+	// size and consumed by appendNode.
+	sizes []int
+	next  int
+}
+
+// stringTable assigns indexes to the distinct node types, kinds and field
+// names of a tree. Index 0 is the empty string.
+type stringTable struct {
+	index   map[string]uint64
+	strings []string
+}
+
+func newStringTable() *stringTable {
+	return &stringTable{index: map[string]uint64{"": 0}, strings: []string{""}}
+}
+
+// intern returns the index of s, adding it if needed.
+func (t *stringTable) intern(s string) uint64 {
+	i, ok := t.index[s]
+	if !ok {
+		i = uint64(len(t.strings))
+		t.index[s] = i
+		t.strings = append(t.strings, s)
+	}
+	return i
+}
+
+// slice returns the source text between the offsets of r.
+func (e *protoEncoder) slice(r Range) (string, bool) {
+	return sourceSlice(e.source, e.base, r)
+}
+
+// sourceSlice returns the text between the offsets of r in source, which
+// starts at offset base, or false if r is outside the source.
+func sourceSlice(source string, base uint32, r Range) (string, bool) {
+	start, end := r.Start.Offset, r.End.Offset
+	if start < base || end < start || int(end-base) > len(source) {
+		return "", false
+	}
+	return source[start-base : end-base], true
+}
+
+// size returns the encoded size of a Node message, interning its strings
+// and recording the sizes of the subtree.
+func (e *protoEncoder) size(node Node) int {
+	slot := len(e.sizes)
+	e.sizes = append(e.sizes, 0)
+
+	size := protowire.VarintSize(protoNodeType, e.strings.intern(string(node.Type()))) +
+		protowire.VarintSize(protoNodeKind, e.strings.intern(node.SyntaxKind())) +
+		protowire.VarintSize(protoNodeField, e.strings.intern(FieldOf(node)))
+	r := node.Range()
+	if s := protoPositionSize(r.Start); s > 0 {
+		size += protowire.BytesSize(protoNodeStart, s)
+	}
+	if s := protoPositionSize(r.End); s > 0 {
+		size += protowire.BytesSize(protoNodeEnd, s)
+	}
+	if text, ok := e.slice(r); !ok || text != node.Text() {
+		size += protowire.BytesSize(protoNodeText, len(node.Text()))
+	}
+	for _, child := range node.Children() {
+		if child != nil {
+			size += protowire.BytesSize(protoNodeChildren, e.size(child))
+		}
+	}
+
+	e.sizes[slot] = size
+	return size
+}
+
+// appendNode appends the fields of a Node message; its size must have been
+// computed by size.
+func (e *protoEncoder) appendNode(buf []byte, node Node) []byte {
+	e.next++
+
+	buf = protowire.AppendVarint(buf, protoNodeType, e.strings.index[string(node.Type())])
+	buf = protowire.AppendVarint(buf, protoNodeKind, e.strings.index[node.SyntaxKind()])
+	buf = protowire.AppendVarint(buf, protoNodeField, e.strings.index[FieldOf(node)])
+	r := node.Range()
+	buf = appendProtoPosition(buf, protoNodeStart, r.Start)
+	buf = appendProtoPosition(buf, protoNodeEnd, r.End)
+	if text, ok := e.slice(r); !ok || text != node.Text() {
+		buf = protowire.AppendString(buf, protoNodeText, node.Text())
+	}
+	for _, child := range node.Children() {
+		if child == nil {
+			continue
+		}
+		buf = protowire.AppendTag(buf, protoNodeChildren, protowire.Bytes)
+		buf = binary.AppendUvarint(buf, uint64(e.sizes[e.next]))
+		buf = e.appendNode(buf, child)
+	}
+	return buf
+}
+
+// protoDecoder holds the state of UnmarshalProto.
+type protoDecoder struct {
+	source  string
+	base    uint32
+	strings []string
+
+	// explicit records the nodes whose text was encoded explicitly.
+	explicit map[*BaseNode]bool
+}
+
+// str returns entry i of the string table.
+func (d *protoDecoder) str(i uint64) (string, error) {
+	if i == 0 && len(d.strings) == 0 {
+		return "", nil
+	}
+	if i >= uint64(len(d.strings)) {
+		return "", fmt.Errorf("proto: string index %d out of range", i)
+	}
+	return d.strings[i], nil
+}
+
+// node decodes a Node message into n, creating its children.
+func (d *protoDecoder) node(n *BaseNode, data []byte) error {
+	r := protowire.Reader{B: data}
+	for !r.Done() {
+		field, wire, err := r.Tag()
+		if err != nil {
+			return err
+		}
+		switch {
+		case field >= protoNodeType && field <= protoNodeField && wire == protowire.Varint:
+			v, err := r.Varint()
+			if err != nil {
+				return err
+			}
+			s, err := d.str(v)
+			if err != nil {
+				return err
+			}
+			switch field {
+			case protoNodeType:
+				n.NodeType = NodeType(s)
+			case protoNodeKind:
+				n.KindID, n.TreeSitterKind = InternKind(s)
+			default:
+				n.FieldName = s
+			}
+		case (field == protoNodeStart || field == protoNodeEnd) && wire == protowire.Bytes:
+			b, err := r.Bytes()
+			if err != nil {
+				return err
+			}
+			line, column, offset, err := protowire.DecodePosition(b)
+			if err != nil {
+				return err
+			}
+			p := Position{Line: line, Column: column, Offset: offset}
+			if field == protoNodeStart {
+				n.SourceRange.Start = p
+			} else {
+				n.SourceRange.End = p
+			}
+		case field == protoNodeText && wire == protowire.Bytes:
+			b, err := r.Bytes()
+			if err != nil {
+				return err
+			}
+			n.Content = string(b)
+			if d.explicit == nil {
+				d.explicit = make(map[*BaseNode]bool)
+			}
+			d.explicit[n] = true
+		case field == protoNodeChildren && wire == protowire.Bytes:
+			b, err := r.Bytes()
+			if err != nil {
+				return err
+			}
+			child := &BaseNode{ParentNode: n}
+			if err := d.node(child, b); err != nil {
+				return err
+			}
+			n.ChildNodes = append(n.ChildNodes, child)
+		default:
+			if err := r.Skip(wire); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// fillText sets the text of the nodes without explicit text from the
+// source.
+func (d *protoDecoder) fillText(n *BaseNode) error {
+	if err := fillSourceText(n, d.source, d.base, d.explicit); err != nil {
+		return fmt.Errorf("proto: %w", err)
+	}
+	return nil
+}
+
+// fillSourceText sets the text of n and its descendants, except those in
+// explicit, to their slice of source, which starts at offset base.
+func fillSourceText(n *BaseNode, source string, base uint32, explicit map[*BaseNode]bool) error {
+	if !explicit[n] {
+		text, ok := sourceSlice(source, base, n.SourceRange)
+		if !ok {
+			return fmt.Errorf("node range %d-%d outside of source", n.SourceRange.Start.Offset, n.SourceRange.End.Offset)
+		}
+		n.Content = text
+	}
+	for _, child := range n.ChildNodes {
+		if err := fillSourceText(child.(*BaseNode), source, base, explicit); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// appendProtoPosition appends a Position field, omitting the zero position.
+func appendProtoPosition(buf []byte, field uint64, p Position) []byte {
+	return protowire.AppendPosition(buf, field, p.Line, p.Column, p.Offset)
+}
+
+func protoPositionSize(p Position) int {
+	return protowire.PositionSize(p.Line, p.Column, p.Offset)
+}
@@ -0,0 +1,86 @@
+package ast
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBaseNodeProtoRoundTrip(t *testing.T) {
+	root := newTestTree()
+	root.ChildNodes[0].(*BaseNode).FieldName = "left"
+	root.NodeType = NodeTypeFunction
+	root.SourceRange = Range{End: Position{Line: 1, Column: 2, Offset: 300}}
+
+	data, err := root.MarshalProto()
+	if err != nil {
+		t.Fatalf("MarshalProto() error = %v", err)
+	}
+
+	var decoded BaseNode
+	if err := decoded.UnmarshalProto(data); err != nil {
+		t.Fatalf("UnmarshalProto() error = %v", err)
+	}
+
+	var want, got []Node
+	for n := range Preorder(root) {
+		want = append(want, n)
+	}
+	for n := range Preorder(&decoded) {
+		got = append(got, n)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("decoded %d nodes, want %d", len(got), len(want))
+	}
+	for i := range want {
+		w, g := want[i], got[i]
+		if g.Type() != w.Type() || g.SyntaxKind() != w.SyntaxKind() || g.Text() != w.Text() ||
+			!reflect.DeepEqual(g.Range(), w.Range()) || FieldOf(g) != FieldOf(w) {
+			t.Errorf("node %d = %+v, want %+v", i, g, w)
+		}
+		if i > 0 && g.Parent() == nil {
+			t.Errorf("node %d has no parent", i)
+		}
+	}
+}
+
+func TestBaseNodeProtoSharesSource(t *testing.T) {
+	// x = 1 with leading whitespace before the root
+	root := &BaseNode{
+		TreeSitterKind: "assignment_expression",
+		Content:        "x = 1",
+		SourceRange:    Range{Start: Position{Column: 2, Offset: 2}, End: Position{Column: 7, Offset: 7}},
+	}
+	for _, c := range []struct {
+		kind, text string
+		start      uint32
+	}{{"identifier", "x", 2}, {"=", "=", 4}, {"number", "1", 6}} {
+		end := c.start + uint32(len(c.text))
+		root.ChildNodes = append(root.ChildNodes, &BaseNode{
+			TreeSitterKind: c.kind,
+			Content:        c.text,
+			SourceRange:    Range{Start: Position{Column: c.start, Offset: c.start}, End: Position{Column: end, Offset: end}},
+			ParentNode:     root,
+		})
+	}
+
+	data, err := root.MarshalProto()
+	if err != nil {
+		t.Fatalf("MarshalProto() error = %v", err)
+	}
+	var decoded BaseNode
+	if err := decoded.UnmarshalProto(data); err != nil {
+		t.Fatalf("UnmarshalProto() error = %v", err)
+	}
+	if decoded.Text() != "x = 1" || len(decoded.Children()) != 3 {
+		t.Fatalf("decoded %q with %d children", decoded.Text(), len(decoded.Children()))
+	}
+	for i, child := range decoded.Children() {
+		if want := root.ChildNodes[i].Text(); child.Text() != want {
+			t.Errorf("child %d text = %q, want %q", i, child.Text(), want)
+		}
+	}
+
+	if err := decoded.UnmarshalProto(data[:len(data)-1]); err == nil {
+		t.Error("UnmarshalProto() of truncated data: expected error")
+	}
+}
@@ -0,0 +1,55 @@
+package ast
+
+// ChildIndex returns the position of node among its parent's children, or
+// -1 if node has no parent or is not one of its children.
+func ChildIndex(node Node) int {
+	if node == nil || node.Parent() == nil {
+		return -1
+	}
+	for i, child := range node.Parent().Children() {
+		if child == node {
+			return i
+		}
+	}
+	return -1
+}
+
+// NextSibling returns the node following node in its parent's children, or
+// nil if node is the last child or has no parent.
+func NextSibling(node Node) Node {
+	i := ChildIndex(node)
+	if i < 0 {
+		return nil
+	}
+	siblings := node.Parent().Children()
+	if i+1 >= len(siblings) {
+		return nil
+	}
+	return siblings[i+1]
+}
+
+// PrevSibling returns the node preceding node in its parent's children, or
+// nil if node is the first child or has no parent.
+func PrevSibling(node Node) Node {
+	i := ChildIndex(node)
+	if i <= 0 {
+		return nil
+	}
+	return node.Parent().Children()[i-1]
+}
+
+// ChildIndex returns the position of the node among its parent's children,
+// or -1 for the root.
+func (n *BaseNode) ChildIndex() int {
+	return ChildIndex(n)
+}
+
+// NextSibling returns the next sibling of the node, or nil.
+func (n *BaseNode) NextSibling() Node {
+	return NextSibling(n)
+}
+
+// PrevSibling returns the previous sibling of the node, or nil.
+func (n *BaseNode) PrevSibling() Node {
+	return PrevSibling(n)
+}
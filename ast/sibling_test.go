@@ -0,0 +1,39 @@
+package ast
+
+import (
+	"testing"
+)
+
+func TestSiblings(t *testing.T) {
+	root := newTestTree()
+	a := root.Children()[0].(*BaseNode)
+	b := root.Children()[1].(*BaseNode)
+	a1 := a.Children()[0].(*BaseNode)
+	a2 := a.Children()[1].(*BaseNode)
+
+	if a.ChildIndex() != 0 || b.ChildIndex() != 1 || a2.ChildIndex() != 1 {
+		t.Errorf("ChildIndex() = %d, %d, %d, want 0, 1, 1", a.ChildIndex(), b.ChildIndex(), a2.ChildIndex())
+	}
+	if root.ChildIndex() != -1 {
+		t.Errorf("root ChildIndex() = %d, want -1", root.ChildIndex())
+	}
+
+	if a.NextSibling() != b || b.NextSibling() != nil {
+		t.Error("NextSibling() returned the wrong node")
+	}
+	if b.PrevSibling() != a || a.PrevSibling() != nil {
+		t.Error("PrevSibling() returned the wrong node")
+	}
+	if a1.NextSibling() != a2 || a2.PrevSibling() != a1 {
+		t.Error("sibling navigation between a1 and a2 failed")
+	}
+	if root.NextSibling() != nil || root.PrevSibling() != nil {
+		t.Error("root should have no siblings")
+	}
+
+	// A node that is not among its parent's children
+	orphan := &BaseNode{ParentNode: root}
+	if ChildIndex(orphan) != -1 || NextSibling(orphan) != nil || PrevSibling(nil) != nil {
+		t.Error("orphan nodes should have no siblings")
+	}
+}
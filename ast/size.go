@@ -0,0 +1,82 @@
+package ast
+
+import "reflect"
+
+// SizeOf estimates the bytes retained by nodes and their descendants: the
+// nodes themselves, their child lists and the typed nodes they hold, such
+// as the parameters of a function. Memory shared between the nodes, such
+// as the child lists a typed statement shares with the node it was built
+// from, is counted once. Parents are not counted, nor is text: parsed
+// nodes share one copy of the source. The children of lazy nodes are not
+// loaded, so only those loaded so far are counted.
+//
+// SizeOf walks the values the nodes refer to with reflection, so it suits
+// measuring, such as to compare the footprint of parser options, rather
+// than accounting on every parse.
+func SizeOf(nodes ...Node) int {
+	s := sizer{seen: make(map[uintptr]bool)}
+	for _, node := range nodes {
+		if node != nil {
+			s.stack = append(s.stack, reflect.ValueOf(node))
+		}
+	}
+	return s.run()
+}
+
+// sizer adds up the memory values refer to, keeping its own stack rather
+// than recursing, as trees can be deep.
+type sizer struct {
+	seen  map[uintptr]bool // pointers and slice arrays counted
+	stack []reflect.Value
+	bytes int
+}
+
+// run counts the memory the values on the stack refer to.
+func (s *sizer) run() int {
+	for len(s.stack) > 0 {
+		v := s.stack[len(s.stack)-1]
+		s.stack = s.stack[:len(s.stack)-1]
+		switch v.Kind() {
+		case reflect.Pointer:
+			if v.IsNil() || s.seen[v.Pointer()] {
+				continue
+			}
+			s.seen[v.Pointer()] = true
+			s.bytes += int(v.Type().Elem().Size())
+			s.push(v.Elem())
+		case reflect.Interface:
+			if !v.IsNil() {
+				s.push(v.Elem())
+			}
+		case reflect.Slice:
+			if v.Cap() == 0 || s.seen[v.Pointer()] {
+				continue
+			}
+			s.seen[v.Pointer()] = true
+			s.bytes += v.Cap() * int(v.Type().Elem().Size())
+			for i := range v.Len() {
+				s.push(v.Index(i))
+			}
+		case reflect.Array:
+			for i := range v.Len() {
+				s.push(v.Index(i))
+			}
+		case reflect.Struct:
+			t := v.Type()
+			for i := range v.NumField() {
+				if t.Field(i).Name != "ParentNode" {
+					s.push(v.Field(i))
+				}
+			}
+		}
+	}
+	return s.bytes
+}
+
+// push adds v to the stack if it can refer to memory to count.
+func (s *sizer) push(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface, reflect.Slice, reflect.Array, reflect.Struct:
+		s.stack = append(s.stack, v)
+	}
+}
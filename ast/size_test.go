@@ -0,0 +1,40 @@
+package ast
+
+import (
+	"testing"
+	"unsafe"
+)
+
+func TestSizeOf(t *testing.T) {
+	if n := SizeOf(nil); n != 0 {
+		t.Errorf("SizeOf(nil) = %d, want 0", n)
+	}
+
+	node := int(unsafe.Sizeof(BaseNode{}))
+	list := int(unsafe.Sizeof(Node(nil)))
+	root := newTestTree() // root(a(a1, a2), b)
+	want := 5*node + 2*list + 2*list
+	if got := SizeOf(root); got != want {
+		t.Errorf("SizeOf(tree) = %d, want %d", got, want)
+	}
+
+	// Subtrees count their own nodes, and shared ones once
+	a := root.ChildNodes[0]
+	if got := SizeOf(a); got != 3*node+2*list {
+		t.Errorf("SizeOf(a) = %d, want %d", got, 3*node+2*list)
+	}
+	if got := SizeOf(root, a); got != want {
+		t.Errorf("SizeOf(root, a) = %d, want %d", got, want)
+	}
+
+	// Typed nodes count their fields and the typed nodes they hold, but
+	// not the children they share with their base node
+	fn := &FunctionDeclaration{
+		BaseNode:   *root,
+		Parameters: []*Parameter{{Name: "x"}},
+	}
+	typed := int(unsafe.Sizeof(FunctionDeclaration{})) + int(unsafe.Sizeof(&Parameter{})) + int(unsafe.Sizeof(Parameter{}))
+	if got := SizeOf(fn, root); got != want+typed {
+		t.Errorf("SizeOf(fn, root) = %d, want %d", got, want+typed)
+	}
+}
@@ -0,0 +1,63 @@
+package ast
+
+import (
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// UTF16Index converts the byte offsets used by tree-sitter into the UTF-16
+// code unit offsets used by JavaScript tooling (ESTree, the TypeScript
+// compiler API, LSP).
+type UTF16Index struct {
+	base  uint32   // byte offset of the indexed text
+	units []uint32 // UTF-16 offset of each byte of the text; nil when ASCII
+}
+
+// NewUTF16Index indexes the text of root, normally a program node. Offsets
+// before root, which can only be leading whitespace, map to themselves.
+func NewUTF16Index(root Node) *UTF16Index {
+	x := &UTF16Index{base: root.Range().Start.Offset}
+	text := root.Text()
+	ascii := true
+	for i := 0; i < len(text); i++ {
+		if text[i] >= utf8.RuneSelf {
+			ascii = false
+			break
+		}
+	}
+	if ascii {
+		return x
+	}
+
+	x.units = make([]uint32, len(text)+1)
+	var units uint32
+	for i, r := range text {
+		size := utf8.RuneLen(r)
+		if size < 0 {
+			size = 1
+		}
+		for j := 0; j < size && i+j < len(text); j++ {
+			x.units[i+j] = units
+		}
+		units += uint32(utf16.RuneLen(r))
+	}
+	x.units[len(text)] = units
+	return x
+}
+
+// Offset converts a byte offset to a UTF-16 offset.
+func (x *UTF16Index) Offset(byteOffset uint32) uint32 {
+	if x.units == nil || byteOffset <= x.base {
+		return byteOffset
+	}
+	i := byteOffset - x.base
+	if int(i) >= len(x.units) {
+		i = uint32(len(x.units) - 1)
+	}
+	return x.base + x.units[i]
+}
+
+// Column returns the column of p in UTF-16 code units.
+func (x *UTF16Index) Column(p Position) uint32 {
+	return x.Offset(p.Offset) - x.Offset(p.Offset-p.Column)
+}
@@ -0,0 +1,47 @@
+package ast
+
+import (
+	"testing"
+)
+
+func TestUTF16Index(t *testing.T) {
+	// "é" is two bytes and one unit, "😀" four bytes and two units
+	text := "a = \"é😀\";\nb;"
+	root := &BaseNode{
+		Content: text,
+		SourceRange: Range{
+			Start: Position{Offset: 2},
+			End:   Position{Line: 1, Column: 2, Offset: uint32(2 + len(text))},
+		},
+	}
+	x := NewUTF16Index(root)
+
+	tests := []struct {
+		offset uint32
+		want   uint32
+	}{
+		{0, 0},
+		{2, 2},
+		{2 + 5, 7},   // "é"
+		{2 + 7, 8},   // "😀"
+		{2 + 11, 10}, // closing quote
+		{2 + 14, 13}, // "b"
+	}
+	for _, tt := range tests {
+		if got := x.Offset(tt.offset); got != tt.want {
+			t.Errorf("Offset(%d) = %d, want %d", tt.offset, got, tt.want)
+		}
+	}
+
+	if got := x.Column(Position{Line: 0, Column: 11 + 2, Offset: 2 + 11}); got != 10 {
+		t.Errorf("Column() = %d, want 10", got)
+	}
+	if got := x.Column(Position{Line: 1, Column: 0, Offset: 2 + 14}); got != 0 {
+		t.Errorf("Column() on next line = %d, want 0", got)
+	}
+
+	ascii := NewUTF16Index(&BaseNode{Content: "abc"})
+	if got := ascii.Offset(2); got != 2 {
+		t.Errorf("ASCII Offset(2) = %d, want 2", got)
+	}
+}
@@ -0,0 +1,76 @@
+package ast
+
+// Visitor is implemented by types that need to observe both the descent into
+// and the ascent out of each node, such as scope trackers and
+// transformations that keep a stack of state.
+type Visitor interface {
+	// Enter is called before the children of node are visited. If it
+	// returns false, the children are skipped and Exit is not called for
+	// node.
+	Enter(node Node) bool
+
+	// Exit is called after all children of node have been visited.
+	Exit(node Node)
+}
+
+// Walk traverses the tree rooted at node in depth-first order, calling
+// v.Enter before and v.Exit after visiting the children of each node. The
+// traversal uses an explicit stack, so arbitrarily deep trees are supported.
+func Walk(node Node, v Visitor) {
+	if node == nil || !v.Enter(node) {
+		return
+	}
+
+	type frame struct {
+		node Node
+		next int // index of the next child to visit
+	}
+	stack := []frame{{node: node}}
+	for len(stack) > 0 {
+		top := &stack[len(stack)-1]
+		children := top.node.Children()
+		if top.next >= len(children) {
+			v.Exit(top.node)
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		child := children[top.next]
+		top.next++
+		if child != nil && v.Enter(child) {
+			stack = append(stack, frame{node: child})
+		}
+	}
+}
+
+// Inspect traverses the tree rooted at node in depth-first order, calling f
+// for each node. If f returns false, the children of that node are skipped.
+func Inspect(node Node, f func(Node) bool) {
+	Walk(node, inspector(f))
+}
+
+type inspector func(Node) bool
+
+func (f inspector) Enter(node Node) bool { return f(node) }
+func (f inspector) Exit(Node)            {}
+
+// VisitorFuncs adapts a pair of functions to the Visitor interface. A nil
+// EnterFunc visits every node; a nil ExitFunc does nothing.
+type VisitorFuncs struct {
+	EnterFunc func(Node) bool
+	ExitFunc  func(Node)
+}
+
+// Enter calls EnterFunc, if set.
+func (v VisitorFuncs) Enter(node Node) bool {
+	if v.EnterFunc == nil {
+		return true
+	}
+	return v.EnterFunc(node)
+}
+
+// Exit calls ExitFunc, if set.
+func (v VisitorFuncs) Exit(node Node) {
+	if v.ExitFunc != nil {
+		v.ExitFunc(node)
+	}
+}
@@ -0,0 +1,97 @@
+package ast
+
+import (
+	"reflect"
+	"testing"
+)
+
+// newTestTree builds: root -> (a -> (a1, a2), b)
+func newTestTree() *BaseNode {
+	root := &BaseNode{Content: "root"}
+	a := &BaseNode{Content: "a", ParentNode: root}
+	b := &BaseNode{Content: "b", ParentNode: root}
+	a1 := &BaseNode{Content: "a1", ParentNode: a}
+	a2 := &BaseNode{Content: "a2", ParentNode: a}
+	a.ChildNodes = []Node{a1, a2}
+	root.ChildNodes = []Node{a, b}
+	return root
+}
+
+// traceVisitor records Enter and Exit calls.
+type traceVisitor struct {
+	events []string
+	skip   string
+	depth  int
+	max    int
+}
+
+func (v *traceVisitor) Enter(node Node) bool {
+	v.events = append(v.events, "enter "+node.Text())
+	if node.Text() == v.skip {
+		return false
+	}
+	v.depth++
+	if v.depth > v.max {
+		v.max = v.depth
+	}
+	return true
+}
+
+func (v *traceVisitor) Exit(node Node) {
+	v.depth--
+	v.events = append(v.events, "exit "+node.Text())
+}
+
+func TestWalk(t *testing.T) {
+	v := &traceVisitor{}
+	Walk(newTestTree(), v)
+
+	want := []string{
+		"enter root", "enter a", "enter a1", "exit a1", "enter a2", "exit a2", "exit a",
+		"enter b", "exit b", "exit root",
+	}
+	if !reflect.DeepEqual(v.events, want) {
+		t.Errorf("Walk() events = %v, want %v", v.events, want)
+	}
+	if v.depth != 0 || v.max != 3 {
+		t.Errorf("depth = %d, max = %d, want 0 and 3", v.depth, v.max)
+	}
+}
+
+func TestWalkSkip(t *testing.T) {
+	v := &traceVisitor{skip: "a"}
+	Walk(newTestTree(), v)
+
+	want := []string{"enter root", "enter a", "enter b", "exit b", "exit root"}
+	if !reflect.DeepEqual(v.events, want) {
+		t.Errorf("Walk() events = %v, want %v", v.events, want)
+	}
+
+	// A nil node is a no-op
+	Walk(nil, v)
+}
+
+func TestInspect(t *testing.T) {
+	var visited []string
+	Inspect(newTestTree(), func(node Node) bool {
+		visited = append(visited, node.Text())
+		return node.Text() != "a"
+	})
+
+	want := []string{"root", "a", "b"}
+	if !reflect.DeepEqual(visited, want) {
+		t.Errorf("Inspect() visited = %v, want %v", visited, want)
+	}
+}
+
+func TestVisitorFuncs(t *testing.T) {
+	var exits []string
+	Walk(newTestTree(), VisitorFuncs{
+		ExitFunc: func(node Node) { exits = append(exits, node.Text()) },
+	})
+
+	want := []string{"a1", "a2", "a", "b", "root"}
+	if !reflect.DeepEqual(exits, want) {
+		t.Errorf("exit order = %v, want %v", exits, want)
+	}
+}
@@ -0,0 +1,363 @@
+// Package babel converts tsgoast syntax trees into the node shapes produced
+// by @babel/parser with the "typescript" plugin, so that tools built for
+// Babel can consume tsgoast results or compare them against Babel output.
+//
+// The conversion starts from the typescript-estree form produced by the
+// estree package with estree.WithTypes and applies Babel's deviations from
+// ESTree: a File root, typed literals (StringLiteral, NumericLiteral, ...),
+// ObjectProperty and ObjectMethod, ClassMethod and ClassProperty, PrivateName,
+// optional chains as OptionalMemberExpression and OptionalCallExpression,
+// directives, start/end offsets instead of range, and Babel 7 names for
+// TypeScript fields (typeParameters for type arguments, parameters and
+// typeAnnotation on signatures).
+package babel
+
+import (
+	"encoding/json"
+
+	"github.com/ahmadramadhannn/tsgoast/ast"
+	"github.com/ahmadramadhannn/tsgoast/estree"
+)
+
+// Node is a Babel AST node.
+type Node map[string]any
+
+// Type returns the node type, such as "StringLiteral".
+func (n Node) Type() string {
+	t, _ := n["type"].(string)
+	return t
+}
+
+// Convert converts the subtree rooted at root into Babel nodes. A program
+// root is wrapped in a File node, as returned by @babel/parser's parse. It
+// returns nil if root is nil.
+func Convert(root ast.Node) Node {
+	if root == nil {
+		return nil
+	}
+	converted, _ := convert(estree.Convert(root, estree.WithTypes())).(Node)
+	if converted.Type() != "Program" {
+		return converted
+	}
+
+	file := Node{"type": "File", "program": converted}
+	copyLocation(file, converted)
+	file["comments"] = converted["comments"]
+	delete(converted, "comments")
+	return file
+}
+
+// Marshal converts root with Convert and encodes the result as JSON.
+func Marshal(root ast.Node) ([]byte, error) {
+	return json.Marshal(Convert(root))
+}
+
+// signatureTypes are TypeScript signatures whose parameters and return type
+// Babel stores as "parameters" and "typeAnnotation".
+var signatureTypes = map[string]bool{
+	"TSMethodSignature":               true,
+	"TSCallSignatureDeclaration":      true,
+	"TSConstructSignatureDeclaration": true,
+	"TSFunctionType":                  true,
+	"TSConstructorType":               true,
+}
+
+// convert converts a value of an ESTree tree: nodes, lists of nodes and
+// scalars.
+func convert(v any) any {
+	switch v := v.(type) {
+	case estree.Node:
+		if v == nil {
+			return nil
+		}
+		return convertNode(v)
+	case []estree.Node:
+		list := make([]any, len(v))
+		for i, item := range v {
+			list[i] = convert(item)
+		}
+		return list
+	case []any:
+		list := make([]any, len(v))
+		for i, item := range v {
+			list[i] = convert(item)
+		}
+		return list
+	}
+	return v
+}
+
+// convertNode converts a single ESTree node and its children.
+func convertNode(in estree.Node) Node {
+	out := make(Node, len(in)+2)
+	for key, value := range in {
+		switch key {
+		case "range", "loc":
+			continue
+		}
+		out[key] = convert(value)
+	}
+	setLocation(out, in)
+
+	// Babel 7 calls type arguments typeParameters
+	if args, ok := out["typeArguments"]; ok {
+		out["typeParameters"] = args
+		delete(out, "typeArguments")
+	}
+	if args, ok := out["superTypeArguments"]; ok {
+		out["superTypeParameters"] = args
+		delete(out, "superTypeArguments")
+	}
+
+	switch typ := out.Type(); {
+	case typ == "Literal":
+		literal(out)
+	case typ == "Program":
+		directives(out)
+	case typ == "Line":
+		out["type"] = "CommentLine"
+	case typ == "Block":
+		out["type"] = "CommentBlock"
+	case typ == "Property":
+		property(out)
+	case typ == "MethodDefinition" || typ == "TSAbstractMethodDefinition":
+		classMethod(out, typ == "TSAbstractMethodDefinition")
+	case typ == "PropertyDefinition" || typ == "TSAbstractPropertyDefinition":
+		out["type"] = "ClassProperty"
+		if typ == "TSAbstractPropertyDefinition" {
+			out["abstract"] = true
+		}
+		if key, ok := out["key"].(Node); ok && key.Type() == "PrivateName" {
+			out["type"] = "ClassPrivateProperty"
+		}
+	case typ == "PrivateIdentifier":
+		privateName(out)
+	case typ == "MemberExpression":
+		if out["optional"] == true || isOptionalChain(out["object"]) {
+			out["type"] = "OptionalMemberExpression"
+		}
+	case typ == "CallExpression":
+		if out["optional"] == true || isOptionalChain(out["callee"]) {
+			out["type"] = "OptionalCallExpression"
+		}
+	case typ == "ExportAllDeclaration":
+		if exported, ok := out["exported"].(Node); ok {
+			specifier := Node{"type": "ExportNamespaceSpecifier", "exported": exported}
+			copyLocation(specifier, exported)
+			out["type"] = "ExportNamedDeclaration"
+			out["specifiers"] = []any{specifier}
+			out["declaration"] = nil
+			delete(out, "exported")
+		}
+	case typ == "FunctionDeclaration" || typ == "FunctionExpression" || typ == "TSDeclareFunction":
+		delete(out, "expression")
+	case typ == "TSTypeParameter":
+		// Babel 7 stores type parameter names as strings
+		if name, ok := out["name"].(Node); ok {
+			out["name"] = name["name"]
+		}
+	case typ == "TSInterfaceHeritage" || typ == "TSClassImplements":
+		out["type"] = "TSExpressionWithTypeArguments"
+		out["expression"] = entityName(out["expression"])
+	case signatureTypes[typ]:
+		out["parameters"] = out["params"]
+		out["typeAnnotation"] = out["returnType"]
+		delete(out, "params")
+		delete(out, "returnType")
+	}
+	return out
+}
+
+// setLocation replaces the ESTree range of in with Babel's start, end and
+// loc, which includes the offset of each position as "index".
+func setLocation(out Node, in estree.Node) {
+	r, ok := in["range"].([]uint32)
+	if !ok || len(r) != 2 {
+		return
+	}
+	out["start"] = r[0]
+	out["end"] = r[1]
+
+	loc, _ := in["loc"].(map[string]any)
+	start, _ := loc["start"].(map[string]any)
+	end, _ := loc["end"].(map[string]any)
+	out["loc"] = map[string]any{
+		"start": map[string]any{"line": start["line"], "column": start["column"], "index": r[0]},
+		"end":   map[string]any{"line": end["line"], "column": end["column"], "index": r[1]},
+	}
+}
+
+// copyLocation copies the location of src to dst.
+func copyLocation(dst, src Node) {
+	for _, key := range []string{"start", "end", "loc"} {
+		if v, ok := src[key]; ok {
+			dst[key] = v
+		}
+	}
+}
+
+// literal converts an ESTree Literal into Babel's typed literals.
+func literal(n Node) {
+	raw, _ := n["raw"].(string)
+	delete(n, "raw")
+
+	if regex, ok := n["regex"].(map[string]string); ok {
+		n["type"] = "RegExpLiteral"
+		n["pattern"] = regex["pattern"]
+		n["flags"] = regex["flags"]
+		n["extra"] = map[string]any{"raw": raw}
+		delete(n, "regex")
+		delete(n, "value")
+		return
+	}
+	if bigint, ok := n["bigint"].(string); ok {
+		n["type"] = "BigIntLiteral"
+		n["value"] = bigint
+		n["extra"] = map[string]any{"rawValue": bigint, "raw": raw}
+		delete(n, "bigint")
+		return
+	}
+
+	switch value := n["value"].(type) {
+	case string:
+		n["type"] = "StringLiteral"
+		n["extra"] = map[string]any{"rawValue": value, "raw": raw}
+	case float64:
+		n["type"] = "NumericLiteral"
+		n["extra"] = map[string]any{"rawValue": value, "raw": raw}
+	case bool:
+		n["type"] = "BooleanLiteral"
+	default:
+		n["type"] = "NullLiteral"
+		delete(n, "value")
+	}
+}
+
+// directives moves leading string expression statements of a program into
+// its directives, as Babel does for "use strict".
+func directives(program Node) {
+	body, _ := program["body"].([]any)
+	list := []any{}
+	for len(body) > 0 {
+		stmt, ok := body[0].(Node)
+		if !ok || stmt.Type() != "ExpressionStatement" {
+			break
+		}
+		expr, ok := stmt["expression"].(Node)
+		if !ok || expr.Type() != "StringLiteral" {
+			break
+		}
+		extra, _ := expr["extra"].(map[string]any)
+		raw, _ := extra["raw"].(string)
+		value := Node{"type": "DirectiveLiteral", "extra": map[string]any{"raw": raw, "rawValue": unquoteRaw(raw)}}
+		value["value"] = unquoteRaw(raw)
+		copyLocation(value, expr)
+		directive := Node{"type": "Directive", "value": value}
+		copyLocation(directive, stmt)
+		list = append(list, directive)
+		body = body[1:]
+	}
+	program["body"] = body
+	program["directives"] = list
+	program["interpreter"] = nil
+}
+
+// unquoteRaw returns the contents of a quoted directive without resolving
+// escapes, which Babel keeps verbatim in directive values.
+func unquoteRaw(raw string) string {
+	if len(raw) >= 2 {
+		return raw[1 : len(raw)-1]
+	}
+	return raw
+}
+
+// property converts an ESTree Property into an ObjectProperty or
+// ObjectMethod.
+func property(n Node) {
+	kind, _ := n["kind"].(string)
+	if n["method"] != true && kind == "init" {
+		n["type"] = "ObjectProperty"
+		delete(n, "kind")
+		delete(n, "method")
+		return
+	}
+
+	n["type"] = "ObjectMethod"
+	if kind == "init" {
+		n["kind"] = "method"
+	}
+	hoistFunction(n)
+	delete(n, "shorthand")
+}
+
+// classMethod converts a MethodDefinition into a ClassMethod, or a
+// TSDeclareMethod when it has no body.
+func classMethod(n Node, abstract bool) {
+	n["type"] = "ClassMethod"
+	if value, ok := n["value"].(Node); ok && value.Type() == "TSEmptyBodyFunctionExpression" {
+		n["type"] = "TSDeclareMethod"
+	}
+	if abstract {
+		n["abstract"] = true
+	}
+	hoistFunction(n)
+}
+
+// hoistFunction moves the function properties of a method's value onto the
+// method itself, as Babel has no separate function node for methods.
+func hoistFunction(n Node) {
+	value, ok := n["value"].(Node)
+	if !ok {
+		return
+	}
+	for _, key := range []string{"id", "params", "body", "async", "generator", "returnType", "typeParameters"} {
+		if v, ok := value[key]; ok {
+			n[key] = v
+		}
+	}
+	delete(n, "value")
+	delete(n, "method")
+}
+
+// privateName converts a PrivateIdentifier into a PrivateName wrapping an
+// Identifier that excludes the leading "#".
+func privateName(n Node) {
+	name := n["name"]
+	id := Node{"type": "Identifier", "name": name}
+	if start, ok := n["start"].(uint32); ok {
+		id["start"] = start + 1
+		id["end"] = n["end"]
+		if loc, ok := n["loc"].(map[string]any); ok {
+			startLoc, _ := loc["start"].(map[string]any)
+			column, _ := startLoc["column"].(uint32)
+			id["loc"] = map[string]any{
+				"start": map[string]any{"line": startLoc["line"], "column": column + 1, "index": start + 1},
+				"end":   loc["end"],
+			}
+		}
+	}
+	n["type"] = "PrivateName"
+	n["id"] = id
+	delete(n, "name")
+}
+
+// isOptionalChain reports whether v continues an optional chain.
+func isOptionalChain(v any) bool {
+	n, ok := v.(Node)
+	if !ok {
+		return false
+	}
+	return n.Type() == "OptionalMemberExpression" || n.Type() == "OptionalCallExpression"
+}
+
+// entityName converts a heritage expression (an identifier or member
+// expression) into a TypeScript entity name.
+func entityName(v any) any {
+	n, ok := v.(Node)
+	if !ok || n.Type() != "MemberExpression" {
+		return v
+	}
+	out := Node{"type": "TSQualifiedName", "left": entityName(n["object"]), "right": n["property"]}
+	copyLocation(out, n)
+	return out
+}
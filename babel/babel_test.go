@@ -0,0 +1,156 @@
+package babel
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/ahmadramadhannn/tsgoast"
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+func parse(t *testing.T, source string) *ast.BaseNode {
+	t.Helper()
+	parser, err := tsgoast.New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+	defer parser.Close()
+
+	root, err := parser.Parse([]byte(source))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	return root
+}
+
+// get follows a path of object keys and array indexes through JSON values.
+func get(t *testing.T, v any, path ...any) any {
+	t.Helper()
+	for _, step := range path {
+		switch s := step.(type) {
+		case string:
+			m, ok := v.(map[string]any)
+			if !ok {
+				t.Fatalf("path %v: %v is not an object", path, v)
+			}
+			v = m[s]
+		case int:
+			a, ok := v.([]any)
+			if !ok || s >= len(a) {
+				t.Fatalf("path %v: %v is not an array with index %d", path, v, s)
+			}
+			v = a[s]
+		}
+	}
+	return v
+}
+
+func TestConvert(t *testing.T) {
+	source := `"use strict";
+// entry
+const s: string = 'x', n = 1.5, b = true, z = null, big = 10n, re = /a+/g;
+const obj = { a, b: 1, m(): void {}, get g() { return 1; } };
+class Counter<T> extends Base<T> implements Countable {
+  #count = 0;
+  static total: number;
+  abstract reset(): void;
+  increment(by = 1) { this.#count += by; }
+}
+a?.b.c();
+export * as ns from "./ns";
+interface Shape { area(scale: number): number }
+`
+	data, err := Marshal(parse(t, source))
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	var file map[string]any
+	if err := json.Unmarshal(data, &file); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	decl := []any{"program", "body", 0, "declarations"}
+	classBody := []any{"program", "body", 2, "body", "body"}
+	tests := []struct {
+		path []any
+		want any
+	}{
+		{[]any{"type"}, "File"},
+		{[]any{"program", "type"}, "Program"},
+		{[]any{"program", "directives", 0, "value", "value"}, "use strict"},
+		{[]any{"comments", 0, "type"}, "CommentLine"},
+		{[]any{"comments", 0, "value"}, " entry"},
+		{[]any{"program", "body", 0, "start"}, 23.0},
+		{[]any{"program", "body", 0, "loc", "start", "index"}, 23.0},
+		{[]any{"program", "body", 0, "loc", "start", "line"}, 3.0},
+
+		{append(decl, 0, "id", "typeAnnotation", "typeAnnotation", "type"), "TSStringKeyword"},
+		{append(decl, 0, "init", "type"), "StringLiteral"},
+		{append(decl, 0, "init", "extra", "raw"), "'x'"},
+		{append(decl, 1, "init", "type"), "NumericLiteral"},
+		{append(decl, 1, "init", "value"), 1.5},
+		{append(decl, 2, "init", "type"), "BooleanLiteral"},
+		{append(decl, 3, "init", "type"), "NullLiteral"},
+		{append(decl, 4, "init", "type"), "BigIntLiteral"},
+		{append(decl, 4, "init", "value"), "10"},
+		{append(decl, 5, "init", "type"), "RegExpLiteral"},
+		{append(decl, 5, "init", "flags"), "g"},
+
+		{[]any{"program", "body", 1, "declarations", 0, "init", "properties", 0, "type"}, "ObjectProperty"},
+		{[]any{"program", "body", 1, "declarations", 0, "init", "properties", 0, "shorthand"}, true},
+		{[]any{"program", "body", 1, "declarations", 0, "init", "properties", 2, "type"}, "ObjectMethod"},
+		{[]any{"program", "body", 1, "declarations", 0, "init", "properties", 2, "kind"}, "method"},
+		{[]any{"program", "body", 1, "declarations", 0, "init", "properties", 2, "returnType", "typeAnnotation", "type"}, "TSVoidKeyword"},
+		{[]any{"program", "body", 1, "declarations", 0, "init", "properties", 3, "kind"}, "get"},
+
+		{[]any{"program", "body", 2, "typeParameters", "params", 0, "name"}, "T"},
+		{[]any{"program", "body", 2, "superTypeParameters", "params", 0, "typeName", "name"}, "T"},
+		{[]any{"program", "body", 2, "implements", 0, "type"}, "TSExpressionWithTypeArguments"},
+		{append(classBody, 0, "type"), "ClassPrivateProperty"},
+		{append(classBody, 0, "key", "type"), "PrivateName"},
+		{append(classBody, 0, "key", "id", "name"), "count"},
+		{append(classBody, 1, "type"), "ClassProperty"},
+		{append(classBody, 1, "static"), true},
+		{append(classBody, 2, "type"), "TSDeclareMethod"},
+		{append(classBody, 2, "abstract"), true},
+		{append(classBody, 3, "type"), "ClassMethod"},
+		{append(classBody, 3, "kind"), "method"},
+		{append(classBody, 3, "params", 0, "type"), "AssignmentPattern"},
+
+		{[]any{"program", "body", 3, "expression", "type"}, "OptionalCallExpression"},
+		{[]any{"program", "body", 3, "expression", "optional"}, false},
+		{[]any{"program", "body", 3, "expression", "callee", "type"}, "OptionalMemberExpression"},
+		{[]any{"program", "body", 3, "expression", "callee", "object", "optional"}, true},
+
+		{[]any{"program", "body", 4, "type"}, "ExportNamedDeclaration"},
+		{[]any{"program", "body", 4, "specifiers", 0, "type"}, "ExportNamespaceSpecifier"},
+
+		{[]any{"program", "body", 5, "body", "body", 0, "type"}, "TSMethodSignature"},
+		{[]any{"program", "body", 5, "body", "body", 0, "parameters", 0, "name"}, "scale"},
+		{[]any{"program", "body", 5, "body", "body", 0, "typeAnnotation", "typeAnnotation", "type"}, "TSNumberKeyword"},
+	}
+	for _, tt := range tests {
+		if got := get(t, file, tt.path...); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%v = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+
+	if _, ok := get(t, file, "program").(map[string]any)["comments"]; ok {
+		t.Error("comments should be moved from the program to the file")
+	}
+	if _, ok := get(t, file, "program", "body", 0).(map[string]any)["range"]; ok {
+		t.Error("range should be replaced by start and end")
+	}
+}
+
+func TestConvertNonProgram(t *testing.T) {
+	root := parse(t, "f(1);")
+	call := root.Children()[0].Children()[0]
+	if got := Convert(call).Type(); got != "CallExpression" {
+		t.Errorf("Convert(call).Type() = %q, want CallExpression", got)
+	}
+	if Convert(nil) != nil {
+		t.Error("Convert(nil) should return nil")
+	}
+}
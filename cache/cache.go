@@ -0,0 +1,143 @@
+// Package cache caches parsed trees by content, so that repeated analysis
+// runs skip the files that did not change.
+//
+// A Cache stores trees serialized in MessagePack in a chain of backends,
+// such as an in-memory LRU in front of a directory:
+//
+//	c := cache.New(cache.NewLRU(64<<20), cache.NewDir(".tsgoast-cache"))
+//	tree, err := c.ParseFile(parser, "src/app.ts")
+//
+// Entries are keyed by the path of the file, the hash of its content and
+// the version of the parser (see tsgoast.Parser.Version), so edited files
+// and upgraded parsers miss the cache rather than return stale trees.
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/ahmadramadhannn/tsgoast"
+)
+
+// Backend stores serialized trees by key. Backends must be safe for
+// concurrent use.
+type Backend interface {
+	// Get returns the data stored under key, with ok false if there is
+	// none.
+	Get(key string) (data []byte, ok bool, err error)
+
+	// Put stores data under key.
+	Put(key string, data []byte) error
+}
+
+// Stats counts the lookups of a Cache.
+type Stats struct {
+	Hits   int
+	Misses int
+	Errors int   // failed backend operations and undecodable entries
+	Err    error // last of the Errors
+}
+
+// Cache parses files, reusing the trees of the files parsed before from
+// its backends. It is safe for concurrent use, with one parser per
+// goroutine.
+type Cache struct {
+	backends []Backend
+
+	mu    sync.Mutex
+	stats Stats
+}
+
+// New returns a cache storing trees in backends, looked up in order. A
+// tree found in a backend is stored in the backends before it, and a
+// parsed tree in all of them.
+func New(backends ...Backend) *Cache {
+	return &Cache{backends: backends}
+}
+
+// Key returns the key of source, the content of the file at path, parsed
+// by p: a hex-encoded hash, usable as a file name.
+func Key(p *tsgoast.Parser, path string, source []byte) string {
+	content := sha256.Sum256(source)
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00%s\x00%x", p.Version(), path, content)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// ParseTree returns the tree of source, the content of the file at path,
+// like p.ParseTree. Failing backends do not fail ParseTree: the tree is
+// parsed instead, and the failure is counted in Stats.
+func (c *Cache) ParseTree(p *tsgoast.Parser, path string, source []byte) (*tsgoast.Tree, error) {
+	key := Key(p, path, source)
+	for i, backend := range c.backends {
+		data, ok, err := backend.Get(key)
+		if err != nil {
+			c.fail(err)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		tree := &tsgoast.Tree{}
+		if err := tree.UnmarshalMsgpack(data); err != nil {
+			c.fail(fmt.Errorf("cache entry %s: %w", key, err))
+			continue
+		}
+		c.put(c.backends[:i], key, data)
+		c.mu.Lock()
+		c.stats.Hits++
+		c.mu.Unlock()
+		return tree, nil
+	}
+
+	c.mu.Lock()
+	c.stats.Misses++
+	c.mu.Unlock()
+	tree, err := p.ParseTree(source)
+	if err != nil {
+		return nil, err
+	}
+	if len(c.backends) > 0 {
+		data, err := tree.MarshalMsgpack()
+		if err != nil {
+			c.fail(err)
+		} else {
+			c.put(c.backends, key, data)
+		}
+	}
+	return tree, nil
+}
+
+// ParseFile reads and parses the file at path, like ParseTree.
+func (c *Cache) ParseFile(p *tsgoast.Parser, path string) (*tsgoast.Tree, error) {
+	source, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	return c.ParseTree(p, path, source)
+}
+
+// Stats returns the lookups of the cache so far.
+func (c *Cache) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stats
+}
+
+func (c *Cache) put(backends []Backend, key string, data []byte) {
+	for _, backend := range backends {
+		if err := backend.Put(key, data); err != nil {
+			c.fail(err)
+		}
+	}
+}
+
+func (c *Cache) fail(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.stats.Errors++
+	c.stats.Err = err
+}
@@ -0,0 +1,144 @@
+package cache
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ahmadramadhannn/tsgoast"
+)
+
+func newParser(t *testing.T, newParser func() (*tsgoast.Parser, error)) *tsgoast.Parser {
+	t.Helper()
+	p, err := newParser()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+	t.Cleanup(p.Close)
+	return p
+}
+
+func TestCache(t *testing.T) {
+	p := newParser(t, tsgoast.New)
+	source := []byte("import { a } from './a';\nexport function f(x: number) { return a + x; }\n")
+
+	lru := NewLRU(1 << 20)
+	dir := NewDir(t.TempDir())
+	c := New(lru, dir)
+
+	tree, err := c.ParseTree(p, "src/f.ts", source)
+	if err != nil {
+		t.Fatalf("ParseTree() error = %v", err)
+	}
+	if s := c.Stats(); s.Misses != 1 || s.Hits != 0 {
+		t.Errorf("Stats() = %+v, want 1 miss", s)
+	}
+
+	cached, err := c.ParseTree(p, "src/f.ts", source)
+	if err != nil {
+		t.Fatalf("ParseTree() error = %v", err)
+	}
+	if s := c.Stats(); s.Hits != 1 {
+		t.Errorf("Stats() = %+v, want 1 hit", s)
+	}
+	if got, want := toJSON(t, cached), toJSON(t, tree); got != want {
+		t.Errorf("cached tree =\n%s\nwant\n%s", got, want)
+	}
+	if len(cached.Statements) != 2 {
+		t.Errorf("cached tree has %d statements, want 2", len(cached.Statements))
+	}
+
+	// A new process finds the tree on disk, and keeps it in memory
+	lru2 := NewLRU(1 << 20)
+	c2 := New(lru2, dir)
+	if _, err := c2.ParseTree(p, "src/f.ts", source); err != nil {
+		t.Fatalf("ParseTree() error = %v", err)
+	}
+	if s := c2.Stats(); s.Hits != 1 || lru2.Len() != 1 {
+		t.Errorf("Stats() = %+v with %d entries in memory, want 1 hit promoted", s, lru2.Len())
+	}
+
+	// Other contents, paths and parsers miss
+	tsx := newParser(t, tsgoast.NewTSX)
+	limited := newParser(t, tsgoast.New)
+	limited.SetLimits(tsgoast.Limits{MaxDepth: 10})
+	for _, k := range []string{
+		Key(p, "src/f.ts", append(source, '\n')),
+		Key(p, "src/g.ts", source),
+		Key(tsx, "src/f.ts", source),
+		Key(limited, "src/f.ts", source),
+	} {
+		if k == Key(p, "src/f.ts", source) {
+			t.Errorf("Key() = %s for different inputs", k)
+		}
+	}
+}
+
+func TestCacheRecovers(t *testing.T) {
+	p := newParser(t, tsgoast.New)
+	source := []byte("const a = 1;\n")
+	root := t.TempDir()
+	dir := NewDir(root)
+	key := Key(p, "a.ts", source)
+	if err := dir.Put(key, []byte("not msgpack")); err != nil {
+		t.Fatal(err)
+	}
+
+	failing := failingBackend{errors.New("backend down")}
+	c := New(failing, dir)
+	tree, err := c.ParseTree(p, "a.ts", source)
+	if err != nil {
+		t.Fatalf("ParseTree() error = %v", err)
+	}
+	if len(tree.Statements) != 1 {
+		t.Errorf("ParseTree() statements = %d, want 1", len(tree.Statements))
+	}
+	s := c.Stats()
+	if s.Misses != 1 || s.Errors != 3 || s.Err == nil {
+		t.Errorf("Stats() = %+v, want 1 miss and 3 errors", s)
+	}
+
+	// The corrupt entry was replaced
+	if _, err := New(dir).ParseTree(p, "a.ts", source); err != nil {
+		t.Fatalf("ParseTree() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, key[:2], key[2:])); err != nil {
+		t.Errorf("entry not written: %v", err)
+	}
+}
+
+func TestCacheParseFile(t *testing.T) {
+	p := newParser(t, tsgoast.New)
+	c := New(NewLRU(1 << 20))
+	path := filepath.Join(t.TempDir(), "a.ts")
+	if err := os.WriteFile(path, []byte("let x = 1;\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	for range 2 {
+		if _, err := c.ParseFile(p, path); err != nil {
+			t.Fatalf("ParseFile() error = %v", err)
+		}
+	}
+	if s := c.Stats(); s.Hits != 1 || s.Misses != 1 {
+		t.Errorf("Stats() = %+v, want 1 hit and 1 miss", s)
+	}
+	if _, err := c.ParseFile(p, path+".missing"); err == nil {
+		t.Error("ParseFile() of a missing file: want error")
+	}
+}
+
+type failingBackend struct{ err error }
+
+func (b failingBackend) Get(string) ([]byte, bool, error) { return nil, false, b.err }
+func (b failingBackend) Put(string, []byte) error         { return b.err }
+
+func toJSON(t *testing.T, tree *tsgoast.Tree) string {
+	t.Helper()
+	data, err := json.Marshal(tree)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	return string(data)
+}
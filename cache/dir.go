@@ -0,0 +1,66 @@
+package cache
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// Dir is an on-disk backend storing each entry in a file of a directory,
+// shared by the processes using it. Entries are never evicted: the
+// directory can be removed at any time to clear the cache.
+type Dir struct {
+	root string
+}
+
+// NewDir returns a backend storing entries under root, which is created
+// when the first entry is stored.
+func NewDir(root string) *Dir {
+	return &Dir{root: root}
+}
+
+// Get reads the entry of key.
+func (d *Dir) Get(key string) ([]byte, bool, error) {
+	data, err := os.ReadFile(d.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return data, true, nil
+}
+
+// Put writes the entry of key. The entry is written to a temporary file
+// first and renamed, so that concurrent readers never see partial data.
+func (d *Dir) Put(key string, data []byte) error {
+	path := d.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(data)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), path)
+	}
+	if err != nil {
+		os.Remove(f.Name())
+	}
+	return err
+}
+
+// path returns the file of key, in a subdirectory named after its first
+// two characters to keep directories small.
+func (d *Dir) path(key string) string {
+	if len(key) < 3 {
+		return filepath.Join(d.root, key)
+	}
+	return filepath.Join(d.root, key[:2], key[2:])
+}
@@ -0,0 +1,33 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDir(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "cache")
+	d := NewDir(root)
+	if _, ok, err := d.Get("abcdef"); ok || err != nil {
+		t.Errorf("Get() of a missing entry = %v, %v", ok, err)
+	}
+	if err := d.Put("abcdef", []byte("data")); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	if err := d.Put("abcdef", []byte("new data")); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	data, ok, err := d.Get("abcdef")
+	if !ok || err != nil || string(data) != "new data" {
+		t.Errorf("Get() = %q, %v, %v", data, ok, err)
+	}
+
+	entries, err := os.ReadDir(filepath.Join(root, "ab"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Name() != "cdef" {
+		t.Errorf("entries = %v, want cdef only", entries)
+	}
+}
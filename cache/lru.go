@@ -0,0 +1,72 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+)
+
+// LRU is an in-memory backend holding at most a number of bytes of data,
+// evicting the least recently used entries first.
+type LRU struct {
+	maxBytes int
+
+	mu      sync.Mutex
+	size    int
+	order   *list.List // of *lruEntry, most recently used first
+	entries map[string]*list.Element
+}
+
+type lruEntry struct {
+	key  string
+	data []byte
+}
+
+// NewLRU returns an in-memory backend holding at most maxBytes bytes of
+// data.
+func NewLRU(maxBytes int) *LRU {
+	return &LRU{maxBytes: maxBytes, order: list.New(), entries: make(map[string]*list.Element)}
+}
+
+// Get returns the data stored under key, marking it as recently used.
+func (l *LRU) Get(key string) ([]byte, bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	e, ok := l.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+	l.order.MoveToFront(e)
+	return e.Value.(*lruEntry).data, true, nil
+}
+
+// Put stores data under key, evicting the least recently used entries to
+// make room. Data larger than the LRU is not stored.
+func (l *LRU) Put(key string, data []byte) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if e, ok := l.entries[key]; ok {
+		l.remove(e)
+	}
+	if len(data) > l.maxBytes {
+		return nil
+	}
+	for l.size+len(data) > l.maxBytes {
+		l.remove(l.order.Back())
+	}
+	l.entries[key] = l.order.PushFront(&lruEntry{key: key, data: data})
+	l.size += len(data)
+	return nil
+}
+
+// Len returns the number of entries of the LRU.
+func (l *LRU) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.order.Len()
+}
+
+func (l *LRU) remove(e *list.Element) {
+	entry := l.order.Remove(e).(*lruEntry)
+	delete(l.entries, entry.key)
+	l.size -= len(entry.data)
+}
@@ -0,0 +1,40 @@
+package cache
+
+import (
+	"testing"
+)
+
+func TestLRU(t *testing.T) {
+	l := NewLRU(10)
+	l.Put("a", []byte("aaaa"))
+	l.Put("b", []byte("bbbb"))
+	if _, ok, _ := l.Get("a"); !ok {
+		t.Fatal("Get(a) missed")
+	}
+
+	// b is the least recently used
+	l.Put("c", []byte("cccc"))
+	if _, ok, _ := l.Get("b"); ok {
+		t.Error("Get(b) hit, want evicted")
+	}
+	for _, key := range []string{"a", "c"} {
+		if _, ok, _ := l.Get(key); !ok {
+			t.Errorf("Get(%s) missed", key)
+		}
+	}
+
+	// Replacing an entry frees its bytes
+	l.Put("a", []byte("aa"))
+	l.Put("d", []byte("dddd"))
+	if l.Len() != 3 {
+		t.Errorf("Len() = %d, want 3", l.Len())
+	}
+	if data, _, _ := l.Get("a"); string(data) != "aa" {
+		t.Errorf("Get(a) = %q, want aa", data)
+	}
+
+	l.Put("big", make([]byte, 11))
+	if _, ok, _ := l.Get("big"); ok || l.Len() != 3 {
+		t.Error("Put() stored data larger than the LRU")
+	}
+}
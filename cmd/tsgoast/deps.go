@@ -0,0 +1,190 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/ahmadramadhannn/tsgoast/analyzer"
+)
+
+// depsReport is the JSON output of deps.
+type depsReport struct {
+	Version  int           `json:"version"`
+	Files    []fileDeps    `json:"files"`
+	Packages []packageDeps `json:"packages"`
+	Cycles   [][]string    `json:"cycles"`
+}
+
+// fileDeps lists the modules a file imports: the files of the graph, and
+// the external packages.
+type fileDeps struct {
+	Path       string   `json:"path"`
+	Imports    []string `json:"imports"`
+	Packages   []string `json:"packages"`
+	Unresolved []string `json:"unresolved,omitempty"` // relative specifiers of no file
+}
+
+// packageDeps lists the files importing an external package.
+type packageDeps struct {
+	Package   string   `json:"package"`
+	Importers []string `json:"importers"`
+}
+
+// runDeps prints the import graph of files, telling the files of the graph
+// apart from external packages, and reports its cycles.
+func runDeps(e *env, args []string) int {
+	fs, opts := newFlags(e, "deps", "files...")
+	format := fs.String("format", "text", "output format: text, dot, or json as a shorthand for -output json")
+	external := fs.Bool("external", true, "include external packages")
+	files, ok := parseFlags(fs, args)
+	if !ok {
+		return exitError
+	}
+	if len(files) == 0 {
+		return usageError(fs, "no files")
+	}
+	if *format == "json" {
+		if !aliasJSON(fs, opts, "-format json") {
+			return exitError
+		}
+		*format = "text"
+	}
+	switch {
+	case *format != "text" && *format != "dot":
+		return usageError(fs, "unknown format %q", *format)
+	case *format != "text" && opts.json():
+		return usageError(fs, "-format %s and -output json are exclusive", *format)
+	}
+	files, err := sourceFiles(files)
+	if err != nil {
+		return fail(e.stderr, "deps", err)
+	}
+
+	ps := newParsers(e, opts)
+	defer ps.close()
+	graph := analyzer.NewImportGraph()
+	for _, path := range files {
+		f, err := ps.parse(path)
+		if err != nil {
+			return fail(e.stderr, "deps", err)
+		}
+		graph.AddFile(f.path, f.tree.Root)
+	}
+	report := newDepsReport(graph, *external)
+
+	switch {
+	case opts.json():
+		err = writeJSON(e.stdout, report)
+	case *format == "dot":
+		err = writeDepsDOT(e.stdout, report)
+	default:
+		for _, f := range report.Files {
+			fmt.Fprintln(e.stdout, f.Path)
+			for _, dep := range f.Imports {
+				fmt.Fprintf(e.stdout, "  %s\n", dep)
+			}
+			for _, pkg := range f.Packages {
+				fmt.Fprintf(e.stdout, "  %s (external)\n", pkg)
+			}
+			for _, spec := range f.Unresolved {
+				fmt.Fprintf(e.stdout, "  %s (unresolved)\n", spec)
+			}
+		}
+	}
+	if err != nil {
+		return fail(e.stderr, "deps", err)
+	}
+
+	for _, cycle := range report.Cycles {
+		fmt.Fprintf(e.stderr, "import cycle: %s\n", strings.Join(cycle, ", "))
+	}
+	if len(report.Cycles) > 0 {
+		return exitProblems
+	}
+	return exitOK
+}
+
+// newDepsReport returns the report of graph, with the external packages
+// if external is set.
+func newDepsReport(graph *analyzer.ImportGraph, external bool) depsReport {
+	report := depsReport{Version: jsonVersion, Files: []fileDeps{}, Packages: []packageDeps{}, Cycles: graph.Cycles()}
+	if report.Cycles == nil {
+		report.Cycles = [][]string{}
+	}
+	importers := make(map[string][]string)
+	for _, file := range graph.Files() {
+		deps := fileDeps{Path: file, Imports: graph.Dependencies(file), Packages: []string{}}
+		if deps.Imports == nil {
+			deps.Imports = []string{}
+		}
+		for _, spec := range graph.Specifiers(file) {
+			switch {
+			case analyzer.ClassifySpecifier(spec) == analyzer.ImportKindRelative:
+				if graph.Resolve(file, spec) == "" {
+					deps.Unresolved = append(deps.Unresolved, spec)
+				}
+			case external:
+				pkg := packageName(spec)
+				if !slices.Contains(deps.Packages, pkg) {
+					deps.Packages = append(deps.Packages, pkg)
+					importers[pkg] = append(importers[pkg], file)
+				}
+			}
+		}
+		slices.Sort(deps.Packages)
+		report.Files = append(report.Files, deps)
+	}
+	for pkg, files := range importers {
+		report.Packages = append(report.Packages, packageDeps{Package: pkg, Importers: files})
+	}
+	slices.SortFunc(report.Packages, func(a, b packageDeps) int { return strings.Compare(a.Package, b.Package) })
+	return report
+}
+
+// packageName returns the package of an external specifier, without the
+// path within it: "lodash" for "lodash/fp", "@scope/pkg" for
+// "@scope/pkg/sub". Node built-ins keep their node: prefix.
+func packageName(specifier string) string {
+	parts := strings.SplitN(specifier, "/", 3)
+	if strings.HasPrefix(specifier, "@") && len(parts) > 1 {
+		return parts[0] + "/" + parts[1]
+	}
+	return parts[0]
+}
+
+// writeDepsDOT writes the report as a Graphviz digraph: files as boxes,
+// packages as gray ellipses, and the imports between the files of a cycle
+// in red.
+func writeDepsDOT(w io.Writer, report depsReport) error {
+	inCycle := make(map[string]int) // file to its cycle, from 1
+	for i, cycle := range report.Cycles {
+		for _, file := range cycle {
+			inCycle[file] = i + 1
+		}
+	}
+	var b strings.Builder
+	b.WriteString("digraph imports {\n  rankdir=LR;\n  node [shape=box];\n")
+	for _, f := range report.Files {
+		fmt.Fprintf(&b, "  %q;\n", f.Path)
+	}
+	for _, p := range report.Packages {
+		fmt.Fprintf(&b, "  %q [shape=ellipse, style=filled, fillcolor=lightgray];\n", p.Package)
+	}
+	for _, f := range report.Files {
+		for _, dep := range f.Imports {
+			attrs := ""
+			if c := inCycle[f.Path]; c > 0 && inCycle[dep] == c {
+				attrs = " [color=red]"
+			}
+			fmt.Fprintf(&b, "  %q -> %q%s;\n", f.Path, dep, attrs)
+		}
+		for _, pkg := range f.Packages {
+			fmt.Fprintf(&b, "  %q -> %q [style=dashed];\n", f.Path, pkg)
+		}
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
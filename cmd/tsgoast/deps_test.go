@@ -0,0 +1,48 @@
+package main
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDeps(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"a.ts":       `import { b } from "./b"; import _ from "lodash/fp"; import x from "@scope/pkg/sub";`,
+		"b.ts":       `import { a } from "./a.js"; import fs from "node:fs";`,
+		"c/index.ts": `import { a } from "../a"; import { gone } from "./gone";`,
+	})
+	a, b, c := filepath.Join(dir, "a.ts"), filepath.Join(dir, "b.ts"), filepath.Join(dir, "c/index.ts")
+
+	code, stdout, stderr := runTest(t, "", "deps", dir)
+	want := a + "\n  " + b + "\n  @scope/pkg (external)\n  lodash (external)\n" +
+		b + "\n  " + a + "\n  node:fs (external)\n" +
+		c + "\n  " + a + "\n  ./gone (unresolved)\n"
+	if code != exitProblems || stdout != want {
+		t.Errorf("deps = %d\n%s\nwant\n%s", code, stdout, want)
+	}
+	if want := "import cycle: " + a + ", " + b + "\n"; stderr != want {
+		t.Errorf("deps reported %q, want %q", stderr, want)
+	}
+
+	code, stdout, _ = runTest(t, "", "deps", "-output", "json", "-external=false", c)
+	var report depsReport
+	if err := json.Unmarshal([]byte(stdout), &report); err != nil || code != exitOK {
+		t.Fatalf("deps -format json = %d, %v\n%s", code, err, stdout)
+	}
+	if len(report.Files) != 1 || len(report.Packages) != 0 || len(report.Cycles) != 0 || strings.Join(report.Files[0].Unresolved, " ") != "../a ./gone" {
+		t.Errorf("deps -format json = %+v", report)
+	}
+
+	_, stdout, _ = runTest(t, "", "deps", "-format", "dot", dir)
+	for _, want := range []string{
+		`"` + a + `" -> "` + b + `" [color=red];`,
+		`"` + c + `" -> "` + a + `";`,
+		`"lodash" [shape=ellipse`,
+	} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("deps -format dot lacks %s:\n%s", want, stdout)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ahmadramadhannn/tsgoast"
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+// changeReport is the JSON output of diff for a change.
+type changeReport struct {
+	Kind         tsgoast.ChangeKind `json:"kind"`
+	Name         string             `json:"name,omitempty"`
+	SyntaxKind   string             `json:"syntaxKind"`
+	OldRange     *ast.Range         `json:"oldRange,omitempty"`
+	NewRange     *ast.Range         `json:"newRange,omitempty"`
+	OldSignature string             `json:"oldSignature,omitempty"`
+	NewSignature string             `json:"newSignature,omitempty"`
+	Exported     bool               `json:"exported"`
+	Breaking     bool               `json:"breaking"`
+}
+
+// declarationKinds names the declaration kinds in the text output of diff;
+// other kinds are printed without their _declaration suffix.
+var declarationKinds = map[string]string{
+	"function_declaration":           "function",
+	"generator_function_declaration": "function",
+	"function_signature":             "function",
+	"abstract_class_declaration":     "class",
+	"lexical_declaration":            "variable",
+	"variable_declaration":           "variable",
+	"type_alias_declaration":         "type",
+	"method_definition":              "method",
+	"method_signature":               "method",
+	"abstract_method_signature":      "method",
+	"public_field_definition":        "field",
+	"property_signature":             "property",
+}
+
+// runDiff prints the declarations added, removed and modified between two
+// versions of a file, and whether the changes break its exported API.
+func runDiff(e *env, args []string) int {
+	fs, opts := newFlags(e, "diff", "old new")
+	breaking := fs.Bool("breaking", false, "report the breaking changes only")
+	files, ok := parseFlags(fs, args)
+	if !ok {
+		return exitError
+	}
+	if len(files) != 2 {
+		return usageError(fs, "want two files")
+	}
+
+	ps := newParsers(e, opts)
+	defer ps.close()
+	oldFile, err := ps.parse(files[0])
+	if err != nil {
+		return fail(e.stderr, "diff", err)
+	}
+	newFile, err := ps.parse(files[1])
+	if err != nil {
+		return fail(e.stderr, "diff", err)
+	}
+
+	reports := []changeReport{}
+	for _, c := range tsgoast.Diff(oldFile.tree, newFile.tree) {
+		if *breaking && !c.Breaking() {
+			continue
+		}
+		r := changeReport{Kind: c.Kind, Name: c.Name, SyntaxKind: c.SyntaxKind, Exported: c.Exported(), Breaking: c.Breaking()}
+		if c.Old != nil {
+			r.OldRange, r.OldSignature = &c.OldRange, tsgoast.Signature(c.Old)
+		}
+		if c.New != nil {
+			r.NewRange, r.NewSignature = &c.NewRange, tsgoast.Signature(c.New)
+		}
+		if c.Kind == tsgoast.ChangeModified && !c.SignatureChanged() {
+			r.OldSignature, r.NewSignature = "", ""
+		}
+		reports = append(reports, r)
+	}
+
+	if opts.json() {
+		out := struct {
+			Version int            `json:"version"`
+			Old     string         `json:"old"`
+			New     string         `json:"new"`
+			Changes []changeReport `json:"changes"`
+		}{jsonVersion, oldFile.path, newFile.path, reports}
+		if err := writeJSON(e.stdout, out); err != nil {
+			return fail(e.stderr, "diff", err)
+		}
+	} else {
+		for _, r := range reports {
+			var at string
+			if r.NewRange != nil {
+				at = position(newFile.path, *r.NewRange)
+			} else {
+				at = position(oldFile.path, *r.OldRange)
+			}
+			line := fmt.Sprintf("%s: %s %s", at, r.Kind, declarationKind(r.SyntaxKind))
+			if r.Name != "" {
+				line += " " + r.Name
+			}
+			if r.Breaking {
+				line += " (breaking)"
+			}
+			fmt.Fprintln(e.stdout, line)
+			if r.OldSignature != "" && r.NewSignature != "" {
+				fmt.Fprintf(e.stdout, "\t- %s\n\t+ %s\n", r.OldSignature, r.NewSignature)
+			}
+		}
+	}
+	if len(reports) > 0 {
+		return exitProblems
+	}
+	return exitOK
+}
+
+// declarationKind returns the name of a declaration kind in the text
+// output of diff.
+func declarationKind(kind string) string {
+	if name, ok := declarationKinds[kind]; ok {
+		return name
+	}
+	return strings.ReplaceAll(strings.TrimSuffix(kind, "_declaration"), "_", " ")
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"old.ts": "export function load(id: string) {\n  return get(id);\n}\nexport function drop() {}\nfunction helper() {}\n",
+		"new.ts": "export function load(id: number) {\n  return get(id);\n}\nfunction helper() { return 1; }\nexport const added = 1;\n",
+	})
+	old, new := filepath.Join(dir, "old.ts"), filepath.Join(dir, "new.ts")
+
+	code, stdout, _ := runTest(t, "", "diff", old, new)
+	want := new + ":1:1: modified function load (breaking)\n" +
+		"\t- export function load(id: string)\n" +
+		"\t+ export function load(id: number)\n" +
+		new + ":4:1: modified function helper\n" +
+		new + ":5:1: added variable added\n" +
+		old + ":4:1: removed function drop (breaking)\n"
+	if code != exitProblems || stdout != want {
+		t.Errorf("diff = %d\n%s\nwant\n%s", code, stdout, want)
+	}
+
+	code, stdout, _ = runTest(t, "", "diff", "-breaking", "-output", "json", old, new)
+	var out struct {
+		Old, New string
+		Changes  []changeReport
+	}
+	if err := json.Unmarshal([]byte(stdout), &out); err != nil || code != exitProblems {
+		t.Fatalf("diff -output json = %d, %v\n%s", code, err, stdout)
+	}
+	if out.Old != old || len(out.Changes) != 2 || out.Changes[0].Name != "load" || out.Changes[1].NewRange != nil || !out.Changes[1].Breaking {
+		t.Errorf("diff -breaking -output json = %+v", out)
+	}
+
+	// Standard input stands for either file
+	if code, stdout, _ := runTest(t, "function helper() {}\n", "diff", "-", old, "-stdin-path", "a.ts"); code != exitProblems || stdout != old+":1:1: added function load\n"+old+":4:1: added function drop\n" {
+		t.Errorf("diff - old.ts = %d\n%s", code, stdout)
+	}
+	if code, stdout, _ := runTest(t, "", "diff", old, old); code != exitOK || stdout != "" {
+		t.Errorf("diff of the same file = %d, %q", code, stdout)
+	}
+	if code, _, _ := runTest(t, "", "diff", old); code != exitError {
+		t.Errorf("diff of one file = %d, want %d", code, exitError)
+	}
+}
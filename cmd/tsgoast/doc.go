@@ -0,0 +1,38 @@
+// Command tsgoast parses and inspects TypeScript from the shell.
+//
+// Usage:
+//
+//	tsgoast <command> [flags] [files]
+//
+// The commands are:
+//
+//	parse    parse files, reporting syntax errors, or print their trees as JSON
+//	inspect  print the syntax tree of files, or statistics on their parse
+//	query    print the nodes of files matching a selector or tree-sitter query
+//	metrics  print the size and complexity of functions and files
+//	lint     report problems found by the configured rules
+//	symbols  print the outline of files: classes, members, functions and types
+//	deps     print the import graph of files and report its cycles
+//	diff     print the declarations changed between two files, and breaking changes
+//	repl     explore the syntax tree of a file interactively
+//
+// Run tsgoast <command> -h for the flags of a command. Flags may follow
+// the files. Files ending in .tsx or .jsx are parsed as TSX.
+//
+// The file - stands for standard input, such as the buffer of an editor.
+// The -stdin-path flag gives the path it is reported as, which also
+// chooses its dialect:
+//
+//	tsgoast lint -stdin-path src/app.tsx - < buffer
+//
+// Every command but repl takes -output json to write its results as a
+// single JSON object with a "version" field, for other programs to read.
+// The version changes only for changes that could break them. Run tsgoast
+// help json for the schema of each command.
+//
+// The exit code is 0 on success, 1 when the files have syntax errors, or
+// for query when nothing matched, for metrics when a threshold is
+// exceeded, for lint when an error is found, for deps when the imports
+// have cycles and for diff when the files differ, and 2 when the command
+// could not run, such as for a missing file.
+package main
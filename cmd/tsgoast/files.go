@@ -0,0 +1,199 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/ahmadramadhannn/tsgoast"
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+// sourceExts are the extensions of the files found in directories.
+var sourceExts = []string{".ts", ".tsx", ".mts", ".cts", ".js", ".jsx", ".mjs", ".cjs"}
+
+// sourceFiles returns the files of paths, replacing directories with the
+// source files below them, in lexical order. Dependencies and hidden
+// directories, such as node_modules and .git, are skipped. The path -
+// stands for standard input and is kept as is.
+func sourceFiles(paths []string) ([]string, error) {
+	var files []string
+	for _, path := range paths {
+		if path == "-" {
+			files = append(files, path)
+			continue
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+		err = filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				if p != path && (d.Name() == "node_modules" || strings.HasPrefix(d.Name(), ".")) {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if slices.Contains(sourceExts, filepath.Ext(p)) && !strings.HasSuffix(p, ".d.ts") {
+				files = append(files, p)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+// stdinName is the name reported for standard input without -stdin-path.
+const stdinName = "<stdin>"
+
+// file is a parsed source file.
+type file struct {
+	path   string // as reported: the -stdin-path or stdinName for standard input
+	source []byte
+	tree   *tsgoast.Tree
+	parser *tsgoast.Parser
+}
+
+// parsers parses files with the parser of their dialect, created on first
+// use.
+type parsers struct {
+	ts, tsx *tsgoast.Parser
+
+	stdin     io.Reader
+	stdinPath string
+	stdinRead bool
+}
+
+// newParsers returns the parsers of a command run in e with opts.
+func newParsers(e *env, opts *options) *parsers {
+	return &parsers{stdin: e.stdin, stdinPath: opts.stdinPath}
+}
+
+// name returns the path reported for the file at path: the -stdin-path,
+// or stdinName, for standard input.
+func (ps *parsers) name(path string) string {
+	if path != "-" {
+		return path
+	}
+	if ps.stdinPath != "" {
+		return ps.stdinPath
+	}
+	return stdinName
+}
+
+// get returns the parser for the file at path.
+func (ps *parsers) get(path string) (*tsgoast.Parser, error) {
+	parser, newParser := &ps.ts, tsgoast.New
+	switch filepath.Ext(ps.name(path)) {
+	case ".tsx", ".jsx":
+		parser, newParser = &ps.tsx, tsgoast.NewTSX
+	}
+	if *parser == nil {
+		p, err := newParser()
+		if err != nil {
+			return nil, err
+		}
+		*parser = p
+	}
+	return *parser, nil
+}
+
+// read returns the contents of the file at path. Standard input can be
+// read only once.
+func (ps *parsers) read(path string) ([]byte, error) {
+	if path != "-" {
+		return os.ReadFile(path)
+	}
+	if ps.stdinRead {
+		return nil, errors.New("standard input given more than once")
+	}
+	ps.stdinRead = true
+	source, err := io.ReadAll(ps.stdin)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read standard input: %w", err)
+	}
+	return source, nil
+}
+
+// parse reads and parses the file at path.
+func (ps *parsers) parse(path string) (*file, error) {
+	source, err := ps.read(path)
+	if err != nil {
+		return nil, err
+	}
+	parser, err := ps.get(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(source) == 0 {
+		// An empty file is a valid, empty module
+		source = []byte("\n")
+	}
+	name := ps.name(path)
+	tree, err := parser.ParseTree(source)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return &file{path: name, source: source, tree: tree, parser: parser}, nil
+}
+
+func (ps *parsers) close() {
+	for _, p := range []*tsgoast.Parser{ps.ts, ps.tsx} {
+		if p != nil {
+			p.Close()
+		}
+	}
+}
+
+// syntaxError is a syntax error of a parsed file.
+type syntaxError struct {
+	Range   ast.Range `json:"range"`
+	Message string    `json:"message"`
+}
+
+// syntaxErrors returns the syntax errors of tree: the text tree-sitter
+// could not parse, and the tokens it assumed missing, which are empty.
+// The nodes under an error are not reported again.
+func syntaxErrors(tree *tsgoast.Tree) []syntaxError {
+	var errs []syntaxError
+	ast.Inspect(tree.Root, func(node ast.Node) bool {
+		r := node.Range()
+		switch {
+		case node.SyntaxKind() == "ERROR":
+			text := firstLine(strings.TrimSpace(node.Text()))
+			errs = append(errs, syntaxError{Range: r, Message: fmt.Sprintf("syntax error at %q", text)})
+			return false
+		case node.Parent() != nil && r.Start.Offset == r.End.Offset && len(node.Children()) == 0:
+			errs = append(errs, syntaxError{Range: r, Message: fmt.Sprintf("missing %s", node.SyntaxKind())})
+		}
+		return true
+	})
+	return errs
+}
+
+// position formats the start of r in path as path:line:column, counting
+// from 1 like compilers do.
+func position(path string, r ast.Range) string {
+	return fmt.Sprintf("%s:%d:%d", path, r.Start.Line+1, r.Start.Column+1)
+}
+
+// fail reports err and returns exitError.
+func fail(w io.Writer, name string, err error) int {
+	fmt.Fprintf(w, "tsgoast %s: %v\n", name, err)
+	return exitError
+}
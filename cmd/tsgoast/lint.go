@@ -0,0 +1,420 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/ahmadramadhannn/tsgoast/analyzer"
+	"github.com/ahmadramadhannn/tsgoast/ast"
+	"github.com/ahmadramadhannn/tsgoast/sarif"
+)
+
+// defaultLintConfig is the configuration file lint reads when -config is
+// not given, if it exists.
+const defaultLintConfig = ".tsgoast.json"
+
+// lintRule is a rule of the lint command, built on a detection of the
+// analyzer.
+type lintRule struct {
+	id          string
+	description string
+	severity    analyzer.Severity // by default; empty for rules off by default
+	options     ruleOptions       // by default
+	check       func(a *analyzer.Analyzer, opts ruleOptions) []analyzer.Finding
+}
+
+// ruleOptions are the options of a rule in the configuration.
+type ruleOptions struct {
+	Max      int      `json:"max,omitempty"`      // long-function, complexity
+	Patterns []string `json:"patterns,omitempty"` // banned-call
+}
+
+// ruleConfig configures a rule: either a severity, "error", "warning",
+// "note" or "off", or an object holding the severity and the options.
+type ruleConfig struct {
+	Severity string `json:"severity"`
+	ruleOptions
+}
+
+func (c *ruleConfig) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		return json.Unmarshal(data, &c.Severity)
+	}
+	type plain ruleConfig
+	return json.Unmarshal(data, (*plain)(c))
+}
+
+// lintConfig is the configuration file of lint:
+//
+//	{
+//	  "rules": {
+//	    "no-console": "error",
+//	    "non-null-assertion": "off",
+//	    "long-function": {"severity": "warning", "max": 80},
+//	    "banned-call": {"severity": "error", "patterns": ["eval", "document.write"]}
+//	  }
+//	}
+//
+// Rules not listed keep their default severity and options.
+type lintConfig struct {
+	Rules map[string]ruleConfig `json:"rules"`
+}
+
+// lintRules are the rules of lint, by ID.
+var lintRules = []lintRule{
+	{"no-console", "Calls to console methods", analyzer.SeverityWarning, ruleOptions{}, func(a *analyzer.Analyzer, _ ruleOptions) []analyzer.Finding {
+		return relabel(findings(a.FindBannedCalls(analyzer.BannedCallConfig{Patterns: []string{"console.*"}})), "no-console")
+	}},
+	{analyzer.RuleDebugger, analyzer.RuleDescriptions[analyzer.RuleDebugger], analyzer.SeverityWarning, ruleOptions{}, func(a *analyzer.Analyzer, _ ruleOptions) []analyzer.Finding {
+		return findings(a.FindBannedCalls(analyzer.BannedCallConfig{Debugger: true}))
+	}},
+	{analyzer.RuleBannedCall, analyzer.RuleDescriptions[analyzer.RuleBannedCall], "", ruleOptions{}, func(a *analyzer.Analyzer, opts ruleOptions) []analyzer.Finding {
+		return findings(a.FindBannedCalls(analyzer.BannedCallConfig{Patterns: opts.Patterns}))
+	}},
+	{analyzer.RuleUnusedVariable, analyzer.RuleDescriptions[analyzer.RuleUnusedVariable], analyzer.SeverityWarning, ruleOptions{}, func(a *analyzer.Analyzer, _ ruleOptions) []analyzer.Finding {
+		return findings(a.FindUnusedVariables())
+	}},
+	{analyzer.RuleUnusedImport, analyzer.RuleDescriptions[analyzer.RuleUnusedImport], analyzer.SeverityWarning, ruleOptions{}, func(a *analyzer.Analyzer, _ ruleOptions) []analyzer.Finding {
+		return findings(a.FindUnusedImports())
+	}},
+	{analyzer.RuleNonNullAssertion, analyzer.RuleDescriptions[analyzer.RuleNonNullAssertion], analyzer.SeverityNote, ruleOptions{}, func(a *analyzer.Analyzer, _ ruleOptions) []analyzer.Finding {
+		return findings(a.FindNonNullAssertions())
+	}},
+	{"long-function", "Functions of more lines than max", analyzer.SeverityWarning, ruleOptions{Max: 50}, func(a *analyzer.Analyzer, opts ruleOptions) []analyzer.Finding {
+		var fs []analyzer.Finding
+		for _, fn := range a.FunctionMetrics() {
+			if fn.LOC > opts.Max {
+				fs = append(fs, analyzer.Finding{RuleID: "long-function", Message: fmt.Sprintf("function %s has %d lines (max %d)", fn.Name, fn.LOC, opts.Max), Node: fn.Node, Range: fn.Range})
+			}
+		}
+		return fs
+	}},
+	{"complexity", "Functions of a higher cyclomatic complexity than max", "", ruleOptions{Max: 10}, func(a *analyzer.Analyzer, opts ruleOptions) []analyzer.Finding {
+		var fs []analyzer.Finding
+		for _, fn := range a.FunctionMetrics() {
+			if fn.Complexity > opts.Max {
+				fs = append(fs, analyzer.Finding{RuleID: "complexity", Message: fmt.Sprintf("function %s has complexity %d (max %d)", fn.Name, fn.Complexity, opts.Max), Node: fn.Node, Range: fn.Range})
+			}
+		}
+		return fs
+	}},
+	{analyzer.RuleFloatingPromise, analyzer.RuleDescriptions[analyzer.RuleFloatingPromise], analyzer.SeverityWarning, ruleOptions{}, func(a *analyzer.Analyzer, _ ruleOptions) []analyzer.Finding {
+		return findings(a.FindFloatingPromises())
+	}},
+	{analyzer.RulePromiseConstructor, analyzer.RuleDescriptions[analyzer.RulePromiseConstructor], analyzer.SeverityWarning, ruleOptions{}, func(a *analyzer.Analyzer, _ ruleOptions) []analyzer.Finding {
+		return findings(a.FindPromiseConstructorAntiPatterns())
+	}},
+	{analyzer.RuleMisplacedAwait, analyzer.RuleDescriptions[analyzer.RuleMisplacedAwait], analyzer.SeverityError, ruleOptions{}, func(a *analyzer.Analyzer, _ ruleOptions) []analyzer.Finding {
+		return findings(a.MisplacedAwaits())
+	}},
+	{analyzer.RuleNonExhaustiveSwitch, analyzer.RuleDescriptions[analyzer.RuleNonExhaustiveSwitch], analyzer.SeverityWarning, ruleOptions{}, func(a *analyzer.Analyzer, _ ruleOptions) []analyzer.Finding {
+		return findings(a.FindNonExhaustiveSwitches())
+	}},
+	{analyzer.RuleUnusedEnumMember, analyzer.RuleDescriptions[analyzer.RuleUnusedEnumMember], analyzer.SeverityNote, ruleOptions{}, func(a *analyzer.Analyzer, _ ruleOptions) []analyzer.Finding {
+		return findings(a.FindUnusedEnumMembers())
+	}},
+	{analyzer.RuleUnusedTypeParameter, analyzer.RuleDescriptions[analyzer.RuleUnusedTypeParameter], analyzer.SeverityWarning, ruleOptions{}, func(a *analyzer.Analyzer, _ ruleOptions) []analyzer.Finding {
+		var fs []analyzer.Finding
+		for _, u := range a.FindUnusedTypeParameters() {
+			if f := u.Finding(); f.RuleID == analyzer.RuleUnusedTypeParameter {
+				fs = append(fs, f)
+			}
+		}
+		return fs
+	}},
+}
+
+// findings returns the findings of the results of a detection.
+func findings[T interface{ Finding() analyzer.Finding }](results []T) []analyzer.Finding {
+	fs := make([]analyzer.Finding, len(results))
+	for i, r := range results {
+		fs[i] = r.Finding()
+	}
+	return fs
+}
+
+// relabel sets the rule of findings to id.
+func relabel(fs []analyzer.Finding, id string) []analyzer.Finding {
+	for i := range fs {
+		fs[i].RuleID = id
+	}
+	return fs
+}
+
+// enabledRule is a rule with its configured severity and options.
+type enabledRule struct {
+	*lintRule
+	severity analyzer.Severity
+	options  ruleOptions
+}
+
+// configure returns the rules enabled by config.
+func configure(config lintConfig) ([]enabledRule, error) {
+	for id := range config.Rules {
+		if !slices.ContainsFunc(lintRules, func(r lintRule) bool { return r.id == id }) {
+			return nil, fmt.Errorf("unknown rule %q", id)
+		}
+	}
+	var rules []enabledRule
+	for i := range lintRules {
+		rule := &lintRules[i]
+		enabled := enabledRule{lintRule: rule, severity: rule.severity, options: rule.options}
+		if c, ok := config.Rules[rule.id]; ok {
+			switch analyzer.Severity(c.Severity) {
+			case analyzer.SeverityError, analyzer.SeverityWarning, analyzer.SeverityNote:
+				enabled.severity = analyzer.Severity(c.Severity)
+			case "off":
+				enabled.severity = ""
+			case "":
+				// Options only
+				if enabled.severity == "" {
+					enabled.severity = analyzer.SeverityWarning
+				}
+			default:
+				return nil, fmt.Errorf("rule %s: unknown severity %q", rule.id, c.Severity)
+			}
+			if c.Max > 0 {
+				enabled.options.Max = c.Max
+			}
+			if c.Patterns != nil {
+				enabled.options.Patterns = c.Patterns
+			}
+		}
+		if enabled.severity != "" {
+			rules = append(rules, enabled)
+		}
+	}
+	return rules, nil
+}
+
+// loadLintConfig reads the configuration file at path, or the default one
+// if path is empty and it exists.
+func loadLintConfig(path string) (lintConfig, error) {
+	var config lintConfig
+	name := path
+	if name == "" {
+		name = defaultLintConfig
+	}
+	data, err := os.ReadFile(name)
+	if path == "" && errors.Is(err, fs.ErrNotExist) {
+		return config, nil
+	}
+	if err != nil {
+		return config, err
+	}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return config, fmt.Errorf("%s: %w", name, err)
+	}
+	return config, nil
+}
+
+// runLint reports the findings of the configured rules in files.
+func runLint(e *env, args []string) int {
+	flags, opts := newFlags(e, "lint", "files...")
+	configPath := flags.String("config", "", "configuration file (default "+defaultLintConfig+" if it exists)")
+	format := flags.String("format", "text", "output format: text, sarif, or json as a shorthand for -output json")
+	strict := flags.Bool("strict", false, "fail for warnings too, not only errors")
+	list := flags.Bool("rules", false, "list the rules and their configured severity, and exit")
+	files, ok := parseFlags(flags, args)
+	if !ok {
+		return exitError
+	}
+	if *format == "json" {
+		if !aliasJSON(flags, opts, "-format json") {
+			return exitError
+		}
+		*format = "text"
+	}
+	switch {
+	case *format != "text" && *format != "sarif":
+		return usageError(flags, "unknown format %q", *format)
+	case *format != "text" && opts.json():
+		return usageError(flags, "-format %s and -output json are exclusive", *format)
+	}
+	config, err := loadLintConfig(*configPath)
+	if err != nil {
+		return fail(e.stderr, "lint", err)
+	}
+	rules, err := configure(config)
+	if err != nil {
+		return fail(e.stderr, "lint", err)
+	}
+	if *list {
+		reports := make([]ruleReport, len(lintRules))
+		for i, rule := range lintRules {
+			severity := "off"
+			if i := slices.IndexFunc(rules, func(r enabledRule) bool { return r.id == rule.id }); i >= 0 {
+				severity = string(rules[i].severity)
+			}
+			reports[i] = ruleReport{ID: rule.id, Severity: severity, Description: rule.description}
+		}
+		if opts.json() {
+			err := writeJSON(e.stdout, struct {
+				Version int          `json:"version"`
+				Rules   []ruleReport `json:"rules"`
+			}{jsonVersion, reports})
+			if err != nil {
+				return fail(e.stderr, "lint", err)
+			}
+			return exitOK
+		}
+		tw := tabwriter.NewWriter(e.stdout, 0, 8, 2, ' ', 0)
+		for _, r := range reports {
+			fmt.Fprintf(tw, "%s\t%s\t%s\n", r.ID, r.Severity, r.Description)
+		}
+		tw.Flush()
+		return exitOK
+	}
+	if len(files) == 0 {
+		return usageError(flags, "no files")
+	}
+	files, err = sourceFiles(files)
+	if err != nil {
+		return fail(e.stderr, "lint", err)
+	}
+
+	ps := newParsers(e, opts)
+	defer ps.close()
+	all := make([]analyzer.Finding, 0)
+	for _, path := range files {
+		f, err := ps.parse(path)
+		if err != nil {
+			return fail(e.stderr, "lint", err)
+		}
+		all = append(all, lint(f.tree.Root, f.path, rules)...)
+	}
+
+	switch {
+	case *format == "sarif":
+		data, err := sarif.Marshal(all)
+		if err != nil {
+			return fail(e.stderr, "lint", err)
+		}
+		fmt.Fprintf(e.stdout, "%s\n", data)
+	case opts.json():
+		reports := make([]findingReport, len(all))
+		for i, f := range all {
+			reports[i] = findingReport{Path: f.File, Range: f.Range, Rule: f.RuleID, Severity: string(f.Severity), Message: f.Message}
+		}
+		err := writeJSON(e.stdout, struct {
+			Version  int             `json:"version"`
+			Findings []findingReport `json:"findings"`
+		}{jsonVersion, reports})
+		if err != nil {
+			return fail(e.stderr, "lint", err)
+		}
+	default:
+		for _, f := range all {
+			fmt.Fprintf(e.stdout, "%s: %s: %s (%s)\n", position(f.File, f.Range), f.Severity, f.Message, f.RuleID)
+		}
+	}
+
+	for _, f := range all {
+		if f.Severity == analyzer.SeverityError || *strict && f.Severity == analyzer.SeverityWarning {
+			return exitProblems
+		}
+	}
+	return exitOK
+}
+
+// lint returns the findings of rules in the tree rooted at root, parsed
+// from the file at path, ordered by position, leaving out those that
+// comments suppress.
+func lint(root *ast.BaseNode, path string, rules []enabledRule) []analyzer.Finding {
+	a := analyzer.New(root)
+	s := suppressions(root)
+	var fs []analyzer.Finding
+	for _, rule := range rules {
+		for _, f := range rule.check(a, rule.options) {
+			if s.suppressed(rule.id, int(f.Range.Start.Line)) {
+				continue
+			}
+			f.Severity, f.File = rule.severity, path
+			fs = append(fs, f)
+		}
+	}
+	slices.SortStableFunc(fs, func(a, b analyzer.Finding) int {
+		return int(a.Range.Start.Offset) - int(b.Range.Start.Offset)
+	})
+	return fs
+}
+
+// findingReport is the JSON output of lint for a finding.
+type findingReport struct {
+	Path     string    `json:"path"`
+	Range    ast.Range `json:"range"`
+	Rule     string    `json:"rule"`
+	Severity string    `json:"severity"`
+	Message  string    `json:"message"`
+}
+
+// ruleReport is the JSON output of lint -rules for a rule.
+type ruleReport struct {
+	ID          string `json:"id"`
+	Severity    string `json:"severity"`
+	Description string `json:"description"`
+}
+
+// Suppression comments, followed by the rules they suppress, separated by
+// commas or spaces, or by nothing to suppress every rule:
+//
+//	// tsgoast-disable-next-line no-console
+//	console.log(x); // tsgoast-disable-line
+//	/* tsgoast-disable-file non-null-assertion, unused-variable */
+const (
+	disableNextLine = "tsgoast-disable-next-line"
+	disableLine     = "tsgoast-disable-line"
+	disableFile     = "tsgoast-disable-file"
+)
+
+// suppression holds the rules suppressed by the comments of a file, by
+// 0-based line, with -1 for the whole file. A nil rule list suppresses
+// every rule.
+type suppression map[int][][]string
+
+// suppressions returns the suppressions of the comments in the tree rooted
+// at root.
+func suppressions(root ast.Node) suppression {
+	s := make(suppression)
+	ast.Inspect(root, func(node ast.Node) bool {
+		if node.SyntaxKind() != "comment" {
+			return true
+		}
+		text := strings.TrimPrefix(node.Text(), "//")
+		text = strings.TrimSuffix(strings.TrimPrefix(text, "/*"), "*/")
+		directive, rest, _ := strings.Cut(strings.TrimSpace(text), " ")
+		var line int
+		switch directive {
+		case disableNextLine:
+			line = int(node.Range().End.Line) + 1
+		case disableLine:
+			line = int(node.Range().Start.Line)
+		case disableFile:
+			line = -1
+		default:
+			return false
+		}
+		rules := strings.FieldsFunc(rest, func(r rune) bool { return r == ',' || r == ' ' || r == '\t' })
+		s[line] = append(s[line], rules)
+		return false
+	})
+	return s
+}
+
+// suppressed reports whether rule is suppressed on line.
+func (s suppression) suppressed(rule string, line int) bool {
+	for _, l := range []int{-1, line} {
+		for _, rules := range s[l] {
+			if len(rules) == 0 || slices.Contains(rules, rule) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLint(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"a.ts": `import { unused } from "./b";
+
+export function f(user) {
+	const name = user!.name;
+	console.log(user);
+	console.info(user); // tsgoast-disable-line
+	// tsgoast-disable-next-line no-console, unused-variable
+	console.warn(user); const tmp = 1;
+	debugger;
+}
+`,
+		"b.ts": "/* tsgoast-disable-file */\nconsole.log(1);\n",
+	})
+	a := filepath.Join(dir, "a.ts")
+
+	code, stdout, _ := runTest(t, "", "lint", dir)
+	want := a + ":1:10: warning: unused is imported from ./b but never used (unused-import)\n" +
+		a + ":4:8: warning: name is declared but never used (unused-variable)\n" +
+		a + ":4:15: note: non-null assertion on user (non-null-assertion)\n" +
+		a + ":5:2: warning: call to banned function console.log (no-console)\n" +
+		a + ":9:2: warning: unexpected debugger statement (no-debugger)\n"
+	if code != exitOK || stdout != want {
+		t.Errorf("lint = %d\n%s\nwant\n%s", code, stdout, want)
+	}
+	if code, _, _ := runTest(t, "", "lint", "-strict", dir); code != exitProblems {
+		t.Errorf("lint -strict = %d, want %d", code, exitProblems)
+	}
+
+	config := filepath.Join(dir, "lint.json")
+	err := os.WriteFile(config, []byte(`{"rules": {
+		"no-console": "error",
+		"non-null-assertion": "off",
+		"unused-import": "off",
+		"unused-variable": "off",
+		"no-debugger": "off",
+		"long-function": {"max": 3},
+		"banned-call": {"severity": "note", "patterns": ["console.info"]}
+	}}`), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	code, stdout, _ = runTest(t, "", "lint", a, "-config", config, "-output", "json")
+	var out struct {
+		Version  int
+		Findings []findingReport
+	}
+	if err := json.Unmarshal([]byte(stdout), &out); err != nil || out.Version != jsonVersion {
+		t.Fatalf("lint -format json = %v\n%s", err, stdout)
+	}
+	var got []string
+	for _, r := range out.Findings {
+		got = append(got, r.Rule+" "+r.Severity)
+	}
+	if want := "long-function warning|no-console error"; code != exitProblems || strings.Join(got, "|") != want {
+		t.Errorf("lint -config = %d, %s, want %s", code, strings.Join(got, "|"), want)
+	}
+
+	code, stdout, _ = runTest(t, "", "lint", a, "-format", "sarif")
+	if code != exitOK || !strings.Contains(stdout, `"ruleId": "no-console"`) || !strings.Contains(stdout, `"uri": "`+a+`"`) {
+		t.Errorf("lint -format sarif = %d\n%s", code, stdout)
+	}
+
+	code, stdout, _ = runTest(t, "", "lint", "-rules", "-config", config)
+	if code != exitOK || !strings.Contains(stdout, "banned-call") || !strings.Contains(stdout, "unused-import          off") {
+		t.Errorf("lint -rules = %d\n%s", code, stdout)
+	}
+
+	if err := os.WriteFile(config, []byte(`{"rules": {"no-such-rule": "error"}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if code, _, stderr := runTest(t, "", "lint", a, "-config", config); code != exitError || !strings.Contains(stderr, "no-such-rule") {
+		t.Errorf("lint with an unknown rule = %d, %q", code, stderr)
+	}
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+// Exit codes.
+const (
+	exitOK       = 0
+	exitProblems = 1 // syntax errors or other findings in the input
+	exitError    = 2 // bad usage or unreadable input
+)
+
+// env is the environment of a command.
+type env struct {
+	stdin          io.Reader
+	stdout, stderr io.Writer
+}
+
+// command is a subcommand of tsgoast.
+type command struct {
+	name    string
+	summary string
+	run     func(e *env, args []string) int
+}
+
+var commands []command
+
+func init() {
+	commands = []command{
+		{"parse", "parse files, reporting syntax errors, or print their trees as JSON", runParse},
+		{"inspect", "print the syntax tree of files, or statistics on their parse", runInspect},
+		{"query", "print the nodes of files matching a selector or tree-sitter query", runQuery},
+		{"metrics", "print the size and complexity of functions and files", runMetrics},
+		{"lint", "report problems found by the configured rules", runLint},
+		{"symbols", "print the outline of files: classes, members, functions and types", runSymbols},
+		{"deps", "print the import graph of files and report its cycles", runDeps},
+		{"diff", "print the declarations changed between two files, and breaking changes", runDiff},
+		{"repl", "explore the syntax tree of a file interactively", runREPL},
+	}
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], &env{stdin: os.Stdin, stdout: os.Stdout, stderr: os.Stderr}))
+}
+
+// run runs the command named by args[0] and returns the exit code.
+func run(args []string, e *env) int {
+	if len(args) == 2 && args[0] == "help" && args[1] == "json" {
+		fmt.Fprint(e.stdout, jsonHelp)
+		return exitOK
+	}
+	if len(args) == 0 || args[0] == "-h" || args[0] == "-help" || args[0] == "--help" || args[0] == "help" {
+		usage(e.stderr)
+		if len(args) == 0 {
+			return exitError
+		}
+		return exitOK
+	}
+	for _, c := range commands {
+		if c.name == args[0] {
+			return c.run(e, args[1:])
+		}
+	}
+	fmt.Fprintf(e.stderr, "tsgoast: unknown command %q\n", args[0])
+	usage(e.stderr)
+	return exitError
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "usage: tsgoast <command> [flags] [files]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "commands:")
+	for _, c := range commands {
+		fmt.Fprintf(w, "  %-8s %s\n", c.name, c.summary)
+	}
+}
+
+// options are the flags common to the commands.
+type options struct {
+	output    string // "text" or "json"
+	stdinPath string // path standing for standard input
+}
+
+// json reports whether the output is JSON.
+func (o *options) json() bool {
+	return o.output == "json"
+}
+
+// newFlags returns the flag set of the named command, with the common
+// flags, writing its errors and usage to the stderr of e.
+func newFlags(e *env, name, args string) (*flag.FlagSet, *options) {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(e.stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(e.stderr, "usage: tsgoast %s [flags] %s\n", name, args)
+		fs.PrintDefaults()
+	}
+	opts := &options{}
+	fs.StringVar(&opts.output, "output", "text", "output format: text, or json as documented by tsgoast help json")
+	fs.StringVar(&opts.stdinPath, "stdin-path", "", "path of the file read from standard input, given as -, to report and to choose its dialect")
+	return fs, opts
+}
+
+// parseFlags parses args with fs, allowing flags after the positional
+// arguments, which it returns. It returns false if the flags are invalid
+// or help was asked for, after printing why.
+func parseFlags(fs *flag.FlagSet, args []string) ([]string, bool) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, false
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			break
+		}
+		if args[0] == "--" {
+			positional = append(positional, args[1:]...)
+			break
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+	if output := fs.Lookup("output"); output != nil && output.Value.String() != "text" && output.Value.String() != "json" {
+		usageError(fs, "unknown output %q", output.Value.String())
+		return nil, false
+	}
+	return positional, true
+}
+
+// aliasJSON selects JSON output for alias, a flag standing for -output
+// json. It returns false, after printing why, if -output asks for text.
+func aliasJSON(fs *flag.FlagSet, opts *options, alias string) bool {
+	if !opts.json() && isSet(fs, "output") {
+		usageError(fs, "%s and -output %s are exclusive", alias, opts.output)
+		return false
+	}
+	opts.output = "json"
+	return true
+}
+
+// isSet reports whether the named flag was given.
+func isSet(fs *flag.FlagSet, name string) bool {
+	set := false
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
+
+// usageError reports a wrong use of a command and returns exitError.
+func usageError(fs *flag.FlagSet, format string, args ...any) int {
+	fmt.Fprintf(fs.Output(), "tsgoast %s: %s\n", fs.Name(), fmt.Sprintf(format, args...))
+	fs.Usage()
+	return exitError
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// runTest runs tsgoast with args and returns its exit code and output.
+func runTest(t *testing.T, stdin string, args ...string) (code int, stdout, stderr string) {
+	t.Helper()
+	var out, errOut bytes.Buffer
+	code = run(args, &env{stdin: strings.NewReader(stdin), stdout: &out, stderr: &errOut})
+	return code, out.String(), errOut.String()
+}
+
+// writeFiles writes files, by path relative to a temporary directory, and
+// returns the directory.
+func writeFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestRun(t *testing.T) {
+	if code, _, stderr := runTest(t, ""); code != exitError || !strings.Contains(stderr, "usage") {
+		t.Errorf("run() = %d, %q, want usage", code, stderr)
+	}
+	if code, _, stderr := runTest(t, "", "frobnicate"); code != exitError || !strings.Contains(stderr, "unknown command") {
+		t.Errorf("run(frobnicate) = %d, %q", code, stderr)
+	}
+	if code, _, _ := runTest(t, "", "help"); code != exitOK {
+		t.Errorf("run(help) = %d, want %d", code, exitOK)
+	}
+}
+
+func TestStdin(t *testing.T) {
+	code, _, stderr := runTest(t, "let x = ;\n", "parse", "-")
+	if want := stdinName + ":1:7: syntax error at \"=\"\n"; code != exitProblems || stderr != want {
+		t.Errorf("parse - = %d, %q, want %q", code, stderr, want)
+	}
+
+	// -stdin-path names standard input and chooses its dialect
+	code, stdout, _ := runTest(t, "const a = <div />;\n", "query", "-stdin-path", "src/app.tsx", "jsx_self_closing_element", "-")
+	if want := "src/app.tsx:1:11: const a = <div />;\n"; code != exitOK || stdout != want {
+		t.Errorf("query -stdin-path = %d, %q, want %q", code, stdout, want)
+	}
+
+	dir := writeFiles(t, map[string]string{"a.ts": "import { b } from './b';\n"})
+	code, stdout, _ = runTest(t, "export const b = 1;\n", "deps", "-stdin-path", filepath.Join(dir, "b.ts"), dir, "-")
+	if want := filepath.Join(dir, "a.ts") + "\n  " + filepath.Join(dir, "b.ts") + "\n" + filepath.Join(dir, "b.ts") + "\n"; code != exitOK || stdout != want {
+		t.Errorf("deps with - = %d, %q, want %q", code, stdout, want)
+	}
+
+	if code, _, stderr := runTest(t, "x;\n", "parse", "-", "-"); code != exitError || !strings.Contains(stderr, "more than once") {
+		t.Errorf("parse - - = %d, %q", code, stderr)
+	}
+}
+
+func TestOutputJSON(t *testing.T) {
+	dir := writeFiles(t, map[string]string{"a.ts": "export function f(x: number) {\n  console.log(x);\n}\n"})
+	for _, args := range [][]string{
+		{"parse", dir},
+		{"inspect", dir},
+		{"query", "identifier", dir},
+		{"metrics", dir},
+		{"lint", dir},
+		{"lint", "-rules"},
+		{"symbols", dir},
+		{"deps", dir},
+	} {
+		code, stdout, stderr := runTest(t, "", append(args, "-output", "json")...)
+		var out map[string]json.RawMessage
+		if err := json.Unmarshal([]byte(stdout), &out); err != nil || code != exitOK {
+			t.Errorf("%s -output json = %d, %v, %s\n%s", strings.Join(args, " "), code, err, stderr, stdout)
+			continue
+		}
+		if v := string(out["version"]); v != strconv.Itoa(jsonVersion) {
+			t.Errorf("%s -output json has version %s, want %d", strings.Join(args, " "), v, jsonVersion)
+		}
+		if len(out) < 2 {
+			t.Errorf("%s -output json = %s, want results", strings.Join(args, " "), stdout)
+		}
+	}
+
+	if code, _, stderr := runTest(t, "", "parse", "-output", "yaml", dir); code != exitError || !strings.Contains(stderr, "unknown output") {
+		t.Errorf("parse -output yaml = %d, %q", code, stderr)
+	}
+	for _, args := range [][]string{
+		{"metrics", "-format", "csv"},
+		{"lint", "-format", "sarif"},
+		{"deps", "-format", "dot"},
+	} {
+		if code, _, stderr := runTest(t, "", append(args, "-output", "json", dir)...); code != exitError || !strings.Contains(stderr, "exclusive") {
+			t.Errorf("%s -output json = %d, %q", strings.Join(args, " "), code, stderr)
+		}
+	}
+	for _, args := range [][]string{
+		{"parse", "-json"},
+		{"symbols", "-json"},
+		{"metrics", "-format", "json"},
+		{"lint", "-format", "json"},
+		{"deps", "-format", "json", "-output", "json"},
+	} {
+		code, stdout, stderr := runTest(t, "", append(args, dir)...)
+		if err := json.Unmarshal([]byte(stdout), new(map[string]json.RawMessage)); err != nil || code != exitOK {
+			t.Errorf("%s = %d, %v, %s", strings.Join(args, " "), code, err, stderr)
+		}
+	}
+	for _, args := range [][]string{
+		{"parse", "-json", "-output", "text"},
+		{"metrics", "-format", "json", "-output", "text"},
+	} {
+		if code, _, stderr := runTest(t, "", append(args, dir)...); code != exitError || !strings.Contains(stderr, "exclusive") {
+			t.Errorf("%s = %d, %q", strings.Join(args, " "), code, stderr)
+		}
+	}
+	if code, stdout, _ := runTest(t, "", "help", "json"); code != exitOK || !strings.Contains(stdout, `"version"`) {
+		t.Errorf("help json = %d\n%s", code, stdout)
+	}
+}
@@ -0,0 +1,170 @@
+package main
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/ahmadramadhannn/tsgoast/analyzer"
+)
+
+// fileReport is the JSON output of metrics for a file.
+type fileReport struct {
+	Path          string           `json:"path"`
+	LOC           int              `json:"loc"`
+	Functions     int              `json:"functions"`
+	Classes       int              `json:"classes"`
+	Interfaces    int              `json:"interfaces"`
+	Imports       int              `json:"imports"`
+	Exports       int              `json:"exports"`
+	Complexity    int              `json:"complexity"`
+	MaxComplexity int              `json:"maxComplexity"`
+	FunctionList  []functionReport `json:"functionList"`
+}
+
+// functionReport is the JSON output of metrics for a function.
+type functionReport struct {
+	Name       string `json:"name"`
+	Kind       string `json:"kind"`
+	Line       int    `json:"line"`
+	EndLine    int    `json:"endLine"`
+	LOC        int    `json:"loc"`
+	Parameters int    `json:"parameters"`
+	Complexity int    `json:"complexity"`
+	IsAsync    bool   `json:"async"`
+	IsExported bool   `json:"exported"`
+}
+
+// thresholds are the limits of the metrics flags; zero means no limit.
+type thresholds struct {
+	complexity, loc, params, fileLOC int
+}
+
+// runMetrics prints the size and complexity metrics of the functions or
+// files, and fails if they exceed the thresholds given.
+func runMetrics(e *env, args []string) int {
+	fs, opts := newFlags(e, "metrics", "files...")
+	format := fs.String("format", "table", "output format: table, csv, or json as a shorthand for -output json")
+	by := fs.String("by", "function", "with -format table or csv, report by function or by file")
+	var limits thresholds
+	fs.IntVar(&limits.complexity, "max-complexity", 0, "fail for functions of a higher cyclomatic complexity")
+	fs.IntVar(&limits.loc, "max-loc", 0, "fail for functions of more lines")
+	fs.IntVar(&limits.params, "max-params", 0, "fail for functions of more parameters")
+	fs.IntVar(&limits.fileLOC, "max-file-loc", 0, "fail for files of more lines")
+	files, ok := parseFlags(fs, args)
+	if !ok {
+		return exitError
+	}
+	if len(files) == 0 {
+		return usageError(fs, "no files")
+	}
+	if *format == "json" {
+		if !aliasJSON(fs, opts, "-format json") {
+			return exitError
+		}
+		*format = "table"
+	}
+	switch {
+	case *format != "table" && *format != "csv":
+		return usageError(fs, "unknown format %q", *format)
+	case *format != "table" && opts.json():
+		return usageError(fs, "-format %s and -output json are exclusive", *format)
+	case *by != "function" && *by != "file":
+		return usageError(fs, "cannot report by %q", *by)
+	}
+	files, err := sourceFiles(files)
+	if err != nil {
+		return fail(e.stderr, "metrics", err)
+	}
+
+	ps := newParsers(e, opts)
+	defer ps.close()
+	var fileMetrics []analyzer.FileMetrics
+	var functionMetrics []analyzer.FunctionMetrics
+	reports := []fileReport{}
+	var violations []string
+	for _, path := range files {
+		f, err := ps.parse(path)
+		if err != nil {
+			return fail(e.stderr, "metrics", err)
+		}
+		path := f.path
+		a := analyzer.New(f.tree.Root)
+		file := a.FileMetrics()
+		file.File = path
+		functions := a.FunctionMetrics()
+		fileMetrics = append(fileMetrics, file)
+
+		report := fileReport{
+			Path: path, LOC: file.LOC, Functions: file.Functions, Classes: file.Classes,
+			Interfaces: file.Interfaces, Imports: file.Imports, Exports: file.Exports,
+			Complexity: file.Complexity, MaxComplexity: file.MaxComplexity,
+			FunctionList: make([]functionReport, 0, len(functions)),
+		}
+		if limits.fileLOC > 0 && file.LOC > limits.fileLOC {
+			violations = append(violations, fmt.Sprintf("%s: file has %d lines (max %d)", path, file.LOC, limits.fileLOC))
+		}
+		for _, fn := range functions {
+			fn.File = path
+			functionMetrics = append(functionMetrics, fn)
+			report.FunctionList = append(report.FunctionList, functionReport{
+				Name: fn.Name, Kind: fn.Kind, Line: fn.Line, EndLine: fn.EndLine, LOC: fn.LOC,
+				Parameters: fn.Parameters, Complexity: fn.Complexity, IsAsync: fn.IsAsync, IsExported: fn.IsExported,
+			})
+			violations = append(violations, limits.check(fn)...)
+		}
+		reports = append(reports, report)
+	}
+
+	switch {
+	case opts.json():
+		err = writeJSON(e.stdout, struct {
+			Version int          `json:"version"`
+			Files   []fileReport `json:"files"`
+		}{jsonVersion, reports})
+	case *format == "csv" && *by == "file":
+		err = analyzer.WriteFileMetricsCSV(e.stdout, fileMetrics, 0)
+	case *format == "csv":
+		err = analyzer.WriteFunctionMetricsCSV(e.stdout, functionMetrics, 0)
+	case *by == "file":
+		tw := tabwriter.NewWriter(e.stdout, 0, 8, 2, ' ', tabwriter.AlignRight)
+		fmt.Fprintln(tw, "LOC\tFUNCTIONS\tCLASSES\tCOMPLEXITY\tMAX\t\tFILE")
+		for _, m := range fileMetrics {
+			fmt.Fprintf(tw, "%d\t%d\t%d\t%d\t%d\t\t%s\n", m.LOC, m.Functions, m.Classes, m.Complexity, m.MaxComplexity, m.File)
+		}
+		err = tw.Flush()
+	default:
+		tw := tabwriter.NewWriter(e.stdout, 0, 8, 2, ' ', tabwriter.AlignRight)
+		fmt.Fprintln(tw, "LOC\tPARAMS\tCOMPLEXITY\t\tFUNCTION")
+		for _, m := range functionMetrics {
+			fmt.Fprintf(tw, "%d\t%d\t%d\t\t%s:%d %s\n", m.LOC, m.Parameters, m.Complexity, m.File, m.Line, m.Name)
+		}
+		err = tw.Flush()
+	}
+	if err != nil {
+		return fail(e.stderr, "metrics", err)
+	}
+
+	for _, v := range violations {
+		fmt.Fprintln(e.stderr, v)
+	}
+	if len(violations) > 0 {
+		return exitProblems
+	}
+	return exitOK
+}
+
+// check returns the thresholds fn exceeds, as messages.
+func (t thresholds) check(fn analyzer.FunctionMetrics) []string {
+	var violations []string
+	at := fmt.Sprintf("%s:%d: %s", fn.File, fn.Line, fn.Name)
+	if t.complexity > 0 && fn.Complexity > t.complexity {
+		violations = append(violations, fmt.Sprintf("%s has complexity %d (max %d)", at, fn.Complexity, t.complexity))
+	}
+	if t.loc > 0 && fn.LOC > t.loc {
+		violations = append(violations, fmt.Sprintf("%s has %d lines (max %d)", at, fn.LOC, t.loc))
+	}
+	if t.params > 0 && fn.Parameters > t.params {
+		violations = append(violations, fmt.Sprintf("%s has %d parameters (max %d)", at, fn.Parameters, t.params))
+	}
+	return violations
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMetrics(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"a.ts": "export function f(a, b, c) {\n  if (a && b) {\n    return c;\n  }\n  return a ? b : c;\n}\n",
+		"b.ts": "const g = () => 1;\n",
+	})
+	a := filepath.Join(dir, "a.ts")
+
+	code, stdout, _ := runTest(t, "", "metrics", dir)
+	if code != exitOK || !strings.Contains(stdout, "6       3           4  "+a+":1 f\n") {
+		t.Errorf("metrics = %d\n%s", code, stdout)
+	}
+
+	code, stdout, _ = runTest(t, "", "metrics", "-output", "json", dir)
+	var out struct{ Files []fileReport }
+	if err := json.Unmarshal([]byte(stdout), &out); err != nil || code != exitOK {
+		t.Fatalf("metrics -format json = %d, %v\n%s", code, err, stdout)
+	}
+	if reports := out.Files; len(reports) != 2 || reports[0].Path != a || reports[0].MaxComplexity != 4 || len(reports[0].FunctionList) != 1 || !reports[0].FunctionList[0].IsExported {
+		t.Errorf("metrics -format json = %+v", out.Files)
+	}
+
+	code, stdout, _ = runTest(t, "", "metrics", "-format", "csv", "-by", "file", dir)
+	if lines := strings.Split(strings.TrimSpace(stdout), "\n"); code != exitOK || len(lines) != 3 || !strings.HasPrefix(lines[0], "file,loc,") {
+		t.Errorf("metrics -format csv -by file = %d\n%s", code, stdout)
+	}
+
+	code, _, stderr := runTest(t, "", "metrics", dir, "-max-complexity", "3", "-max-params", "3", "-max-file-loc", "5")
+	want := a + ": file has 7 lines (max 5)\n" + a + ":1: f has complexity 4 (max 3)\n"
+	if code != exitProblems || stderr != want {
+		t.Errorf("metrics with thresholds = %d, %q, want %q", code, stderr, want)
+	}
+
+	if code, _, _ := runTest(t, "", "metrics", "-format", "xml", dir); code != exitError {
+		t.Errorf("metrics -format xml = %d, want %d", code, exitError)
+	}
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// jsonVersion is the version of the JSON output of the commands. It
+// changes only when a change could break readers of the output; adding
+// fields does not change it.
+const jsonVersion = 1
+
+// writeJSON writes v, the JSON output of a command, indented.
+func writeJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// jsonHelp documents the JSON output, printed by tsgoast help json.
+const jsonHelp = `With -output json, each command writes a single JSON object to standard
+output, holding its "version", currently 1, and its results. Errors and
+threshold violations are still reported on standard error, and the exit
+codes do not change.
+
+Paths are as given on the command line; standard input, given as -, is
+reported as its -stdin-path, or as <stdin>. Ranges are objects
+{"start": pos, "end": pos}, where pos is {"line", "column", "offset"}:
+the line counts from 0, the column and offset count bytes from 0 from the
+start of the line and of the file.
+
+parse     {"version", "files": [{"path", "errors": [{"range", "message"}], "root": node}]}
+inspect   {"version", "files": [{"path", "stats": {"bytes", "nodes", "depth",
+          "statements", "errors", "memory", "parseTimeNs", "convertTimeNs"},
+          "root": node (with -tree only)}]}
+query     {"version", "matches": [{"path", "range", "kind", "text", "capture" (if any)}]}
+metrics   {"version", "files": [{"path", "loc", "functions", "classes", "interfaces",
+          "imports", "exports", "complexity", "maxComplexity", "functionList":
+          [{"name", "kind", "line", "endLine", "loc", "parameters", "complexity",
+          "async", "exported"}]}]}, lines counting from 1
+lint      {"version", "findings": [{"path", "range", "rule", "severity", "message"}]}
+          or with -rules {"version", "rules": [{"id", "severity", "description"}]}
+symbols   {"version", "files": [{"path", "symbols": [LSP DocumentSymbol]}]}
+deps      {"version", "files": [{"path", "imports", "packages", "unresolved" (if any)}],
+          "packages": [{"package", "importers"}], "cycles": [[path]]}
+diff      {"version", "old": path, "new": path, "changes": [{"kind", "name" (if any),
+          "syntaxKind", "oldRange", "newRange" (if any), "oldSignature",
+          "newSignature" (if changed), "exported", "breaking"}]}
+
+A node is {"type", "kind", "field" (if any), "text", "range", "children" (if any)}.
+`
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ahmadramadhannn/tsgoast"
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+// parsedFile is the JSON output of parse for a file.
+type parsedFile struct {
+	Path   string        `json:"path"`
+	Errors []syntaxError `json:"errors"`
+	Root   *ast.BaseNode `json:"root"`
+}
+
+// runParse parses files, printing their syntax errors, or with -output
+// json a JSON document holding them and the syntax tree of each file.
+func runParse(e *env, args []string) int {
+	fs, opts := newFlags(e, "parse", "files...")
+	asJSON := fs.Bool("json", false, "shorthand for -output json")
+	quiet := fs.Bool("q", false, "print nothing, only set the exit code")
+	files, ok := parseFlags(fs, args)
+	if !ok {
+		return exitError
+	}
+	if *asJSON && !aliasJSON(fs, opts, "-json") {
+		return exitError
+	}
+	if len(files) == 0 {
+		return usageError(fs, "no files")
+	}
+	files, err := sourceFiles(files)
+	if err != nil {
+		return fail(e.stderr, "parse", err)
+	}
+
+	ps := newParsers(e, opts)
+	defer ps.close()
+	code := exitOK
+	parsed := []parsedFile{}
+	for _, path := range files {
+		f, err := ps.parse(path)
+		if err != nil {
+			return fail(e.stderr, "parse", err)
+		}
+		errs := syntaxErrors(f.tree)
+		if len(errs) > 0 {
+			code = exitProblems
+		}
+		if *quiet {
+			continue
+		}
+		if opts.json() {
+			if errs == nil {
+				errs = []syntaxError{}
+			}
+			parsed = append(parsed, parsedFile{Path: f.path, Errors: errs, Root: f.tree.Root})
+			continue
+		}
+		for _, se := range errs {
+			fmt.Fprintf(e.stderr, "%s: %s\n", position(f.path, se.Range), se.Message)
+		}
+	}
+	if opts.json() && !*quiet {
+		out := struct {
+			Version int          `json:"version"`
+			Files   []parsedFile `json:"files"`
+		}{jsonVersion, parsed}
+		if err := writeJSON(e.stdout, out); err != nil {
+			return fail(e.stderr, "parse", err)
+		}
+	}
+	return code
+}
+
+// inspectedFile is the JSON output of inspect for a file.
+type inspectedFile struct {
+	Path  string        `json:"path"`
+	Stats fileStats     `json:"stats"`
+	Root  *ast.BaseNode `json:"root,omitempty"`
+}
+
+// fileStats are the statistics printed by inspect.
+type fileStats struct {
+	Bytes       int   `json:"bytes"`
+	Nodes       int   `json:"nodes"`
+	Depth       int   `json:"depth"`
+	Statements  int   `json:"statements"`
+	Errors      int   `json:"errors"`
+	Memory      int   `json:"memory"`
+	ParseTime   int64 `json:"parseTimeNs"`
+	ConvertTime int64 `json:"convertTimeNs"`
+}
+
+// runInspect prints the syntax tree of files, with -tree, or statistics on
+// their parse.
+func runInspect(e *env, args []string) int {
+	fs, opts := newFlags(e, "inspect", "files...")
+	asTree := fs.Bool("tree", false, "print the syntax tree as an outline, or with -output json include it")
+	depth := fs.Int("depth", 0, "with -tree, print nodes down to this depth only; 0 means all")
+	kinds := fs.String("kinds", "", "with -tree, print only nodes of these comma-separated kinds")
+	tokens := fs.Bool("tokens", false, "with -tree, print anonymous tokens such as punctuation")
+	ranges := fs.Bool("ranges", false, "with -tree, print the line:column range of each node")
+	files, ok := parseFlags(fs, args)
+	if !ok {
+		return exitError
+	}
+	if len(files) == 0 {
+		return usageError(fs, "no files")
+	}
+	files, err := sourceFiles(files)
+	if err != nil {
+		return fail(e.stderr, "inspect", err)
+	}
+
+	dump := tsgoast.DumpOptions{MaxDepth: *depth, IncludeTokens: *tokens, ShowRanges: *ranges}
+	if *kinds != "" {
+		dump.Kinds = strings.Split(*kinds, ",")
+	}
+	ps := newParsers(e, opts)
+	defer ps.close()
+	code := exitOK
+	inspected := []inspectedFile{}
+	for i, path := range files {
+		f, err := ps.parse(path)
+		if err != nil {
+			return fail(e.stderr, "inspect", err)
+		}
+		errs := syntaxErrors(f.tree)
+		if len(errs) > 0 {
+			code = exitProblems
+		}
+		stats := f.tree.Stats()
+		if opts.json() {
+			file := inspectedFile{Path: f.path, Stats: fileStats{
+				Bytes:       stats.Bytes,
+				Nodes:       stats.Nodes,
+				Depth:       stats.MaxDepth,
+				Statements:  len(f.tree.Statements),
+				Errors:      len(errs),
+				Memory:      f.tree.MemoryFootprint(),
+				ParseTime:   stats.ParseTime.Nanoseconds(),
+				ConvertTime: stats.ConvertTime.Nanoseconds(),
+			}}
+			if *asTree {
+				file.Root = f.tree.Root
+			}
+			inspected = append(inspected, file)
+			continue
+		}
+		if len(files) > 1 {
+			if i > 0 {
+				fmt.Fprintln(e.stdout)
+			}
+			fmt.Fprintf(e.stdout, "%s:\n", f.path)
+		}
+		if *asTree {
+			if err := f.tree.Dump(e.stdout, dump); err != nil {
+				return fail(e.stderr, "inspect", err)
+			}
+			continue
+		}
+		fmt.Fprintf(e.stdout, "bytes       %d\n", stats.Bytes)
+		fmt.Fprintf(e.stdout, "nodes       %d\n", stats.Nodes)
+		fmt.Fprintf(e.stdout, "depth       %d\n", stats.MaxDepth)
+		fmt.Fprintf(e.stdout, "statements  %d\n", len(f.tree.Statements))
+		fmt.Fprintf(e.stdout, "errors      %d\n", len(errs))
+		fmt.Fprintf(e.stdout, "memory      %d\n", f.tree.MemoryFootprint())
+		fmt.Fprintf(e.stdout, "parse time  %v\n", stats.ParseTime.Round(time.Microsecond))
+		fmt.Fprintf(e.stdout, "convert     %v\n", stats.ConvertTime.Round(time.Microsecond))
+	}
+	if opts.json() {
+		out := struct {
+			Version int             `json:"version"`
+			Files   []inspectedFile `json:"files"`
+		}{jsonVersion, inspected}
+		if err := writeJSON(e.stdout, out); err != nil {
+			return fail(e.stderr, "inspect", err)
+		}
+	}
+	return code
+}
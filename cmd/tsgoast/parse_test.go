@@ -0,0 +1,81 @@
+package main
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"ok.ts":               "export const a = 1;\n",
+		"view.tsx":            "export const V = () => <div>{a}</div>;\n",
+		"broken.ts":           "let x = ;\nif (a) { b()\n",
+		"node_modules/dep.ts": "let = ;",
+		"types.d.ts":          "declare const x: number;\n",
+		"sub/empty.ts":        "",
+		"sub/notes.txt":       "not code",
+	})
+	ok, broken := filepath.Join(dir, "ok.ts"), filepath.Join(dir, "broken.ts")
+
+	if code, stdout, stderr := runTest(t, "", "parse", ok); code != exitOK || stdout != "" || stderr != "" {
+		t.Errorf("parse ok.ts = %d, %q, %q", code, stdout, stderr)
+	}
+
+	code, _, stderr := runTest(t, "", "parse", broken)
+	want := broken + ":1:7: syntax error at \"=\"\n" + broken + ":2:13: missing }\n"
+	if code != exitProblems || stderr != want {
+		t.Errorf("parse broken.ts = %d, %q, want %q", code, stderr, want)
+	}
+	if code, _, stderr := runTest(t, "", "parse", "-q", broken); code != exitProblems || stderr != "" {
+		t.Errorf("parse -q broken.ts = %d, %q", code, stderr)
+	}
+
+	// Flags may follow the files, and directories are walked
+	code, stdout, _ := runTest(t, "", "parse", dir, "--output", "json")
+	var out struct {
+		Version int
+		Files   []struct {
+			Path   string
+			Errors []struct{ Message string }
+			Root   struct{ Kind string }
+		}
+	}
+	if err := json.Unmarshal([]byte(stdout), &out); err != nil || code != exitProblems || out.Version != jsonVersion || len(out.Files) != 4 {
+		t.Fatalf("parse dir --json = %d, %v\n%s", code, err, stdout)
+	}
+	if file := out.Files[0]; file.Path != broken || len(file.Errors) != 2 || file.Root.Kind != "program" {
+		t.Errorf("parse --json = %+v", file)
+	}
+
+	if code, _, stderr := runTest(t, "", "parse", filepath.Join(dir, "missing.ts")); code != exitError || stderr == "" {
+		t.Errorf("parse missing.ts = %d, %q", code, stderr)
+	}
+	if code, _, _ := runTest(t, "", "parse"); code != exitError {
+		t.Errorf("parse without files = %d, want %d", code, exitError)
+	}
+	if code, _, _ := runTest(t, "", "parse", "-nope", ok); code != exitError {
+		t.Errorf("parse -nope = %d, want %d", code, exitError)
+	}
+}
+
+func TestInspect(t *testing.T) {
+	dir := writeFiles(t, map[string]string{"a.ts": "function f(x) { return x; }\n"})
+	path := filepath.Join(dir, "a.ts")
+
+	code, stdout, _ := runTest(t, "", "inspect", path, "--tree", "--depth", "2")
+	want := "program\n" +
+		"└── function_declaration f\n" +
+		"    ├── name: identifier f\n" +
+		"    ├── parameters: formal_parameters\n" +
+		"    └── body: statement_block\n"
+	if code != exitOK || stdout != want {
+		t.Errorf("inspect --tree = %d\n%s\nwant\n%s", code, stdout, want)
+	}
+
+	code, stdout, _ = runTest(t, "", "inspect", path)
+	if code != exitOK || !strings.Contains(stdout, "statements  1\n") || !strings.Contains(stdout, "errors      0\n") {
+		t.Errorf("inspect = %d\n%s", code, stdout)
+	}
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ahmadramadhannn/tsgoast/analyzer"
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+// queryMatch is the JSON output of query for a match.
+type queryMatch struct {
+	Path    string    `json:"path"`
+	Range   ast.Range `json:"range"`
+	Kind    string    `json:"kind"`
+	Text    string    `json:"text"`
+	Capture string    `json:"capture,omitempty"`
+}
+
+// runQuery prints the nodes of files matching a selector, or captured by
+// a tree-sitter query, one per line like grep.
+func runQuery(e *env, args []string) int {
+	fs, opts := newFlags(e, "query", "selector|query files...")
+	sexp := fs.Bool("sexp", false, "treat the query as a tree-sitter S-expression query; the default for queries starting with (")
+	only := fs.Bool("o", false, "print the text of the matched nodes rather than their lines")
+	count := fs.Bool("c", false, "print the number of matches of each file; ignored with -output json")
+	list := fs.Bool("l", false, "print the files with matches only; ignored with -output json")
+	args, ok := parseFlags(fs, args)
+	if !ok {
+		return exitError
+	}
+	if len(args) < 2 {
+		return usageError(fs, "want a query and files")
+	}
+	query := args[0]
+	files, err := sourceFiles(args[1:])
+	if err != nil {
+		return fail(e.stderr, "query", err)
+	}
+	isSexp := *sexp || strings.HasPrefix(strings.TrimSpace(query), "(")
+	var selector *analyzer.Selector
+	if !isSexp {
+		if selector, err = analyzer.CompileSelector(query); err != nil {
+			return fail(e.stderr, "query", err)
+		}
+	}
+
+	ps := newParsers(e, opts)
+	defer ps.close()
+	code := exitProblems // no match, like grep
+	found := []queryMatch{}
+	for _, path := range files {
+		f, err := ps.parse(path)
+		if err != nil {
+			return fail(e.stderr, "query", err)
+		}
+		var matches []match
+		if isSexp {
+			results, err := f.parser.Query(f.tree, query)
+			if err != nil {
+				return fail(e.stderr, "query", err)
+			}
+			for _, m := range results {
+				for _, c := range m.Captures {
+					matches = append(matches, match{node: c.Node, capture: c.Name})
+				}
+			}
+		} else {
+			for _, node := range analyzer.New(f.tree.Root).FindNodes(selector.Matches) {
+				matches = append(matches, match{node: node})
+			}
+		}
+		if len(matches) == 0 {
+			continue
+		}
+		code = exitOK
+		switch {
+		case opts.json():
+			for _, m := range matches {
+				found = append(found, queryMatch{
+					Path:    f.path,
+					Range:   m.node.Range(),
+					Kind:    m.node.SyntaxKind(),
+					Text:    m.node.Text(),
+					Capture: m.capture,
+				})
+			}
+		case *list:
+			fmt.Fprintln(e.stdout, f.path)
+		case *count:
+			fmt.Fprintf(e.stdout, "%s:%d\n", f.path, len(matches))
+		default:
+			for _, m := range matches {
+				text := sourceLine(f.source, m.node.Range())
+				if *only {
+					text = firstLine(m.node.Text())
+				}
+				if m.capture != "" {
+					text = "@" + m.capture + " " + text
+				}
+				fmt.Fprintf(e.stdout, "%s: %s\n", position(f.path, m.node.Range()), text)
+			}
+		}
+	}
+	if opts.json() {
+		out := struct {
+			Version int          `json:"version"`
+			Matches []queryMatch `json:"matches"`
+		}{jsonVersion, found}
+		if err := writeJSON(e.stdout, out); err != nil {
+			return fail(e.stderr, "query", err)
+		}
+	}
+	return code
+}
+
+// match is a node matched by a query, and its capture name for
+// S-expression queries.
+type match struct {
+	node    ast.Node
+	capture string
+}
+
+// sourceLine returns the line of source where r starts, without its
+// indentation.
+func sourceLine(source []byte, r ast.Range) string {
+	start := int(r.Start.Offset) - int(r.Start.Column)
+	end := start
+	for end < len(source) && source[end] != '\n' {
+		end++
+	}
+	return strings.TrimSpace(string(source[start:end]))
+}
+
+// firstLine returns the first line of text, marking the rest as elided.
+func firstLine(text string) string {
+	if i := strings.IndexAny(text, "\r\n"); i >= 0 {
+		return text[:i] + "…"
+	}
+	return text
+}
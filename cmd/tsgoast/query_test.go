@@ -0,0 +1,61 @@
+package main
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+)
+
+func TestQuery(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"a.ts": "function handleClick() {\n  return 1;\n}\nfunction other() {}\n",
+		"b.ts": "export function handleKey(e) {}\n",
+		"c.ts": "const x = 1;\n",
+	})
+	a, b := filepath.Join(dir, "a.ts"), filepath.Join(dir, "b.ts")
+
+	code, stdout, _ := runTest(t, "", "query", "function_declaration[name=/^handle/]", dir)
+	want := a + ":1:1: function handleClick() {\n" + b + ":1:8: export function handleKey(e) {}\n"
+	if code != exitOK || stdout != want {
+		t.Errorf("query = %d\n%s\nwant\n%s", code, stdout, want)
+	}
+
+	code, stdout, _ = runTest(t, "", "query", "-o", "function_declaration[name=/^handle/]", a)
+	if want := a + ":1:1: function handleClick() {…\n"; code != exitOK || stdout != want {
+		t.Errorf("query -o = %d, %q, want %q", code, stdout, want)
+	}
+
+	code, stdout, _ = runTest(t, "", "query", "function_declaration", dir, "-c")
+	if want := a + ":2\n" + b + ":1\n"; code != exitOK || stdout != want {
+		t.Errorf("query -c = %d, %q, want %q", code, stdout, want)
+	}
+	code, stdout, _ = runTest(t, "", "query", "-l", "lexical_declaration", dir)
+	if want := filepath.Join(dir, "c.ts") + "\n"; code != exitOK || stdout != want {
+		t.Errorf("query -l = %d, %q, want %q", code, stdout, want)
+	}
+
+	// S-expression queries print their captures
+	code, stdout, _ = runTest(t, "", "query", `(function_declaration name: (identifier) @name (#match? @name "^other"))`, a)
+	if want := a + ":4:10: @name function other() {}\n"; code != exitOK || stdout != want {
+		t.Errorf("query sexp = %d, %q, want %q", code, stdout, want)
+	}
+
+	code, stdout, _ = runTest(t, "", "query", "-output", "json", "identifier[text=other]", a)
+	var out struct{ Matches []queryMatch }
+	if err := json.Unmarshal([]byte(stdout), &out); err != nil || code != exitOK || len(out.Matches) != 1 {
+		t.Fatalf("query -output json = %d, %v\n%s", code, err, stdout)
+	}
+	if m := out.Matches[0]; m.Path != a || m.Kind != "identifier" || m.Text != "other" || m.Range.Start.Line != 3 || m.Range.Start.Column != 9 {
+		t.Errorf("query -output json = %+v", m)
+	}
+
+	if code, stdout, _ := runTest(t, "", "query", "class_declaration", dir); code != exitProblems || stdout != "" {
+		t.Errorf("query without matches = %d, %q", code, stdout)
+	}
+	if code, _, _ := runTest(t, "", "query", "[name=", dir); code != exitError {
+		t.Errorf("query with a bad selector = %d, want %d", code, exitError)
+	}
+	if code, _, _ := runTest(t, "", "query", "(nope", dir); code != exitError {
+		t.Errorf("query with a bad S-expression = %d, want %d", code, exitError)
+	}
+}
@@ -0,0 +1,385 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/ahmadramadhannn/tsgoast"
+	"github.com/ahmadramadhannn/tsgoast/analyzer"
+	"github.com/ahmadramadhannn/tsgoast/ast"
+	"github.com/ahmadramadhannn/tsgoast/internal/syntax"
+)
+
+// repl is the state of an interactive session on a file: the current
+// node, and the matches of the last find or query to select from.
+type repl struct {
+	e       *env
+	file    *file
+	node    ast.Node
+	matches []ast.Node
+}
+
+// replCommand is a command of the REPL.
+type replCommand struct {
+	name    string
+	args    string
+	summary string
+	run     func(r *repl, arg string) error
+}
+
+var replCommands []replCommand
+
+func init() {
+	replCommands = []replCommand{
+		{"show", "", "print the details of the current node", (*repl).show},
+		{"tree", "[depth]", "print the subtree of the current node, 3 levels deep by default", (*repl).tree},
+		{"text", "", "print the source of the current node", (*repl).text},
+		{"children", "", "list the children of the current node", (*repl).children},
+		{"up", "", "go to the parent", (*repl).up},
+		{"down", "[n]", "go to the nth child listed by children, the first by default", (*repl).down},
+		{"next", "", "go to the next sibling", func(r *repl, _ string) error { return r.sibling(1) }},
+		{"prev", "", "go to the previous sibling", func(r *repl, _ string) error { return r.sibling(-1) }},
+		{"field", "name", "go to the child in the field name", (*repl).field},
+		{"root", "", "go to the root", (*repl).root},
+		{"at", "line:column", "go to the deepest node at a position, counted from 1", (*repl).at},
+		{"find", "selector", "list the nodes below the current one matching a selector", (*repl).find},
+		{"query", "query", "list the captures of a tree-sitter query below the current node", (*repl).query},
+		{"go", "n", "go to the nth match of the last find or query", (*repl).goMatch},
+		{"help", "", "print this help", (*repl).help},
+		{"quit", "", "leave the REPL; so does end of input", nil},
+	}
+}
+
+// runREPL reads commands from standard input to explore the syntax tree
+// of a file: run selectors and queries, move around the tree and print the
+// details of nodes.
+func runREPL(e *env, args []string) int {
+	fs, opts := newFlags(e, "repl", "file")
+	files, ok := parseFlags(fs, args)
+	if !ok {
+		return exitError
+	}
+	if len(files) != 1 {
+		return usageError(fs, "want a file")
+	}
+	if files[0] == "-" {
+		return usageError(fs, "standard input is read for commands")
+	}
+	if opts.json() {
+		return usageError(fs, "no JSON output")
+	}
+
+	ps := newParsers(e, opts)
+	defer ps.close()
+	f, err := ps.parse(files[0])
+	if err != nil {
+		return fail(e.stderr, "repl", err)
+	}
+	r := &repl{e: e, file: f, node: f.tree.Root}
+
+	prompt := ""
+	if in, ok := e.stdin.(*os.File); ok {
+		if info, err := in.Stat(); err == nil && info.Mode()&os.ModeCharDevice != 0 {
+			prompt = "> "
+			fmt.Fprintf(e.stdout, "%s: %d lines; type help for the commands\n", f.path, strings.Count(string(f.source), "\n"))
+		}
+	}
+	scanner := bufio.NewScanner(e.stdin)
+	for {
+		fmt.Fprint(e.stdout, prompt)
+		if !scanner.Scan() {
+			break
+		}
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if line == "quit" || line == "exit" {
+			return exitOK
+		}
+		if err := r.exec(line); err != nil {
+			fmt.Fprintf(e.stdout, "error: %v\n", err)
+		}
+	}
+	if prompt != "" {
+		fmt.Fprintln(e.stdout)
+	}
+	if err := scanner.Err(); err != nil {
+		return fail(e.stderr, "repl", err)
+	}
+	return exitOK
+}
+
+// exec runs a line of input: a command, the number of a match, or else a
+// selector to find, or a query if it starts with (.
+func (r *repl) exec(line string) error {
+	name, arg, _ := strings.Cut(line, " ")
+	arg = strings.TrimSpace(arg)
+	for _, c := range replCommands {
+		if c.name == name && c.run != nil {
+			return c.run(r, arg)
+		}
+	}
+	switch {
+	case strings.HasPrefix(line, "("):
+		return r.query(line)
+	case strings.Trim(line, "0123456789") == "":
+		return r.goMatch(line)
+	}
+	return r.find(line)
+}
+
+func (r *repl) help(string) error {
+	for _, c := range replCommands {
+		fmt.Fprintf(r.e.stdout, "  %-22s %s\n", strings.TrimSpace(c.name+" "+c.args), c.summary)
+	}
+	fmt.Fprintln(r.e.stdout, "Other input is run as a selector, or as a query if it starts with (, and a number goes to that match.")
+	return nil
+}
+
+// show prints the details of the current node:
+//
+//	identifier "load"
+//	  type      identifier
+//	  field     name
+//	  range     1:10-1:14 (bytes 9-13)
+//	  children  0
+//	  path      program > function_declaration > identifier
+func (r *repl) show(string) error {
+	n := r.node
+	w := r.e.stdout
+	fmt.Fprintln(w, nodeLine(n))
+	fmt.Fprintf(w, "  type      %s\n", n.Type())
+	if field := ast.FieldOf(n); field != "" {
+		fmt.Fprintf(w, "  field     %s\n", field)
+	}
+	rg := n.Range()
+	fmt.Fprintf(w, "  range     %d:%d-%d:%d (bytes %d-%d)\n",
+		rg.Start.Line+1, rg.Start.Column+1, rg.End.Line+1, rg.End.Column+1, rg.Start.Offset, rg.End.Offset)
+	fmt.Fprintf(w, "  children  %d\n", len(namedChildren(n)))
+	var path []string
+	for p := n; p != nil; p = p.Parent() {
+		path = append([]string{p.SyntaxKind()}, path...)
+	}
+	fmt.Fprintf(w, "  path      %s\n", strings.Join(path, " > "))
+	return nil
+}
+
+func (r *repl) tree(arg string) error {
+	depth := 3
+	if arg != "" {
+		d, err := strconv.Atoi(arg)
+		if err != nil || d < 0 {
+			return fmt.Errorf("bad depth %q", arg)
+		}
+		depth = d
+	}
+	return tsgoast.DumpNode(r.e.stdout, r.node, tsgoast.DumpOptions{MaxDepth: depth})
+}
+
+func (r *repl) text(string) error {
+	fmt.Fprintln(r.e.stdout, r.node.Text())
+	return nil
+}
+
+func (r *repl) children(string) error {
+	for i, child := range namedChildren(r.node) {
+		line := nodeLine(child)
+		if field := ast.FieldOf(child); field != "" {
+			line = field + ": " + line
+		}
+		fmt.Fprintf(r.e.stdout, "[%d] %s\n", i+1, line)
+	}
+	return nil
+}
+
+func (r *repl) up(string) error {
+	if r.node.Parent() == nil {
+		return errors.New("at the root")
+	}
+	return r.move(r.node.Parent())
+}
+
+func (r *repl) down(arg string) error {
+	children := namedChildren(r.node)
+	i := 1
+	if arg != "" {
+		var err error
+		if i, err = strconv.Atoi(arg); err != nil {
+			return fmt.Errorf("bad child number %q", arg)
+		}
+	}
+	if i < 1 || i > len(children) {
+		return fmt.Errorf("no child %d of %d", i, len(children))
+	}
+	return r.move(children[i-1])
+}
+
+// sibling moves to the named sibling delta places away.
+func (r *repl) sibling(delta int) error {
+	parent := r.node.Parent()
+	if parent == nil {
+		return errors.New("the root has no siblings")
+	}
+	siblings := namedChildren(parent)
+	for i, s := range siblings {
+		if s == r.node && i+delta >= 0 && i+delta < len(siblings) {
+			return r.move(siblings[i+delta])
+		}
+	}
+	return errors.New("no such sibling")
+}
+
+func (r *repl) field(arg string) error {
+	child := ast.ChildByField(r.node, arg)
+	if child == nil {
+		return fmt.Errorf("no field %q", arg)
+	}
+	return r.move(child)
+}
+
+func (r *repl) root(string) error {
+	return r.move(r.file.tree.Root)
+}
+
+func (r *repl) at(arg string) error {
+	line, column, ok := strings.Cut(arg, ":")
+	l, err1 := strconv.Atoi(line)
+	c, err2 := strconv.Atoi(column)
+	if !ok || err1 != nil || err2 != nil || l < 1 || c < 1 {
+		return fmt.Errorf("bad position %q, want line:column", arg)
+	}
+	offset, ok := offsetOf(r.file.source, l-1, c-1)
+	if !ok {
+		return fmt.Errorf("no position %s", arg)
+	}
+	pos := ast.Position{Line: uint32(l - 1), Column: uint32(c - 1), Offset: offset}
+	node := r.file.tree.NodeCovering(ast.Range{Start: pos, End: pos})
+	if node == nil {
+		return fmt.Errorf("no node at %s", arg)
+	}
+	return r.move(node)
+}
+
+func (r *repl) find(arg string) error {
+	selector, err := analyzer.CompileSelector(arg)
+	if err != nil {
+		return err
+	}
+	var matches []ast.Node
+	ast.Inspect(r.node, func(node ast.Node) bool {
+		if selector.Matches(node) {
+			matches = append(matches, node)
+		}
+		return true
+	})
+	return r.list(matches, nil)
+}
+
+func (r *repl) query(arg string) error {
+	results, err := r.file.parser.Query(r.file.tree, arg)
+	if err != nil {
+		return err
+	}
+	within := r.node.Range()
+	var matches []ast.Node
+	var captures []string
+	for _, m := range results {
+		for _, c := range m.Captures {
+			if rg := c.Node.Range(); rg.Start.Offset >= within.Start.Offset && rg.End.Offset <= within.End.Offset {
+				matches = append(matches, c.Node)
+				captures = append(captures, c.Name)
+			}
+		}
+	}
+	return r.list(matches, captures)
+}
+
+// list prints matches, numbered for go, with their capture names if any,
+// and keeps them.
+func (r *repl) list(matches []ast.Node, captures []string) error {
+	r.matches = matches
+	if len(matches) == 0 {
+		fmt.Fprintln(r.e.stdout, "no matches")
+		return nil
+	}
+	for i, node := range matches {
+		line := nodeLine(node)
+		if captures != nil {
+			line = "@" + captures[i] + " " + line
+		}
+		rg := node.Range()
+		fmt.Fprintf(r.e.stdout, "[%d] %d:%d %s\n", i+1, rg.Start.Line+1, rg.Start.Column+1, line)
+	}
+	return nil
+}
+
+func (r *repl) goMatch(arg string) error {
+	i, err := strconv.Atoi(arg)
+	if err != nil {
+		return fmt.Errorf("bad match number %q", arg)
+	}
+	if i < 1 || i > len(r.matches) {
+		return fmt.Errorf("no match %d of %d", i, len(r.matches))
+	}
+	return r.move(r.matches[i-1])
+}
+
+// move makes node the current node and prints it.
+func (r *repl) move(node ast.Node) error {
+	r.node = node
+	rg := node.Range()
+	fmt.Fprintf(r.e.stdout, "%d:%d %s\n", rg.Start.Line+1, rg.Start.Column+1, nodeLine(node))
+	return nil
+}
+
+// nodeLine describes node on a line: its kind, and its first line of text
+// if short.
+func nodeLine(node ast.Node) string {
+	text := firstLine(node.Text())
+	if len(node.Children()) > 0 && len(text) > 40 {
+		return node.SyntaxKind()
+	}
+	if len(text) > 60 {
+		text = text[:60] + "…"
+	}
+	return node.SyntaxKind() + " " + strconv.Quote(text)
+}
+
+// namedChildren returns the children of node, leaving out anonymous tokens
+// such as punctuation and keywords.
+func namedChildren(node ast.Node) []ast.Node {
+	var children []ast.Node
+	for _, child := range node.Children() {
+		if !syntax.IsToken(child) {
+			children = append(children, child)
+		}
+	}
+	return children
+}
+
+// offsetOf returns the byte offset of a 0-based line and byte column of
+// source.
+func offsetOf(source []byte, line, column int) (uint32, bool) {
+	offset := 0
+	for ; line > 0; line-- {
+		i := bytes.IndexByte(source[offset:], '\n')
+		if i < 0 {
+			return 0, false
+		}
+		offset += i + 1
+	}
+	end := bytes.IndexByte(source[offset:], '\n')
+	if end < 0 {
+		end = len(source) - offset
+	}
+	if column > end {
+		return 0, false
+	}
+	return uint32(offset + column), true
+}
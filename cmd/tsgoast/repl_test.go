@@ -0,0 +1,57 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestREPL(t *testing.T) {
+	dir := writeFiles(t, map[string]string{"a.ts": "function load(id: string) {\n  return get(id);\n}\n"})
+	path := filepath.Join(dir, "a.ts")
+
+	input := "identifier[text=id]\n" +
+		"1\n" +
+		"show\n" +
+		"up\n" +
+		"children\n" +
+		"down 2\n" +
+		"next\n" +
+		"at 2:10\n" +
+		"root\n" +
+		"(return_statement) @r\n" +
+		"go 1\n" +
+		"tree 1\n" +
+		"field nope\n" +
+		"quit\n" +
+		"show\n"
+	code, stdout, _ := runTest(t, input, "repl", path)
+	want := `[1] 1:15 identifier "id"
+[2] 2:14 identifier "id"
+1:15 identifier "id"
+identifier "id"
+  type      identifier
+  field     pattern
+  range     1:15-1:17 (bytes 14-16)
+  children  0
+  path      program > function_declaration > formal_parameters > required_parameter > identifier
+1:15 required_parameter "id: string"
+[1] pattern: identifier "id"
+[2] type: type_annotation ": string"
+1:17 type_annotation ": string"
+error: no such sibling
+2:10 identifier "get"
+1:1 program "function load(id: string) {…"
+[1] 2:3 @r return_statement "return get(id);"
+2:3 return_statement "return get(id);"
+return_statement
+└── call_expression
+error: no field "nope"
+`
+	if code != exitOK || stdout != want {
+		t.Errorf("repl = %d\n%s\nwant\n%s", code, stdout, want)
+	}
+
+	if code, _, _ := runTest(t, "", "repl", "-"); code != exitError {
+		t.Errorf("repl - = %d, want %d", code, exitError)
+	}
+}
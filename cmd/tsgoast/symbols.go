@@ -0,0 +1,112 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ahmadramadhannn/tsgoast/analyzer"
+)
+
+// symbolKinds names the symbol kinds in the text output of symbols.
+var symbolKinds = map[analyzer.SymbolKind]string{
+	analyzer.SymbolKindModule:        "module",
+	analyzer.SymbolKindNamespace:     "namespace",
+	analyzer.SymbolKindClass:         "class",
+	analyzer.SymbolKindMethod:        "method",
+	analyzer.SymbolKindProperty:      "property",
+	analyzer.SymbolKindField:         "field",
+	analyzer.SymbolKindConstructor:   "constructor",
+	analyzer.SymbolKindEnum:          "enum",
+	analyzer.SymbolKindInterface:     "interface",
+	analyzer.SymbolKindFunction:      "function",
+	analyzer.SymbolKindVariable:      "variable",
+	analyzer.SymbolKindConstant:      "constant",
+	analyzer.SymbolKindEnumMember:    "member",
+	analyzer.SymbolKindStruct:        "type",
+	analyzer.SymbolKindTypeParameter: "type parameter",
+}
+
+// fileSymbols is the JSON output of symbols for a file.
+type fileSymbols struct {
+	Path    string                    `json:"path"`
+	Symbols []analyzer.DocumentSymbol `json:"symbols"`
+}
+
+// runSymbols prints the outline of files: their classes and members,
+// functions, types and variables.
+func runSymbols(e *env, args []string) int {
+	fs, opts := newFlags(e, "symbols", "files...")
+	asJSON := fs.Bool("json", false, "shorthand for -output json")
+	files, ok := parseFlags(fs, args)
+	if !ok {
+		return exitError
+	}
+	if *asJSON && !aliasJSON(fs, opts, "-json") {
+		return exitError
+	}
+	if len(files) == 0 {
+		return usageError(fs, "no files")
+	}
+	files, err := sourceFiles(files)
+	if err != nil {
+		return fail(e.stderr, "symbols", err)
+	}
+
+	ps := newParsers(e, opts)
+	defer ps.close()
+	outlines := []fileSymbols{}
+	for i, path := range files {
+		f, err := ps.parse(path)
+		if err != nil {
+			return fail(e.stderr, "symbols", err)
+		}
+		symbols := analyzer.DocumentSymbols(f.tree)
+		if opts.json() {
+			if symbols == nil {
+				symbols = []analyzer.DocumentSymbol{}
+			}
+			outlines = append(outlines, fileSymbols{Path: f.path, Symbols: symbols})
+			continue
+		}
+		if len(files) > 1 {
+			if i > 0 {
+				fmt.Fprintln(e.stdout)
+			}
+			fmt.Fprintf(e.stdout, "%s:\n", f.path)
+		}
+		printSymbols(e.stdout, symbols, "")
+	}
+	if opts.json() {
+		out := struct {
+			Version int           `json:"version"`
+			Files   []fileSymbols `json:"files"`
+		}{jsonVersion, outlines}
+		if err := writeJSON(e.stdout, out); err != nil {
+			return fail(e.stderr, "symbols", err)
+		}
+	}
+	return exitOK
+}
+
+// printSymbols prints symbols and their children, one per line, indented
+// by their depth:
+//
+//	class Service 1:1-9:2
+//	  method fetch(id: string): User 2:3-4:4
+func printSymbols(w io.Writer, symbols []analyzer.DocumentSymbol, indent string) {
+	for _, s := range symbols {
+		kind := symbolKinds[s.Kind]
+		if kind == "" {
+			kind = fmt.Sprintf("symbol(%d)", s.Kind)
+		}
+		detail := s.Detail
+		if detail != "" && !strings.HasPrefix(detail, "(") && !strings.HasPrefix(detail, "<") {
+			detail = ": " + detail
+		}
+		r := s.Range
+		fmt.Fprintf(w, "%s%s %s%s %d:%d-%d:%d\n", indent, kind, s.Name, detail,
+			r.Start.Line+1, r.Start.Character+1, r.End.Line+1, r.End.Character+1)
+		printSymbols(w, s.Children, indent+"  ")
+	}
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+)
+
+func TestSymbols(t *testing.T) {
+	dir := writeFiles(t, map[string]string{"a.ts": `export class Service {
+  private cache: Map<string, User>;
+  fetch(id: string): User {
+    return this.cache.get(id);
+  }
+}
+export interface User { id: string }
+export function load(): void {}
+const limit = 10;
+`})
+	path := filepath.Join(dir, "a.ts")
+
+	code, stdout, _ := runTest(t, "", "symbols", path)
+	want := `class Service 1:1-6:2
+  property cache: Map<string, User> 2:3-2:35
+  method fetch(id: string): User 3:3-5:4
+interface User 7:1-7:37
+  property id: string 7:25-7:35
+function load(): void 8:1-8:32
+constant limit 9:1-9:18
+`
+	if code != exitOK || stdout != want {
+		t.Errorf("symbols = %d\n%s\nwant\n%s", code, stdout, want)
+	}
+
+	code, stdout, _ = runTest(t, "", "symbols", path, "--output", "json")
+	var out struct{ Files []fileSymbols }
+	if err := json.Unmarshal([]byte(stdout), &out); err != nil || code != exitOK || len(out.Files) != 1 {
+		t.Fatalf("symbols --json = %d, %v\n%s", code, err, stdout)
+	}
+	if file := out.Files[0]; file.Path != path || len(file.Symbols) != 4 || len(file.Symbols[0].Children) != 2 {
+		t.Errorf("symbols --json = %+v", file)
+	}
+}
@@ -0,0 +1,403 @@
+package codemod
+
+import (
+	"bytes"
+	"cmp"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/ahmadramadhannn/tsgoast"
+	"github.com/ahmadramadhannn/tsgoast/analyzer"
+	"github.com/ahmadramadhannn/tsgoast/ast"
+	"github.com/ahmadramadhannn/tsgoast/edits"
+	"github.com/ahmadramadhannn/tsgoast/transform"
+)
+
+// Barrel directives, written in comments of a barrel file to mark the
+// exports that SyncBarrel leaves to the developer:
+//
+//	// barrel:keep
+//	export { render } from "./render";
+//	export * as legacy from "./legacy"; // barrel:keep
+//
+//	// barrel:ignore ./internal ./testing
+//
+// A re-export marked with barrel:keep is kept as written, and the module it
+// re-exports is not exported otherwise. The modules listed after
+// barrel:ignore are not exported at all.
+const (
+	BarrelKeep   = "barrel:keep"
+	BarrelIgnore = "barrel:ignore"
+)
+
+// SyncBarrelRule is the rule name of the warnings reported by SyncBarrel.
+const SyncBarrelRule = "sync-barrel"
+
+// SyncBarrel regenerates the re-exports of the barrel file at barrel so
+// that it exports the exported names of every module of dir: the .ts and
+// .tsx files, except declaration files, tests and index files, and the
+// subdirectories holding an index file. The files are not modified.
+//
+// The relative export ... from statements of the barrel are replaced by
+// one statement per module, exporting its names explicitly, with type-only
+// names in a separate export type statement. Imports, local exports and
+// re-exports marked with a directive (see BarrelKeep) are kept, and names
+// the barrel already exports that way are not exported again. A name
+// exported by several modules is exported from the first one and reported
+// in Result.Warnings. A missing barrel file is created.
+func SyncBarrel(barrel, dir string) (*Result, error) {
+	source, err := os.ReadFile(barrel)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("codemod: %w", err)
+	}
+	modules, err := barrelModules(barrel, dir)
+	if err != nil {
+		return nil, err
+	}
+	result := &Result{Path: barrel, Source: source, Output: source, Applied: make(map[string]int)}
+
+	parser, err := tsgoast.New()
+	if err != nil {
+		return nil, err
+	}
+	defer parser.Close()
+
+	var tree *tsgoast.Tree
+	if len(bytes.TrimSpace(source)) > 0 {
+		if tree, err = parser.ParseTree(source); err != nil {
+			return nil, fmt.Errorf("codemod: %s: %w", barrel, err)
+		}
+	}
+	b := newBarrel(tree)
+
+	// Parse every module under dir, for the re-exports of the modules to
+	// resolve
+	graph := analyzer.NewImportGraph()
+	typeNames := make(map[string]map[string]bool)
+	err = filepath.WalkDir(dir, func(file string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		name := entry.Name()
+		if entry.IsDir() {
+			if file != dir && (name == "node_modules" || strings.HasPrefix(name, ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		ext := filepath.Ext(name)
+		if ext != ".ts" && ext != ".tsx" || strings.HasSuffix(name, ".d.ts") {
+			return nil
+		}
+		text, err := os.ReadFile(file)
+		if err != nil || len(bytes.TrimSpace(text)) == 0 {
+			return err
+		}
+		p := parser
+		if ext == ".tsx" {
+			if p, err = tsgoast.NewTSX(); err != nil {
+				return err
+			}
+			defer p.Close()
+		}
+		root, err := p.Parse(text)
+		if err != nil {
+			return fmt.Errorf("%s: %w", file, err)
+		}
+		slashed := filepath.ToSlash(file)
+		graph.AddFile(slashed, root)
+		typeNames[slashed] = typeOnlyNames(root)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("codemod: %w", err)
+	}
+
+	// Generate the statements of each module, skipping the names exported
+	// already
+	var lines []string
+	exportedBy := make(map[string]string)
+	for _, name := range b.exported {
+		exportedBy[name] = "the barrel"
+	}
+	quote, semicolon := b.style()
+	for _, file := range modules {
+		specifier := moduleSpecifier(barrel, file)
+		if b.manual[specifier] {
+			continue
+		}
+		var values, types []string
+		for _, sym := range graph.ExportedSymbols(filepath.ToSlash(file)) {
+			if sym.Name == "default" || sym.Name == "*" {
+				continue
+			}
+			if other, ok := exportedBy[sym.Name]; ok {
+				if other != "the barrel" {
+					result.Warnings = append(result.Warnings, Warning{
+						Rule:    SyncBarrelRule,
+						Message: fmt.Sprintf("%s is exported by both %s and %s", sym.Name, other, specifier),
+					})
+				}
+				continue
+			}
+			exportedBy[sym.Name] = specifier
+			if typeNames[sym.File][sym.Name] {
+				types = append(types, sym.Name)
+			} else {
+				values = append(values, sym.Name)
+			}
+		}
+		sortNames(values)
+		sortNames(types)
+		from := " } from " + quote + specifier + quote + semicolon
+		if len(values) > 0 {
+			lines = append(lines, "export { "+strings.Join(values, ", ")+from)
+		}
+		if len(types) > 0 {
+			lines = append(lines, "export type { "+strings.Join(types, ", ")+from)
+		}
+	}
+	block := strings.Join(lines, "\n")
+
+	output, err := b.replace(source, block)
+	if err != nil {
+		return nil, fmt.Errorf("codemod: %s: %w", barrel, err)
+	}
+	result.Output = output
+	if result.Changed() {
+		result.Applied[SyncBarrelRule] = 1
+	}
+	return result, nil
+}
+
+// barrelModules returns the modules of dir a barrel exports, sorted.
+func barrelModules(barrel, dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("codemod: %w", err)
+	}
+	var modules []string
+	for _, entry := range entries {
+		name := entry.Name()
+		file := filepath.Join(dir, name)
+		if entry.IsDir() {
+			for _, index := range []string{"index.ts", "index.tsx"} {
+				if _, err := os.Stat(filepath.Join(file, index)); err == nil {
+					modules = append(modules, filepath.Join(file, index))
+					break
+				}
+			}
+			continue
+		}
+		ext := filepath.Ext(name)
+		base := strings.TrimSuffix(name, ext)
+		switch {
+		case ext != ".ts" && ext != ".tsx",
+			strings.HasSuffix(base, ".d"), strings.HasSuffix(base, ".test"), strings.HasSuffix(base, ".spec"),
+			base == "index", filepath.Clean(file) == filepath.Clean(barrel):
+			continue
+		}
+		modules = append(modules, file)
+	}
+	slices.Sort(modules)
+	return modules, nil
+}
+
+// moduleSpecifier returns the specifier of file relative to the barrel,
+// without extension, and naming directories for their index files.
+func moduleSpecifier(barrel, file string) string {
+	rel, err := filepath.Rel(filepath.Dir(barrel), file)
+	if err != nil {
+		rel = file
+	}
+	rel = filepath.ToSlash(rel)
+	rel = strings.TrimSuffix(rel, path.Ext(rel))
+	rel = strings.TrimSuffix(rel, "/index")
+	if !strings.HasPrefix(rel, "../") {
+		rel = "./" + rel
+	}
+	return rel
+}
+
+// typeOnlyNames returns the names of the interfaces and type aliases of a
+// file that are not also the names of values.
+func typeOnlyNames(root ast.Node) map[string]bool {
+	types := make(map[string]bool)
+	values := make(map[string]bool)
+	for _, stmt := range root.Children() {
+		decl := stmt
+		if stmt.SyntaxKind() == "export_statement" {
+			if ast.FirstChildOfKind(stmt, "type") != nil {
+				// export type { A, B }
+				for _, spec := range ast.Children(ast.FirstChildOfKind(stmt, "export_clause"), isExportSpecifier) {
+					types[exportedName(spec)] = true
+				}
+				continue
+			}
+			if decl = ast.ChildByField(stmt, "declaration"); decl == nil {
+				continue
+			}
+		}
+		name := ast.ChildByField(decl, "name")
+		switch {
+		case decl.SyntaxKind() == "interface_declaration" || decl.SyntaxKind() == "type_alias_declaration":
+			if name != nil {
+				types[name.Text()] = true
+			}
+		case name != nil:
+			values[name.Text()] = true
+		}
+	}
+	for name := range values {
+		delete(types, name)
+	}
+	return types
+}
+
+// sortNames sorts names ignoring case, as OrganizeImports sorts the named
+// imports.
+func sortNames(names []string) {
+	slices.SortFunc(names, func(a, b string) int {
+		return cmp.Or(cmp.Compare(strings.ToLower(a), strings.ToLower(b)), cmp.Compare(a, b))
+	})
+}
+
+func isExportSpecifier(n ast.Node) bool {
+	return n.SyntaxKind() == "export_specifier"
+}
+
+// exportedName returns the name an export specifier exports.
+func exportedName(spec ast.Node) string {
+	if alias := ast.ChildByField(spec, "alias"); alias != nil {
+		return alias.Text()
+	}
+	if name := ast.ChildByField(spec, "name"); name != nil {
+		return name.Text()
+	}
+	return spec.Text()
+}
+
+// barrelFile holds the statements of a barrel file.
+type barrelFile struct {
+	tree      *tsgoast.Tree
+	generated []ast.Node      // relative re-exports to regenerate
+	manual    map[string]bool // specifiers of kept or ignored modules
+	exported  []string        // names exported by the kept statements
+}
+
+func newBarrel(tree *tsgoast.Tree) *barrelFile {
+	b := &barrelFile{tree: tree, manual: make(map[string]bool)}
+	if tree == nil {
+		return b
+	}
+	children := tree.Root.Children()
+	for i, stmt := range children {
+		if stmt.SyntaxKind() == "comment" {
+			if _, list, ok := strings.Cut(stmt.Text(), BarrelIgnore); ok {
+				for _, specifier := range strings.Fields(strings.TrimSuffix(list, "*/")) {
+					b.manual[specifier] = true
+				}
+			}
+			continue
+		}
+		if stmt.SyntaxKind() != "export_statement" {
+			continue
+		}
+		source := ast.ChildByField(stmt, "source")
+		specifier := ""
+		if source != nil {
+			specifier = strings.Trim(source.Text(), "\"'")
+		}
+		if source == nil || analyzer.ClassifySpecifier(specifier) != analyzer.ImportKindRelative || isKept(children, i) {
+			if source != nil {
+				b.manual[specifier] = true
+			}
+			if clause := ast.FirstChildOfKind(stmt, "export_clause"); clause != nil {
+				for _, spec := range ast.Children(clause, isExportSpecifier) {
+					b.exported = append(b.exported, exportedName(spec))
+				}
+			}
+			if decl := ast.ChildByField(stmt, "declaration"); decl != nil {
+				if name := ast.ChildByField(decl, "name"); name != nil {
+					b.exported = append(b.exported, name.Text())
+				}
+			}
+			continue
+		}
+		b.generated = append(b.generated, stmt)
+	}
+	return b
+}
+
+// isKept reports whether the statement children[i] is marked with the
+// keep directive, in a comment on the line before it or at the end of its
+// line.
+func isKept(children []ast.Node, i int) bool {
+	stmt := children[i]
+	if i > 0 {
+		prev := children[i-1]
+		trailing := i > 1 && children[i-2].Range().End.Line == prev.Range().Start.Line
+		if prev.SyntaxKind() == "comment" && !trailing && prev.Range().End.Line+1 == stmt.Range().Start.Line &&
+			strings.Contains(prev.Text(), BarrelKeep) {
+			return true
+		}
+	}
+	if i+1 < len(children) {
+		next := children[i+1]
+		if next.SyntaxKind() == "comment" && next.Range().Start.Line == stmt.Range().End.Line &&
+			strings.Contains(next.Text(), BarrelKeep) {
+			return true
+		}
+	}
+	return false
+}
+
+// style returns the quote and the statement terminator of the barrel.
+func (b *barrelFile) style() (quote, semicolon string) {
+	quote, semicolon = `"`, ";"
+	if b.tree == nil {
+		return quote, semicolon
+	}
+	for node := range ast.Preorder(b.tree.Root) {
+		if node.SyntaxKind() == "export_statement" || node.SyntaxKind() == "import_statement" {
+			if source := ast.ChildByField(node, "source"); source != nil {
+				quote = source.Text()[:1]
+			}
+			if !strings.HasSuffix(node.Text(), ";") {
+				semicolon = ""
+			}
+			break
+		}
+	}
+	return quote, semicolon
+}
+
+// replace returns source with the regenerated statements replaced by
+// block: at the place of the first of them, or else at the end of the
+// file.
+func (b *barrelFile) replace(source []byte, block string) ([]byte, error) {
+	if b.tree == nil {
+		if block == "" {
+			return source, nil
+		}
+		return []byte(block + "\n"), nil
+	}
+	var e []edits.Edit
+	for i, stmt := range b.generated {
+		if i == 0 && block != "" {
+			e = append(e, edits.Replace(stmt, block))
+			continue
+		}
+		e = append(e, transform.RemoveStatement(stmt))
+	}
+	if len(b.generated) == 0 && block != "" {
+		text := strings.TrimRight(string(source), "\n")
+		return []byte(text + "\n" + block + "\n"), nil
+	}
+	return edits.Apply(source, e)
+}
@@ -0,0 +1,102 @@
+package codemod
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, text := range files {
+		file := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(file, []byte(text), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestSyncBarrel(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"button.tsx":      "export function Button() { return <button />; }\nexport interface ButtonProps { label: string }\n",
+		"format.ts":       "export const format = (s: string) => s;\nexport type Formatter = typeof format;\nexport default format;\n",
+		"dup.ts":          "export const format = 1;\nexport const extra = 2;\n",
+		"legacy.ts":       "export const old = 1;\n",
+		"internal.ts":     "export const secret = 1;\n",
+		"format.test.ts":  "export const testOnly = 1;\n",
+		"types.d.ts":      "export declare const ambient: number;\n",
+		"forms/index.ts":  "export * from \"./input\";\n",
+		"forms/input.ts":  "export class Input {}\nexport type InputValue = string;\n",
+		"notes/readme.md": "no index here\n",
+		"index.ts": `import './polyfill';
+// barrel:ignore ./internal
+export { removed } from './removed';
+export { old as legacy } from './legacy'; // barrel:keep
+export * from './format';
+export const VERSION = '1';
+`,
+	})
+
+	result, err := SyncBarrel(filepath.Join(dir, "index.ts"), dir)
+	if err != nil {
+		t.Fatalf("SyncBarrel() error = %v", err)
+	}
+	want := `import './polyfill';
+// barrel:ignore ./internal
+export { Button } from './button';
+export type { ButtonProps } from './button';
+export { extra, format } from './dup';
+export type { Formatter } from './format';
+export { Input } from './forms';
+export type { InputValue } from './forms';
+export { old as legacy } from './legacy'; // barrel:keep
+export const VERSION = '1';
+`
+	if got := string(result.Output); got != want {
+		t.Errorf("SyncBarrel() =\n%s\nwant\n%s", got, want)
+	}
+	if len(result.Warnings) != 1 || !strings.Contains(result.Warnings[0].Message, "format is exported by both ./dup and ./format") {
+		t.Errorf("SyncBarrel() warnings = %v", result.Warnings)
+	}
+	if result.Applied[SyncBarrelRule] != 1 {
+		t.Errorf("SyncBarrel() applied = %v", result.Applied)
+	}
+}
+
+func TestSyncBarrelNewFile(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"a.ts": "export const a = 1;\nexport interface A {}\nexport class A {}\n",
+		"b.ts": "const b = 2;\nexport { b as bee };\n",
+	})
+
+	result, err := SyncBarrel(filepath.Join(dir, "index.ts"), dir)
+	if err != nil {
+		t.Fatalf("SyncBarrel() error = %v", err)
+	}
+	want := "export { A, a } from \"./a\";\nexport { bee } from \"./b\";\n"
+	if got := string(result.Output); got != want {
+		t.Errorf("SyncBarrel() =\n%s\nwant\n%s", got, want)
+	}
+
+	// Syncing the synced barrel changes nothing
+	writeFiles(t, dir, map[string]string{"index.ts": want})
+	result, err = SyncBarrel(filepath.Join(dir, "index.ts"), dir)
+	if err != nil {
+		t.Fatalf("SyncBarrel() error = %v", err)
+	}
+	if result.Changed() {
+		t.Errorf("SyncBarrel() changed a synced barrel:\n%s", result.Diff())
+	}
+}
+
+func TestSyncBarrelErrors(t *testing.T) {
+	if _, err := SyncBarrel("index.ts", filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("SyncBarrel() with a missing directory: want error")
+	}
+}
@@ -0,0 +1,298 @@
+// Package codemod runs source-to-source migrations of TypeScript code.
+//
+// A Rule selects nodes with a structural pattern (see
+// analyzer.CompilePattern), a predicate, or both, and rewrites each match,
+// either by substituting the pattern's metavariables into a Replace
+// template or by editing the tree through a transform.Cursor. A Runner
+// applies its registered rules to files and reports the rewritten source
+// of each, with a unified diff:
+//
+//	var r codemod.Runner
+//	r.Register(&codemod.Rule{
+//		Name:    "logger",
+//		Pattern: "console.log($$$ARGS)",
+//		Replace: "logger.debug($$$ARGS)",
+//	})
+//	results, err := r.Run(paths)
+//	...
+//	codemod.WriteDiff(os.Stdout, results)
+//
+// Untouched code keeps its original text and layout.
+package codemod
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ahmadramadhannn/tsgoast"
+	"github.com/ahmadramadhannn/tsgoast/analyzer"
+	"github.com/ahmadramadhannn/tsgoast/ast"
+	"github.com/ahmadramadhannn/tsgoast/printer"
+	"github.com/ahmadramadhannn/tsgoast/transform"
+)
+
+// Rule is a rewrite applied by a Runner.
+type Rule struct {
+	// Name identifies the rule in results.
+	Name string
+
+	// Pattern selects the nodes to rewrite. It may be empty if Match is
+	// set.
+	Pattern string
+
+	// Match, if set, filters the nodes matched by Pattern, or selects
+	// nodes on its own when Pattern is empty. The match has no bindings
+	// in that case.
+	Match func(m analyzer.PatternMatch) bool
+
+	// Replace is the replacement code of a match. Metavariables bound by
+	// Pattern ($NAME and $$$NAME) are substituted with the code they
+	// matched; a $$$NAME list keeps its original separators.
+	Replace string
+
+	// Rewrite, if set, is called instead of using Replace, to edit the
+	// tree around a match. The children of matched nodes are not visited.
+	Rewrite func(c *transform.Cursor, m analyzer.PatternMatch)
+
+	// Warn, if set, is called for each match before it is rewritten. A
+	// non-empty message reports the match in Result.Warnings and leaves it
+	// unchanged. A rule with Warn needs no rewrite.
+	Warn func(m analyzer.PatternMatch) string
+
+	pattern *analyzer.Pattern
+}
+
+// Runner applies registered rules to source files. The zero value is a
+// runner without rules.
+type Runner struct {
+	rules []*Rule
+}
+
+// Register adds rules to the runner, compiling their patterns. Rules are
+// applied in registration order.
+func (r *Runner) Register(rules ...*Rule) error {
+	for _, rule := range rules {
+		switch {
+		case rule.Name == "":
+			return fmt.Errorf("codemod: rule has no name")
+		case rule.Pattern == "" && rule.Match == nil:
+			return fmt.Errorf("codemod: rule %s has neither a pattern nor a match function", rule.Name)
+		case rule.Rewrite == nil && rule.Replace == "" && rule.Warn == nil:
+			return fmt.Errorf("codemod: rule %s has no rewrite", rule.Name)
+		}
+		if rule.Pattern != "" {
+			pattern, err := analyzer.CompilePattern(rule.Pattern)
+			if err != nil {
+				return fmt.Errorf("codemod: rule %s: %w", rule.Name, err)
+			}
+			rule.pattern = pattern
+		}
+	}
+	r.rules = append(r.rules, rules...)
+	return nil
+}
+
+// Rules returns the registered rules.
+func (r *Runner) Rules() []*Rule {
+	return r.rules
+}
+
+// Result is the outcome of running the rules on one file.
+type Result struct {
+	Path   string
+	Source []byte // original source
+	Output []byte // rewritten source
+
+	// Applied counts the matches rewritten by each rule, by rule name.
+	Applied map[string]int
+
+	// Warnings are the matches reported by the Warn function of a rule.
+	Warnings []Warning
+}
+
+// Warning is a match that a rule reported rather than rewrote.
+type Warning struct {
+	Rule    string
+	Range   ast.Range // in the source as rewritten by the earlier rules
+	Message string
+}
+
+// String formats the warning as line:column: message (rule).
+func (w Warning) String() string {
+	return fmt.Sprintf("%d:%d: %s (%s)", w.Range.Start.Line+1, w.Range.Start.Column+1, w.Message, w.Rule)
+}
+
+// Changed reports whether the rules changed the source.
+func (r *Result) Changed() bool {
+	return !bytes.Equal(r.Source, r.Output)
+}
+
+// Diff returns the unified diff of the changes, with the path prefixed by
+// a/ and b/ as in git, or an empty string if nothing changed.
+func (r *Result) Diff() string {
+	path := filepath.ToSlash(r.Path)
+	return UnifiedDiff("a/"+path, "b/"+path, string(r.Source), string(r.Output))
+}
+
+// Run reads and rewrites each file. The files are not modified.
+func (r *Runner) Run(paths []string) ([]*Result, error) {
+	results := make([]*Result, 0, len(paths))
+	for _, path := range paths {
+		source, err := os.ReadFile(path)
+		if err != nil {
+			return results, fmt.Errorf("codemod: %w", err)
+		}
+		result, err := r.Rewrite(path, source)
+		if err != nil {
+			return results, err
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// Rewrite applies the rules to source, the content of the file at path.
+// Files ending in .tsx are parsed with JSX support.
+//
+// Each rule rewrites the matches of a single pass over the tree; the
+// output is parsed again before the next rule, so rules see the code
+// produced by earlier ones.
+func (r *Runner) Rewrite(path string, source []byte) (*Result, error) {
+	result := &Result{Path: path, Source: source, Output: source, Applied: make(map[string]int)}
+	if len(bytes.TrimSpace(source)) == 0 {
+		return result, nil
+	}
+
+	newParser := tsgoast.New
+	if strings.HasSuffix(path, ".tsx") {
+		newParser = tsgoast.NewTSX
+	}
+	parser, err := newParser()
+	if err != nil {
+		return nil, err
+	}
+	defer parser.Close()
+
+	for _, rule := range r.rules {
+		tree, err := parser.ParseTree(result.Output)
+		if err != nil {
+			return nil, fmt.Errorf("codemod: %s: %w", path, err)
+		}
+		count := 0
+		transform.Apply(tree, func(c *transform.Cursor) {
+			m, ok := rule.matchNode(c.Node())
+			if !ok {
+				return
+			}
+			if rule.Warn != nil {
+				if msg := rule.Warn(m); msg != "" {
+					result.Warnings = append(result.Warnings, Warning{Rule: rule.Name, Range: m.Range, Message: msg})
+					return
+				}
+			}
+			switch {
+			case rule.Rewrite != nil:
+				rule.Rewrite(c, m)
+			case rule.Replace != "":
+				c.Replace(&ast.BaseNode{Content: expand(rule.Replace, m)})
+			default:
+				return
+			}
+			c.SkipChildren()
+			count++
+		})
+		if count > 0 {
+			result.Applied[rule.Name] += count
+			result.Output = []byte(printer.String(tree.Root))
+		}
+	}
+	return result, nil
+}
+
+// matchNode reports whether the rule applies to node.
+func (rule *Rule) matchNode(node ast.Node) (analyzer.PatternMatch, bool) {
+	m := analyzer.PatternMatch{Node: node, Range: node.Range()}
+	if rule.pattern != nil {
+		var ok bool
+		if m, ok = rule.pattern.MatchNode(node); !ok {
+			return m, false
+		}
+	}
+	return m, rule.Match == nil || rule.Match(m)
+}
+
+// expand substitutes the metavariables of a match into a template.
+func expand(template string, m analyzer.PatternMatch) string {
+	var b strings.Builder
+	for {
+		i := strings.IndexByte(template, '$')
+		if i < 0 {
+			b.WriteString(template)
+			return b.String()
+		}
+		b.WriteString(template[:i])
+		template = template[i:]
+
+		dollars := len(template) - len(strings.TrimLeft(template, "$"))
+		rest := template[dollars:]
+		name := rest[:len(rest)-len(strings.TrimLeftFunc(rest, isMetavariableRune))]
+		list, isList := m.Lists[name]
+		node, isNode := m.Bindings[name]
+		switch {
+		case dollars == 3 && isList:
+			b.WriteString(joinList(list))
+		case dollars == 1 && isNode:
+			b.WriteString(printer.String(node))
+		default:
+			// Not a bound metavariable: keep the text as it is
+			b.WriteString(template[:dollars+len(name)])
+		}
+		template = rest[len(name):]
+	}
+}
+
+func isMetavariableRune(r rune) bool {
+	return 'A' <= r && r <= 'Z' || '0' <= r && r <= '9' || r == '_'
+}
+
+// joinList prints the nodes of a list binding separated by the original
+// text between them, such as ", ".
+func joinList(nodes []ast.Node) string {
+	var b strings.Builder
+	for i, node := range nodes {
+		if i > 0 {
+			b.WriteString(separator(nodes[i-1], node))
+		}
+		b.WriteString(printer.String(node))
+	}
+	return b.String()
+}
+
+// separator returns the source text between two siblings, or ", " if it
+// is not available.
+func separator(prev, next ast.Node) string {
+	parent := prev.Parent()
+	if parent == nil {
+		return ", "
+	}
+	start := parent.Range().Start.Offset
+	from, to := prev.Range().End.Offset-start, next.Range().Start.Offset-start
+	if text := parent.Text(); from <= to && int(to) <= len(text) {
+		return text[from:to]
+	}
+	return ", "
+}
+
+// WriteDiff writes the diffs of the changed results to w.
+func WriteDiff(w io.Writer, results []*Result) error {
+	for _, result := range results {
+		if _, err := io.WriteString(w, result.Diff()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package codemod
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ahmadramadhannn/tsgoast/analyzer"
+	"github.com/ahmadramadhannn/tsgoast/transform"
+)
+
+func TestRunnerRewrite(t *testing.T) {
+	var r Runner
+	err := r.Register(
+		&Rule{
+			Name:    "logger",
+			Pattern: "console.log($$$ARGS)",
+			Replace: "logger.debug($$$ARGS)",
+		},
+		&Rule{
+			Name:    "no-debugger",
+			Pattern: "debugger;",
+			Rewrite: func(c *transform.Cursor, m analyzer.PatternMatch) { c.Delete() },
+		},
+		&Rule{
+			Name:    "strict-equals",
+			Pattern: "$A == $B",
+			Match: func(m analyzer.PatternMatch) bool {
+				return m.Bindings["B"].Text() != "null"
+			},
+			Replace: "$A === $B",
+		},
+	)
+	if err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+
+	source := `function check(user: User) {
+  console.log("checking", user.id,  user.name);
+  debugger;
+  if (user.role == "admin" || user.parent == null) {
+    return true;
+  }
+  return false;
+}
+`
+	result, err := r.Rewrite("src/check.ts", []byte(source))
+	if err != nil {
+		t.Fatalf("Rewrite() error = %v", err)
+	}
+
+	want := `function check(user: User) {
+  logger.debug("checking", user.id,  user.name);
+  if (user.role === "admin" || user.parent == null) {
+    return true;
+  }
+  return false;
+}
+`
+	if string(result.Output) != want {
+		t.Errorf("Output =\n%s\nwant\n%s", result.Output, want)
+	}
+	for name, count := range map[string]int{"logger": 1, "no-debugger": 1, "strict-equals": 1} {
+		if result.Applied[name] != count {
+			t.Errorf("Applied[%s] = %d, want %d", name, result.Applied[name], count)
+		}
+	}
+
+	wantDiff := `--- a/src/check.ts
++++ b/src/check.ts
+@@ -1,7 +1,6 @@
+ function check(user: User) {
+-  console.log("checking", user.id,  user.name);
+-  debugger;
+-  if (user.role == "admin" || user.parent == null) {
++  logger.debug("checking", user.id,  user.name);
++  if (user.role === "admin" || user.parent == null) {
+     return true;
+   }
+   return false;
+`
+	if diff := result.Diff(); diff != wantDiff {
+		t.Errorf("Diff() =\n%s\nwant\n%s", diff, wantDiff)
+	}
+}
+
+func TestRunnerRun(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"a.ts":  "var x = 1;\n",
+		"b.tsx": "const el = <div>{x}</div>;\n",
+	}
+	var paths []string
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		paths = append(paths, path)
+	}
+
+	var r Runner
+	if err := r.Register(&Rule{Name: "no-var", Pattern: "var $X = $Y;", Replace: "let $X = $Y;"}); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	results, err := r.Run(paths)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	var diff strings.Builder
+	if err := WriteDiff(&diff, results); err != nil {
+		t.Fatal(err)
+	}
+	changed := 0
+	for _, result := range results {
+		if result.Changed() {
+			changed++
+		}
+	}
+	if changed != 1 || !strings.Contains(diff.String(), "-var x = 1;\n+let x = 1;\n") {
+		t.Errorf("changed %d files, diff:\n%s", changed, diff.String())
+	}
+}
+
+func TestRegisterErrors(t *testing.T) {
+	var r Runner
+	for _, rule := range []*Rule{
+		{Pattern: "x", Replace: "y"},
+		{Name: "no-selector", Replace: "y"},
+		{Name: "no-rewrite", Pattern: "x"},
+		{Name: "bad-pattern", Pattern: "(", Replace: "y"},
+	} {
+		if err := r.Register(rule); err == nil {
+			t.Errorf("Register(%+v): expected error", rule)
+		}
+	}
+	if len(r.Rules()) != 0 {
+		t.Errorf("Rules() = %v, want none", r.Rules())
+	}
+}
@@ -0,0 +1,237 @@
+package codemod
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ahmadramadhannn/tsgoast/analyzer"
+	"github.com/ahmadramadhannn/tsgoast/ast"
+	"github.com/ahmadramadhannn/tsgoast/transform"
+)
+
+// CommonJSRules returns rules converting a CommonJS module to an
+// ECMAScript module:
+//
+//	const x = require("y");           import x from "y";
+//	const { a, b: c } = require("y"); import { a, b as c } from "y";
+//	const a = require("y").a;         import { a } from "y";
+//	require("y");                     import "y";
+//	module.exports = x;               export default x;
+//	exports.a = a;                    export { a };
+//	module.exports.a = f;             export { f as a };
+//	exports.a = () => 1;              export const a = () => 1;
+//
+// Only top-level statements are converted. The last rule, "commonjs",
+// warns about the require calls and uses of exports left over, such as
+// requires of computed paths or inside functions, which cannot be turned
+// into imports without changing when or whether the module is loaded.
+func CommonJSRules() []*Rule {
+	return []*Rule{
+		{
+			Name:    "require",
+			Match:   func(m analyzer.PatternMatch) bool { return requireImport(m.Node) != "" },
+			Rewrite: func(c *transform.Cursor, m analyzer.PatternMatch) { replaceText(c, requireImport(m.Node)) },
+		},
+		{
+			Name:    "exports",
+			Match:   func(m analyzer.PatternMatch) bool { return exportsExport(m.Node) != "" },
+			Rewrite: func(c *transform.Cursor, m analyzer.PatternMatch) { replaceText(c, exportsExport(m.Node)) },
+		},
+		{
+			Name:  "commonjs",
+			Match: func(m analyzer.PatternMatch) bool { return commonJSWarning(m.Node) != "" },
+			Warn:  func(m analyzer.PatternMatch) string { return commonJSWarning(m.Node) },
+		},
+	}
+}
+
+func replaceText(c *transform.Cursor, text string) {
+	c.Replace(&ast.BaseNode{Content: text})
+}
+
+// requireImport returns the import declaration replacing a top-level
+// require statement, or an empty string if node is not one.
+func requireImport(node ast.Node) string {
+	if !isTopLevel(node) {
+		return ""
+	}
+	switch node.SyntaxKind() {
+	case "expression_statement":
+		if source := requireSource(firstNamedChild(node)); source != "" {
+			return "import " + source + ";"
+		}
+	case "lexical_declaration", "variable_declaration":
+		declarators := ast.ChildrenOfKind(node, "variable_declarator")
+		if len(declarators) != 1 {
+			return ""
+		}
+		name := ast.ChildByField(declarators[0], "name")
+		value := ast.ChildByField(declarators[0], "value")
+		if name == nil || value == nil {
+			return ""
+		}
+		if source := requireSource(value); source != "" {
+			switch name.SyntaxKind() {
+			case "identifier":
+				return fmt.Sprintf("import %s from %s;", name.Text(), source)
+			case "object_pattern":
+				if specs, ok := importSpecifiers(name); ok {
+					return fmt.Sprintf("import { %s } from %s;", specs, source)
+				}
+			}
+			return ""
+		}
+		// const a = require("y").b
+		if value.SyntaxKind() != "member_expression" || name.SyntaxKind() != "identifier" {
+			return ""
+		}
+		source := requireSource(ast.ChildByField(value, "object"))
+		property := ast.ChildByField(value, "property")
+		if source == "" || property == nil {
+			return ""
+		}
+		return fmt.Sprintf("import { %s } from %s;", alias(property.Text(), name.Text()), source)
+	}
+	return ""
+}
+
+// importSpecifiers returns the import specifiers equivalent to a
+// destructuring pattern, or false if the pattern has defaults, rest
+// elements or nested patterns.
+func importSpecifiers(pattern ast.Node) (string, bool) {
+	var specs []string
+	for _, prop := range pattern.Children() {
+		switch prop.SyntaxKind() {
+		case "{", "}", ",":
+		case "shorthand_property_identifier_pattern":
+			specs = append(specs, prop.Text())
+		case "pair_pattern":
+			key := ast.ChildByField(prop, "key")
+			value := ast.ChildByField(prop, "value")
+			if key == nil || value == nil || value.SyntaxKind() != "identifier" {
+				return "", false
+			}
+			specs = append(specs, alias(key.Text(), value.Text()))
+		default:
+			return "", false
+		}
+	}
+	return strings.Join(specs, ", "), len(specs) > 0
+}
+
+// exportsExport returns the export declaration replacing a top-level
+// assignment to module.exports or one of its properties, or an empty
+// string if node is not one.
+func exportsExport(node ast.Node) string {
+	if !isTopLevel(node) || node.SyntaxKind() != "expression_statement" {
+		return ""
+	}
+	assign := firstNamedChild(node)
+	if assign == nil || assign.SyntaxKind() != "assignment_expression" {
+		return ""
+	}
+	left := ast.ChildByField(assign, "left")
+	right := ast.ChildByField(assign, "right")
+	if left == nil || right == nil {
+		return ""
+	}
+	if isModuleExports(left) {
+		return "export default " + right.Text() + ";"
+	}
+	if left.SyntaxKind() != "member_expression" || !isExportsObject(ast.ChildByField(left, "object")) {
+		return ""
+	}
+	property := ast.ChildByField(left, "property")
+	if property == nil || property.SyntaxKind() != "property_identifier" {
+		return ""
+	}
+	if right.SyntaxKind() == "identifier" {
+		return "export { " + alias(right.Text(), property.Text()) + " };"
+	}
+	return fmt.Sprintf("export const %s = %s;", property.Text(), right.Text())
+}
+
+// commonJSWarning returns why node, a CommonJS construct left after the
+// conversion, cannot be converted, or an empty string if node is not one.
+func commonJSWarning(node ast.Node) string {
+	switch node.SyntaxKind() {
+	case "call_expression":
+		fn := ast.ChildByField(node, "function")
+		if fn == nil || fn.SyntaxKind() != "identifier" || fn.Text() != "require" {
+			return ""
+		}
+		if requireSource(node) == "" {
+			return "require of a computed module path cannot be converted to an import"
+		}
+		return "require outside a top-level declaration cannot be converted to an import"
+	case "member_expression":
+		if isModuleExports(node) {
+			return "module.exports cannot be converted to an export here"
+		}
+	case "identifier":
+		if node.Text() == "exports" {
+			return "exports cannot be converted to an export here"
+		}
+	}
+	return ""
+}
+
+// requireSource returns the quoted module path of a require("y") call, or
+// an empty string if node is not one.
+func requireSource(node ast.Node) string {
+	if node == nil || node.SyntaxKind() != "call_expression" {
+		return ""
+	}
+	fn := ast.ChildByField(node, "function")
+	args := ast.ChildByField(node, "arguments")
+	if fn == nil || fn.SyntaxKind() != "identifier" || fn.Text() != "require" || args == nil {
+		return ""
+	}
+	var named []ast.Node
+	for _, arg := range args.Children() {
+		if kind := arg.SyntaxKind(); kind != "(" && kind != ")" && kind != "," {
+			named = append(named, arg)
+		}
+	}
+	if len(named) != 1 || named[0].SyntaxKind() != "string" {
+		return ""
+	}
+	return named[0].Text()
+}
+
+// isExportsObject reports whether node is exports or module.exports.
+func isExportsObject(node ast.Node) bool {
+	return node != nil && (node.SyntaxKind() == "identifier" && node.Text() == "exports" || isModuleExports(node))
+}
+
+// isModuleExports reports whether node is module.exports.
+func isModuleExports(node ast.Node) bool {
+	if node.SyntaxKind() != "member_expression" {
+		return false
+	}
+	object := ast.ChildByField(node, "object")
+	property := ast.ChildByField(node, "property")
+	return object != nil && property != nil && object.Text() == "module" && property.Text() == "exports"
+}
+
+// isTopLevel reports whether node is a statement of the program.
+func isTopLevel(node ast.Node) bool {
+	parent := node.Parent()
+	return parent != nil && parent.SyntaxKind() == "program"
+}
+
+// firstNamedChild returns the first child of node that is not punctuation.
+func firstNamedChild(node ast.Node) ast.Node {
+	return ast.FirstChild(node, func(child ast.Node) bool {
+		return child.SyntaxKind() != ";" && child.SyntaxKind() != "(" && child.SyntaxKind() != ")"
+	})
+}
+
+// alias formats the import or export specifier "name as as", or just
+// name if both are the same.
+func alias(name, as string) string {
+	if name == as {
+		return name
+	}
+	return name + " as " + as
+}
@@ -0,0 +1,81 @@
+package codemod
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCommonJSRules(t *testing.T) {
+	var r Runner
+	if err := r.Register(CommonJSRules()...); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+
+	source := `const fs = require("fs");
+const { join, resolve: res } = require("path");
+const read = require("./io").read;
+require("./polyfill");
+const plugin = require(name);
+
+function load() {
+  return require("./lazy");
+}
+
+exports.load = load;
+module.exports.helper = helperImpl;
+exports.version = "1.0";
+module.exports = load;
+`
+	result, err := r.Rewrite("index.js", []byte(source))
+	if err != nil {
+		t.Fatalf("Rewrite() error = %v", err)
+	}
+
+	want := `import fs from "fs";
+import { join, resolve as res } from "path";
+import { read } from "./io";
+import "./polyfill";
+const plugin = require(name);
+
+function load() {
+  return require("./lazy");
+}
+
+export { load };
+export { helperImpl as helper };
+export const version = "1.0";
+export default load;
+`
+	if string(result.Output) != want {
+		t.Errorf("Output =\n%q\nwant\n%q", result.Output, want)
+	}
+	if result.Applied["require"] != 4 || result.Applied["exports"] != 4 {
+		t.Errorf("Applied = %v", result.Applied)
+	}
+
+	var warnings []string
+	for _, w := range result.Warnings {
+		warnings = append(warnings, w.String())
+	}
+	wantWarnings := []string{
+		"5:16: require of a computed module path cannot be converted to an import (commonjs)",
+		"8:10: require outside a top-level declaration cannot be converted to an import (commonjs)",
+	}
+	if strings.Join(warnings, "\n") != strings.Join(wantWarnings, "\n") {
+		t.Errorf("Warnings =\n%s\nwant\n%s", strings.Join(warnings, "\n"), strings.Join(wantWarnings, "\n"))
+	}
+}
+
+func TestCommonJSExportsReference(t *testing.T) {
+	var r Runner
+	if err := r.Register(CommonJSRules()...); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	result, err := r.Rewrite("a.js", []byte("function f() { exports.x = 1; }\nif (ok) module.exports = f;\n"))
+	if err != nil {
+		t.Fatalf("Rewrite() error = %v", err)
+	}
+	if result.Changed() || len(result.Warnings) != 2 {
+		t.Errorf("Changed() = %v, Warnings = %v", result.Changed(), result.Warnings)
+	}
+}
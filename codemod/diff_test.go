@@ -0,0 +1,56 @@
+package codemod
+
+import "testing"
+
+func TestUnifiedDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		old, new string
+		want     string
+	}{
+		{"equal", "a\nb\n", "a\nb\n", ""},
+		{
+			"separate hunks",
+			"1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n",
+			"0\n1\n2\n3\n4\n5\n6\n7\n8\n9\n10\neleven\n12\n",
+			`--- old
++++ new
+@@ -1,3 +1,4 @@
++0
+ 1
+ 2
+ 3
+@@ -8,5 +9,5 @@
+ 8
+ 9
+ 10
+-11
++eleven
+ 12
+`,
+		},
+		{
+			"no trailing newline",
+			"a\nb",
+			"a\nc",
+			`--- old
++++ new
+@@ -1,2 +1,2 @@
+ a
+-b
+\ No newline at end of file
++c
+\ No newline at end of file
+`,
+		},
+		{"from empty", "", "x\n", "--- old\n+++ new\n@@ -0,0 +1 @@\n+x\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := UnifiedDiff("old", "new", tt.old, tt.new); got != tt.want {
+				t.Errorf("UnifiedDiff() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package tsgoast
+
+import (
+	"fmt"
+
+	"github.com/ahmadramadhannn/tsgoast/ast"
+	sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// Cursor walks a syntax tree without converting it to ast nodes, mirroring
+// tree-sitter's TreeCursor. It keeps only the tree-sitter tree in memory,
+// which makes it suitable for streaming over very large files; individual
+// subtrees can still be converted with Node.
+//
+// A Cursor must be closed when no longer needed.
+type Cursor struct {
+	parser *Parser
+	tree   *sitter.Tree
+	cursor *sitter.TreeCursor
+	source []byte
+}
+
+// NewCursor parses source and returns a cursor positioned at the root node.
+func (p *Parser) NewCursor(source []byte) (*Cursor, error) {
+	if len(source) == 0 {
+		return nil, fmt.Errorf("source code is empty")
+	}
+
+	tree := p.parser.Parse(source, nil)
+	if tree == nil {
+		return nil, fmt.Errorf("failed to parse source code")
+	}
+
+	return &Cursor{
+		parser: p,
+		tree:   tree,
+		cursor: tree.Walk(),
+		source: source,
+	}, nil
+}
+
+// GotoFirstChild moves to the first child of the current node. It returns
+// false if the node has no children.
+func (c *Cursor) GotoFirstChild() bool {
+	return c.cursor.GotoFirstChild()
+}
+
+// GotoLastChild moves to the last child of the current node. It returns
+// false if the node has no children.
+func (c *Cursor) GotoLastChild() bool {
+	return c.cursor.GotoLastChild()
+}
+
+// GotoNextSibling moves to the next sibling of the current node. It returns
+// false if there is none.
+func (c *Cursor) GotoNextSibling() bool {
+	return c.cursor.GotoNextSibling()
+}
+
+// GotoPreviousSibling moves to the previous sibling of the current node. It
+// returns false if there is none.
+func (c *Cursor) GotoPreviousSibling() bool {
+	return c.cursor.GotoPreviousSibling()
+}
+
+// GotoParent moves to the parent of the current node. It returns false at
+// the root.
+func (c *Cursor) GotoParent() bool {
+	return c.cursor.GotoParent()
+}
+
+// GotoFirstChildForOffset moves to the first child of the current node that
+// contains or starts after the given byte offset. It returns false if there
+// is no such child.
+func (c *Cursor) GotoFirstChildForOffset(offset uint32) bool {
+	return c.cursor.GotoFirstChildForByte(offset) != nil
+}
+
+// GotoChildByField moves to the first child of the current node stored in
+// the given field (e.g. "name" or "body"). It returns false, leaving the
+// cursor in place, if there is no such child.
+func (c *Cursor) GotoChildByField(field string) bool {
+	if !c.cursor.GotoFirstChild() {
+		return false
+	}
+	for {
+		if c.cursor.FieldName() == field {
+			return true
+		}
+		if !c.cursor.GotoNextSibling() {
+			c.cursor.GotoParent()
+			return false
+		}
+	}
+}
+
+// Reset moves the cursor back to the root node.
+func (c *Cursor) Reset() {
+	c.cursor.Reset(*c.tree.RootNode())
+}
+
+// Kind returns the tree-sitter kind of the current node.
+func (c *Cursor) Kind() string {
+	return c.parser.kind(c.cursor.Node()).name
+}
+
+// FieldName returns the field under which the current node is stored in its
+// parent (e.g. "name"), or an empty string.
+func (c *Cursor) FieldName() string {
+	return c.cursor.FieldName()
+}
+
+// IsNamed reports whether the current node is a named node rather than an
+// anonymous token such as a keyword or punctuation.
+func (c *Cursor) IsNamed() bool {
+	return c.cursor.Node().IsNamed()
+}
+
+// Depth returns the depth of the current node, the root being at depth 0.
+func (c *Cursor) Depth() int {
+	return int(c.cursor.Depth())
+}
+
+// Text returns the source text of the current node.
+func (c *Cursor) Text() string {
+	node := c.cursor.Node()
+	return string(c.source[node.StartByte():node.EndByte()])
+}
+
+// Range returns the source range of the current node.
+func (c *Cursor) Range() ast.Range {
+	node := c.cursor.Node()
+	return ast.Range{
+		Start: ast.Position{
+			Line:   uint32(node.StartPosition().Row),
+			Column: uint32(node.StartPosition().Column),
+			Offset: uint32(node.StartByte()),
+		},
+		End: ast.Position{
+			Line:   uint32(node.EndPosition().Row),
+			Column: uint32(node.EndPosition().Column),
+			Offset: uint32(node.EndByte()),
+		},
+	}
+}
+
+// Node converts the subtree rooted at the current node to ast nodes. The
+// returned node has no parent; the limits of the parser apply from it.
+func (c *Cursor) Node() *ast.BaseNode {
+	node := c.cursor.Node()
+	text := string(c.source[node.StartByte():node.EndByte()])
+	root, _ := c.parser.convertTree(node, text, node.StartByte(), nil, 0)
+	return root
+}
+
+// Close releases the resources held by the cursor and its tree.
+func (c *Cursor) Close() {
+	if c.cursor != nil {
+		c.cursor.Close()
+		c.cursor = nil
+	}
+	if c.tree != nil {
+		c.tree.Close()
+		c.tree = nil
+	}
+}
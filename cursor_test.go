@@ -0,0 +1,124 @@
+package tsgoast
+
+import (
+	"testing"
+
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+func TestCursor(t *testing.T) {
+	parser, err := New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+	defer parser.Close()
+
+	source := []byte(`function greet(name: string) {
+	return name;
+}
+const x = 1;`)
+
+	cursor, err := parser.NewCursor(source)
+	if err != nil {
+		t.Fatalf("NewCursor() error = %v", err)
+	}
+	defer cursor.Close()
+
+	if cursor.Kind() != "program" || cursor.Depth() != 0 {
+		t.Fatalf("root = %s at depth %d", cursor.Kind(), cursor.Depth())
+	}
+	if cursor.GotoParent() || cursor.GotoNextSibling() {
+		t.Error("root should have no parent or siblings")
+	}
+
+	if !cursor.GotoFirstChild() || cursor.Kind() != "function_declaration" {
+		t.Fatalf("first child = %s, want function_declaration", cursor.Kind())
+	}
+	if !cursor.GotoChildByField("name") || cursor.Text() != "greet" || cursor.FieldName() != "name" {
+		t.Fatalf("name field = %s (%s)", cursor.Text(), cursor.FieldName())
+	}
+	if !cursor.IsNamed() || cursor.Depth() != 2 {
+		t.Errorf("name node named = %v depth = %d", cursor.IsNamed(), cursor.Depth())
+	}
+	if !cursor.GotoParent() || cursor.GotoChildByField("missing") || cursor.Kind() != "function_declaration" {
+		t.Errorf("GotoChildByField(missing) should leave the cursor on the function")
+	}
+
+	if !cursor.GotoNextSibling() || cursor.Kind() != "lexical_declaration" {
+		t.Fatalf("second statement = %s, want lexical_declaration", cursor.Kind())
+	}
+	if r := cursor.Range(); r.Start.Line != 3 || r.Start.Offset != uint32(len(source)-len("const x = 1;")) {
+		t.Errorf("Range() = %+v", r)
+	}
+
+	node := cursor.Node()
+	if node.SyntaxKind() != "lexical_declaration" || node.Text() != "const x = 1;" || node.Parent() != nil {
+		t.Errorf("Node() = %s %q", node.SyntaxKind(), node.Text())
+	}
+
+	cursor.Reset()
+	if cursor.Kind() != "program" {
+		t.Errorf("after Reset() kind = %s, want program", cursor.Kind())
+	}
+	if !cursor.GotoFirstChildForOffset(uint32(len(source)-2)) || cursor.Kind() != "lexical_declaration" {
+		t.Errorf("GotoFirstChildForOffset() = %s, want lexical_declaration", cursor.Kind())
+	}
+}
+
+func TestCursorMatchesParse(t *testing.T) {
+	parser, err := New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+	defer parser.Close()
+
+	source := []byte(`class A { m(a: number) { if (a) { return [a, { b: a }]; } } }`)
+
+	root, err := parser.Parse(source)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	var want []string
+	var collect func(ast.Node)
+	collect = func(n ast.Node) {
+		want = append(want, n.SyntaxKind())
+		for _, child := range n.Children() {
+			collect(child)
+		}
+	}
+	collect(root)
+
+	cursor, err := parser.NewCursor(source)
+	if err != nil {
+		t.Fatalf("NewCursor() error = %v", err)
+	}
+	defer cursor.Close()
+
+	// Pre-order traversal with the cursor alone
+	var got []string
+	for done := false; !done; {
+		got = append(got, cursor.Kind())
+		if cursor.GotoFirstChild() {
+			continue
+		}
+		for !cursor.GotoNextSibling() {
+			if !cursor.GotoParent() {
+				done = true
+				break
+			}
+		}
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("cursor visited %d nodes, Parse produced %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("node %d = %s, want %s", i, got[i], want[i])
+		}
+	}
+
+	if _, err := parser.NewCursor(nil); err == nil {
+		t.Error("NewCursor(nil) expected error")
+	}
+}
@@ -0,0 +1,495 @@
+package tsgoast
+
+import (
+	"strings"
+
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+// ChangeKind classifies a change reported by Diff.
+type ChangeKind string
+
+// Change kind constants.
+const (
+	ChangeAdded    ChangeKind = "added"
+	ChangeRemoved  ChangeKind = "removed"
+	ChangeModified ChangeKind = "modified"
+)
+
+// Change describes a declaration or statement that differs between two trees.
+// Old is nil for additions and New is nil for removals.
+type Change struct {
+	Kind ChangeKind
+
+	// Name is the declared name, qualified with the enclosing class or
+	// interface for members (e.g. "Service.fetch"). It is empty for
+	// statements that do not declare anything.
+	Name string
+
+	// SyntaxKind is the tree-sitter kind of the declaration, with export
+	// wrappers removed.
+	SyntaxKind string
+
+	Old      ast.Node
+	New      ast.Node
+	OldRange ast.Range
+	NewRange ast.Range
+}
+
+// containerBodies maps declaration kinds whose members are diffed
+// individually to the kind of their body node.
+var containerBodies = map[string]string{
+	"class_declaration":          "class_body",
+	"abstract_class_declaration": "class_body",
+	"interface_declaration":      "interface_body",
+}
+
+// Diff compares the top-level declarations and statements of two trees.
+//
+// Declarations are matched by kind and name; other statements are matched by
+// content and then by kind in source order. Two nodes are considered equal
+// when their token sequences match, so whitespace and comment edits are not
+// reported. When a class or interface keeps its header, changes are reported
+// per member instead of for the whole declaration.
+//
+// Changes are returned in the order of the new tree, followed by removals in
+// the order of the old tree.
+func Diff(oldTree, newTree *Tree) []Change {
+	var oldItems, newItems []ast.Node
+	if oldTree != nil && oldTree.Root != nil {
+		oldItems = diffItems(oldTree.Root)
+	}
+	if newTree != nil && newTree.Root != nil {
+		newItems = diffItems(newTree.Root)
+	}
+	return diffLists(oldItems, newItems, "")
+}
+
+// diffLists matches two lists of sibling declarations and reports their
+// differences.
+func diffLists(oldItems, newItems []ast.Node, prefix string) []Change {
+	matched := make(map[ast.Node]ast.Node) // new -> old
+	used := make(map[ast.Node]bool)
+
+	// Match declarations by kind and name
+	oldByKey := make(map[string][]ast.Node)
+	for _, item := range oldItems {
+		if key := diffKey(item); key != "" {
+			oldByKey[key] = append(oldByKey[key], item)
+		}
+	}
+	for _, item := range newItems {
+		key := diffKey(item)
+		if key == "" || len(oldByKey[key]) == 0 {
+			continue
+		}
+		matched[item] = oldByKey[key][0]
+		used[oldByKey[key][0]] = true
+		oldByKey[key] = oldByKey[key][1:]
+	}
+
+	// Match anonymous statements by content, then by kind
+	unmatched := func(node ast.Node, same func(a, b ast.Node) bool) ast.Node {
+		for _, candidate := range oldItems {
+			if !used[candidate] && diffKey(candidate) == "" && same(candidate, node) {
+				return candidate
+			}
+		}
+		return nil
+	}
+	for _, pass := range []func(a, b ast.Node) bool{
+		func(a, b ast.Node) bool { return tokenText(a) == tokenText(b) },
+		func(a, b ast.Node) bool { return a.SyntaxKind() == b.SyntaxKind() },
+	} {
+		for _, item := range newItems {
+			if _, ok := matched[item]; ok || diffKey(item) != "" {
+				continue
+			}
+			if old := unmatched(item, pass); old != nil {
+				matched[item] = old
+				used[old] = true
+			}
+		}
+	}
+
+	var changes []Change
+	for _, item := range newItems {
+		old, ok := matched[item]
+		if !ok {
+			changes = append(changes, newChange(ChangeAdded, prefix, nil, item))
+			continue
+		}
+		if tokenText(old) == tokenText(item) {
+			continue
+		}
+		if members := diffMembers(old, item, prefix); members != nil {
+			changes = append(changes, members...)
+			continue
+		}
+		changes = append(changes, newChange(ChangeModified, prefix, old, item))
+	}
+	for _, item := range oldItems {
+		if !used[item] {
+			changes = append(changes, newChange(ChangeRemoved, prefix, item, nil))
+		}
+	}
+	return changes
+}
+
+// diffMembers diffs the members of two versions of a class or interface.
+// It returns nil when the declarations are not containers or when their
+// headers differ.
+func diffMembers(oldNode, newNode ast.Node, prefix string) []Change {
+	oldDecl, newDecl := unwrapExport(oldNode), unwrapExport(newNode)
+	bodyKind, ok := containerBodies[newDecl.SyntaxKind()]
+	if !ok || oldDecl.SyntaxKind() != newDecl.SyntaxKind() {
+		return nil
+	}
+
+	oldBody, newBody := ast.FirstChildOfKind(oldDecl, bodyKind), ast.FirstChildOfKind(newDecl, bodyKind)
+	if oldBody == nil || newBody == nil {
+		return nil
+	}
+	if headerTokens(oldNode, oldBody) != headerTokens(newNode, newBody) {
+		return nil
+	}
+
+	return diffLists(diffItems(oldBody), diffItems(newBody), prefix+diffName(newDecl)+".")
+}
+
+// newChange builds a Change for the given pair of nodes.
+func newChange(kind ChangeKind, prefix string, old, new ast.Node) Change {
+	change := Change{Kind: kind, Old: old, New: new}
+	subject := new
+	if subject == nil {
+		subject = old
+	}
+	decl := unwrapExport(subject)
+	change.SyntaxKind = decl.SyntaxKind()
+	if name := diffName(decl); name != "" {
+		change.Name = prefix + name
+	}
+	if old != nil {
+		change.OldRange = old.Range()
+	}
+	if new != nil {
+		change.NewRange = new.Range()
+	}
+	return change
+}
+
+// diffItems returns the children of node that take part in a diff,
+// skipping comments and punctuation.
+func diffItems(node ast.Node) []ast.Node {
+	var items []ast.Node
+	for _, child := range node.Children() {
+		switch child.SyntaxKind() {
+		case "comment", "{", "}", ";", ",":
+			continue
+		}
+		items = append(items, child)
+	}
+	return items
+}
+
+// diffKey returns the matching key of a declaration, or an empty string for
+// statements that do not declare a name.
+func diffKey(node ast.Node) string {
+	decl := unwrapExport(node)
+	name := diffName(decl)
+	if name == "" {
+		return ""
+	}
+	return decl.SyntaxKind() + ":" + name
+}
+
+// diffName returns the name declared by a node, if any.
+func diffName(node ast.Node) string {
+	switch node.SyntaxKind() {
+	case "lexical_declaration", "variable_declaration":
+		var names []string
+		for _, child := range node.Children() {
+			if child.SyntaxKind() == "variable_declarator" && len(child.Children()) > 0 {
+				names = append(names, child.Children()[0].Text())
+			}
+		}
+		return strings.Join(names, ",")
+	case "expression_statement", "if_statement", "for_statement", "for_in_statement",
+		"while_statement", "do_statement", "switch_statement", "try_statement",
+		"return_statement", "throw_statement", "import_statement", "export_statement",
+		"statement_block", "empty_statement":
+		return ""
+	}
+
+	for _, child := range node.Children() {
+		switch child.SyntaxKind() {
+		case "identifier", "type_identifier", "property_identifier",
+			"private_property_identifier", "string", "number", "nested_identifier":
+			return child.Text()
+		case "formal_parameters", "statement_block", "class_body", "=":
+			return ""
+		}
+	}
+	return ""
+}
+
+// unwrapExport returns the declaration wrapped by an export statement, or the
+// node itself.
+func unwrapExport(node ast.Node) ast.Node {
+	if node.SyntaxKind() != "export_statement" {
+		return node
+	}
+	for _, child := range node.Children() {
+		switch child.SyntaxKind() {
+		case "export", "default", "comment":
+			continue
+		}
+		if strings.HasSuffix(child.SyntaxKind(), "declaration") {
+			return child
+		}
+		break
+	}
+	return node
+}
+
+// tokenText returns the leaf tokens of a node joined by spaces, ignoring
+// comments and formatting.
+func tokenText(node ast.Node) string {
+	var b strings.Builder
+	appendTokens(&b, node, nil)
+	return b.String()
+}
+
+// headerTokens returns the tokens of a declaration that precede its body.
+func headerTokens(node, body ast.Node) string {
+	var b strings.Builder
+	appendTokens(&b, node, body)
+	return b.String()
+}
+
+// appendTokens writes the leaf tokens of node to b, stopping at stop.
+// It reports whether stop was reached.
+func appendTokens(b *strings.Builder, node, stop ast.Node) bool {
+	if node == stop {
+		return true
+	}
+	if node.SyntaxKind() == "comment" {
+		return false
+	}
+	children := node.Children()
+	if len(children) == 0 {
+		b.WriteString(node.Text())
+		b.WriteByte(' ')
+		return false
+	}
+	for _, child := range children {
+		if appendTokens(b, child, stop) {
+			return true
+		}
+	}
+	return false
+}
+
+// Exported reports whether the changed declaration is part of the API of
+// its module: exported, in the old tree or, for additions, in the new one,
+// at the top level or from an export clause, or a member other than a
+// private one of an exported class or interface.
+func (c Change) Exported() bool {
+	if c.Old != nil {
+		return isExported(c.Old)
+	}
+	return c.New != nil && isExported(c.New)
+}
+
+// SignatureChanged reports whether a modified declaration changed its
+// signature, as returned by Signature, rather than only its body or
+// initializer.
+func (c Change) SignatureChanged() bool {
+	if c.Kind != ChangeModified {
+		return false
+	}
+	oldTokens, _ := signature(c.Old)
+	newTokens, _ := signature(c.New)
+	return oldTokens != newTokens
+}
+
+// Breaking reports whether the change can break the importers of the
+// module: the removal of an exported declaration, the change of its
+// signature or of its export, or the addition of a required member to an
+// exported interface or of an abstract member to an exported class. The
+// check is syntactic, so that changing a type used by a signature, or the
+// inferred type of a variable, is not detected.
+func (c Change) Breaking() bool {
+	switch c.Kind {
+	case ChangeRemoved:
+		return isExported(c.Old)
+	case ChangeModified:
+		return isExported(c.Old) && (!isExported(c.New) || c.SignatureChanged())
+	case ChangeAdded:
+		return isExported(c.New) && isRequiredMember(c.New)
+	}
+	return false
+}
+
+// Signature returns the text of the signature of a declaration, on one
+// line: its tokens without function bodies, class and interface bodies,
+// whose members are diffed one by one, and the initializers of variables
+// and fields, other than functions.
+//
+//	export async function load(id: string): Promise<User>
+//	const limit: number
+func Signature(node ast.Node) string {
+	_, text := signature(node)
+	return text
+}
+
+// signature returns the tokens of the signature of node, separated by
+// spaces, and its text, spaced as in the source.
+func signature(node ast.Node) (tokens, text string) {
+	var tb, b strings.Builder
+	var end uint32
+	joined := true // whether the next token follows the previous one
+	var walk func(n ast.Node)
+	walk = func(n ast.Node) {
+		children := n.Children()
+		if len(children) == 0 {
+			r := n.Range()
+			if b.Len() > 0 && !joined && r.Start.Offset > end {
+				b.WriteByte(' ')
+			}
+			b.WriteString(n.Text())
+			tb.WriteString(n.Text())
+			tb.WriteByte(' ')
+			end, joined = r.End.Offset, false
+			return
+		}
+		for i, child := range children {
+			switch {
+			case child.SyntaxKind() == "comment":
+			case excludedFromSignature(child):
+				joined = true
+			case (child.SyntaxKind() == "=" || child.SyntaxKind() == "=>") && i+1 < len(children) && excludedFromSignature(children[i+1]):
+				// The = of a left out initializer, or the => of a body
+			default:
+				walk(child)
+			}
+		}
+	}
+	walk(node)
+	return tb.String(), b.String()
+}
+
+// excludedFromSignature reports whether node is left out of the signature
+// of the declaration holding it.
+func excludedFromSignature(node ast.Node) bool {
+	parent := node.Parent()
+	if parent == nil {
+		return false
+	}
+	switch node.SyntaxKind() {
+	case "statement_block":
+		return ast.ChildByField(parent, "body") == node
+	case "class_body", "interface_body":
+		return true
+	}
+	switch parent.SyntaxKind() {
+	case "variable_declarator", "public_field_definition":
+		switch node.SyntaxKind() {
+		case "arrow_function", "function_expression", "function", "generator_function":
+			return false
+		}
+		return ast.ChildByField(parent, "value") == node
+	case "arrow_function":
+		return ast.ChildByField(parent, "body") == node
+	}
+	return false
+}
+
+// isExported reports whether node, a top-level statement or a member of a
+// class or interface, is exported; see Change.Exported.
+func isExported(node ast.Node) bool {
+	parent := node.Parent()
+	if parent == nil {
+		return false
+	}
+	switch parent.SyntaxKind() {
+	case "program":
+		if node.SyntaxKind() == "export_statement" {
+			return true
+		}
+		exported := exportClauseNames(parent)
+		for name := range strings.SplitSeq(diffName(node), ",") {
+			if exported[name] {
+				return true
+			}
+		}
+		return false
+	case "class_body", "interface_body":
+		if isPrivateMember(node) {
+			return false
+		}
+		decl := parent.Parent()
+		if decl == nil {
+			return false
+		}
+		if p := decl.Parent(); p != nil && p.SyntaxKind() == "export_statement" {
+			return true
+		}
+		return isExported(decl)
+	}
+	return false
+}
+
+// exportClauseNames returns the local names exported by the export clauses
+// of program, such as a and b for export { a, b as c }.
+func exportClauseNames(program ast.Node) map[string]bool {
+	names := make(map[string]bool)
+	for _, stmt := range program.Children() {
+		if stmt.SyntaxKind() != "export_statement" || ast.ChildByField(stmt, "source") != nil {
+			continue
+		}
+		clause := ast.FirstChildOfKind(stmt, "export_clause")
+		if clause == nil {
+			continue
+		}
+		for _, spec := range ast.ChildrenOfKind(clause, "export_specifier") {
+			if name := ast.ChildByField(spec, "name"); name != nil {
+				names[name.Text()] = true
+			}
+		}
+	}
+	return names
+}
+
+// isPrivateMember reports whether a class member is private, by modifier
+// or by a #name.
+func isPrivateMember(node ast.Node) bool {
+	for _, child := range node.Children() {
+		switch child.SyntaxKind() {
+		case "accessibility_modifier":
+			if child.Text() == "private" {
+				return true
+			}
+		case "private_property_identifier":
+			return true
+		}
+	}
+	return false
+}
+
+// isRequiredMember reports whether node is a member implementations must
+// provide: a non-optional member of an interface, or an abstract member of
+// a class.
+func isRequiredMember(node ast.Node) bool {
+	parent := node.Parent()
+	if parent == nil {
+		return false
+	}
+	switch parent.SyntaxKind() {
+	case "interface_body":
+		return ast.FirstChildOfKind(node, "?") == nil
+	case "class_body":
+		return strings.HasPrefix(node.SyntaxKind(), "abstract_") || ast.FirstChildOfKind(node, "abstract") != nil
+	}
+	return false
+}
@@ -0,0 +1,199 @@
+package tsgoast
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	parser, err := New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+	defer parser.Close()
+
+	oldTree, err := parser.ParseTree([]byte(`
+import { get } from "./http";
+
+const retries = 3;
+
+export function load(id: string) {
+	return get(id);
+}
+
+function unused() {}
+
+class Service {
+	timeout = 10;
+	fetch() { return 1; }
+	close() {}
+}
+
+console.log("ready");
+`))
+	if err != nil {
+		t.Fatalf("Failed to parse old source: %v", err)
+	}
+
+	newTree, err := parser.ParseTree([]byte(`
+import { get } from "./http";
+
+// Formatting and comments do not count as changes
+const retries   =   3;
+
+export function load(id: string, force = false) {
+	return get(id);
+}
+
+class Service {
+	timeout = 10;
+	fetch() { return 2; }
+	reset() {}
+}
+
+function extra() {}
+
+console.log("ready");
+`))
+	if err != nil {
+		t.Fatalf("Failed to parse new source: %v", err)
+	}
+
+	changes := Diff(oldTree, newTree)
+
+	want := []struct {
+		kind ChangeKind
+		name string
+	}{
+		{ChangeModified, "load"},
+		{ChangeModified, "Service.fetch"},
+		{ChangeAdded, "Service.reset"},
+		{ChangeRemoved, "Service.close"},
+		{ChangeAdded, "extra"},
+		{ChangeRemoved, "unused"},
+	}
+	if len(changes) != len(want) {
+		for _, c := range changes {
+			t.Logf("%s %s (%s)", c.Kind, c.Name, c.SyntaxKind)
+		}
+		t.Fatalf("Diff() returned %d changes, want %d", len(changes), len(want))
+	}
+	for i, w := range want {
+		if changes[i].Kind != w.kind || changes[i].Name != w.name {
+			t.Errorf("change %d = %s %q, want %s %q", i, changes[i].Kind, changes[i].Name, w.kind, w.name)
+		}
+	}
+
+	load := changes[0]
+	if load.SyntaxKind != "function_declaration" {
+		t.Errorf("load SyntaxKind = %q, want function_declaration", load.SyntaxKind)
+	}
+	if load.Old == nil || load.New == nil || load.OldRange.Start.Line != 5 || load.NewRange.Start.Line != 6 {
+		t.Errorf("load ranges = %d/%d, want 5/6", load.OldRange.Start.Line, load.NewRange.Start.Line)
+	}
+	if removed := changes[5]; removed.New != nil || removed.Old == nil {
+		t.Errorf("removed change should only have an old node")
+	}
+}
+
+func TestDiffStatements(t *testing.T) {
+	parser, err := New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+	defer parser.Close()
+
+	oldTree, _ := parser.ParseTree([]byte(`if (a) { run(); }
+start();`))
+	newTree, _ := parser.ParseTree([]byte(`start();
+if (a) { run(true); }`))
+
+	changes := Diff(oldTree, newTree)
+	if len(changes) != 1 || changes[0].Kind != ChangeModified || changes[0].SyntaxKind != "if_statement" {
+		t.Fatalf("Diff() = %+v, want a single modified if_statement", changes)
+	}
+
+	if changes := Diff(oldTree, oldTree); len(changes) != 0 {
+		t.Errorf("Diff() of identical trees returned %d changes", len(changes))
+	}
+}
+
+func TestDiffBreaking(t *testing.T) {
+	parser, err := New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+	defer parser.Close()
+
+	oldTree, _ := parser.ParseTree([]byte(`export function load(id: string): User {
+	return get(id);
+}
+export function save(user: User) { put(user); }
+export function drop() {}
+function helper(a) {}
+const limit: number = 10, retries = 3;
+export { limit };
+export const parse = (text: string) => JSON.parse(text);
+export interface User {
+	id: string;
+}
+export class Store {
+	private cache = new Map();
+	get(id: string) {}
+}
+`))
+	newTree, _ := parser.ParseTree([]byte(`export function load(id: number): User {
+	return get(id);
+}
+export function save(user: User) { put(user, true); }
+function helper(a, b) {}
+const limit: number = 20, retries = 3;
+export { limit };
+export const parse = (text: string, strict?: boolean) => JSON.parse(text);
+export function added() {}
+export interface User {
+	id: string;
+	name: string;
+	email?: string;
+}
+export class Store {
+	private cache = new WeakMap();
+	get(id: string) { return 1; }
+}
+`))
+
+	var got []string
+	for _, c := range Diff(oldTree, newTree) {
+		got = append(got, fmt.Sprintf("%s %s exported=%t signature=%t breaking=%t", c.Kind, c.Name, c.Exported(), c.SignatureChanged(), c.Breaking()))
+	}
+	want := []string{
+		"modified load exported=true signature=true breaking=true",
+		"modified save exported=true signature=false breaking=false",
+		"modified helper exported=false signature=true breaking=false",
+		"modified limit,retries exported=true signature=false breaking=false",
+		"modified parse exported=true signature=true breaking=true",
+		"added added exported=true signature=false breaking=false",
+		"added User.name exported=true signature=false breaking=true",
+		"added User.email exported=true signature=false breaking=false",
+		"modified Store.cache exported=false signature=false breaking=false",
+		"modified Store.get exported=true signature=false breaking=false",
+		"removed drop exported=true signature=false breaking=true",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Diff() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	for source, want := range map[string]string{
+		"export async function load(id: string,\n  force = false): Promise<User> { return get(id); }": "export async function load(id: string, force = false): Promise<User>",
+		"const a = 1, b: string = '';":                              "const a, b: string;",
+		"class Store<T> extends Base implements Cache { get() {} }": "class Store<T> extends Base implements Cache",
+		"type Id = string | number;":                                "type Id = string | number;",
+		"const f = (x: number): number => x * 2;":                   "const f = (x: number): number;",
+	} {
+		tree, _ := parser.ParseTree([]byte(source))
+		if got := Signature(tree.Root.Children()[0]); got != want {
+			t.Errorf("Signature(%q) = %q, want %q", source, got, want)
+		}
+	}
+}
@@ -0,0 +1,199 @@
+// Package docparser parses JSDoc/TSDoc comment blocks.
+package docparser
+
+import (
+	"strings"
+)
+
+// Doc represents a parsed /** ... */ documentation comment.
+type Doc struct {
+	// Summary is the first paragraph of the description.
+	Summary string
+
+	// Description is the full free text preceding the first block tag.
+	Description string
+
+	Params     []*Param
+	Returns    *Returns
+	Deprecated *Deprecated
+	Examples   []string
+
+	// Tags holds every block tag in source order, including the ones
+	// decoded into the fields above.
+	Tags []*Tag
+}
+
+// Param represents a @param tag.
+type Param struct {
+	Name        string
+	Type        string
+	Description string
+	IsOptional  bool
+	Default     string
+}
+
+// Returns represents a @returns (or @return) tag.
+type Returns struct {
+	Type        string
+	Description string
+}
+
+// Deprecated represents a @deprecated tag.
+type Deprecated struct {
+	Message string
+}
+
+// Tag represents a raw block tag such as `@since 1.2`.
+type Tag struct {
+	Name string // without the leading @
+	Text string
+}
+
+// IsDocComment checks if a comment is a documentation comment (/** ... */).
+func IsDocComment(comment string) bool {
+	return strings.HasPrefix(comment, "/**") && !strings.HasPrefix(comment, "/**/") &&
+		strings.HasSuffix(comment, "*/")
+}
+
+// Parse parses a documentation comment, including its /** and */
+// delimiters. It returns nil if comment is not a documentation comment.
+func Parse(comment string) *Doc {
+	if !IsDocComment(comment) {
+		return nil
+	}
+
+	doc := &Doc{}
+
+	var description []string
+	var current *Tag
+	for _, line := range commentLines(comment) {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "@") {
+			name, text, _ := strings.Cut(trimmed[1:], " ")
+			current = &Tag{Name: name, Text: strings.TrimSpace(text)}
+			doc.Tags = append(doc.Tags, current)
+			continue
+		}
+
+		if current == nil {
+			description = append(description, trimmed)
+		} else if current.Name == "example" {
+			// Examples keep their indentation
+			current.Text += "\n" + line
+		} else {
+			current.Text = strings.TrimSpace(current.Text + " " + trimmed)
+		}
+	}
+
+	doc.Description = strings.TrimSpace(strings.Join(description, "\n"))
+	doc.Summary = firstParagraph(doc.Description)
+
+	for _, tag := range doc.Tags {
+		tag.Text = strings.TrimRight(tag.Text, " \n")
+		switch tag.Name {
+		case "param", "arg", "argument":
+			doc.Params = append(doc.Params, parseParam(tag.Text))
+		case "returns", "return":
+			typ, rest := cutType(tag.Text)
+			doc.Returns = &Returns{Type: typ, Description: trimDash(rest)}
+		case "deprecated":
+			doc.Deprecated = &Deprecated{Message: tag.Text}
+		case "example":
+			doc.Examples = append(doc.Examples, strings.Trim(tag.Text, "\n"))
+		}
+	}
+
+	return doc
+}
+
+// Param returns the @param with the given name, or nil if it is undocumented.
+func (d *Doc) Param(name string) *Param {
+	if d == nil {
+		return nil
+	}
+	for _, p := range d.Params {
+		if p.Name == name {
+			return p
+		}
+	}
+	return nil
+}
+
+// IsDeprecated checks if the documentation carries a @deprecated tag.
+func (d *Doc) IsDeprecated() bool {
+	return d != nil && d.Deprecated != nil
+}
+
+// commentLines strips the comment delimiters and the leading " * " of
+// every line.
+func commentLines(comment string) []string {
+	body := strings.TrimSuffix(strings.TrimPrefix(comment, "/**"), "*/")
+
+	lines := strings.Split(body, "\n")
+	for i, line := range lines {
+		line = strings.TrimLeft(line, " \t")
+		if strings.HasPrefix(line, "*") {
+			line = strings.TrimPrefix(line[1:], " ")
+		}
+		lines[i] = strings.TrimRight(line, " \t\r")
+	}
+	return lines
+}
+
+// firstParagraph returns the text before the first blank line.
+func firstParagraph(text string) string {
+	if idx := strings.Index(text, "\n\n"); idx >= 0 {
+		text = text[:idx]
+	}
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// parseParam parses the text of a @param tag:
+// `{type} name - description`, `[name]` or `[name=default]`.
+func parseParam(text string) *Param {
+	param := &Param{}
+	param.Type, text = cutType(text)
+
+	name, rest, _ := strings.Cut(text, " ")
+	if strings.HasPrefix(name, "[") && strings.HasSuffix(name, "]") {
+		param.IsOptional = true
+		name = name[1 : len(name)-1]
+		if n, def, ok := strings.Cut(name, "="); ok {
+			name, param.Default = n, def
+		}
+	}
+	param.Name = name
+	param.Description = trimDash(rest)
+
+	return param
+}
+
+// cutType splits a leading `{type}` from the tag text, handling nested
+// braces such as {{ id: number }}.
+func cutType(text string) (typ, rest string) {
+	text = strings.TrimSpace(text)
+	if !strings.HasPrefix(text, "{") {
+		return "", text
+	}
+
+	depth := 0
+	for i, r := range text {
+		switch r {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return strings.TrimSpace(text[1:i]), strings.TrimSpace(text[i+1:])
+			}
+		}
+	}
+	return "", text
+}
+
+// trimDash removes the optional "- " separator before a tag description.
+func trimDash(text string) string {
+	text = strings.TrimSpace(text)
+	text = strings.TrimPrefix(text, "-")
+	return strings.TrimSpace(text)
+}
@@ -0,0 +1,102 @@
+package docparser
+
+import (
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	doc := Parse(`/**
+	 * Fetches a user by id.
+	 * Results are cached.
+	 *
+	 * Longer explanation here.
+	 *
+	 * @param {string} id - The user id
+	 * @param {{ fresh: boolean }} [opts={}] Fetch options
+	 *   spanning two lines
+	 * @returns {Promise<User>} The user
+	 * @deprecated Use loadUser instead.
+	 * @example
+	 *   const user = await fetchUser("42");
+	 *   console.log(user.name);
+	 * @since 1.2
+	 */`)
+
+	if doc == nil {
+		t.Fatal("Parse() returned nil")
+	}
+
+	if want := "Fetches a user by id. Results are cached."; doc.Summary != want {
+		t.Errorf("Summary = %q, want %q", doc.Summary, want)
+	}
+	if want := "Fetches a user by id.\nResults are cached.\n\nLonger explanation here."; doc.Description != want {
+		t.Errorf("Description = %q, want %q", doc.Description, want)
+	}
+
+	if len(doc.Params) != 2 {
+		t.Fatalf("Params = %d, want 2", len(doc.Params))
+	}
+	if p := doc.Param("id"); p == nil || p.Type != "string" || p.Description != "The user id" || p.IsOptional {
+		t.Errorf("Param(id) = %+v", p)
+	}
+	opts := doc.Param("opts")
+	if opts == nil {
+		t.Fatal("Param(opts) = nil")
+	}
+	if opts.Type != "{ fresh: boolean }" || !opts.IsOptional || opts.Default != "{}" {
+		t.Errorf("Param(opts) = %+v", opts)
+	}
+	if opts.Description != "Fetch options spanning two lines" {
+		t.Errorf("Param(opts).Description = %q", opts.Description)
+	}
+
+	if doc.Returns == nil || doc.Returns.Type != "Promise<User>" || doc.Returns.Description != "The user" {
+		t.Errorf("Returns = %+v", doc.Returns)
+	}
+
+	if !doc.IsDeprecated() || doc.Deprecated.Message != "Use loadUser instead." {
+		t.Errorf("Deprecated = %+v", doc.Deprecated)
+	}
+
+	if len(doc.Examples) != 1 {
+		t.Fatalf("Examples = %d, want 1", len(doc.Examples))
+	}
+	if want := "  const user = await fetchUser(\"42\");\n  console.log(user.name);"; doc.Examples[0] != want {
+		t.Errorf("Examples[0] = %q, want %q", doc.Examples[0], want)
+	}
+
+	if len(doc.Tags) != 6 || doc.Tags[5].Name != "since" || doc.Tags[5].Text != "1.2" {
+		t.Errorf("Tags = %+v", doc.Tags)
+	}
+}
+
+func TestParseSingleLine(t *testing.T) {
+	doc := Parse("/** The answer. */")
+	if doc == nil || doc.Summary != "The answer." {
+		t.Errorf("Parse() = %+v, want summary %q", doc, "The answer.")
+	}
+}
+
+func TestParseNonDocComment(t *testing.T) {
+	tests := []string{
+		"// line comment",
+		"/* block comment */",
+		"/**/",
+	}
+
+	for _, comment := range tests {
+		if doc := Parse(comment); doc != nil {
+			t.Errorf("Parse(%q) = %+v, want nil", comment, doc)
+		}
+	}
+}
+
+func TestNilDoc(t *testing.T) {
+	var doc *Doc // as returned for an undocumented node
+	if p := doc.Param("id"); p != nil {
+		t.Errorf("Param() of a nil Doc = %+v, want nil", p)
+	}
+	if doc.IsDeprecated() {
+		t.Error("IsDeprecated() of a nil Doc = true")
+	}
+}
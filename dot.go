@@ -0,0 +1,168 @@
+package tsgoast
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ahmadramadhannn/tsgoast/ast"
+	"github.com/ahmadramadhannn/tsgoast/internal/syntax"
+)
+
+// maxDOTLabelText is the number of characters of source text shown in a
+// DOT node label before it is truncated.
+const maxDOTLabelText = 30
+
+// declarationKinds is the set of tree-sitter kinds that declare a name.
+var declarationKinds = map[string]bool{
+	"function_declaration":           true,
+	"generator_function_declaration": true,
+	"function_signature":             true,
+	"class_declaration":              true,
+	"abstract_class_declaration":     true,
+	"interface_declaration":          true,
+	"type_alias_declaration":         true,
+	"enum_declaration":               true,
+	"internal_module":                true,
+	"module":                         true,
+	"lexical_declaration":            true,
+	"variable_declaration":           true,
+	"variable_declarator":            true,
+	"method_definition":              true,
+	"abstract_method_signature":      true,
+	"method_signature":               true,
+	"public_field_definition":        true,
+	"property_signature":             true,
+	"enum_assignment":                true,
+	"import_statement":               true,
+}
+
+// IsDeclaration reports whether node declares a name: functions, classes,
+// interfaces, type aliases, enums, namespaces, variables, class and
+// interface members, and imports. It is suitable as DOTOptions.Filter to
+// render declarations only.
+func IsDeclaration(node ast.Node) bool {
+	return node != nil && declarationKinds[node.SyntaxKind()]
+}
+
+// DOTOptions configures WriteDOT.
+type DOTOptions struct {
+	// Name is the name of the generated graph. Defaults to "ast".
+	Name string
+
+	// Filter selects the nodes to render. Nodes it rejects are omitted and
+	// their rendered descendants are connected to the nearest rendered
+	// ancestor. The root is always rendered. Nil renders every node.
+	Filter func(ast.Node) bool
+
+	// MaxDepth limits the depth of the rendered graph below the root.
+	// Zero means no limit.
+	MaxDepth int
+
+	// IncludeTokens renders anonymous tokens such as keywords and
+	// punctuation, which are omitted by default.
+	IncludeTokens bool
+
+	// ShowRanges adds the line:column range of each node to its label.
+	ShowRanges bool
+}
+
+// WriteDOT renders the syntax tree of tree as a Graphviz DOT digraph, with
+// one box per node labelled with its kind and name or text, and edges
+// labelled with the field of the parent holding the child.
+//
+// Render the output with Graphviz, e.g. `dot -Tsvg ast.dot -o ast.svg`.
+func WriteDOT(w io.Writer, tree *Tree, opts DOTOptions) error {
+	if tree == nil || tree.Root == nil {
+		return fmt.Errorf("tree has no root node")
+	}
+	name := opts.Name
+	if name == "" {
+		name = "ast"
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "digraph %s {\n", dotQuote(name))
+	fmt.Fprintln(bw, `  node [shape=box, fontname="monospace"];`)
+
+	next := 0
+	var visit func(node ast.Node, parent ast.Node, parentID, depth int)
+	visit = func(node ast.Node, parent ast.Node, parentID, depth int) {
+		id, nodeDepth := parentID, depth
+		if parent == nil || dotIncluded(node, opts) {
+			id, nodeDepth = next, depth+1
+			next++
+			fmt.Fprintf(bw, "  n%d [label=%s];\n", id, dotQuote(dotLabel(node, opts.ShowRanges)))
+			if parent != nil {
+				fmt.Fprintf(bw, "  n%d -> n%d", parentID, id)
+				if field := ast.FieldOf(node); field != "" && node.Parent() == parent {
+					fmt.Fprintf(bw, " [label=%s]", dotQuote(field))
+				}
+				fmt.Fprintln(bw, ";")
+			}
+			parent = node
+			if opts.MaxDepth > 0 && nodeDepth >= opts.MaxDepth {
+				return
+			}
+		}
+		for _, child := range node.Children() {
+			visit(child, parent, id, nodeDepth)
+		}
+	}
+	visit(tree.Root, nil, 0, -1)
+
+	fmt.Fprintln(bw, "}")
+	return bw.Flush()
+}
+
+// dotIncluded reports whether a non-root node is rendered.
+func dotIncluded(node ast.Node, opts DOTOptions) bool {
+	if !opts.IncludeTokens && syntax.IsToken(node) {
+		return false
+	}
+	return opts.Filter == nil || opts.Filter(node)
+}
+
+// dotLabel returns the label of a node: its kind, followed by its detail
+// and optionally its range.
+func dotLabel(node ast.Node, showRange bool) string {
+	label := node.SyntaxKind()
+	if detail := nodeDetail(node); detail != "" {
+		label += "\n" + detail
+	}
+	if showRange {
+		label += "\n" + formatRange(node.Range())
+	}
+	return label
+}
+
+// nodeDetail returns the declared name for declarations or the source text
+// for leaves and string literals, truncated to maxDOTLabelText characters.
+func nodeDetail(node ast.Node) string {
+	kind := node.SyntaxKind()
+	detail := ""
+	switch {
+	case declarationKinds[kind]:
+		detail = diffName(node)
+	case kind == "string", kind == "template_string":
+		detail = node.Text()
+	case len(node.Children()) == 0 && node.Text() != kind:
+		detail = node.Text()
+	}
+	if len([]rune(detail)) > maxDOTLabelText {
+		detail = string([]rune(detail)[:maxDOTLabelText]) + "…"
+	}
+	return detail
+}
+
+// formatRange formats r as 1-based line and 0-based column, e.g. "3:4-3:9".
+func formatRange(r ast.Range) string {
+	return fmt.Sprintf("%d:%d-%d:%d", r.Start.Line+1, r.Start.Column, r.End.Line+1, r.End.Column)
+}
+
+// dotQuote quotes s as a DOT string.
+func dotQuote(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", "")
+	return `"` + r.Replace(s) + `"`
+}
@@ -0,0 +1,116 @@
+package tsgoast
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWriteDOT(t *testing.T) {
+	parser, err := New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+	defer parser.Close()
+
+	tree, err := parser.ParseTree([]byte(`import { a } from "./a";
+class Service {
+	fetch(id: string) { return "say \"hi\""; }
+}
+const x = 1;
+`))
+	if err != nil {
+		t.Fatalf("ParseTree() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		opts     DOTOptions
+		contains []string
+		excludes []string
+		nodes    int
+	}{
+		{
+			name: "full tree",
+			opts: DOTOptions{},
+			contains: []string{
+				`digraph "ast" {`,
+				`[label="class_declaration\nService"]`,
+				`[label="name"]`,
+				`[label="string\n\"say \\\"hi\\\"\""]`,
+			},
+			excludes: []string{`[label="{"]`, `[label="class"]`},
+		},
+		{
+			name:     "tokens",
+			opts:     DOTOptions{IncludeTokens: true},
+			contains: []string{`[label="{"]`, `[label="class"]`},
+		},
+		{
+			name: "declarations only",
+			opts: DOTOptions{Name: "decls", Filter: IsDeclaration, ShowRanges: true},
+			contains: []string{
+				`digraph "decls" {`,
+				`[label="import_statement\n1:0-1:24"]`,
+				`[label="class_declaration\nService\n2:0-4:1"]`,
+				`[label="method_definition\nfetch\n3:1-3:43"]`,
+				`[label="variable_declarator\nx\n5:6-5:11"]`,
+				"n0 -> n2;",
+				"n2 -> n3;",
+			},
+			excludes: []string{"identifier", "statement_block"},
+			nodes:    6,
+		},
+		{
+			name:     "max depth",
+			opts:     DOTOptions{MaxDepth: 1},
+			contains: []string{`[label="program"]`, `[label="class_declaration\nService"]`},
+			excludes: []string{"class_body"},
+			nodes:    4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := WriteDOT(&buf, tree, tt.opts); err != nil {
+				t.Fatalf("WriteDOT() error = %v", err)
+			}
+			out := buf.String()
+			for _, want := range tt.contains {
+				if !strings.Contains(out, want) {
+					t.Errorf("output missing %s:\n%s", want, out)
+				}
+			}
+			for _, unwanted := range tt.excludes {
+				if strings.Contains(out, unwanted) {
+					t.Errorf("output contains %s:\n%s", unwanted, out)
+				}
+			}
+			if !strings.HasSuffix(out, "}\n") {
+				t.Errorf("output is not terminated:\n%s", out)
+			}
+			if tt.nodes > 0 {
+				if got := countDOTNodes(out); got != tt.nodes {
+					t.Errorf("nodes = %d, want %d:\n%s", got, tt.nodes, out)
+				}
+			}
+		})
+	}
+
+	if err := WriteDOT(&bytes.Buffer{}, nil, DOTOptions{}); err == nil {
+		t.Error("WriteDOT(nil) error = nil, want an error")
+	}
+}
+
+// countDOTNodes counts node statements in DOT output.
+func countDOTNodes(out string) int {
+	count := 0
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "n") && !strings.HasPrefix(line, "node") && !strings.Contains(line, "->") {
+			count++
+		}
+	}
+	return count
+}
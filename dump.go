@@ -0,0 +1,124 @@
+package tsgoast
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/ahmadramadhannn/tsgoast/ast"
+	"github.com/ahmadramadhannn/tsgoast/internal/syntax"
+)
+
+// DumpOptions configures Tree.Dump.
+type DumpOptions struct {
+	// Kinds restricts the output to nodes of the given tree-sitter kinds.
+	// Empty means all kinds.
+	Kinds []string
+
+	// Filter selects the nodes to print, in addition to Kinds. Nil prints
+	// every node.
+	Filter func(ast.Node) bool
+
+	// MaxDepth limits the depth of the printed tree below the root.
+	// Zero means no limit.
+	MaxDepth int
+
+	// IncludeTokens prints anonymous tokens such as keywords and
+	// punctuation, which are omitted by default.
+	IncludeTokens bool
+
+	// ShowRanges appends the line:column range of each node.
+	ShowRanges bool
+}
+
+// Dump writes the syntax tree as an indented outline, one node per line,
+// showing the parent field holding the node, its kind, and its declared name
+// or source text:
+//
+//	program
+//	└── class_declaration Service
+//	    ├── name: type_identifier Service
+//	    └── body: class_body
+//
+// Nodes excluded by Kinds or Filter are skipped and their printed
+// descendants are shown under the nearest printed ancestor. The root is
+// always printed.
+func (t *Tree) Dump(w io.Writer, opts DumpOptions) error {
+	if t == nil || t.Root == nil {
+		return fmt.Errorf("tree has no root node")
+	}
+	return DumpNode(w, t.Root, opts)
+}
+
+// DumpNode writes the subtree rooted at node as an outline, like
+// Tree.Dump, such as to print a node found by a query.
+func DumpNode(w io.Writer, node ast.Node, opts DumpOptions) error {
+	if node == nil {
+		return fmt.Errorf("no node to dump")
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, dumpLine(node, nil, opts))
+
+	var visit func(node ast.Node, prefix string, depth int)
+	visit = func(node ast.Node, prefix string, depth int) {
+		if opts.MaxDepth > 0 && depth >= opts.MaxDepth {
+			return
+		}
+		children := dumpChildren(node, opts)
+		for i, child := range children {
+			branch, indent := "├── ", "│   "
+			if i == len(children)-1 {
+				branch, indent = "└── ", "    "
+			}
+			fmt.Fprintf(bw, "%s%s%s\n", prefix, branch, dumpLine(child, node, opts))
+			visit(child, prefix+indent, depth+1)
+		}
+	}
+	visit(node, "", 0)
+
+	return bw.Flush()
+}
+
+// dumpChildren returns the printed nodes directly below node, descending
+// through nodes that are not printed.
+func dumpChildren(node ast.Node, opts DumpOptions) []ast.Node {
+	var children []ast.Node
+	for _, child := range node.Children() {
+		if !opts.IncludeTokens && syntax.IsToken(child) {
+			continue
+		}
+		if dumpIncluded(child, opts) {
+			children = append(children, child)
+		} else {
+			children = append(children, dumpChildren(child, opts)...)
+		}
+	}
+	return children
+}
+
+// dumpIncluded reports whether a node passes the kind and filter options.
+func dumpIncluded(node ast.Node, opts DumpOptions) bool {
+	if len(opts.Kinds) > 0 && !slices.Contains(opts.Kinds, node.SyntaxKind()) {
+		return false
+	}
+	return opts.Filter == nil || opts.Filter(node)
+}
+
+// dumpLine returns the line printed for node below the printed node parent.
+func dumpLine(node, parent ast.Node, opts DumpOptions) string {
+	var b strings.Builder
+	if field := ast.FieldOf(node); field != "" && parent != nil && node.Parent() == parent {
+		b.WriteString(field + ": ")
+	}
+	b.WriteString(node.SyntaxKind())
+	if detail := nodeDetail(node); detail != "" {
+		b.WriteString(" " + strings.NewReplacer("\r", `\r`, "\n", `\n`, "\t", `\t`).Replace(detail))
+	}
+	if opts.ShowRanges {
+		b.WriteString(" [" + formatRange(node.Range()) + "]")
+	}
+	return b.String()
+}
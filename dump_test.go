@@ -0,0 +1,105 @@
+package tsgoast
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTreeDump(t *testing.T) {
+	parser, err := New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+	defer parser.Close()
+
+	tree, err := parser.ParseTree([]byte(`class Service {
+	fetch(id: string) { return "ok"; }
+}
+const x = 1;
+`))
+	if err != nil {
+		t.Fatalf("ParseTree() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		opts DumpOptions
+		want string
+	}{
+		{
+			name: "depth",
+			opts: DumpOptions{MaxDepth: 2},
+			want: `program
+├── class_declaration Service
+│   ├── name: type_identifier Service
+│   └── body: class_body
+└── lexical_declaration x
+    └── variable_declarator x
+`,
+		},
+		{
+			name: "kinds",
+			opts: DumpOptions{Kinds: []string{"class_declaration", "method_definition", "string"}},
+			want: `program
+└── class_declaration Service
+    └── method_definition fetch
+        └── string "ok"
+`,
+		},
+		{
+			name: "filter with ranges",
+			opts: DumpOptions{Filter: IsDeclaration, ShowRanges: true},
+			want: `program [1:0-5:0]
+├── class_declaration Service [1:0-3:1]
+│   └── method_definition fetch [2:1-2:35]
+└── lexical_declaration x [4:0-4:12]
+    └── variable_declarator x [4:6-4:11]
+`,
+		},
+		{
+			name: "tokens",
+			opts: DumpOptions{IncludeTokens: true, MaxDepth: 2},
+			want: `program
+├── class_declaration Service
+│   ├── class
+│   ├── name: type_identifier Service
+│   └── body: class_body
+└── lexical_declaration x
+    ├── kind: const
+    ├── variable_declarator x
+    └── ;
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out strings.Builder
+			if err := tree.Dump(&out, tt.opts); err != nil {
+				t.Fatalf("Dump() error = %v", err)
+			}
+			if got := out.String(); got != tt.want {
+				t.Errorf("Dump() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+
+	if err := (&Tree{}).Dump(&strings.Builder{}, DumpOptions{}); err == nil {
+		t.Error("Dump() on empty tree: expected error")
+	}
+
+	// DumpNode prints the subtree of any node
+	var out strings.Builder
+	method := tree.Root.Children()[0].Children()[2].Children()[1]
+	if err := DumpNode(&out, method, DumpOptions{MaxDepth: 1}); err != nil {
+		t.Fatalf("DumpNode() error = %v", err)
+	}
+	want := `method_definition fetch
+├── name: property_identifier fetch
+├── parameters: formal_parameters
+└── body: statement_block
+`
+	if out.String() != want {
+		t.Errorf("DumpNode() =\n%s\nwant\n%s", out.String(), want)
+	}
+}
@@ -0,0 +1,140 @@
+// Package edits applies text edits to source files. An Edit replaces the
+// text of a byte range of the original source; a Set collects the edits of
+// one file, for example from a rename and a codemod, rejects edits that
+// overlap, and applies them all at once:
+//
+//	var set edits.Set
+//	if err := set.Add(edits.Replace(node, "total")); err != nil {
+//		...
+//	}
+//	out, err := set.Apply(source)
+//
+// Edits are addressed by the offsets of the original source, so they can
+// be computed independently from the same tree and applied in any order.
+package edits
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+// Edit replaces the source text in Range with NewText. An empty range
+// inserts NewText; an empty NewText deletes the range.
+type Edit struct {
+	Range   ast.Range
+	NewText string
+}
+
+// Replace returns the edit replacing the text of node with text.
+func Replace(node ast.Node, text string) Edit {
+	return Edit{Range: node.Range(), NewText: text}
+}
+
+// Delete returns the edit deleting the text of node.
+func Delete(node ast.Node) Edit {
+	return Edit{Range: node.Range()}
+}
+
+// InsertBefore returns the edit inserting text right before node.
+func InsertBefore(node ast.Node, text string) Edit {
+	start := node.Range().Start
+	return Edit{Range: ast.Range{Start: start, End: start}, NewText: text}
+}
+
+// InsertAfter returns the edit inserting text right after node.
+func InsertAfter(node ast.Node, text string) Edit {
+	end := node.Range().End
+	return Edit{Range: ast.Range{Start: end, End: end}, NewText: text}
+}
+
+func (e Edit) start() uint32 { return e.Range.Start.Offset }
+func (e Edit) end() uint32   { return e.Range.End.Offset }
+
+// String formats the edit for error messages and debugging.
+func (e Edit) String() string {
+	return fmt.Sprintf("%d:%d-%d:%d %q",
+		e.Range.Start.Line+1, e.Range.Start.Column+1, e.Range.End.Line+1, e.Range.End.Column+1, e.NewText)
+}
+
+// overlaps reports whether two sorted edits cannot both be applied: their
+// ranges share text, or both insert at the same offset, which leaves the
+// order of the insertions undefined.
+func overlaps(a, b Edit) bool {
+	return b.start() < a.end() || b.start() == a.start() && a.end() == a.start() && b.end() == b.start()
+}
+
+// compare orders edits by offset, insertions before replacements at the
+// same offset.
+func compare(a, b Edit) int {
+	if a.start() != b.start() {
+		return int(a.start()) - int(b.start())
+	}
+	return int(a.end()) - int(b.end())
+}
+
+// Set is a collection of non-overlapping edits of one source, kept sorted
+// by offset. The zero value is an empty set.
+type Set struct {
+	edits []Edit
+}
+
+// Add adds edits to the set. It fails, adding none of them, if an edit has
+// an invalid range or overlaps another edit of the set or of edits.
+// Identical edits are only kept once.
+func (s *Set) Add(edits ...Edit) error {
+	merged := slices.Clone(s.edits)
+	for _, e := range edits {
+		if e.end() < e.start() {
+			return fmt.Errorf("edits: invalid range in edit %s", e)
+		}
+		i, _ := slices.BinarySearchFunc(merged, e, compare)
+		if i < len(merged) && merged[i] == e {
+			continue
+		}
+		if i > 0 && overlaps(merged[i-1], e) {
+			return fmt.Errorf("edits: edit %s overlaps %s", e, merged[i-1])
+		}
+		if i < len(merged) && overlaps(e, merged[i]) {
+			return fmt.Errorf("edits: edit %s overlaps %s", e, merged[i])
+		}
+		merged = slices.Insert(merged, i, e)
+	}
+	s.edits = merged
+	return nil
+}
+
+// Len returns the number of edits in the set.
+func (s *Set) Len() int {
+	return len(s.edits)
+}
+
+// Edits returns the edits of the set, sorted by offset.
+func (s *Set) Edits() []Edit {
+	return slices.Clone(s.edits)
+}
+
+// Apply returns source with the edits of the set applied.
+func (s *Set) Apply(source []byte) ([]byte, error) {
+	out := make([]byte, 0, len(source))
+	pos := uint32(0)
+	for _, e := range s.edits {
+		if int(e.end()) > len(source) {
+			return nil, fmt.Errorf("edits: edit %s is out of range", e)
+		}
+		out = append(out, source[pos:e.start()]...)
+		out = append(out, e.NewText...)
+		pos = e.end()
+	}
+	return append(out, source[pos:]...), nil
+}
+
+// Apply returns source with edits applied. The edits must not overlap.
+func Apply(source []byte, edits []Edit) ([]byte, error) {
+	var s Set
+	if err := s.Add(edits...); err != nil {
+		return nil, err
+	}
+	return s.Apply(source)
+}
@@ -0,0 +1,92 @@
+package edits
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ahmadramadhannn/tsgoast"
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+func at(start, end uint32) ast.Range {
+	return ast.Range{Start: ast.Position{Offset: start}, End: ast.Position{Offset: end}}
+}
+
+func TestApply(t *testing.T) {
+	out, err := Apply([]byte("abcdef"), []Edit{
+		{at(4, 6), "Z"},
+		{at(0, 1), "A"},
+		{at(2, 2), "+"},
+		{at(2, 3), "C"},
+	})
+	if err != nil || string(out) != "Ab+CdZ" {
+		t.Errorf("Apply() = %q, %v", out, err)
+	}
+}
+
+func TestSetAdd(t *testing.T) {
+	tests := []struct {
+		name  string
+		edits []Edit
+		want  string // error substring, or empty
+	}{
+		{"disjoint", []Edit{{at(0, 2), "x"}, {at(2, 4), "y"}}, ""},
+		{"identical", []Edit{{at(0, 2), "x"}, {at(0, 2), "x"}}, ""},
+		{"overlapping", []Edit{{at(0, 3), "x"}, {at(2, 4), "y"}}, "overlaps"},
+		{"nested", []Edit{{at(0, 6), "x"}, {at(2, 3), "y"}}, "overlaps"},
+		{"insertion inside", []Edit{{at(0, 6), "x"}, {at(3, 3), "y"}}, "overlaps"},
+		{"same insertion point", []Edit{{at(3, 3), "x"}, {at(3, 3), "y"}}, "overlaps"},
+		{"invalid range", []Edit{{at(3, 1), "x"}}, "invalid range"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var s Set
+			err := s.Add(tt.edits...)
+			switch {
+			case tt.want == "" && err != nil:
+				t.Errorf("Add() error = %v", err)
+			case tt.want != "" && (err == nil || !strings.Contains(err.Error(), tt.want)):
+				t.Errorf("Add() error = %v, want %q", err, tt.want)
+			case tt.want != "" && s.Len() != 0:
+				t.Errorf("Len() = %d after failed Add, want 0", s.Len())
+			}
+		})
+	}
+}
+
+func TestNodeEdits(t *testing.T) {
+	src := "const a = 1;\nlog(a);\n"
+	parser, err := tsgoast.New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+	defer parser.Close()
+	tree, err := parser.ParseTree([]byte(src))
+	if err != nil {
+		t.Fatalf("ParseTree() error = %v", err)
+	}
+
+	var decl, call ast.Node
+	for node := range ast.Preorder(tree.Root) {
+		switch node.SyntaxKind() {
+		case "lexical_declaration":
+			decl = node
+		case "expression_statement":
+			call = node
+		}
+	}
+
+	var s Set
+	if err := s.Add(Replace(decl, "let a = 2;"), InsertBefore(call, "// log\n"), InsertAfter(call, " // done")); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	out, err := s.Apply([]byte(src))
+	if want := "let a = 2;\n// log\nlog(a); // done\n"; err != nil || string(out) != want {
+		t.Errorf("Apply() = %q, %v, want %q", out, err, want)
+	}
+
+	if err := s.Add(Delete(decl)); err == nil {
+		t.Error("Add(Delete(decl)) after replacing it: expected error")
+	}
+}
@@ -0,0 +1,1124 @@
+// Package estree converts tsgoast syntax trees into ESTree-shaped nodes, the
+// AST format used across the JavaScript ecosystem (ESLint, acorn, espree,
+// AST explorers).
+//
+// Nodes are plain maps so they can be encoded with encoding/json and
+// consumed by tools that expect ESTree JSON. Every node carries a "type",
+// a "range" of [start, end] offsets and a "loc" with 1-based lines and
+// 0-based columns. Offsets and columns are counted in UTF-16 code units,
+// as in JavaScript.
+//
+// By default TypeScript type annotations, assertions and non-null
+// assertions are erased, since ESTree has no representation for them, and
+// type-only declarations are reported with their typescript-estree node
+// types (TSInterfaceDeclaration, TSTypeAliasDeclaration, TSEnumDeclaration,
+// TSModuleDeclaration) carrying only their name. WithTypes keeps the full
+// TypeScript syntax instead. Syntax without an ESTree counterpart is
+// reported with a type derived from its tree-sitter kind (e.g.
+// "JsxElement") and its source text in "raw".
+package estree
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/ahmadramadhannn/tsgoast/ast"
+	"github.com/ahmadramadhannn/tsgoast/internal/syntax"
+)
+
+// Node is an ESTree node.
+type Node map[string]any
+
+// Type returns the node type, such as "FunctionDeclaration".
+func (n Node) Type() string {
+	t, _ := n["type"].(string)
+	return t
+}
+
+// Convert converts the subtree rooted at root, normally a program node,
+// into an ESTree node. It returns nil if root is nil.
+func Convert(root ast.Node, opts ...Option) Node {
+	if root == nil {
+		return nil
+	}
+	c := newConverter(root)
+	for _, opt := range opts {
+		opt(c)
+	}
+	if root.SyntaxKind() == "program" {
+		return c.program(root)
+	}
+	return c.convert(root)
+}
+
+// Marshal converts root with Convert and encodes the result as JSON.
+func Marshal(root ast.Node, opts ...Option) ([]byte, error) {
+	return json.Marshal(Convert(root, opts...))
+}
+
+// converter maps tree-sitter byte offsets to UTF-16 offsets and builds
+// ESTree nodes.
+type converter struct {
+	index *ast.UTF16Index
+	types bool // keep TypeScript syntax, see WithTypes
+}
+
+// newConverter prepares offset conversion for the text of root.
+func newConverter(root ast.Node) *converter {
+	return &converter{index: ast.NewUTF16Index(root)}
+}
+
+// offset converts a byte offset to a UTF-16 offset.
+func (c *converter) offset(byteOffset uint32) uint32 {
+	return c.index.Offset(byteOffset)
+}
+
+// position converts a tree-sitter position to an ESTree position.
+func (c *converter) position(p ast.Position) map[string]any {
+	return map[string]any{"line": p.Line + 1, "column": c.index.Column(p)}
+}
+
+// node creates an ESTree node of the given type spanning src.
+func (c *converter) node(typ string, src ast.Node) Node {
+	r := src.Range()
+	return Node{
+		"type":  typ,
+		"range": []uint32{c.offset(r.Start.Offset), c.offset(r.End.Offset)},
+		"loc": map[string]any{
+			"start": c.position(r.Start),
+			"end":   c.position(r.End),
+		},
+	}
+}
+
+// program converts the root node, collecting its comments.
+func (c *converter) program(n ast.Node) Node {
+	out := c.node("Program", n)
+	out["sourceType"] = "module"
+	out["body"] = c.statements(n)
+
+	comments := []Node{}
+	for node := range ast.Preorder(n) {
+		if node.SyntaxKind() != "comment" {
+			continue
+		}
+		text := node.Text()
+		var comment Node
+		if strings.HasPrefix(text, "//") {
+			comment = c.node("Line", node)
+			comment["value"] = text[2:]
+		} else {
+			comment = c.node("Block", node)
+			comment["value"] = strings.TrimSuffix(strings.TrimPrefix(text, "/*"), "*/")
+		}
+		comments = append(comments, comment)
+	}
+	out["comments"] = comments
+	return out
+}
+
+// statements converts the statement children of a program or block.
+func (c *converter) statements(n ast.Node) []Node {
+	body := []Node{}
+	for _, child := range syntax.Named(n) {
+		body = append(body, c.convert(child))
+	}
+	return body
+}
+
+// convert converts a statement, declaration, expression or pattern.
+func (c *converter) convert(n ast.Node) Node {
+	if n == nil {
+		return nil
+	}
+
+	switch n.SyntaxKind() {
+	// Statements
+	case "program":
+		return c.program(n)
+	case "expression_statement":
+		out := c.node("ExpressionStatement", n)
+		out["expression"] = c.convert(syntax.FirstNamed(n))
+		return out
+	case "statement_block":
+		out := c.node("BlockStatement", n)
+		out["body"] = c.statements(n)
+		return out
+	case "empty_statement":
+		return c.node("EmptyStatement", n)
+	case "debugger_statement":
+		return c.node("DebuggerStatement", n)
+	case "lexical_declaration", "variable_declaration":
+		return c.variableDeclaration(n)
+	case "return_statement", "throw_statement":
+		out := c.node(map[string]string{
+			"return_statement": "ReturnStatement",
+			"throw_statement":  "ThrowStatement",
+		}[n.SyntaxKind()], n)
+		out["argument"] = c.convert(syntax.FirstNamed(n))
+		return out
+	case "if_statement":
+		out := c.node("IfStatement", n)
+		out["test"] = c.convert(ast.ChildByField(n, "condition"))
+		out["consequent"] = c.convert(ast.ChildByField(n, "consequence"))
+		out["alternate"] = nil
+		if alt := ast.ChildByField(n, "alternative"); alt != nil {
+			out["alternate"] = c.convert(syntax.FirstNamed(alt))
+		}
+		return out
+	case "for_statement":
+		return c.forStatement(n)
+	case "for_in_statement":
+		return c.forInStatement(n)
+	case "while_statement":
+		out := c.node("WhileStatement", n)
+		out["test"] = c.convert(ast.ChildByField(n, "condition"))
+		out["body"] = c.convert(ast.ChildByField(n, "body"))
+		return out
+	case "do_statement":
+		out := c.node("DoWhileStatement", n)
+		out["body"] = c.convert(ast.ChildByField(n, "body"))
+		out["test"] = c.convert(ast.ChildByField(n, "condition"))
+		return out
+	case "break_statement", "continue_statement":
+		out := c.node(map[string]string{
+			"break_statement":    "BreakStatement",
+			"continue_statement": "ContinueStatement",
+		}[n.SyntaxKind()], n)
+		out["label"] = c.convert(ast.ChildByField(n, "label"))
+		return out
+	case "labeled_statement":
+		out := c.node("LabeledStatement", n)
+		out["label"] = c.convert(ast.ChildByField(n, "label"))
+		out["body"] = c.convert(ast.ChildByField(n, "body"))
+		return out
+	case "try_statement":
+		return c.tryStatement(n)
+	case "switch_statement":
+		return c.switchStatement(n)
+
+	// Declarations
+	case "function_declaration", "generator_function_declaration":
+		return c.function("FunctionDeclaration", n)
+	case "function_signature":
+		return c.function("TSDeclareFunction", n)
+	case "function_expression", "function", "generator_function":
+		return c.function("FunctionExpression", n)
+	case "arrow_function":
+		return c.arrowFunction(n)
+	case "class_declaration", "abstract_class_declaration":
+		return c.class("ClassDeclaration", n)
+	case "class":
+		return c.class("ClassExpression", n)
+	case "import_statement":
+		return c.importDeclaration(n)
+	case "export_statement":
+		return c.exportDeclaration(n)
+	case "interface_declaration", "type_alias_declaration", "enum_declaration", "internal_module", "module":
+		typ := map[string]string{
+			"interface_declaration":  "TSInterfaceDeclaration",
+			"type_alias_declaration": "TSTypeAliasDeclaration",
+			"enum_declaration":       "TSEnumDeclaration",
+			"internal_module":        "TSModuleDeclaration",
+			"module":                 "TSModuleDeclaration",
+		}[n.SyntaxKind()]
+		if c.types {
+			return c.tsDeclaration(typ, n)
+		}
+		return c.typeDeclaration(typ, n)
+	case "ambient_declaration":
+		out := c.convert(syntax.FirstNamed(n))
+		if out != nil {
+			out["declare"] = true
+		}
+		return out
+
+	// Expressions
+	case "identifier", "property_identifier", "shorthand_property_identifier",
+		"shorthand_property_identifier_pattern", "type_identifier", "statement_identifier":
+		out := c.node("Identifier", n)
+		out["name"] = n.Text()
+		return out
+	case "undefined":
+		out := c.node("Identifier", n)
+		out["name"] = "undefined"
+		return out
+	case "private_property_identifier":
+		out := c.node("PrivateIdentifier", n)
+		out["name"] = strings.TrimPrefix(n.Text(), "#")
+		return out
+	case "this":
+		return c.node("ThisExpression", n)
+	case "super":
+		return c.node("Super", n)
+	case "string", "number", "true", "false", "null", "regex":
+		return c.literal(n)
+	case "template_string":
+		return c.templateLiteral(n)
+	case "parenthesized_expression", "template_substitution", "computed_property_name":
+		return c.convert(syntax.FirstNamed(n))
+	case "as_expression", "satisfies_expression", "non_null_expression", "type_assertion":
+		if c.types {
+			return c.tsExpression(n)
+		}
+		return c.convert(firstExpression(n))
+	case "call_expression":
+		return c.callExpression(n)
+	case "new_expression":
+		out := c.node("NewExpression", n)
+		out["callee"] = c.convert(ast.ChildByField(n, "constructor"))
+		out["arguments"] = c.arguments(ast.ChildByField(n, "arguments"))
+		if c.types {
+			out["typeArguments"] = c.typeArguments(ast.ChildByField(n, "type_arguments"))
+		}
+		return out
+	case "member_expression":
+		out := c.node("MemberExpression", n)
+		out["object"] = c.convert(ast.ChildByField(n, "object"))
+		out["property"] = c.convert(ast.ChildByField(n, "property"))
+		out["computed"] = false
+		out["optional"] = ast.FirstChildOfKind(n, "optional_chain") != nil
+		return out
+	case "subscript_expression":
+		out := c.node("MemberExpression", n)
+		out["object"] = c.convert(ast.ChildByField(n, "object"))
+		out["property"] = c.convert(ast.ChildByField(n, "index"))
+		out["computed"] = true
+		out["optional"] = ast.FirstChildOfKind(n, "optional_chain") != nil
+		return out
+	case "binary_expression":
+		operator := syntax.FieldText(n, "operator")
+		typ := "BinaryExpression"
+		if operator == "&&" || operator == "||" || operator == "??" {
+			typ = "LogicalExpression"
+		}
+		out := c.node(typ, n)
+		out["operator"] = operator
+		out["left"] = c.convert(ast.ChildByField(n, "left"))
+		out["right"] = c.convert(ast.ChildByField(n, "right"))
+		return out
+	case "unary_expression":
+		out := c.node("UnaryExpression", n)
+		out["operator"] = syntax.FieldText(n, "operator")
+		out["prefix"] = true
+		out["argument"] = c.convert(ast.ChildByField(n, "argument"))
+		return out
+	case "update_expression":
+		out := c.node("UpdateExpression", n)
+		out["operator"] = syntax.FieldText(n, "operator")
+		children := n.Children()
+		out["prefix"] = len(children) > 0 && ast.ChildByField(n, "operator") == children[0]
+		out["argument"] = c.convert(ast.ChildByField(n, "argument"))
+		return out
+	case "assignment_expression", "augmented_assignment_expression":
+		out := c.node("AssignmentExpression", n)
+		out["operator"] = "="
+		if n.SyntaxKind() == "augmented_assignment_expression" {
+			out["operator"] = syntax.FieldText(n, "operator")
+		}
+		out["left"] = c.convert(ast.ChildByField(n, "left"))
+		out["right"] = c.convert(ast.ChildByField(n, "right"))
+		return out
+	case "ternary_expression":
+		out := c.node("ConditionalExpression", n)
+		out["test"] = c.convert(ast.ChildByField(n, "condition"))
+		out["consequent"] = c.convert(ast.ChildByField(n, "consequence"))
+		out["alternate"] = c.convert(ast.ChildByField(n, "alternative"))
+		return out
+	case "await_expression":
+		out := c.node("AwaitExpression", n)
+		out["argument"] = c.convert(syntax.FirstNamed(n))
+		return out
+	case "yield_expression":
+		out := c.node("YieldExpression", n)
+		out["argument"] = c.convert(syntax.FirstNamed(n))
+		out["delegate"] = syntax.HasToken(n, "*")
+		return out
+	case "sequence_expression":
+		out := c.node("SequenceExpression", n)
+		out["expressions"] = c.sequence(n, nil)
+		return out
+	case "spread_element":
+		out := c.node("SpreadElement", n)
+		out["argument"] = c.convert(syntax.FirstNamed(n))
+		return out
+	case "object":
+		return c.object("ObjectExpression", n)
+	case "array":
+		return c.array("ArrayExpression", n)
+
+	// Patterns
+	case "object_pattern":
+		return c.object("ObjectPattern", n)
+	case "array_pattern":
+		return c.array("ArrayPattern", n)
+	case "rest_pattern":
+		out := c.node("RestElement", n)
+		out["argument"] = c.convert(syntax.FirstNamed(n))
+		return out
+	case "assignment_pattern":
+		out := c.node("AssignmentPattern", n)
+		out["left"] = c.convert(ast.ChildByField(n, "left"))
+		out["right"] = c.convert(ast.ChildByField(n, "right"))
+		return out
+	}
+
+	out := c.node(syntax.PascalCase(n.SyntaxKind()), n)
+	out["raw"] = n.Text()
+	return out
+}
+
+// variableDeclaration converts a var, let or const declaration.
+func (c *converter) variableDeclaration(n ast.Node) Node {
+	out := c.node("VariableDeclaration", n)
+	out["kind"] = "var"
+	if kind := ast.ChildByField(n, "kind"); kind != nil {
+		out["kind"] = kind.Text()
+	}
+	declarations := []Node{}
+	for _, declarator := range ast.ChildrenOfKind(n, "variable_declarator") {
+		d := c.node("VariableDeclarator", declarator)
+		d["id"] = c.convert(ast.ChildByField(declarator, "name"))
+		c.annotate(d["id"].(Node), declarator)
+		d["init"] = c.convert(ast.ChildByField(declarator, "value"))
+		declarations = append(declarations, d)
+	}
+	out["declarations"] = declarations
+	return out
+}
+
+// forStatement converts a C-style for loop.
+func (c *converter) forStatement(n ast.Node) Node {
+	out := c.node("ForStatement", n)
+	clause := func(field string) Node {
+		for _, child := range ast.ChildrenByField(n, field) {
+			switch child.SyntaxKind() {
+			case "empty_statement", ";":
+				continue
+			case "expression_statement":
+				return c.convert(syntax.FirstNamed(child))
+			}
+			return c.convert(child)
+		}
+		return nil
+	}
+	out["init"] = clause("initializer")
+	out["test"] = clause("condition")
+	out["update"] = clause("increment")
+	out["body"] = c.convert(ast.ChildByField(n, "body"))
+	return out
+}
+
+// forInStatement converts a for-in or for-of loop.
+func (c *converter) forInStatement(n ast.Node) Node {
+	typ := "ForInStatement"
+	if syntax.FieldText(n, "operator") == "of" {
+		typ = "ForOfStatement"
+	}
+	out := c.node(typ, n)
+
+	left := c.convert(ast.ChildByField(n, "left"))
+	if kind := ast.ChildByField(n, "kind"); kind != nil {
+		leftNode := ast.ChildByField(n, "left")
+		declarator := c.node("VariableDeclarator", leftNode)
+		declarator["id"] = left
+		declarator["init"] = nil
+
+		declaration := c.node("VariableDeclaration", leftNode)
+		declaration["range"] = []uint32{c.offset(kind.Range().Start.Offset), c.offset(leftNode.Range().End.Offset)}
+		declaration["loc"] = map[string]any{
+			"start": c.position(kind.Range().Start),
+			"end":   c.position(leftNode.Range().End),
+		}
+		declaration["kind"] = kind.Text()
+		declaration["declarations"] = []Node{declarator}
+		left = declaration
+	}
+	out["left"] = left
+	out["right"] = c.convert(ast.ChildByField(n, "right"))
+	out["body"] = c.convert(ast.ChildByField(n, "body"))
+	if typ == "ForOfStatement" {
+		out["await"] = syntax.HasToken(n, "await")
+	}
+	return out
+}
+
+// tryStatement converts a try statement with its catch and finally clauses.
+func (c *converter) tryStatement(n ast.Node) Node {
+	out := c.node("TryStatement", n)
+	out["block"] = c.convert(ast.ChildByField(n, "body"))
+	out["handler"] = nil
+	if handler := ast.ChildByField(n, "handler"); handler != nil {
+		h := c.node("CatchClause", handler)
+		h["param"] = c.convert(ast.ChildByField(handler, "parameter"))
+		h["body"] = c.convert(ast.ChildByField(handler, "body"))
+		out["handler"] = h
+	}
+	out["finalizer"] = nil
+	if finalizer := ast.ChildByField(n, "finalizer"); finalizer != nil {
+		out["finalizer"] = c.convert(ast.ChildByField(finalizer, "body"))
+	}
+	return out
+}
+
+// switchStatement converts a switch statement and its cases.
+func (c *converter) switchStatement(n ast.Node) Node {
+	out := c.node("SwitchStatement", n)
+	out["discriminant"] = c.convert(ast.ChildByField(n, "value"))
+	cases := []Node{}
+	if body := ast.ChildByField(n, "body"); body != nil {
+		for _, clause := range syntax.Named(body) {
+			if clause.SyntaxKind() != "switch_case" && clause.SyntaxKind() != "switch_default" {
+				continue
+			}
+			sc := c.node("SwitchCase", clause)
+			sc["test"] = c.convert(ast.ChildByField(clause, "value"))
+			consequent := []Node{}
+			for _, stmt := range ast.ChildrenByField(clause, "body") {
+				if stmt.SyntaxKind() != "comment" {
+					consequent = append(consequent, c.convert(stmt))
+				}
+			}
+			sc["consequent"] = consequent
+			cases = append(cases, sc)
+		}
+	}
+	out["cases"] = cases
+	return out
+}
+
+// function converts a function declaration or expression. For methods, n
+// is the method_definition and the function spans the whole method.
+func (c *converter) function(typ string, n ast.Node) Node {
+	out := c.node(typ, n)
+	out["id"] = nil
+	switch n.SyntaxKind() {
+	case "method_definition", "abstract_method_signature", "method_signature":
+	default:
+		out["id"] = c.convert(ast.ChildByField(n, "name"))
+	}
+	out["params"] = c.params(ast.ChildByField(n, "parameters"))
+	out["body"] = c.convert(ast.ChildByField(n, "body"))
+	out["async"] = syntax.HasToken(n, "async")
+	out["generator"] = syntax.HasToken(n, "*")
+	out["expression"] = false
+	c.functionTypes(out, n)
+	return out
+}
+
+// functionTypes adds the return type and type parameters of a function
+// when types are kept.
+func (c *converter) functionTypes(out Node, n ast.Node) {
+	if !c.types {
+		return
+	}
+	out["returnType"] = c.typeAnnotation(ast.ChildByField(n, "return_type"))
+	out["typeParameters"] = c.typeParameters(ast.ChildByField(n, "type_parameters"))
+}
+
+// arrowFunction converts an arrow function.
+func (c *converter) arrowFunction(n ast.Node) Node {
+	out := c.node("ArrowFunctionExpression", n)
+	out["id"] = nil
+	if param := ast.ChildByField(n, "parameter"); param != nil {
+		out["params"] = []Node{c.convert(param)}
+	} else {
+		out["params"] = c.params(ast.ChildByField(n, "parameters"))
+	}
+	body := ast.ChildByField(n, "body")
+	out["body"] = c.convert(body)
+	out["async"] = syntax.HasToken(n, "async")
+	out["generator"] = false
+	out["expression"] = body != nil && body.SyntaxKind() != "statement_block"
+	c.functionTypes(out, n)
+	return out
+}
+
+// params converts formal parameters to patterns, dropping their types.
+func (c *converter) params(n ast.Node) []Node {
+	params := []Node{}
+	if n == nil {
+		return params
+	}
+	for _, param := range syntax.Named(n) {
+		if this := ast.ChildByField(param, "pattern"); this != nil && this.SyntaxKind() == "this" && !c.types {
+			// `this` parameters only exist in the type system
+			continue
+		}
+		switch param.SyntaxKind() {
+		case "required_parameter", "optional_parameter":
+			params = append(params, c.param(param))
+		case "this_type", "decorator":
+			continue
+		default:
+			params = append(params, c.convert(param))
+		}
+	}
+	return params
+}
+
+// param converts a required or optional parameter to a pattern.
+func (c *converter) param(n ast.Node) Node {
+	patternNode := ast.ChildByField(n, "pattern")
+	var pattern Node
+	if patternNode != nil && patternNode.SyntaxKind() == "this" {
+		pattern = c.identifier(patternNode)
+	} else {
+		pattern = c.convert(patternNode)
+	}
+	if pattern == nil {
+		return nil
+	}
+	c.annotate(pattern, n)
+	if n.SyntaxKind() == "optional_parameter" {
+		pattern["optional"] = true
+	}
+
+	if value := ast.ChildByField(n, "value"); value != nil {
+		assignment := c.node("AssignmentPattern", n)
+		assignment["left"] = pattern
+		assignment["right"] = c.convert(value)
+		pattern = assignment
+	}
+
+	if c.types && (ast.FirstChildOfKind(n, "accessibility_modifier") != nil ||
+		syntax.HasToken(n, "readonly") || syntax.HasToken(n, "override")) {
+		property := c.node("TSParameterProperty", n)
+		property["parameter"] = pattern
+		property["readonly"] = false
+		c.modifiers(property, n)
+		delete(property, "optional")
+		return property
+	}
+	return pattern
+}
+
+// class converts a class declaration or expression.
+func (c *converter) class(typ string, n ast.Node) Node {
+	out := c.node(typ, n)
+	out["id"] = c.convert(ast.ChildByField(n, "name"))
+	out["superClass"] = nil
+	if heritage := ast.FirstChildOfKind(n, "class_heritage"); heritage != nil {
+		if extends := ast.FirstChildOfKind(heritage, "extends_clause"); extends != nil {
+			out["superClass"] = c.convert(ast.ChildByField(extends, "value"))
+		}
+	}
+	if c.types {
+		c.classTypes(out, n)
+	}
+
+	members := []Node{}
+	body := ast.ChildByField(n, "body")
+	if body != nil {
+		for _, member := range syntax.Named(body) {
+			if m := c.classMember(member); m != nil {
+				members = append(members, m)
+			}
+		}
+	}
+	if body != nil {
+		classBody := c.node("ClassBody", body)
+		classBody["body"] = members
+		out["body"] = classBody
+	}
+	return out
+}
+
+// classMember converts a method, field or static block. Members without an
+// ESTree counterpart, such as index signatures, yield nil.
+func (c *converter) classMember(n ast.Node) Node {
+	switch n.SyntaxKind() {
+	case "method_definition":
+		key := ast.ChildByField(n, "name")
+		out := c.node("MethodDefinition", n)
+		out["key"] = c.convert(key)
+		out["computed"] = key != nil && key.SyntaxKind() == "computed_property_name"
+		out["static"] = syntax.HasToken(n, "static")
+		out["kind"] = "method"
+		switch {
+		case key != nil && key.Text() == "constructor":
+			out["kind"] = "constructor"
+		case syntax.HasToken(n, "get"):
+			out["kind"] = "get"
+		case syntax.HasToken(n, "set"):
+			out["kind"] = "set"
+		}
+		out["value"] = c.function("FunctionExpression", n)
+		c.modifiers(out, n)
+		return out
+	case "abstract_method_signature", "method_signature":
+		if !c.types {
+			return nil
+		}
+		typ := "MethodDefinition"
+		if n.SyntaxKind() == "abstract_method_signature" {
+			typ = "TSAbstractMethodDefinition"
+		}
+		key := ast.ChildByField(n, "name")
+		out := c.node(typ, n)
+		out["key"] = c.convert(key)
+		out["computed"] = key != nil && key.SyntaxKind() == "computed_property_name"
+		out["static"] = syntax.HasToken(n, "static")
+		out["kind"] = "method"
+		if key != nil && key.Text() == "constructor" {
+			out["kind"] = "constructor"
+		}
+		out["value"] = c.function("TSEmptyBodyFunctionExpression", n)
+		c.modifiers(out, n)
+		return out
+	case "index_signature":
+		if !c.types {
+			return nil
+		}
+		return c.indexSignature(n)
+	case "public_field_definition":
+		key := ast.ChildByField(n, "name")
+		typ := "PropertyDefinition"
+		if c.types && syntax.HasToken(n, "abstract") {
+			typ = "TSAbstractPropertyDefinition"
+		}
+		out := c.node(typ, n)
+		out["key"] = c.convert(key)
+		out["value"] = c.convert(ast.ChildByField(n, "value"))
+		out["computed"] = key != nil && key.SyntaxKind() == "computed_property_name"
+		out["static"] = syntax.HasToken(n, "static")
+		c.annotate(out, n)
+		c.modifiers(out, n)
+		return out
+	case "class_static_block":
+		out := c.node("StaticBlock", n)
+		body := []Node{}
+		if block := ast.ChildByField(n, "body"); block != nil {
+			body = c.statements(block)
+		}
+		out["body"] = body
+		return out
+	}
+	return nil
+}
+
+// importDeclaration converts an import statement.
+func (c *converter) importDeclaration(n ast.Node) Node {
+	out := c.node("ImportDeclaration", n)
+	specifiers := []Node{}
+	if clause := ast.FirstChildOfKind(n, "import_clause"); clause != nil {
+		for _, child := range syntax.Named(clause) {
+			switch child.SyntaxKind() {
+			case "identifier":
+				s := c.node("ImportDefaultSpecifier", child)
+				s["local"] = c.convert(child)
+				specifiers = append(specifiers, s)
+			case "namespace_import":
+				s := c.node("ImportNamespaceSpecifier", child)
+				s["local"] = c.convert(ast.FirstChildOfKind(child, "identifier"))
+				specifiers = append(specifiers, s)
+			case "named_imports":
+				for _, spec := range ast.ChildrenOfKind(child, "import_specifier") {
+					s := c.node("ImportSpecifier", spec)
+					s["imported"] = c.convert(ast.ChildByField(spec, "name"))
+					s["local"] = s["imported"]
+					if alias := ast.ChildByField(spec, "alias"); alias != nil {
+						s["local"] = c.convert(alias)
+					}
+					specifiers = append(specifiers, s)
+				}
+			}
+		}
+	}
+	out["specifiers"] = specifiers
+	out["source"] = c.convert(ast.ChildByField(n, "source"))
+	return out
+}
+
+// exportDeclaration converts an export statement to a named, default or
+// all export.
+func (c *converter) exportDeclaration(n ast.Node) Node {
+	source := c.convert(ast.ChildByField(n, "source"))
+
+	if syntax.HasToken(n, "default") {
+		out := c.node("ExportDefaultDeclaration", n)
+		declaration := ast.ChildByField(n, "declaration")
+		if declaration == nil {
+			declaration = ast.ChildByField(n, "value")
+		}
+		out["declaration"] = c.convert(declaration)
+		return out
+	}
+
+	if syntax.HasToken(n, "*") || ast.FirstChildOfKind(n, "namespace_export") != nil {
+		out := c.node("ExportAllDeclaration", n)
+		out["exported"] = nil
+		if ns := ast.FirstChildOfKind(n, "namespace_export"); ns != nil {
+			out["exported"] = c.convert(syntax.FirstNamed(ns))
+		}
+		out["source"] = source
+		return out
+	}
+
+	out := c.node("ExportNamedDeclaration", n)
+	out["declaration"] = c.convert(ast.ChildByField(n, "declaration"))
+	specifiers := []Node{}
+	if clause := ast.FirstChildOfKind(n, "export_clause"); clause != nil {
+		for _, spec := range ast.ChildrenOfKind(clause, "export_specifier") {
+			s := c.node("ExportSpecifier", spec)
+			s["local"] = c.convert(ast.ChildByField(spec, "name"))
+			s["exported"] = s["local"]
+			if alias := ast.ChildByField(spec, "alias"); alias != nil {
+				s["exported"] = c.convert(alias)
+			}
+			specifiers = append(specifiers, s)
+		}
+	}
+	out["specifiers"] = specifiers
+	out["source"] = source
+	return out
+}
+
+// typeDeclaration converts a TypeScript-only declaration, keeping its name.
+func (c *converter) typeDeclaration(typ string, n ast.Node) Node {
+	out := c.node(typ, n)
+	out["id"] = c.convert(ast.ChildByField(n, "name"))
+	out["declare"] = false
+	if parent := n.Parent(); parent != nil && parent.SyntaxKind() == "ambient_declaration" {
+		out["declare"] = true
+	}
+	return out
+}
+
+// literal converts a string, number, boolean, null or regex literal.
+func (c *converter) literal(n ast.Node) Node {
+	out := c.node("Literal", n)
+	raw := n.Text()
+	out["raw"] = raw
+
+	switch n.SyntaxKind() {
+	case "string":
+		out["value"] = unquote(raw)
+	case "number":
+		digits := strings.ReplaceAll(raw, "_", "")
+		if strings.HasSuffix(digits, "n") {
+			out["value"] = nil
+			out["bigint"] = strings.TrimSuffix(digits, "n")
+			break
+		}
+		out["value"] = parseNumber(digits)
+	case "true":
+		out["value"] = true
+	case "false":
+		out["value"] = false
+	case "null":
+		out["value"] = nil
+	case "regex":
+		out["value"] = nil
+		out["regex"] = map[string]string{
+			"pattern": syntax.FieldText(n, "pattern"),
+			"flags":   syntax.FieldText(n, "flags"),
+		}
+	}
+	return out
+}
+
+// templateLiteral converts a template string into its quasis and
+// interpolated expressions.
+func (c *converter) templateLiteral(n ast.Node) Node {
+	out := c.node("TemplateLiteral", n)
+	quasis := []Node{}
+	expressions := []Node{}
+
+	r := n.Range()
+	start := r.Start // position after the opening backtick, once adjusted
+	start.Offset++
+	start.Column++
+	var raw strings.Builder
+	flush := func(end ast.Position, tail bool) {
+		q := Node{
+			"type":  "TemplateElement",
+			"range": []uint32{c.offset(start.Offset), c.offset(end.Offset)},
+			"loc": map[string]any{
+				"start": c.position(start),
+				"end":   c.position(end),
+			},
+			"value": map[string]any{"raw": raw.String(), "cooked": unescape(raw.String())},
+			"tail":  tail,
+		}
+		quasis = append(quasis, q)
+		raw.Reset()
+	}
+
+	for _, child := range n.Children() {
+		switch child.SyntaxKind() {
+		case "template_substitution":
+			flush(child.Range().Start, false)
+			expressions = append(expressions, c.convert(child))
+			start = child.Range().End
+		case "`":
+			continue
+		default:
+			raw.WriteString(child.Text())
+		}
+	}
+	end := r.End
+	end.Offset--
+	end.Column--
+	flush(end, true)
+
+	out["quasis"] = quasis
+	out["expressions"] = expressions
+	return out
+}
+
+// callExpression converts a call, which may be a tagged template.
+func (c *converter) callExpression(n ast.Node) Node {
+	callee := c.convert(ast.ChildByField(n, "function"))
+	args := ast.ChildByField(n, "arguments")
+	if args != nil && args.SyntaxKind() == "template_string" {
+		out := c.node("TaggedTemplateExpression", n)
+		out["tag"] = callee
+		out["quasi"] = c.convert(args)
+		return out
+	}
+
+	out := c.node("CallExpression", n)
+	out["callee"] = callee
+	out["arguments"] = c.arguments(args)
+	if c.types {
+		out["typeArguments"] = c.typeArguments(ast.ChildByField(n, "type_arguments"))
+	}
+	out["optional"] = ast.FirstChildOfKind(n, "optional_chain") != nil || ast.FirstChildOfKind(n, "optional_chain") != nil
+	return out
+}
+
+// arguments converts the arguments of a call or new expression.
+func (c *converter) arguments(n ast.Node) []Node {
+	args := []Node{}
+	if n == nil {
+		return args
+	}
+	for _, arg := range syntax.Named(n) {
+		args = append(args, c.convert(arg))
+	}
+	return args
+}
+
+// sequence flattens nested sequence expressions.
+func (c *converter) sequence(n ast.Node, expressions []Node) []Node {
+	for _, child := range syntax.Named(n) {
+		if child.SyntaxKind() == "sequence_expression" {
+			expressions = c.sequence(child, expressions)
+			continue
+		}
+		expressions = append(expressions, c.convert(child))
+	}
+	return expressions
+}
+
+// object converts an object literal or object pattern.
+func (c *converter) object(typ string, n ast.Node) Node {
+	out := c.node(typ, n)
+	properties := []Node{}
+	for _, member := range syntax.Named(n) {
+		switch member.SyntaxKind() {
+		case "pair", "pair_pattern":
+			key := ast.ChildByField(member, "key")
+			p := c.property(member, key, false)
+			p["value"] = c.convert(ast.ChildByField(member, "value"))
+			properties = append(properties, p)
+		case "shorthand_property_identifier", "shorthand_property_identifier_pattern":
+			p := c.property(member, member, true)
+			p["value"] = p["key"]
+			properties = append(properties, p)
+		case "object_assignment_pattern":
+			left := ast.ChildByField(member, "left")
+			p := c.property(member, left, true)
+			assignment := c.node("AssignmentPattern", member)
+			assignment["left"] = c.convert(left)
+			assignment["right"] = c.convert(ast.ChildByField(member, "right"))
+			p["value"] = assignment
+			properties = append(properties, p)
+		case "method_definition":
+			key := ast.ChildByField(member, "name")
+			p := c.property(member, key, false)
+			p["value"] = c.function("FunctionExpression", member)
+			switch {
+			case syntax.HasToken(member, "get"):
+				p["kind"] = "get"
+			case syntax.HasToken(member, "set"):
+				p["kind"] = "set"
+			default:
+				p["method"] = true
+			}
+			properties = append(properties, p)
+		case "spread_element":
+			properties = append(properties, c.convert(member))
+		case "rest_pattern":
+			properties = append(properties, c.convert(member))
+		}
+	}
+	out["properties"] = properties
+	return out
+}
+
+// property creates a Property node for an object member with the given key.
+func (c *converter) property(member, key ast.Node, shorthand bool) Node {
+	p := c.node("Property", member)
+	p["key"] = c.convert(key)
+	p["computed"] = key != nil && key.SyntaxKind() == "computed_property_name"
+	p["kind"] = "init"
+	p["method"] = false
+	p["shorthand"] = shorthand
+	return p
+}
+
+// array converts an array literal or pattern, preserving holes as nil
+// elements.
+func (c *converter) array(typ string, n ast.Node) Node {
+	out := c.node(typ, n)
+	elements := []any{}
+	pending := false // an element was seen since the last comma
+	for _, child := range n.Children() {
+		switch child.SyntaxKind() {
+		case "[", "]", "comment":
+			continue
+		case ",":
+			if !pending {
+				elements = append(elements, nil)
+			}
+			pending = false
+		default:
+			elements = append(elements, c.convert(child))
+			pending = true
+		}
+	}
+	out["elements"] = elements
+	return out
+}
+
+// firstExpression returns the expression wrapped by a parenthesized
+// expression or TypeScript assertion. The expression precedes the type in
+// `x as T`, but follows it in `<T>x`.
+func firstExpression(n ast.Node) ast.Node {
+	nodes := syntax.Named(n)
+	if n.SyntaxKind() == "type_assertion" && len(nodes) > 0 {
+		return nodes[len(nodes)-1]
+	}
+	if len(nodes) > 0 {
+		return nodes[0]
+	}
+	return nil
+}
+
+// parseNumber parses a JavaScript numeric literal.
+func parseNumber(raw string) float64 {
+	lower := strings.ToLower(raw)
+	for prefix, base := range map[string]int{"0x": 16, "0o": 8, "0b": 2} {
+		if strings.HasPrefix(lower, prefix) {
+			v, _ := strconv.ParseUint(lower[2:], base, 64)
+			return float64(v)
+		}
+	}
+	v, _ := strconv.ParseFloat(raw, 64)
+	return v
+}
+
+// unquote removes the quotes of a string literal and resolves its escapes.
+func unquote(raw string) string {
+	if len(raw) >= 2 {
+		raw = raw[1 : len(raw)-1]
+	}
+	return unescape(raw)
+}
+
+// unescape resolves JavaScript escape sequences.
+func unescape(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 >= len(s) {
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'n':
+			b.WriteByte('\n')
+		case 't':
+			b.WriteByte('\t')
+		case 'r':
+			b.WriteByte('\r')
+		case 'b':
+			b.WriteByte('\b')
+		case 'f':
+			b.WriteByte('\f')
+		case 'v':
+			b.WriteByte('\v')
+		case '0':
+			b.WriteByte(0)
+		case '\n':
+			// Line continuation
+		case '\r':
+			if i+1 < len(s) && s[i+1] == '\n' {
+				i++
+			}
+		case 'x':
+			if i+2 < len(s) {
+				if v, err := strconv.ParseUint(s[i+1:i+3], 16, 8); err == nil {
+					b.WriteRune(rune(v))
+					i += 2
+					continue
+				}
+			}
+			b.WriteByte('x')
+		case 'u':
+			r, n := unicodeEscape(s[i+1:])
+			if n == 0 {
+				b.WriteByte('u')
+				continue
+			}
+			// Combine surrogate pairs written as two escapes
+			if utf16.IsSurrogate(r) && strings.HasPrefix(s[i+1+n:], `\u`) {
+				if r2, n2 := unicodeEscape(s[i+3+n:]); n2 > 0 {
+					if combined := utf16.DecodeRune(r, r2); combined != utf8.RuneError {
+						r = combined
+						n += 2 + n2
+					}
+				}
+			}
+			b.WriteRune(r)
+			i += n
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String()
+}
+
+// unicodeEscape parses the part of a \u escape following the "u", either
+// four hex digits or a braced code point. It returns the rune and the
+// number of bytes consumed, or 0 if the escape is malformed.
+func unicodeEscape(s string) (rune, int) {
+	if strings.HasPrefix(s, "{") {
+		end := strings.IndexByte(s, '}')
+		if end < 0 {
+			return 0, 0
+		}
+		v, err := strconv.ParseUint(s[1:end], 16, 32)
+		if err != nil {
+			return 0, 0
+		}
+		return rune(v), end + 1
+	}
+	if len(s) < 4 {
+		return 0, 0
+	}
+	v, err := strconv.ParseUint(s[:4], 16, 32)
+	if err != nil {
+		return 0, 0
+	}
+	return rune(v), 4
+}
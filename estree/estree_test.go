@@ -0,0 +1,280 @@
+package estree
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/ahmadramadhannn/tsgoast"
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+func parse(t *testing.T, source string) *ast.BaseNode {
+	t.Helper()
+	parser, err := tsgoast.New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+	defer parser.Close()
+
+	root, err := parser.Parse([]byte(source))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	return root
+}
+
+// roundTrip encodes and decodes n so that tests compare plain JSON values.
+func roundTrip(t *testing.T, n Node) map[string]any {
+	t.Helper()
+	data, err := json.Marshal(n)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	var out map[string]any
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	return out
+}
+
+// get follows a path of object keys and array indexes.
+func get(t *testing.T, v any, path ...any) any {
+	t.Helper()
+	for _, step := range path {
+		switch s := step.(type) {
+		case string:
+			m, ok := v.(map[string]any)
+			if !ok {
+				t.Fatalf("path %v: %v is not an object", path, v)
+			}
+			v = m[s]
+		case int:
+			a, ok := v.([]any)
+			if !ok || s >= len(a) {
+				t.Fatalf("path %v: %v is not an array with index %d", path, v, s)
+			}
+			v = a[s]
+		}
+	}
+	return v
+}
+
+func TestConvertVariableDeclaration(t *testing.T) {
+	got := roundTrip(t, Convert(parse(t, "const x = 1;")))
+
+	want := map[string]any{
+		"type":       "Program",
+		"sourceType": "module",
+		"range":      []any{0.0, 12.0},
+		"loc":        loc(1, 0, 1, 12),
+		"comments":   []any{},
+		"body": []any{map[string]any{
+			"type":  "VariableDeclaration",
+			"kind":  "const",
+			"range": []any{0.0, 12.0},
+			"loc":   loc(1, 0, 1, 12),
+			"declarations": []any{map[string]any{
+				"type":  "VariableDeclarator",
+				"range": []any{6.0, 11.0},
+				"loc":   loc(1, 6, 1, 11),
+				"id": map[string]any{
+					"type":  "Identifier",
+					"name":  "x",
+					"range": []any{6.0, 7.0},
+					"loc":   loc(1, 6, 1, 7),
+				},
+				"init": map[string]any{
+					"type":  "Literal",
+					"value": 1.0,
+					"raw":   "1",
+					"range": []any{10.0, 11.0},
+					"loc":   loc(1, 10, 1, 11),
+				},
+			}},
+		}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		gotJSON, _ := json.MarshalIndent(got, "", "  ")
+		t.Errorf("Convert() =\n%s", gotJSON)
+	}
+}
+
+func loc(startLine, startColumn, endLine, endColumn float64) map[string]any {
+	return map[string]any{
+		"start": map[string]any{"line": startLine, "column": startColumn},
+		"end":   map[string]any{"line": endLine, "column": endColumn},
+	}
+}
+
+func TestConvertShapes(t *testing.T) {
+	program := roundTrip(t, Convert(parse(t, `import def, { a as b } from "./m";
+export async function load(id: string, opts?: Options): Promise<void> {
+  // fetch it
+  const res = await fetch(url + id, { method: "GET", ...opts });
+  return res?.json() as Data;
+}
+class Service extends Base {
+  static count = 0;
+  get name() { return this.n; }
+}
+for (const item of items) { if (!item) continue; else item.run(); }
+const tag = html`+"`<p>${x}</p>`"+`;
+const [first, , third] = list;
+interface Shape { area(): number }
+`)))
+
+	tests := []struct {
+		path []any
+		want any
+	}{
+		{[]any{"body", 0, "type"}, "ImportDeclaration"},
+		{[]any{"body", 0, "specifiers", 0, "type"}, "ImportDefaultSpecifier"},
+		{[]any{"body", 0, "specifiers", 1, "imported", "name"}, "a"},
+		{[]any{"body", 0, "specifiers", 1, "local", "name"}, "b"},
+		{[]any{"body", 0, "source", "value"}, "./m"},
+
+		{[]any{"body", 1, "type"}, "ExportNamedDeclaration"},
+		{[]any{"body", 1, "declaration", "type"}, "FunctionDeclaration"},
+		{[]any{"body", 1, "declaration", "async"}, true},
+		{[]any{"body", 1, "declaration", "id", "name"}, "load"},
+		{[]any{"body", 1, "declaration", "params", 0, "name"}, "id"},
+		{[]any{"body", 1, "declaration", "params", 1, "optional"}, true},
+		{[]any{"body", 1, "declaration", "body", "body", 0, "declarations", 0, "init", "type"}, "AwaitExpression"},
+		{[]any{"body", 1, "declaration", "body", "body", 0, "declarations", 0, "init", "argument", "arguments", 0, "type"}, "BinaryExpression"},
+		{[]any{"body", 1, "declaration", "body", "body", 0, "declarations", 0, "init", "argument", "arguments", 1, "properties", 1, "type"}, "SpreadElement"},
+		{[]any{"body", 1, "declaration", "body", "body", 1, "argument", "type"}, "CallExpression"},
+		{[]any{"body", 1, "declaration", "body", "body", 1, "argument", "callee", "optional"}, true},
+
+		{[]any{"body", 2, "type"}, "ClassDeclaration"},
+		{[]any{"body", 2, "superClass", "name"}, "Base"},
+		{[]any{"body", 2, "body", "body", 0, "type"}, "PropertyDefinition"},
+		{[]any{"body", 2, "body", "body", 0, "static"}, true},
+		{[]any{"body", 2, "body", "body", 1, "kind"}, "get"},
+		{[]any{"body", 2, "body", "body", 1, "value", "body", "body", 0, "argument", "object", "type"}, "ThisExpression"},
+
+		{[]any{"body", 3, "type"}, "ForOfStatement"},
+		{[]any{"body", 3, "left", "kind"}, "const"},
+		{[]any{"body", 3, "body", "body", 0, "test", "operator"}, "!"},
+		{[]any{"body", 3, "body", "body", 0, "consequent", "type"}, "ContinueStatement"},
+
+		{[]any{"body", 4, "declarations", 0, "init", "type"}, "TaggedTemplateExpression"},
+		{[]any{"body", 4, "declarations", 0, "init", "quasi", "quasis", 0, "value", "raw"}, "<p>"},
+		{[]any{"body", 4, "declarations", 0, "init", "quasi", "quasis", 1, "tail"}, true},
+		{[]any{"body", 4, "declarations", 0, "init", "quasi", "expressions", 0, "name"}, "x"},
+
+		{[]any{"body", 5, "declarations", 0, "id", "type"}, "ArrayPattern"},
+		{[]any{"body", 5, "declarations", 0, "id", "elements", 1}, nil},
+		{[]any{"body", 5, "declarations", 0, "id", "elements", 2, "name"}, "third"},
+
+		{[]any{"body", 6, "type"}, "TSInterfaceDeclaration"},
+		{[]any{"body", 6, "id", "name"}, "Shape"},
+
+		{[]any{"comments", 0, "type"}, "Line"},
+		{[]any{"comments", 0, "value"}, " fetch it"},
+	}
+	for _, tt := range tests {
+		if got := get(t, program, tt.path...); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%v = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestConvertUTF16Offsets(t *testing.T) {
+	program := roundTrip(t, Convert(parse(t, "const s = \"é😀\\u0041\"; s;")))
+
+	if got := get(t, program, "body", 0, "declarations", 0, "init", "value"); got != "é😀A" {
+		t.Errorf("string value = %q, want %q", got, "é😀A")
+	}
+	// "é" is one UTF-16 unit and "😀" is two
+	if got := get(t, program, "body", 1, "range"); !reflect.DeepEqual(got, []any{23.0, 25.0}) {
+		t.Errorf("range after non-ASCII = %v, want [23 25]", got)
+	}
+	if got := get(t, program, "body", 1, "loc", "start", "column"); got != 23.0 {
+		t.Errorf("column after non-ASCII = %v, want 23", got)
+	}
+}
+
+func TestMarshal(t *testing.T) {
+	data, err := Marshal(parse(t, "x;"))
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	var program map[string]any
+	if err := json.Unmarshal(data, &program); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if program["type"] != "Program" {
+		t.Errorf("type = %v, want Program", program["type"])
+	}
+	if Convert(nil) != nil {
+		t.Error("Convert(nil) should return nil")
+	}
+}
+
+func TestConvertWithTypes(t *testing.T) {
+	source := `function pick<T extends object, K extends keyof T>(obj: T, ...keys: K[]): Pick<T, K> { return obj as any; }
+interface Shape extends Base<number> { readonly area?: number; scale(by: number): Shape }
+type Id = string | number;
+enum Color { Red, Green = "g" }
+class Box<T> implements Shape { private readonly v: T; constructor(public size: number) {} }
+let n = value!;
+`
+	root := parse(t, source)
+	plain := roundTrip(t, Convert(root))
+	typed := roundTrip(t, Convert(root, WithTypes()))
+
+	if got := get(t, plain, "body", 0, "params", 0); got.(map[string]any)["typeAnnotation"] != nil {
+		t.Errorf("types should be erased by default, got %v", got)
+	}
+	if got := get(t, plain, "body", 5, "declarations", 0, "init", "type"); got != "Identifier" {
+		t.Errorf("non-null assertion should be erased by default, got %v", got)
+	}
+
+	tests := []struct {
+		path []any
+		want any
+	}{
+		{[]any{"body", 0, "typeParameters", "params", 0, "name", "name"}, "T"},
+		{[]any{"body", 0, "typeParameters", "params", 0, "constraint", "type"}, "TSObjectKeyword"},
+		{[]any{"body", 0, "typeParameters", "params", 1, "constraint", "operator"}, "keyof"},
+		{[]any{"body", 0, "params", 0, "typeAnnotation", "type"}, "TSTypeAnnotation"},
+		{[]any{"body", 0, "params", 0, "typeAnnotation", "typeAnnotation", "typeName", "name"}, "T"},
+		{[]any{"body", 0, "params", 1, "type"}, "RestElement"},
+		{[]any{"body", 0, "params", 1, "typeAnnotation", "typeAnnotation", "type"}, "TSArrayType"},
+		{[]any{"body", 0, "returnType", "typeAnnotation", "typeArguments", "params", 1, "typeName", "name"}, "K"},
+		{[]any{"body", 0, "body", "body", 0, "argument", "type"}, "TSAsExpression"},
+		{[]any{"body", 0, "body", "body", 0, "argument", "typeAnnotation", "type"}, "TSAnyKeyword"},
+
+		{[]any{"body", 1, "type"}, "TSInterfaceDeclaration"},
+		{[]any{"body", 1, "extends", 0, "expression", "name"}, "Base"},
+		{[]any{"body", 1, "extends", 0, "typeArguments", "params", 0, "type"}, "TSNumberKeyword"},
+		{[]any{"body", 1, "body", "body", 0, "type"}, "TSPropertySignature"},
+		{[]any{"body", 1, "body", "body", 0, "readonly"}, true},
+		{[]any{"body", 1, "body", "body", 0, "optional"}, true},
+		{[]any{"body", 1, "body", "body", 1, "type"}, "TSMethodSignature"},
+		{[]any{"body", 1, "body", "body", 1, "returnType", "typeAnnotation", "typeName", "name"}, "Shape"},
+
+		{[]any{"body", 2, "typeAnnotation", "type"}, "TSUnionType"},
+		{[]any{"body", 2, "typeAnnotation", "types", 1, "type"}, "TSNumberKeyword"},
+
+		{[]any{"body", 3, "members", 1, "id", "name"}, "Green"},
+		{[]any{"body", 3, "members", 1, "initializer", "value"}, "g"},
+
+		{[]any{"body", 4, "typeParameters", "params", 0, "name", "name"}, "T"},
+		{[]any{"body", 4, "implements", 0, "type"}, "TSClassImplements"},
+		{[]any{"body", 4, "body", "body", 0, "accessibility"}, "private"},
+		{[]any{"body", 4, "body", "body", 0, "readonly"}, true},
+		{[]any{"body", 4, "body", "body", 0, "typeAnnotation", "typeAnnotation", "typeName", "name"}, "T"},
+		{[]any{"body", 4, "body", "body", 1, "value", "params", 0, "type"}, "TSParameterProperty"},
+		{[]any{"body", 4, "body", "body", 1, "value", "params", 0, "accessibility"}, "public"},
+		{[]any{"body", 4, "body", "body", 1, "value", "params", 0, "parameter", "name"}, "size"},
+
+		{[]any{"body", 5, "declarations", 0, "init", "type"}, "TSNonNullExpression"},
+	}
+	for _, tt := range tests {
+		if got := get(t, typed, tt.path...); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%v = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,574 @@
+package estree
+
+import (
+	"strings"
+
+	"github.com/ahmadramadhannn/tsgoast/ast"
+	"github.com/ahmadramadhannn/tsgoast/internal/syntax"
+)
+
+// Option configures a conversion.
+type Option func(*converter)
+
+// WithTypes keeps TypeScript syntax in the output as typescript-estree
+// nodes instead of erasing it: type annotations (TSTypeAnnotation), type
+// parameters and arguments, assertions (TSAsExpression,
+// TSNonNullExpression), parameter properties, and full interface, type
+// alias, enum and namespace declarations.
+func WithTypes() Option {
+	return func(c *converter) {
+		c.types = true
+	}
+}
+
+// keywordTypes maps predefined types to their typescript-estree node types.
+var keywordTypes = map[string]string{
+	"any":       "TSAnyKeyword",
+	"unknown":   "TSUnknownKeyword",
+	"number":    "TSNumberKeyword",
+	"string":    "TSStringKeyword",
+	"boolean":   "TSBooleanKeyword",
+	"bigint":    "TSBigIntKeyword",
+	"symbol":    "TSSymbolKeyword",
+	"object":    "TSObjectKeyword",
+	"void":      "TSVoidKeyword",
+	"never":     "TSNeverKeyword",
+	"undefined": "TSUndefinedKeyword",
+	"null":      "TSNullKeyword",
+	"intrinsic": "TSIntrinsicKeyword",
+}
+
+// typeAnnotation converts a type annotation such as ": string" into a
+// TSTypeAnnotation. It returns nil if n is nil.
+func (c *converter) typeAnnotation(n ast.Node) Node {
+	if n == nil {
+		return nil
+	}
+	out := c.node("TSTypeAnnotation", n)
+	switch n.SyntaxKind() {
+	case "type_annotation":
+		out["typeAnnotation"] = c.tsType(syntax.FirstNamed(n))
+	case "asserts_annotation", "type_predicate_annotation":
+		out["typeAnnotation"] = c.typePredicate(syntax.FirstNamed(n))
+	default:
+		// A bare type, as in the return type of a function type
+		out["typeAnnotation"] = c.tsType(n)
+	}
+	return out
+}
+
+// typePredicate converts `x is T`, `asserts x` or `asserts x is T`.
+func (c *converter) typePredicate(n ast.Node) Node {
+	if n == nil {
+		return nil
+	}
+	out := c.node("TSTypePredicate", n)
+	out["asserts"] = n.SyntaxKind() == "asserts"
+	predicate := n
+	if n.SyntaxKind() == "asserts" {
+		if inner := ast.FirstChildOfKind(n, "type_predicate"); inner != nil {
+			predicate = inner
+		}
+	}
+	if predicate.SyntaxKind() == "type_predicate" {
+		out["parameterName"] = c.convert(ast.ChildByField(predicate, "name"))
+		out["typeAnnotation"] = c.typeAnnotation(ast.ChildByField(predicate, "type"))
+	} else {
+		out["parameterName"] = c.convert(syntax.FirstNamed(n))
+		out["typeAnnotation"] = nil
+	}
+	return out
+}
+
+// tsType converts a type node.
+func (c *converter) tsType(n ast.Node) Node {
+	if n == nil {
+		return nil
+	}
+
+	switch n.SyntaxKind() {
+	case "predefined_type":
+		if typ, ok := keywordTypes[n.Text()]; ok {
+			return c.node(typ, n)
+		}
+	case "type_identifier", "identifier":
+		out := c.node("TSTypeReference", n)
+		out["typeName"] = c.identifier(n)
+		return out
+	case "nested_type_identifier":
+		out := c.node("TSTypeReference", n)
+		out["typeName"] = c.qualifiedName(n)
+		return out
+	case "generic_type":
+		out := c.node("TSTypeReference", n)
+		name := ast.ChildByField(n, "name")
+		if name != nil && name.SyntaxKind() == "nested_type_identifier" {
+			out["typeName"] = c.qualifiedName(name)
+		} else {
+			out["typeName"] = c.convert(name)
+		}
+		out["typeArguments"] = c.typeArguments(ast.ChildByField(n, "type_arguments"))
+		return out
+	case "this_type", "this":
+		return c.node("TSThisType", n)
+	case "array_type":
+		out := c.node("TSArrayType", n)
+		out["elementType"] = c.tsType(syntax.FirstNamed(n))
+		return out
+	case "union_type", "intersection_type":
+		typ := "TSUnionType"
+		if n.SyntaxKind() == "intersection_type" {
+			typ = "TSIntersectionType"
+		}
+		out := c.node(typ, n)
+		out["types"] = c.flattenTypes(n, n.SyntaxKind(), nil)
+		return out
+	case "parenthesized_type":
+		return c.tsType(syntax.FirstNamed(n))
+	case "literal_type":
+		literal := syntax.FirstNamed(n)
+		if literal != nil {
+			switch literal.SyntaxKind() {
+			case "null":
+				return c.node("TSNullKeyword", n)
+			case "undefined":
+				return c.node("TSUndefinedKeyword", n)
+			}
+		}
+		out := c.node("TSLiteralType", n)
+		out["literal"] = c.convert(literal)
+		return out
+	case "template_literal_type":
+		out := c.node("TSLiteralType", n)
+		out["literal"] = c.templateLiteral(n)
+		return out
+	case "object_type":
+		out := c.node("TSTypeLiteral", n)
+		out["members"] = c.typeMembers(n)
+		return out
+	case "function_type", "constructor_type":
+		typ := "TSFunctionType"
+		if n.SyntaxKind() == "constructor_type" {
+			typ = "TSConstructorType"
+		}
+		out := c.node(typ, n)
+		out["params"] = c.params(ast.ChildByField(n, "parameters"))
+		out["returnType"] = c.typeAnnotation(ast.ChildByField(n, "return_type"))
+		if returnType := ast.ChildByField(n, "type"); returnType != nil {
+			out["returnType"] = c.typeAnnotation(returnType)
+		}
+		out["typeParameters"] = c.typeParameters(ast.ChildByField(n, "type_parameters"))
+		return out
+	case "tuple_type":
+		out := c.node("TSTupleType", n)
+		elements := []Node{}
+		for _, element := range syntax.Named(n) {
+			elements = append(elements, c.tupleElement(element))
+		}
+		out["elementTypes"] = elements
+		return out
+	case "optional_type":
+		out := c.node("TSOptionalType", n)
+		out["typeAnnotation"] = c.tsType(syntax.FirstNamed(n))
+		return out
+	case "rest_type":
+		out := c.node("TSRestType", n)
+		out["typeAnnotation"] = c.tsType(syntax.FirstNamed(n))
+		return out
+	case "index_type_query", "readonly_type":
+		out := c.node("TSTypeOperator", n)
+		out["operator"] = "keyof"
+		if n.SyntaxKind() == "readonly_type" {
+			out["operator"] = "readonly"
+		}
+		out["typeAnnotation"] = c.tsType(syntax.FirstNamed(n))
+		return out
+	case "type_query":
+		out := c.node("TSTypeQuery", n)
+		out["exprName"] = c.convert(syntax.FirstNamed(n))
+		return out
+	case "lookup_type":
+		types := syntax.Named(n)
+		out := c.node("TSIndexedAccessType", n)
+		if len(types) == 2 {
+			out["objectType"] = c.tsType(types[0])
+			out["indexType"] = c.tsType(types[1])
+		}
+		return out
+	case "conditional_type":
+		out := c.node("TSConditionalType", n)
+		out["checkType"] = c.tsType(ast.ChildByField(n, "left"))
+		out["extendsType"] = c.tsType(ast.ChildByField(n, "right"))
+		out["trueType"] = c.tsType(ast.ChildByField(n, "consequence"))
+		out["falseType"] = c.tsType(ast.ChildByField(n, "alternative"))
+		return out
+	case "infer_type":
+		out := c.node("TSInferType", n)
+		parameter := c.node("TSTypeParameter", n)
+		parameter["name"] = c.identifier(syntax.FirstNamed(n))
+		out["typeParameter"] = parameter
+		return out
+	case "type_predicate":
+		return c.typePredicate(n)
+	}
+
+	out := c.node(syntax.PascalCase(n.SyntaxKind()), n)
+	out["raw"] = n.Text()
+	return out
+}
+
+// flattenTypes collects the members of a left-nested union or intersection.
+func (c *converter) flattenTypes(n ast.Node, kind string, types []Node) []Node {
+	for _, child := range syntax.Named(n) {
+		if child.SyntaxKind() == kind {
+			types = c.flattenTypes(child, kind, types)
+			continue
+		}
+		types = append(types, c.tsType(child))
+	}
+	return types
+}
+
+// tupleElement converts a tuple member, which may be named.
+func (c *converter) tupleElement(n ast.Node) Node {
+	if n.SyntaxKind() != "required_parameter" && n.SyntaxKind() != "optional_parameter" {
+		return c.tsType(n)
+	}
+	out := c.node("TSNamedTupleMember", n)
+	out["label"] = c.convert(ast.ChildByField(n, "name"))
+	out["optional"] = n.SyntaxKind() == "optional_parameter"
+	if annotation := ast.ChildByField(n, "type"); annotation != nil {
+		out["elementType"] = c.tsType(syntax.FirstNamed(annotation))
+	}
+	return out
+}
+
+// identifier converts a name node to an Identifier regardless of its kind.
+func (c *converter) identifier(n ast.Node) Node {
+	if n == nil {
+		return nil
+	}
+	out := c.node("Identifier", n)
+	out["name"] = n.Text()
+	return out
+}
+
+// qualifiedName converts a dotted type name such as A.B.C.
+func (c *converter) qualifiedName(n ast.Node) Node {
+	if n.SyntaxKind() != "nested_type_identifier" && n.SyntaxKind() != "nested_identifier" {
+		return c.identifier(n)
+	}
+	parts := syntax.Named(n)
+	if len(parts) != 2 {
+		return c.identifier(n)
+	}
+	out := c.node("TSQualifiedName", n)
+	out["left"] = c.qualifiedName(parts[0])
+	out["right"] = c.identifier(parts[1])
+	return out
+}
+
+// typeParameters converts a `<T extends U = V>` list, or returns nil.
+func (c *converter) typeParameters(n ast.Node) Node {
+	if n == nil || !c.types {
+		return nil
+	}
+	out := c.node("TSTypeParameterDeclaration", n)
+	params := []Node{}
+	for _, param := range ast.ChildrenOfKind(n, "type_parameter") {
+		p := c.node("TSTypeParameter", param)
+		p["name"] = c.identifier(ast.ChildByField(param, "name"))
+		p["constraint"] = nil
+		if constraint := ast.ChildByField(param, "constraint"); constraint != nil {
+			p["constraint"] = c.tsType(syntax.FirstNamed(constraint))
+		}
+		p["default"] = nil
+		if value := ast.ChildByField(param, "value"); value != nil {
+			p["default"] = c.tsType(syntax.FirstNamed(value))
+		}
+		p["in"] = syntax.HasToken(param, "in")
+		p["out"] = syntax.HasToken(param, "out")
+		p["const"] = syntax.HasToken(param, "const")
+		params = append(params, p)
+	}
+	out["params"] = params
+	return out
+}
+
+// typeArguments converts a `<A, B>` list, or returns nil.
+func (c *converter) typeArguments(n ast.Node) Node {
+	if n == nil || !c.types {
+		return nil
+	}
+	out := c.node("TSTypeParameterInstantiation", n)
+	params := []Node{}
+	for _, arg := range syntax.Named(n) {
+		params = append(params, c.tsType(arg))
+	}
+	out["params"] = params
+	return out
+}
+
+// typeMembers converts the members of an interface body or object type.
+func (c *converter) typeMembers(n ast.Node) []Node {
+	members := []Node{}
+	for _, member := range syntax.Named(n) {
+		switch member.SyntaxKind() {
+		case "property_signature":
+			key := ast.ChildByField(member, "name")
+			out := c.node("TSPropertySignature", member)
+			out["key"] = c.convert(key)
+			out["computed"] = key != nil && key.SyntaxKind() == "computed_property_name"
+			out["optional"] = syntax.HasToken(member, "?")
+			out["readonly"] = syntax.HasToken(member, "readonly")
+			out["typeAnnotation"] = c.typeAnnotation(ast.ChildByField(member, "type"))
+			members = append(members, out)
+		case "method_signature":
+			key := ast.ChildByField(member, "name")
+			out := c.node("TSMethodSignature", member)
+			out["key"] = c.convert(key)
+			out["computed"] = key != nil && key.SyntaxKind() == "computed_property_name"
+			out["optional"] = syntax.HasToken(member, "?")
+			out["kind"] = "method"
+			if syntax.HasToken(member, "get") {
+				out["kind"] = "get"
+			} else if syntax.HasToken(member, "set") {
+				out["kind"] = "set"
+			}
+			c.signature(out, member)
+			members = append(members, out)
+		case "call_signature":
+			out := c.node("TSCallSignatureDeclaration", member)
+			c.signature(out, member)
+			members = append(members, out)
+		case "construct_signature":
+			out := c.node("TSConstructSignatureDeclaration", member)
+			c.signature(out, member)
+			if annotation := ast.ChildByField(member, "type"); annotation != nil {
+				out["returnType"] = c.typeAnnotation(annotation)
+			}
+			members = append(members, out)
+		case "index_signature":
+			members = append(members, c.indexSignature(member))
+		}
+	}
+	return members
+}
+
+// signature adds the parameters, return type and type parameters of a
+// signature member to out.
+func (c *converter) signature(out Node, n ast.Node) {
+	out["params"] = c.params(ast.ChildByField(n, "parameters"))
+	out["returnType"] = c.typeAnnotation(ast.ChildByField(n, "return_type"))
+	out["typeParameters"] = c.typeParameters(ast.ChildByField(n, "type_parameters"))
+}
+
+// indexSignature converts `[key: K]: V`. Mapped types (`[K in T]: V`) are
+// reported as TSMappedType.
+func (c *converter) indexSignature(n ast.Node) Node {
+	if clause := ast.FirstChildOfKind(n, "mapped_type_clause"); clause != nil {
+		out := c.node("TSMappedType", n)
+		parameter := c.node("TSTypeParameter", clause)
+		parameter["name"] = c.identifier(ast.ChildByField(clause, "name"))
+		parameter["constraint"] = c.tsType(ast.ChildByField(clause, "type"))
+		out["typeParameter"] = parameter
+		out["typeAnnotation"] = nil
+		if annotation := ast.ChildByField(n, "type"); annotation != nil {
+			out["typeAnnotation"] = c.tsType(syntax.FirstNamed(annotation))
+		}
+		return out
+	}
+
+	out := c.node("TSIndexSignature", n)
+	parameters := []Node{}
+	if name := ast.ChildByField(n, "name"); name != nil {
+		param := c.identifier(name)
+		if indexType := ast.ChildByField(n, "index_type"); indexType != nil {
+			annotation := c.node("TSTypeAnnotation", indexType)
+			annotation["typeAnnotation"] = c.tsType(indexType)
+			param["typeAnnotation"] = annotation
+		}
+		parameters = append(parameters, param)
+	}
+	out["parameters"] = parameters
+	out["typeAnnotation"] = c.typeAnnotation(ast.ChildByField(n, "type"))
+	out["readonly"] = syntax.HasToken(n, "readonly")
+	out["static"] = syntax.HasToken(n, "static")
+	return out
+}
+
+// tsExpression converts a type assertion, satisfies or non-null expression.
+func (c *converter) tsExpression(n ast.Node) Node {
+	nodes := syntax.Named(n)
+	switch n.SyntaxKind() {
+	case "non_null_expression":
+		out := c.node("TSNonNullExpression", n)
+		out["expression"] = c.convert(syntax.FirstNamed(n))
+		return out
+	case "type_assertion":
+		out := c.node("TSTypeAssertion", n)
+		if len(nodes) == 2 {
+			out["typeAnnotation"] = c.tsType(syntax.FirstNamed(nodes[0]))
+			out["expression"] = c.convert(nodes[1])
+		}
+		return out
+	}
+
+	typ := "TSAsExpression"
+	if n.SyntaxKind() == "satisfies_expression" {
+		typ = "TSSatisfiesExpression"
+	}
+	out := c.node(typ, n)
+	if len(nodes) == 2 {
+		out["expression"] = c.convert(nodes[0])
+		out["typeAnnotation"] = c.tsType(nodes[1])
+	}
+	return out
+}
+
+// tsDeclaration converts an interface, type alias, enum or namespace
+// declaration with its full structure.
+func (c *converter) tsDeclaration(typ string, n ast.Node) Node {
+	out := c.typeDeclaration(typ, n)
+
+	switch n.SyntaxKind() {
+	case "interface_declaration":
+		out["typeParameters"] = c.typeParameters(ast.ChildByField(n, "type_parameters"))
+		extends := []Node{}
+		if clause := ast.FirstChildOfKind(n, "extends_type_clause"); clause != nil {
+			for _, heritage := range ast.ChildrenByField(clause, "type") {
+				extends = append(extends, c.heritage("TSInterfaceHeritage", heritage))
+			}
+		}
+		out["extends"] = extends
+		if body := ast.ChildByField(n, "body"); body != nil {
+			b := c.node("TSInterfaceBody", body)
+			b["body"] = c.typeMembers(body)
+			out["body"] = b
+		}
+	case "type_alias_declaration":
+		out["typeParameters"] = c.typeParameters(ast.ChildByField(n, "type_parameters"))
+		out["typeAnnotation"] = c.tsType(ast.ChildByField(n, "value"))
+	case "enum_declaration":
+		out["const"] = syntax.HasToken(n, "const")
+		members := []Node{}
+		if body := ast.ChildByField(n, "body"); body != nil {
+			for _, member := range syntax.Named(body) {
+				m := c.node("TSEnumMember", member)
+				m["initializer"] = nil
+				if member.SyntaxKind() == "enum_assignment" {
+					m["id"] = c.convert(ast.ChildByField(member, "name"))
+					m["initializer"] = c.convert(ast.ChildByField(member, "value"))
+				} else {
+					m["id"] = c.convert(member)
+				}
+				members = append(members, m)
+			}
+		}
+		out["members"] = members
+	case "internal_module", "module":
+		if name := ast.ChildByField(n, "name"); name != nil && name.SyntaxKind() == "nested_identifier" {
+			out["id"] = c.qualifiedName(name)
+		}
+		out["body"] = nil
+		if body := ast.ChildByField(n, "body"); body != nil {
+			b := c.node("TSModuleBlock", body)
+			b["body"] = c.statements(body)
+			out["body"] = b
+		}
+		out["kind"] = "namespace"
+		if n.SyntaxKind() == "module" {
+			out["kind"] = "module"
+		}
+	}
+	return out
+}
+
+// heritage converts an extends or implements entry with its type arguments.
+func (c *converter) heritage(typ string, n ast.Node) Node {
+	out := c.node(typ, n)
+	name := n
+	if n.SyntaxKind() == "generic_type" {
+		name = ast.ChildByField(n, "name")
+		out["typeArguments"] = c.typeArguments(ast.ChildByField(n, "type_arguments"))
+	} else {
+		out["typeArguments"] = nil
+	}
+	out["expression"] = c.qualifiedExpression(name)
+	return out
+}
+
+// qualifiedExpression converts a possibly dotted type name used as a value,
+// such as the A.B in `implements A.B`.
+func (c *converter) qualifiedExpression(n ast.Node) Node {
+	if n == nil {
+		return nil
+	}
+	if n.SyntaxKind() != "nested_type_identifier" && n.SyntaxKind() != "nested_identifier" &&
+		n.SyntaxKind() != "member_expression" {
+		return c.identifier(n)
+	}
+	parts := syntax.Named(n)
+	if len(parts) != 2 {
+		return c.identifier(n)
+	}
+	out := c.node("MemberExpression", n)
+	out["object"] = c.qualifiedExpression(parts[0])
+	out["property"] = c.identifier(parts[1])
+	out["computed"] = false
+	out["optional"] = false
+	return out
+}
+
+// annotate adds the type annotation in the "type" field of n, if any, to
+// the converted pattern or key.
+func (c *converter) annotate(out Node, n ast.Node) {
+	if out == nil || !c.types {
+		return
+	}
+	if annotation := ast.ChildByField(n, "type"); annotation != nil {
+		out["typeAnnotation"] = c.typeAnnotation(annotation)
+	}
+}
+
+// classTypes adds the type parameters, implemented interfaces, superclass
+// type arguments and abstract flag of a class.
+func (c *converter) classTypes(out Node, n ast.Node) {
+	out["typeParameters"] = c.typeParameters(ast.ChildByField(n, "type_parameters"))
+	out["abstract"] = syntax.HasToken(n, "abstract")
+	out["superTypeArguments"] = nil
+	implements := []Node{}
+	if heritage := ast.FirstChildOfKind(n, "class_heritage"); heritage != nil {
+		if extends := ast.FirstChildOfKind(heritage, "extends_clause"); extends != nil {
+			out["superTypeArguments"] = c.typeArguments(ast.ChildByField(extends, "type_arguments"))
+		}
+		if clause := ast.FirstChildOfKind(heritage, "implements_clause"); clause != nil {
+			for _, iface := range syntax.Named(clause) {
+				implements = append(implements, c.heritage("TSClassImplements", iface))
+			}
+		}
+	}
+	out["implements"] = implements
+}
+
+// modifiers adds TypeScript member modifiers of n to out.
+func (c *converter) modifiers(out Node, n ast.Node) {
+	if !c.types {
+		return
+	}
+	if modifier := ast.FirstChildOfKind(n, "accessibility_modifier"); modifier != nil {
+		out["accessibility"] = strings.TrimSpace(modifier.Text())
+	}
+	if syntax.HasToken(n, "readonly") {
+		out["readonly"] = true
+	}
+	if syntax.HasToken(n, "declare") {
+		out["declare"] = true
+	}
+	if syntax.HasToken(n, "override") {
+		out["override"] = true
+	}
+	if syntax.HasToken(n, "?") {
+		out["optional"] = true
+	}
+}
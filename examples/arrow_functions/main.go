@@ -55,7 +55,7 @@ func main() {
 	fmt.Println()
 	for i, fn := range functions {
 		name := analyzer.GetFunctionName(fn)
-		isAsync := analyzer.IsAsync(fn)
+		isAsync := analyzer.IsAsyncFunction(fn)
 		nodeType := fn.Type()
 
 		fmt.Printf("%d. %s\n", i+1, name)
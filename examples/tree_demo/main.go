@@ -96,7 +96,7 @@ func main() {
 	fmt.Printf("Found %d functions:\n", len(functions))
 	for _, fn := range functions {
 		name := analyzer.GetFunctionName(fn)
-		isAsync := analyzer.IsAsync(fn)
+		isAsync := analyzer.IsAsyncFunction(fn)
 		fmt.Printf("  - %s (async: %v)\n", name, isAsync)
 	}
 }
@@ -0,0 +1,492 @@
+// Package fromgo generates TypeScript declarations from Go types, so that a
+// Go backend and a TypeScript frontend can share the shapes of the data
+// they exchange.
+//
+// Structs become interfaces whose properties follow encoding/json: fields
+// are named after their json tag, omitempty fields are optional, fields
+// tagged "-", unexported fields and fields of function and channel types
+// are left out, and embedded structs are inherited with extends. Named types with constants become unions of the
+// constant values:
+//
+//	type Status string
+//
+//	const (
+//		Active   Status = "active"
+//		Disabled Status = "disabled"
+//	)
+//
+//	type User struct {
+//		ID     int64    `json:"id"`
+//		Email  string   `json:"email,omitempty"`
+//		Status Status   `json:"status"`
+//		Tags   []string `json:"tags"`
+//	}
+//
+// generates
+//
+//	export type Status = "active" | "disabled";
+//
+//	export interface User {
+//	  id: number;
+//	  email?: string;
+//	  status: Status;
+//	  tags: string[];
+//	}
+//
+// The types are read with go/types; Load type-checks the Go package in a
+// directory.
+package fromgo
+
+import (
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/constant"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// DefaultTypeMap maps Go types with custom JSON encodings to the
+// TypeScript types of their encoding.
+var DefaultTypeMap = map[string]string{
+	"time.Time":                "string",
+	"time.Duration":            "number",
+	"encoding/json.RawMessage": "unknown",
+	"encoding/json.Number":     "string",
+}
+
+// Config controls the generated declarations.
+type Config struct {
+	// Names selects the types to generate, by name. Types they refer to in
+	// the same package are generated as well. All exported types are
+	// generated if Names is empty, except for interfaces.
+	Names []string
+
+	// TypeMap maps Go types, by package path and name ("time.Time"), to
+	// TypeScript types, in addition to DefaultTypeMap.
+	TypeMap map[string]string
+
+	// Indent is the indentation of interface members, two spaces if empty.
+	Indent string
+
+	// NoExport leaves out the export keyword of the declarations.
+	NoExport bool
+}
+
+// Generate returns the TypeScript declarations of the types of pkg using
+// the default configuration.
+func Generate(pkg *types.Package) (string, error) {
+	return (&Config{}).Generate(pkg)
+}
+
+// Load parses and type-checks the Go package in dir, without its tests,
+// using the build constraints of the current platform. Imports are
+// resolved from compiled export data.
+func Load(dir string) (*types.Package, error) {
+	bp, err := build.ImportDir(dir, 0)
+	if err != nil {
+		return nil, fmt.Errorf("fromgo: %w", err)
+	}
+	fset := token.NewFileSet()
+	files := make([]*ast.File, 0, len(bp.GoFiles))
+	for _, name := range bp.GoFiles {
+		f, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, f)
+	}
+	conf := types.Config{Importer: importer.Default()}
+	return conf.Check(bp.ImportPath, fset, files, nil)
+}
+
+// Generate returns the TypeScript declarations of the types of pkg, in the
+// order they are declared.
+func (c *Config) Generate(pkg *types.Package) (string, error) {
+	g := &generator{
+		config:  c,
+		pkg:     pkg,
+		indent:  c.Indent,
+		pending: make(map[*types.TypeName]bool),
+		consts:  make(map[*types.TypeName][]*types.Const),
+	}
+	if g.indent == "" {
+		g.indent = "  "
+	}
+
+	scope := pkg.Scope()
+	for _, name := range scope.Names() {
+		if obj, ok := scope.Lookup(name).(*types.Const); ok {
+			if named, ok := obj.Type().(*types.Named); ok && named.Obj().Pkg() == pkg {
+				g.consts[named.Obj()] = append(g.consts[named.Obj()], obj)
+			}
+		}
+	}
+
+	if len(c.Names) == 0 {
+		for _, name := range scope.Names() {
+			if obj, ok := scope.Lookup(name).(*types.TypeName); ok && obj.Exported() && isData(obj.Type()) && !types.IsInterface(obj.Type()) {
+				g.require(obj)
+			}
+		}
+	}
+	for _, name := range c.Names {
+		obj, ok := scope.Lookup(name).(*types.TypeName)
+		if !ok {
+			return "", fmt.Errorf("fromgo: %s is not a type of package %s", name, pkg.Path())
+		}
+		g.require(obj)
+	}
+
+	// Generating a declaration can require more types; repeat until all
+	// are done
+	var decls []*types.TypeName
+	text := make(map[*types.TypeName]string)
+	for len(g.queue) > 0 {
+		obj := g.queue[0]
+		g.queue = g.queue[1:]
+		decl, err := g.declaration(obj)
+		if err != nil {
+			return "", err
+		}
+		text[obj] = decl
+		decls = append(decls, obj)
+	}
+	slices.SortFunc(decls, func(a, b *types.TypeName) int { return int(a.Pos()) - int(b.Pos()) })
+
+	var b strings.Builder
+	for i, obj := range decls {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(text[obj])
+	}
+	return b.String(), nil
+}
+
+// generator holds the state of one Generate call.
+type generator struct {
+	config  *Config
+	pkg     *types.Package
+	indent  string
+	pending map[*types.TypeName]bool // declarations required so far
+	queue   []*types.TypeName
+	consts  map[*types.TypeName][]*types.Const
+}
+
+// require schedules the declaration of a type of the package.
+func (g *generator) require(obj *types.TypeName) {
+	if !g.pending[obj] {
+		g.pending[obj] = true
+		g.queue = append(g.queue, obj)
+	}
+}
+
+// declaration returns the TypeScript declaration of a named type.
+func (g *generator) declaration(obj *types.TypeName) (string, error) {
+	export := "export "
+	if g.config.NoExport {
+		export = ""
+	}
+	name := obj.Name()
+	if named, ok := obj.Type().(*types.Named); ok {
+		name += g.typeParams(named.TypeParams())
+	}
+
+	if consts := g.consts[obj]; len(consts) > 0 {
+		values := make([]string, 0, len(consts))
+		slices.SortFunc(consts, func(a, b *types.Const) int { return int(a.Pos()) - int(b.Pos()) })
+		for _, c := range consts {
+			if v := literal(c.Val()); v != "" && !slices.Contains(values, v) {
+				values = append(values, v)
+			}
+		}
+		if len(values) > 0 {
+			return fmt.Sprintf("%stype %s = %s;\n", export, name, strings.Join(values, " | ")), nil
+		}
+	}
+
+	st, ok := obj.Type().Underlying().(*types.Struct)
+	if !ok || obj.IsAlias() {
+		t := obj.Type().Underlying()
+		if obj.IsAlias() {
+			t = types.Unalias(obj.Type())
+		}
+		ts, err := g.typeOf(t)
+		if err != nil {
+			return "", fmt.Errorf("fromgo: %s: %w", obj.Name(), err)
+		}
+		return fmt.Sprintf("%stype %s = %s;\n", export, name, ts), nil
+	}
+
+	members, extends, err := g.members(st)
+	if err != nil {
+		return "", fmt.Errorf("fromgo: %s.%w", obj.Name(), err)
+	}
+	heritage := ""
+	if len(extends) > 0 {
+		heritage = " extends " + strings.Join(extends, ", ")
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%sinterface %s%s {\n", export, name, heritage)
+	for _, m := range members {
+		fmt.Fprintf(&b, "%s%s;\n", g.indent, m)
+	}
+	b.WriteString("}\n")
+	return b.String(), nil
+}
+
+// member is a property of an object type.
+type member struct {
+	name     string
+	optional bool
+	ts       string
+}
+
+func (m member) String() string {
+	optional := ""
+	if m.optional {
+		optional = "?"
+	}
+	return propertyName(m.name) + optional + ": " + m.ts
+}
+
+// members returns the properties of the JSON encoding of a struct, and the
+// types it extends: the embedded structs of the package. The fields of
+// other embedded structs are promoted, as encoding/json does.
+func (g *generator) members(st *types.Struct) (members []member, extends []string, err error) {
+	for i := range st.NumFields() {
+		field := st.Field(i)
+		jsonName, opts := jsonTag(reflect.StructTag(st.Tag(i)).Get("json"))
+		if jsonName == "-" && opts == "" {
+			continue
+		}
+
+		if field.Embedded() && jsonName == "" {
+			t := field.Type()
+			if ptr, ok := t.(*types.Pointer); ok {
+				t = ptr.Elem()
+			}
+			if embedded, ok := t.Underlying().(*types.Struct); ok {
+				if named, ok := t.(*types.Named); ok && named.Obj().Pkg() == g.pkg {
+					ts, err := g.typeOf(named)
+					if err != nil {
+						return nil, nil, fmt.Errorf("%s: %w", field.Name(), err)
+					}
+					extends = append(extends, ts)
+					continue
+				}
+				promoted, more, err := g.members(embedded)
+				if err != nil {
+					return nil, nil, err
+				}
+				members = append(members, promoted...)
+				extends = append(extends, more...)
+				continue
+			}
+		}
+		if !field.Exported() || !isData(field.Type()) {
+			continue
+		}
+
+		if jsonName == "" {
+			jsonName = field.Name()
+		}
+		ts, err := g.fieldType(field.Type(), opts)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", field.Name(), err)
+		}
+		optional := hasOption(opts, "omitempty") || hasOption(opts, "omitzero")
+		members = append(members, member{jsonName, optional, ts})
+	}
+	return members, extends, nil
+}
+
+// fieldType returns the TypeScript type of a field with the given json
+// tag options.
+func (g *generator) fieldType(t types.Type, opts string) (string, error) {
+	if hasOption(opts, "string") {
+		// The ,string option quotes numbers and booleans
+		switch u := t.Underlying().(type) {
+		case *types.Basic:
+			if u.Info()&(types.IsNumeric|types.IsBoolean) != 0 {
+				return "string", nil
+			}
+		}
+	}
+	return g.typeOf(t)
+}
+
+// typeOf returns the TypeScript type of a Go type.
+func (g *generator) typeOf(t types.Type) (string, error) {
+	switch t := t.(type) {
+	case *types.Named:
+		obj := t.Obj()
+		if ts, ok := g.mapped(obj); ok {
+			return ts, nil
+		}
+		if obj.Pkg() == g.pkg {
+			g.require(t.Origin().Obj())
+			args, err := g.typeArgs(t.TypeArgs())
+			return obj.Name() + args, err
+		}
+		// Types of other packages are inlined
+		return g.typeOf(t.Underlying())
+	case *types.Alias:
+		if ts, ok := g.mapped(t.Obj()); ok {
+			return ts, nil
+		}
+		return g.typeOf(types.Unalias(t))
+	case *types.TypeParam:
+		return t.Obj().Name(), nil
+	case *types.Basic:
+		switch {
+		case t.Info()&types.IsBoolean != 0:
+			return "boolean", nil
+		case t.Info()&types.IsString != 0:
+			return "string", nil
+		case t.Info()&types.IsNumeric != 0:
+			return "number", nil
+		case t.Kind() == types.UntypedNil:
+			return "null", nil
+		}
+		return "", fmt.Errorf("unsupported type %s", t)
+	case *types.Pointer:
+		elem, err := g.typeOf(t.Elem())
+		return elem + " | null", err
+	case *types.Slice:
+		if b, ok := t.Elem().Underlying().(*types.Basic); ok && b.Kind() == types.Byte {
+			return "string", nil // base64
+		}
+		return g.arrayOf(t.Elem())
+	case *types.Array:
+		return g.arrayOf(t.Elem())
+	case *types.Map:
+		value, err := g.typeOf(t.Elem())
+		return "Record<string, " + value + ">", err
+	case *types.Interface:
+		return "unknown", nil
+	case *types.Struct:
+		members, extends, err := g.members(t)
+		if err != nil {
+			return "", err
+		}
+		var b strings.Builder
+		for _, base := range extends {
+			b.WriteString(base + " & ")
+		}
+		b.WriteString("{ ")
+		for _, m := range members {
+			b.WriteString(m.String() + "; ")
+		}
+		b.WriteString("}")
+		return b.String(), nil
+	}
+	return "", fmt.Errorf("unsupported type %s", t)
+}
+
+// isData reports whether values of type t can be encoded as JSON: t is
+// not a function or a channel.
+func isData(t types.Type) bool {
+	switch t.Underlying().(type) {
+	case *types.Signature, *types.Chan:
+		return false
+	}
+	return true
+}
+
+// arrayOf returns the TypeScript array type of elements of type elem.
+func (g *generator) arrayOf(elem types.Type) (string, error) {
+	ts, err := g.typeOf(elem)
+	if strings.ContainsAny(ts, " |") {
+		ts = "(" + ts + ")"
+	}
+	return ts + "[]", err
+}
+
+// mapped returns the TypeScript type that a named type is mapped to by the
+// configuration.
+func (g *generator) mapped(obj *types.TypeName) (string, bool) {
+	if obj.Pkg() == nil {
+		return "", false
+	}
+	key := obj.Pkg().Path() + "." + obj.Name()
+	if ts, ok := g.config.TypeMap[key]; ok {
+		return ts, true
+	}
+	ts, ok := DefaultTypeMap[key]
+	return ts, ok
+}
+
+// typeParams formats the type parameters of a generic type.
+func (g *generator) typeParams(params *types.TypeParamList) string {
+	if params.Len() == 0 {
+		return ""
+	}
+	names := make([]string, params.Len())
+	for i := range params.Len() {
+		names[i] = params.At(i).Obj().Name()
+	}
+	return "<" + strings.Join(names, ", ") + ">"
+}
+
+// typeArgs formats the type arguments of an instantiated type.
+func (g *generator) typeArgs(args *types.TypeList) (string, error) {
+	if args.Len() == 0 {
+		return "", nil
+	}
+	list := make([]string, args.Len())
+	for i := range args.Len() {
+		ts, err := g.typeOf(args.At(i))
+		if err != nil {
+			return "", err
+		}
+		list[i] = ts
+	}
+	return "<" + strings.Join(list, ", ") + ">", nil
+}
+
+// literal formats a constant value as a TypeScript literal type, or
+// returns an empty string for values without one.
+func literal(v constant.Value) string {
+	switch v.Kind() {
+	case constant.String:
+		return strconv.Quote(constant.StringVal(v))
+	case constant.Int:
+		return v.ExactString()
+	case constant.Float:
+		f, _ := constant.Float64Val(v)
+		return strconv.FormatFloat(f, 'g', -1, 64)
+	case constant.Bool:
+		return strconv.FormatBool(constant.BoolVal(v))
+	}
+	return ""
+}
+
+// jsonTag splits a json struct tag into the name and options.
+func jsonTag(tag string) (name, opts string) {
+	name, opts, _ = strings.Cut(tag, ",")
+	return name, opts
+}
+
+// hasOption reports whether the comma-separated tag options include opt.
+func hasOption(opts, opt string) bool {
+	return slices.Contains(strings.Split(opts, ","), opt)
+}
+
+// propertyName quotes a property name that is not a valid identifier.
+func propertyName(name string) string {
+	for i, r := range name {
+		if !(r == '_' || r == '$' || 'a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || i > 0 && '0' <= r && r <= '9') {
+			return strconv.Quote(name)
+		}
+	}
+	return name
+}
@@ -0,0 +1,172 @@
+package fromgo
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const source = `package api
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type Status string
+
+const (
+	Active   Status = "active"
+	Disabled Status = "disabled"
+)
+
+type Level int
+
+const (
+	Low Level = iota
+	High
+)
+
+type Base struct {
+	ID      int64     ` + "`json:\"id\"`" + `
+	Created time.Time ` + "`json:\"created_at\"`" + `
+}
+
+type User struct {
+	Base
+	Name     string            ` + "`json:\"name\"`" + `
+	Email    string            ` + "`json:\"email,omitempty\"`" + `
+	Status   Status            ` + "`json:\"status\"`" + `
+	Tags     []string          ` + "`json:\"tags\"`" + `
+	Manager  *User             ` + "`json:\"manager\"`" + `
+	Meta     map[string]any    ` + "`json:\"meta\"`" + `
+	Count    int               ` + "`json:\"count,string\"`" + `
+	Raw      json.RawMessage   ` + "`json:\"raw\"`" + `
+	Password string            ` + "`json:\"-\"`" + `
+	OnChange func()
+	internal int
+	Dashed   bool              ` + "`json:\"is-admin\"`" + `
+}
+
+type Page[T any] struct {
+	Items []T ` + "`json:\"items\"`" + `
+	Next  *string
+}
+
+type UserPage = Page[User]
+
+type Handler func()
+
+type Store interface{ Get() }
+`
+
+func check(t *testing.T, src string) *types.Package {
+	t.Helper()
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "api.go", src, 0)
+	if err != nil {
+		t.Fatalf("ParseFile() error = %v", err)
+	}
+	conf := types.Config{Importer: importer.Default()}
+	pkg, err := conf.Check("example.com/api", fset, []*ast.File{f}, nil)
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	return pkg
+}
+
+func TestGenerate(t *testing.T) {
+	got, err := Generate(check(t, source))
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	want := `export type Status = "active" | "disabled";
+
+export type Level = 0 | 1;
+
+export interface Base {
+  id: number;
+  created_at: string;
+}
+
+export interface User extends Base {
+  name: string;
+  email?: string;
+  status: Status;
+  tags: string[];
+  manager: User | null;
+  meta: Record<string, unknown>;
+  count: string;
+  raw: unknown;
+  "is-admin": boolean;
+}
+
+export interface Page<T> {
+  items: T[];
+  Next: string | null;
+}
+
+export type UserPage = Page<User>;
+`
+	if got != want {
+		t.Errorf("Generate() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestGenerateNames(t *testing.T) {
+	config := &Config{
+		Names:    []string{"User"},
+		TypeMap:  map[string]string{"time.Time": "Date"},
+		Indent:   "\t",
+		NoExport: true,
+	}
+	got, err := config.Generate(check(t, source))
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	// User brings in the types it refers to, in declaration order
+	for _, want := range []string{"type Status =", "interface Base {\n\tid: number;\n\tcreated_at: Date;", "interface User extends Base"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Generate() missing %q in\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "export") || strings.Contains(got, "Page") || strings.Contains(got, "Level") {
+		t.Errorf("Generate() =\n%s", got)
+	}
+
+	if _, err := (&Config{Names: []string{"Missing"}}).Generate(check(t, source)); err == nil {
+		t.Error("Generate() of an unknown type: expected error")
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"point.go":      "package geo\n\ntype Point struct {\n\tX, Y float64\n}\n",
+		"point_test.go": "package geo\n\ntype Fixture struct{}\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	pkg, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	got, err := Generate(pkg)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if want := "export interface Point {\n  X: number;\n  Y: number;\n}\n"; got != want {
+		t.Errorf("Generate() = %q, want %q", got, want)
+	}
+}
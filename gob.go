@@ -0,0 +1,42 @@
+package tsgoast
+
+import (
+	"encoding/gob"
+	"fmt"
+	"io"
+
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+// gobTree is the gob-encoded form of a Tree.
+type gobTree struct {
+	Root        *ast.BaseNode
+	Diagnostics []Diagnostic
+}
+
+// EncodeTree writes tree and its diagnostics to w with encoding/gob, for
+// Go-native caches. Like MarshalJSON, typed statements are not stored,
+// since they are derived from the root.
+func EncodeTree(w io.Writer, tree *Tree) error {
+	if tree == nil || tree.Root == nil {
+		return fmt.Errorf("tree has no root node")
+	}
+	return gob.NewEncoder(w).Encode(gobTree{Root: tree.Root, Diagnostics: tree.Diagnostics})
+}
+
+// DecodeTree reads a tree written by EncodeTree, restoring parent pointers
+// and rebuilding the typed statements.
+func DecodeTree(r io.Reader) (*Tree, error) {
+	var g gobTree
+	if err := gob.NewDecoder(r).Decode(&g); err != nil {
+		return nil, err
+	}
+	if g.Root == nil {
+		return nil, fmt.Errorf("tree has no root node")
+	}
+	return &Tree{
+		Root:        g.Root,
+		Statements:  (&Parser{}).extractStatements(g.Root),
+		Diagnostics: g.Diagnostics,
+	}, nil
+}
@@ -0,0 +1,47 @@
+package tsgoast
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+func TestEncodeTreeRoundTrip(t *testing.T) {
+	parser, err := New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+	defer parser.Close()
+
+	tree, err := parser.ParseTree([]byte(`import { x } from "./x";
+export function load(id: string) { return x(id); }
+class Service {}
+`))
+	if err != nil {
+		t.Fatalf("ParseTree() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := EncodeTree(&buf, tree); err != nil {
+		t.Fatalf("EncodeTree() error = %v", err)
+	}
+	decoded, err := DecodeTree(&buf)
+	if err != nil {
+		t.Fatalf("DecodeTree() error = %v", err)
+	}
+
+	want, _ := json.Marshal(tree)
+	got, _ := json.Marshal(decoded)
+	if string(got) != string(want) {
+		t.Errorf("decoded tree differs:\n got %s\nwant %s", got, want)
+	}
+	if class, ok := decoded.Statements[2].(*ast.ClassDeclaration); !ok || class.Name != "Service" {
+		t.Errorf("statement 2 = %#v, want class Service", decoded.Statements[2])
+	}
+
+	if err := EncodeTree(&buf, &Tree{}); err == nil {
+		t.Error("EncodeTree() on empty tree: expected error")
+	}
+}
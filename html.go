@@ -0,0 +1,194 @@
+package tsgoast
+
+import (
+	"bufio"
+	"fmt"
+	"html/template"
+	"io"
+
+	"github.com/ahmadramadhannn/tsgoast/ast"
+	"github.com/ahmadramadhannn/tsgoast/internal/syntax"
+)
+
+// htmlNode is a node of the tree embedded in the HTML explorer. Start and
+// End are UTF-16 offsets into the source, as used by JavaScript strings.
+type htmlNode struct {
+	Kind     string      `json:"k"`
+	Field    string      `json:"f,omitempty"`
+	Detail   string      `json:"d,omitempty"`
+	Range    string      `json:"r"`
+	Start    uint32      `json:"s"`
+	End      uint32      `json:"e"`
+	Children []*htmlNode `json:"c,omitempty"`
+}
+
+// htmlPage is the data of the explorer template.
+type htmlPage struct {
+	Title  string
+	Source string
+	Root   *htmlNode
+}
+
+// WriteHTML writes a self-contained HTML page for exploring the syntax tree
+// of tree next to its source: hovering or selecting a node highlights its
+// source range, and clicking in the source selects the innermost node
+// covering that position. Anonymous tokens are omitted. source must be the
+// text tree was parsed from. The page has no external dependencies.
+func WriteHTML(w io.Writer, tree *Tree, source []byte) error {
+	if tree == nil || tree.Root == nil {
+		return fmt.Errorf("tree has no root node")
+	}
+
+	index := ast.NewUTF16Index(&ast.BaseNode{Content: string(source)})
+	var convert func(node ast.Node) *htmlNode
+	convert = func(node ast.Node) *htmlNode {
+		r := node.Range()
+		n := &htmlNode{
+			Kind:   node.SyntaxKind(),
+			Field:  ast.FieldOf(node),
+			Detail: nodeDetail(node),
+			Range:  formatRange(r),
+			Start:  index.Offset(r.Start.Offset),
+			End:    index.Offset(r.End.Offset),
+		}
+		for _, child := range node.Children() {
+			if !syntax.IsToken(child) {
+				n.Children = append(n.Children, convert(child))
+			}
+		}
+		return n
+	}
+
+	bw := bufio.NewWriter(w)
+	if err := htmlTemplate.Execute(bw, htmlPage{
+		Title:  "tsgoast AST explorer",
+		Source: string(source),
+		Root:   convert(tree.Root),
+	}); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+var htmlTemplate = template.Must(template.New("explorer").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { margin: 0; display: flex; height: 100vh; font: 13px/1.5 ui-monospace, Menlo, Consolas, monospace; }
+#tree, #source { flex: 1; overflow: auto; padding: 8px 12px; }
+#tree { border-right: 1px solid #ccc; }
+#source { margin: 0; white-space: pre; tab-size: 4; }
+ul { list-style: none; margin: 0; padding-left: 16px; }
+#tree > ul { padding-left: 0; }
+summary, .leaf { cursor: pointer; white-space: nowrap; }
+.leaf { padding-left: 14px; }
+.field { color: #8250df; }
+.kind { color: #0550ae; font-weight: bold; }
+.detail { color: #116329; }
+.range { color: #888; }
+.selected { background: #fff1a8; }
+mark { background: #fff1a8; }
+</style>
+</head>
+<body>
+<div id="tree"></div>
+<pre id="source"></pre>
+<script>
+const source = {{.Source}};
+const root = {{.Root}};
+const treePane = document.getElementById("tree");
+const sourcePane = document.getElementById("source");
+let selected = null;
+
+function label(node) {
+  const span = document.createElement("span");
+  const add = (cls, text) => {
+    const part = document.createElement("span");
+    part.className = cls;
+    part.textContent = text;
+    span.appendChild(part);
+  };
+  if (node.f) add("field", node.f + ": ");
+  add("kind", node.k);
+  if (node.d) add("detail", " " + node.d);
+  add("range", " [" + node.r + "]");
+  return span;
+}
+
+function render(node, parent) {
+  const item = document.createElement("li");
+  let head;
+  if (node.c) {
+    const details = document.createElement("details");
+    details.open = true;
+    head = document.createElement("summary");
+    details.appendChild(head);
+    const list = document.createElement("ul");
+    node.c.forEach(child => render(child, list));
+    details.appendChild(list);
+    item.appendChild(details);
+  } else {
+    head = document.createElement("div");
+    head.className = "leaf";
+    item.appendChild(head);
+  }
+  head.appendChild(label(node));
+  head.addEventListener("mouseenter", () => highlight(node));
+  head.addEventListener("mouseleave", () => highlight(selected && selected.node));
+  head.addEventListener("click", () => select(node));
+  node.head = head;
+  parent.appendChild(item);
+}
+
+function highlight(node) {
+  sourcePane.textContent = "";
+  if (!node) {
+    sourcePane.textContent = source;
+    return;
+  }
+  const mark = document.createElement("mark");
+  mark.textContent = source.slice(node.s, node.e);
+  sourcePane.append(source.slice(0, node.s), mark, source.slice(node.e));
+  mark.scrollIntoView({block: "nearest"});
+}
+
+function select(node) {
+  if (selected) selected.head.classList.remove("selected");
+  selected = node && {node: node, head: node.head};
+  if (node) {
+    node.head.classList.add("selected");
+    for (let el = node.head.parentElement; el; el = el.parentElement) {
+      if (el.tagName === "DETAILS") el.open = true;
+    }
+    node.head.scrollIntoView({block: "nearest"});
+  }
+  highlight(node);
+}
+
+function nodeAt(node, offset) {
+  for (const child of node.c || []) {
+    if (child.s <= offset && offset < child.e) return nodeAt(child, offset);
+  }
+  return node;
+}
+
+sourcePane.addEventListener("click", () => {
+  const sel = window.getSelection();
+  if (!sel.rangeCount) return;
+  const range = sel.getRangeAt(0);
+  const before = document.createRange();
+  before.selectNodeContents(sourcePane);
+  before.setEnd(range.startContainer, range.startOffset);
+  select(nodeAt(root, before.toString().length));
+});
+
+const list = document.createElement("ul");
+render(root, list);
+treePane.appendChild(list);
+highlight(null);
+</script>
+</body>
+</html>
+`))
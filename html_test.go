@@ -0,0 +1,49 @@
+package tsgoast
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWriteHTML(t *testing.T) {
+	parser, err := New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+	defer parser.Close()
+
+	source := []byte(`const s = "😀</script><b>";
+function greet() {}
+`)
+	tree, err := parser.ParseTree(source)
+	if err != nil {
+		t.Fatalf("ParseTree() error = %v", err)
+	}
+
+	var out strings.Builder
+	if err := WriteHTML(&out, tree, source); err != nil {
+		t.Fatalf("WriteHTML() error = %v", err)
+	}
+	page := out.String()
+
+	for _, want := range []string{
+		"<!DOCTYPE html>",
+		`"f":"name"`,
+		// the function starts at byte 30, UTF-16 offset 28
+		`"k":"function_declaration","d":"greet","r":"2:0-2:19","s":28`,
+	} {
+		if !strings.Contains(page, want) {
+			t.Errorf("WriteHTML() output missing %q", want)
+		}
+	}
+	if strings.Count(page, "</script>") != 1 {
+		t.Error("source text was not escaped inside the script element")
+	}
+	if strings.Contains(page, `"k":"("`) {
+		t.Error("anonymous tokens should be omitted")
+	}
+
+	if err := WriteHTML(&out, &Tree{}, nil); err == nil {
+		t.Error("WriteHTML() on empty tree: expected error")
+	}
+}
@@ -0,0 +1,186 @@
+// Package msgpack reads and writes the subset of MessagePack used by the
+// encoders of ast.BaseNode and tsgoast.Tree.
+package msgpack
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// ErrTruncated is the error reading data that ends early.
+var ErrTruncated = errors.New("msgpack: truncated data")
+
+// Nil is the encoding of nil.
+const Nil = 0xc0
+
+// Reader reads MessagePack values.
+type Reader struct {
+	B []byte // left to read
+}
+
+// next returns the next n bytes.
+func (r *Reader) next(n int) ([]byte, error) {
+	if n < 0 || n > len(r.B) {
+		return nil, ErrTruncated
+	}
+	b := r.B[:n]
+	r.B = r.B[n:]
+	return b, nil
+}
+
+// length reads a big-endian length of n bytes.
+func (r *Reader) length(n int) (int, error) {
+	b, err := r.next(n)
+	if err != nil {
+		return 0, err
+	}
+	switch n {
+	case 1:
+		return int(b[0]), nil
+	case 2:
+		return int(binary.BigEndian.Uint16(b)), nil
+	default:
+		return int(binary.BigEndian.Uint32(b)), nil
+	}
+}
+
+// SkipNil consumes a nil value and reports whether there was one.
+func (r *Reader) SkipNil() bool {
+	if len(r.B) > 0 && r.B[0] == Nil {
+		r.B = r.B[1:]
+		return true
+	}
+	return false
+}
+
+// Unsigned reads an unsigned integer.
+func (r *Reader) Unsigned() (uint64, error) {
+	b, err := r.next(1)
+	if err != nil {
+		return 0, err
+	}
+	switch c := b[0]; {
+	case c <= 0x7f:
+		return uint64(c), nil
+	case c == 0xcc:
+		v, err := r.length(1)
+		return uint64(v), err
+	case c == 0xcd:
+		v, err := r.length(2)
+		return uint64(v), err
+	case c == 0xce:
+		b, err := r.next(4)
+		if err != nil {
+			return 0, err
+		}
+		return uint64(binary.BigEndian.Uint32(b)), nil
+	case c == 0xcf:
+		b, err := r.next(8)
+		if err != nil {
+			return 0, err
+		}
+		return binary.BigEndian.Uint64(b), nil
+	default:
+		return 0, fmt.Errorf("msgpack: expected unsigned integer, got 0x%02x", c)
+	}
+}
+
+// Str reads a string.
+func (r *Reader) Str() (string, error) {
+	b, err := r.next(1)
+	if err != nil {
+		return "", err
+	}
+	var n int
+	switch c := b[0]; {
+	case c >= 0xa0 && c <= 0xbf:
+		n = int(c & 0x1f)
+	case c == 0xd9:
+		n, err = r.length(1)
+	case c == 0xda:
+		n, err = r.length(2)
+	case c == 0xdb:
+		n, err = r.length(4)
+	default:
+		return "", fmt.Errorf("msgpack: expected string, got 0x%02x", c)
+	}
+	if err != nil {
+		return "", err
+	}
+	s, err := r.next(n)
+	return string(s), err
+}
+
+// Array reads the header of an array and returns its length.
+func (r *Reader) Array() (int, error) {
+	b, err := r.next(1)
+	if err != nil {
+		return 0, err
+	}
+	switch c := b[0]; {
+	case c >= 0x90 && c <= 0x9f:
+		return int(c & 0x0f), nil
+	case c == 0xdc:
+		return r.length(2)
+	case c == 0xdd:
+		return r.length(4)
+	default:
+		return 0, fmt.Errorf("msgpack: expected array, got 0x%02x", c)
+	}
+}
+
+// ExpectArray reads the header of an array of n elements.
+func (r *Reader) ExpectArray(n int) error {
+	count, err := r.Array()
+	if err != nil {
+		return err
+	}
+	if count != n {
+		return fmt.Errorf("msgpack: expected array of %d elements, got %d", n, count)
+	}
+	return nil
+}
+
+// AppendUint appends v in the smallest unsigned integer format.
+func AppendUint(buf []byte, v uint64) []byte {
+	switch {
+	case v <= 0x7f:
+		return append(buf, byte(v))
+	case v <= 0xff:
+		return append(buf, 0xcc, byte(v))
+	case v <= 0xffff:
+		return binary.BigEndian.AppendUint16(append(buf, 0xcd), uint16(v))
+	case v <= 0xffffffff:
+		return binary.BigEndian.AppendUint32(append(buf, 0xce), uint32(v))
+	default:
+		return binary.BigEndian.AppendUint64(append(buf, 0xcf), v)
+	}
+}
+
+// AppendString appends s in the smallest string format.
+func AppendString(buf []byte, s string) []byte {
+	switch n := len(s); {
+	case n <= 31:
+		buf = append(buf, 0xa0|byte(n))
+	case n <= 0xff:
+		buf = append(buf, 0xd9, byte(n))
+	case n <= 0xffff:
+		buf = binary.BigEndian.AppendUint16(append(buf, 0xda), uint16(n))
+	default:
+		buf = binary.BigEndian.AppendUint32(append(buf, 0xdb), uint32(n))
+	}
+	return append(buf, s...)
+}
+
+// AppendArray appends the header of an array of n elements.
+func AppendArray(buf []byte, n int) []byte {
+	switch {
+	case n <= 15:
+		return append(buf, 0x90|byte(n))
+	case n <= 0xffff:
+		return binary.BigEndian.AppendUint16(append(buf, 0xdc), uint16(n))
+	default:
+		return binary.BigEndian.AppendUint32(append(buf, 0xdd), uint32(n))
+	}
+}
@@ -0,0 +1,43 @@
+package msgpack
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRoundTrip(t *testing.T) {
+	values := []uint64{0, 0x7f, 0xff, 0xffff, 0xffffffff, 1 << 40}
+	strs := []string{"", "short", strings.Repeat("x", 300), strings.Repeat("y", 70000)}
+
+	buf := AppendArray(nil, len(values)+len(strs)+1)
+	for _, v := range values {
+		buf = AppendUint(buf, v)
+	}
+	for _, s := range strs {
+		buf = AppendString(buf, s)
+	}
+	buf = append(buf, Nil)
+
+	r := &Reader{B: buf}
+	if err := r.ExpectArray(len(values) + len(strs) + 1); err != nil {
+		t.Fatalf("ExpectArray() error = %v", err)
+	}
+	for _, want := range values {
+		if got, err := r.Unsigned(); got != want || err != nil {
+			t.Errorf("Unsigned() = %d, %v, want %d", got, err, want)
+		}
+	}
+	for _, want := range strs {
+		if got, err := r.Str(); got != want || err != nil {
+			t.Errorf("Str() = %d bytes, %v, want %d", len(got), err, len(want))
+		}
+	}
+	if !r.SkipNil() || len(r.B) != 0 {
+		t.Errorf("SkipNil() left %d bytes", len(r.B))
+	}
+
+	if _, err := (&Reader{B: AppendString(nil, "abc")[:2]}).Str(); !errors.Is(err, ErrTruncated) {
+		t.Errorf("Str() of truncated data error = %v", err)
+	}
+}
@@ -0,0 +1,186 @@
+// Package protowire reads and writes the Protocol Buffers wire format, for
+// the encoders of ast.BaseNode and tsgoast.Tree, which are written by hand
+// so that tsgoast does not depend on a protobuf runtime.
+package protowire
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/bits"
+)
+
+// Wire types.
+const (
+	Varint  = 0
+	Fixed64 = 1
+	Bytes   = 2
+	Fixed32 = 5
+)
+
+// Field numbers of the Position message.
+const (
+	positionLine   = 1
+	positionColumn = 2
+	positionOffset = 3
+)
+
+// ErrTruncated is the error reading a message that ends early.
+var ErrTruncated = errors.New("proto: truncated message")
+
+// Reader reads the fields of a message.
+type Reader struct {
+	B []byte // left to read
+}
+
+// Done reports whether every field was read.
+func (r *Reader) Done() bool {
+	return len(r.B) == 0
+}
+
+// Varint reads a varint.
+func (r *Reader) Varint() (uint64, error) {
+	v, n := binary.Uvarint(r.B)
+	if n <= 0 {
+		return 0, ErrTruncated
+	}
+	r.B = r.B[n:]
+	return v, nil
+}
+
+// Tag reads the number and wire type of the next field.
+func (r *Reader) Tag() (field, wire uint64, err error) {
+	key, err := r.Varint()
+	if err != nil {
+		return 0, 0, err
+	}
+	return key >> 3, key & 7, nil
+}
+
+// Bytes reads the value of a length-delimited field.
+func (r *Reader) Bytes() ([]byte, error) {
+	n, err := r.Varint()
+	if err != nil {
+		return nil, err
+	}
+	if n > uint64(len(r.B)) {
+		return nil, ErrTruncated
+	}
+	b := r.B[:n]
+	r.B = r.B[n:]
+	return b, nil
+}
+
+// Skip skips the value of an unknown field.
+func (r *Reader) Skip(wire uint64) error {
+	var n int
+	switch wire {
+	case Varint:
+		_, err := r.Varint()
+		return err
+	case Bytes:
+		_, err := r.Bytes()
+		return err
+	case Fixed64:
+		n = 8
+	case Fixed32:
+		n = 4
+	default:
+		return fmt.Errorf("proto: unsupported wire type %d", wire)
+	}
+	if len(r.B) < n {
+		return ErrTruncated
+	}
+	r.B = r.B[n:]
+	return nil
+}
+
+// AppendTag appends the key of a field.
+func AppendTag(buf []byte, field, wire uint64) []byte {
+	return binary.AppendUvarint(buf, field<<3|wire)
+}
+
+// AppendVarint appends a varint field, omitting zero values.
+func AppendVarint(buf []byte, field, v uint64) []byte {
+	if v == 0 {
+		return buf
+	}
+	buf = AppendTag(buf, field, Varint)
+	return binary.AppendUvarint(buf, v)
+}
+
+// AppendString appends a length-delimited field.
+func AppendString(buf []byte, field uint64, s string) []byte {
+	buf = AppendTag(buf, field, Bytes)
+	buf = binary.AppendUvarint(buf, uint64(len(s)))
+	return append(buf, s...)
+}
+
+// AppendPosition appends a Position field of ast.proto, omitting the zero
+// position.
+func AppendPosition(buf []byte, field uint64, line, column, offset uint32) []byte {
+	size := PositionSize(line, column, offset)
+	if size == 0 {
+		return buf
+	}
+	buf = AppendTag(buf, field, Bytes)
+	buf = binary.AppendUvarint(buf, uint64(size))
+	buf = AppendVarint(buf, positionLine, uint64(line))
+	buf = AppendVarint(buf, positionColumn, uint64(column))
+	return AppendVarint(buf, positionOffset, uint64(offset))
+}
+
+// PositionSize returns the encoded size of a Position message.
+func PositionSize(line, column, offset uint32) int {
+	return VarintSize(positionLine, uint64(line)) +
+		VarintSize(positionColumn, uint64(column)) +
+		VarintSize(positionOffset, uint64(offset))
+}
+
+// DecodePosition decodes a Position message.
+func DecodePosition(data []byte) (line, column, offset uint32, err error) {
+	r := Reader{B: data}
+	for !r.Done() {
+		field, wire, err := r.Tag()
+		if err != nil {
+			return 0, 0, 0, err
+		}
+		if wire != Varint || field < positionLine || field > positionOffset {
+			if err := r.Skip(wire); err != nil {
+				return 0, 0, 0, err
+			}
+			continue
+		}
+		v, err := r.Varint()
+		if err != nil {
+			return 0, 0, 0, err
+		}
+		switch field {
+		case positionLine:
+			line = uint32(v)
+		case positionColumn:
+			column = uint32(v)
+		default:
+			offset = uint32(v)
+		}
+	}
+	return line, column, offset, nil
+}
+
+// VarintSize returns the encoded size of a varint field, which is zero for
+// zero values.
+func VarintSize(field, v uint64) int {
+	if v == 0 {
+		return 0
+	}
+	return uvarintSize(field<<3) + uvarintSize(v)
+}
+
+// BytesSize returns the encoded size of a length-delimited field.
+func BytesSize(field uint64, n int) int {
+	return uvarintSize(field<<3) + uvarintSize(uint64(n)) + n
+}
+
+func uvarintSize(v uint64) int {
+	return (bits.Len64(v|1) + 6) / 7
+}
@@ -0,0 +1,51 @@
+package protowire
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRoundTrip(t *testing.T) {
+	var buf []byte
+	buf = AppendVarint(buf, 1, 0) // omitted
+	buf = AppendVarint(buf, 2, 300)
+	buf = AppendString(buf, 3, "text")
+	buf = AppendPosition(buf, 4, 2, 5, 40)
+	buf = AppendPosition(buf, 5, 0, 0, 0) // omitted
+	want := VarintSize(2, 300) + BytesSize(3, 4) + BytesSize(4, PositionSize(2, 5, 40))
+	if len(buf) != want {
+		t.Errorf("encoded %d bytes, want %d", len(buf), want)
+	}
+
+	r := Reader{B: buf}
+	if field, wire, err := r.Tag(); field != 2 || wire != Varint || err != nil {
+		t.Fatalf("Tag() = %d, %d, %v", field, wire, err)
+	}
+	if v, err := r.Varint(); v != 300 || err != nil {
+		t.Errorf("Varint() = %d, %v", v, err)
+	}
+	if _, wire, _ := r.Tag(); r.Skip(wire) != nil {
+		t.Error("Skip() failed")
+	}
+	if _, _, err := r.Tag(); err != nil {
+		t.Fatalf("Tag() error = %v", err)
+	}
+	b, err := r.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes() error = %v", err)
+	}
+	if line, column, offset, err := DecodePosition(b); line != 2 || column != 5 || offset != 40 || err != nil {
+		t.Errorf("DecodePosition() = %d:%d@%d, %v", line, column, offset, err)
+	}
+	if !r.Done() {
+		t.Errorf("%d bytes left", len(r.B))
+	}
+
+	r = Reader{B: AppendString(nil, 1, "text")[:3]}
+	if _, _, err := r.Tag(); err != nil {
+		t.Fatalf("Tag() error = %v", err)
+	}
+	if _, err := r.Bytes(); !errors.Is(err, ErrTruncated) {
+		t.Errorf("Bytes() of a truncated message error = %v", err)
+	}
+}
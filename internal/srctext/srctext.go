@@ -0,0 +1,25 @@
+// Package srctext holds the helpers shared by the packages editing source
+// text around the nodes of a tree, such as transform and refactor.
+package srctext
+
+import (
+	"strings"
+
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+// RootText returns the text of the tree containing node and the offset it
+// starts at.
+func RootText(node ast.Node) (string, uint32) {
+	for node.Parent() != nil {
+		node = node.Parent()
+	}
+	return node.Text(), node.Range().Start.Offset
+}
+
+// LineIndent returns the indentation of the line containing byte i of
+// text.
+func LineIndent(text string, i int) string {
+	line := text[strings.LastIndexByte(text[:i], '\n')+1:]
+	return line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+}
@@ -0,0 +1,44 @@
+package srctext
+
+import (
+	"testing"
+
+	"github.com/ahmadramadhannn/tsgoast"
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+func TestRootText(t *testing.T) {
+	parser, err := tsgoast.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer parser.Close()
+	src := "class A {\n\tm() {\n\t\treturn 1;\n\t}\n}\n"
+	root, err := parser.Parse([]byte(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var ret ast.Node
+	for node := range ast.Preorder(root) {
+		if node.SyntaxKind() == "return_statement" {
+			ret = node
+		}
+	}
+	text, base := RootText(ret)
+	if text != src || base != 0 {
+		t.Errorf("RootText() = %q, %d, want the whole source", text, base)
+	}
+	if got := LineIndent(text, int(ret.Range().Start.Offset)); got != "\t\t" {
+		t.Errorf("LineIndent() = %q, want two tabs", got)
+	}
+}
+
+func TestLineIndent(t *testing.T) {
+	text := "a\n  b\n\tc"
+	for i, want := range map[int]string{0: "", 4: "  ", 7: "\t", 3: "  "} {
+		if got := LineIndent(text, i); got != want {
+			t.Errorf("LineIndent(%d) = %q, want %q", i, got, want)
+		}
+	}
+}
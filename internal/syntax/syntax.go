@@ -0,0 +1,76 @@
+// Package syntax holds the helpers shared by the packages converting the
+// tree-sitter syntax tree to other shapes, such as estree and tscompat:
+// telling anonymous tokens from syntax nodes and naming kinds.
+package syntax
+
+import (
+	"strings"
+
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+// literalKeywords are named nodes whose text equals their kind.
+var literalKeywords = map[string]bool{
+	"this": true, "super": true, "null": true, "true": true, "false": true, "undefined": true,
+}
+
+// IsToken reports whether n is an anonymous token such as a keyword or
+// punctuation: a leaf whose text is its own kind. Literal keywords such as
+// true and null are syntax nodes.
+func IsToken(n ast.Node) bool {
+	return len(n.Children()) == 0 && n.Text() == n.SyntaxKind() && !literalKeywords[n.SyntaxKind()]
+}
+
+// HasToken reports whether n has a direct token child with the given text.
+func HasToken(n ast.Node, token string) bool {
+	for _, child := range n.Children() {
+		if child.SyntaxKind() == token && IsToken(child) {
+			return true
+		}
+	}
+	return false
+}
+
+// Named returns the syntax node children of n, skipping tokens and
+// comments.
+func Named(n ast.Node) []ast.Node {
+	var nodes []ast.Node
+	for _, child := range n.Children() {
+		if child.SyntaxKind() == "comment" || IsToken(child) {
+			continue
+		}
+		nodes = append(nodes, child)
+	}
+	return nodes
+}
+
+// FirstNamed returns the first syntax node child of n, or nil.
+func FirstNamed(n ast.Node) ast.Node {
+	if nodes := Named(n); len(nodes) > 0 {
+		return nodes[0]
+	}
+	return nil
+}
+
+// FieldText returns the text of the child of n in the given field, or "".
+func FieldText(n ast.Node, field string) string {
+	if child := ast.ChildByField(n, field); child != nil {
+		return child.Text()
+	}
+	return ""
+}
+
+// PascalCase converts a tree-sitter kind such as "jsx_element" to
+// "JsxElement", which matches the name of most kinds in TypeScript and
+// ESTree.
+func PascalCase(kind string) string {
+	var b strings.Builder
+	for _, part := range strings.Split(kind, "_") {
+		if part == "" {
+			continue
+		}
+		b.WriteString(strings.ToUpper(part[:1]))
+		b.WriteString(part[1:])
+	}
+	return b.String()
+}
@@ -0,0 +1,66 @@
+package syntax_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/ahmadramadhannn/tsgoast"
+	"github.com/ahmadramadhannn/tsgoast/ast"
+	"github.com/ahmadramadhannn/tsgoast/internal/syntax"
+)
+
+func TestNamed(t *testing.T) {
+	parser, err := tsgoast.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer parser.Close()
+	root, err := parser.Parse([]byte("async function f(/* none */) { return this; }\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	fn := root.Children()[0]
+
+	var kinds []string
+	for _, n := range syntax.Named(fn) {
+		kinds = append(kinds, n.SyntaxKind())
+	}
+	if got, want := kinds, []string{"identifier", "formal_parameters", "statement_block"}; !slices.Equal(got, want) {
+		t.Errorf("syntax.Named() = %v, want %v", got, want)
+	}
+	if got := syntax.FirstNamed(fn); got == nil || got.Text() != "f" {
+		t.Errorf("syntax.FirstNamed() = %v, want f", got)
+	}
+	if len(syntax.Named(ast.ChildByField(fn, "parameters"))) != 0 {
+		t.Error("syntax.Named() kept a comment")
+	}
+	if !syntax.HasToken(fn, "async") || syntax.HasToken(fn, "function*") {
+		t.Error("syntax.HasToken() is wrong")
+	}
+	if syntax.FieldText(fn, "name") != "f" || syntax.FieldText(fn, "return_type") != "" {
+		t.Errorf("syntax.FieldText() = %q, %q", syntax.FieldText(fn, "name"), syntax.FieldText(fn, "return_type"))
+	}
+
+	var this ast.Node
+	for n := range ast.Preorder(fn) {
+		if n.SyntaxKind() == "this" {
+			this = n
+		}
+	}
+	if this == nil || syntax.IsToken(this) {
+		t.Errorf("syntax.IsToken(this) = true, want a syntax node")
+	}
+}
+
+func TestPascalCase(t *testing.T) {
+	for kind, want := range map[string]string{
+		"jsx_element":      "JsxElement",
+		"identifier":       "Identifier",
+		"_private_kind":    "PrivateKind",
+		"for_in_statement": "ForInStatement",
+	} {
+		if got := syntax.PascalCase(kind); got != want {
+			t.Errorf("syntax.PascalCase(%q) = %q, want %q", kind, got, want)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package tsgoast
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+// jsonTree is the serialized form of a Tree.
+type jsonTree struct {
+	Root        *ast.BaseNode `json:"root"`
+	Diagnostics []Diagnostic  `json:"diagnostics,omitempty"`
+}
+
+// MarshalJSON encodes the syntax tree of t and its diagnostics. Typed
+// statements are not stored, since they are derived from the root. Trees
+// nested deeper than ast.MaxJSONDepth are refused, which Limits with a
+// MaxDepth no greater than it rule out.
+func (t *Tree) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonTree{Root: t.Root, Diagnostics: t.Diagnostics})
+}
+
+// UnmarshalJSON decodes a tree produced by MarshalJSON, restoring parent
+// pointers and rebuilding the typed statements, so a cached tree can be
+// used exactly like a freshly parsed one.
+func (t *Tree) UnmarshalJSON(data []byte) error {
+	var j jsonTree
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+	if j.Root == nil {
+		return fmt.Errorf("tree has no root node")
+	}
+
+	t.Root = j.Root
+	t.Diagnostics = j.Diagnostics
+	t.Statements = (&Parser{}).extractStatements(j.Root)
+	return nil
+}
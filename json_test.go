@@ -0,0 +1,70 @@
+package tsgoast
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+func TestTreeJSONRoundTrip(t *testing.T) {
+	parser, err := New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+	defer parser.Close()
+
+	source := `import { x } from "./x";
+export async function load(id: string): Promise<void> {}
+class Service {}
+`
+	tree, err := parser.ParseTree([]byte(source))
+	if err != nil {
+		t.Fatalf("ParseTree() error = %v", err)
+	}
+
+	data, err := json.Marshal(tree)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	var decoded Tree
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if decoded.Root.Text() != tree.Root.Text() || decoded.Root.SyntaxKind() != "program" {
+		t.Errorf("decoded root = %q (%s)", decoded.Root.Text(), decoded.Root.SyntaxKind())
+	}
+	if len(decoded.Statements) != len(tree.Statements) {
+		t.Fatalf("decoded %d statements, want %d", len(decoded.Statements), len(tree.Statements))
+	}
+	for i, stmt := range tree.Statements {
+		got := decoded.Statements[i]
+		if got.SyntaxKind() != stmt.SyntaxKind() || got.Range() != stmt.Range() {
+			t.Errorf("statement %d = %s %v, want %s %v", i, got.SyntaxKind(), got.Range(), stmt.SyntaxKind(), stmt.Range())
+		}
+	}
+	if class, ok := decoded.Statements[2].(*ast.ClassDeclaration); !ok || class.Name != "Service" {
+		t.Errorf("statement 2 = %#v, want class Service", decoded.Statements[2])
+	}
+
+	// Parent pointers and field names survive the round trip
+	offset := uint32(strings.Index(source, "id:"))
+	fn := decoded.NodeCovering(ast.Range{
+		Start: ast.Position{Offset: offset},
+		End:   ast.Position{Offset: offset + 2},
+	})
+	if fn == nil || fn.Text() != "id" || fn.Parent().SyntaxKind() != "required_parameter" {
+		t.Fatalf("NodeCovering() = %v", fn)
+	}
+	decl := fn.Parent().Parent().Parent()
+	if name := ast.ChildByField(decl, "name"); name == nil || name.Text() != "load" {
+		t.Errorf("ChildByField(name) = %v, want load", name)
+	}
+
+	if err := json.Unmarshal([]byte(`{}`), &decoded); err == nil {
+		t.Error("Unmarshal() without root should fail")
+	}
+}
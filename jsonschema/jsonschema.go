@@ -0,0 +1,507 @@
+// Package jsonschema generates JSON Schema documents (draft 2020-12) from
+// the interfaces, type aliases and enums of a TypeScript file, to validate
+// at runtime the payloads whose types are declared in TypeScript:
+//
+//	interface User {
+//	  readonly id: number;
+//	  role: "admin" | "user";
+//	  tags?: string[];
+//	}
+//
+// becomes
+//
+//	{
+//	  "type": "object",
+//	  "properties": {
+//	    "id": {"type": "number", "readOnly": true},
+//	    "role": {"enum": ["admin", "user"]},
+//	    "tags": {"type": "array", "items": {"type": "string"}}
+//	  },
+//	  "required": ["id", "role"]
+//	}
+//
+// Types declared in the file are referenced through $defs. Generic types
+// are expanded for each use. Methods are left out, as they are not data;
+// function types and type operators such as keyof and mapped types cannot
+// be represented and make generation fail.
+package jsonschema
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ahmadramadhannn/tsgoast"
+	"github.com/ahmadramadhannn/tsgoast/analyzer"
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+// Draft is the $schema URI of the generated documents.
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema is a JSON Schema or subschema. The empty schema accepts any
+// value.
+type Schema struct {
+	Schema      string `json:"$schema,omitempty"`
+	Ref         string `json:"$ref,omitempty"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+
+	Type   string `json:"type,omitempty"`
+	Format string `json:"format,omitempty"`
+	Const  any    `json:"const,omitempty"`
+	Enum   []any  `json:"enum,omitempty"`
+
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+
+	Items       *Schema   `json:"items,omitempty"`
+	PrefixItems []*Schema `json:"prefixItems,omitempty"`
+	MinItems    *int      `json:"minItems,omitempty"`
+	MaxItems    *int      `json:"maxItems,omitempty"`
+
+	AnyOf []*Schema `json:"anyOf,omitempty"`
+	AllOf []*Schema `json:"allOf,omitempty"`
+	Not   *Schema   `json:"not,omitempty"`
+
+	ReadOnly bool `json:"readOnly,omitempty"`
+
+	Defs map[string]*Schema `json:"$defs,omitempty"`
+}
+
+// Config controls schema generation. The zero value generates standalone
+// documents, with the declarations they refer to in $defs.
+type Config struct {
+	// RefPrefix is prepended to the names of declared types to reference
+	// them, "#/$defs/" by default. Documents that hold the definitions
+	// elsewhere, such as OpenAPI components, set their own prefix.
+	RefPrefix string
+}
+
+// Generate returns the schema of the type declared as name in tree, with
+// the declarations it refers to in $defs.
+func Generate(tree *tsgoast.Tree, name string) (*Schema, error) {
+	return (&Config{}).Generate(tree, name)
+}
+
+// GenerateAll returns a schema document defining every non-generic
+// interface, type alias and enum of tree in $defs.
+func GenerateAll(tree *tsgoast.Tree) (*Schema, error) {
+	return (&Config{}).GenerateAll(tree)
+}
+
+// Generate returns the schema of the type declared as name in tree, with
+// the declarations it refers to in $defs.
+func (c *Config) Generate(tree *tsgoast.Tree, name string) (*Schema, error) {
+	g, err := c.newGenerator(tree)
+	if err != nil {
+		return nil, err
+	}
+	decl, ok := g.decls[name]
+	if !ok {
+		return nil, fmt.Errorf("jsonschema: %s is not declared", name)
+	}
+	schema, err := g.declaration(decl, nil)
+	if err != nil {
+		return nil, fmt.Errorf("jsonschema: %w", err)
+	}
+	schema.Schema = Draft
+	schema.Title = name
+	if len(g.defs) > 0 {
+		schema.Defs = g.defs
+	}
+	return schema, nil
+}
+
+// GenerateAll returns a schema document defining every non-generic
+// interface, type alias and enum of tree in $defs.
+func (c *Config) GenerateAll(tree *tsgoast.Tree) (*Schema, error) {
+	g, err := c.newGenerator(tree)
+	if err != nil {
+		return nil, err
+	}
+	for name, decl := range g.decls {
+		if len(typeParameters(decl)) == 0 {
+			if _, err := g.reference(name, nil); err != nil {
+				return nil, fmt.Errorf("jsonschema: %w", err)
+			}
+		}
+	}
+	return &Schema{Schema: Draft, Defs: g.defs}, nil
+}
+
+// TypeSchema returns the schema of a type expression of tree, such as the
+// type of a parameter, and the definitions of the declared types it refers
+// to.
+func (c *Config) TypeSchema(tree *tsgoast.Tree, t *analyzer.TypeExpr) (*Schema, map[string]*Schema, error) {
+	g, err := c.newGenerator(tree)
+	if err != nil {
+		return nil, nil, err
+	}
+	schema, err := g.typeSchema(t, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("jsonschema: %w", err)
+	}
+	return schema, g.defs, nil
+}
+
+// generator holds the declarations of a file and the definitions
+// generated so far.
+type generator struct {
+	refPrefix string
+	decls     map[string]ast.Node
+	enums     map[ast.Node]analyzer.Enum
+	defs      map[string]*Schema
+}
+
+func (c *Config) newGenerator(tree *tsgoast.Tree) (*generator, error) {
+	if tree == nil || tree.Root == nil {
+		return nil, fmt.Errorf("jsonschema: tree has no root node")
+	}
+	g := &generator{
+		refPrefix: c.RefPrefix,
+		decls:     make(map[string]ast.Node),
+		enums:     make(map[ast.Node]analyzer.Enum),
+		defs:      make(map[string]*Schema),
+	}
+	if g.refPrefix == "" {
+		g.refPrefix = "#/$defs/"
+	}
+	for node := range ast.Preorder(tree.Root) {
+		switch node.SyntaxKind() {
+		case "interface_declaration", "type_alias_declaration", "enum_declaration":
+			if name := ast.ChildByField(node, "name"); name != nil {
+				if _, ok := g.decls[name.Text()]; !ok {
+					g.decls[name.Text()] = node
+				}
+			}
+		}
+	}
+	for _, e := range analyzer.New(tree.Root).FindEnums() {
+		g.enums[e.Node] = e
+	}
+	return g, nil
+}
+
+// reference returns the schema of a use of the declared type name with
+// the given type arguments. Non-generic declarations are added to $defs
+// and referenced; generic ones are expanded in place.
+func (g *generator) reference(name string, args []*Schema) (*Schema, error) {
+	decl := g.decls[name]
+	if params := typeParameters(decl); len(params) > 0 {
+		if len(args) != len(params) {
+			return nil, fmt.Errorf("%s expects %d type arguments", name, len(params))
+		}
+		bindings := make(map[string]*Schema, len(params))
+		for i, param := range params {
+			bindings[param] = args[i]
+		}
+		return g.declaration(decl, bindings)
+	}
+
+	ref := &Schema{Ref: g.refPrefix + name}
+	if _, ok := g.defs[name]; ok {
+		return ref, nil
+	}
+	// Reserve the definition first, so recursive types refer to it
+	g.defs[name] = &Schema{}
+	schema, err := g.declaration(decl, nil)
+	if err != nil {
+		delete(g.defs, name)
+		return nil, err
+	}
+	g.defs[name] = schema
+	return ref, nil
+}
+
+// declaration returns the schema of an interface, type alias or enum
+// declaration, with type parameters bound to bindings.
+func (g *generator) declaration(decl ast.Node, bindings map[string]*Schema) (*Schema, error) {
+	name := ast.ChildByField(decl, "name").Text()
+	var schema *Schema
+	var err error
+	switch decl.SyntaxKind() {
+	case "interface_declaration":
+		schema, err = g.interfaceSchema(decl, bindings)
+	case "type_alias_declaration":
+		def := analyzer.GetTypeAliasDefinition(decl)
+		schema, err = g.typeSchema(def.Type, bindings)
+	case "enum_declaration":
+		schema = g.enumSchema(g.enums[decl])
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	if doc := analyzer.GetDoc(decl); doc != nil && doc.Description != "" && schema.Ref == "" {
+		described := *schema
+		described.Description = doc.Description
+		schema = &described
+	}
+	return schema, nil
+}
+
+// interfaceSchema returns the schema of an interface: its members, along
+// with those of the interfaces it extends.
+func (g *generator) interfaceSchema(decl ast.Node, bindings map[string]*Schema) (*Schema, error) {
+	own, err := g.typeSchema(analyzer.ParseTypeExpr(ast.ChildByField(decl, "body")), bindings)
+	if err != nil {
+		return nil, err
+	}
+	heritage := analyzer.GetHeritage(decl)
+	if heritage == nil || len(heritage.Extends) == 0 {
+		return own, nil
+	}
+	all := &Schema{}
+	for _, base := range heritage.Extends {
+		schema, err := g.typeSchema(&analyzer.TypeExpr{
+			Kind:          analyzer.TypeKindReference,
+			Name:          base.Name,
+			Text:          base.Text,
+			TypeArguments: base.TypeArguments,
+		}, bindings)
+		if err != nil {
+			return nil, err
+		}
+		all.AllOf = append(all.AllOf, schema)
+	}
+	all.AllOf = append(all.AllOf, own)
+	return all, nil
+}
+
+// enumSchema returns the schema of the values of an enum.
+func (g *generator) enumSchema(e analyzer.Enum) *Schema {
+	schema := &Schema{}
+	next := 0.0
+	for _, m := range e.Members {
+		if m.Initializer == "" {
+			schema.Enum = append(schema.Enum, next)
+			next++
+			continue
+		}
+		value := literalValue(m.Initializer)
+		if n, ok := value.(float64); ok {
+			next = n + 1
+		}
+		schema.Enum = append(schema.Enum, value)
+	}
+	return schema
+}
+
+// typeSchema returns the schema of a type expression.
+func (g *generator) typeSchema(t *analyzer.TypeExpr, bindings map[string]*Schema) (*Schema, error) {
+	if t == nil {
+		return &Schema{}, nil
+	}
+	var schema *Schema
+	switch t.Kind {
+	case analyzer.TypeKindPrimitive:
+		switch t.Name {
+		case "string", "number", "boolean", "object", "null":
+			schema = &Schema{Type: t.Name}
+		case "bigint":
+			schema = &Schema{Type: "integer"}
+		case "any", "unknown":
+			schema = &Schema{}
+		case "never", "undefined", "void":
+			schema = &Schema{Not: &Schema{}}
+		default:
+			return nil, fmt.Errorf("unsupported type %s", t.Text)
+		}
+	case analyzer.TypeKindLiteral:
+		schema = literalSchema(t.Text)
+	case analyzer.TypeKindReference:
+		var err error
+		if schema, err = g.referenceSchema(t, bindings); err != nil {
+			return nil, err
+		}
+	case analyzer.TypeKindUnion:
+		return g.unionSchema(t, bindings)
+	case analyzer.TypeKindIntersection:
+		schema = &Schema{}
+		for _, member := range t.Types {
+			s, err := g.typeSchema(member, bindings)
+			if err != nil {
+				return nil, err
+			}
+			schema.AllOf = append(schema.AllOf, s)
+		}
+	case analyzer.TypeKindArray:
+		items, err := g.typeSchema(t.Element, bindings)
+		if err != nil {
+			return nil, err
+		}
+		schema = &Schema{Type: "array", Items: items}
+	case analyzer.TypeKindTuple:
+		schema = &Schema{Type: "array"}
+		required := 0
+		for _, element := range t.Types {
+			s, err := g.typeSchema(element, bindings)
+			if err != nil {
+				return nil, err
+			}
+			schema.PrefixItems = append(schema.PrefixItems, s)
+			if !element.IsOptional {
+				required++
+			}
+		}
+		total := len(t.Types)
+		schema.MinItems, schema.MaxItems = &required, &total
+	case analyzer.TypeKindObject:
+		schema = &Schema{Type: "object"}
+		for _, m := range t.Members {
+			if m.IsMethod {
+				continue
+			}
+			s, err := g.typeSchema(m.Type, bindings)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", m.Name, err)
+			}
+			if m.IsReadonly {
+				s = withReadOnly(s)
+			}
+			if schema.Properties == nil {
+				schema.Properties = make(map[string]*Schema)
+			}
+			schema.Properties[m.Name] = s
+			if !m.IsOptional {
+				schema.Required = append(schema.Required, m.Name)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unsupported type %s", t.Text)
+	}
+	if t.IsReadonly {
+		schema = withReadOnly(schema)
+	}
+	return schema, nil
+}
+
+// referenceSchema returns the schema of a type reference: a type
+// parameter, a type declared in the file, or a built-in type.
+func (g *generator) referenceSchema(t *analyzer.TypeExpr, bindings map[string]*Schema) (*Schema, error) {
+	if s, ok := bindings[t.Name]; ok && len(t.TypeArguments) == 0 {
+		return s, nil
+	}
+	args := make([]*Schema, len(t.TypeArguments))
+	for i, arg := range t.TypeArguments {
+		s, err := g.typeSchema(arg, bindings)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = s
+	}
+	if _, ok := g.decls[t.Name]; ok {
+		return g.reference(t.Name, args)
+	}
+
+	switch {
+	case t.Name == "Date":
+		return &Schema{Type: "string", Format: "date-time"}, nil
+	case (t.Name == "Array" || t.Name == "ReadonlyArray") && len(args) == 1:
+		return &Schema{Type: "array", Items: args[0], ReadOnly: t.Name == "ReadonlyArray"}, nil
+	case t.Name == "Record" && len(args) == 2:
+		return &Schema{Type: "object", AdditionalProperties: args[1]}, nil
+	}
+	return nil, fmt.Errorf("unresolved type %s", t.Text)
+}
+
+// unionSchema returns the schema of a union type: an enum if its members
+// are literals, or else anyOf its members. undefined members are dropped,
+// as JSON has no undefined value.
+func (g *generator) unionSchema(t *analyzer.TypeExpr, bindings map[string]*Schema) (*Schema, error) {
+	var members []*Schema
+	literals := true
+	for _, member := range t.Types {
+		if member.Text == "undefined" {
+			continue
+		}
+		s, err := g.typeSchema(member, bindings)
+		if err != nil {
+			return nil, err
+		}
+		literals = literals && member.Kind == analyzer.TypeKindLiteral
+		members = append(members, s)
+	}
+
+	switch {
+	case len(members) == 1:
+		return members[0], nil
+	case literals:
+		schema := &Schema{}
+		for _, s := range members {
+			if s.Type == "null" {
+				schema.Enum = append(schema.Enum, nil)
+			} else {
+				schema.Enum = append(schema.Enum, s.Const)
+			}
+		}
+		return schema, nil
+	}
+	return &Schema{AnyOf: members}, nil
+}
+
+// withReadOnly returns a copy of s marked as read-only. References are
+// wrapped, as siblings of $ref are ignored by older drafts.
+func withReadOnly(s *Schema) *Schema {
+	if s.Ref != "" {
+		return &Schema{AllOf: []*Schema{s}, ReadOnly: true}
+	}
+	readOnly := *s
+	readOnly.ReadOnly = true
+	return &readOnly
+}
+
+// literalSchema returns the schema of a literal type.
+func literalSchema(text string) *Schema {
+	switch text {
+	case "null":
+		return &Schema{Type: "null"}
+	case "undefined":
+		return &Schema{Not: &Schema{}}
+	}
+	if strings.HasPrefix(text, "`") {
+		return &Schema{Type: "string"} // template literal type
+	}
+	return &Schema{Const: literalValue(text)}
+}
+
+// literalValue returns the value of a string, number or boolean literal.
+func literalValue(text string) any {
+	switch text {
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+	if n, err := strconv.ParseFloat(strings.ReplaceAll(text, "_", ""), 64); err == nil {
+		return n
+	}
+	if len(text) >= 2 && (text[0] == '"' || text[0] == '\'') && text[len(text)-1] == text[0] {
+		body := text[1 : len(text)-1]
+		if text[0] == '\'' {
+			body = strings.ReplaceAll(strings.ReplaceAll(body, `\'`, "'"), `"`, `\"`)
+		}
+		if s, err := strconv.Unquote(`"` + body + `"`); err == nil {
+			return s
+		}
+		return body
+	}
+	return text
+}
+
+// typeParameters returns the names of the type parameters of a
+// declaration.
+func typeParameters(decl ast.Node) []string {
+	params := ast.ChildByField(decl, "type_parameters")
+	if params == nil {
+		return nil
+	}
+	var names []string
+	for _, param := range ast.ChildrenOfKind(params, "type_parameter") {
+		if name := ast.ChildByField(param, "name"); name != nil {
+			names = append(names, name.Text())
+		}
+	}
+	return names
+}
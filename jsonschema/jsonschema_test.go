@@ -0,0 +1,146 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/ahmadramadhannn/tsgoast"
+)
+
+func parse(t *testing.T, src string) *tsgoast.Tree {
+	t.Helper()
+	parser, err := tsgoast.New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+	defer parser.Close()
+	tree, err := parser.ParseTree([]byte(src))
+	if err != nil {
+		t.Fatalf("ParseTree() error = %v", err)
+	}
+	return tree
+}
+
+// compact returns the JSON encoding of s.
+func compact(t *testing.T, s *Schema) string {
+	t.Helper()
+	b, err := json.Marshal(s)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	return string(b)
+}
+
+const source = `
+enum Level { Low, High = 5, Top }
+
+/** A user of the service. */
+interface User {
+  readonly id: number;
+  role: "admin" | 'user' | null;
+  tags?: string[];
+  point: [number, number?];
+  level: Level;
+  manager?: User;
+  created: Date;
+  save(): void;
+}
+
+type Page<T> = { items: T[]; total: number | undefined };
+
+type UserPage = Page<User>;
+
+interface Admin extends User {
+  scopes: Record<string, boolean>;
+}
+`
+
+func TestGenerate(t *testing.T) {
+	tree := parse(t, source)
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"Level", `{"$schema":"` + Draft + `","title":"Level","enum":[0,5,6]}`},
+		{"User", `{"$schema":"` + Draft + `","title":"User","description":"A user of the service.","type":"object",` +
+			`"properties":{"created":{"type":"string","format":"date-time"},` +
+			`"id":{"type":"number","readOnly":true},` +
+			`"level":{"$ref":"#/$defs/Level"},` +
+			`"manager":{"$ref":"#/$defs/User"},` +
+			`"point":{"type":"array","prefixItems":[{"type":"number"},{"type":"number"}],"minItems":1,"maxItems":2},` +
+			`"role":{"enum":["admin","user",null]},` +
+			`"tags":{"type":"array","items":{"type":"string"}}},` +
+			`"required":["id","role","point","level","created"],` +
+			`"$defs":{"Level":{"enum":[0,5,6]},"User":{"description":"A user of the service.","type":"object",` +
+			`"properties":{"created":{"type":"string","format":"date-time"},` +
+			`"id":{"type":"number","readOnly":true},` +
+			`"level":{"$ref":"#/$defs/Level"},` +
+			`"manager":{"$ref":"#/$defs/User"},` +
+			`"point":{"type":"array","prefixItems":[{"type":"number"},{"type":"number"}],"minItems":1,"maxItems":2},` +
+			`"role":{"enum":["admin","user",null]},` +
+			`"tags":{"type":"array","items":{"type":"string"}}},` +
+			`"required":["id","role","point","level","created"]}}}`},
+		{"UserPage", `{"$schema":"` + Draft + `","title":"UserPage","type":"object",` +
+			`"properties":{"items":{"type":"array","items":{"$ref":"#/$defs/User"}},"total":{"type":"number"}},` +
+			`"required":["items","total"],"$defs":{`},
+		{"Admin", `{"$schema":"` + Draft + `","title":"Admin","allOf":[{"$ref":"#/$defs/User"},` +
+			`{"type":"object","properties":{"scopes":{"type":"object","additionalProperties":{"type":"boolean"}}},` +
+			`"required":["scopes"]}],"$defs":{`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema, err := Generate(tree, tt.name)
+			if err != nil {
+				t.Fatalf("Generate() error = %v", err)
+			}
+			if got := compact(t, schema); !strings.HasPrefix(got, tt.want) {
+				t.Errorf("Generate() =\n%s\nwant prefix\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateAll(t *testing.T) {
+	schema, err := GenerateAll(parse(t, source))
+	if err != nil {
+		t.Fatalf("GenerateAll() error = %v", err)
+	}
+	for _, name := range []string{"Level", "User", "UserPage", "Admin"} {
+		if schema.Defs[name] == nil {
+			t.Errorf("GenerateAll() has no definition of %s", name)
+		}
+	}
+	if schema.Defs["Page"] != nil {
+		t.Error("GenerateAll() defines the generic type Page")
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	tree := parse(t, `
+interface Handler { callback: (x: number) => void; }
+interface Missing { value: Unknown; }
+type Keys = keyof Handler;
+`)
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"Handler", "jsonschema: Handler: callback: unsupported type (x: number) => void"},
+		{"Missing", "jsonschema: Missing: value: unresolved type Unknown"},
+		{"Keys", "jsonschema: Keys: unsupported type keyof Handler"},
+		{"Nothing", "jsonschema: Nothing is not declared"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Generate(tree, tt.name)
+			if err == nil || err.Error() != tt.want {
+				t.Errorf("Generate() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package tsgoast
+
+import (
+	"fmt"
+
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+// Limits bound the trees a parser converts, so that pathological input,
+// such as deeply nested minified code, degrades into truncated trees
+// rather than exhausting the memory of the program, or the stack of the
+// consumers of the trees that recurse once per level of nesting.
+type Limits struct {
+	// MaxDepth is the depth of the deepest converted node, the root being
+	// at depth 0. The nodes at that depth are converted without their
+	// children. Zero or less means no limit.
+	MaxDepth int
+
+	// MaxNodes is the number of nodes converted, after which the children
+	// of the remaining nodes are left out. Zero or less means no limit.
+	// Lazy conversion and Reparse do not enforce it.
+	MaxNodes int
+}
+
+// Diagnostic reports a problem met while converting a tree, such as a
+// subtree truncated by the Limits of the parser.
+type Diagnostic struct {
+	Range   ast.Range `json:"range"`
+	Message string    `json:"message"`
+}
+
+// String formats the diagnostic as line:column: message.
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%d:%d: %s", d.Range.Start.Line+1, d.Range.Start.Column+1, d.Message)
+}
+
+// SetLimits sets the limits of the trees the parser converts; see Limits.
+// New parsers have no limits. Truncated subtrees are reported in
+// Tree.Diagnostics and counted in ParseStats.Truncated. Lazy conversion
+// converts one level at a time, so only MaxDepth applies to it, as it does
+// to Reparse.
+func (p *Parser) SetLimits(limits Limits) {
+	p.limits = limits
+}
+
+// conversion is the state of the conversion of a tree, checked against
+// the limits of the parser.
+type conversion struct {
+	depth       int // of the node whose children are converted
+	nodes       int // converted, or to be as children of converted ones
+	truncated   int
+	diagnostics []Diagnostic
+}
+
+// truncate reports whether the children of node, at the current depth,
+// are to be left out, recording why. Otherwise, they are counted.
+func (p *Parser) truncate(node *ast.BaseNode, children int) bool {
+	c := &p.conv
+	switch {
+	case p.limits.MaxDepth > 0 && c.depth >= p.limits.MaxDepth:
+		c.diagnostics = append(c.diagnostics, p.depthDiagnostic(node))
+	case p.limits.MaxNodes > 0 && c.nodes+children > p.limits.MaxNodes:
+		if c.nodes <= p.limits.MaxNodes {
+			// Report the first truncation only, the others follow
+			c.diagnostics = append(c.diagnostics, Diagnostic{
+				Range:   node.SourceRange,
+				Message: fmt.Sprintf("tree of more than %d nodes truncated", p.limits.MaxNodes),
+			})
+			c.nodes = p.limits.MaxNodes + 1
+		}
+	default:
+		c.nodes += children
+		return false
+	}
+	c.truncated++
+	return true
+}
+
+// depthDiagnostic returns the diagnostic of node, whose children are left
+// out for being deeper than the limits of p.
+func (p *Parser) depthDiagnostic(node *ast.BaseNode) Diagnostic {
+	return Diagnostic{
+		Range:   node.SourceRange,
+		Message: fmt.Sprintf("nesting deeper than %d levels truncated", p.limits.MaxDepth),
+	}
+}
@@ -0,0 +1,223 @@
+package tsgoast
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+// nested returns an expression statement nesting n parenthesized and n
+// array expressions.
+func nested(n int) []byte {
+	return []byte("x = " + strings.Repeat("([", n) + "1" + strings.Repeat("])", n) + ";\n")
+}
+
+func TestParseLimits(t *testing.T) {
+	parser, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer parser.Close()
+
+	// New parsers have no limits
+	source := nested(2000)
+	deep, err := parser.ParseTree(nested(20000))
+	if err != nil {
+		t.Fatalf("ParseTree() error = %v", err)
+	}
+	if depth := deep.Stats().MaxDepth; depth < 40000 || len(deep.Diagnostics) != 0 || deep.Stats().Truncated != 0 {
+		t.Errorf("ParseTree() without limits = depth %d, %v", depth, deep.Diagnostics)
+	}
+
+	parser.SetLimits(Limits{MaxDepth: 100})
+	tree, err := parser.ParseTree(source)
+	if err != nil {
+		t.Fatalf("ParseTree() error = %v", err)
+	}
+	stats := tree.Stats()
+	if stats.MaxDepth != 100 || stats.Truncated != 1 || len(tree.Diagnostics) != 1 {
+		t.Fatalf("ParseTree() = depth %d, %v, want depth 100 and one diagnostic", stats.MaxDepth, tree.Diagnostics)
+	}
+	if d := tree.Diagnostics[0]; !strings.Contains(d.String(), "deeper than 100") || d.Range.Start.Offset == 0 {
+		t.Errorf("Diagnostics[0] = %v", d)
+	}
+	if len(tree.Statements) != 1 {
+		t.Errorf("Statements = %d, want 1", len(tree.Statements))
+	}
+
+	parser.SetLimits(Limits{MaxNodes: 50})
+	tree, err = parser.ParseTree(source)
+	if err != nil {
+		t.Fatalf("ParseTree() error = %v", err)
+	}
+	if n := tree.Stats().Nodes; n > 50 || len(tree.Diagnostics) != 1 || tree.Stats().Truncated == 0 {
+		t.Errorf("ParseTree() = %d nodes, %v, want at most 50 and one diagnostic", n, tree.Diagnostics)
+	}
+
+	parser.SetLimits(Limits{MaxDepth: 100})
+	parser.SetLazy(true)
+	root, err := parser.Parse(source)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if _, depth := measureAll(root); depth != 100 {
+		t.Errorf("lazy Parse() depth = %d, want 100", depth)
+	}
+}
+
+func TestDiagnosticsEncoding(t *testing.T) {
+	parser, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer parser.Close()
+	parser.SetLimits(Limits{MaxDepth: 20})
+	tree, err := parser.ParseTree([]byte("let a = 1;\n" + string(nested(20))))
+	if err != nil {
+		t.Fatalf("ParseTree() error = %v", err)
+	}
+	if len(tree.Diagnostics) != 1 {
+		t.Fatalf("Diagnostics = %v, want 1", tree.Diagnostics)
+	}
+
+	decode := map[string]func() (*Tree, error){
+		"JSON": func() (*Tree, error) {
+			data, err := json.Marshal(tree)
+			if err != nil {
+				return nil, err
+			}
+			decoded := &Tree{}
+			return decoded, json.Unmarshal(data, decoded)
+		},
+		"msgpack": func() (*Tree, error) {
+			data, err := tree.MarshalMsgpack()
+			if err != nil {
+				return nil, err
+			}
+			decoded := &Tree{}
+			return decoded, decoded.UnmarshalMsgpack(data)
+		},
+		"proto": func() (*Tree, error) {
+			data, err := tree.MarshalProto()
+			if err != nil {
+				return nil, err
+			}
+			decoded := &Tree{}
+			return decoded, decoded.UnmarshalProto(data)
+		},
+		"gob": func() (*Tree, error) {
+			var buf bytes.Buffer
+			if err := EncodeTree(&buf, tree); err != nil {
+				return nil, err
+			}
+			return DecodeTree(&buf)
+		},
+	}
+	for name, decode := range decode {
+		decoded, err := decode()
+		if err != nil {
+			t.Errorf("%s: error = %v", name, err)
+			continue
+		}
+		if !slices.Equal(decoded.Diagnostics, tree.Diagnostics) {
+			t.Errorf("%s: Diagnostics = %v, want %v", name, decoded.Diagnostics, tree.Diagnostics)
+		}
+		if decoded.Root.Text() != tree.Root.Text() || len(decoded.Statements) != 2 {
+			t.Errorf("%s: decoded %d statements of %q", name, len(decoded.Statements), decoded.Root.Text())
+		}
+	}
+}
+
+// measureAll is measure converting the children of lazy nodes.
+func measureAll(root ast.Node) (nodes, depth int) {
+	for node := range ast.Preorder(root) {
+		nodes++
+		d := 0
+		for parent := node.Parent(); parent != nil; parent = parent.Parent() {
+			d++
+		}
+		depth = max(depth, d)
+	}
+	return nodes, depth
+}
+
+func TestReparseLimits(t *testing.T) {
+	parser, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer parser.Close()
+	parser.SetLimits(Limits{MaxDepth: 50})
+
+	deep := string(nested(100))
+	source := []byte("let a = 1;\n" + deep + deep)
+	tree, err := parser.Reparse(nil, source, SourceEdit{})
+	if err != nil {
+		t.Fatalf("Reparse() error = %v", err)
+	}
+	if len(tree.Diagnostics) != 2 {
+		t.Fatalf("Diagnostics = %v, want 2", tree.Diagnostics)
+	}
+	before := tree.Diagnostics[1]
+
+	// Lengthen the first line: the subtrees after it are reused, and their
+	// diagnostics shifted
+	edited := []byte("let abc = 1;\n" + deep + deep)
+	edit := DiffEdit(source, edited)
+	tree, err = parser.Reparse(tree, edited, edit)
+	if err != nil {
+		t.Fatalf("Reparse() error = %v", err)
+	}
+	if len(tree.Diagnostics) != 2 {
+		t.Fatalf("Diagnostics after the edit = %v, want 2", tree.Diagnostics)
+	}
+	if after := tree.Diagnostics[1]; after.Range.Start.Offset != before.Range.Start.Offset+2 || after.Range.Start.Line != before.Range.Start.Line {
+		t.Errorf("Diagnostics[1] = %v, want %v shifted by 2 bytes", after, before)
+	}
+	if depth := tree.Stats().MaxDepth; depth != 50 {
+		t.Errorf("Stats().MaxDepth = %d, want 50", depth)
+	}
+}
+
+func FuzzParseLimits(f *testing.F) {
+	f.Add([]byte("const a = [[[1]]];"), 3, 0)
+	f.Add(nested(50), 10, 0)
+	f.Add([]byte("function f() { if (a) { return (b); } }"), 0, 12)
+	f.Add([]byte("{{{{{{{{"), 4, 4)
+	f.Fuzz(func(t *testing.T, source []byte, maxDepth, maxNodes int) {
+		if len(source) == 0 {
+			return
+		}
+		parser, err := New()
+		if err != nil {
+			t.Fatalf("New() error = %v", err)
+		}
+		defer parser.Close()
+		limits := Limits{MaxDepth: maxDepth % 64, MaxNodes: maxNodes % 4096}
+		parser.SetLimits(limits)
+
+		tree, err := parser.ParseTree(source)
+		if err != nil {
+			t.Fatalf("ParseTree() error = %v", err)
+		}
+		stats := tree.Stats()
+		if limits.MaxDepth > 0 && stats.MaxDepth > limits.MaxDepth {
+			t.Errorf("depth %d beyond the limit %d", stats.MaxDepth, limits.MaxDepth)
+		}
+		if limits.MaxNodes > 0 && stats.Nodes > limits.MaxNodes {
+			t.Errorf("%d nodes beyond the limit %d", stats.Nodes, limits.MaxNodes)
+		}
+		if stats.Truncated > 0 && len(tree.Diagnostics) == 0 {
+			t.Errorf("%d truncated subtrees without diagnostics", stats.Truncated)
+		}
+		for _, d := range tree.Diagnostics {
+			if int(d.Range.End.Offset) > len(source) {
+				t.Errorf("diagnostic %v out of the source", d)
+			}
+		}
+	})
+}
@@ -0,0 +1,50 @@
+package tsgoast
+
+import (
+	"os"
+)
+
+// SetMmapThreshold makes ParseFile and ParseTreeFromFile map the files of
+// at least size bytes into memory rather than read them, on the systems
+// that support it. A size of zero or less never maps files, which is the
+// default.
+//
+// Mapping keeps the contents of large files, such as generated bundles of
+// tens of megabytes, out of the Go heap: the source is held in the page
+// cache, and the only copy on the heap is the one shared by the node
+// texts, which never refer to the mapping. The mapping is released as soon
+// as the file is parsed, rather than when the garbage collector gets to
+// it. Mapped files must not be truncated while they are parsed.
+func (p *Parser) SetMmapThreshold(size int64) {
+	p.mmapMin = size
+}
+
+// readFile returns the content of the file at path, mapped into memory if
+// it is large enough. The content must not be used after release is
+// called.
+func (p *Parser) readFile(path string) (content []byte, release func(), err error) {
+	noop := func() {}
+	if p.mmapMin <= 0 {
+		content, err = os.ReadFile(path)
+		return content, noop, err
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, noop, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, noop, err
+	}
+	if size := info.Size(); size >= p.mmapMin && size > 0 && size == int64(int(size)) {
+		if data, err := mmapFile(f, int(size)); err == nil {
+			return data, func() { munmapFile(data) }, nil
+		}
+	}
+
+	// Mapping is not supported, or failed: read the file instead
+	content, err = os.ReadFile(path)
+	return content, noop, err
+}
@@ -0,0 +1,16 @@
+//go:build !unix
+
+package tsgoast
+
+import (
+	"errors"
+	"os"
+)
+
+// mmapFile fails: files are read rather than mapped on this system.
+func mmapFile(f *os.File, size int) ([]byte, error) {
+	return nil, errors.ErrUnsupported
+}
+
+// munmapFile does nothing.
+func munmapFile(data []byte) {}
@@ -0,0 +1,93 @@
+package tsgoast
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestParseFileMmap(t *testing.T) {
+	source := largeSource(t, 64<<10)
+	path := filepath.Join(t.TempDir(), "bundle.ts")
+	if err := os.WriteFile(path, source, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	empty := filepath.Join(t.TempDir(), "empty.ts")
+	if err := os.WriteFile(empty, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	parser, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer parser.Close()
+	want, err := parser.ParseTreeFromFile(path)
+	if err != nil {
+		t.Fatalf("ParseTreeFromFile() error = %v", err)
+	}
+
+	parser.SetMmapThreshold(1)
+	root, err := parser.ParseFile(path)
+	if err != nil {
+		t.Fatalf("ParseFile() error = %v", err)
+	}
+	tree, err := parser.ParseTreeFromFile(path)
+	if err != nil {
+		t.Fatalf("ParseTreeFromFile() error = %v", err)
+	}
+
+	// The texts outlive the mapping
+	runtime.GC()
+	if root.Text() != string(source) || tree.Root.Text() != string(source) {
+		t.Error("mapped ParseFile() texts differ from the source")
+	}
+	got, err := json.Marshal(tree)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	wantJSON, err := json.Marshal(want)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if string(got) != string(wantJSON) {
+		t.Error("mapped ParseTreeFromFile() differs from ParseTreeFromFile()")
+	}
+
+	if _, err := parser.ParseFile(empty); err == nil {
+		t.Error("ParseFile() of an empty file: want error")
+	}
+	if _, err := parser.ParseFile(path + ".missing"); err == nil {
+		t.Error("ParseFile() of a missing file: want error")
+	}
+}
+
+// BenchmarkParseFileMmap parses a 4MB file read or mapped into memory.
+func BenchmarkParseFileMmap(b *testing.B) {
+	path := filepath.Join(b.TempDir(), "bundle.ts")
+	if err := os.WriteFile(path, largeSource(b, 4<<20), 0o644); err != nil {
+		b.Fatal(err)
+	}
+	parser, err := New()
+	if err != nil {
+		b.Fatalf("Failed to create parser: %v", err)
+	}
+	defer parser.Close()
+
+	for _, bench := range []struct {
+		name      string
+		threshold int64
+	}{{"read", 0}, {"mmap", 1}} {
+		b.Run(bench.name, func(b *testing.B) {
+			parser.SetMmapThreshold(bench.threshold)
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := parser.ParseFile(path); err != nil {
+					b.Fatalf("ParseFile error: %v", err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,18 @@
+//go:build unix
+
+package tsgoast
+
+import (
+	"os"
+	"syscall"
+)
+
+// mmapFile maps the first size bytes of f into memory, read-only.
+func mmapFile(f *os.File, size int) ([]byte, error) {
+	return syscall.Mmap(int(f.Fd()), 0, size, syscall.PROT_READ, syscall.MAP_SHARED)
+}
+
+// munmapFile releases a mapping of mmapFile.
+func munmapFile(data []byte) {
+	syscall.Munmap(data)
+}
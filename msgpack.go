@@ -0,0 +1,89 @@
+package tsgoast
+
+import (
+	"fmt"
+
+	"github.com/ahmadramadhannn/tsgoast/ast"
+	"github.com/ahmadramadhannn/tsgoast/internal/msgpack"
+)
+
+// msgpackDiagnosticLen is the number of elements of an encoded diagnostic.
+const msgpackDiagnosticLen = 7
+
+// MarshalMsgpack encodes the syntax tree of t and its diagnostics in
+// MessagePack: the array of the diagnostics, each the array
+//
+//	[startLine, startColumn, startOffset, endLine, endColumn, endOffset, message]
+//
+// followed by the root (see ast.BaseNode.MarshalMsgpack). Like
+// MarshalJSON, typed statements are not stored, since they are derived
+// from the root.
+func (t *Tree) MarshalMsgpack() ([]byte, error) {
+	if t.Root == nil {
+		return nil, fmt.Errorf("tree has no root node")
+	}
+	root, err := t.Root.MarshalMsgpack()
+	if err != nil {
+		return nil, err
+	}
+	buf := msgpack.AppendArray(nil, len(t.Diagnostics))
+	for _, d := range t.Diagnostics {
+		buf = msgpack.AppendArray(buf, msgpackDiagnosticLen)
+		for _, v := range []uint32{
+			d.Range.Start.Line, d.Range.Start.Column, d.Range.Start.Offset,
+			d.Range.End.Line, d.Range.End.Column, d.Range.End.Offset,
+		} {
+			buf = msgpack.AppendUint(buf, uint64(v))
+		}
+		buf = msgpack.AppendString(buf, d.Message)
+	}
+	return append(buf, root...), nil
+}
+
+// UnmarshalMsgpack decodes a tree produced by MarshalMsgpack, restoring
+// parent pointers and rebuilding the typed statements.
+func (t *Tree) UnmarshalMsgpack(data []byte) error {
+	r := &msgpack.Reader{B: data}
+	count, err := r.Array()
+	if err != nil {
+		return err
+	}
+	var diagnostics []Diagnostic
+	for range count {
+		if err := r.ExpectArray(msgpackDiagnosticLen); err != nil {
+			return err
+		}
+		var values [6]uint32
+		for i := range values {
+			v, err := r.Unsigned()
+			if err != nil {
+				return err
+			}
+			if v > 1<<32-1 {
+				return fmt.Errorf("msgpack: value %d out of range", v)
+			}
+			values[i] = uint32(v)
+		}
+		message, err := r.Str()
+		if err != nil {
+			return err
+		}
+		diagnostics = append(diagnostics, Diagnostic{
+			Range: ast.Range{
+				Start: ast.Position{Line: values[0], Column: values[1], Offset: values[2]},
+				End:   ast.Position{Line: values[3], Column: values[4], Offset: values[5]},
+			},
+			Message: message,
+		})
+	}
+
+	root := &ast.BaseNode{}
+	if err := root.UnmarshalMsgpack(r.B); err != nil {
+		return err
+	}
+
+	t.Root = root
+	t.Diagnostics = diagnostics
+	t.Statements = (&Parser{}).extractStatements(root)
+	return nil
+}
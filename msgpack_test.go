@@ -0,0 +1,81 @@
+package tsgoast
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestTreeMsgpackRoundTrip(t *testing.T) {
+	parser, err := New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+	defer parser.Close()
+
+	tree, err := parser.ParseTree([]byte(`
+import { x } from "./x";
+export async function load(id: string): Promise<void> {}
+class Service { greet = "héllo 😀"; }
+`))
+	if err != nil {
+		t.Fatalf("ParseTree() error = %v", err)
+	}
+
+	data, err := tree.MarshalMsgpack()
+	if err != nil {
+		t.Fatalf("MarshalMsgpack() error = %v", err)
+	}
+	var decoded Tree
+	if err := decoded.UnmarshalMsgpack(data); err != nil {
+		t.Fatalf("UnmarshalMsgpack() error = %v", err)
+	}
+
+	want, _ := json.Marshal(tree)
+	got, _ := json.Marshal(&decoded)
+	if string(got) != string(want) {
+		t.Errorf("decoded tree differs:\n got %s\nwant %s", got, want)
+	}
+	if len(decoded.Statements) != len(tree.Statements) {
+		t.Errorf("decoded %d statements, want %d", len(decoded.Statements), len(tree.Statements))
+	}
+	if len(data) >= len(want)/4 {
+		t.Errorf("msgpack encoding is %d bytes, JSON %d bytes", len(data), len(want))
+	}
+}
+
+func FuzzTreeMsgpackRoundTrip(f *testing.F) {
+	f.Add([]byte(`const x = 1;`))
+	f.Add([]byte("class A<T> { #p?: T; static async *m() { yield `a${b}`; } }"))
+	f.Add([]byte("let s = \"😀\"; /* ünïcode */ foo(s!)"))
+	f.Add([]byte(`function (`))
+
+	parser, err := New()
+	if err != nil {
+		f.Fatalf("Failed to create parser: %v", err)
+	}
+	defer parser.Close()
+
+	f.Fuzz(func(t *testing.T, source []byte) {
+		// Arbitrary bytes must be rejected or decoded without panicking
+		_ = (&Tree{}).UnmarshalMsgpack(source)
+
+		tree, err := parser.ParseTree(source)
+		if err != nil {
+			return
+		}
+		data, err := tree.MarshalMsgpack()
+		if err != nil {
+			t.Fatalf("MarshalMsgpack() error = %v", err)
+		}
+		var decoded Tree
+		if err := decoded.UnmarshalMsgpack(data); err != nil {
+			t.Fatalf("UnmarshalMsgpack() error = %v", err)
+		}
+
+		want, _ := json.Marshal(tree)
+		got, _ := json.Marshal(&decoded)
+		if string(got) != string(want) {
+			t.Errorf("decoded tree differs:\n got %s\nwant %s", got, want)
+		}
+	})
+}
@@ -0,0 +1,35 @@
+package tsgoast
+
+import (
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+// NodeCovering returns the deepest node whose range fully contains r, or nil
+// if r lies outside the tree. Ranges are compared by byte offset. When r
+// sits on the boundary between two siblings, the first one is returned.
+func (t *Tree) NodeCovering(r ast.Range) ast.Node {
+	if t == nil || t.Root == nil || !covers(t.Root, r) {
+		return nil
+	}
+
+	var node ast.Node = t.Root
+	for {
+		var next ast.Node
+		for _, child := range node.Children() {
+			if covers(child, r) {
+				next = child
+				break
+			}
+		}
+		if next == nil {
+			return node
+		}
+		node = next
+	}
+}
+
+// covers checks if the range of node contains r.
+func covers(node ast.Node, r ast.Range) bool {
+	nr := node.Range()
+	return nr.Start.Offset <= r.Start.Offset && r.End.Offset <= nr.End.Offset
+}
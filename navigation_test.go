@@ -0,0 +1,63 @@
+package tsgoast
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+func TestNodeCovering(t *testing.T) {
+	parser, err := New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+	defer parser.Close()
+
+	source := `function greet(name: string) {
+	return "Hello, " + name;
+}`
+	tree, err := parser.ParseTree([]byte(source))
+	if err != nil {
+		t.Fatalf("ParseTree() error = %v", err)
+	}
+
+	span := func(text string) ast.Range {
+		start := uint32(strings.Index(source, text))
+		return ast.Range{
+			Start: ast.Position{Offset: start},
+			End:   ast.Position{Offset: start + uint32(len(text))},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		r        ast.Range
+		wantKind string
+		wantText string
+	}{
+		{"identifier", span("greet"), "identifier", "greet"},
+		{"inside identifier", span("ree"), "identifier", "greet"},
+		{"binary expression", span(`"Hello, " + name`), "binary_expression", `"Hello, " + name`},
+		{"across operands", span(`, " + na`), "binary_expression", `"Hello, " + name`},
+		{"statement", span("return"), "return", "return"},
+		{"whole body", span("{\n\treturn \"Hello, \" + name;\n}"), "statement_block", "{\n\treturn \"Hello, \" + name;\n}"},
+		{"whole file", span(source), "function_declaration", source},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node := tree.NodeCovering(tt.r)
+			if node == nil {
+				t.Fatal("NodeCovering() = nil")
+			}
+			if node.SyntaxKind() != tt.wantKind || node.Text() != tt.wantText {
+				t.Errorf("NodeCovering() = %s %q, want %s %q", node.SyntaxKind(), node.Text(), tt.wantKind, tt.wantText)
+			}
+		})
+	}
+
+	outside := ast.Range{Start: ast.Position{Offset: 0}, End: ast.Position{Offset: uint32(len(source) + 10)}}
+	if node := tree.NodeCovering(outside); node != nil {
+		t.Errorf("NodeCovering() outside the source = %s, want nil", node.SyntaxKind())
+	}
+}
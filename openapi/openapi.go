@@ -0,0 +1,337 @@
+// Package openapi generates OpenAPI 3.1 documents from TypeScript sources,
+// for API documentation pipelines driven by the types of a service.
+//
+// The exported interfaces, type aliases and enums of a file become the
+// components.schemas of the document, along with the declarations they
+// refer to; the schemas are generated by package jsonschema. With
+// Config.Routes, controller methods decorated with NestJS-style route
+// decorators become the paths of the document:
+//
+//	@Controller("users")
+//	export class UsersController {
+//	  @Post(":id/tags")
+//	  async tag(@Param("id") id: string, @Body() tag: Tag): Promise<User> { ... }
+//	}
+//
+// documents POST /users/{id}/tags with a path parameter, a JSON request
+// body referencing the Tag schema and a 201 response referencing User.
+// Parameters are read from the @Param, @Query, @Headers and @Body
+// decorators, and the status code from @HttpCode.
+package openapi
+
+import (
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/ahmadramadhannn/tsgoast"
+	"github.com/ahmadramadhannn/tsgoast/analyzer"
+	"github.com/ahmadramadhannn/tsgoast/ast"
+	"github.com/ahmadramadhannn/tsgoast/jsonschema"
+)
+
+// Version is the OpenAPI version of the generated documents.
+const Version = "3.1.0"
+
+// Document is an OpenAPI document.
+type Document struct {
+	OpenAPI    string               `json:"openapi"`
+	Info       Info                 `json:"info"`
+	Paths      map[string]*PathItem `json:"paths,omitempty"`
+	Components Components           `json:"components"`
+}
+
+// Info holds the metadata of the API.
+type Info struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+// Components holds the reusable schemas of the document.
+type Components struct {
+	Schemas map[string]*jsonschema.Schema `json:"schemas,omitempty"`
+}
+
+// PathItem holds the operations of a path, by lowercase HTTP method.
+type PathItem map[string]*Operation
+
+// Operation is an API operation: a route handler.
+type Operation struct {
+	OperationID string               `json:"operationId,omitempty"`
+	Tags        []string             `json:"tags,omitempty"`
+	Parameters  []*Parameter         `json:"parameters,omitempty"`
+	RequestBody *RequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*Response `json:"responses"`
+}
+
+// Parameter is a path, query or header parameter of an operation.
+type Parameter struct {
+	Name     string             `json:"name"`
+	In       string             `json:"in"`
+	Required bool               `json:"required,omitempty"`
+	Schema   *jsonschema.Schema `json:"schema,omitempty"`
+}
+
+// RequestBody is the body of a request.
+type RequestBody struct {
+	Required bool                 `json:"required,omitempty"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+// Response is a response of an operation.
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+// MediaType holds the schema of a request or response body.
+type MediaType struct {
+	Schema *jsonschema.Schema `json:"schema"`
+}
+
+// Config controls document generation.
+type Config struct {
+	Title   string // title of the API, "API" by default
+	Version string // version of the API, "1.0.0" by default
+
+	// Routes adds the paths of the route handlers of the file.
+	Routes bool
+}
+
+// Generate returns the document of the exported types of tree.
+func Generate(tree *tsgoast.Tree) (*Document, error) {
+	return (&Config{}).Generate(tree)
+}
+
+// Generate returns the document of the exported types of tree and, if
+// c.Routes is set, of its route handlers.
+func (c *Config) Generate(tree *tsgoast.Tree) (*Document, error) {
+	if tree == nil || tree.Root == nil {
+		return nil, fmt.Errorf("openapi: tree has no root node")
+	}
+	g := &generator{
+		tree:    tree,
+		schemas: &jsonschema.Config{RefPrefix: "#/components/schemas/"},
+		doc: &Document{
+			OpenAPI:    Version,
+			Info:       Info{Title: c.Title, Version: c.Version},
+			Components: Components{Schemas: make(map[string]*jsonschema.Schema)},
+		},
+	}
+	if g.doc.Info.Title == "" {
+		g.doc.Info.Title = "API"
+	}
+	if g.doc.Info.Version == "" {
+		g.doc.Info.Version = "1.0.0"
+	}
+
+	for _, name := range exportedTypes(tree.Root) {
+		if _, err := g.schema(&analyzer.TypeExpr{Kind: analyzer.TypeKindReference, Name: name, Text: name}); err != nil {
+			return nil, fmt.Errorf("openapi: %w", err)
+		}
+	}
+	if c.Routes {
+		for _, handler := range analyzer.New(tree.Root).FindRouteHandlers() {
+			if err := g.route(handler); err != nil {
+				return nil, fmt.Errorf("openapi: %s %s: %w", handler.HTTPMethod, handler.Path, err)
+			}
+		}
+	}
+	return g.doc, nil
+}
+
+// generator holds the document being generated.
+type generator struct {
+	tree    *tsgoast.Tree
+	schemas *jsonschema.Config
+	doc     *Document
+}
+
+// schema returns the schema of a type, adding the declarations it refers
+// to to the components.
+func (g *generator) schema(t *analyzer.TypeExpr) (*jsonschema.Schema, error) {
+	schema, defs, err := g.schemas.TypeSchema(g.tree, t)
+	if err != nil {
+		return nil, err
+	}
+	maps.Copy(g.doc.Components.Schemas, defs)
+	return schema, nil
+}
+
+// route adds the operation of a route handler to the document.
+func (g *generator) route(handler analyzer.RouteHandler) error {
+	method := strings.ToLower(handler.HTTPMethod)
+	if method == "all" {
+		return nil // no single operation to document
+	}
+	op := &Operation{
+		OperationID: handler.Handler,
+		Responses:   make(map[string]*Response),
+	}
+	if handler.Controller != "" {
+		op.Tags = []string{handler.Controller}
+	}
+
+	fn := handler.Decorator.Target
+	path, pathParams := openAPIPath(handler.Path)
+	if params := ast.ChildByField(fn, "parameters"); params != nil {
+		for _, param := range params.Children() {
+			if err := g.parameter(op, param); err != nil {
+				return err
+			}
+		}
+	}
+	// Path parameters without a typed @Param are strings
+	for _, name := range pathParams {
+		if !slices.ContainsFunc(op.Parameters, func(p *Parameter) bool { return p.In == "path" && p.Name == name }) {
+			op.Parameters = append(op.Parameters, &Parameter{Name: name, In: "path", Required: true, Schema: &jsonschema.Schema{Type: "string"}})
+		}
+	}
+	slices.SortStableFunc(op.Parameters, func(a, b *Parameter) int {
+		return slices.Index(parameterOrder, a.In) - slices.Index(parameterOrder, b.In)
+	})
+
+	status := http.StatusOK
+	if method == "post" {
+		status = http.StatusCreated
+	}
+	for _, d := range analyzer.GetDecorators(fn) {
+		if d.Name == "HttpCode" && len(d.Arguments) == 1 {
+			if code, err := strconv.Atoi(d.Arguments[0]); err == nil {
+				status = code
+			}
+		}
+	}
+	response := &Response{Description: http.StatusText(status)}
+	if result := resultType(analyzer.ParseTypeExpr(ast.ChildByField(fn, "return_type"))); result != nil {
+		schema, err := g.schema(result)
+		if err != nil {
+			return fmt.Errorf("response: %w", err)
+		}
+		response.Content = map[string]MediaType{"application/json": {Schema: schema}}
+	}
+	op.Responses[strconv.Itoa(status)] = response
+
+	if g.doc.Paths == nil {
+		g.doc.Paths = make(map[string]*PathItem)
+	}
+	item := g.doc.Paths[path]
+	if item == nil {
+		item = &PathItem{}
+		g.doc.Paths[path] = item
+	}
+	(*item)[method] = op
+	return nil
+}
+
+// parameterOrder orders the parameters of an operation by location.
+var parameterOrder = []string{"path", "query", "header"}
+
+// parameterLocations maps parameter decorators to parameter locations.
+var parameterLocations = map[string]string{
+	"Param":   "path",
+	"Query":   "query",
+	"Headers": "header",
+}
+
+// parameter adds the parameter or request body described by the
+// decorators of a handler parameter to op.
+func (g *generator) parameter(op *Operation, param ast.Node) error {
+	required := param.SyntaxKind() == "required_parameter"
+	for _, d := range analyzer.GetDecorators(param) {
+		in, ok := parameterLocations[d.Name]
+		if d.Name != "Body" && !ok {
+			continue
+		}
+		t := analyzer.ParseTypeExpr(ast.ChildByField(param, "type"))
+		schema, err := g.schema(t)
+		if err != nil {
+			return fmt.Errorf("%s: %w", d.TargetName, err)
+		}
+		name, named := d.StringArgument(0)
+		switch {
+		case d.Name == "Body":
+			op.RequestBody = &RequestBody{
+				Required: required,
+				Content:  map[string]MediaType{"application/json": {Schema: schema}},
+			}
+		case named:
+			op.Parameters = append(op.Parameters, &Parameter{Name: name, In: in, Required: required || in == "path", Schema: schema})
+		default:
+			// An object of all the parameters: one parameter per property
+			object := g.resolve(schema)
+			if object == nil || object.Type != "object" {
+				return fmt.Errorf("%s: @%s() parameter is not an object", d.TargetName, d.Name)
+			}
+			for _, prop := range slices.Sorted(maps.Keys(object.Properties)) {
+				op.Parameters = append(op.Parameters, &Parameter{
+					Name:     prop,
+					In:       in,
+					Required: in == "path" || slices.Contains(object.Required, prop),
+					Schema:   object.Properties[prop],
+				})
+			}
+		}
+	}
+	return nil
+}
+
+// resolve returns the component schema s refers to, or s itself.
+func (g *generator) resolve(s *jsonschema.Schema) *jsonschema.Schema {
+	if name, ok := strings.CutPrefix(s.Ref, "#/components/schemas/"); ok {
+		return g.doc.Components.Schemas[name]
+	}
+	return s
+}
+
+// resultType returns the type of the response of a handler returning t,
+// unwrapping promises and observables, or nil if it returns nothing.
+func resultType(t *analyzer.TypeExpr) *analyzer.TypeExpr {
+	for t != nil && t.Kind == analyzer.TypeKindReference && (t.Name == "Promise" || t.Name == "Observable") && len(t.TypeArguments) == 1 {
+		t = t.TypeArguments[0]
+	}
+	if t == nil || t.Kind == analyzer.TypeKindPrimitive && t.Name == "void" {
+		return nil
+	}
+	return t
+}
+
+// openAPIPath converts an Express-style route path to an OpenAPI path,
+// returning the names of its parameters: /users/:id becomes /users/{id}.
+func openAPIPath(path string) (string, []string) {
+	var params []string
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if name, ok := strings.CutPrefix(segment, ":"); ok {
+			name = strings.TrimSuffix(name, "?")
+			params = append(params, name)
+			segments[i] = "{" + name + "}"
+		}
+	}
+	return strings.Join(segments, "/"), params
+}
+
+// exportedTypes returns the names of the non-generic interfaces, type
+// aliases and enums exported by the program root.
+func exportedTypes(root ast.Node) []string {
+	var names []string
+	for _, stmt := range root.Children() {
+		if stmt.SyntaxKind() != "export_statement" {
+			continue
+		}
+		decl := ast.ChildByField(stmt, "declaration")
+		if decl == nil || ast.ChildByField(decl, "type_parameters") != nil {
+			continue
+		}
+		switch decl.SyntaxKind() {
+		case "interface_declaration", "type_alias_declaration", "enum_declaration":
+			if name := ast.ChildByField(decl, "name"); name != nil {
+				names = append(names, name.Text())
+			}
+		}
+	}
+	return names
+}
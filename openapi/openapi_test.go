@@ -0,0 +1,152 @@
+package openapi
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/ahmadramadhannn/tsgoast"
+)
+
+func parse(t *testing.T, src string) *tsgoast.Tree {
+	t.Helper()
+	parser, err := tsgoast.New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+	defer parser.Close()
+	tree, err := parser.ParseTree([]byte(src))
+	if err != nil {
+		t.Fatalf("ParseTree() error = %v", err)
+	}
+	return tree
+}
+
+const source = `
+enum Role { Admin = "admin", User = "user" }
+
+export interface User {
+  id: number;
+  role: Role;
+}
+
+export interface CreateUser {
+  name: string;
+}
+
+export interface Filter {
+  limit?: number;
+  q: string;
+}
+
+interface Internal {
+  secret: string;
+}
+
+export type Page<T> = { items: T[] };
+
+@Controller("users")
+export class UsersController {
+  @Get()
+  list(@Query() filter: Filter): Promise<Page<User>> {}
+
+  @Get(":id")
+  find(@Param("id") id: number, @Headers("x-trace") trace?: string): Observable<User> {}
+
+  @Post()
+  create(@Body() body: CreateUser): User {}
+
+  @Delete(":id")
+  @HttpCode(204)
+  remove(id: string): void {}
+}
+`
+
+func marshal(t *testing.T, v any) string {
+	t.Helper()
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	return string(b)
+}
+
+func TestGenerate(t *testing.T) {
+	doc, err := Generate(parse(t, source))
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	want := `{"openapi":"3.1.0","info":{"title":"API","version":"1.0.0"},"components":{"schemas":{` +
+		`"CreateUser":{"type":"object","properties":{"name":{"type":"string"}},"required":["name"]},` +
+		`"Filter":{"type":"object","properties":{"limit":{"type":"number"},"q":{"type":"string"}},"required":["q"]},` +
+		`"Role":{"enum":["admin","user"]},` +
+		`"User":{"type":"object","properties":{"id":{"type":"number"},"role":{"$ref":"#/components/schemas/Role"}},"required":["id","role"]}}}}`
+	if got := marshal(t, doc); got != want {
+		t.Errorf("Generate() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestGenerateRoutes(t *testing.T) {
+	doc, err := (&Config{Title: "Users", Version: "2.0.0", Routes: true}).Generate(parse(t, source))
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if doc.Info.Title != "Users" || doc.Info.Version != "2.0.0" {
+		t.Errorf("Info = %+v", doc.Info)
+	}
+
+	tests := []struct {
+		path, method string
+		want         string
+	}{
+		{"/users", "get", `{"operationId":"list","tags":["UsersController"],"parameters":[` +
+			`{"name":"limit","in":"query","schema":{"type":"number"}},` +
+			`{"name":"q","in":"query","required":true,"schema":{"type":"string"}}],` +
+			`"responses":{"200":{"description":"OK","content":{"application/json":{"schema":` +
+			`{"type":"object","properties":{"items":{"type":"array","items":{"$ref":"#/components/schemas/User"}}},"required":["items"]}}}}}}`},
+		{"/users/{id}", "get", `{"operationId":"find","tags":["UsersController"],"parameters":[` +
+			`{"name":"id","in":"path","required":true,"schema":{"type":"number"}},` +
+			`{"name":"x-trace","in":"header","schema":{"type":"string"}}],` +
+			`"responses":{"200":{"description":"OK","content":{"application/json":{"schema":{"$ref":"#/components/schemas/User"}}}}}}`},
+		{"/users", "post", `{"operationId":"create","tags":["UsersController"],` +
+			`"requestBody":{"required":true,"content":{"application/json":{"schema":{"$ref":"#/components/schemas/CreateUser"}}}},` +
+			`"responses":{"201":{"description":"Created","content":{"application/json":{"schema":{"$ref":"#/components/schemas/User"}}}}}}`},
+		{"/users/{id}", "delete", `{"operationId":"remove","tags":["UsersController"],"parameters":[` +
+			`{"name":"id","in":"path","required":true,"schema":{"type":"string"}}],` +
+			`"responses":{"204":{"description":"No Content"}}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			item := doc.Paths[tt.path]
+			if item == nil || (*item)[tt.method] == nil {
+				t.Fatalf("no operation %s %s", tt.method, tt.path)
+			}
+			if got := marshal(t, (*item)[tt.method]); got != tt.want {
+				t.Errorf("operation =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateRouteErrors(t *testing.T) {
+	tree := parse(t, `
+@Controller("jobs")
+class JobsController {
+  @Post()
+  run(@Body() body: Unknown) {}
+}
+`)
+	_, err := (&Config{Routes: true}).Generate(tree)
+	if err == nil || !strings.Contains(err.Error(), "POST /jobs: body: ") || !strings.Contains(err.Error(), "unresolved type Unknown") {
+		t.Errorf("Generate() error = %v", err)
+	}
+}
+
+func TestOpenAPIPath(t *testing.T) {
+	path, params := openAPIPath("/users/:id/posts/:post?")
+	if path != "/users/{id}/posts/{post}" || strings.Join(params, ",") != "id,post" {
+		t.Errorf("openAPIPath() = %q, %v", path, params)
+	}
+}
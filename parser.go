@@ -2,8 +2,11 @@
 package tsgoast
 
 import (
+	"context"
 	"fmt"
-	"os"
+	"runtime"
+	"runtime/pprof"
+	"time"
 
 	"github.com/ahmadramadhannn/tsgoast/ast"
 	sitter "github.com/tree-sitter/go-tree-sitter"
@@ -14,62 +17,292 @@ import (
 type Parser struct {
 	parser   *sitter.Parser
 	language *sitter.Language
+	dialect  string // "typescript" or "tsx"
+	lazy     bool
+	mmapMin  int64 // size from which files are mapped, 0 to never map
+	hook     ParseHook
+	arena    bool
+	slab     *slab // arena of the tree being converted
+	labels   bool  // label the profiles of file parses
+	limits   Limits
+	conv     conversion // state of the tree being converted
+
+	kinds      []parserKind      // by tree-sitter symbol
+	fields     map[string]string // interned field names
+	fieldsByID []string          // interned field names, by field id
+}
+
+// parserKind describes the nodes of a tree-sitter symbol.
+type parserKind struct {
+	name     string // interned, see ast.InternKind
+	id       ast.Kind
+	nodeType ast.NodeType
 }
 
 // New creates a new TypeScript parser.
 func New() (*Parser, error) {
+	return newParser("typescript", sitter.NewLanguage(typescript.LanguageTypescript()))
+}
+
+// NewTSX creates a new parser for TypeScript with JSX (.tsx files).
+func NewTSX() (*Parser, error) {
+	return newParser("tsx", sitter.NewLanguage(typescript.LanguageTSX()))
+}
+
+// newParser creates a parser for the given tree-sitter language.
+func newParser(dialect string, lang *sitter.Language) (*Parser, error) {
 	parser := sitter.NewParser()
-	lang := sitter.NewLanguage(typescript.LanguageTypescript())
 
 	if err := parser.SetLanguage(lang); err != nil {
 		return nil, fmt.Errorf("failed to set language: %w", err)
 	}
 
-	return &Parser{
+	p := &Parser{
 		parser:   parser,
 		language: lang,
-	}, nil
+		dialect:  dialect,
+		kinds:    make([]parserKind, lang.NodeKindCount()),
+		fields:   make(map[string]string, lang.FieldCount()),
+	}
+	p.fieldsByID = make([]string, lang.FieldCount()+1)
+
+	// Intern the kinds and fields of the grammar once, so that nodes share
+	// their strings rather than each holding a copy
+	for i := range p.kinds {
+		id, name := ast.InternKind(lang.NodeKindForId(uint16(i)))
+		p.kinds[i] = parserKind{name: name, id: id, nodeType: p.mapNodeType(name)}
+	}
+	for i := uint32(1); i <= lang.FieldCount(); i++ {
+		name := lang.FieldNameForId(uint16(i))
+		p.fields[name] = name
+		p.fieldsByID[i] = name
+	}
+
+	return p, nil
 }
 
-// Parse parses TypeScript source code and returns the root AST node.
+// Parse parses TypeScript source code and returns the root AST node. The
+// subtrees left out by the limits of p, if any, are reported by ParseTree
+// only.
 func (p *Parser) Parse(source []byte) (*ast.BaseNode, error) {
+	result, err := p.parse("", source)
+	return result.root, err
+}
+
+// parsed is the result of parse.
+type parsed struct {
+	root        *ast.BaseNode
+	text        string // source text the nodes share
+	stats       ParseStats
+	slab        *slab // the nodes, if allocated from an arena
+	diagnostics []Diagnostic
+}
+
+// parse parses source, the content of the file at path if any, reporting
+// the statistics of the parse to the parse hook.
+func (p *Parser) parse(path string, source []byte) (result parsed, err error) {
+	if p.labels && path != "" {
+		pprof.Do(context.Background(), pprof.Labels("tsgoast_file", path), func(context.Context) {
+			result, err = p.convert(source)
+		})
+	} else {
+		result, err = p.convert(source)
+	}
+	p.report(path, result.root, result.stats, err)
+	return result, err
+}
+
+// convert parses source with tree-sitter and converts the tree, timing
+// both.
+func (p *Parser) convert(source []byte) (parsed, error) {
+	result := parsed{stats: ParseStats{Bytes: len(source)}}
 	if len(source) == 0 {
-		return nil, fmt.Errorf("source code is empty")
+		return result, fmt.Errorf("source code is empty")
 	}
 
+	start := time.Now()
 	tree := p.parser.Parse(source, nil)
+	result.stats.ParseTime = time.Since(start)
 	if tree == nil {
-		return nil, fmt.Errorf("failed to parse source code")
+		return result, fmt.Errorf("failed to parse source code")
 	}
-	defer tree.Close()
 
 	root := tree.RootNode()
 	if root == nil {
-		return nil, fmt.Errorf("failed to get root node")
+		tree.Close()
+		return result, fmt.Errorf("failed to get root node")
+	}
+
+	// The text of every node is a slice of one copy of the source
+	start = time.Now()
+	result.text = string(source)
+	if p.lazy {
+		result.root = p.convertLazy(root, result.text, nil, 0)
+		runtime.AddCleanup(result.root, func(tree *sitter.Tree) { tree.Close() }, tree)
+		result.stats.ConvertTime = time.Since(start)
+		return result, nil
+	}
+	defer tree.Close()
+	if p.arena {
+		result.slab = newSlab(int(root.DescendantCount()))
+		p.slab = result.slab
+		defer func() { p.slab = nil }()
 	}
+	result.root, result.diagnostics = p.convertTree(root, result.text, 0, nil, 0)
+	result.stats.ConvertTime = time.Since(start)
+	result.stats.Truncated = len(result.diagnostics)
+	return result, nil
+}
+
+// SetLazy sets whether the parser converts trees lazily: the children of
+// each node are converted the first time its Children method is called,
+// rather than during parsing, which saves most of the conversion of the
+// nodes that are never visited, as when only the top-level declarations
+// are inspected. Walking the whole tree once converts it completely.
+//
+// The tree-sitter tree of a lazy tree is kept until the root node is
+// unreachable. Converting children is not synchronized, so a lazy tree
+// must not be used by several goroutines at once until it is converted
+// completely.
+func (p *Parser) SetLazy(lazy bool) {
+	p.lazy = lazy
+}
+
+// formatVersion is the version of the conversion of tree-sitter trees,
+// raised whenever a source may be converted to a different tree, or trees
+// encoded differently.
+const formatVersion = 2
 
-	return p.convertNode(root, source, nil), nil
+// Version identifies the trees p produces, for caches of parsed trees: two
+// parsers with the same version parse a source to the same tree. It covers
+// the dialect, the revision of the grammar, the conversion and the limits
+// of p.
+func (p *Parser) Version() string {
+	return fmt.Sprintf("tsgoast/%d %s abi%d states%d depth%d nodes%d", formatVersion, p.dialect,
+		p.language.AbiVersion(), p.language.ParseStateCount(), max(p.limits.MaxDepth, 0), max(p.limits.MaxNodes, 0))
 }
 
 // ParseFile parses a TypeScript file and returns the root AST node.
 func (p *Parser) ParseFile(path string) (*ast.BaseNode, error) {
-	source, err := os.ReadFile(path)
+	source, release, err := p.readFile(path)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read file: %w", err)
+		err = fmt.Errorf("failed to read file: %w", err)
+		p.report(path, nil, ParseStats{}, err)
+		return nil, err
 	}
+	defer release()
 
-	return p.Parse(source)
+	result, err := p.parse(path, source)
+	return result.root, err
 }
 
-// convertNode converts a tree-sitter node to our AST node.
-func (p *Parser) convertNode(node *sitter.Node, source []byte, parent *ast.BaseNode) *ast.BaseNode {
+// convertTree converts a tree-sitter node at the given depth and its
+// subtree, within the limits of p, like convertNode, and returns the
+// diagnostics of the truncated subtrees.
+func (p *Parser) convertTree(node *sitter.Node, text string, base uint, parent *ast.BaseNode, depth int) (*ast.BaseNode, []Diagnostic) {
+	p.conv = conversion{depth: depth, nodes: 1}
+	root := p.convertNode(node, text, base, parent)
+	diagnostics := p.conv.diagnostics
+	p.conv = conversion{}
+	return root, diagnostics
+}
+
+// convertNode converts a tree-sitter node to our AST node. text is the
+// source from offset base on. The subtree is converted with an explicit
+// stack rather than recursion, so that deeply nested input cannot exhaust
+// the stack.
+func (p *Parser) convertNode(node *sitter.Node, text string, base uint, parent *ast.BaseNode) *ast.BaseNode {
 	if node == nil {
 		return nil
 	}
 
-	baseNode := &ast.BaseNode{
-		NodeType: p.mapNodeType(node.Kind()),
-		Content:  string(source[node.StartByte():node.EndByte()]),
+	// pending is a converted node whose children are being converted
+	type pending struct {
+		node     *sitter.Node
+		baseNode *ast.BaseNode
+		next     uint // index of the next child to convert
+		count    uint
+	}
+	var stack []pending
+
+	// open converts node, and pushes it if its children are converted
+	open := func(node *sitter.Node, parent *ast.BaseNode) *ast.BaseNode {
+		baseNode := p.newBaseNode(node, text[node.StartByte()-base:node.EndByte()-base], parent)
+		childCount := node.ChildCount()
+		if childCount > 0 && !p.truncate(baseNode, int(childCount)) {
+			p.conv.depth++
+			if p.slab != nil {
+				baseNode.ChildNodes = p.slab.children(int(childCount))
+			} else {
+				baseNode.ChildNodes = make([]ast.Node, 0, childCount)
+			}
+			stack = append(stack, pending{node: node, baseNode: baseNode, count: childCount})
+		}
+		return baseNode
+	}
+
+	root := open(node, parent)
+	for len(stack) > 0 {
+		top := &stack[len(stack)-1]
+		if top.next == top.count {
+			stack = stack[:len(stack)-1]
+			p.conv.depth--
+			continue
+		}
+		i := top.next
+		top.next++
+		child := top.node.Child(i)
+		if child == nil {
+			continue
+		}
+		// open may grow the stack, so top is not used after it
+		node, parent := top.node, top.baseNode
+		childNode := open(child, parent)
+		childNode.FieldName = p.fieldName(node, i)
+		parent.ChildNodes = append(parent.ChildNodes, childNode)
+	}
+
+	return root
+}
+
+// convertLazy converts a tree-sitter node to our AST node, leaving its
+// children to be converted on first access. text is the source. Nodes at
+// the maximum depth of the limits of p get no children.
+func (p *Parser) convertLazy(node *sitter.Node, text string, parent *ast.BaseNode, depth int) *ast.BaseNode {
+	baseNode := p.newBaseNode(node, text[node.StartByte():node.EndByte()], parent)
+
+	if childCount := node.ChildCount(); childCount > 0 && (p.limits.MaxDepth <= 0 || depth < p.limits.MaxDepth) {
+		baseNode.SetChildLoader(func() []ast.Node {
+			children := make([]ast.Node, 0, childCount)
+			for i := uint(0); i < childCount; i++ {
+				if child := node.Child(i); child != nil {
+					childNode := p.convertLazy(child, text, baseNode, depth+1)
+					childNode.FieldName = p.fieldName(node, i)
+					children = append(children, childNode)
+				}
+			}
+			return children
+		})
+	}
+
+	return baseNode
+}
+
+// newBaseNode returns the AST node of a tree-sitter node, without its
+// children.
+func (p *Parser) newBaseNode(node *sitter.Node, content string, parent *ast.BaseNode) *ast.BaseNode {
+	kind := p.kind(node)
+	var baseNode *ast.BaseNode
+	if p.slab != nil {
+		baseNode = p.slab.node()
+	} else {
+		baseNode = new(ast.BaseNode)
+	}
+	*baseNode = ast.BaseNode{
+		NodeType:       kind.nodeType,
+		TreeSitterKind: kind.name,
+		KindID:         kind.id,
+		Content:        content,
 		SourceRange: ast.Range{
 			Start: ast.Position{
 				Line:   uint32(node.StartPosition().Row),
@@ -89,22 +322,34 @@ func (p *Parser) convertNode(node *sitter.Node, source []byte, parent *ast.BaseN
 		baseNode.ParentNode = parent
 	}
 
-	// Convert children
-	childCount := node.ChildCount()
-	if childCount > 0 {
-		baseNode.ChildNodes = make([]ast.Node, 0, childCount)
-		for i := uint(0); i < childCount; i++ {
-			child := node.Child(i)
-			if child != nil {
-				childNode := p.convertNode(child, source, baseNode)
-				if childNode != nil {
-					baseNode.ChildNodes = append(baseNode.ChildNodes, childNode)
-				}
-			}
-		}
+	return baseNode
+}
+
+// kind returns the kind of node.
+func (p *Parser) kind(node *sitter.Node) parserKind {
+	if id := int(node.KindId()); id < len(p.kinds) {
+		return p.kinds[id]
 	}
+	id, name := ast.InternKind(node.Kind())
+	return parserKind{name: name, id: id, nodeType: p.mapNodeType(name)}
+}
 
-	return baseNode
+// fieldName returns the interned name of the field of the child i of
+// node.
+func (p *Parser) fieldName(node *sitter.Node, i uint) string {
+	name := node.FieldNameForChild(uint32(i))
+	if interned, ok := p.fields[name]; ok {
+		return interned
+	}
+	return name
+}
+
+// fieldByID returns the interned name of the field of the node at cursor.
+func (p *Parser) fieldByID(cursor *sitter.TreeCursor) string {
+	if id := int(cursor.FieldId()); id < len(p.fieldsByID) {
+		return p.fieldsByID[id]
+	}
+	return cursor.FieldName()
 }
 
 // nodeTypeMap maps tree-sitter node types to our AST node types.
@@ -1,9 +1,14 @@
 package tsgoast
 
 import (
+	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
+	"strings"
 	"testing"
+	"unsafe"
 
 	"github.com/ahmadramadhannn/tsgoast/ast"
 )
@@ -26,6 +31,35 @@ func TestNew(t *testing.T) {
 	}
 }
 
+func TestNewTSX(t *testing.T) {
+	parser, err := NewTSX()
+	if err != nil {
+		t.Fatalf("NewTSX() error = %v, want nil", err)
+	}
+	defer parser.Close()
+
+	root, err := parser.Parse([]byte("const App = () => <div className=\"app\">hello</div>;"))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	found := false
+	var walk func(ast.Node)
+	walk = func(n ast.Node) {
+		if n.SyntaxKind() == "jsx_element" {
+			found = true
+		}
+		for _, child := range n.Children() {
+			walk(child)
+		}
+	}
+	walk(root)
+
+	if !found {
+		t.Error("TSX parser did not produce a jsx_element node")
+	}
+}
+
 func TestParse(t *testing.T) {
 	parser, err := New()
 	if err != nil {
@@ -343,3 +377,256 @@ func TestParseFileWithTempFile(t *testing.T) {
 		t.Fatal("ParseFile() returned nil node")
 	}
 }
+
+func TestFieldNames(t *testing.T) {
+	parser, err := New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+	defer parser.Close()
+
+	root, err := parser.Parse([]byte(`function add(a: number, b: number): number { return a + b; }`))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	fn := root.Children()[0].(*ast.BaseNode)
+	tests := []struct {
+		field string
+		want  string
+	}{
+		{"name", "add"},
+		{"parameters", "(a: number, b: number)"},
+		{"return_type", ": number"},
+		{"body", "{ return a + b; }"},
+	}
+	for _, tt := range tests {
+		child := fn.ChildByField(tt.field)
+		if child == nil || child.Text() != tt.want {
+			t.Errorf("ChildByField(%q) = %v, want %q", tt.field, child, tt.want)
+		}
+	}
+
+	body := fn.ChildByField("body")
+	ret := ast.FirstChildOfKind(body, "return_statement")
+	binary := ast.FindDescendant(ret, func(n ast.Node) bool { return n.SyntaxKind() == "binary_expression" })
+	if left := ast.ChildByField(binary, "left"); left == nil || left.Text() != "a" {
+		t.Errorf("binary left = %v, want a", left)
+	}
+	if op := ast.ChildByField(binary, "operator"); op == nil || op.Text() != "+" {
+		t.Errorf("binary operator = %v, want +", op)
+	}
+}
+
+func TestParseLazy(t *testing.T) {
+	source := []byte(`import { a } from "./a";
+export class Greeter<T> {
+	greet(name: string): string { return "hello " + name; }
+}
+function add(x: number, y: number) { return x + y; }
+`)
+	eager, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer eager.Close()
+	lazy, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer lazy.Close()
+	lazy.SetLazy(true)
+
+	root, err := lazy.Parse(source)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if root.ChildNodes != nil {
+		t.Error("lazy Parse() converted the children of the root")
+	}
+	first := root.Children()[0].(*ast.BaseNode)
+	if first.ChildNodes != nil {
+		t.Error("Children() converted the grandchildren of the root")
+	}
+
+	want, err := eager.Parse(source)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	gotJSON, err := json.Marshal(root)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	wantJSON, err := json.Marshal(want)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if string(gotJSON) != string(wantJSON) {
+		t.Errorf("lazy tree differs from eager tree:\n%s\nwant\n%s", gotJSON, wantJSON)
+	}
+
+	tree, err := lazy.ParseTree(source)
+	if err != nil {
+		t.Fatalf("ParseTree() error = %v", err)
+	}
+	fn, ok := tree.Statements[2].(*ast.FunctionDeclaration)
+	if !ok || fn.Name != "add" {
+		t.Fatalf("ParseTree() statement 2 = %#v, want function add", tree.Statements[2])
+	}
+	if body := fn.ChildByField("body"); body == nil || body.Parent() != tree.Root.Children()[2] {
+		t.Errorf("function body = %v, want the body of the root's child", body)
+	}
+}
+
+func BenchmarkParseLazy(b *testing.B) {
+	source, err := os.ReadFile("testdata/simple.ts")
+	if err != nil {
+		b.Fatal(err)
+	}
+	for _, lazy := range []bool{false, true} {
+		b.Run(fmt.Sprintf("lazy=%t", lazy), func(b *testing.B) {
+			parser, err := New()
+			if err != nil {
+				b.Fatalf("Failed to create parser: %v", err)
+			}
+			defer parser.Close()
+			parser.SetLazy(lazy)
+
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				tree, err := parser.ParseTree(source)
+				if err != nil {
+					b.Fatalf("ParseTree error: %v", err)
+				}
+				_ = tree.Statements
+			}
+		})
+	}
+}
+
+// largeSource returns about size bytes of TypeScript, repeating the files
+// of testdata.
+func largeSource(tb testing.TB, size int) []byte {
+	tb.Helper()
+	var chunk []byte
+	for _, name := range []string{"simple.ts", "functions.ts", "types.ts"} {
+		b, err := os.ReadFile(filepath.Join("testdata", name))
+		if err != nil {
+			tb.Fatal(err)
+		}
+		chunk = append(append(chunk, b...), '\n')
+	}
+	var source []byte
+	for i := 0; len(source) < size; i++ {
+		// Rename the declarations of each copy to keep the source valid
+		source = append(source, fmt.Sprintf("namespace copy%d {\n", i)...)
+		source = append(append(source, chunk...), "}\n"...)
+	}
+	return source
+}
+
+// BenchmarkParseLarge parses a 1MB file, reporting the heap retained by
+// the tree as retained-B.
+func BenchmarkParseLarge(b *testing.B) {
+	source := largeSource(b, 1<<20)
+	parser, err := New()
+	if err != nil {
+		b.Fatalf("Failed to create parser: %v", err)
+	}
+	defer parser.Close()
+
+	b.SetBytes(int64(len(source)))
+	b.ReportAllocs()
+	var retained uint64
+	for i := 0; i < b.N; i++ {
+		var before, after runtime.MemStats
+		runtime.GC()
+		runtime.ReadMemStats(&before)
+		root, err := parser.Parse(source)
+		if err != nil {
+			b.Fatalf("Parse error: %v", err)
+		}
+		runtime.GC()
+		runtime.ReadMemStats(&after)
+		retained += after.HeapAlloc - before.HeapAlloc
+		runtime.KeepAlive(root)
+	}
+	b.ReportMetric(float64(retained)/float64(b.N), "retained-B")
+}
+
+func TestParseSharesSource(t *testing.T) {
+	parser, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer parser.Close()
+
+	root, err := parser.Parse([]byte("const answer = 42;\n"))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	start := unsafe.StringData(root.Text())
+	for node := range ast.Preorder(root) {
+		if node.Text() == "" {
+			continue
+		}
+		offset := uintptr(node.Range().Start.Offset - root.Range().Start.Offset)
+		if unsafe.StringData(node.Text()) != (*byte)(unsafe.Add(unsafe.Pointer(start), offset)) {
+			t.Errorf("text of %s %q is a copy of the source", node.SyntaxKind(), node.Text())
+		}
+	}
+}
+
+func TestNodeKinds(t *testing.T) {
+	parser, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer parser.Close()
+
+	// The Kind constants name kinds of the grammar
+	for k := ast.KindProgram; k <= ast.KindTypeAnnotation; k++ {
+		if parser.language.IdForNodeKind(k.String(), true) == 0 {
+			t.Errorf("kind %q is not a node of the grammar", k)
+		}
+	}
+
+	root, err := parser.Parse([]byte("class A { m(x?: number) { return this.f(x); } }"))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	for node := range ast.Preorder(root) {
+		b := node.(*ast.BaseNode)
+		if b.KindID == ast.KindUnknown || b.KindID.String() != b.TreeSitterKind {
+			t.Errorf("node %s has kind %d (%q)", b.TreeSitterKind, b.KindID, b.KindID)
+		}
+	}
+	if ast.KindOf(root) != ast.KindProgram {
+		t.Errorf("KindOf(root) = %v, want program", ast.KindOf(root))
+	}
+}
+
+func TestParserVersion(t *testing.T) {
+	ts, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer ts.Close()
+	tsx, err := NewTSX()
+	if err != nil {
+		t.Fatalf("NewTSX() error = %v", err)
+	}
+	defer tsx.Close()
+
+	if ts.Version() == tsx.Version() {
+		t.Errorf("TypeScript and TSX parsers have the same version %q", ts.Version())
+	}
+	if !strings.HasPrefix(ts.Version(), "tsgoast/") {
+		t.Errorf("Version() = %q", ts.Version())
+	}
+	version := ts.Version()
+	ts.SetLimits(Limits{MaxDepth: 100})
+	if ts.Version() == version {
+		t.Errorf("parsers with and without limits have the same version %q", version)
+	}
+}
@@ -0,0 +1,247 @@
+package tsgoast
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+// Pool hands out parsers to concurrent goroutines. A Parser is not safe
+// for concurrent use, and creating one for every file is wasteful; a Pool
+// keeps the parsers returned to it for reuse and bounds the number of
+// parsers in use at once.
+//
+//	pool := tsgoast.NewPool(0)
+//	defer pool.Close()
+//	root, err := pool.Parse(source) // safe for concurrent use
+//
+// A Pool must be closed when no longer needed, to release its parsers.
+type Pool struct {
+	newParser func() (*Parser, error)
+	slots     chan struct{} // one value per parser in use
+
+	mu     sync.Mutex
+	idle   []*Parser
+	closed bool
+}
+
+// NewPool returns a pool of at most size TypeScript parsers. A size of
+// zero or less uses GOMAXPROCS.
+func NewPool(size int) *Pool {
+	return newPool(size, New)
+}
+
+// NewTSXPool returns a pool of at most size TSX parsers. A size of zero or
+// less uses GOMAXPROCS.
+func NewTSXPool(size int) *Pool {
+	return newPool(size, NewTSX)
+}
+
+func newPool(size int, newParser func() (*Parser, error)) *Pool {
+	if size <= 0 {
+		size = runtime.GOMAXPROCS(0)
+	}
+	return &Pool{newParser: newParser, slots: make(chan struct{}, size)}
+}
+
+// Get returns a parser of the pool, creating one if none is idle. It
+// blocks while the pool has as many parsers in use as its size. The
+// parser must be given back with Put.
+func (pool *Pool) Get() (*Parser, error) {
+	pool.slots <- struct{}{}
+	pool.mu.Lock()
+	if pool.closed {
+		pool.mu.Unlock()
+		<-pool.slots
+		return nil, fmt.Errorf("parser pool is closed")
+	}
+	if n := len(pool.idle); n > 0 {
+		p := pool.idle[n-1]
+		pool.idle = pool.idle[:n-1]
+		pool.mu.Unlock()
+		return p, nil
+	}
+	pool.mu.Unlock()
+
+	p, err := pool.newParser()
+	if err != nil {
+		<-pool.slots
+		return nil, err
+	}
+	return p, nil
+}
+
+// Put gives back a parser obtained from Get. Parsers given back to a
+// closed pool are closed.
+func (pool *Pool) Put(p *Parser) {
+	pool.mu.Lock()
+	if pool.closed {
+		p.Close()
+	} else {
+		pool.idle = append(pool.idle, p)
+	}
+	pool.mu.Unlock()
+	<-pool.slots
+}
+
+// Parse parses source with a parser of the pool, like Parser.Parse.
+func (pool *Pool) Parse(source []byte) (*ast.BaseNode, error) {
+	p, err := pool.Get()
+	if err != nil {
+		return nil, err
+	}
+	defer pool.Put(p)
+	return p.Parse(source)
+}
+
+// ParseTree parses source with a parser of the pool, like
+// Parser.ParseTree.
+func (pool *Pool) ParseTree(source []byte) (*Tree, error) {
+	p, err := pool.Get()
+	if err != nil {
+		return nil, err
+	}
+	defer pool.Put(p)
+	return p.ParseTree(source)
+}
+
+// Close closes the idle parsers of the pool, and the parsers in use as
+// they are given back. Get fails once the pool is closed.
+func (pool *Pool) Close() {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+	pool.closed = true
+	for _, p := range pool.idle {
+		p.Close()
+	}
+	pool.idle = nil
+}
+
+// ParseResult is the result of parsing a file with ParseAll.
+type ParseResult struct {
+	Path string
+	Tree *Tree
+	Err  error // a *FileError
+}
+
+// FileError is the error parsing a file with ParseAll or
+// Project.ParseConcurrently.
+type FileError struct {
+	Path string
+	Err  error
+}
+
+func (e *FileError) Error() string {
+	return e.Path + ": " + e.Err.Error()
+}
+
+func (e *FileError) Unwrap() error {
+	return e.Err
+}
+
+// ParseAll parses the files at paths on workers goroutines and sends the
+// result of each file on the returned channel as soon as it is parsed, so
+// results arrive in no particular order. The channel is closed once every
+// file is parsed, and must be drained. Each worker creates its own parsers:
+// .tsx and .jsx files are parsed as TSX, other files as TypeScript. A
+// workers value of zero or less uses GOMAXPROCS.
+func ParseAll(paths []string, workers int) <-chan ParseResult {
+	return parseFiles(context.Background(), &Project{}, paths, workers)
+}
+
+// isTSX reports whether the file at path is parsed as TSX by ParseAll.
+func isTSX(path string) bool {
+	switch filepath.Ext(path) {
+	case ".tsx", ".jsx":
+		return true
+	}
+	return false
+}
+
+// parseFiles is ParseAll, stopping to hand out files once ctx is done. The
+// paths are those of project, which sets their directory, their grammar
+// and how empty files are parsed.
+func parseFiles(ctx context.Context, project *Project, paths []string, workers int) <-chan ParseResult {
+	asTSX := project.TSX
+	if asTSX == nil {
+		asTSX = isTSX
+	}
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	results := make(chan ParseResult, workers)
+	jobs := make(chan string)
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers && w < len(paths); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var ts, tsx *Parser
+			defer func() {
+				for _, p := range []*Parser{ts, tsx} {
+					if p != nil {
+						p.Close()
+					}
+				}
+			}()
+			for path := range jobs {
+				result := ParseResult{Path: path}
+				parser, newParser := &ts, New
+				if asTSX(path) {
+					parser, newParser = &tsx, NewTSX
+				}
+				if *parser == nil {
+					*parser, result.Err = newParser()
+				}
+				name := joinDir(project.Dir, path)
+				switch {
+				case result.Err != nil:
+				case project.AllowEmpty && isEmptyFile(name):
+					// An empty file is a valid, empty module
+					result.Tree, result.Err = (*parser).ParseTree([]byte("\n"))
+				default:
+					result.Tree, result.Err = (*parser).ParseTreeFromFile(name)
+				}
+				if result.Err != nil {
+					result.Err = &FileError{Path: path, Err: result.Err}
+				}
+				results <- result
+			}
+		}()
+	}
+	go func() {
+	feed:
+		for _, path := range paths {
+			select {
+			case jobs <- path:
+			case <-ctx.Done():
+				break feed
+			}
+		}
+		close(jobs)
+		wg.Wait()
+		close(results)
+	}()
+	return results
+}
+
+// joinDir returns the path of the file at path, relative to dir and
+// maybe with slashes, or path itself if dir is empty.
+func joinDir(dir, path string) string {
+	if dir == "" {
+		return path
+	}
+	return filepath.Join(dir, filepath.FromSlash(path))
+}
+
+// isEmptyFile reports whether the file at name exists and is empty.
+func isEmptyFile(name string) bool {
+	info, err := os.Stat(name)
+	return err == nil && info.Mode().IsRegular() && info.Size() == 0
+}
@@ -0,0 +1,121 @@
+package tsgoast
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+func TestPool(t *testing.T) {
+	pool := NewPool(2)
+	defer pool.Close()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 8)
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			tree, err := pool.ParseTree([]byte("const a = 1;\nfunction f() {}\n"))
+			if err == nil && len(tree.Statements) != 2 {
+				t.Errorf("ParseTree() statements = %d, want 2", len(tree.Statements))
+			}
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("ParseTree() error = %v", err)
+		}
+	}
+	if n := len(pool.idle); n < 1 || n > 2 {
+		t.Errorf("pool has %d idle parsers, want 1 or 2", n)
+	}
+
+	p, err := pool.Get()
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	pool.Close()
+	pool.Put(p)
+	if p.parser != nil {
+		t.Error("Put() to a closed pool did not close the parser")
+	}
+	if _, err := pool.Parse([]byte("x")); err == nil {
+		t.Error("Parse() on a closed pool: want error")
+	}
+}
+
+func TestTSXPool(t *testing.T) {
+	pool := NewTSXPool(0)
+	defer pool.Close()
+
+	root, err := pool.Parse([]byte("const App = () => <div />;"))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if !strings.Contains(root.Children()[0].Text(), "<div />") || hasError(root) {
+		t.Errorf("Parse() did not parse JSX: %s", root.Text())
+	}
+}
+
+func TestParseAll(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"a.ts":     "export const a = 1;\n",
+		"b.tsx":    "export const B = () => <b />;\n",
+		"c.ts":     "import { a } from './a';\nexport function c() { return a; }\n",
+		"empty.ts": "",
+	}
+	var paths []string
+	for name, text := range files {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(text), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		paths = append(paths, path)
+	}
+	paths = append(paths, filepath.Join(dir, "missing.ts"))
+
+	var parsed, failed []string
+	for result := range ParseAll(paths, 2) {
+		name := filepath.Base(result.Path)
+		if result.Err != nil {
+			if !strings.Contains(result.Err.Error(), result.Path) {
+				t.Errorf("error %q does not name the file", result.Err)
+			}
+			failed = append(failed, name)
+			continue
+		}
+		if hasError(result.Tree.Root) {
+			t.Errorf("%s parsed with errors", name)
+		}
+		parsed = append(parsed, name)
+	}
+	sort.Strings(parsed)
+	sort.Strings(failed)
+	if got := strings.Join(parsed, " "); got != "a.ts b.tsx c.ts" {
+		t.Errorf("ParseAll() parsed %s", got)
+	}
+	if got := strings.Join(failed, " "); got != "empty.ts missing.ts" {
+		t.Errorf("ParseAll() failed on %s", got)
+	}
+
+	if _, ok := <-ParseAll(nil, 0); ok {
+		t.Error("ParseAll(nil) sent a result")
+	}
+}
+
+// hasError reports whether the tree rooted at root holds a syntax error.
+func hasError(root ast.Node) bool {
+	return ast.FindDescendant(root, func(n ast.Node) bool {
+		return n.SyntaxKind() == "ERROR" || n.SyntaxKind() == "MISSING"
+	}) != nil
+}
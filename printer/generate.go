@@ -0,0 +1,507 @@
+package printer
+
+import (
+	"cmp"
+	"strings"
+
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+// generate prints a synthesized typed node from its fields. It reports
+// false for node types it cannot generate.
+func (p *printer) generate(n ast.Node) bool {
+	switch n := n.(type) {
+	case *ast.VariableStatement:
+		p.variableStatement(n)
+		p.write(";")
+	case *ast.FunctionDeclaration:
+		p.modifier(n.IsExported, "export")
+		p.modifier(n.IsAsync, "async")
+		p.write("function")
+		if n.IsGenerator {
+			p.write("*")
+		}
+		p.write(" " + n.Name)
+		p.typeParameters(n.TypeParameters)
+		p.signature(n.Parameters, n.ReturnType)
+		if n.Body != nil {
+			p.write(" ")
+			p.node(n.Body)
+		} else {
+			p.write(";")
+		}
+	case *ast.FunctionNode:
+		p.modifier(n.IsExported, "export")
+		p.modifier(n.IsAsync, "async")
+		p.write("function")
+		if n.IsGenerator {
+			p.write("*")
+		}
+		p.write(" " + n.Name)
+		p.typeParameters(n.TypeParameters)
+		p.signature(n.Parameters, n.ReturnType)
+		p.write(" ")
+		p.textBody(n.Body)
+	case *ast.ArrowFunctionNode:
+		p.modifier(n.IsAsync, "async")
+		p.signature(n.Parameters, n.ReturnType)
+		p.write(" => ")
+		p.textBody(n.Body)
+	case *ast.MethodNode:
+		if n.Visibility != "" {
+			p.write(n.Visibility + " ")
+		}
+		p.modifier(n.IsStatic, "static")
+		p.modifier(n.IsAbstract, "abstract")
+		p.modifier(n.IsAsync, "async")
+		p.write(n.Name)
+		p.signature(n.Parameters, n.ReturnType)
+		if n.IsAbstract && n.Body == "" {
+			p.write(";")
+		} else {
+			p.write(" ")
+			p.textBody(n.Body)
+		}
+	case *ast.ClassDeclaration:
+		for _, decorator := range n.Decorators {
+			p.write("@" + strings.TrimPrefix(decorator, "@") + "\n" + p.lineIndent())
+		}
+		p.modifier(n.IsExported, "export")
+		p.modifier(n.IsAbstract, "abstract")
+		p.write("class " + n.Name)
+		p.typeParameters(n.TypeParameters)
+		if n.SuperClass != "" {
+			p.write(" extends " + n.SuperClass)
+		}
+		p.write(" ")
+		if n.Body != nil {
+			p.node(n.Body)
+		} else {
+			p.write("{}")
+		}
+	case *ast.ClassBody:
+		p.block(n.Members)
+	case *ast.InterfaceNode:
+		p.modifier(n.IsExported, "export")
+		p.write("interface " + n.Name)
+		p.typeParameters(n.TypeParameters)
+		if len(n.Extends) > 0 {
+			p.write(" extends " + strings.Join(n.Extends, ", "))
+		}
+		p.write(" ")
+		p.interfaceBody(n)
+	case *ast.TypeAliasNode:
+		p.modifier(n.IsExported, "export")
+		p.write("type " + n.Name)
+		p.typeParameters(n.TypeParameters)
+		p.write(" = " + n.TypeDefinition + ";")
+	case *ast.EnumDeclaration:
+		p.modifier(n.IsExported, "export")
+		p.modifier(n.IsConst, "const")
+		p.write("enum " + n.Name + " ")
+		p.enumBody(n.Members)
+	case *ast.EnumMember:
+		p.write(n.Name)
+		if n.Initializer != nil {
+			p.write(" = ")
+			p.node(n.Initializer)
+		}
+	case *ast.NamespaceDeclaration:
+		p.modifier(n.IsExported, "export")
+		p.write("namespace " + n.Name + " ")
+		p.block(statementNodes(n.Body))
+	case *ast.ImportDeclaration:
+		p.write("import ")
+		if len(n.Specifiers) > 0 {
+			p.specifiers(n.Specifiers)
+			p.write(" from ")
+		}
+		p.write(quote(n.Source) + ";")
+	case *ast.ExportDeclaration:
+		p.write("export ")
+		if n.IsDefault {
+			p.write("default ")
+		}
+		if n.Declaration != nil {
+			p.node(n.Declaration)
+			if n.IsDefault && !isLineNode(n.Declaration) {
+				p.write(";")
+			}
+			break
+		}
+		p.write("{ ")
+		p.list(n.Specifiers)
+		p.write(" }")
+		if n.Source != "" {
+			p.write(" from " + quote(n.Source))
+		}
+		p.write(";")
+	case *ast.BlockStatement:
+		p.block(statementNodes(n.Statements))
+	case *ast.ExpressionStatement:
+		p.node(n.Expression)
+		p.write(";")
+	case *ast.IfStatement:
+		p.write("if (")
+		p.node(n.Condition)
+		p.write(") ")
+		p.body(n.Consequence)
+		if n.Alternative != nil {
+			p.write(" else ")
+			p.node(n.Alternative)
+		}
+	case *ast.WhileStatement:
+		p.write("while (")
+		p.node(n.Condition)
+		p.write(") ")
+		p.body(n.Body)
+	case *ast.ForStatement:
+		p.write("for (")
+		p.forClause(n.Initializer)
+		p.write("; ")
+		p.node(n.Condition)
+		p.write("; ")
+		p.node(n.Increment)
+		p.write(") ")
+		p.body(n.Body)
+	case *ast.ForInStatement:
+		p.write("for (")
+		p.forClause(n.Left)
+		p.write(" in ")
+		p.node(n.Right)
+		p.write(") ")
+		p.body(n.Body)
+	case *ast.ForOfStatement:
+		p.write("for ")
+		if n.IsAwait {
+			p.write("await ")
+		}
+		p.write("(")
+		p.forClause(n.Left)
+		p.write(" of ")
+		p.node(n.Right)
+		p.write(") ")
+		p.body(n.Body)
+	case *ast.SwitchStatement:
+		p.write("switch (")
+		p.node(n.Discriminant)
+		p.write(") ")
+		var cases []ast.Node
+		for _, c := range n.Cases {
+			if c != nil {
+				cases = append(cases, c)
+			}
+		}
+		p.block(cases)
+	case *ast.SwitchCase:
+		if n.Test != nil {
+			p.write("case ")
+			p.node(n.Test)
+			p.write(":")
+		} else {
+			p.write("default:")
+		}
+		indent := p.lineIndent() + p.indent
+		for _, stmt := range n.Consequent {
+			p.write("\n" + indent)
+			p.node(stmt)
+		}
+	case *ast.TryStatement:
+		p.write("try ")
+		p.body(n.Body)
+		if n.Handler != nil {
+			p.write(" ")
+			p.node(n.Handler)
+		}
+		if n.Finalizer != nil {
+			p.write(" finally ")
+			p.node(n.Finalizer)
+		}
+	case *ast.CatchClause:
+		p.write("catch ")
+		if n.Parameter != "" {
+			p.write("(" + n.Parameter)
+			if n.ParamType != "" {
+				p.write(": " + n.ParamType)
+			}
+			p.write(") ")
+		}
+		p.body(n.Body)
+	case *ast.ReturnStatement:
+		p.keywordStatement("return", n.Argument)
+	case *ast.ThrowStatement:
+		p.keywordStatement("throw", n.Argument)
+	case *ast.BreakStatement:
+		p.labelStatement("break", n.Label)
+	case *ast.ContinueStatement:
+		p.labelStatement("continue", n.Label)
+	case *ast.EmptyStatement:
+		p.write(";")
+	case *ast.DebuggerStatement:
+		p.write("debugger;")
+	case *ast.LabeledStatement:
+		p.write(n.Label + ": ")
+		p.node(n.Statement)
+	case *ast.WithStatement:
+		p.write("with (")
+		p.node(n.Object)
+		p.write(") ")
+		p.body(n.Body)
+	case *ast.IdentifierNode:
+		p.write(n.Name)
+	case *ast.LiteralNode:
+		p.write(n.Value)
+	case *ast.ExpressionNode:
+		return p.expression(n)
+	default:
+		return false
+	}
+	return true
+}
+
+// expression prints the expression kinds whose operands are enough to
+// generate them.
+func (p *printer) expression(n *ast.ExpressionNode) bool {
+	switch n.ExprType {
+	case ast.ExpressionTypeBinary, ast.ExpressionTypeAssignment:
+		p.node(n.Left)
+		p.write(" " + n.Operator + " ")
+		p.node(n.Right)
+	case ast.ExpressionTypeUnary:
+		p.write(n.Operator)
+		if n.Operator != "" && isWordByte(n.Operator[len(n.Operator)-1]) {
+			p.write(" ")
+		}
+		p.node(cmp.Or(n.Left, n.Right))
+	case ast.ExpressionTypeMember:
+		p.node(n.Left)
+		p.write(".")
+		p.node(n.Right)
+	case ast.ExpressionTypeCall:
+		p.node(n.Left)
+		p.write("(")
+		p.node(n.Right)
+		p.write(")")
+	case ast.ExpressionTypeAwait, ast.ExpressionTypeNew:
+		p.write(string(n.ExprType) + " ")
+		p.node(cmp.Or(n.Left, n.Right))
+	default:
+		return false
+	}
+	return true
+}
+
+// modifier writes keyword followed by a space if set.
+func (p *printer) modifier(set bool, keyword string) {
+	if set {
+		p.write(keyword + " ")
+	}
+}
+
+func (p *printer) typeParameters(params []string) {
+	if len(params) > 0 {
+		p.write("<" + strings.Join(params, ", ") + ">")
+	}
+}
+
+// signature writes a parameter list and optional return type.
+func (p *printer) signature(params []*ast.Parameter, returnType string) {
+	p.write("(")
+	for i, param := range params {
+		if i > 0 {
+			p.write(", ")
+		}
+		if param.IsRest {
+			p.write("...")
+		}
+		p.write(param.Name)
+		if param.IsOptional {
+			p.write("?")
+		}
+		if param.Type != "" {
+			p.write(": " + param.Type)
+		}
+		if param.DefaultValue != "" {
+			p.write(" = " + param.DefaultValue)
+		}
+	}
+	p.write(")")
+	if returnType != "" {
+		p.write(": " + returnType)
+	}
+}
+
+// textBody writes a function body held as text, or an empty block.
+func (p *printer) textBody(body string) {
+	if body == "" {
+		body = "{}"
+	}
+	p.write(body)
+}
+
+// body writes a statement body, or an empty block if it is nil.
+func (p *printer) body(block *ast.BlockStatement) {
+	if block == nil {
+		p.write("{}")
+		return
+	}
+	p.node(block)
+}
+
+// block writes nodes one per line between braces, indented one level more
+// than the current line.
+func (p *printer) block(nodes []ast.Node) {
+	indent := p.lineIndent()
+	p.write("{")
+	for _, n := range nodes {
+		p.write("\n" + indent + p.indent)
+		p.node(n)
+	}
+	if len(nodes) > 0 {
+		p.write("\n" + indent)
+	}
+	p.write("}")
+}
+
+func (p *printer) interfaceBody(n *ast.InterfaceNode) {
+	indent := p.lineIndent()
+	p.write("{")
+	for _, prop := range n.Properties {
+		p.write("\n" + indent + p.indent)
+		if prop.IsReadonly {
+			p.write("readonly ")
+		}
+		p.write(prop.Name)
+		if prop.IsOptional {
+			p.write("?")
+		}
+		p.write(": " + cmp.Or(prop.Type, "any") + ";")
+	}
+	for _, method := range n.Methods {
+		p.write("\n" + indent + p.indent + method.Name)
+		if method.IsOptional {
+			p.write("?")
+		}
+		p.signature(method.Parameters, cmp.Or(method.ReturnType, "void"))
+		p.write(";")
+	}
+	if len(n.Properties)+len(n.Methods) > 0 {
+		p.write("\n" + indent)
+	}
+	p.write("}")
+}
+
+func (p *printer) enumBody(members []*ast.EnumMember) {
+	indent := p.lineIndent()
+	p.write("{")
+	for _, m := range members {
+		if m == nil {
+			continue
+		}
+		p.write("\n" + indent + p.indent)
+		p.node(m)
+		p.write(",")
+	}
+	if len(members) > 0 {
+		p.write("\n" + indent)
+	}
+	p.write("}")
+}
+
+// variableStatement writes a variable statement without its semicolon.
+func (p *printer) variableStatement(n *ast.VariableStatement) {
+	p.write(cmp.Or(n.Kind, "let"))
+	for i, d := range n.Declarations {
+		if i > 0 {
+			p.write(",")
+		}
+		p.write(" " + d.Name)
+		if d.Type != "" {
+			p.write(": " + d.Type)
+		}
+		if d.Initializer != nil {
+			p.write(" = ")
+			p.node(d.Initializer)
+		}
+	}
+}
+
+// forClause writes the initializer or left side of a for loop, where
+// variable statements have no semicolon.
+func (p *printer) forClause(n ast.Node) {
+	if v, ok := n.(*ast.VariableStatement); ok && IsSynthesized(v) && len(v.Children()) == 0 && v.Text() == "" {
+		p.variableStatement(v)
+		return
+	}
+	p.node(n)
+}
+
+// specifiers writes import specifiers: a default import and a namespace
+// import as they are, and named imports between braces.
+func (p *printer) specifiers(specs []ast.Node) {
+	var named []ast.Node
+	first := true
+	for _, spec := range specs {
+		if _, ok := spec.(*ast.IdentifierNode); !ok && spec.SyntaxKind() != "identifier" && spec.SyntaxKind() != "namespace_import" {
+			named = append(named, spec)
+			continue
+		}
+		if !first {
+			p.write(", ")
+		}
+		p.node(spec)
+		first = false
+	}
+	if len(named) > 0 {
+		if !first {
+			p.write(", ")
+		}
+		p.write("{ ")
+		p.list(named)
+		p.write(" }")
+	}
+}
+
+// list writes nodes separated by commas.
+func (p *printer) list(nodes []ast.Node) {
+	for i, n := range nodes {
+		if i > 0 {
+			p.write(", ")
+		}
+		p.node(n)
+	}
+}
+
+func (p *printer) keywordStatement(keyword string, argument ast.Node) {
+	p.write(keyword)
+	if argument != nil {
+		p.write(" ")
+		p.node(argument)
+	}
+	p.write(";")
+}
+
+func (p *printer) labelStatement(keyword, label string) {
+	p.write(keyword)
+	if label != "" {
+		p.write(" " + label)
+	}
+	p.write(";")
+}
+
+// statementNodes converts statements to nodes.
+func statementNodes(stmts []ast.Statement) []ast.Node {
+	nodes := make([]ast.Node, len(stmts))
+	for i, s := range stmts {
+		nodes[i] = s
+	}
+	return nodes
+}
+
+// quote returns s as a string literal, unless it is quoted already.
+func quote(s string) string {
+	if len(s) >= 2 && strings.ContainsRune(`"'`+"`", rune(s[0])) && s[len(s)-1] == s[0] {
+		return s
+	}
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s) + `"`
+}
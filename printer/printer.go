@@ -0,0 +1,295 @@
+// Package printer renders AST nodes back to TypeScript source text.
+//
+// Nodes that come from the parser are printed from their original text:
+// the whitespace between the children of a node is copied from the source,
+// so an untouched tree prints exactly as it was parsed, and a tree with a
+// few replaced, inserted or deleted nodes keeps the layout of everything
+// else. Synthesized nodes, which have no source range, are generated: a
+// node with children prints its children, a leaf prints its Content, and
+// typed nodes with neither (such as an *ast.ReturnStatement built by hand)
+// are printed from their fields.
+package printer
+
+import (
+	"bytes"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+// Config controls the output of generated code.
+type Config struct {
+	// Indent is the indentation unit of generated blocks. It defaults to
+	// two spaces. Original text is never re-indented.
+	Indent string
+}
+
+// Fprint writes the source text of node to w.
+func (c *Config) Fprint(w io.Writer, node ast.Node) error {
+	p := &printer{indent: c.Indent}
+	if p.indent == "" {
+		p.indent = "  "
+	}
+	p.node(node)
+	_, err := w.Write(p.buf)
+	return err
+}
+
+// Fprint writes the source text of node to w using the default Config.
+func Fprint(w io.Writer, node ast.Node) error {
+	return (&Config{}).Fprint(w, node)
+}
+
+// String returns the source text of node using the default Config.
+func String(node ast.Node) string {
+	var b strings.Builder
+	Fprint(&b, node)
+	return b.String()
+}
+
+// IsSynthesized reports whether node was created outside the parser, that
+// is, whether it has no source range.
+func IsSynthesized(node ast.Node) bool {
+	return node.Range() == ast.Range{}
+}
+
+// printer holds the output being built.
+type printer struct {
+	indent string
+	buf    []byte
+}
+
+func (p *printer) write(s string) {
+	p.buf = append(p.buf, s...)
+}
+
+// node prints n, from its original text when it has a source range.
+func (p *printer) node(n ast.Node) {
+	if n == nil {
+		return
+	}
+	if IsSynthesized(n) {
+		p.synthesized(n)
+	} else {
+		p.original(n)
+	}
+}
+
+// original prints a parsed node. Its children are printed recursively and
+// the text between them is copied from the node's own text. Children that
+// lie outside the node or out of order (synthesized or moved from elsewhere)
+// are separated by generated whitespace, and the text of deleted children
+// is skipped, keeping only the whitespace that followed it.
+func (p *printer) original(n ast.Node) {
+	children := n.Children()
+	if len(children) == 0 {
+		p.write(n.Text())
+		return
+	}
+
+	r := n.Range()
+	text := n.Text()
+	slice := func(from, to uint32) (string, bool) {
+		from -= r.Start.Offset
+		to -= r.Start.Offset
+		if from > to || int(to) > len(text) {
+			return "", false
+		}
+		return text[from:to], true
+	}
+
+	// Generated line breaks copy the first one between children, without
+	// the blank lines around it
+	var lineSep string
+	pos := r.Start.Offset
+	for _, c := range children {
+		if c == nil || IsSynthesized(c) || c.Range().Start.Offset < pos {
+			continue
+		}
+		if gap, ok := slice(pos, c.Range().Start.Offset); ok && isSpace(gap) && strings.Contains(gap, "\n") {
+			i := strings.LastIndexByte(gap, '\n')
+			if i > 0 && gap[i-1] == '\r' {
+				i--
+			}
+			lineSep = gap[i:]
+			break
+		}
+		pos = c.Range().End.Offset
+	}
+
+	// replaced returns the whitespace before the source text at pos, if
+	// that text was replaced by children printed in its place rather than
+	// kept
+	replaced := func(pos uint32, rest []ast.Node) (string, bool) {
+		end := r.End.Offset
+		for _, c := range rest {
+			if c != nil && !IsSynthesized(c) && c.Range().Start.Offset >= pos {
+				end = c.Range().Start.Offset
+				break
+			}
+		}
+		text, ok := slice(pos, end)
+		if !ok || isSpace(text) {
+			return "", false
+		}
+		return text[:len(text)-len(strings.TrimLeft(text, " \t\r\n"))], true
+	}
+
+	pos = r.Start.Offset
+	var prev ast.Node // last child printed
+	synthesized := false
+	for i, c := range children {
+		if c == nil {
+			continue
+		}
+		cr := c.Range()
+		gap, inPlace := "", false
+		if !IsSynthesized(c) && cr.Start.Offset >= pos && cr.End.Offset <= r.End.Offset {
+			gap, inPlace = slice(pos, cr.Start.Offset)
+		}
+		lead, isReplacement := "", false
+		if prev != nil && !synthesized && !inPlace {
+			lead, isReplacement = replaced(pos, children[i+1:])
+		}
+		switch {
+		case isReplacement && strings.Contains(lead, "\n") && isLineNode(c):
+			// A statement replacing another keeps the line breaks before it
+			p.write(lead)
+			p.node(c)
+		case prev != nil && (!inPlace || synthesized && gap == ""):
+			p.adjoin(prev, c, lineSep)
+		case !inPlace || isSpace(gap):
+			p.write(gap)
+			p.node(c)
+		default:
+			// Deleted children: keep the whitespace that followed them
+			if prev != nil {
+				p.write(gap[len(strings.TrimRight(gap, " \t\r\n")):])
+			}
+			p.node(c)
+		}
+		if inPlace {
+			pos = cr.End.Offset
+		}
+		prev, synthesized = c, !inPlace
+	}
+	if tail, ok := slice(pos, r.End.Offset); ok {
+		// Keep the trailing whitespace, without the text of deleted children
+		p.write(tail[len(strings.TrimRight(tail, " \t\r\n")):])
+	}
+}
+
+// synthesized prints a node created outside the parser.
+func (p *printer) synthesized(n ast.Node) {
+	if children := n.Children(); len(children) > 0 {
+		var prev ast.Node
+		for _, c := range children {
+			if c == nil {
+				continue
+			}
+			if prev != nil {
+				p.adjoin(prev, c, "")
+			} else {
+				p.node(c)
+			}
+			prev = c
+		}
+		return
+	}
+	if n.Text() != "" || !p.generate(n) {
+		p.write(n.Text())
+	}
+}
+
+// adjoin prints next after prev when the two were not adjacent in the
+// source. Statements and members go on separate lines, using lineSep when
+// the parent has one; other nodes are separated by a space where needed.
+func (p *printer) adjoin(prev, next ast.Node, lineSep string) {
+	if isLineNode(prev) || isLineNode(next) {
+		if lineSep == "" {
+			lineSep = "\n" + p.lineIndent()
+		}
+		p.write(lineSep)
+		p.node(next)
+		return
+	}
+
+	mark := len(p.buf)
+	p.node(next)
+	if needsSpace(p.buf[:mark], p.buf[mark:]) {
+		p.buf = slices.Insert(p.buf, mark, ' ')
+	}
+}
+
+// lineIndent returns the indentation of the line being written.
+func (p *printer) lineIndent() string {
+	line := p.buf[bytes.LastIndexByte(p.buf, '\n')+1:]
+	return string(line[:len(line)-len(bytes.TrimLeft(line, " \t"))])
+}
+
+// isLineNode reports whether n is printed on its own line when generated
+// next to its siblings: statements, declarations, class and interface
+// members, switch cases and comments. Nodes without a kind count as
+// statements when their text ends with a semicolon.
+func isLineNode(n ast.Node) bool {
+	switch n.(type) {
+	case ast.Statement, *ast.InterfaceNode, *ast.TypeAliasNode, *ast.FunctionNode, *ast.MethodNode, *ast.SwitchCase:
+		return true
+	}
+	kind := n.SyntaxKind()
+	if kind == "" {
+		return strings.HasSuffix(strings.TrimSpace(n.Text()), ";")
+	}
+	for _, suffix := range []string{"_statement", "_declaration", "_definition", "_signature", "comment"} {
+		if strings.HasSuffix(kind, suffix) {
+			return true
+		}
+	}
+	return kind == "switch_case" || kind == "switch_default"
+}
+
+// spaceKeywords are keywords followed by a space before a parenthesis.
+var spaceKeywords = map[string]bool{
+	"if": true, "for": true, "while": true, "switch": true, "catch": true, "with": true,
+	"return": true, "await": true, "typeof": true, "void": true, "in": true, "of": true,
+	"yield": true, "case": true, "new": true, "delete": true, "instanceof": true,
+}
+
+// needsSpace reports whether a space goes between generated tokens, given
+// the output before and the text after.
+func needsSpace(before, after []byte) bool {
+	if len(before) == 0 || len(after) == 0 {
+		return false
+	}
+	last, first := before[len(before)-1], after[0]
+	switch {
+	case isSpaceByte(last) || isSpaceByte(first):
+		return false
+	case strings.IndexByte("([.!~", last) >= 0:
+		return false
+	case strings.IndexByte(",;)].:", first) >= 0:
+		return false
+	case first == '(' && isWordByte(last):
+		start := len(before)
+		for start > 0 && isWordByte(before[start-1]) {
+			start--
+		}
+		return spaceKeywords[string(before[start:])]
+	}
+	return true
+}
+
+func isWordByte(b byte) bool {
+	return b == '_' || b == '$' || 'a' <= b && b <= 'z' || 'A' <= b && b <= 'Z' || '0' <= b && b <= '9'
+}
+
+func isSpaceByte(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\r' || b == '\n'
+}
+
+// isSpace reports whether s consists of whitespace only.
+func isSpace(s string) bool {
+	return strings.TrimLeft(s, " \t\r\n") == ""
+}
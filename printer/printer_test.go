@@ -0,0 +1,186 @@
+package printer
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ahmadramadhannn/tsgoast"
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+func parse(t *testing.T, src string) *ast.BaseNode {
+	t.Helper()
+	parser, err := tsgoast.New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+	defer parser.Close()
+
+	root, err := parser.Parse([]byte(src))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	return root
+}
+
+// find returns the first node of the given kind.
+func find(root ast.Node, kind string) *ast.BaseNode {
+	for n := range ast.Preorder(root) {
+		if n.SyntaxKind() == kind {
+			return n.(*ast.BaseNode)
+		}
+	}
+	return nil
+}
+
+func TestPrintUnchanged(t *testing.T) {
+	files, _ := filepath.Glob("../testdata/*.ts")
+	if len(files) == 0 {
+		t.Fatal("no test files")
+	}
+	for _, file := range files {
+		src, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := String(parse(t, string(src))); got != string(src) {
+			t.Errorf("%s: printed source differs:\n%s", file, got)
+		}
+	}
+}
+
+func TestPrintReplace(t *testing.T) {
+	root := parse(t, "const x = 1;\nlog(x);\n")
+
+	// Replace the declared name and the call argument
+	decl := find(root, "variable_declarator")
+	decl.ChildNodes[0] = &ast.BaseNode{TreeSitterKind: "identifier", Content: "total"}
+	args := find(root, "arguments")
+	args.ChildNodes[1] = &ast.ExpressionNode{
+		ExprType: ast.ExpressionTypeBinary,
+		Operator: "*",
+		Left:     &ast.IdentifierNode{Name: "total"},
+		Right:    &ast.LiteralNode{Value: "2"},
+	}
+
+	want := "const total = 1;\nlog(total * 2);\n"
+	if got := String(root); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestPrintReplaceStatements(t *testing.T) {
+	root := parse(t, "a();\n\nb();\nc();\n")
+
+	// Replaced statements keep the blank lines and the final newline
+	// around them
+	root.ChildNodes[1] = &ast.BaseNode{Content: "x();"}
+	root.ChildNodes[2] = &ast.BaseNode{Content: "y();"}
+
+	want := "a();\n\nx();\ny();\n"
+	if got := String(root); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestPrintInsertAndDelete(t *testing.T) {
+	root := parse(t, `function f() {
+    a();
+    b();
+    c();
+}
+`)
+	body := find(root, "statement_block")
+
+	// Delete b(); and insert a generated statement after a();
+	stmts := body.ChildNodes
+	ret := &ast.ReturnStatement{Argument: &ast.IdentifierNode{Name: "done"}}
+	body.ChildNodes = []ast.Node{stmts[0], stmts[1], ret, stmts[3], stmts[4]}
+
+	want := `function f() {
+    a();
+    return done;
+    c();
+}
+`
+	if got := String(root); got != want {
+		t.Errorf("String() =\n%s\nwant\n%s", got, want)
+	}
+
+	body.ChildNodes = slices.Delete(body.ChildNodes, 1, 3)
+	want = `function f() {
+    c();
+}
+`
+	if got := String(root); got != want {
+		t.Errorf("String() after delete =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestPrintGenerated(t *testing.T) {
+	fn := &ast.FunctionDeclaration{
+		Name:       "check",
+		IsExported: true,
+		IsAsync:    true,
+		Parameters: []*ast.Parameter{{Name: "id", Type: "string"}, {Name: "opts", IsOptional: true}},
+		ReturnType: "Promise<boolean>",
+		Body: &ast.BlockStatement{Statements: []ast.Statement{
+			&ast.IfStatement{
+				Condition: &ast.ExpressionNode{
+					ExprType: ast.ExpressionTypeUnary,
+					Operator: "!",
+					Right:    &ast.IdentifierNode{Name: "id"},
+				},
+				Consequence: &ast.BlockStatement{Statements: []ast.Statement{
+					&ast.ThrowStatement{Argument: &ast.BaseNode{Content: `new Error("missing id")`}},
+				}},
+			},
+			&ast.ReturnStatement{Argument: &ast.ExpressionNode{
+				ExprType: ast.ExpressionTypeAwait,
+				Right:    &ast.BaseNode{Content: "exists(id)"},
+			}},
+		}},
+	}
+
+	want := `export async function check(id: string, opts?): Promise<boolean> {
+    if (!id) {
+        throw new Error("missing id");
+    }
+    return await exists(id);
+}`
+	var b strings.Builder
+	if err := (&Config{Indent: "    "}).Fprint(&b, fn); err != nil {
+		t.Fatalf("Fprint() error = %v", err)
+	}
+	if b.String() != want {
+		t.Errorf("Fprint() =\n%s\nwant\n%s", b.String(), want)
+	}
+
+	imp := &ast.ImportDeclaration{
+		Source:     "./util",
+		Specifiers: []ast.Node{&ast.IdentifierNode{Name: "util"}, &ast.BaseNode{TreeSitterKind: "import_specifier", Content: "parse"}},
+	}
+	if got, want := String(imp), `import util, { parse } from "./util";`; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestPrintGeneratedInOriginal(t *testing.T) {
+	root := parse(t, "class A {\n  run() {\n    go();\n  }\n}\n")
+	block := find(root, "statement_block")
+	block.ChildNodes = slices.Insert(block.ChildNodes, 1, ast.Node(&ast.ForOfStatement{
+		Left:  &ast.VariableStatement{Kind: "const", Declarations: []*ast.VariableDeclarator{{Name: "x"}}},
+		Right: &ast.IdentifierNode{Name: "xs"},
+		Body: &ast.BlockStatement{Statements: []ast.Statement{
+			&ast.ExpressionStatement{Expression: &ast.BaseNode{Content: "use(x)"}},
+		}},
+	}))
+
+	want := "class A {\n  run() {\n    for (const x of xs) {\n      use(x);\n    }\n    go();\n  }\n}\n"
+	if got := String(root); got != want {
+		t.Errorf("String() =\n%s\nwant\n%s", got, want)
+	}
+}
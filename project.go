@@ -0,0 +1,103 @@
+package tsgoast
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"os"
+	"slices"
+)
+
+// Project is a set of source files parsed together, such as the files of
+// a repository.
+type Project struct {
+	Paths []string
+
+	// Dir, if set, is the directory the Paths are relative to, which may
+	// then use slashes. Trees and errors are still keyed by Paths.
+	Dir string
+
+	// TSX, if set, reports whether the file at a path of Paths is parsed
+	// with the TSX grammar. By default, like ParseAll, .tsx and .jsx files
+	// are.
+	TSX func(path string) bool
+
+	// AllowEmpty parses empty files as empty modules, where the parser,
+	// and so ParseAll, fails them.
+	AllowEmpty bool
+
+	// Progress, if set, is called by ParseConcurrently after each file is
+	// parsed, one call at a time.
+	Progress func(Progress)
+
+	// Trees holds the trees of the files parsed by ParseConcurrently, by
+	// path.
+	Trees map[string]*Tree
+}
+
+// Progress reports the progress of Project.ParseConcurrently.
+type Progress struct {
+	Path  string // file just parsed
+	Err   error  // error parsing it, if any
+	Done  int    // files parsed so far, including Path
+	Total int
+}
+
+// ParseConcurrently parses the files of the project on workers goroutines,
+// like ParseAll, storing their trees in Trees. A workers value of zero or
+// less uses GOMAXPROCS. The files are handed out largest first, so that
+// a large file parsed last does not keep one core busy while the others
+// are idle.
+//
+// The files that fail to parse are left out of Trees, and their errors,
+// each a *FileError, are joined in the returned error, sorted by path.
+// Once ctx is done, no more files are parsed and ctx.Err() is returned.
+func (p *Project) ParseConcurrently(ctx context.Context, workers int) error {
+	type file struct {
+		path string
+		size int64
+	}
+	files := make([]file, len(p.Paths))
+	for i, path := range p.Paths {
+		files[i].path = path
+		if info, err := os.Stat(joinDir(p.Dir, path)); err == nil {
+			files[i].size = info.Size()
+		}
+	}
+	slices.SortStableFunc(files, func(a, b file) int {
+		return cmp.Compare(b.size, a.size)
+	})
+	paths := make([]string, len(files))
+	for i, f := range files {
+		paths[i] = f.path
+	}
+
+	if p.Trees == nil {
+		p.Trees = make(map[string]*Tree, len(paths))
+	}
+	var errs []*FileError
+	done := 0
+	for result := range parseFiles(ctx, p, paths, workers) {
+		done++
+		if result.Err != nil {
+			errs = append(errs, result.Err.(*FileError))
+		} else {
+			p.Trees[result.Path] = result.Tree
+		}
+		if p.Progress != nil {
+			p.Progress(Progress{Path: result.Path, Err: result.Err, Done: done, Total: len(paths)})
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	slices.SortFunc(errs, func(a, b *FileError) int {
+		return cmp.Compare(a.Path, b.Path)
+	})
+	joined := make([]error, len(errs))
+	for i, err := range errs {
+		joined[i] = err
+	}
+	return errors.Join(joined...)
+}
@@ -0,0 +1,70 @@
+package project
+
+import (
+	"github.com/ahmadramadhannn/tsgoast/analyzer"
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+// ImportGraph returns the import graph of the files of the project, by
+// path relative to the root. It is built on first use.
+func (p *Project) ImportGraph() *analyzer.ImportGraph {
+	return p.importGraph()
+}
+
+// TypeHierarchy returns the inheritance hierarchy of the classes and
+// interfaces of the project.
+func (p *Project) TypeHierarchy() *analyzer.TypeHierarchy {
+	return analyzer.BuildHierarchy(p.roots()...)
+}
+
+// CallGraph returns the call graph of the functions of the project.
+func (p *Project) CallGraph() *analyzer.CallGraph {
+	return analyzer.BuildCallGraph(p.roots()...)
+}
+
+// Findings returns the findings of analyzer.Analyzer.Findings for the files
+// of the project, with their File set, ordered by path and position.
+func (p *Project) Findings() []analyzer.Finding {
+	var findings []analyzer.Finding
+	for _, f := range p.Files() {
+		for _, finding := range analyzer.New(f.Tree.Root).Findings() {
+			finding.File = f.Path
+			findings = append(findings, finding)
+		}
+	}
+	return findings
+}
+
+// FileMetrics returns the metrics of the files of the project, ordered by
+// path.
+func (p *Project) FileMetrics() []analyzer.FileMetrics {
+	metrics := make([]analyzer.FileMetrics, 0, len(p.paths))
+	for _, f := range p.Files() {
+		m := analyzer.New(f.Tree.Root).FileMetrics()
+		m.File = f.Path
+		metrics = append(metrics, m)
+	}
+	return metrics
+}
+
+// FunctionMetrics returns the metrics of the functions of the project,
+// ordered by path and position.
+func (p *Project) FunctionMetrics() []analyzer.FunctionMetrics {
+	var metrics []analyzer.FunctionMetrics
+	for _, f := range p.Files() {
+		for _, m := range analyzer.New(f.Tree.Root).FunctionMetrics() {
+			m.File = f.Path
+			metrics = append(metrics, m)
+		}
+	}
+	return metrics
+}
+
+// roots returns the roots of the trees of the files, ordered by path.
+func (p *Project) roots() []*ast.BaseNode {
+	roots := make([]*ast.BaseNode, 0, len(p.paths))
+	for _, f := range p.Files() {
+		roots = append(roots, f.Tree.Root)
+	}
+	return roots
+}
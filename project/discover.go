@@ -0,0 +1,138 @@
+package project
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// discover returns the files below root matching the include and exclude
+// patterns of opts, or else those of config, if not nil, with its files,
+// in lexical order. Excluded directories are not walked.
+func discover(root string, opts Options, config *TSConfig) ([]string, error) {
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", root)
+	}
+	include, exclude := opts.Include, opts.Exclude
+	var exts, explicit []string
+	if config != nil {
+		configInclude, configExclude, files := config.patterns(root)
+		if include == nil {
+			include, exts = configInclude, config.extensions()
+		}
+		if exclude == nil {
+			exclude = configExclude
+		}
+		explicit = files
+	}
+	if include == nil {
+		include = DefaultInclude
+	}
+	if exclude == nil {
+		exclude = DefaultExclude
+	}
+
+	var files []string
+	err = filepath.WalkDir(root, func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if name == root {
+			return nil
+		}
+		rel, err := filepath.Rel(root, name)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if matchAny(exclude, rel) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.IsDir() && matchAny(include, rel) && (exts == nil || hasExt(rel, exts)) {
+			files = append(files, rel)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Like tsc, the files listed by the configuration are not excluded,
+	// and those missing are reported when parsed.
+	for _, name := range explicit {
+		if !slices.Contains(files, name) {
+			files = append(files, name)
+		}
+	}
+	slices.Sort(files)
+	return files, nil
+}
+
+// hasExt reports whether name ends with one of exts. Unlike path.Ext, it
+// takes .d.ts files to end with .ts.
+func hasExt(name string, exts []string) bool {
+	for _, ext := range exts {
+		if strings.HasSuffix(name, ext) {
+			return true
+		}
+	}
+	return false
+}
+
+// Match reports whether name, a path relative to the root of a project
+// with slashes, matches pattern. Patterns are relative paths whose
+// elements are matched by path.Match, such as *.ts, with ** matching any
+// number of directories:
+//
+//	src/**/*.ts   the .ts files below src
+//	**/*.test.ts  the test files of any directory
+//	lib           the file or directory lib
+//
+// A leading ./ is ignored. Malformed patterns match nothing.
+func Match(pattern, name string) bool {
+	pattern = strings.TrimPrefix(pattern, "./")
+	return matchElems(strings.Split(pattern, "/"), strings.Split(name, "/"))
+}
+
+func matchElems(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			pattern = pattern[1:]
+			for i := 0; i <= len(name); i++ {
+				if matchElems(pattern, name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if ok, err := path.Match(pattern[0], name[0]); !ok || err != nil {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
+
+// matchAny reports whether name matches one of patterns.
+func matchAny(patterns []string, name string) bool {
+	for _, p := range patterns {
+		if Match(p, name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,212 @@
+// Package project loads the source files of a project, such as a
+// repository, and analyzes them together.
+//
+// Load finds the files below a root directory, parses them concurrently
+// and returns a Project to look them up by path and to run the analyses
+// spanning files, such as the import graph:
+//
+//	p, err := project.Load("path/to/repo", project.Options{
+//		Exclude: append(project.DefaultExclude, "**/*.test.ts"),
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	for _, cycle := range p.ImportGraph().Cycles() {
+//		fmt.Println(strings.Join(cycle, " -> "))
+//	}
+//
+// A tsconfig.json at the root, or given by Options.TSConfig, selects the
+// files like tsc does, and maps the import aliases of its paths.
+//
+// Files are identified by their path relative to the root, with slashes.
+package project
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/ahmadramadhannn/tsgoast"
+	"github.com/ahmadramadhannn/tsgoast/analyzer"
+)
+
+// Options configures Load.
+type Options struct {
+	// Include lists the patterns of the files to load, relative to the
+	// root (see Match). Nil means DefaultInclude.
+	Include []string
+
+	// Exclude lists the patterns of the files and directories to leave
+	// out, even if included. Nil means DefaultExclude.
+	Exclude []string
+
+	// Workers is the number of files parsed at once. Zero or less uses
+	// GOMAXPROCS.
+	Workers int
+
+	// Progress, if set, is called after each file is parsed, one call at
+	// a time.
+	Progress func(tsgoast.Progress)
+
+	// TSConfig is the path of the tsconfig.json of the project. Empty
+	// means the tsconfig.json of the root, if any. Its files, include and
+	// exclude select the files to load, unless Include or Exclude are set,
+	// its paths and baseUrl resolve the imports of the import graph, and
+	// JavaScript files are parsed with the TSX grammar if it sets jsx.
+	TSConfig string
+
+	// IgnoreTSConfig loads the project without a tsconfig.json.
+	IgnoreTSConfig bool
+}
+
+// DefaultInclude includes the TypeScript and JavaScript files.
+var DefaultInclude = []string{
+	"**/*.ts", "**/*.tsx", "**/*.mts", "**/*.cts",
+	"**/*.js", "**/*.jsx", "**/*.mjs", "**/*.cjs",
+}
+
+// DefaultExclude leaves out dependencies, hidden directories, such as
+// .git, and declaration files.
+var DefaultExclude = []string{"**/node_modules", "**/.*", "**/*.d.ts"}
+
+// File is a parsed file of a project.
+type File struct {
+	Path string // relative to the root of the project, with slashes
+	Tree *tsgoast.Tree
+}
+
+// Project is the set of parsed files of a project. It is safe for
+// concurrent use.
+type Project struct {
+	// Root is the absolute path of the root directory.
+	Root string
+
+	// Config is the tsconfig.json the project was loaded with, or nil.
+	Config *TSConfig
+
+	files map[string]*File
+	paths []string // sorted
+
+	importGraph func() *analyzer.ImportGraph
+}
+
+// Load finds the files below the directory root matching opts and parses
+// them concurrently. The files that cannot be parsed are left out of the
+// project; their errors, each a *tsgoast.FileError, are joined in the
+// error returned with the project of the other files.
+func Load(root string, opts Options) (*Project, error) {
+	return LoadContext(context.Background(), root, opts)
+}
+
+// LoadContext is Load, stopping once ctx is done to return ctx.Err().
+func LoadContext(ctx context.Context, root string, opts Options) (*Project, error) {
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return nil, err
+	}
+	config, err := findTSConfig(root, opts)
+	if err != nil {
+		return nil, err
+	}
+	paths, err := discover(root, opts, config)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed := &tsgoast.Project{Paths: paths, Dir: root, TSX: config.tsx, AllowEmpty: true, Progress: opts.Progress}
+	err = parsed.ParseConcurrently(ctx, opts.Workers)
+	if ctx.Err() != nil {
+		return nil, err
+	}
+
+	p := newProject(root, config)
+	for _, path := range paths {
+		if tree, ok := parsed.Trees[path]; ok {
+			p.files[path] = &File{Path: path, Tree: tree}
+			p.paths = append(p.paths, path)
+		}
+	}
+	return p, err
+}
+
+// findTSConfig loads the tsconfig.json of opts, if any.
+func findTSConfig(root string, opts Options) (*TSConfig, error) {
+	if opts.IgnoreTSConfig {
+		return nil, nil
+	}
+	name := opts.TSConfig
+	if name == "" {
+		name = filepath.Join(root, "tsconfig.json")
+		if _, err := os.Stat(name); errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+	}
+	return LoadTSConfig(name)
+}
+
+func newProject(root string, config *TSConfig) *Project {
+	p := &Project{Root: root, Config: config, files: make(map[string]*File)}
+	p.importGraph = sync.OnceValue(func() *analyzer.ImportGraph {
+		g := analyzer.NewImportGraph()
+		for _, f := range p.Files() {
+			g.AddFile(f.Path, f.Tree.Root)
+		}
+		if config != nil {
+			g.SetAliasResolver(p.aliases)
+		}
+		return g
+	})
+	return p
+}
+
+// aliases resolves the specifiers mapped by the paths and baseUrl of the
+// tsconfig.json of the project, for its import graph.
+func (p *Project) aliases(_, specifier string) []string {
+	var paths []string
+	for _, name := range p.Config.aliases(specifier) {
+		if rel, ok := p.rel(name); ok {
+			paths = append(paths, rel)
+		}
+	}
+	return paths
+}
+
+// Files returns the files of the project, ordered by path.
+func (p *Project) Files() []*File {
+	files := make([]*File, len(p.paths))
+	for i, path := range p.paths {
+		files[i] = p.files[path]
+	}
+	return files
+}
+
+// File returns the file at path, absolute or relative to the root, or nil
+// if the project has no such file.
+func (p *Project) File(path string) *File {
+	if rel, ok := p.rel(path); ok {
+		return p.files[rel]
+	}
+	return nil
+}
+
+// rel returns the path relative to the root, with slashes, of the file at
+// path, absolute or relative to the root.
+func (p *Project) rel(name string) (string, bool) {
+	if filepath.IsAbs(name) {
+		rel, err := filepath.Rel(p.Root, name)
+		if err != nil {
+			return "", false
+		}
+		name = rel
+	}
+	name = path.Clean(filepath.ToSlash(name))
+	if name == ".." || strings.HasPrefix(name, "../") {
+		return "", false
+	}
+	return name, true
+}
@@ -0,0 +1,182 @@
+package project
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ahmadramadhannn/tsgoast"
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+// writeTree writes files, by path relative to a temporary directory, and
+// returns the directory.
+func writeTree(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func paths(p *Project) []string {
+	var paths []string
+	for _, f := range p.Files() {
+		paths = append(paths, f.Path)
+	}
+	return paths
+}
+
+func TestLoad(t *testing.T) {
+	root := writeTree(t, map[string]string{
+		"src/a.ts":                  "import { b } from './b';\nexport class A extends B {}\n",
+		"src/b.ts":                  "import { a } from './a';\nexport class B {}\nexport function run() { helper(); }\nfunction helper() {}\n",
+		"src/view.tsx":              "export const View = () => <div />;\n",
+		"src/a.test.ts":             "test('a', () => {});\n",
+		"src/types.d.ts":            "declare const x: number;\n",
+		"node_modules/pkg/index.js": "module.exports = 1;\n",
+		".git/hooks/hook.js":        "",
+		"README.md":                 "# readme\n",
+	})
+
+	var progress []string
+	p, err := Load(root, Options{
+		Exclude:  append(slices.Clone(DefaultExclude), "**/*.test.ts"),
+		Workers:  2,
+		Progress: func(pr tsgoast.Progress) { progress = append(progress, pr.Path) },
+	})
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	want := []string{"src/a.ts", "src/b.ts", "src/view.tsx"}
+	if got := paths(p); !slices.Equal(got, want) {
+		t.Errorf("Load() files = %v, want %v", got, want)
+	}
+	if len(progress) != 3 {
+		t.Errorf("Progress called for %v, want 3 files", progress)
+	}
+
+	// Files are looked up by relative or absolute path
+	for _, path := range []string{"src/view.tsx", "./src/../src/view.tsx", filepath.Join(root, "src", "view.tsx")} {
+		if f := p.File(path); f == nil || f.Path != "src/view.tsx" || f.Tree.Root.SyntaxKind() != "program" {
+			t.Errorf("File(%q) = %+v", path, f)
+		}
+	}
+	if f := p.File("src/a.test.ts"); f != nil {
+		t.Errorf("File() of an excluded file = %+v", f)
+	}
+	if f := p.File("../outside.ts"); f != nil {
+		t.Errorf("File() outside the root = %+v", f)
+	}
+	// TSX files are parsed as TSX
+	jsx := false
+	ast.Inspect(p.File("src/view.tsx").Tree.Root, func(node ast.Node) bool {
+		jsx = jsx || node.SyntaxKind() == "jsx_self_closing_element"
+		return true
+	})
+	if !jsx {
+		t.Errorf("view.tsx was not parsed as TSX")
+	}
+
+	if cycles := p.ImportGraph().Cycles(); len(cycles) != 1 || strings.Join(cycles[0], " ") != "src/a.ts src/b.ts" {
+		t.Errorf("ImportGraph().Cycles() = %v", cycles)
+	}
+	if p.ImportGraph() != p.ImportGraph() {
+		t.Error("ImportGraph() is built again")
+	}
+	if supers := p.TypeHierarchy().Supertypes("A"); !slices.Equal(supers, []string{"B"}) {
+		t.Errorf("TypeHierarchy().Supertypes(A) = %v", supers)
+	}
+	if callees := p.CallGraph().Callees("run"); !slices.Equal(callees, []string{"helper"}) {
+		t.Errorf("CallGraph().Callees(run) = %v", callees)
+	}
+	if metrics := p.FileMetrics(); len(metrics) != 3 || metrics[1].File != "src/b.ts" || metrics[1].Functions != 2 {
+		t.Errorf("FileMetrics() = %+v", metrics)
+	}
+	if metrics := p.FunctionMetrics(); len(metrics) != 3 || metrics[0].File != "src/b.ts" {
+		t.Errorf("FunctionMetrics() = %+v", metrics)
+	}
+}
+
+func TestLoadInclude(t *testing.T) {
+	root := writeTree(t, map[string]string{
+		"src/a.ts":     "",
+		"src/lib/b.ts": "",
+		"src/c.js":     "",
+		"test/d.ts":    "",
+	})
+	p, err := Load(root, Options{Include: []string{"src/**/*.ts"}})
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got, want := paths(p), []string{"src/a.ts", "src/lib/b.ts"}; !slices.Equal(got, want) {
+		t.Errorf("Load() files = %v, want %v", got, want)
+	}
+
+	if _, err := Load(filepath.Join(root, "src", "a.ts"), Options{}); err == nil {
+		t.Error("Load() of a file: expected error")
+	}
+	if _, err := Load(filepath.Join(root, "missing"), Options{}); err == nil {
+		t.Error("Load() of a missing directory: expected error")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := LoadContext(ctx, root, Options{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("LoadContext() canceled error = %v", err)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	root := writeTree(t, map[string]string{"a.ts": "export const a = 1;\n", "b.ts": ""})
+	// A dangling link cannot be read
+	if err := os.Symlink(filepath.Join(root, "missing.ts"), filepath.Join(root, "c.ts")); err != nil {
+		t.Skipf("cannot create a symbolic link: %v", err)
+	}
+	p, err := Load(root, Options{Exclude: []string{}})
+	var fileErr *tsgoast.FileError
+	if !errors.As(err, &fileErr) || fileErr.Path != "c.ts" {
+		t.Errorf("Load() error = %v, want a FileError for c.ts", err)
+	}
+	if p == nil || !slices.Equal(paths(p), []string{"a.ts", "b.ts"}) {
+		t.Errorf("Load() with errors = %v, want the other files", p)
+	}
+}
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		pattern, name string
+		want          bool
+	}{
+		{"**/*.ts", "a.ts", true},
+		{"**/*.ts", "src/lib/a.ts", true},
+		{"**/*.ts", "a.tsx", false},
+		{"src/**/*.ts", "src/a.ts", true},
+		{"src/**/*.ts", "lib/a.ts", false},
+		{"src/*.ts", "src/lib/a.ts", false},
+		{"./src/*.ts", "src/a.ts", true},
+		{"**/node_modules", "node_modules", true},
+		{"**/node_modules", "a/node_modules", true},
+		{"**/.*", ".git", true},
+		{"lib", "lib", true},
+		{"lib", "lib/a.ts", false},
+		{"src/**", "src/a/b.ts", true},
+		{"[", "a", false},
+	}
+	for _, tt := range tests {
+		if got := Match(tt.pattern, tt.name); got != tt.want {
+			t.Errorf("Match(%q, %q) = %v, want %v", tt.pattern, tt.name, got, tt.want)
+		}
+	}
+}
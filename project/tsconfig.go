@@ -0,0 +1,363 @@
+package project
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// TSConfig is the part of a tsconfig.json used to load a project, with
+// the configurations it extends merged in. Paths are absolute.
+type TSConfig struct {
+	// Path is the path of the file.
+	Path string
+
+	// Files lists the files of the project, and Include the patterns of
+	// the files to find, with Exclude those of the files found to leave
+	// out. Nil means unset: Include then defaults to every file of the
+	// directory of the configuration, unless Files is set.
+	Files   []string
+	Include []string
+	Exclude []string
+
+	// BaseURL is the directory of non-relative module names, and Paths
+	// maps module names, with a * wildcard, to the paths they stand for,
+	// relative to BaseURL, if set, or to the configuration declaring
+	// Paths.
+	BaseURL string
+	Paths   map[string][]string
+
+	JSX     string // preserve, react, react-jsx..., or "" if unset
+	AllowJS bool
+	OutDir  string
+
+	pathsDir string // directory of the configuration declaring Paths
+}
+
+// rawTSConfig is the JSON of a tsconfig.json. Pointers tell unset values
+// apart.
+type rawTSConfig struct {
+	Extends         json.RawMessage `json:"extends"`
+	Files           *[]string       `json:"files"`
+	Include         *[]string       `json:"include"`
+	Exclude         *[]string       `json:"exclude"`
+	CompilerOptions struct {
+		BaseURL *string             `json:"baseUrl"`
+		Paths   map[string][]string `json:"paths"`
+		JSX     *string             `json:"jsx"`
+		AllowJS *bool               `json:"allowJs"`
+		OutDir  *string             `json:"outDir"`
+	} `json:"compilerOptions"`
+}
+
+// LoadTSConfig reads the tsconfig.json at path and the configurations it
+// extends, by path or by package name, found in node_modules like tsc
+// does. The files, include and exclude of a configuration replace those
+// it extends, and its compiler options override theirs one by one.
+func LoadTSConfig(path string) (*TSConfig, error) {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	return loadTSConfig(path, nil)
+}
+
+// loadTSConfig loads the configuration at path, extended by the chain of
+// configurations in extending.
+func loadTSConfig(path string, extending []string) (*TSConfig, error) {
+	if slices.Contains(extending, path) {
+		return nil, fmt.Errorf("%s: extends itself through %s", path, strings.Join(extending, ", "))
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var raw rawTSConfig
+	if err := json.Unmarshal(stripJSONC(data), &raw); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	dir := filepath.Dir(path)
+
+	config := &TSConfig{}
+	var bases []string
+	if len(raw.Extends) > 0 {
+		if err := json.Unmarshal(raw.Extends, &bases); err != nil {
+			var base string
+			if err := json.Unmarshal(raw.Extends, &base); err != nil {
+				return nil, fmt.Errorf("%s: extends is neither a string nor a list of strings", path)
+			}
+			bases = []string{base}
+		}
+	}
+	for _, base := range bases {
+		basePath, err := resolveExtends(dir, base)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		baseConfig, err := loadTSConfig(basePath, append(slices.Clip(extending), path))
+		if err != nil {
+			return nil, err
+		}
+		config.merge(baseConfig)
+	}
+
+	config.Path = path
+	abs := func(names []string) []string {
+		paths := make([]string, len(names))
+		for i, name := range names {
+			paths[i] = filepath.Join(dir, filepath.FromSlash(name))
+		}
+		return paths
+	}
+	if raw.Files != nil {
+		config.Files = abs(*raw.Files)
+	}
+	if raw.Include != nil {
+		config.Include = abs(*raw.Include)
+	}
+	if raw.Exclude != nil {
+		config.Exclude = abs(*raw.Exclude)
+	}
+	options := raw.CompilerOptions
+	if options.BaseURL != nil {
+		config.BaseURL = filepath.Join(dir, filepath.FromSlash(*options.BaseURL))
+	}
+	if options.Paths != nil {
+		config.Paths, config.pathsDir = options.Paths, dir
+	}
+	if options.JSX != nil {
+		config.JSX = *options.JSX
+	}
+	if options.AllowJS != nil {
+		config.AllowJS = *options.AllowJS
+	}
+	if options.OutDir != nil {
+		config.OutDir = filepath.Join(dir, filepath.FromSlash(*options.OutDir))
+	}
+	return config, nil
+}
+
+// merge sets the settings of c that base sets, for a configuration c
+// extending base, before c applies its own.
+func (c *TSConfig) merge(base *TSConfig) {
+	if base.Files != nil {
+		c.Files = base.Files
+	}
+	if base.Include != nil {
+		c.Include = base.Include
+	}
+	if base.Exclude != nil {
+		c.Exclude = base.Exclude
+	}
+	if base.BaseURL != "" {
+		c.BaseURL = base.BaseURL
+	}
+	if base.Paths != nil {
+		c.Paths, c.pathsDir = base.Paths, base.pathsDir
+	}
+	if base.JSX != "" {
+		c.JSX = base.JSX
+	}
+	c.AllowJS = c.AllowJS || base.AllowJS
+	if base.OutDir != "" {
+		c.OutDir = base.OutDir
+	}
+}
+
+// resolveExtends returns the path of the configuration named by the
+// extends of a configuration in dir: a path, with or without its .json
+// extension, or a package, or a file in a package, found in the
+// node_modules of dir or of its parents.
+func resolveExtends(dir, name string) (string, error) {
+	var candidates []string
+	if filepath.IsAbs(name) || strings.HasPrefix(name, "./") || strings.HasPrefix(name, "../") {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if filepath.IsAbs(name) {
+			path = filepath.Clean(name)
+		}
+		candidates = []string{path, path + ".json"}
+	} else {
+		for d := dir; ; d = filepath.Dir(d) {
+			path := filepath.Join(d, "node_modules", filepath.FromSlash(name))
+			candidates = append(candidates, path, path+".json", filepath.Join(path, "tsconfig.json"))
+			if filepath.Dir(d) == d {
+				break
+			}
+		}
+	}
+	for _, path := range candidates {
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("cannot find the configuration %q it extends: %w", name, fs.ErrNotExist)
+}
+
+// defaultTSExclude is what tsc excludes when a configuration sets no
+// exclude, besides its outDir.
+var defaultTSExclude = []string{"node_modules", "bower_components", "jspm_packages"}
+
+// patterns returns the include and exclude patterns of c, and its files,
+// relative to root with slashes, for discover. Patterns and files outside
+// root are left out. Like tsc, an include pattern whose last element has
+// no wildcard nor extension is a directory, standing for the files below
+// it. Dependencies and hidden files are excluded in any case.
+func (c *TSConfig) patterns(root string) (include, exclude, files []string) {
+	rel := func(name string) (string, bool) {
+		r, err := filepath.Rel(root, name)
+		if err != nil || r == ".." || strings.HasPrefix(r, ".."+string(filepath.Separator)) {
+			return "", false
+		}
+		return filepath.ToSlash(r), true
+	}
+
+	dir := filepath.Dir(c.Path)
+	includes := c.Include
+	if includes == nil && c.Files == nil {
+		includes = []string{filepath.Join(dir, "**", "*")}
+	}
+	include = []string{} // not nil: with only files, nothing is included
+	for _, pattern := range includes {
+		p, ok := rel(pattern)
+		if !ok {
+			continue
+		}
+		last := path.Base(p)
+		if p == "." {
+			p = "**/*"
+		} else if !strings.ContainsAny(last, "*?") && path.Ext(last) == "" {
+			p += "/**/*"
+		}
+		include = append(include, p)
+	}
+
+	excludes := c.Exclude
+	if excludes == nil {
+		for _, name := range defaultTSExclude {
+			excludes = append(excludes, filepath.Join(dir, name))
+		}
+		if c.OutDir != "" {
+			excludes = append(excludes, c.OutDir)
+		}
+	}
+	for _, pattern := range excludes {
+		if p, ok := rel(pattern); ok {
+			exclude = append(exclude, p)
+		}
+	}
+	exclude = append(exclude, "**/node_modules", "**/.*")
+
+	for _, name := range c.Files {
+		if p, ok := rel(name); ok {
+			files = append(files, p)
+		}
+	}
+	return include, exclude, files
+}
+
+// extensions returns the extensions of the files tsc includes.
+func (c *TSConfig) extensions() []string {
+	exts := []string{".ts", ".tsx", ".mts", ".cts"}
+	if c.AllowJS {
+		exts = append(exts, ".js", ".jsx", ".mjs", ".cjs")
+	}
+	return exts
+}
+
+// tsx reports whether the file at name is parsed with the TSX grammar:
+// .tsx and .jsx files, and JavaScript files if c sets jsx, as they may
+// hold JSX then.
+func (c *TSConfig) tsx(name string) bool {
+	switch path.Ext(name) {
+	case ".tsx", ".jsx":
+		return true
+	case ".js", ".mjs", ".cjs":
+		return c != nil && c.JSX != ""
+	}
+	return false
+}
+
+// aliases returns the paths, without extension, a non-relative specifier
+// may refer to: the substitutions of the longest matching pattern of
+// Paths, then the specifier in BaseURL.
+func (c *TSConfig) aliases(specifier string) []string {
+	var paths []string
+	base := c.BaseURL
+	if base == "" {
+		base = c.pathsDir
+	}
+	match, prefix := "", -1
+	for pattern := range c.Paths {
+		before, after, wildcard := strings.Cut(pattern, "*")
+		switch {
+		case !wildcard && pattern == specifier:
+			match, prefix = pattern, len(pattern)+1 // exact matches win
+		case wildcard && len(before) > prefix && len(specifier) >= len(before)+len(after) &&
+			strings.HasPrefix(specifier, before) && strings.HasSuffix(specifier, after):
+			match, prefix = pattern, len(before)
+		}
+	}
+	if prefix >= 0 {
+		before, after, _ := strings.Cut(match, "*")
+		star := strings.TrimSuffix(strings.TrimPrefix(specifier, before), after)
+		for _, sub := range c.Paths[match] {
+			sub = strings.Replace(sub, "*", star, 1)
+			paths = append(paths, filepath.Join(base, filepath.FromSlash(sub)))
+		}
+	}
+	if c.BaseURL != "" {
+		paths = append(paths, filepath.Join(c.BaseURL, filepath.FromSlash(specifier)))
+	}
+	return paths
+}
+
+// stripJSONC returns the JSON of data, a JSON document that may have
+// comments and trailing commas, like tsconfig.json files.
+func stripJSONC(data []byte) []byte {
+	var out bytes.Buffer
+	inString := false
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		switch {
+		case inString:
+			out.WriteByte(c)
+			if c == '\\' && i+1 < len(data) {
+				i++
+				out.WriteByte(data[i])
+			} else if c == '"' {
+				inString = false
+			}
+		case c == '"':
+			inString = true
+			out.WriteByte(c)
+		case c == '/' && i+1 < len(data) && data[i+1] == '/':
+			for i < len(data) && data[i] != '\n' {
+				i++
+			}
+			out.WriteByte('\n')
+		case c == '/' && i+1 < len(data) && data[i+1] == '*':
+			end := bytes.Index(data[i+2:], []byte("*/"))
+			if end < 0 {
+				return out.Bytes()
+			}
+			i += end + 3
+			out.WriteByte(' ')
+		case c == ']' || c == '}':
+			// Drop a trailing comma
+			trimmed := bytes.TrimRight(out.Bytes(), " \t\r\n")
+			if len(trimmed) > 0 && trimmed[len(trimmed)-1] == ',' {
+				out.Truncate(len(trimmed) - 1)
+			}
+			out.WriteByte(c)
+		default:
+			out.WriteByte(c)
+		}
+	}
+	return out.Bytes()
+}
@@ -0,0 +1,162 @@
+package project
+
+import (
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ahmadramadhannn/tsgoast"
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+func TestLoadTSConfig(t *testing.T) {
+	root := writeTree(t, map[string]string{
+		"tsconfig.json": `{
+			// comments and trailing commas are allowed
+			"extends": ["./configs/base", "@org/tsconfig"],
+			"include": ["src", "types/*.d.ts",],
+			"compilerOptions": {
+				/* overrides the base */
+				"jsx": "react-jsx",
+				"url": "http://example.com/*",
+			},
+		}`,
+		"configs/base.json": `{
+			"compilerOptions": {"baseUrl": "..", "paths": {"@app/*": ["src/app/*"]}, "jsx": "preserve", "outDir": "../dist"},
+			"exclude": ["../legacy"]
+		}`,
+		"node_modules/@org/tsconfig/tsconfig.json": `{"compilerOptions": {"allowJs": true}}`,
+	})
+
+	c, err := LoadTSConfig(filepath.Join(root, "tsconfig.json"))
+	if err != nil {
+		t.Fatalf("LoadTSConfig() error = %v", err)
+	}
+	if c.JSX != "react-jsx" || !c.AllowJS {
+		t.Errorf("JSX, AllowJS = %q, %v, want react-jsx, true", c.JSX, c.AllowJS)
+	}
+	if c.BaseURL != root || c.OutDir != filepath.Join(root, "dist") {
+		t.Errorf("BaseURL, OutDir = %q, %q", c.BaseURL, c.OutDir)
+	}
+	if want := []string{filepath.Join(root, "src"), filepath.Join(root, "types", "*.d.ts")}; !slices.Equal(c.Include, want) {
+		t.Errorf("Include = %v, want %v", c.Include, want)
+	}
+	if want := []string{filepath.Join(root, "legacy")}; !slices.Equal(c.Exclude, want) {
+		t.Errorf("Exclude = %v, want %v", c.Exclude, want)
+	}
+
+	want := []string{filepath.Join(root, "src", "app", "models"), filepath.Join(root, "@app", "models")}
+	if got := c.aliases("@app/models"); !slices.Equal(got, want) {
+		t.Errorf("aliases(@app/models) = %v, want %v", got, want)
+	}
+}
+
+func TestLoadTSConfigErrors(t *testing.T) {
+	root := writeTree(t, map[string]string{
+		"cycle.json":   `{"extends": "./cycle2.json"}`,
+		"cycle2.json":  `{"extends": "./cycle"}`,
+		"missing.json": `{"extends": "@org/missing"}`,
+		"bad.json":     `{"include": [}`,
+	})
+	for name, want := range map[string]string{
+		"cycle.json":   "extends itself",
+		"missing.json": `cannot find the configuration "@org/missing"`,
+		"bad.json":     "bad.json: invalid character",
+	} {
+		_, err := LoadTSConfig(filepath.Join(root, name))
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("LoadTSConfig(%s) error = %v, want %q", name, err, want)
+		}
+	}
+	if _, err := LoadTSConfig(filepath.Join(root, "none.json")); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("LoadTSConfig() of a missing file error = %v, want fs.ErrNotExist", err)
+	}
+}
+
+func TestLoadWithTSConfig(t *testing.T) {
+	root := writeTree(t, map[string]string{
+		"tsconfig.json": `{
+			"include": ["src"],
+			"files": ["scripts/build.ts"],
+			"exclude": ["src/**/*.test.ts"],
+			"compilerOptions": {"baseUrl": ".", "paths": {"@models/*": ["src/models/*"]}, "allowJs": true, "jsx": "react"}
+		}`,
+		"src/index.ts":        "import { User } from '@models/user';\nimport { log } from 'src/log';\nimport React from 'react';\n",
+		"src/log.ts":          "export function log() {}\n",
+		"src/models/user.ts":  "export class User {}\n",
+		"src/index.test.ts":   "test('index', () => {});\n",
+		"src/view.js":         "export const View = () => <div />;\n",
+		"src/global.d.ts":     "declare const x: number;\n",
+		"src/notes.md":        "# notes\n",
+		"scripts/build.ts":    "build();\n",
+		"scripts/release.ts":  "release();\n",
+		"node_modules/pkg.ts": "",
+	})
+
+	p, err := Load(root, Options{})
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if p.Config == nil || p.Config.Path != filepath.Join(root, "tsconfig.json") {
+		t.Fatalf("Config = %+v, want the tsconfig.json of the root", p.Config)
+	}
+	want := []string{"scripts/build.ts", "src/global.d.ts", "src/index.ts", "src/log.ts", "src/models/user.ts", "src/view.js"}
+	if got := paths(p); !slices.Equal(got, want) {
+		t.Errorf("Load() files = %v, want %v", got, want)
+	}
+
+	// jsx parses JavaScript files with the TSX grammar
+	var jsx bool
+	ast.Inspect(p.File("src/view.js").Tree.Root, func(n ast.Node) bool {
+		jsx = jsx || n.SyntaxKind() == "jsx_self_closing_element"
+		return !jsx
+	})
+	if !jsx {
+		t.Error("src/view.js not parsed as JSX")
+	}
+
+	// paths and baseUrl resolve imports
+	want = []string{"src/log.ts", "src/models/user.ts"}
+	if got := p.ImportGraph().Dependencies("src/index.ts"); !slices.Equal(got, want) {
+		t.Errorf("Dependencies(src/index.ts) = %v, want %v", got, want)
+	}
+	if got := p.ImportGraph().Resolve("src/index.ts", "react"); got != "" {
+		t.Errorf("Resolve(react) = %q, want none", got)
+	}
+
+	// Options override the configuration
+	p, err = Load(root, Options{Include: []string{"scripts/*.ts"}})
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got, want := paths(p), []string{"scripts/build.ts", "scripts/release.ts"}; !slices.Equal(got, want) {
+		t.Errorf("Load() files with Include = %v, want %v", got, want)
+	}
+	p, err = Load(root, Options{IgnoreTSConfig: true})
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if p.Config != nil || len(p.Files()) != 7 {
+		t.Errorf("Load() with IgnoreTSConfig: config %v, files %v", p.Config, paths(p))
+	}
+}
+
+func TestLoadTSConfigFiles(t *testing.T) {
+	root := writeTree(t, map[string]string{
+		"app/tsconfig.json": `{"files": ["main.ts", "missing.ts"]}`,
+		"app/main.ts":       "main();\n",
+		"app/other.ts":      "other();\n",
+	})
+
+	p, err := Load(root, Options{TSConfig: filepath.Join(root, "app", "tsconfig.json")})
+	var fileErr *tsgoast.FileError
+	if !errors.As(err, &fileErr) || fileErr.Path != "app/missing.ts" {
+		t.Errorf("Load() error = %v, want one for app/missing.ts", err)
+	}
+	if got, want := paths(p), []string{"app/main.ts"}; !slices.Equal(got, want) {
+		t.Errorf("Load() files = %v, want %v", got, want)
+	}
+}
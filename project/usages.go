@@ -0,0 +1,243 @@
+package project
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	"github.com/ahmadramadhannn/tsgoast/analyzer"
+	"github.com/ahmadramadhannn/tsgoast/ast"
+	"github.com/ahmadramadhannn/tsgoast/refactor"
+)
+
+// Symbol is a name exported by a file of a project, such as a function, a
+// class or a type.
+type Symbol struct {
+	File string // path of the declaring file, relative to the root
+	Name string // exported name, "default" for the default export
+}
+
+// Usage is an identifier referring to a symbol.
+type Usage struct {
+	File  string // relative to the root of the project, with slashes
+	Range ast.Range
+	Node  ast.Node
+}
+
+// Symbols returns the symbols named name declared by the files of the
+// project, ordered by file. Re-exports, such as those of barrel files,
+// are left out.
+func (p *Project) Symbols(name string) []Symbol {
+	var symbols []Symbol
+	for _, f := range p.paths {
+		for _, s := range p.ImportGraph().ExportedSymbols(f) {
+			if s.Name == name && s.File == f {
+				symbols = append(symbols, Symbol{File: f, Name: name})
+			}
+		}
+	}
+	return symbols
+}
+
+// FindUsages returns the usages of symbol in the files of the project,
+// ordered by file and position: its declaration and the references to it
+// in the declaring file, and in the other files the names importing or
+// re-exporting it, directly or through barrel files, and the references
+// to the bindings they import. A namespace import refers to the symbol
+// through its member, as in ns.Name.
+//
+// Usages are found from the syntax of the files, with the scopes of
+// refactor.References: dynamic imports and usages through a re-exported
+// namespace, such as export * as ns, are not followed.
+func (p *Project) FindUsages(symbol Symbol) ([]Usage, error) {
+	file := p.File(symbol.File)
+	if file == nil {
+		return nil, fmt.Errorf("find usages: no file %s in the project", symbol.File)
+	}
+	g := p.ImportGraph()
+	if len(exportedAs(g, file.Path, file.Path, symbol.Name)) == 0 {
+		return nil, fmt.Errorf("find usages: %s does not export %s", file.Path, symbol.Name)
+	}
+
+	var usages []Usage
+	add := func(f *File, nodes ...ast.Node) {
+		for _, node := range nodes {
+			usages = append(usages, Usage{File: f.Path, Range: node.Range(), Node: node})
+		}
+	}
+	references := func(f *File, decl ast.Node) []ast.Node {
+		refs, err := refactor.References(f.Tree, decl)
+		if err != nil {
+			return nil // an undeclared name exported or imported by mistake
+		}
+		return refs
+	}
+
+	if decl := exportedDeclaration(file.Tree.Root, symbol.Name); decl != nil {
+		add(file, references(file, decl)...)
+	}
+	for _, f := range p.Files() {
+		if f == file {
+			continue
+		}
+		for _, stmt := range f.Tree.Root.Children() {
+			source := ast.ChildByField(stmt, "source")
+			if source == nil || (stmt.SyntaxKind() != "import_statement" && stmt.SyntaxKind() != "export_statement") {
+				continue
+			}
+			target := g.Resolve(f.Path, stringValue(source))
+			names := exportedAs(g, target, file.Path, symbol.Name)
+			if len(names) == 0 {
+				continue
+			}
+			if stmt.SyntaxKind() == "export_statement" {
+				// export { Name } from "./file"
+				for _, spec := range ast.ChildrenOfKind(ast.FirstChildOfKind(stmt, "export_clause"), "export_specifier") {
+					if name := ast.ChildByField(spec, "name"); name != nil && names[stringValue(name)] {
+						add(f, name)
+					}
+				}
+				continue
+			}
+			clause := ast.FirstChildOfKind(stmt, "import_clause")
+			if clause == nil {
+				continue
+			}
+			for _, child := range clause.Children() {
+				switch child.SyntaxKind() {
+				case "identifier":
+					// import Name from "./file"
+					if names["default"] {
+						add(f, references(f, child)...)
+					}
+				case "named_imports":
+					for _, spec := range ast.ChildrenOfKind(child, "import_specifier") {
+						name := ast.ChildByField(spec, "name")
+						if name == nil || !names[stringValue(name)] {
+							continue
+						}
+						if alias := ast.ChildByField(spec, "alias"); alias != nil {
+							add(f, name)
+							add(f, references(f, alias)...)
+						} else {
+							add(f, references(f, name)...)
+						}
+					}
+				case "namespace_import":
+					ns := ast.FirstChildOfKind(child, "identifier")
+					if ns == nil {
+						continue
+					}
+					for _, ref := range references(f, ns) {
+						if member := namespaceMember(ref); member != nil && names[member.Text()] {
+							add(f, member)
+						}
+					}
+				}
+			}
+		}
+	}
+
+	slices.SortFunc(usages, func(a, b Usage) int {
+		return cmp.Or(cmp.Compare(a.File, b.File), cmp.Compare(a.Range.Start.Offset, b.Range.Start.Offset))
+	})
+	return slices.CompactFunc(usages, func(a, b Usage) bool {
+		return a.File == b.File && a.Range == b.Range
+	}), nil
+}
+
+// exportedAs returns the names under which the file target exports the
+// symbol named name declared by the file decl, or nil if it does not.
+func exportedAs(g *analyzer.ImportGraph, target, decl, name string) map[string]bool {
+	if target == "" {
+		return nil
+	}
+	var names map[string]bool
+	for _, s := range g.ExportedSymbols(target) {
+		if s.File == decl && s.DeclaredAs == name && !s.External {
+			if names == nil {
+				names = make(map[string]bool)
+			}
+			names[s.Name] = true
+		}
+	}
+	return names
+}
+
+// exportedDeclaration returns the node exported as name by the statements
+// of root: the declaration, or the identifier of an export clause or of an
+// export default, or nil for an anonymous default export.
+func exportedDeclaration(root ast.Node, name string) ast.Node {
+	for _, stmt := range root.Children() {
+		if stmt.SyntaxKind() != "export_statement" || ast.ChildByField(stmt, "source") != nil {
+			continue
+		}
+		decl := ast.ChildByField(stmt, "declaration")
+		if ast.FirstChildOfKind(stmt, "default") != nil {
+			if name != "default" {
+				continue
+			}
+			if decl != nil && ast.ChildByField(decl, "name") != nil {
+				return decl
+			}
+			if value := ast.ChildByField(stmt, "value"); value != nil && value.SyntaxKind() == "identifier" {
+				return value
+			}
+			return nil
+		}
+		if clause := ast.FirstChildOfKind(stmt, "export_clause"); clause != nil {
+			for _, spec := range ast.ChildrenOfKind(clause, "export_specifier") {
+				exported := ast.ChildByField(spec, "alias")
+				if exported == nil {
+					exported = ast.ChildByField(spec, "name")
+				}
+				if exported != nil && stringValue(exported) == name {
+					return ast.ChildByField(spec, "name")
+				}
+			}
+			continue
+		}
+		if decl == nil {
+			continue
+		}
+		switch decl.SyntaxKind() {
+		case "lexical_declaration", "variable_declaration":
+			for _, declarator := range ast.ChildrenOfKind(decl, "variable_declarator") {
+				if id := ast.ChildByField(declarator, "name"); id != nil && id.SyntaxKind() == "identifier" && id.Text() == name {
+					return id
+				}
+			}
+		default:
+			if id := ast.ChildByField(decl, "name"); id != nil && id.Text() == name {
+				return decl
+			}
+		}
+	}
+	return nil
+}
+
+// namespaceMember returns the member named after ref, a reference to a
+// namespace import, as in ns.Name or the type ns.Name, or nil.
+func namespaceMember(ref ast.Node) ast.Node {
+	parent := ref.Parent()
+	if parent == nil {
+		return nil
+	}
+	switch {
+	case parent.SyntaxKind() == "member_expression" && ast.ChildByField(parent, "object") == ref:
+		return ast.ChildByField(parent, "property")
+	case parent.SyntaxKind() == "nested_type_identifier" && ast.ChildByField(parent, "module") == ref:
+		return ast.ChildByField(parent, "name")
+	}
+	return nil
+}
+
+// stringValue returns the text of node, without its quotes if it is a
+// string, such as the specifier of an import.
+func stringValue(node ast.Node) string {
+	text := node.Text()
+	if len(text) >= 2 && (text[0] == '"' || text[0] == '\'') && text[len(text)-1] == text[0] {
+		return text[1 : len(text)-1]
+	}
+	return text
+}
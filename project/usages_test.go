@@ -0,0 +1,95 @@
+package project
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestFindUsages(t *testing.T) {
+	root := writeTree(t, map[string]string{
+		"src/models/user.ts": `export class User {
+  static guest(): User { return new User(); }
+}
+export default function load(): User { return User.guest(); }
+`,
+		"src/models/index.ts": `export * from "./user";
+export { default as loadUser } from "./user";
+`,
+		"src/app.ts": `import { User as Account, loadUser } from "./models";
+import * as user from "./models/user";
+const a: Account = loadUser();
+const b: user.User = new user.User();
+function f(User: string) { return User; }
+`,
+		"src/view.ts": `import load from "./models/user";
+import { User } from "./models";
+export { User } from "./models/user";
+load();
+`,
+		"src/other.ts": `export class User {}
+const u = new User();
+`,
+	})
+	p, err := Load(root, Options{})
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if got, want := p.Symbols("User"), []Symbol{{"src/models/user.ts", "User"}, {"src/other.ts", "User"}}; !slices.Equal(got, want) {
+		t.Errorf("Symbols(User) = %v, want %v", got, want)
+	}
+
+	tests := []struct {
+		symbol Symbol
+		want   []string // file:line:column text
+	}{
+		{Symbol{"src/models/user.ts", "User"}, []string{
+			"src/app.ts:1:10 User",
+			"src/app.ts:1:18 Account",
+			"src/app.ts:3:10 Account",
+			"src/app.ts:4:15 User",
+			"src/app.ts:4:31 User",
+			"src/models/user.ts:1:14 User",
+			"src/models/user.ts:2:19 User",
+			"src/models/user.ts:2:37 User",
+			"src/models/user.ts:4:33 User",
+			"src/models/user.ts:4:47 User",
+			"src/view.ts:2:10 User",
+			"src/view.ts:3:10 User",
+		}},
+		{Symbol{"src/models/user.ts", "default"}, []string{
+			"src/app.ts:1:27 loadUser",
+			"src/app.ts:3:20 loadUser",
+			"src/models/index.ts:2:10 default",
+			"src/models/user.ts:4:25 load",
+			"src/view.ts:1:8 load",
+			"src/view.ts:4:1 load",
+		}},
+		{Symbol{"src/other.ts", "User"}, []string{
+			"src/other.ts:1:14 User",
+			"src/other.ts:2:15 User",
+		}},
+	}
+	for _, tt := range tests {
+		usages, err := p.FindUsages(tt.symbol)
+		if err != nil {
+			t.Fatalf("FindUsages(%v) error = %v", tt.symbol, err)
+		}
+		var got []string
+		for _, u := range usages {
+			start := u.Range.Start
+			got = append(got, fmt.Sprintf("%s:%d:%d %s", u.File, start.Line+1, start.Column+1, u.Node.Text()))
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("FindUsages(%v) =\n%s\nwant\n%s", tt.symbol, strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+		}
+	}
+
+	for _, symbol := range []Symbol{{"src/missing.ts", "User"}, {"src/app.ts", "User"}} {
+		if _, err := p.FindUsages(symbol); err == nil {
+			t.Errorf("FindUsages(%v) succeeded, want an error", symbol)
+		}
+	}
+}
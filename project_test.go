@@ -0,0 +1,130 @@
+package tsgoast
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestProjectParseConcurrently(t *testing.T) {
+	dir := t.TempDir()
+	var paths []string
+	for i := 0; i < 20; i++ {
+		path := filepath.Join(dir, fmt.Sprintf("f%02d.ts", i))
+		source := strings.Repeat(fmt.Sprintf("export const v%d = %d;\n", i, i), i+1)
+		if err := os.WriteFile(path, []byte(source), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		paths = append(paths, path)
+	}
+	empty := filepath.Join(dir, "empty.ts")
+	if err := os.WriteFile(empty, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	missing := filepath.Join(dir, "missing.ts")
+	paths = append(paths, missing, empty)
+
+	var progress []Progress
+	project := &Project{
+		Paths:    paths,
+		Progress: func(p Progress) { progress = append(progress, p) },
+	}
+	err := project.ParseConcurrently(context.Background(), 4)
+
+	var fileErrs []string
+	for _, err := range err.(interface{ Unwrap() []error }).Unwrap() {
+		var fileErr *FileError
+		if !errors.As(err, &fileErr) {
+			t.Fatalf("error %v is not a *FileError", err)
+		}
+		fileErrs = append(fileErrs, filepath.Base(fileErr.Path))
+	}
+	if got := strings.Join(fileErrs, " "); got != "empty.ts missing.ts" {
+		t.Errorf("ParseConcurrently() failed on %s, want empty.ts missing.ts", got)
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("ParseConcurrently() error %v does not wrap os.ErrNotExist", err)
+	}
+
+	if len(project.Trees) != 20 {
+		t.Errorf("ParseConcurrently() parsed %d trees, want 20", len(project.Trees))
+	}
+	if tree := project.Trees[paths[3]]; tree == nil || len(tree.Statements) != 4 {
+		t.Errorf("tree of %s = %v, want 4 statements", paths[3], tree)
+	}
+	if len(progress) != len(paths) {
+		t.Fatalf("Progress called %d times, want %d", len(progress), len(paths))
+	}
+	for i, p := range progress {
+		if p.Done != i+1 || p.Total != len(paths) {
+			t.Errorf("progress %d = %d/%d", i, p.Done, p.Total)
+		}
+		if (p.Err != nil) != (p.Path == missing || p.Path == empty) {
+			t.Errorf("progress of %s: error %v", p.Path, p.Err)
+		}
+	}
+}
+
+func TestProjectParseConcurrentlyDir(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "src"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "src", "app.js"), []byte("const App = () => <div />;\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "src", "empty.js"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	project := &Project{
+		Paths:      []string{"src/app.js", "src/empty.js", "src/missing.js"},
+		Dir:        dir,
+		TSX:        func(string) bool { return true },
+		AllowEmpty: true,
+	}
+	err := project.ParseConcurrently(context.Background(), 1)
+	var fileErr *FileError
+	if !errors.As(err, &fileErr) || fileErr.Path != "src/missing.js" {
+		t.Errorf("ParseConcurrently() error = %v, want src/missing.js to fail", err)
+	}
+	if tree := project.Trees["src/app.js"]; tree == nil || hasError(tree.Root) {
+		t.Errorf("tree of src/app.js = %v, want JSX parsed", tree)
+	}
+	if tree := project.Trees["src/empty.js"]; tree == nil || len(tree.Statements) != 0 {
+		t.Errorf("tree of src/empty.js = %v, want an empty module", tree)
+	}
+}
+
+func TestProjectParseConcurrentlyCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	project := &Project{Paths: []string{"testdata/simple.ts", "testdata/functions.ts"}}
+	if err := project.ParseConcurrently(ctx, 1); !errors.Is(err, context.Canceled) {
+		t.Errorf("ParseConcurrently() error = %v, want context.Canceled", err)
+	}
+}
+
+func BenchmarkProjectParseConcurrently(b *testing.B) {
+	dir := b.TempDir()
+	source := largeSource(b, 16<<10)
+	var paths []string
+	for i := 0; i < 200; i++ {
+		path := filepath.Join(dir, fmt.Sprintf("f%03d.ts", i))
+		if err := os.WriteFile(path, source[:len(source)*(i%4+1)/4], 0o644); err != nil {
+			b.Fatal(err)
+		}
+		paths = append(paths, path)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		project := &Project{Paths: paths}
+		if err := project.ParseConcurrently(context.Background(), 0); err != nil {
+			b.Fatalf("ParseConcurrently error: %v", err)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package tsgoast
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/ahmadramadhannn/tsgoast/ast"
+	"github.com/ahmadramadhannn/tsgoast/internal/protowire"
+)
+
+// Field numbers of the diagnostics in ast.proto.
+const (
+	protoSyntaxDiagnostics = 4
+
+	protoDiagnosticStart   = 1
+	protoDiagnosticEnd     = 2
+	protoDiagnosticMessage = 3
+)
+
+// MarshalProto encodes the syntax tree of t and its diagnostics in the
+// Protocol Buffers wire format, as the Syntax message of ast/ast.proto.
+// Like MarshalJSON, typed statements are not stored, since they are
+// derived from the root.
+func (t *Tree) MarshalProto() ([]byte, error) {
+	if t.Root == nil {
+		return nil, fmt.Errorf("tree has no root node")
+	}
+	buf, err := t.Root.MarshalProto()
+	if err != nil {
+		return nil, err
+	}
+	// The fields of a message may come in any order, so the diagnostics
+	// follow those of the root
+	for _, d := range t.Diagnostics {
+		start, end := d.Range.Start, d.Range.End
+		size := protowire.BytesSize(protoDiagnosticMessage, len(d.Message))
+		if s := protowire.PositionSize(start.Line, start.Column, start.Offset); s > 0 {
+			size += protowire.BytesSize(protoDiagnosticStart, s)
+		}
+		if s := protowire.PositionSize(end.Line, end.Column, end.Offset); s > 0 {
+			size += protowire.BytesSize(protoDiagnosticEnd, s)
+		}
+		buf = protowire.AppendTag(buf, protoSyntaxDiagnostics, protowire.Bytes)
+		buf = binary.AppendUvarint(buf, uint64(size))
+		buf = protowire.AppendPosition(buf, protoDiagnosticStart, start.Line, start.Column, start.Offset)
+		buf = protowire.AppendPosition(buf, protoDiagnosticEnd, end.Line, end.Column, end.Offset)
+		buf = protowire.AppendString(buf, protoDiagnosticMessage, d.Message)
+	}
+	return buf, nil
+}
+
+// UnmarshalProto decodes a tree produced by MarshalProto, restoring parent
+// pointers and rebuilding the typed statements.
+func (t *Tree) UnmarshalProto(data []byte) error {
+	root := &ast.BaseNode{}
+	if err := root.UnmarshalProto(data); err != nil {
+		return err
+	}
+
+	// The root skipped the diagnostics
+	var diagnostics []Diagnostic
+	r := protowire.Reader{B: data}
+	for !r.Done() {
+		field, wire, err := r.Tag()
+		if err != nil {
+			return err
+		}
+		if field != protoSyntaxDiagnostics || wire != protowire.Bytes {
+			if err := r.Skip(wire); err != nil {
+				return err
+			}
+			continue
+		}
+		b, err := r.Bytes()
+		if err != nil {
+			return err
+		}
+		d, err := decodeProtoDiagnostic(b)
+		if err != nil {
+			return err
+		}
+		diagnostics = append(diagnostics, d)
+	}
+
+	t.Root = root
+	t.Diagnostics = diagnostics
+	t.Statements = (&Parser{}).extractStatements(root)
+	return nil
+}
+
+// decodeProtoDiagnostic decodes a Diagnostic message.
+func decodeProtoDiagnostic(data []byte) (Diagnostic, error) {
+	var d Diagnostic
+	r := protowire.Reader{B: data}
+	for !r.Done() {
+		field, wire, err := r.Tag()
+		if err != nil {
+			return d, err
+		}
+		if wire != protowire.Bytes || field < protoDiagnosticStart || field > protoDiagnosticMessage {
+			if err := r.Skip(wire); err != nil {
+				return d, err
+			}
+			continue
+		}
+		b, err := r.Bytes()
+		if err != nil {
+			return d, err
+		}
+		if field == protoDiagnosticMessage {
+			d.Message = string(b)
+			continue
+		}
+		line, column, offset, err := protowire.DecodePosition(b)
+		if err != nil {
+			return d, err
+		}
+		p := ast.Position{Line: line, Column: column, Offset: offset}
+		if field == protoDiagnosticStart {
+			d.Range.Start = p
+		} else {
+			d.Range.End = p
+		}
+	}
+	return d, nil
+}
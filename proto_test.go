@@ -0,0 +1,54 @@
+package tsgoast
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+func TestTreeProtoRoundTrip(t *testing.T) {
+	parser, err := New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+	defer parser.Close()
+
+	tree, err := parser.ParseTree([]byte(`
+import { x } from "./x";
+export async function load(id: string): Promise<void> {}
+class Service { greet = "héllo 😀"; }
+`))
+	if err != nil {
+		t.Fatalf("ParseTree() error = %v", err)
+	}
+
+	data, err := tree.MarshalProto()
+	if err != nil {
+		t.Fatalf("MarshalProto() error = %v", err)
+	}
+	var decoded Tree
+	if err := decoded.UnmarshalProto(data); err != nil {
+		t.Fatalf("UnmarshalProto() error = %v", err)
+	}
+
+	// The decoded tree encodes to the same JSON as the original
+	want, _ := json.Marshal(tree)
+	got, _ := json.Marshal(&decoded)
+	if string(got) != string(want) {
+		t.Errorf("decoded tree differs:\n got %s\nwant %s", got, want)
+	}
+	if len(decoded.Statements) != len(tree.Statements) {
+		t.Errorf("decoded %d statements, want %d", len(decoded.Statements), len(tree.Statements))
+	}
+	if class, ok := decoded.Statements[2].(*ast.ClassDeclaration); !ok || class.Name != "Service" {
+		t.Errorf("statement 2 = %#v, want class Service", decoded.Statements[2])
+	}
+	if len(data) >= len(want)/4 {
+		t.Errorf("proto encoding is %d bytes, JSON %d bytes", len(data), len(want))
+	}
+
+	if _, err := (&Tree{}).MarshalProto(); err == nil {
+		t.Error("MarshalProto() on empty tree: expected error")
+	}
+}
@@ -0,0 +1,102 @@
+package tsgoast
+
+import (
+	"fmt"
+
+	"github.com/ahmadramadhannn/tsgoast/ast"
+	sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// QueryCapture is a node captured by a tree-sitter query.
+type QueryCapture struct {
+	Name string // capture name without the leading @
+	Node ast.Node
+}
+
+// QueryMatch is one match of a tree-sitter query pattern.
+type QueryMatch struct {
+	Pattern  int // index of the matched pattern in the query
+	Captures []QueryCapture
+}
+
+// Capture returns the first node captured under name, or nil.
+func (m QueryMatch) Capture(name string) ast.Node {
+	for _, c := range m.Captures {
+		if c.Name == name {
+			return c.Node
+		}
+	}
+	return nil
+}
+
+// Query runs a tree-sitter S-expression query against a parsed tree and
+// returns its matches with captures mapped to the tree's nodes, e.g.
+//
+//	parser.Query(tree, `(function_declaration name: (identifier) @name)`)
+//
+// Predicates such as #eq? and #match? are applied. The tree must have been
+// produced by a parser for the same language.
+func (p *Parser) Query(tree *Tree, query string) ([]QueryMatch, error) {
+	if tree == nil || tree.Root == nil {
+		return nil, fmt.Errorf("tree is empty")
+	}
+	return p.QueryNode(tree.Root, query)
+}
+
+// QueryNode runs a tree-sitter query against the tree rooted at root, as
+// returned by Parse.
+func (p *Parser) QueryNode(root *ast.BaseNode, query string) ([]QueryMatch, error) {
+	q, qerr := sitter.NewQuery(p.language, query)
+	if qerr != nil {
+		return nil, fmt.Errorf("invalid query: %w", qerr)
+	}
+	defer q.Close()
+
+	source := []byte(root.Text())
+	tsTree := p.parser.Parse(source, nil)
+	if tsTree == nil {
+		return nil, fmt.Errorf("failed to parse source code")
+	}
+	defer tsTree.Close()
+
+	cursor := sitter.NewQueryCursor()
+	defer cursor.Close()
+
+	names := q.CaptureNames()
+	offset := uint(root.Range().Start.Offset)
+
+	var matches []QueryMatch
+	results := cursor.Matches(q, tsTree.RootNode(), source)
+	for m := results.Next(); m != nil; m = results.Next() {
+		match := QueryMatch{Pattern: int(m.PatternIndex)}
+		for _, c := range m.Captures {
+			node := findNode(root, c.Node.Kind(), offset+c.Node.StartByte(), offset+c.Node.EndByte())
+			if node == nil {
+				continue
+			}
+			match.Captures = append(match.Captures, QueryCapture{
+				Name: names[c.Index],
+				Node: node,
+			})
+		}
+		matches = append(matches, match)
+	}
+	return matches, nil
+}
+
+// findNode returns the outermost node with the given kind and byte range.
+func findNode(node ast.Node, kind string, start, end uint) ast.Node {
+	r := node.Range()
+	if uint(r.Start.Offset) > start || uint(r.End.Offset) < end {
+		return nil
+	}
+	if node.SyntaxKind() == kind && uint(r.Start.Offset) == start && uint(r.End.Offset) == end {
+		return node
+	}
+	for _, child := range node.Children() {
+		if found := findNode(child, kind, start, end); found != nil {
+			return found
+		}
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package tsgoast
+
+import (
+	"testing"
+)
+
+func TestParserQuery(t *testing.T) {
+	parser, err := New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+	defer parser.Close()
+
+	tree, err := parser.ParseTree([]byte(`
+function greet(name: string) {
+	return "Hello, " + name;
+}
+
+function farewell() {}
+
+const add = (a: number, b: number) => a + b;
+`))
+	if err != nil {
+		t.Fatalf("ParseTree() error = %v", err)
+	}
+
+	matches, err := parser.Query(tree, `(function_declaration name: (identifier) @name) @fn`)
+	if err != nil {
+		t.Fatalf("Query() error = %v", err)
+	}
+	if len(matches) != 2 {
+		t.Fatalf("Query() returned %d matches, want 2", len(matches))
+	}
+
+	wantNames := []string{"greet", "farewell"}
+	for i, m := range matches {
+		name := m.Capture("name")
+		if name == nil || name.Text() != wantNames[i] {
+			t.Errorf("match %d name = %v, want %s", i, name, wantNames[i])
+			continue
+		}
+		if name.SyntaxKind() != "identifier" {
+			t.Errorf("name SyntaxKind() = %s, want identifier", name.SyntaxKind())
+		}
+		fn := m.Capture("fn")
+		if fn == nil || fn.SyntaxKind() != "function_declaration" || name.Parent() != fn {
+			t.Errorf("match %d fn capture is not the parent of the name capture", i)
+		}
+	}
+
+	if matches[1].Capture("name").Range().Start.Line != 5 {
+		t.Errorf("farewell line = %d, want 5", matches[1].Capture("name").Range().Start.Line)
+	}
+}
+
+func TestParserQueryPredicates(t *testing.T) {
+	parser, err := New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+	defer parser.Close()
+
+	tree, err := parser.ParseTree([]byte(`console.log(1); console.error(2); other.log(3);`))
+	if err != nil {
+		t.Fatalf("ParseTree() error = %v", err)
+	}
+
+	matches, err := parser.Query(tree, `(call_expression
+		function: (member_expression
+			object: (identifier) @obj
+			property: (property_identifier) @method)
+		(#eq? @obj "console"))`)
+	if err != nil {
+		t.Fatalf("Query() error = %v", err)
+	}
+	if len(matches) != 2 {
+		t.Fatalf("Query() returned %d matches, want 2", len(matches))
+	}
+	if got := matches[1].Capture("method").Text(); got != "error" {
+		t.Errorf("second method = %s, want error", got)
+	}
+
+	if _, err := parser.Query(tree, `(not_a_node) @x`); err == nil {
+		t.Error("Query() expected error for unknown node kind")
+	}
+	if _, err := parser.Query(nil, `(identifier) @x`); err == nil {
+		t.Error("Query() expected error for nil tree")
+	}
+}
@@ -0,0 +1,313 @@
+package refactor
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ahmadramadhannn/tsgoast/ast"
+	"github.com/ahmadramadhannn/tsgoast/edits"
+	"github.com/ahmadramadhannn/tsgoast/internal/srctext"
+)
+
+// ExtractInterface returns the declaration of an interface named name
+// declaring the public instance members of class, and the edit adding the
+// interface to the implements clause of the class.
+//
+// Fields, parameter properties, methods and accessors are extracted;
+// static, private, protected and #private members and the constructor
+// are not. A getter without a setter becomes a readonly property. Members
+// are declared with their type annotations, or the type of their literal
+// initializer, and keep their doc comments. The interface takes the type
+// parameters of the class and is exported if the class is.
+//
+// The declaration is not inserted, so that it can be placed in the same
+// file or another one, for example with
+//
+//	edits.InsertBefore(class, decl+"\n\n")
+//
+// ExtractInterface fails if name is not a valid identifier or is already
+// declared in the scope of the class.
+func ExtractInterface(class ast.Node, name string) (string, edits.Edit, error) {
+	if class != nil && class.SyntaxKind() == "export_statement" {
+		class = ast.ChildByField(class, "declaration")
+	}
+	if class == nil {
+		return "", edits.Edit{}, fmt.Errorf("extract interface: no class")
+	}
+	if kind := class.SyntaxKind(); kind != "class_declaration" && kind != "abstract_class_declaration" {
+		return "", edits.Edit{}, fmt.Errorf("extract interface: %s is not a class declaration", kind)
+	}
+	if !isIdentifier(name) {
+		return "", edits.Edit{}, fmt.Errorf("extract interface: %q is not a valid identifier", name)
+	}
+	root := class
+	for root.Parent() != nil {
+		root = root.Parent()
+	}
+	if existing, _ := buildScopes(root).enclosing(class).lookup(name); existing != nil {
+		return "", edits.Edit{}, fmt.Errorf("extract interface: %s is already declared at %s", name, position(existing))
+	}
+
+	body := ast.ChildByField(class, "body")
+	indent := "  "
+	var members []string
+	for i, member := range body.Children() {
+		text := memberSignature(member, body)
+		if text == "" {
+			continue
+		}
+		if i > 0 {
+			if prev := body.Children()[i-1]; prev.SyntaxKind() == "comment" && strings.HasPrefix(prev.Text(), "/**") {
+				text = prev.Text() + "\n" + text
+			}
+		}
+		if len(members) == 0 {
+			indent = memberIndent(member, class)
+		}
+		members = append(members, text)
+	}
+	if ctor := constructorOf(body); ctor != nil {
+		members = append(parameterProperties(ctor), members...)
+	}
+
+	typeParams, typeArgs := "", ""
+	if tp := ast.ChildByField(class, "type_parameters"); tp != nil {
+		typeParams = tp.Text()
+		var names []string
+		for _, p := range ast.ChildrenOfKind(tp, "type_parameter") {
+			names = append(names, ast.ChildByField(p, "name").Text())
+		}
+		typeArgs = "<" + strings.Join(names, ", ") + ">"
+	}
+
+	var b strings.Builder
+	if parent := class.Parent(); parent != nil && parent.SyntaxKind() == "export_statement" {
+		b.WriteString("export ")
+	}
+	fmt.Fprintf(&b, "interface %s%s {\n", name, typeParams)
+	for _, m := range members {
+		for i, line := range strings.Split(m, "\n") {
+			line = strings.TrimSpace(line)
+			if i > 0 && strings.HasPrefix(line, "*") {
+				line = " " + line // doc comment continuation
+			}
+			b.WriteString(indent + line + "\n")
+		}
+	}
+	b.WriteString("}")
+
+	return b.String(), implementsEdit(class, name+typeArgs), nil
+}
+
+// memberSignature returns the interface member declaring a public
+// instance member of a class body, or "" if it is not one.
+func memberSignature(member, body ast.Node) string {
+	if !isPublicInstance(member) {
+		return ""
+	}
+	name := ast.ChildByField(member, "name")
+	switch member.SyntaxKind() {
+	case "public_field_definition":
+		if name == nil {
+			return ""
+		}
+		text := modifiers(member, "readonly") + name.Text()
+		if ast.FirstChildOfKind(member, "?") != nil {
+			text += "?"
+		}
+		return text + memberType(ast.ChildByField(member, "type"), ast.ChildByField(member, "value")) + ";"
+	case "method_definition", "abstract_method_signature":
+		if name == nil || name.Text() == "constructor" {
+			return ""
+		}
+		if ast.FirstChildOfKind(member, "get") != nil {
+			text := name.Text() + memberType(ast.ChildByField(member, "return_type"), nil) + ";"
+			if !hasAccessor(body, name.Text(), "set") {
+				text = "readonly " + text
+			}
+			return text
+		}
+		if ast.FirstChildOfKind(member, "set") != nil {
+			if hasAccessor(body, name.Text(), "get") {
+				return "" // declared with the getter
+			}
+			var param ast.Node
+			if params := ast.ChildByField(member, "parameters"); params != nil {
+				param = ast.FirstChild(params, isParameter)
+			}
+			var t ast.Node
+			if param != nil {
+				t = ast.ChildByField(param, "type")
+			}
+			return name.Text() + memberType(t, nil) + ";"
+		}
+		text := name.Text()
+		if ast.FirstChildOfKind(member, "?") != nil {
+			text += "?"
+		}
+		if tp := ast.ChildByField(member, "type_parameters"); tp != nil {
+			text += tp.Text()
+		}
+		return text + parameterList(ast.ChildByField(member, "parameters")) +
+			memberType(ast.ChildByField(member, "return_type"), nil) + ";"
+	case "index_signature":
+		return member.Text() + ";"
+	}
+	return ""
+}
+
+// isPublicInstance reports whether a class member is neither static nor
+// private or protected.
+func isPublicInstance(member ast.Node) bool {
+	if name := ast.ChildByField(member, "name"); name != nil && name.SyntaxKind() == "private_property_identifier" {
+		return false
+	}
+	if ast.FirstChildOfKind(member, "static") != nil {
+		return false
+	}
+	if m := ast.FirstChildOfKind(member, "accessibility_modifier"); m != nil && m.Text() != "public" {
+		return false
+	}
+	return true
+}
+
+// modifiers returns the modifier tokens of node among kinds, each followed
+// by a space.
+func modifiers(node ast.Node, kinds ...string) string {
+	var text string
+	for _, kind := range kinds {
+		if ast.FirstChildOfKind(node, kind) != nil {
+			text += kind + " "
+		}
+	}
+	return text
+}
+
+// memberType returns the type annotation of a member or parameter, or the
+// annotation of the type of its literal initializer, or "" if neither is
+// known.
+func memberType(annotation, value ast.Node) string {
+	if annotation != nil {
+		return annotation.Text()
+	}
+	if value != nil {
+		switch value.SyntaxKind() {
+		case "number":
+			return ": number"
+		case "string", "template_string":
+			return ": string"
+		case "true", "false":
+			return ": boolean"
+		}
+	}
+	return ""
+}
+
+// parameterList returns the parameters of a method as they are declared
+// in an interface: without decorators, modifiers and default values, which
+// make the parameter optional instead.
+func parameterList(params ast.Node) string {
+	if params == nil {
+		return "()"
+	}
+	var list []string
+	for _, param := range ast.Children(params, isParameter) {
+		pattern := ast.ChildByField(param, "pattern")
+		if pattern == nil {
+			list = append(list, param.Text()) // this parameter
+			continue
+		}
+		text := pattern.Text()
+		value := ast.ChildByField(param, "value")
+		if param.SyntaxKind() == "optional_parameter" || value != nil {
+			text += "?"
+		}
+		list = append(list, text+memberType(ast.ChildByField(param, "type"), value))
+	}
+	return "(" + strings.Join(list, ", ") + ")"
+}
+
+func isParameter(n ast.Node) bool {
+	return n.SyntaxKind() == "required_parameter" || n.SyntaxKind() == "optional_parameter"
+}
+
+// hasAccessor reports whether a class body declares an accessor of the
+// given kind, "get" or "set", for name.
+func hasAccessor(body ast.Node, name, kind string) bool {
+	for _, m := range body.Children() {
+		if m.SyntaxKind() != "method_definition" && m.SyntaxKind() != "abstract_method_signature" {
+			continue
+		}
+		if n := ast.ChildByField(m, "name"); n != nil && n.Text() == name && ast.FirstChildOfKind(m, kind) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// constructorOf returns the constructor of a class body, or nil.
+func constructorOf(body ast.Node) ast.Node {
+	for _, m := range ast.ChildrenOfKind(body, "method_definition") {
+		if n := ast.ChildByField(m, "name"); n != nil && n.Text() == "constructor" {
+			return m
+		}
+	}
+	return nil
+}
+
+// parameterProperties returns the members declared by the public
+// parameter properties of a constructor.
+func parameterProperties(ctor ast.Node) []string {
+	params := ast.ChildByField(ctor, "parameters")
+	if params == nil {
+		return nil
+	}
+	var members []string
+	for _, param := range ast.Children(params, isParameter) {
+		m := ast.FirstChildOfKind(param, "accessibility_modifier")
+		readonly := ast.FirstChildOfKind(param, "readonly") != nil
+		if m == nil && !readonly || m != nil && m.Text() != "public" {
+			continue
+		}
+		pattern := ast.ChildByField(param, "pattern")
+		if pattern == nil {
+			continue
+		}
+		text := modifiers(param, "readonly") + pattern.Text()
+		value := ast.ChildByField(param, "value")
+		if param.SyntaxKind() == "optional_parameter" {
+			text += "?"
+		}
+		members = append(members, text+memberType(ast.ChildByField(param, "type"), value)+";")
+	}
+	return members
+}
+
+// memberIndent returns the indentation of member relative to its class.
+func memberIndent(member, class ast.Node) string {
+	text, base := srctext.RootText(member)
+	indent := srctext.LineIndent(text, int(member.Range().Start.Offset-base))
+	outer := srctext.LineIndent(text, int(class.Range().Start.Offset-base))
+	if rest, ok := strings.CutPrefix(indent, outer); ok && rest != "" {
+		return rest
+	}
+	return "  "
+}
+
+// implementsEdit returns the edit adding iface to the implements clause of
+// class, creating the clause if needed.
+func implementsEdit(class ast.Node, iface string) edits.Edit {
+	heritage := ast.FirstChildOfKind(class, "class_heritage")
+	if heritage != nil {
+		if clause := ast.FirstChildOfKind(heritage, "implements_clause"); clause != nil {
+			children := clause.Children()
+			return edits.InsertAfter(children[len(children)-1], ", "+iface)
+		}
+		return edits.InsertAfter(heritage, " implements "+iface)
+	}
+	after := ast.ChildByField(class, "type_parameters")
+	if after == nil {
+		after = ast.ChildByField(class, "name")
+	}
+	return edits.InsertAfter(after, " implements "+iface)
+}
@@ -0,0 +1,128 @@
+package refactor
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ahmadramadhannn/tsgoast/ast"
+	"github.com/ahmadramadhannn/tsgoast/edits"
+)
+
+func TestExtractInterface(t *testing.T) {
+	src := `export class Cache<T> extends Base {
+    static instances = 0;
+    #hits = 0;
+    private store = new Map<string, T>();
+    /**
+     * Maximum number of entries.
+     */
+    limit = 100;
+    readonly name: string;
+    ttl?: number;
+
+    constructor(public readonly owner: string, private clock: Clock, readonly region = "eu") {
+        super();
+    }
+
+    get size(): number { return this.store.size; }
+    get enabled(): boolean { return true; }
+    set enabled(value: boolean) {}
+
+    /** Returns the cached value. */
+    get(key: string, @Inject() fallback?: T): T | undefined {
+        return this.store.get(key) ?? fallback;
+    }
+
+    async refresh<K extends string>(keys: K[], force = false): Promise<void> {}
+
+    protected evict(): void {}
+}
+`
+	tree := parseTree(t, src)
+	class := tree.Root.Children()[0]
+
+	decl, edit, err := ExtractInterface(class, "Store")
+	if err != nil {
+		t.Fatalf("ExtractInterface() error = %v", err)
+	}
+	want := `export interface Store<T> {
+    readonly owner: string;
+    readonly region: string;
+    /**
+     * Maximum number of entries.
+     */
+    limit: number;
+    readonly name: string;
+    ttl?: number;
+    readonly size: number;
+    enabled: boolean;
+    /** Returns the cached value. */
+    get(key: string, fallback?: T): T | undefined;
+    refresh<K extends string>(keys: K[], force?: boolean): Promise<void>;
+}`
+	if decl != want {
+		t.Errorf("ExtractInterface() declaration =\n%s\nwant\n%s", decl, want)
+	}
+
+	out, err := edits.Apply([]byte(src), []edits.Edit{edit})
+	if err != nil {
+		t.Fatalf("edits.Apply() error = %v", err)
+	}
+	if line, _, _ := strings.Cut(string(out), "\n"); line != "export class Cache<T> extends Base implements Store<T> {" {
+		t.Errorf("class header = %q", line)
+	}
+}
+
+func TestExtractInterfaceImplements(t *testing.T) {
+	tests := []struct {
+		src  string
+		want string
+	}{
+		{"class A { x = 1; }", "class A implements IA { x = 1; }"},
+		{"class A<T> { x = 1; }", "class A<T> implements IA<T> { x = 1; }"},
+		{"class A implements B, C { x = 1; }", "class A implements B, C, IA { x = 1; }"},
+		{"abstract class A { abstract run(): void; }", "abstract class A implements IA { abstract run(): void; }"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.src, func(t *testing.T) {
+			tree := parseTree(t, tt.src)
+			_, edit, err := ExtractInterface(tree.Root.Children()[0], "IA")
+			if err != nil {
+				t.Fatalf("ExtractInterface() error = %v", err)
+			}
+			out, err := edits.Apply([]byte(tt.src), []edits.Edit{edit})
+			if err != nil {
+				t.Fatalf("edits.Apply() error = %v", err)
+			}
+			if string(out) != tt.want {
+				t.Errorf("ExtractInterface() = %q, want %q", out, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractInterfaceErrors(t *testing.T) {
+	tree := parseTree(t, "interface Shape {}\nclass Square {}\nfunction f() {}\n")
+	square := ast.ChildByField(tree.Root.Children()[1], "name").Parent()
+	fn := tree.Root.Children()[2]
+
+	tests := []struct {
+		class ast.Node
+		name  string
+		want  string
+	}{
+		{square, "Shape", "Shape is already declared at 1:11"},
+		{square, "class", `"class" is not a valid identifier`},
+		{fn, "F", "function_declaration is not a class declaration"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			_, _, err := ExtractInterface(tt.class, tt.name)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("ExtractInterface() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package refactor
+
+import (
+	"fmt"
+
+	"github.com/ahmadramadhannn/tsgoast"
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+// References returns the identifiers in tree referring to the binding of
+// decl, in source order, starting with its declaring identifier if it
+// comes first. decl is as for Rename. Identifiers of the same name bound
+// by other declarations, such as a shadowing parameter, are left out, as
+// are property keys and the imported and exported names of aliased
+// imports and exports.
+func References(tree *tsgoast.Tree, decl ast.Node) ([]ast.Node, error) {
+	if tree == nil || tree.Root == nil || decl == nil {
+		return nil, fmt.Errorf("references: no declaration")
+	}
+	if !identifierKinds[decl.SyntaxKind()] {
+		if name := ast.ChildByField(decl, "name"); name != nil {
+			decl = name
+		}
+	}
+	if !identifierKinds[decl.SyntaxKind()] {
+		return nil, fmt.Errorf("references: %s is not an identifier or named declaration", decl.SyntaxKind())
+	}
+
+	scopes := buildScopes(tree.Root)
+	binding, _ := scopes.resolve(decl)
+	if binding == nil {
+		return nil, fmt.Errorf("references: %s is not declared in this file", decl.Text())
+	}
+	var refs []ast.Node
+	for node := range ast.Preorder(tree.Root) {
+		if node.Text() != binding.Text() || !isReference(node) {
+			continue
+		}
+		if target, _ := scopes.resolve(node); target == binding {
+			refs = append(refs, node)
+		}
+	}
+	return refs, nil
+}
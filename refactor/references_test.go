@@ -0,0 +1,44 @@
+package refactor
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestReferences(t *testing.T) {
+	tree := parseTree(t, `import { load as fetchUser } from "./api";
+export class User {
+  static from(user: User): User { return { ...user }; }
+}
+function user(User: string) { return User; }
+const admin: User = fetchUser({ User: 1 });
+export { User as Account };
+`)
+
+	tests := []struct {
+		name string
+		n    int
+		want []string // 1-based line:column of each reference
+	}{
+		{"User", 0, []string{"2:14", "3:21", "3:28", "6:14", "7:10"}},
+		{"fetchUser", 0, []string{"1:18", "6:21"}},
+		{"User", 3, []string{"5:15", "5:38"}}, // the parameter shadowing the class
+	}
+	for _, tt := range tests {
+		refs, err := References(tree, findIdentifier(tree.Root, tt.name, tt.n))
+		if err != nil {
+			t.Fatalf("References(%s #%d) error = %v", tt.name, tt.n, err)
+		}
+		var got []string
+		for _, ref := range refs {
+			got = append(got, position(ref))
+		}
+		if strings.Join(got, " ") != strings.Join(tt.want, " ") {
+			t.Errorf("References(%s #%d) = %v, want %v", tt.name, tt.n, got, tt.want)
+		}
+	}
+
+	if _, err := References(tree, findIdentifier(tree.Root, "load", 0)); err == nil {
+		t.Error("References() of an imported name succeeded, want an error")
+	}
+}
@@ -0,0 +1,153 @@
+// Package refactor implements refactorings of TypeScript source, such as
+// renaming a declaration or extracting an interface from a class.
+// Refactorings are computed from a parsed tree and returned as text edits
+// on the source it was parsed from, leaving the rest of the file
+// untouched.
+package refactor
+
+import (
+	"fmt"
+	"sort"
+	"unicode"
+
+	"github.com/ahmadramadhannn/tsgoast"
+	"github.com/ahmadramadhannn/tsgoast/ast"
+	"github.com/ahmadramadhannn/tsgoast/edits"
+)
+
+// reservedWords cannot be used as binding names.
+var reservedWords = map[string]bool{
+	"break": true, "case": true, "catch": true, "class": true, "const": true,
+	"continue": true, "debugger": true, "default": true, "delete": true, "do": true,
+	"else": true, "enum": true, "export": true, "extends": true, "false": true,
+	"finally": true, "for": true, "function": true, "if": true, "import": true,
+	"in": true, "instanceof": true, "new": true, "null": true, "return": true,
+	"super": true, "switch": true, "this": true, "throw": true, "true": true,
+	"try": true, "typeof": true, "var": true, "void": true, "while": true,
+	"with": true, "implements": true, "interface": true, "let": true, "package": true,
+	"private": true, "protected": true, "public": true, "static": true, "yield": true,
+	"await": true,
+}
+
+// Rename returns the edits renaming a declaration and every reference to it
+// in tree to newName.
+//
+// decl is the declaring identifier, a reference to it, or a declaration
+// node with a name field (such as a function or class declaration). Local
+// declarations of any kind can be renamed: variables, functions, classes,
+// interfaces, type aliases, enums, parameters, type parameters and
+// imports.
+//
+// The edits keep the code meaning the same: a shorthand property { x }
+// becomes { x: newName }, an import { x } becomes import { x as newName },
+// and an export { x } becomes export { newName as x }, so property keys and
+// the imported and exported names are preserved. Rename fails if newName
+// is not a valid identifier, is already declared in the scope of decl, or
+// would capture or be shadowed by another declaration.
+//
+// The edits are sorted by offset and can be applied with edits.Apply.
+func Rename(tree *tsgoast.Tree, decl ast.Node, newName string) ([]edits.Edit, error) {
+	if tree == nil || tree.Root == nil || decl == nil {
+		return nil, fmt.Errorf("rename: nothing to rename")
+	}
+	if !identifierKinds[decl.SyntaxKind()] {
+		if name := ast.ChildByField(decl, "name"); name != nil {
+			decl = name
+		}
+	}
+	if !identifierKinds[decl.SyntaxKind()] {
+		return nil, fmt.Errorf("rename: %s is not an identifier or named declaration", decl.SyntaxKind())
+	}
+	if !isIdentifier(newName) {
+		return nil, fmt.Errorf("rename: %q is not a valid identifier", newName)
+	}
+
+	scopes := buildScopes(tree.Root)
+	binding, declScope := scopes.resolve(decl)
+	if binding == nil {
+		return nil, fmt.Errorf("rename: %s is not declared in this file", decl.Text())
+	}
+	oldName := binding.Text()
+	if newName == oldName {
+		return nil, nil
+	}
+	if existing, ok := declScope.bindings[newName]; ok {
+		return nil, fmt.Errorf("rename: %s is already declared at %s", newName, position(existing))
+	}
+
+	var result []edits.Edit
+	for node := range ast.Preorder(tree.Root) {
+		if !isReference(node) {
+			continue
+		}
+		switch node.Text() {
+		case oldName:
+			if target, _ := scopes.resolve(node); target != binding {
+				continue
+			}
+			// Uses of the new name from here must not resolve to a closer
+			// declaration
+			if other, sc := scopes.enclosing(node).lookup(newName); other != nil && isInside(sc, declScope) {
+				return nil, fmt.Errorf("rename: %s at %s would refer to the %s declared at %s",
+					oldName, position(node), newName, position(other))
+			}
+			result = append(result, renameEdit(node, oldName, newName))
+		case newName:
+			// Existing uses of the new name must not be captured by the
+			// renamed declaration
+			if _, sc := scopes.resolve(node); (sc == nil || !isInside(sc, declScope)) && isInside(scopes.enclosing(node), declScope) {
+				return nil, fmt.Errorf("rename: %s at %s would refer to the renamed declaration", newName, position(node))
+			}
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Range.Start.Offset < result[j].Range.Start.Offset
+	})
+	return result, nil
+}
+
+// renameEdit returns the edit renaming one occurrence of a binding.
+func renameEdit(node ast.Node, oldName, newName string) edits.Edit {
+	text := newName
+	parent := node.Parent()
+	switch {
+	case node.SyntaxKind() == "shorthand_property_identifier" ||
+		node.SyntaxKind() == "shorthand_property_identifier_pattern":
+		text = oldName + ": " + newName
+	case parent != nil && parent.SyntaxKind() == "import_specifier" && ast.ChildByField(parent, "alias") == nil:
+		text = oldName + " as " + newName
+	case parent != nil && parent.SyntaxKind() == "export_specifier" && ast.ChildByField(parent, "alias") == nil:
+		text = newName + " as " + oldName
+	}
+	return edits.Replace(node, text)
+}
+
+// isInside reports whether scope sc is outer or one of its descendants.
+func isInside(sc, outer *scope) bool {
+	for ; sc != nil; sc = sc.parent {
+		if sc == outer {
+			return true
+		}
+	}
+	return false
+}
+
+// isIdentifier reports whether name is a valid, non-reserved identifier.
+func isIdentifier(name string) bool {
+	if name == "" || reservedWords[name] {
+		return false
+	}
+	for i, r := range name {
+		if r != '_' && r != '$' && !unicode.IsLetter(r) && (i == 0 || !unicode.IsDigit(r)) {
+			return false
+		}
+	}
+	return true
+}
+
+// position formats the 1-based position of a node.
+func position(node ast.Node) string {
+	start := node.Range().Start
+	return fmt.Sprintf("%d:%d", start.Line+1, start.Column+1)
+}
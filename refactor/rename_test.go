@@ -0,0 +1,178 @@
+package refactor
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ahmadramadhannn/tsgoast"
+	"github.com/ahmadramadhannn/tsgoast/ast"
+	"github.com/ahmadramadhannn/tsgoast/edits"
+)
+
+func parseTree(t *testing.T, src string) *tsgoast.Tree {
+	t.Helper()
+	parser, err := tsgoast.New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+	defer parser.Close()
+
+	tree, err := parser.ParseTree([]byte(src))
+	if err != nil {
+		t.Fatalf("ParseTree() error = %v", err)
+	}
+	return tree
+}
+
+// findIdentifier returns the n-th identifier-like node with the given text.
+func findIdentifier(root ast.Node, text string, n int) ast.Node {
+	for node := range ast.Preorder(root) {
+		if identifierKinds[node.SyntaxKind()] && node.Text() == text {
+			if n == 0 {
+				return node
+			}
+			n--
+		}
+	}
+	return nil
+}
+
+func rename(t *testing.T, src, name string, n int, newName string) (string, error) {
+	t.Helper()
+	tree := parseTree(t, src)
+	decl := findIdentifier(tree.Root, name, n)
+	if decl == nil {
+		t.Fatalf("identifier %s #%d not found", name, n)
+	}
+	result, err := Rename(tree, decl, newName)
+	if err != nil {
+		return "", err
+	}
+	out, err := edits.Apply([]byte(src), result)
+	if err != nil {
+		t.Fatalf("edits.Apply() error = %v", err)
+	}
+	return string(out), nil
+}
+
+func TestRename(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     string
+		old     string
+		n       int // occurrence of old to start from
+		newName string
+		want    string
+	}{
+		{
+			name:    "variable and references",
+			src:     "const count = 1;\nfunction f() { return count + 1; }\nlog(count);\n",
+			old:     "count",
+			n:       2, // from a reference
+			newName: "total",
+			want:    "const total = 1;\nfunction f() { return total + 1; }\nlog(total);\n",
+		},
+		{
+			name:    "shadowed names are left alone",
+			src:     "let x = 1;\nfunction f(x: number) { return x; }\nx++;\n",
+			old:     "x",
+			newName: "y",
+			want:    "let y = 1;\nfunction f(x: number) { return x; }\ny++;\n",
+		},
+		{
+			name:    "parameter",
+			src:     "function f(x: number) { return x * 2; }\nconst x = 3;\n",
+			old:     "x",
+			newName: "value",
+			want:    "function f(value: number) { return value * 2; }\nconst x = 3;\n",
+		},
+		{
+			name:    "shorthand properties",
+			src:     "const id = 1;\nconst user = { id, name };\nconst { id: other } = user;\n",
+			old:     "id",
+			newName: "userId",
+			want:    "const userId = 1;\nconst user = { id: userId, name };\nconst { id: other } = user;\n",
+		},
+		{
+			name:    "destructured declaration",
+			src:     "const { id } = user;\nuse(id);\n",
+			old:     "id",
+			newName: "key",
+			want:    "const { id: key } = user;\nuse(key);\n",
+		},
+		{
+			name:    "export alias",
+			src:     "function load() {}\nload();\nexport { load };\nexport { load as fetch };\n",
+			old:     "load",
+			newName: "loadAll",
+			want:    "function loadAll() {}\nloadAll();\nexport { loadAll as load };\nexport { loadAll as fetch };\n",
+		},
+		{
+			name:    "import",
+			src:     "import { read, write as put } from \"./fs\";\nread(); put();\n",
+			old:     "read",
+			newName: "readFile",
+			want:    "import { read as readFile, write as put } from \"./fs\";\nreadFile(); put();\n",
+		},
+		{
+			name:    "import alias",
+			src:     "import { write as put } from \"./fs\";\nput();\n",
+			old:     "put",
+			newName: "store",
+			want:    "import { write as store } from \"./fs\";\nstore();\n",
+		},
+		{
+			name:    "class as type and value",
+			src:     "class User {}\nconst u: User = new User();\n",
+			old:     "User",
+			n:       1,
+			newName: "Account",
+			want:    "class Account {}\nconst u: Account = new Account();\n",
+		},
+		{
+			name:    "type parameter",
+			src:     "function id<T>(x: T): T { return x; }\ntype T = string;\n",
+			old:     "T",
+			newName: "U",
+			want:    "function id<U>(x: U): U { return x; }\ntype T = string;\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := rename(t, tt.src, tt.old, tt.n, tt.newName)
+			if err != nil {
+				t.Fatalf("Rename() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("renamed source =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenameErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     string
+		old     string
+		newName string
+		want    string
+	}{
+		{"invalid name", "let a = 1;", "a", "1b", "not a valid identifier"},
+		{"reserved word", "let a = 1;", "a", "class", "not a valid identifier"},
+		{"conflict", "let a = 1;\nlet b = 2;", "a", "b", "already declared"},
+		{"shadowed", "let a = 1;\nfunction f(b) { return a + b; }", "a", "b", "would refer to the b"},
+		{"capture", "function f(a) { return a + b; }", "a", "b", "would refer to the renamed"},
+		{"global", "console.log(1);", "console", "con", "not declared"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := rename(t, tt.src, tt.old, 0, tt.newName)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Rename() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,264 @@
+package refactor
+
+import "github.com/ahmadramadhannn/tsgoast/ast"
+
+// functionScopeKinds are the nodes whose scope receives var declarations.
+var functionScopeKinds = map[string]bool{
+	"program":                        true,
+	"function_declaration":           true,
+	"function_expression":            true,
+	"function":                       true,
+	"generator_function_declaration": true,
+	"generator_function":             true,
+	"arrow_function":                 true,
+	"method_definition":              true,
+}
+
+// blockScopeKinds are the other nodes introducing a scope: blocks, loop
+// headers, catch clauses, and the declarations owning type parameters.
+var blockScopeKinds = map[string]bool{
+	"statement_block":            true,
+	"switch_body":                true,
+	"for_statement":              true,
+	"for_in_statement":           true,
+	"catch_clause":               true,
+	"class_declaration":          true,
+	"abstract_class_declaration": true,
+	"class":                      true,
+	"interface_declaration":      true,
+	"type_alias_declaration":     true,
+}
+
+// outerNameKinds are declarations that bind their name in the enclosing
+// scope rather than in the scope they introduce.
+var outerNameKinds = map[string]bool{
+	"function_declaration":           true,
+	"generator_function_declaration": true,
+	"class_declaration":              true,
+	"abstract_class_declaration":     true,
+	"interface_declaration":          true,
+	"type_alias_declaration":         true,
+	"enum_declaration":               true,
+	"internal_module":                true,
+}
+
+// identifierKinds are the kinds of nodes naming a binding. Values and types
+// share one namespace, which is enough to follow classes and enums used in
+// both positions.
+var identifierKinds = map[string]bool{
+	"identifier":                            true,
+	"type_identifier":                       true,
+	"shorthand_property_identifier":         true,
+	"shorthand_property_identifier_pattern": true,
+}
+
+// scope is a lexical scope and the names declared in it.
+type scope struct {
+	node     ast.Node
+	parent   *scope
+	function bool
+	bindings map[string]ast.Node // name -> declaring identifier
+}
+
+// lookup returns the declaring identifier of name visible from s, and the
+// scope declaring it, or nil if name is not declared.
+func (s *scope) lookup(name string) (ast.Node, *scope) {
+	for ; s != nil; s = s.parent {
+		if decl, ok := s.bindings[name]; ok {
+			return decl, s
+		}
+	}
+	return nil, nil
+}
+
+// scopes holds the scope tree of a syntax tree.
+type scopes struct {
+	byNode map[ast.Node]*scope
+	decls  map[ast.Node]*scope // declaring identifier -> its scope
+}
+
+// buildScopes computes the scopes of the tree rooted at root and the
+// declarations of each.
+func buildScopes(root ast.Node) *scopes {
+	s := &scopes{byNode: make(map[ast.Node]*scope), decls: make(map[ast.Node]*scope)}
+	for node := range ast.Preorder(root) {
+		kind := node.SyntaxKind()
+		if node == root || functionScopeKinds[kind] || blockScopeKinds[kind] {
+			s.byNode[node] = &scope{
+				node:     node,
+				parent:   s.enclosing(node),
+				function: node == root || functionScopeKinds[kind],
+				bindings: make(map[string]ast.Node),
+			}
+		}
+	}
+	for node := range ast.Preorder(root) {
+		s.declare(node)
+	}
+	return s
+}
+
+// enclosing returns the innermost scope strictly containing node.
+func (s *scopes) enclosing(node ast.Node) *scope {
+	for p := node.Parent(); p != nil; p = p.Parent() {
+		if sc, ok := s.byNode[p]; ok {
+			return sc
+		}
+	}
+	return nil
+}
+
+// at returns the innermost scope containing node, including the scope it
+// introduces.
+func (s *scopes) at(node ast.Node) *scope {
+	if sc, ok := s.byNode[node]; ok {
+		return sc
+	}
+	return s.enclosing(node)
+}
+
+// functionScope returns the innermost function scope containing node.
+func (s *scopes) functionScope(node ast.Node) *scope {
+	sc := s.enclosing(node)
+	for sc != nil && !sc.function {
+		sc = sc.parent
+	}
+	return sc
+}
+
+// bind declares the identifiers of names in sc.
+func (s *scopes) bind(sc *scope, names ...ast.Node) {
+	if sc == nil {
+		return
+	}
+	for _, name := range names {
+		if name != nil && identifierKinds[name.SyntaxKind()] {
+			if _, ok := sc.bindings[name.Text()]; !ok {
+				sc.bindings[name.Text()] = name
+			}
+			s.decls[name] = sc
+		}
+	}
+}
+
+// declare records the names declared by node, if any.
+func (s *scopes) declare(node ast.Node) {
+	kind := node.SyntaxKind()
+	switch {
+	case outerNameKinds[kind]:
+		s.bind(s.enclosing(node), ast.ChildByField(node, "name"))
+	case kind == "function_expression" || kind == "function" || kind == "generator_function" || kind == "class":
+		s.bind(s.at(node), ast.ChildByField(node, "name"))
+	}
+
+	switch kind {
+	case "variable_declarator":
+		names := patternNames(ast.ChildByField(node, "name"))
+		if decl := node.Parent(); decl != nil && decl.SyntaxKind() == "variable_declaration" {
+			s.bind(s.functionScope(node), names...)
+		} else {
+			s.bind(s.enclosing(node), names...)
+		}
+	case "required_parameter", "optional_parameter":
+		s.bind(s.enclosing(node), patternNames(ast.ChildByField(node, "pattern"))...)
+	case "arrow_function":
+		s.bind(s.at(node), ast.ChildByField(node, "parameter"))
+	case "catch_clause":
+		s.bind(s.at(node), patternNames(ast.ChildByField(node, "parameter"))...)
+	case "for_in_statement":
+		switch declKind := ast.ChildByField(node, "kind"); {
+		case declKind == nil:
+		case declKind.Text() == "var":
+			s.bind(s.functionScope(node), patternNames(ast.ChildByField(node, "left"))...)
+		default:
+			s.bind(s.at(node), patternNames(ast.ChildByField(node, "left"))...)
+		}
+	case "type_parameter":
+		s.bind(s.enclosing(node), ast.ChildByField(node, "name"))
+	case "import_clause":
+		root := s.enclosing(node)
+		for root != nil && root.parent != nil {
+			root = root.parent
+		}
+		for child := range ast.Preorder(node) {
+			switch child.SyntaxKind() {
+			case "import_clause", "namespace_import":
+				for _, id := range ast.ChildrenOfKind(child, "identifier") {
+					s.bind(root, id)
+				}
+			case "import_specifier":
+				s.bind(root, importBinding(child))
+			}
+		}
+	}
+}
+
+// importBinding returns the local name bound by an import specifier: its
+// alias, or its name.
+func importBinding(spec ast.Node) ast.Node {
+	if alias := ast.ChildByField(spec, "alias"); alias != nil {
+		return alias
+	}
+	return ast.ChildByField(spec, "name")
+}
+
+// patternNames returns the identifiers bound by a binding pattern, such as
+// the name of a variable or the destructured names of an object pattern.
+// Property keys and default values are skipped.
+func patternNames(pattern ast.Node) []ast.Node {
+	if pattern == nil {
+		return nil
+	}
+	switch pattern.SyntaxKind() {
+	case "identifier", "shorthand_property_identifier_pattern":
+		return []ast.Node{pattern}
+	case "pair_pattern":
+		return patternNames(ast.ChildByField(pattern, "value"))
+	case "assignment_pattern", "object_assignment_pattern":
+		return patternNames(ast.ChildByField(pattern, "left"))
+	case "object_pattern", "array_pattern", "rest_pattern":
+		var names []ast.Node
+		for _, child := range pattern.Children() {
+			names = append(names, patternNames(child)...)
+		}
+		return names
+	}
+	return nil
+}
+
+// isReference reports whether an identifier node refers to a binding of
+// the file: not the imported name of an aliased import, the exported name
+// of an aliased export, or a name re-exported from another module.
+func isReference(node ast.Node) bool {
+	if !identifierKinds[node.SyntaxKind()] {
+		return false
+	}
+	parent := node.Parent()
+	if parent == nil {
+		return true
+	}
+	field := ast.FieldOf(node)
+	switch parent.SyntaxKind() {
+	case "import_specifier":
+		return importBinding(parent) == node
+	case "export_specifier":
+		if field == "alias" {
+			return false
+		}
+		for p := parent.Parent(); p != nil; p = p.Parent() {
+			if p.SyntaxKind() == "export_statement" {
+				return ast.ChildByField(p, "source") == nil
+			}
+		}
+	}
+	return true
+}
+
+// resolve returns the declaring identifier that the identifier id refers
+// to and its scope, or nil for undeclared (global) names.
+func (s *scopes) resolve(id ast.Node) (ast.Node, *scope) {
+	if sc, ok := s.decls[id]; ok {
+		return id, sc
+	}
+	return s.enclosing(id).lookup(id.Text())
+}
@@ -0,0 +1,346 @@
+package tsgoast
+
+import (
+	"bytes"
+	"cmp"
+	"fmt"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ahmadramadhannn/tsgoast/ast"
+	sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// SourceEdit describes an edit of a source, in byte offsets: the bytes
+// from Start to OldEnd of the old source were replaced with the bytes
+// from Start to NewEnd of the new source.
+type SourceEdit struct {
+	Start  uint32
+	OldEnd uint32
+	NewEnd uint32
+}
+
+// DiffEdit returns the edit turning oldSource into newSource, spanning
+// the bytes between their common prefix and suffix. It suits editors that
+// send whole documents rather than the changes they make.
+func DiffEdit(oldSource, newSource []byte) SourceEdit {
+	n := min(len(oldSource), len(newSource))
+	prefix := 0
+	for prefix < n && oldSource[prefix] == newSource[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < n-prefix && oldSource[len(oldSource)-1-suffix] == newSource[len(newSource)-1-suffix] {
+		suffix++
+	}
+	return SourceEdit{
+		Start:  uint32(prefix),
+		OldEnd: uint32(len(oldSource) - suffix),
+		NewEnd: uint32(len(newSource) - suffix),
+	}
+}
+
+// Reparse parses source, the source of old changed by edit, reusing what
+// it can of old. The tree-sitter tree kept by the previous Reparse is
+// edited and reparsed incrementally, and only the nodes that the edit
+// touches are converted again: the others are taken over from old, those
+// after the edit copied with their positions shifted past it, and the
+// typed statements before the edit are carried over as they are. For a
+// one-keystroke edit, this costs a fraction of a full parse.
+//
+// Reparse takes over the nodes of old, so old must not be used afterwards.
+// It must be the tree parsed from the old source, unmodified. Trees from
+// ParseTree or parsed lazily are parsed again completely on their first
+// Reparse; the trees returned by Reparse keep their tree-sitter tree, for
+// the next edit, until they are unreachable.
+func (p *Parser) Reparse(old *Tree, source []byte, edit SourceEdit) (*Tree, error) {
+	if len(source) == 0 {
+		return nil, fmt.Errorf("source code is empty")
+	}
+	r := &reparser{parser: p, edit: edit}
+	var oldRoot *ast.BaseNode
+	var oldSyntax *sitter.Tree
+	if old != nil && old.Root != nil && old.source != "" && !old.lazy {
+		if edit.Start > edit.OldEnd || edit.Start > edit.NewEnd ||
+			int(edit.OldEnd) > len(old.source) || int(edit.NewEnd) > len(source) ||
+			len(old.source)-int(edit.OldEnd) != len(source)-int(edit.NewEnd) {
+			return nil, fmt.Errorf("reparse: edit %d-%d to %d does not match the sources", edit.Start, edit.OldEnd, edit.NewEnd)
+		}
+		oldRoot, oldSyntax = old.Root, old.syntax
+		r.input = sitter.InputEdit{
+			StartByte:     uint(edit.Start),
+			OldEndByte:    uint(edit.OldEnd),
+			NewEndByte:    uint(edit.NewEnd),
+			StartPosition: pointAt(source, edit.Start),
+		}
+		r.input.OldEndPosition = advance(r.input.StartPosition, old.source[edit.Start:edit.OldEnd])
+		r.input.NewEndPosition = advance(r.input.StartPosition, string(source[edit.Start:edit.NewEnd]))
+	}
+
+	stats := ParseStats{Bytes: len(source)}
+	start := time.Now()
+	var syntax *sitter.Tree
+	if oldSyntax != nil {
+		edited := oldSyntax.Clone()
+		edited.Edit(&r.input)
+		syntax = p.parser.Parse(source, edited)
+		if syntax != nil {
+			r.changed = edited.ChangedRanges(syntax)
+		}
+		edited.Close()
+	} else {
+		syntax = p.parser.Parse(source, nil)
+	}
+	if syntax == nil {
+		return nil, fmt.Errorf("failed to parse source code")
+	}
+	rootNode := syntax.RootNode()
+	if rootNode == nil {
+		syntax.Close()
+		return nil, fmt.Errorf("failed to get root node")
+	}
+
+	stats.ParseTime = time.Since(start)
+
+	start = time.Now()
+	r.text = string(source)
+	cursor := rootNode.Walk()
+	root := r.convert(cursor, oldRoot, nil)
+	cursor.Close()
+	if old != nil && oldRoot != nil {
+		r.keepDiagnostics(old.Diagnostics)
+	}
+	stats.ConvertTime = time.Since(start)
+	stats.Truncated = len(r.diagnostics)
+	p.report("", root, stats, nil)
+	tree := &Tree{Root: root, Diagnostics: r.diagnostics, source: r.text, syntax: syntax, stats: stats}
+	runtime.AddCleanup(tree, func(syntax *sitter.Tree) { syntax.Close() }, syntax)
+
+	// Carry over the statements before the edit, rebuild the others
+	kept := make(map[uint32]ast.Statement)
+	if old != nil && oldRoot != nil {
+		for _, stmt := range old.Statements {
+			if start := stmt.Range().Start.Offset; stmt.Range().End.Offset < edit.Start {
+				kept[start] = stmt
+			}
+		}
+	}
+	tree.Statements = make([]ast.Statement, 0, len(root.ChildNodes))
+	for _, child := range root.Children() {
+		r := child.Range()
+		if stmt, ok := kept[r.Start.Offset]; ok && r.End.Offset < edit.Start && stmt.Range() == r {
+			if b, ok := stmt.(interface{ Base() *ast.BaseNode }); ok {
+				b.Base().ParentNode = root
+			}
+			tree.Statements = append(tree.Statements, stmt)
+			continue
+		}
+		if stmt := p.buildStatement(child); stmt != nil {
+			tree.Statements = append(tree.Statements, stmt)
+		}
+	}
+	return tree, nil
+}
+
+// reparser converts a reparsed tree, reusing the nodes of the old tree
+// that the edit did not touch.
+type reparser struct {
+	parser  *Parser
+	text    string
+	edit    SourceEdit
+	input   sitter.InputEdit
+	changed []sitter.Range // ranges whose syntax changed
+
+	depth       int // of the node converted
+	diagnostics []Diagnostic
+}
+
+// convert converts the node at cursor, reusing old, the node of the old
+// tree at the same place, or its descendants. Children are visited with
+// the cursor, as indexing them costs their position in tree-sitter.
+func (r *reparser) convert(cursor *sitter.TreeCursor, old *ast.BaseNode, parent *ast.BaseNode) *ast.BaseNode {
+	node := cursor.Node()
+	if old != nil && r.reusable(node, old) {
+		reused := r.shift(old)
+		reused.ParentNode = parent
+		return reused
+	}
+
+	baseNode := r.parser.newBaseNode(node, r.text[node.StartByte():node.EndByte()], parent)
+	if limit := r.parser.limits.MaxDepth; limit > 0 && r.depth >= limit && node.ChildCount() > 0 {
+		r.diagnostics = append(r.diagnostics, r.parser.depthDiagnostic(baseNode))
+		return baseNode
+	}
+	if !cursor.GotoFirstChild() {
+		return baseNode
+	}
+	r.depth++
+	var oldChildren []ast.Node
+	if old != nil {
+		oldChildren = old.ChildNodes
+	}
+	baseNode.ChildNodes = make([]ast.Node, 0, node.ChildCount())
+	for j := 0; ; {
+		// The old children are in source order too: skip those that end
+		// before this one starts, and match the next one if it overlaps
+		child := cursor.Node()
+		var match *ast.BaseNode
+		start, end := r.oldOffset(child.StartByte()), r.oldOffset(child.EndByte())
+		for j < len(oldChildren) {
+			if rng := oldChildren[j].Range(); rng.End.Offset > start || rng.End.Offset == start && rng.Start.Offset == start {
+				break
+			}
+			j++
+		}
+		if j < len(oldChildren) && oldChildren[j].Range().Start.Offset <= end {
+			// Each old child is matched once at most, so that its nodes
+			// are not reused twice
+			match, _ = oldChildren[j].(*ast.BaseNode)
+			j++
+		}
+		childNode := r.convert(cursor, match, baseNode)
+		childNode.FieldName = r.parser.fieldByID(cursor)
+		baseNode.ChildNodes = append(baseNode.ChildNodes, childNode)
+		if !cursor.GotoNextSibling() {
+			break
+		}
+	}
+	cursor.GotoParent()
+	r.depth--
+	return baseNode
+}
+
+// keepDiagnostics adds the diagnostics of the old tree outside the edit,
+// shifted past it, to those of the new one. Reused nodes keep their
+// truncated subtrees, and the nodes converted again were reported again;
+// the old diagnostics of ranges whose syntax changed are dropped.
+func (r *reparser) keepDiagnostics(old []Diagnostic) {
+	seen := make(map[uint32]bool, len(r.diagnostics))
+	for _, d := range r.diagnostics {
+		seen[d.Range.Start.Offset] = true
+	}
+	n := len(r.diagnostics)
+	for _, d := range old {
+		if d.Range.End.Offset >= r.edit.Start && d.Range.Start.Offset <= r.edit.OldEnd {
+			continue
+		}
+		if d.Range.Start.Offset >= r.edit.Start {
+			d.Range.Start = r.shiftPosition(d.Range.Start)
+			d.Range.End = r.shiftPosition(d.Range.End)
+		}
+		if !seen[d.Range.Start.Offset] && !r.touched(uint(d.Range.Start.Offset), uint(d.Range.End.Offset)) {
+			r.diagnostics = append(r.diagnostics, d)
+		}
+	}
+	if len(r.diagnostics) > n {
+		slices.SortFunc(r.diagnostics, func(a, b Diagnostic) int {
+			return cmp.Compare(a.Range.Start.Offset, b.Range.Start.Offset)
+		})
+	}
+}
+
+// touched reports whether the bytes from start to end of the new source
+// overlap a range whose syntax changed.
+func (r *reparser) touched(start, end uint) bool {
+	for _, c := range r.changed {
+		if start <= c.EndByte && end >= c.StartByte {
+			return true
+		}
+	}
+	return false
+}
+
+// reusable reports whether old, a node of the old tree, can stand for
+// node: they have the same kind and text, and the edit did not touch them.
+func (r *reparser) reusable(node *sitter.Node, old *ast.BaseNode) bool {
+	start, end := node.StartByte(), node.EndByte()
+	if old.KindID == ast.KindUnknown || old.KindID != r.parser.kind(node).id {
+		return false
+	}
+	if end >= uint(r.edit.Start) && start <= uint(r.edit.NewEnd) {
+		return false
+	}
+	if old.SourceRange.Start.Offset != r.oldOffset(start) || old.SourceRange.End.Offset != r.oldOffset(end) {
+		return false
+	}
+	return !r.touched(start, end)
+}
+
+// oldOffset returns the offset in the old source of offset in the new
+// one. Offsets in the inserted text map to the start of the edit.
+func (r *reparser) oldOffset(offset uint) uint32 {
+	switch {
+	case offset <= uint(r.edit.Start):
+		return uint32(offset)
+	case offset >= uint(r.edit.NewEnd):
+		return uint32(offset) - r.edit.NewEnd + r.edit.OldEnd
+	}
+	return r.edit.Start
+}
+
+// shift returns a reused subtree with its positions moved past the edit
+// and its text pointing to the new source. Subtrees after the edit are
+// copied, leaving the old tree as it was for the rest of the conversion;
+// those before the edit are returned as they are.
+func (r *reparser) shift(node *ast.BaseNode) *ast.BaseNode {
+	if node.SourceRange.Start.Offset < r.edit.Start {
+		return node
+	}
+	type pending struct{ old, shifted *ast.BaseNode }
+	root := r.shiftedCopy(node, nil)
+	for stack := []pending{{node, root}}; len(stack) > 0; {
+		p := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for i, child := range p.old.ChildNodes {
+			if b, ok := child.(interface{ Base() *ast.BaseNode }); ok {
+				shifted := r.shiftedCopy(b.Base(), p.shifted)
+				p.shifted.ChildNodes[i] = shifted
+				stack = append(stack, pending{b.Base(), shifted})
+			}
+		}
+	}
+	return root
+}
+
+// shiftedCopy returns a copy of node, without its descendants, shifted
+// past the edit under parent.
+func (r *reparser) shiftedCopy(node, parent *ast.BaseNode) *ast.BaseNode {
+	c := *node
+	c.SourceRange.Start = r.shiftPosition(node.SourceRange.Start)
+	c.SourceRange.End = r.shiftPosition(node.SourceRange.End)
+	c.Content = r.text[c.SourceRange.Start.Offset:c.SourceRange.End.Offset]
+	c.ParentNode = parent
+	if node.ChildNodes != nil {
+		c.ChildNodes = slices.Clone(node.ChildNodes)
+	}
+	return &c
+}
+
+// shiftPosition returns the new position of pos, a position after the
+// edit in the old source.
+func (r *reparser) shiftPosition(pos ast.Position) ast.Position {
+	oldEnd, newEnd := r.input.OldEndPosition, r.input.NewEndPosition
+	if pos.Line == uint32(oldEnd.Row) {
+		pos.Column = pos.Column - uint32(oldEnd.Column) + uint32(newEnd.Column)
+	}
+	pos.Line = pos.Line - uint32(oldEnd.Row) + uint32(newEnd.Row)
+	pos.Offset = pos.Offset - r.edit.OldEnd + r.edit.NewEnd
+	return pos
+}
+
+// pointAt returns the row and byte column of offset in source.
+func pointAt(source []byte, offset uint32) sitter.Point {
+	before := source[:offset]
+	line := bytes.LastIndexByte(before, '\n')
+	return sitter.Point{Row: uint(bytes.Count(before, []byte("\n"))), Column: uint(len(before) - line - 1)}
+}
+
+// advance returns the point after text, starting at point.
+func advance(point sitter.Point, text string) sitter.Point {
+	if line := strings.LastIndexByte(text, '\n'); line >= 0 {
+		return sitter.Point{Row: point.Row + uint(strings.Count(text, "\n")), Column: uint(len(text) - line - 1)}
+	}
+	return sitter.Point{Row: point.Row, Column: point.Column + uint(len(text))}
+}
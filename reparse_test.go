@@ -0,0 +1,246 @@
+package tsgoast
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+func TestReparse(t *testing.T) {
+	parser, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer parser.Close()
+
+	source := []byte(`import { a } from "./a";
+
+export class Greeter {
+	greet(name: string): string {
+		return "hello " + name;
+	}
+}
+
+function add(x: number, y: number) {
+	return x + y;
+}
+
+const total = add(1, 2);
+`)
+	tree, err := parser.ParseTree(source)
+	if err != nil {
+		t.Fatalf("ParseTree() error = %v", err)
+	}
+
+	edits := []struct {
+		name     string
+		old, new string // replaced text, at its first occurrence
+	}{
+		{"keystroke in a function", "x + y", "x + yy"},
+		{"keystroke in a method", `"hello "`, `"hello, "`},
+		{"new line", "function add", "let n = 0;\nfunction add"},
+		{"deletion of a statement", "let n = 0;\n", ""},
+		{"syntax error", "return x + yy;", "return x + ;"},
+		{"fix of the syntax error", "return x + ;", "return x\n\t\t+ y;"},
+		{"first line", `import { a }`, `import { a, b }`},
+		{"end of the source", "add(1, 2);\n", "add(1, 2);\nadd(3, 4);\n"},
+		{"no change", "", ""},
+	}
+	for _, e := range edits {
+		t.Run(e.name, func(t *testing.T) {
+			start := bytes.Index(source, []byte(e.old))
+			if start < 0 {
+				t.Fatalf("%q not found", e.old)
+			}
+			newSource := append(append(append([]byte{}, source[:start]...), e.new...), source[start+len(e.old):]...)
+			edit := SourceEdit{Start: uint32(start), OldEnd: uint32(start + len(e.old)), NewEnd: uint32(start + len(e.new))}
+			if diff := DiffEdit(source, newSource); diff.NewEnd-diff.Start > edit.NewEnd-edit.Start {
+				t.Errorf("DiffEdit() = %+v, wider than %+v", diff, edit)
+			}
+
+			var before []ast.Statement
+			for _, stmt := range tree.Statements {
+				if stmt.Range().End.Offset < edit.Start {
+					before = append(before, stmt)
+				}
+			}
+			got, err := parser.Reparse(tree, newSource, edit)
+			if err != nil {
+				t.Fatalf("Reparse() error = %v", err)
+			}
+			want, err := parser.ParseTree(newSource)
+			if err != nil {
+				t.Fatalf("ParseTree() error = %v", err)
+			}
+			if g, w := treeJSON(t, got), treeJSON(t, want); g != w {
+				t.Errorf("Reparse() =\n%s\nwant\n%s", g, w)
+			}
+			checkParents(t, got.Root)
+			for i, stmt := range before {
+				if got.Statements[i] != stmt {
+					t.Errorf("Reparse() rebuilt statement %d before the edit", i)
+				}
+			}
+			source, tree = newSource, got
+		})
+	}
+
+	// The nodes after an edit are taken over as shifted copies, leaving
+	// the old ones as they were
+	last := tree.Root.ChildNodes[len(tree.Root.ChildNodes)-1]
+	lastRange := last.Range()
+	newSource := append([]byte("\n"), source...)
+	got, err := parser.Reparse(tree, newSource, SourceEdit{Start: 0, OldEnd: 0, NewEnd: 1})
+	if err != nil {
+		t.Fatalf("Reparse() error = %v", err)
+	}
+	shifted := got.Root.ChildNodes[len(got.Root.ChildNodes)-1]
+	if r := shifted.Range(); r.Start.Line != 15 || r.Start.Offset != uint32(bytes.LastIndex(newSource, []byte("add(3"))) {
+		t.Errorf("last statement range = %+v", r)
+	}
+	if last.Range() != lastRange || shifted == last {
+		t.Errorf("Reparse() moved the old last statement to %+v", last.Range())
+	}
+	tree, source = got, newSource
+
+	if _, err := parser.Reparse(tree, source, SourceEdit{Start: 1, OldEnd: 2, NewEnd: 4}); err == nil {
+		t.Error("Reparse() with a mismatched edit: want error")
+	}
+}
+
+func FuzzReparse(f *testing.F) {
+	f.Add("let a = 1;\nfunction f() { return a + 2; }\nconst b = `x${a}y`;\n", uint(61), uint(1), ";", uint(20), uint(0), "x")
+	f.Add("class A {\n\tm() { return [1, 2]; }\n}\n", uint(0), uint(0), "let n = 0;\n", uint(30), uint(2), "")
+	f.Add("if (a) { b(); } else { c(); }\n", uint(4), uint(1), "aa", uint(25), uint(0), "d(); ")
+	f.Fuzz(func(t *testing.T, source string, at1, del1 uint, ins1 string, at2, del2 uint, ins2 string) {
+		parser, err := New()
+		if err != nil {
+			t.Fatalf("New() error = %v", err)
+		}
+		defer parser.Close()
+
+		tree, err := parser.Reparse(nil, []byte(source), SourceEdit{})
+		if err != nil {
+			t.Fatalf("Reparse() error = %v", err)
+		}
+		for _, e := range []struct {
+			at, del uint
+			ins     string
+		}{{at1, del1, ins1}, {at2, del2, ins2}} {
+			start := int(e.at % uint(len(source)+1))
+			end := start + int(e.del%uint(len(source)-start+1))
+			edited := source[:start] + e.ins + source[end:]
+			edit := SourceEdit{Start: uint32(start), OldEnd: uint32(end), NewEnd: uint32(start + len(e.ins))}
+			if tree, err = parser.Reparse(tree, []byte(edited), edit); err != nil {
+				t.Fatalf("Reparse() error = %v", err)
+			}
+			want, err := parser.ParseTree([]byte(edited))
+			if err != nil {
+				t.Fatalf("ParseTree() error = %v", err)
+			}
+			if g, w := treeJSON(t, tree), treeJSON(t, want); g != w {
+				t.Fatalf("Reparse() of %q =\n%s\nwant\n%s", edited, g, w)
+			}
+			checkParents(t, tree.Root)
+			for node := range ast.Preorder(tree.Root) {
+				if r := node.Range(); node.Text() != edited[r.Start.Offset:r.End.Offset] {
+					t.Fatalf("text of %s at %+v = %q, not the source", node.SyntaxKind(), r, node.Text())
+				}
+			}
+			source = edited
+		}
+	})
+}
+
+func TestDiffEdit(t *testing.T) {
+	tests := []struct {
+		old, new string
+		want     SourceEdit
+	}{
+		{"abc", "abc", SourceEdit{3, 3, 3}},
+		{"abc", "abxc", SourceEdit{2, 2, 3}},
+		{"abc", "ac", SourceEdit{1, 2, 1}},
+		{"aaa", "aaaa", SourceEdit{3, 3, 4}},
+		{"", "x", SourceEdit{0, 0, 1}},
+	}
+	for _, tt := range tests {
+		if got := DiffEdit([]byte(tt.old), []byte(tt.new)); got != tt.want {
+			t.Errorf("DiffEdit(%q, %q) = %+v, want %+v", tt.old, tt.new, got, tt.want)
+		}
+	}
+}
+
+// treeJSON renders the nodes of tree and the kinds and ranges of its
+// statements, for comparison.
+func treeJSON(t *testing.T, tree *Tree) string {
+	t.Helper()
+	var b strings.Builder
+	root, err := json.Marshal(tree.Root)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	b.Write(root)
+	for _, stmt := range tree.Statements {
+		fmt.Fprintf(&b, "\n%T %+v %s", stmt, stmt.Range(), stmt.Text())
+	}
+	return b.String()
+}
+
+// checkParents checks that the children of the tree rooted at node point
+// to their parents.
+func checkParents(t *testing.T, node ast.Node) {
+	t.Helper()
+	for _, child := range node.Children() {
+		if child.Parent() != node {
+			t.Errorf("parent of %s %q is not %s", child.SyntaxKind(), child.Text(), node.SyntaxKind())
+			return
+		}
+		checkParents(t, child)
+	}
+}
+
+// BenchmarkReparse reparses a file after a one-keystroke edit, and parses
+// it again completely for comparison.
+func BenchmarkReparse(b *testing.B) {
+	for _, size := range []int{32 << 10, 1 << 20} {
+		source := largeSource(b, size)
+		parser, err := New()
+		if err != nil {
+			b.Fatalf("Failed to create parser: %v", err)
+		}
+		defer parser.Close()
+
+		// Type a character in the middle of the file and delete it again
+		at := bytes.Index(source[len(source)/2:], []byte("return ")) + len(source)/2 + len("return ")
+		typed := append(append(append([]byte{}, source[:at]...), 'x'), source[at:]...)
+		versions := [][]byte{typed, source}
+		edits := []SourceEdit{{uint32(at), uint32(at), uint32(at + 1)}, {uint32(at), uint32(at + 1), uint32(at)}}
+
+		b.Run(fmt.Sprintf("%dKB", size>>10), func(b *testing.B) {
+			tree, err := parser.Reparse(nil, source, SourceEdit{})
+			if err != nil {
+				b.Fatalf("Reparse error: %v", err)
+			}
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				tree, err = parser.Reparse(tree, versions[i%2], edits[i%2])
+				if err != nil {
+					b.Fatalf("Reparse error: %v", err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("%dKB/full", size>>10), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := parser.ParseTree(versions[i%2]); err != nil {
+					b.Fatalf("ParseTree error: %v", err)
+				}
+			}
+		})
+	}
+}
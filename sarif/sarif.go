@@ -0,0 +1,181 @@
+// Package sarif encodes analyzer findings as a SARIF 2.1.0 log, the format
+// read by GitHub code scanning and most static analysis dashboards.
+//
+// Each distinct rule ID becomes a rule of the tsgoast tool driver, described
+// by analyzer.RuleDescriptions when known, and each finding becomes a result
+// located in its File. Lines and columns are 1-based; columns are counted in
+// UTF-16 code units, the SARIF default, when the finding carries its Node.
+package sarif
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/ahmadramadhannn/tsgoast/analyzer"
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+// Version and Schema identify the SARIF version written by this package.
+const (
+	Version = "2.1.0"
+	Schema  = "https://json.schemastore.org/sarif-2.1.0.json"
+)
+
+// ToolName and ToolURI identify the tool in the log's driver.
+const (
+	ToolName = "tsgoast"
+	ToolURI  = "https://github.com/ahmadramadhannn/tsgoast"
+)
+
+// Log is a SARIF log.
+type Log struct {
+	Version string `json:"version"`
+	Schema  string `json:"$schema"`
+	Runs    []Run  `json:"runs"`
+}
+
+// Run is a single run of the tool.
+type Run struct {
+	Tool    Tool     `json:"tool"`
+	Results []Result `json:"results"`
+}
+
+// Tool describes the analysis tool.
+type Tool struct {
+	Driver Driver `json:"driver"`
+}
+
+// Driver is the tool component that produced the results.
+type Driver struct {
+	Name           string `json:"name"`
+	InformationURI string `json:"informationUri,omitempty"`
+	Rules          []Rule `json:"rules"`
+}
+
+// Rule describes a rule referenced by results.
+type Rule struct {
+	ID               string  `json:"id"`
+	ShortDescription Message `json:"shortDescription"`
+}
+
+// Result is a single finding.
+type Result struct {
+	RuleID    string     `json:"ruleId"`
+	RuleIndex int        `json:"ruleIndex"`
+	Level     string     `json:"level"`
+	Message   Message    `json:"message"`
+	Locations []Location `json:"locations"`
+}
+
+// Message is a plain text message.
+type Message struct {
+	Text string `json:"text"`
+}
+
+// Location is the location of a result.
+type Location struct {
+	PhysicalLocation PhysicalLocation `json:"physicalLocation"`
+}
+
+// PhysicalLocation is a region of an artifact (a file).
+type PhysicalLocation struct {
+	ArtifactLocation ArtifactLocation `json:"artifactLocation"`
+	Region           Region           `json:"region"`
+}
+
+// ArtifactLocation identifies a file by URI, normally a path relative to
+// the repository root.
+type ArtifactLocation struct {
+	URI string `json:"uri,omitempty"`
+}
+
+// Region is a 1-based line and column range. EndColumn is exclusive.
+type Region struct {
+	StartLine   uint32 `json:"startLine"`
+	StartColumn uint32 `json:"startColumn"`
+	EndLine     uint32 `json:"endLine"`
+	EndColumn   uint32 `json:"endColumn"`
+}
+
+// Convert builds a SARIF log with a single run holding the findings.
+func Convert(findings []analyzer.Finding) *Log {
+	ruleIDs := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, f := range findings {
+		if !seen[f.RuleID] {
+			seen[f.RuleID] = true
+			ruleIDs = append(ruleIDs, f.RuleID)
+		}
+	}
+	sort.Strings(ruleIDs)
+
+	rules := make([]Rule, len(ruleIDs))
+	index := make(map[string]int, len(ruleIDs))
+	for i, id := range ruleIDs {
+		description := analyzer.RuleDescriptions[id]
+		if description == "" {
+			description = id
+		}
+		rules[i] = Rule{ID: id, ShortDescription: Message{Text: description}}
+		index[id] = i
+	}
+
+	indexes := make(map[ast.Node]*ast.UTF16Index)
+	results := make([]Result, len(findings))
+	for i, f := range findings {
+		level := string(f.Severity)
+		if level == "" {
+			level = string(analyzer.SeverityWarning)
+		}
+		results[i] = Result{
+			RuleID:    f.RuleID,
+			RuleIndex: index[f.RuleID],
+			Level:     level,
+			Message:   Message{Text: f.Message},
+			Locations: []Location{{
+				PhysicalLocation: PhysicalLocation{
+					ArtifactLocation: ArtifactLocation{URI: f.File},
+					Region:           region(f, indexes),
+				},
+			}},
+		}
+	}
+
+	return &Log{
+		Version: Version,
+		Schema:  Schema,
+		Runs: []Run{{
+			Tool:    Tool{Driver: Driver{Name: ToolName, InformationURI: ToolURI, Rules: rules}},
+			Results: results,
+		}},
+	}
+}
+
+// Marshal encodes the findings as an indented SARIF log.
+func Marshal(findings []analyzer.Finding) ([]byte, error) {
+	return json.MarshalIndent(Convert(findings), "", "  ")
+}
+
+// region returns the SARIF region of a finding, converting columns to UTF-16
+// using the finding's tree. indexes caches the index of each tree.
+func region(f analyzer.Finding, indexes map[ast.Node]*ast.UTF16Index) Region {
+	start, end := f.Range.Start.Column, f.Range.End.Column
+	if f.Node != nil {
+		root := f.Node
+		for root.Parent() != nil {
+			root = root.Parent()
+		}
+		index, ok := indexes[root]
+		if !ok {
+			index = ast.NewUTF16Index(root)
+			indexes[root] = index
+		}
+		start, end = index.Column(f.Range.Start), index.Column(f.Range.End)
+	}
+	return Region{
+		StartLine:   f.Range.Start.Line + 1,
+		StartColumn: start + 1,
+		EndLine:     f.Range.End.Line + 1,
+		EndColumn:   end + 1,
+	}
+}
@@ -0,0 +1,80 @@
+package sarif
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ahmadramadhannn/tsgoast"
+	"github.com/ahmadramadhannn/tsgoast/analyzer"
+)
+
+func TestMarshal(t *testing.T) {
+	parser, err := tsgoast.New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+	defer parser.Close()
+
+	root, err := parser.Parse([]byte("const s = \"😀\"; debugger;\nalert(s);\n"))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	findings := analyzer.New(root).Findings()
+	for i := range findings {
+		findings[i].File = "src/app.ts"
+	}
+	findings = append(findings, analyzer.Finding{RuleID: "custom", Message: "custom rule"})
+
+	data, err := Marshal(findings)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	var log Log
+	if err := json.Unmarshal(data, &log); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if log.Version != "2.1.0" || len(log.Runs) != 1 {
+		t.Fatalf("got version %q with %d runs", log.Version, len(log.Runs))
+	}
+	run := log.Runs[0]
+	if run.Tool.Driver.Name != "tsgoast" {
+		t.Errorf("driver name = %q", run.Tool.Driver.Name)
+	}
+
+	wantRules := []string{"banned-call", "custom", "no-debugger"}
+	if len(run.Tool.Driver.Rules) != len(wantRules) {
+		t.Fatalf("got %d rules, want %d", len(run.Tool.Driver.Rules), len(wantRules))
+	}
+	for i, id := range wantRules {
+		if got := run.Tool.Driver.Rules[i].ID; got != id {
+			t.Errorf("rule %d = %q, want %q", i, got, id)
+		}
+	}
+	if got := run.Tool.Driver.Rules[1].ShortDescription.Text; got != "custom" {
+		t.Errorf("custom rule description = %q", got)
+	}
+
+	if len(run.Results) != 3 {
+		t.Fatalf("got %d results, want 3", len(run.Results))
+	}
+
+	debugger := run.Results[0]
+	if debugger.RuleID != "no-debugger" || debugger.RuleIndex != 2 || debugger.Level != "warning" {
+		t.Errorf("debugger result = %+v", debugger)
+	}
+	loc := debugger.Locations[0].PhysicalLocation
+	if loc.ArtifactLocation.URI != "src/app.ts" {
+		t.Errorf("uri = %q", loc.ArtifactLocation.URI)
+	}
+	// the emoji is 4 bytes but 2 UTF-16 code units
+	if want := (Region{StartLine: 1, StartColumn: 17, EndLine: 1, EndColumn: 26}); loc.Region != want {
+		t.Errorf("region = %+v, want %+v", loc.Region, want)
+	}
+
+	alert := run.Results[1].Locations[0].PhysicalLocation.Region
+	if want := (Region{StartLine: 2, StartColumn: 1, EndLine: 2, EndColumn: 9}); alert != want {
+		t.Errorf("region = %+v, want %+v", alert, want)
+	}
+}
@@ -0,0 +1,68 @@
+package tsgoast
+
+import (
+	"fmt"
+	"iter"
+
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+// Statements parses source and returns an iterator over its typed
+// top-level statements, like the Statements of ParseTree. Each statement
+// is converted as the iteration reaches it, and the root node does not
+// list them, so statements dropped by the loop can be freed while the
+// rest of the file is converted: scanners that only look at declarations
+// never hold the whole tree. The statements share one copy of the source,
+// and their Parent is a root node without children. The limits of the
+// parser apply to each statement on its own, and truncated subtrees are
+// not reported.
+//
+// A failing parse yields a nil statement and the error, and ends the
+// iteration. Breaking out of the loop skips the conversion of the
+// remaining statements.
+//
+//	for stmt, err := range parser.Statements(source) {
+//		if err != nil {
+//			return err
+//		}
+//		if fn, ok := stmt.(*ast.FunctionDeclaration); ok {
+//			fmt.Println(fn.Name)
+//		}
+//	}
+func (p *Parser) Statements(source []byte) iter.Seq2[ast.Statement, error] {
+	return func(yield func(ast.Statement, error) bool) {
+		if len(source) == 0 {
+			yield(nil, fmt.Errorf("source code is empty"))
+			return
+		}
+		tree := p.parser.Parse(source, nil)
+		if tree == nil {
+			yield(nil, fmt.Errorf("failed to parse source code"))
+			return
+		}
+		defer tree.Close()
+		rootNode := tree.RootNode()
+		if rootNode == nil {
+			yield(nil, fmt.Errorf("failed to get root node"))
+			return
+		}
+
+		text := string(source)
+		root := p.newBaseNode(rootNode, text, nil)
+		cursor := rootNode.Walk()
+		defer cursor.Close()
+		if !cursor.GotoFirstChild() {
+			return
+		}
+		for {
+			child, _ := p.convertTree(cursor.Node(), text, 0, root, 1)
+			child.FieldName = p.fieldByID(cursor)
+			if stmt := p.buildStatement(child); stmt != nil && !yield(stmt, nil) {
+				return
+			}
+			if !cursor.GotoNextSibling() {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package tsgoast
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+func TestParserStatements(t *testing.T) {
+	source := []byte(`import { a } from "./a";
+// comment
+export class Greeter {}
+function add(x: number, y: number) { return x + y; }
+const total = add(1, 2);
+if (total) { console.log(total); }
+`)
+	parser, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer parser.Close()
+	want, err := parser.ParseTree(source)
+	if err != nil {
+		t.Fatalf("ParseTree() error = %v", err)
+	}
+
+	var got []ast.Statement
+	for stmt, err := range parser.Statements(source) {
+		if err != nil {
+			t.Fatalf("Statements() error = %v", err)
+		}
+		if stmt.Parent() == nil || len(stmt.Parent().Children()) != 0 {
+			t.Errorf("parent of %T = %v, want a root without children", stmt, stmt.Parent())
+		}
+		got = append(got, stmt)
+	}
+	if len(got) != len(want.Statements) {
+		t.Fatalf("Statements() yielded %d statements, want %d", len(got), len(want.Statements))
+	}
+	for i, stmt := range got {
+		w := want.Statements[i]
+		if g, w := fmt.Sprintf("%T %v %q", stmt, stmt.Range(), stmt.Text()), fmt.Sprintf("%T %v %q", w, w.Range(), w.Text()); g != w {
+			t.Errorf("statement %d = %s, want %s", i, g, w)
+		}
+	}
+	fn, ok := got[2].(*ast.FunctionDeclaration)
+	if !ok || fn.Name != "add" || len(fn.Children()) == 0 {
+		t.Errorf("statement 2 = %#v, want function add with children", got[2])
+	}
+
+	n := 0
+	for range parser.Statements(source) {
+		n++
+		if n == 2 {
+			break
+		}
+	}
+	if n != 2 {
+		t.Errorf("Statements() ran on after break: %d", n)
+	}
+
+	for stmt, err := range parser.Statements(nil) {
+		if stmt != nil || err == nil {
+			t.Errorf("Statements(nil) = %v, %v, want an error", stmt, err)
+		}
+	}
+}
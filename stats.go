@@ -0,0 +1,120 @@
+package tsgoast
+
+import (
+	"time"
+	"unsafe"
+
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+// ParseStats describes the parse of a tree.
+type ParseStats struct {
+	Nodes    int // converted nodes
+	MaxDepth int // depth of the deepest converted node, 0 for the root
+	Bytes    int // size of the source
+
+	// Truncated is the number of subtrees left out by the limits of the
+	// parser; see Parser.SetLimits.
+	Truncated int
+
+	ParseTime   time.Duration // parsing by tree-sitter
+	ConvertTime time.Duration // conversion to AST nodes
+}
+
+// Stats returns the statistics of the parse of t. The nodes are counted
+// when Stats is called, so that they cover the nodes of lazy trees
+// converted since; the times are zero for trees that were not parsed,
+// such as decoded ones.
+func (t *Tree) Stats() ParseStats {
+	stats := t.stats
+	stats.Nodes, stats.MaxDepth = measure(t.Root)
+	if stats.Bytes == 0 && t.Root != nil {
+		stats.Bytes = len(t.Root.Text())
+	}
+	return stats
+}
+
+// MemoryFootprint estimates the bytes retained by t: its nodes and typed
+// statements, counted by ast.SizeOf, the source they share and, for trees
+// parsed with an arena, the room left in its slabs. It leaves out the
+// memory of the tree-sitter tree kept by Reparse. Comparing footprints
+// tells what options such as lazy conversion or arenas save, and sizes
+// caches of trees.
+func (t *Tree) MemoryFootprint() int {
+	nodes := make([]ast.Node, 0, len(t.Statements)+1)
+	if t.Root != nil {
+		nodes = append(nodes, t.Root)
+	}
+	for _, stmt := range t.Statements {
+		nodes = append(nodes, stmt)
+	}
+	size := ast.SizeOf(nodes...) + len(t.source) + cap(t.Diagnostics)*int(unsafe.Sizeof(Diagnostic{}))
+	if t.slab != nil {
+		size += (cap(t.slab.nodes)-len(t.slab.nodes))*int(unsafe.Sizeof(ast.BaseNode{})) +
+			(cap(t.slab.lists)-len(t.slab.lists))*int(unsafe.Sizeof(ast.Node(nil)))
+	}
+	return size
+}
+
+// measure returns the number of nodes of the tree rooted at root and its
+// depth, without converting the children of lazy nodes. It keeps its own
+// stack, as trees can be deeper than recursion allows.
+func measure(root ast.Node) (nodes, depth int) {
+	if root == nil {
+		return 0, 0
+	}
+	type entry struct {
+		node  ast.Node
+		depth int
+	}
+	stack := []entry{{root, 0}}
+	for len(stack) > 0 {
+		e := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		nodes++
+		depth = max(depth, e.depth)
+		var children []ast.Node
+		if b, ok := e.node.(interface{ Base() *ast.BaseNode }); ok {
+			children = b.Base().ChildNodes
+		} else {
+			children = e.node.Children()
+		}
+		for _, child := range children {
+			if child != nil {
+				stack = append(stack, entry{child, e.depth + 1})
+			}
+		}
+	}
+	return nodes, depth
+}
+
+// ParseHook is called after each parse of a parser, with the path of the
+// file parsed (empty for sources parsed directly), the statistics of the
+// parse, and the error if the parse failed.
+type ParseHook func(path string, stats ParseStats, err error)
+
+// SetParseHook sets the hook called after each parse, such as to record
+// the parse times and sizes of the files of a project. Counting the nodes
+// of each tree walks it; a nil hook, the default, skips that.
+func (p *Parser) SetParseHook(hook ParseHook) {
+	p.hook = hook
+}
+
+// SetProfileLabels sets whether ParseFile and ParseTreeFromFile label
+// their CPU profile samples with the path of the file, as the
+// "tsgoast_file" pprof label, so that profiles of services parsing many
+// files tell the costly ones apart.
+func (p *Parser) SetProfileLabels(labels bool) {
+	p.labels = labels
+}
+
+// report calls the parse hook, if any.
+func (p *Parser) report(path string, root *ast.BaseNode, stats ParseStats, err error) {
+	if p.hook == nil {
+		return
+	}
+	if root != nil {
+		stats.Nodes, stats.MaxDepth = measure(root)
+	}
+	p.hook(path, stats, err)
+}
@@ -0,0 +1,118 @@
+package tsgoast
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"unsafe"
+
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+func TestTreeStats(t *testing.T) {
+	parser, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer parser.Close()
+
+	source := []byte("const a = 1;\nfunction f() { if (a) { return a; } }\n")
+	tree, err := parser.ParseTree(source)
+	if err != nil {
+		t.Fatalf("ParseTree() error = %v", err)
+	}
+	stats := tree.Stats()
+	nodes, depth := 0, 0
+	for node := range ast.Preorder(tree.Root) {
+		nodes++
+		d := 0
+		for parent := node.Parent(); parent != nil; parent = parent.Parent() {
+			d++
+		}
+		depth = max(depth, d)
+	}
+	if stats.Nodes != nodes || stats.MaxDepth != depth {
+		t.Errorf("Stats() = %d nodes of depth %d, want %d of depth %d", stats.Nodes, stats.MaxDepth, nodes, depth)
+	}
+	if stats.Bytes != len(source) || stats.ParseTime <= 0 || stats.ConvertTime <= 0 {
+		t.Errorf("Stats() = %+v", stats)
+	}
+
+	// Lazy trees count the nodes converted so far
+	parser.SetLazy(true)
+	lazy, err := parser.ParseTree(source)
+	if err != nil {
+		t.Fatalf("ParseTree() error = %v", err)
+	}
+	if n := lazy.Stats().Nodes; n >= stats.Nodes {
+		t.Errorf("Stats() of a lazy tree = %d nodes, want fewer than %d", n, stats.Nodes)
+	}
+}
+
+func TestParseHook(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "a.ts")
+	if err := os.WriteFile(path, []byte("export const a = 1;\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	parser, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer parser.Close()
+	var calls []string
+	parser.SetParseHook(func(path string, stats ParseStats, err error) {
+		call := filepath.Base(path)
+		if err != nil {
+			call += " error"
+		} else if stats.Nodes == 0 || stats.Bytes == 0 {
+			call += " no stats"
+		}
+		calls = append(calls, call)
+	})
+	parser.SetProfileLabels(true)
+
+	parser.ParseFile(path)
+	parser.ParseTreeFromFile(path)
+	parser.ParseTreeFromFile(filepath.Join(dir, "missing.ts"))
+	parser.Parse([]byte("x;"))
+	parser.Parse(nil)
+
+	want := "a.ts|a.ts|missing.ts error|.|. error"
+	if got := strings.Join(calls, "|"); got != want {
+		t.Errorf("hook calls = %s, want %s", got, want)
+	}
+}
+
+func TestTreeMemoryFootprint(t *testing.T) {
+	parser, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer parser.Close()
+
+	source := largeSource(t, 32<<10)
+	tree, err := parser.ParseTree(source)
+	if err != nil {
+		t.Fatalf("ParseTree() error = %v", err)
+	}
+	size := tree.MemoryFootprint()
+	if nodes := tree.Stats().Nodes; size < len(source)+nodes*int(unsafe.Sizeof(ast.BaseNode{})) {
+		t.Errorf("MemoryFootprint() = %d, less than the source and %d nodes", size, nodes)
+	}
+	if root := ast.SizeOf(tree.Root); root >= size {
+		t.Errorf("SizeOf(Root) = %d, not less than MemoryFootprint() = %d", root, size)
+	}
+
+	// Lazy trees retain the nodes converted so far only
+	parser.SetLazy(true)
+	lazy, err := parser.ParseTree(source)
+	if err != nil {
+		t.Fatalf("ParseTree() error = %v", err)
+	}
+	if n := lazy.MemoryFootprint(); n >= size {
+		t.Errorf("MemoryFootprint() of a lazy tree = %d, want less than %d", n, size)
+	}
+}
@@ -0,0 +1,482 @@
+// Package stub generates stub implementations of TypeScript interfaces
+// and abstract classes, for test scaffolding tools. Given
+//
+//	interface Store<T> {
+//	  readonly size: number;
+//	  get(key: string): Promise<T | undefined>;
+//	  clear(): void;
+//	}
+//
+// Generate returns
+//
+//	export class StoreStub<T> implements Store<T> {
+//	  readonly size: number = 0;
+//	  get(key: string): Promise<T | undefined> {
+//	    return Promise.resolve(undefined);
+//	  }
+//	  clear(): void {}
+//	}
+//
+// Stubs implement the members of the interface and of the interfaces it
+// extends, or the abstract members of the class and of the abstract
+// classes it extends. Supertypes are only followed when they are declared
+// in the same file, with their type parameters replaced by the type
+// arguments of the extends clause. The bodies of the stub methods and the
+// initial values of its properties are chosen by Config.Body and
+// Config.Value; by default methods return, and properties hold, the zero
+// value of their type.
+package stub
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ahmadramadhannn/tsgoast/analyzer"
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+// MemberKind is the kind of a member to implement.
+type MemberKind string
+
+// Member kind constants.
+const (
+	MemberProperty MemberKind = "property"
+	MemberMethod   MemberKind = "method"
+	MemberGetter   MemberKind = "getter"
+	MemberSetter   MemberKind = "setter"
+)
+
+// Member is a member of the stubbed type.
+type Member struct {
+	Kind           MemberKind
+	Name           string // as written, e.g. "size" or "[Symbol.iterator]"
+	TypeParameters string // type parameters of a method, e.g. "<U>"
+	Parameters     string // parameters of a method or accessor, e.g. "(key: string)"
+	Modifier       string // accessibility modifier of an abstract member
+	IsOptional     bool
+	IsReadonly     bool
+
+	// Type is the type of a property or the return type of a method or
+	// getter, or nil if it is not declared.
+	Type *analyzer.TypeExpr
+}
+
+// Config controls stub generation.
+type Config struct {
+	// Name is the name of the stub class, the name of the stubbed type
+	// followed by "Stub" by default.
+	Name string
+
+	// Indent is the indentation unit, two spaces by default.
+	Indent string
+
+	// NoExport leaves the stub class unexported.
+	NoExport bool
+
+	// Body returns the statements of the body of a method or accessor,
+	// ReturnZero by default. An empty body is printed as {}.
+	Body func(m Member) string
+
+	// Value returns the initializer of a property, or "" to leave it
+	// uninitialized; by default the zero value of its type for required
+	// properties.
+	Value func(m Member) string
+}
+
+// Generate returns a stub class implementing the interface or abstract
+// class declared by decl.
+func Generate(decl ast.Node) (string, error) {
+	return (&Config{}).Generate(decl)
+}
+
+// Generate returns a stub class implementing the interface or abstract
+// class declared by decl.
+func (c *Config) Generate(decl ast.Node) (string, error) {
+	if decl != nil && decl.SyntaxKind() == "export_statement" {
+		decl = ast.ChildByField(decl, "declaration")
+	}
+	if decl == nil {
+		return "", fmt.Errorf("stub: no declaration")
+	}
+	keyword := "implements"
+	switch decl.SyntaxKind() {
+	case "interface_declaration":
+	case "abstract_class_declaration":
+		keyword = "extends"
+	default:
+		return "", fmt.Errorf("stub: %s is not an interface or abstract class", decl.SyntaxKind())
+	}
+	name := ast.ChildByField(decl, "name").Text()
+
+	var a *analyzer.Analyzer
+	root := decl
+	for root.Parent() != nil {
+		root = root.Parent()
+	}
+	if base, ok := root.(*ast.BaseNode); ok {
+		a = analyzer.New(base)
+	}
+	members := collect(a, decl, nil, make(map[string]bool), nil)
+
+	indent := c.Indent
+	if indent == "" {
+		indent = "  "
+	}
+	body, value := c.Body, c.Value
+	if body == nil {
+		body = ReturnZero
+	}
+	if value == nil {
+		value = func(m Member) string {
+			if m.IsOptional {
+				return ""
+			}
+			return ZeroValue(m.Type)
+		}
+	}
+
+	var b strings.Builder
+	if !c.NoExport {
+		b.WriteString("export ")
+	}
+	stubName := c.Name
+	if stubName == "" {
+		stubName = name + "Stub"
+	}
+	fmt.Fprintf(&b, "class %s", stubName)
+	params, args := "", ""
+	if tp := ast.ChildByField(decl, "type_parameters"); tp != nil {
+		params = tp.Text()
+		var names []string
+		for _, p := range ast.ChildrenOfKind(tp, "type_parameter") {
+			names = append(names, ast.ChildByField(p, "name").Text())
+		}
+		args = "<" + strings.Join(names, ", ") + ">"
+	}
+	fmt.Fprintf(&b, "%s %s %s%s {\n", params, keyword, name, args)
+	for _, m := range members {
+		b.WriteString(indent)
+		if m.Modifier != "" {
+			b.WriteString(m.Modifier + " ")
+		}
+		if m.Kind == MemberProperty {
+			if m.IsReadonly {
+				b.WriteString("readonly ")
+			}
+			b.WriteString(m.Name)
+			if m.IsOptional {
+				b.WriteString("?")
+			}
+			if m.Type != nil {
+				b.WriteString(": " + m.Type.Text)
+			}
+			if v := value(m); v != "" {
+				b.WriteString(" = " + v)
+			}
+			b.WriteString(";\n")
+			continue
+		}
+
+		switch m.Kind {
+		case MemberGetter:
+			b.WriteString("get ")
+		case MemberSetter:
+			b.WriteString("set ")
+		}
+		b.WriteString(m.Name + m.TypeParameters + m.Parameters)
+		if m.Type != nil {
+			b.WriteString(": " + m.Type.Text)
+		}
+		stmts := strings.TrimSpace(body(m))
+		if stmts == "" {
+			b.WriteString(" {}\n")
+			continue
+		}
+		b.WriteString(" {\n")
+		for _, line := range strings.Split(stmts, "\n") {
+			b.WriteString(indent + indent + line + "\n")
+		}
+		b.WriteString(indent + "}\n")
+	}
+	b.WriteString("}\n")
+	return b.String(), nil
+}
+
+// collect appends to members the members of decl to implement, and those
+// of its supertypes declared in the same file, skipping the names in seen.
+// Type parameters of decl are replaced by their bindings.
+func collect(a *analyzer.Analyzer, decl ast.Node, bindings map[string]*analyzer.TypeExpr, seen map[string]bool, members []Member) []Member {
+	body := ast.ChildByField(decl, "body")
+	if body == nil {
+		return members
+	}
+	for _, child := range body.Children() {
+		m, abstract, ok := newMember(child, bindings)
+		if !ok {
+			continue
+		}
+		key := m.Name
+		if m.Kind == MemberGetter || m.Kind == MemberSetter {
+			key = string(m.Kind) + " " + m.Name
+		}
+		if seen[key] || seen[m.Name] {
+			continue
+		}
+		seen[key] = true
+		if abstract || decl.SyntaxKind() == "interface_declaration" {
+			members = append(members, m)
+		}
+	}
+
+	if a == nil {
+		return members
+	}
+	heritage := a.ResolveHeritage(decl)
+	for _, ref := range heritage.Extends {
+		base := ref.Declaration
+		if base == nil || base.SyntaxKind() != decl.SyntaxKind() {
+			continue
+		}
+		var baseBindings map[string]*analyzer.TypeExpr
+		if tp := ast.ChildByField(base, "type_parameters"); tp != nil {
+			baseBindings = make(map[string]*analyzer.TypeExpr)
+			for i, p := range ast.ChildrenOfKind(tp, "type_parameter") {
+				arg := &analyzer.TypeExpr{Kind: analyzer.TypeKindPrimitive, Name: "unknown", Text: "unknown"}
+				if i < len(ref.TypeArguments) {
+					arg = substitute(ref.TypeArguments[i], bindings)
+				} else if def := ast.ChildByField(p, "value"); def != nil {
+					// The default type follows the "=" token
+					t := ast.FirstChild(def, func(n ast.Node) bool { return n.SyntaxKind() != "=" })
+					arg = substitute(analyzer.ParseTypeExpr(t), bindings)
+				}
+				baseBindings[ast.ChildByField(p, "name").Text()] = arg
+			}
+		}
+		members = collect(a, base, baseBindings, seen, members)
+	}
+	return members
+}
+
+// newMember returns the member declared by a member of an interface or
+// class body, and whether it is abstract.
+func newMember(node ast.Node, bindings map[string]*analyzer.TypeExpr) (m Member, abstract, ok bool) {
+	switch node.SyntaxKind() {
+	case "property_signature", "public_field_definition":
+		m.Kind = MemberProperty
+		m.Type = analyzer.ParseTypeExpr(ast.ChildByField(node, "type"))
+	case "method_signature", "abstract_method_signature", "method_definition":
+		m.Kind = MemberMethod
+		if p := ast.ChildByField(node, "parameters"); p != nil {
+			m.Parameters = substituteText(p.Text(), bindings)
+		}
+		if tp := ast.ChildByField(node, "type_parameters"); tp != nil {
+			m.TypeParameters = tp.Text()
+		}
+		m.Type = analyzer.ParseTypeExpr(ast.ChildByField(node, "return_type"))
+	default:
+		return m, false, false
+	}
+	name := ast.ChildByField(node, "name")
+	if name == nil {
+		return m, false, false
+	}
+	m.Name = name.Text()
+	m.Type = substitute(m.Type, bindings)
+
+	for _, child := range node.Children() {
+		switch child.SyntaxKind() {
+		case "?":
+			m.IsOptional = true
+		case "readonly":
+			m.IsReadonly = true
+		case "abstract":
+			abstract = true
+		case "get":
+			m.Kind = MemberGetter
+		case "set":
+			m.Kind = MemberSetter
+		case "accessibility_modifier":
+			m.Modifier = child.Text()
+		}
+	}
+	if m.Kind == MemberMethod || m.Kind == MemberGetter {
+		// Optional methods are implemented like required ones
+		m.IsOptional = false
+	}
+	return m, abstract, true
+}
+
+// substitute returns t with the type parameters in bindings replaced.
+func substitute(t *analyzer.TypeExpr, bindings map[string]*analyzer.TypeExpr) *analyzer.TypeExpr {
+	if t == nil || len(bindings) == 0 {
+		return t
+	}
+	if arg, ok := bindings[t.Name]; ok && t.Kind == analyzer.TypeKindReference && len(t.TypeArguments) == 0 {
+		return arg
+	}
+	s := *t
+	s.Text = substituteText(t.Text, bindings)
+	s.TypeArguments = substituteAll(t.TypeArguments, bindings)
+	s.Types = substituteAll(t.Types, bindings)
+	s.Element = substitute(t.Element, bindings)
+	s.ReturnType = substitute(t.ReturnType, bindings)
+	if t.Members != nil {
+		s.Members = make([]*analyzer.TypeMember, len(t.Members))
+		for i, m := range t.Members {
+			member := *m
+			member.Type = substitute(m.Type, bindings)
+			s.Members[i] = &member
+		}
+	}
+	if t.Parameters != nil {
+		s.Parameters = make([]*ast.Parameter, len(t.Parameters))
+		for i, p := range t.Parameters {
+			param := *p
+			param.Type = substituteText(p.Type, bindings)
+			s.Parameters[i] = &param
+		}
+	}
+	return &s
+}
+
+func substituteAll(types []*analyzer.TypeExpr, bindings map[string]*analyzer.TypeExpr) []*analyzer.TypeExpr {
+	if types == nil {
+		return nil
+	}
+	out := make([]*analyzer.TypeExpr, len(types))
+	for i, t := range types {
+		out[i] = substitute(t, bindings)
+	}
+	return out
+}
+
+// substituteText replaces the identifiers of text naming type parameters
+// in bindings with the text of their bindings. Identifiers following a dot
+// or followed by a colon, which name properties, are kept.
+func substituteText(text string, bindings map[string]*analyzer.TypeExpr) string {
+	if len(bindings) == 0 {
+		return text
+	}
+	var b strings.Builder
+	for i := 0; i < len(text); {
+		if !isIdentStart(text[i]) {
+			b.WriteByte(text[i])
+			i++
+			continue
+		}
+		j := i + 1
+		for j < len(text) && (isIdentStart(text[j]) || text[j] >= '0' && text[j] <= '9') {
+			j++
+		}
+		word := text[i:j]
+		rest := strings.TrimLeft(text[j:], " \t?")
+		if arg, ok := bindings[word]; ok && (i == 0 || text[i-1] != '.') && !strings.HasPrefix(rest, ":") {
+			word = arg.Text
+		}
+		b.WriteString(word)
+		i = j
+	}
+	return b.String()
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || c == '$' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// ReturnZero returns a body returning the zero value of the return type of
+// a method or getter. The bodies of setters and of methods returning void
+// are empty.
+func ReturnZero(m Member) string {
+	if m.Kind == MemberSetter || m.Type == nil || m.Type.Name == "void" && m.Type.Kind == analyzer.TypeKindPrimitive {
+		return ""
+	}
+	return "return " + ZeroValue(m.Type) + ";"
+}
+
+// ThrowNotImplemented returns a body throwing an error naming the member.
+func ThrowNotImplemented(m Member) string {
+	return fmt.Sprintf("throw new Error(%q);", "not implemented: "+m.Name)
+}
+
+// ZeroValue returns an expression of type t that a stub can return: an
+// empty string, zero, false, an empty collection, a resolved promise, or
+// for other types an empty object asserted to the type.
+func ZeroValue(t *analyzer.TypeExpr) string {
+	if t == nil {
+		return "undefined"
+	}
+	switch t.Kind {
+	case analyzer.TypeKindPrimitive:
+		switch t.Name {
+		case "string":
+			return `""`
+		case "number":
+			return "0"
+		case "boolean":
+			return "false"
+		case "symbol":
+			return "Symbol()"
+		case "object":
+			return "{}"
+		case "null":
+			return "null"
+		case "never":
+			return "undefined as never"
+		}
+		return "undefined"
+	case analyzer.TypeKindLiteral:
+		if strings.HasPrefix(t.Text, "`") {
+			return `"" as ` + t.Text
+		}
+		return t.Text
+	case analyzer.TypeKindUnion:
+		for _, member := range t.Types {
+			if member.Text == "undefined" || member.Text == "void" {
+				return "undefined"
+			}
+		}
+		for _, member := range t.Types {
+			if member.Text == "null" {
+				return "null"
+			}
+		}
+		if len(t.Types) > 0 {
+			return ZeroValue(t.Types[0])
+		}
+	case analyzer.TypeKindArray:
+		return "[]"
+	case analyzer.TypeKindTuple:
+		var elements []string
+		for _, element := range t.Types {
+			if !element.IsOptional {
+				elements = append(elements, ZeroValue(element))
+			}
+		}
+		return "[" + strings.Join(elements, ", ") + "]"
+	case analyzer.TypeKindFunction:
+		result := ZeroValue(t.ReturnType)
+		if strings.HasPrefix(result, "{") {
+			result = "(" + result + ")"
+		}
+		return "() => " + result
+	case analyzer.TypeKindReference:
+		switch t.Name {
+		case "Promise":
+			if len(t.TypeArguments) == 1 {
+				if value := ZeroValue(t.TypeArguments[0]); value != "undefined" {
+					return "Promise.resolve(" + value + ")"
+				}
+			}
+			return "Promise.resolve(undefined)"
+		case "Array", "ReadonlyArray":
+			return "[]"
+		case "Map", "Set", "WeakMap", "WeakSet":
+			return "new " + t.Text + "()"
+		case "Date":
+			return "new Date(0)"
+		case "bigint":
+			return "0n" // parsed as a type identifier
+		}
+	}
+	return "{} as " + t.Text
+}
@@ -0,0 +1,160 @@
+package stub
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ahmadramadhannn/tsgoast"
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+// declaration parses src and returns the declaration of name.
+func declaration(t *testing.T, src, name string) ast.Node {
+	t.Helper()
+	parser, err := tsgoast.New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+	defer parser.Close()
+	root, err := parser.Parse([]byte(src))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	for node := range ast.Preorder(root) {
+		switch node.SyntaxKind() {
+		case "interface_declaration", "abstract_class_declaration", "class_declaration":
+			if ast.ChildByField(node, "name").Text() == name {
+				return node
+			}
+		}
+	}
+	t.Fatalf("%s is not declared", name)
+	return nil
+}
+
+func TestGenerateInterface(t *testing.T) {
+	decl := declaration(t, `
+interface Named {
+  name: string;
+  readonly id: number;
+}
+
+interface Store<T> extends Named {
+  size: number;
+  tags?: string[];
+  get(key: string): Promise<T | undefined>;
+  all(): Promise<T[]>;
+  clear(): void;
+  map<U>(fn: (value: T) => U): Map<string, U>;
+  get ready(): boolean;
+  set limit(value: number);
+}
+
+interface UserStore extends Store<User> {
+  owner: User;
+}
+`, "UserStore")
+
+	want := `export class UserStoreStub implements UserStore {
+  owner: User = {} as User;
+  size: number = 0;
+  tags?: string[];
+  get(key: string): Promise<User | undefined> {
+    return Promise.resolve(undefined);
+  }
+  all(): Promise<User[]> {
+    return Promise.resolve([]);
+  }
+  clear(): void {}
+  map<U>(fn: (value: User) => U): Map<string, U> {
+    return new Map<string, U>();
+  }
+  get ready(): boolean {
+    return false;
+  }
+  set limit(value: number) {}
+  name: string = "";
+  readonly id: number = 0;
+}
+`
+	got, err := Generate(decl)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if got != want {
+		t.Errorf("Generate() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestGenerateAbstractClass(t *testing.T) {
+	decl := declaration(t, `
+abstract class Shape<T, R = string> {
+  abstract name(): R;
+  abstract area(): number;
+  abstract scale(by: T): this;
+  describe(): string { return "shape"; }
+}
+
+abstract class Polygon extends Shape<number> {
+  protected abstract sides: number;
+  area(): number { return 0; }
+  abstract get label(): string;
+}
+`, "Polygon")
+
+	c := &Config{Name: "FakePolygon", Indent: "    ", NoExport: true, Body: ThrowNotImplemented}
+	want := `class FakePolygon extends Polygon {
+    protected sides: number = 0;
+    get label(): string {
+        throw new Error("not implemented: label");
+    }
+    name(): string {
+        throw new Error("not implemented: name");
+    }
+    scale(by: number): this {
+        throw new Error("not implemented: scale");
+    }
+}
+`
+	got, err := c.Generate(decl)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if got != want {
+		t.Errorf("Generate() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	decl := declaration(t, "class Concrete {}", "Concrete")
+	if _, err := Generate(decl); err == nil || !strings.Contains(err.Error(), "not an interface or abstract class") {
+		t.Errorf("Generate() error = %v", err)
+	}
+}
+
+func TestZeroValue(t *testing.T) {
+	tests := []struct {
+		typ  string
+		want string
+	}{
+		{"string", `""`},
+		{"bigint", "0n"},
+		{`"on" | "off"`, `"on"`},
+		{"string | null", "null"},
+		{"[number, string?]", "[0]"},
+		{"() => { a: 1 }", "() => ({} as { a: 1 })"},
+		{"Date", "new Date(0)"},
+		{"Promise<void>", "Promise.resolve(undefined)"},
+		{"keyof User", "{} as keyof User"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.typ, func(t *testing.T) {
+			decl := declaration(t, "interface X { x: "+tt.typ+" }", "X")
+			members := collect(nil, decl, nil, make(map[string]bool), nil)
+			if got := ZeroValue(members[0].Type); got != tt.want {
+				t.Errorf("ZeroValue(%s) = %s, want %s", tt.typ, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package transform
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/ahmadramadhannn/tsgoast"
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+// ImportRule rewrites a module specifier imported by the file at file. It
+// returns the new specifier, and false to leave the specifier unchanged.
+type ImportRule func(file, specifier string) (string, bool)
+
+// RewriteImports rewrites the module specifiers of the static imports,
+// re-exports and dynamic import() calls of tree, the syntax tree of the
+// file at file. Each specifier is passed through the rules in order, each
+// rule seeing the result of the previous ones. Dynamic imports of computed
+// specifiers are left alone.
+//
+// RewriteImports returns the number of specifiers changed. Print the tree
+// with the printer package to get the rewritten source.
+func RewriteImports(tree *tsgoast.Tree, file string, rules ...ImportRule) int {
+	count := 0
+	Apply(tree, func(c *Cursor) {
+		node := c.Node()
+		if node.SyntaxKind() != "string" || !isModuleSpecifier(node) {
+			return
+		}
+		text := node.Text()
+		if len(text) < 2 {
+			return
+		}
+		specifier := text[1 : len(text)-1]
+		rewritten := specifier
+		for _, rule := range rules {
+			if s, ok := rule(file, rewritten); ok {
+				rewritten = s
+			}
+		}
+		if rewritten == specifier {
+			return
+		}
+		quote := text[:1]
+		c.Replace(&ast.BaseNode{
+			NodeType:       ast.NodeTypeLiteral,
+			TreeSitterKind: "string",
+			Content:        quote + rewritten + quote,
+		})
+		c.SkipChildren()
+		count++
+	})
+	return count
+}
+
+// isModuleSpecifier reports whether the string node is the source of an
+// import or export statement, or the argument of a dynamic import().
+func isModuleSpecifier(node ast.Node) bool {
+	parent := node.Parent()
+	if parent == nil {
+		return false
+	}
+	switch parent.SyntaxKind() {
+	case "import_statement", "export_statement":
+		return ast.ChildByField(parent, "source") == node
+	case "arguments":
+		call := parent.Parent()
+		if call == nil || call.SyntaxKind() != "call_expression" {
+			return false
+		}
+		fn := ast.ChildByField(call, "function")
+		args := ast.Children(parent, func(n ast.Node) bool { return n.SyntaxKind() == "string" })
+		return fn != nil && fn.SyntaxKind() == "import" && len(args) > 0 && args[0] == node
+	}
+	return false
+}
+
+// MapPrefix returns a rule replacing the prefix from of specifiers with to,
+// such as "lodash/" with "lodash-es/".
+func MapPrefix(from, to string) ImportRule {
+	return func(_, specifier string) (string, bool) {
+		if rest, ok := strings.CutPrefix(specifier, from); ok {
+			return to + rest, true
+		}
+		return specifier, false
+	}
+}
+
+// AliasToRelative returns a rule turning specifiers under a path alias,
+// such as "@/components/button" for the alias "@" mapped to dir "src",
+// into paths relative to the importing file. dir is resolved against the
+// same directory as the file names passed to RewriteImports.
+func AliasToRelative(alias, dir string) ImportRule {
+	alias = strings.TrimSuffix(alias, "/")
+	return func(file, specifier string) (string, bool) {
+		rest, ok := strings.CutPrefix(specifier, alias)
+		if !ok || rest != "" && rest[0] != '/' {
+			return specifier, false
+		}
+		target := filepath.Join(dir, filepath.FromSlash(rest))
+		rel, err := filepath.Rel(filepath.Dir(file), target)
+		if err != nil {
+			return specifier, false
+		}
+		rel = filepath.ToSlash(rel)
+		if !isRelative(rel) {
+			rel = "./" + rel
+		}
+		return rel, true
+	}
+}
+
+// AddExtension returns a rule adding ext, such as ".js", to relative
+// specifiers without an extension, as ECMAScript modules require.
+func AddExtension(ext string) ImportRule {
+	return func(_, specifier string) (string, bool) {
+		if !isRelative(specifier) || path.Ext(specifier) != "" || strings.HasSuffix(specifier, "/") {
+			return specifier, false
+		}
+		return specifier + ext, true
+	}
+}
+
+// RemoveExtension returns a rule removing ext from relative specifiers
+// ending in it.
+func RemoveExtension(ext string) ImportRule {
+	return func(_, specifier string) (string, bool) {
+		if !isRelative(specifier) || path.Ext(specifier) != ext {
+			return specifier, false
+		}
+		return strings.TrimSuffix(specifier, ext), true
+	}
+}
+
+// isRelative reports whether specifier is a relative module path.
+func isRelative(specifier string) bool {
+	return specifier == "." || specifier == ".." ||
+		strings.HasPrefix(specifier, "./") || strings.HasPrefix(specifier, "../")
+}
@@ -0,0 +1,64 @@
+package transform
+
+import (
+	"testing"
+
+	"github.com/ahmadramadhannn/tsgoast/printer"
+)
+
+func TestRewriteImports(t *testing.T) {
+	src := "import { a } from \"@/lib/a\";\n" +
+		"import b from './b.ts';\n" +
+		"export * from \"../c\";\n" +
+		"export { d } from \"lodash/d\";\n" +
+		"const e = await import(\"./e\");\n" +
+		"const f = await import(name);\n" +
+		"import \"./styles.css\";\n"
+	tree := parseTree(t, src)
+
+	n := RewriteImports(tree, "src/pages/home.ts",
+		AliasToRelative("@", "src"),
+		MapPrefix("lodash/", "lodash-es/"),
+		RemoveExtension(".ts"),
+		AddExtension(".js"),
+	)
+
+	want := "import { a } from \"../lib/a.js\";\n" +
+		"import b from './b.js';\n" +
+		"export * from \"../c.js\";\n" +
+		"export { d } from \"lodash-es/d\";\n" +
+		"const e = await import(\"./e.js\");\n" +
+		"const f = await import(name);\n" +
+		"import \"./styles.css\";\n"
+	if got := printer.String(tree.Root); got != want {
+		t.Errorf("rewritten source =\n%s\nwant\n%s", got, want)
+	}
+	if n != 5 {
+		t.Errorf("RewriteImports() = %d, want 5", n)
+	}
+}
+
+func TestImportRules(t *testing.T) {
+	tests := []struct {
+		name      string
+		rule      ImportRule
+		file      string
+		specifier string
+		want      string
+	}{
+		{"alias same directory", AliasToRelative("~/", "src"), "src/main.ts", "~/util", "./util"},
+		{"alias directory itself", AliasToRelative("~", "src"), "src/main.ts", "~", "."},
+		{"alias prefix only", AliasToRelative("@", "src"), "src/main.ts", "@scope/pkg", "@scope/pkg"},
+		{"extension kept", AddExtension(".js"), "a.ts", "./data.json", "./data.json"},
+		{"bare specifier", AddExtension(".js"), "a.ts", "react", "react"},
+		{"remove other extension", RemoveExtension(".js"), "a.ts", "./x.mjs", "./x.mjs"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, _ := tt.rule(tt.file, tt.specifier); got != tt.want {
+				t.Errorf("rule(%q, %q) = %q, want %q", tt.file, tt.specifier, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,372 @@
+package transform
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/ahmadramadhannn/tsgoast"
+	"github.com/ahmadramadhannn/tsgoast/analyzer"
+	"github.com/ahmadramadhannn/tsgoast/ast"
+	"github.com/ahmadramadhannn/tsgoast/edits"
+	"github.com/ahmadramadhannn/tsgoast/internal/srctext"
+)
+
+// DefaultInstrumentTemplate is the default template of Instrument, logging
+// the time each call takes.
+const DefaultInstrumentTemplate = `const __start = performance.now();
+try {
+	{{body}}
+} finally {
+	console.debug(` + "`{{name}} took ${performance.now() - __start}ms`" + `);
+}`
+
+// Instrumentation configures Instrument.
+type Instrumentation struct {
+	// Names holds path.Match patterns selecting functions by name. Methods
+	// and class fields holding functions are named after their class, as
+	// in "UserService.find", so "*Service.*" selects every method of the
+	// services.
+	Names []string
+
+	// Decorators selects the methods and fields decorated with one of
+	// these decorators, such as "Trace" or "Get".
+	Decorators []string
+
+	// Template is the code wrapping the body of the functions;
+	// DefaultInstrumentTemplate by default. The line holding {{body}} is
+	// replaced with the statements of the body, and {{name}} with the name
+	// of the function. Template lines are indented with tabs, which are
+	// written with the indentation of the file.
+	Template string
+
+	// Imports are added to the file if a function is instrumented, for
+	// the code of the template.
+	Imports []ImportSpec
+}
+
+// Instrument returns the edits wrapping the body of the functions selected
+// by config with its template, for tracing or timing them. Function
+// declarations, function expressions and arrow functions assigned to a
+// variable or a class field, and methods are instrumented; constructors
+// are not, as their super call must come first. An arrow function with an
+// expression body is given a block body returning the expression.
+//
+// Functions whose body starts with the first line of the template are
+// instrumented already and are skipped, so instrumenting a file twice
+// changes nothing. Multi-line template literals keep their text.
+func Instrument(tree *tsgoast.Tree, config *Instrumentation) ([]edits.Edit, error) {
+	if tree == nil || tree.Root == nil {
+		return nil, fmt.Errorf("instrument: tree has no root node")
+	}
+	if config == nil || len(config.Names) == 0 && len(config.Decorators) == 0 {
+		return nil, fmt.Errorf("instrument: no names or decorators select the functions to instrument")
+	}
+	for _, pattern := range config.Names {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("instrument: name pattern %q: %w", pattern, err)
+		}
+	}
+	template := config.Template
+	if template == "" {
+		template = DefaultInstrumentTemplate
+	}
+	lines := strings.Split(strings.Trim(template, "\n"), "\n")
+	bodyLines := 0
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "{{body}}" {
+			bodyLines++
+		}
+	}
+	if bodyLines != 1 {
+		return nil, fmt.Errorf("instrument: template must hold {{body}} on a line of its own once")
+	}
+
+	text, base := srctext.RootText(tree.Root)
+	in := &instrumenter{
+		text:      text,
+		base:      base,
+		unit:      indentUnit(text),
+		semicolon: usesSemicolons(tree.Root),
+		template:  lines,
+		names:     make(map[ast.Node]string),
+		nested:    make(map[ast.Node][]ast.Node),
+	}
+
+	// Select the functions, then group the ones nested in others, whose
+	// bodies are rewritten with the body of the outer function
+	var selected []ast.Node
+	for node := range ast.Preorder(tree.Root) {
+		name, ok := functionName(node)
+		if !ok || !config.selects(node, name) || in.instrumented(node, name) {
+			continue
+		}
+		in.names[node] = name
+		selected = append(selected, node)
+	}
+	for node := range ast.Preorder(tree.Root) {
+		if node.SyntaxKind() == "template_string" && strings.Contains(node.Text(), "\n") {
+			in.literals = append(in.literals, node.Range())
+		}
+	}
+	var outer []ast.Node
+	for _, fn := range selected {
+		parent := fn.Parent()
+		for parent != nil && in.names[parent] == "" {
+			parent = parent.Parent()
+		}
+		if parent == nil {
+			outer = append(outer, fn)
+		} else {
+			in.nested[parent] = append(in.nested[parent], fn)
+		}
+	}
+	if len(outer) == 0 {
+		return nil, nil
+	}
+
+	var result []edits.Edit
+	for _, fn := range outer {
+		result = append(result, edits.Replace(ast.ChildByField(fn, "body"), in.wrap(fn, "")))
+	}
+	for _, spec := range config.Imports {
+		e, err := InsertImport(tree, spec)
+		if err != nil {
+			return nil, fmt.Errorf("instrument: %w", err)
+		}
+		result = appendInsertions(result, e)
+	}
+	return result, nil
+}
+
+// functionName returns the name of a function to instrument, and whether
+// node is one.
+func functionName(node ast.Node) (string, bool) {
+	body := ast.ChildByField(node, "body")
+	if body == nil {
+		return "", false
+	}
+	name := ast.ChildByField(node, "name")
+	switch node.SyntaxKind() {
+	case "function_declaration", "generator_function_declaration":
+	case "method_definition":
+		if name == nil || name.Text() == "constructor" {
+			return "", false
+		}
+		return qualifiedName(node, name.Text()), true
+	case "function_expression", "generator_function", "arrow_function":
+		switch parent := node.Parent(); {
+		case parent == nil:
+		case parent.SyntaxKind() == "variable_declarator":
+			name = ast.ChildByField(parent, "name")
+		case parent.SyntaxKind() == "public_field_definition":
+			if field := ast.ChildByField(parent, "name"); field != nil {
+				return qualifiedName(parent, field.Text()), true
+			}
+		}
+	default:
+		return "", false
+	}
+	if name == nil || name.SyntaxKind() != "identifier" {
+		return "", false
+	}
+	return name.Text(), true
+}
+
+// qualifiedName returns the name of a class member, prefixed with the name
+// of its class.
+func qualifiedName(member ast.Node, name string) string {
+	body := member.Parent()
+	if body == nil || body.SyntaxKind() != "class_body" || body.Parent() == nil {
+		return name
+	}
+	class := body.Parent()
+	className := ast.ChildByField(class, "name")
+	if className == nil && class.Parent() != nil && class.Parent().SyntaxKind() == "variable_declarator" {
+		// const Foo = class { ... }
+		className = ast.ChildByField(class.Parent(), "name")
+	}
+	if className == nil {
+		return name
+	}
+	return className.Text() + "." + name
+}
+
+// selects reports whether the function fn, named name, is selected.
+func (c *Instrumentation) selects(fn ast.Node, name string) bool {
+	for _, pattern := range c.Names {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	if len(c.Decorators) == 0 {
+		return false
+	}
+	target := fn
+	if fn.SyntaxKind() != "method_definition" {
+		if target = fn.Parent(); target == nil || target.SyntaxKind() != "public_field_definition" {
+			return false
+		}
+	}
+	for _, d := range analyzer.GetDecorators(target) {
+		if slices.Contains(c.Decorators, d.Name) || slices.Contains(c.Decorators, "@"+d.Name) {
+			return true
+		}
+	}
+	return false
+}
+
+// instrumenter rewrites the bodies of the selected functions.
+type instrumenter struct {
+	text      string
+	base      uint32
+	unit      string
+	semicolon bool
+	template  []string
+
+	names    map[ast.Node]string     // selected functions
+	nested   map[ast.Node][]ast.Node // selected functions in each selected function
+	literals []ast.Range             // multi-line template literals
+}
+
+// instrumented reports whether the body of fn starts with the first line
+// of the template already.
+func (in *instrumenter) instrumented(fn ast.Node, name string) bool {
+	body := ast.ChildByField(fn, "body")
+	if body.SyntaxKind() != "statement_block" {
+		return false
+	}
+	first := ast.FirstChild(body, func(n ast.Node) bool { return n.SyntaxKind() != "{" && n.SyntaxKind() != "}" })
+	if first == nil {
+		return false
+	}
+	line, _, _ := strings.Cut(first.Text(), "\n")
+	return strings.TrimSpace(line) == strings.TrimSpace(in.expand(in.template[0], name))
+}
+
+// expand returns a template line with the name of the function.
+func (in *instrumenter) expand(line, name string) string {
+	return strings.ReplaceAll(line, "{{name}}", name)
+}
+
+// templateIndent splits a template line into its indentation, written
+// with the indentation unit of the file, and its code.
+func (in *instrumenter) templateIndent(line string) (string, string) {
+	code := strings.TrimLeft(line, "\t")
+	return strings.Repeat(in.unit, len(line)-len(code)), code
+}
+
+// wrap returns the instrumented body of fn, a block. shift is the
+// indentation added to the lines of fn by the instrumentation of the
+// function containing it.
+func (in *instrumenter) wrap(fn ast.Node, shift string) string {
+	body := ast.ChildByField(fn, "body")
+	indent := shift + srctext.LineIndent(in.text, int(body.Range().Start.Offset-in.base))
+
+	var bodyIndent string
+	for _, line := range in.template {
+		if strings.TrimSpace(line) == "{{body}}" {
+			bodyIndent, _ = in.templateIndent(line)
+		}
+	}
+	var stmts string
+	if body.SyntaxKind() == "statement_block" {
+		var first, last ast.Node
+		for _, child := range body.Children() {
+			if kind := child.SyntaxKind(); kind != "{" && kind != "}" {
+				if first == nil {
+					first = child
+				}
+				last = child
+			}
+		}
+		if first != nil {
+			stmts = in.reindent(first.Range().Start.Offset, last.Range().End.Offset, shift+bodyIndent, in.nested[fn])
+		}
+	} else {
+		r := body.Range()
+		stmts = "return " + in.reindent(r.Start.Offset, r.End.Offset, shift+bodyIndent, in.nested[fn])
+		if in.semicolon {
+			stmts += ";"
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString("{")
+	for _, line := range in.template {
+		lineIndent, code := in.templateIndent(line)
+		switch {
+		case strings.TrimSpace(line) == "{{body}}":
+			if stmts != "" {
+				b.WriteString("\n" + indent + in.unit + lineIndent + stmts)
+			}
+		case strings.TrimSpace(line) == "":
+			b.WriteString("\n")
+		default:
+			b.WriteString("\n" + indent + in.unit + lineIndent + in.expand(code, in.names[fn]))
+		}
+	}
+	b.WriteString("\n" + indent + "}")
+	return b.String()
+}
+
+// reindent returns the source between the offsets start and end, with the
+// bodies of the nested functions instrumented, and prefix added to the
+// indentation of every line but the first.
+func (in *instrumenter) reindent(start, end uint32, prefix string, nested []ast.Node) string {
+	var b strings.Builder
+	pos := start
+	for _, fn := range nested {
+		r := ast.ChildByField(fn, "body").Range()
+		b.WriteString(in.indentLines(pos, r.Start.Offset, prefix))
+		b.WriteString(in.wrap(fn, prefix))
+		pos = r.End.Offset
+	}
+	b.WriteString(in.indentLines(pos, end, prefix))
+	return b.String()
+}
+
+// indentLines returns the source between the offsets start and end with
+// prefix added to the lines starting there, except blank lines and the
+// lines of template literals.
+func (in *instrumenter) indentLines(start, end uint32, prefix string) string {
+	text := in.text[start-in.base : end-in.base]
+	var b strings.Builder
+	for i := 0; i < len(text); i++ {
+		b.WriteByte(text[i])
+		if text[i] != '\n' || i+1 < len(text) && (text[i+1] == '\n' || text[i+1] == '\r') {
+			continue
+		}
+		offset := start + uint32(i) + 1
+		inLiteral := slices.ContainsFunc(in.literals, func(r ast.Range) bool {
+			return r.Start.Offset < offset && offset < r.End.Offset
+		})
+		if !inLiteral {
+			b.WriteString(prefix)
+		}
+	}
+	return b.String()
+}
+
+// appendInsertions appends the edits of insert to result, merging the
+// insertions at the same offset, as InsertImport returns for several
+// imports added to the same place.
+func appendInsertions(result, insert []edits.Edit) []edits.Edit {
+	for _, e := range insert {
+		i := slices.IndexFunc(result, func(r edits.Edit) bool {
+			return r.Range == e.Range && r.Range.Start.Offset == r.Range.End.Offset
+		})
+		if i < 0 {
+			result = append(result, e)
+			continue
+		}
+		prev := result[i].NewText
+		if strings.HasSuffix(prev, "\n\n") && strings.HasSuffix(e.NewText, "\n\n") {
+			// Imports added before the first statement
+			prev = strings.TrimSuffix(prev, "\n")
+		}
+		result[i].NewText = prev + e.NewText
+	}
+	return result
+}
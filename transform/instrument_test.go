@@ -0,0 +1,135 @@
+package transform
+
+import (
+	"testing"
+)
+
+func TestInstrument(t *testing.T) {
+	tests := []struct {
+		name   string
+		config *Instrumentation
+		src    string
+		want   string
+	}{
+		{
+			name:   "function by name",
+			config: &Instrumentation{Names: []string{"load*"}},
+			src: `function loadUser(id: string) {
+  const user = db.find(id);
+  return user;
+}
+
+function save() {}
+`,
+			want: "function loadUser(id: string) {\n" +
+				"  const __start = performance.now();\n" +
+				"  try {\n" +
+				"    const user = db.find(id);\n" +
+				"    return user;\n" +
+				"  } finally {\n" +
+				"    console.debug(`loadUser took ${performance.now() - __start}ms`);\n" +
+				"  }\n" +
+				"}\n\nfunction save() {}\n",
+		},
+		{
+			name: "methods by decorator with imports",
+			config: &Instrumentation{
+				Decorators: []string{"Trace"},
+				Template:   "return tracer.startActiveSpan(\"{{name}}\", () => {\n\t{{body}}\n});",
+				Imports:    []ImportSpec{{Source: "./tracing", Names: []string{"tracer", "Trace"}}},
+			},
+			src: `import { Injectable } from "./di";
+
+class UserService {
+	@Trace()
+	async find(id: string) {
+		return this.repo.find(id);
+	}
+
+	@Trace() handler = (e: Event) => e.type;
+
+	constructor() {}
+}
+`,
+			want: `import { Injectable } from "./di";
+import { tracer, Trace } from "./tracing";
+
+class UserService {
+	@Trace()
+	async find(id: string) {
+		return tracer.startActiveSpan("UserService.find", () => {
+			return this.repo.find(id);
+		});
+	}
+
+	@Trace() handler = (e: Event) => {
+		return tracer.startActiveSpan("UserService.handler", () => {
+			return e.type;
+		});
+	};
+
+	constructor() {}
+}
+`,
+		},
+		{
+			name: "nested functions and template literals",
+			config: &Instrumentation{
+				Names:    []string{"*"},
+				Template: "trace(\"{{name}}\")\n{{body}}",
+			},
+			src: "const outer = () => {\n" +
+				"  const inner = function () {\n" +
+				"    return `a\nb`\n" +
+				"  }\n" +
+				"  return inner\n" +
+				"}\n",
+			want: "const outer = () => {\n" +
+				"  trace(\"outer\")\n" +
+				"  const inner = function () {\n" +
+				"    trace(\"inner\")\n" +
+				"    return `a\nb`\n" +
+				"  }\n" +
+				"  return inner\n" +
+				"}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Instrument(parseTree(t, tt.src), tt.config)
+			if err != nil {
+				t.Fatalf("Instrument() error = %v", err)
+			}
+			got := applyEdits(t, tt.src, result...)
+			if got != tt.want {
+				t.Errorf("Instrument() =\n%s\nwant\n%s", got, tt.want)
+			}
+
+			// Instrumenting twice changes nothing
+			again, err := Instrument(parseTree(t, got), &Instrumentation{Names: tt.config.Names, Decorators: tt.config.Decorators, Template: tt.config.Template})
+			if err != nil {
+				t.Fatalf("Instrument() error = %v", err)
+			}
+			if len(again) != 0 {
+				t.Errorf("Instrument() of instrumented code = %v, want no edits", again)
+			}
+		})
+	}
+}
+
+func TestInstrumentErrors(t *testing.T) {
+	tree := parseTree(t, "function f() {}\n")
+	configs := []*Instrumentation{
+		nil,
+		{},
+		{Names: []string{"["}},
+		{Names: []string{"f"}, Template: "log()"},
+		{Names: []string{"f"}, Template: "{{body}}\n{{body}}"},
+	}
+	for _, config := range configs {
+		if _, err := Instrument(tree, config); err == nil {
+			t.Errorf("Instrument(%+v): want error", config)
+		}
+	}
+}
@@ -0,0 +1,346 @@
+package transform
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/ahmadramadhannn/tsgoast"
+	"github.com/ahmadramadhannn/tsgoast/analyzer"
+	"github.com/ahmadramadhannn/tsgoast/ast"
+	"github.com/ahmadramadhannn/tsgoast/edits"
+	"github.com/ahmadramadhannn/tsgoast/internal/srctext"
+)
+
+// ImportGroup is a group of import declarations, by the kind of module
+// they import.
+type ImportGroup string
+
+// Import group constants.
+const (
+	GroupBuiltin  ImportGroup = "builtin"  // Node.js built-in modules, such as "fs" or "node:path"
+	GroupExternal ImportGroup = "external" // packages
+	GroupInternal ImportGroup = "internal" // project modules imported by alias, see ImportOrder.Internal
+	GroupRelative ImportGroup = "relative" // relative paths
+)
+
+// DefaultImportGroups is the default order of import groups.
+var DefaultImportGroups = []ImportGroup{GroupBuiltin, GroupExternal, GroupInternal, GroupRelative}
+
+// ImportOrder configures OrganizeImports.
+type ImportOrder struct {
+	// Groups lists the import groups in the order they are written,
+	// separated by blank lines; DefaultImportGroups by default. Imports of
+	// the groups not listed are written last, in one group.
+	Groups []ImportGroup
+
+	// Internal holds the prefixes of the specifiers of internal modules,
+	// such as "@app/" or "~/".
+	Internal []string
+
+	// KeepUnused keeps the imports that are never used.
+	KeepUnused bool
+}
+
+// nodeBuiltins are the Node.js built-in modules, imported with or without
+// the node: prefix.
+var nodeBuiltins = map[string]bool{
+	"assert": true, "async_hooks": true, "buffer": true, "child_process": true,
+	"cluster": true, "console": true, "constants": true, "crypto": true,
+	"dgram": true, "diagnostics_channel": true, "dns": true, "domain": true,
+	"events": true, "fs": true, "http": true, "http2": true, "https": true,
+	"inspector": true, "module": true, "net": true, "os": true, "path": true,
+	"perf_hooks": true, "process": true, "punycode": true, "querystring": true,
+	"readline": true, "repl": true, "stream": true, "string_decoder": true,
+	"timers": true, "tls": true, "trace_events": true, "tty": true, "url": true,
+	"util": true, "v8": true, "vm": true, "wasi": true, "worker_threads": true,
+	"zlib": true,
+}
+
+// group returns the import group of a module specifier.
+func (o *ImportOrder) group(specifier string) ImportGroup {
+	if analyzer.ClassifySpecifier(specifier) == analyzer.ImportKindRelative {
+		return GroupRelative
+	}
+	module, _, _ := strings.Cut(specifier, "/")
+	if strings.HasPrefix(specifier, "node:") || nodeBuiltins[module] {
+		return GroupBuiltin
+	}
+	for _, prefix := range o.Internal {
+		if strings.HasPrefix(specifier, prefix) {
+			return GroupInternal
+		}
+	}
+	return GroupExternal
+}
+
+// OrganizeImports returns the edits organizing the import declarations at
+// the top level of tree: imports are sorted by module specifier into the
+// groups of order, the named imports of each declaration are sorted, the
+// declarations importing the same module are merged, and the bindings
+// that are never used are removed (see analyzer.FindUnusedImports). A nil
+// order uses the defaults.
+//
+// Side-effect imports such as import "./polyfill" stay in place, as the
+// order they run in may matter; the imports before and after them are
+// organized separately. Declarations with import attributes and import =
+// require declarations are sorted but not merged. OrganizeImports returns
+// no edits if the imports are organized already.
+func OrganizeImports(tree *tsgoast.Tree, order *ImportOrder) ([]edits.Edit, error) {
+	if tree == nil || tree.Root == nil {
+		return nil, fmt.Errorf("organize imports: tree has no root node")
+	}
+	if order == nil {
+		order = &ImportOrder{}
+	}
+	var imports []ast.Node
+	for _, stmt := range tree.Root.Children() {
+		if stmt.SyntaxKind() == "import_statement" {
+			imports = append(imports, stmt)
+		}
+	}
+	if len(imports) == 0 {
+		return nil, nil
+	}
+
+	unused := make(map[ast.Node]bool)
+	if !order.KeepUnused {
+		for _, u := range analyzer.New(tree.Root).FindUnusedImports() {
+			unused[u.Node] = true
+		}
+	}
+	quote := `"`
+	if source := ast.ChildByField(imports[0], "source"); source != nil && len(source.Text()) > 0 {
+		quote = source.Text()[:1]
+	}
+	semicolon := ""
+	if usesSemicolons(tree.Root) {
+		semicolon = ";"
+	}
+
+	// Organize the runs of imports between side-effect imports
+	var parts []string
+	var run []*importDecl
+	flush := func() {
+		if text := order.format(run, quote, semicolon); text != "" {
+			parts = append(parts, text)
+		}
+		run = nil
+	}
+	for _, imp := range imports {
+		if ast.FirstChildOfKind(imp, "import_clause") == nil && ast.FirstChildOfKind(imp, "import_require_clause") == nil {
+			flush()
+			parts = append(parts, imp.Text())
+			continue
+		}
+		if decl := newImportDecl(imp, unused); decl != nil {
+			run = append(run, decl)
+		}
+	}
+	flush()
+	text := strings.Join(parts, "\n")
+
+	first, last := imports[0], imports[len(imports)-1]
+	source, base := srctext.RootText(first)
+	start, end := first.Range().Start.Offset, last.Range().End.Offset
+	if text == source[start-base:end-base] {
+		return nil, nil
+	}
+	if text == "" {
+		var result []edits.Edit
+		for _, imp := range imports {
+			result = append(result, RemoveStatement(imp))
+		}
+		return result, nil
+	}
+
+	// Replace the imports at once if nothing else is between them, or else
+	// replace the first one and remove the others, keeping what is between
+	contiguous := true
+	between := false
+	for _, stmt := range tree.Root.Children() {
+		switch {
+		case stmt == first:
+			between = true
+		case stmt == last:
+			between = false
+		case between && stmt.SyntaxKind() != "import_statement":
+			contiguous = false
+		}
+	}
+	if contiguous {
+		return []edits.Edit{{Range: ast.Range{Start: first.Range().Start, End: last.Range().End}, NewText: text}}, nil
+	}
+	result := []edits.Edit{edits.Replace(first, text)}
+	for _, imp := range imports[1:] {
+		result = append(result, RemoveStatement(imp))
+	}
+	return result, nil
+}
+
+// importDecl is an import declaration being organized.
+type importDecl struct {
+	source     string
+	typeOnly   bool
+	defaults   []string
+	namespaces []string
+	names      []string
+
+	// raw is the text of a declaration that is not merged
+	raw string
+}
+
+// newImportDecl returns the declaration of imp without its unused
+// bindings, or nil if all of them are unused. Declarations with import
+// attributes are kept whole if any of their bindings is used.
+func newImportDecl(imp ast.Node, unused map[ast.Node]bool) *importDecl {
+	decl := &importDecl{typeOnly: ast.FirstChildOfKind(imp, "type") != nil}
+	if source := ast.ChildByField(imp, "source"); source != nil {
+		text := source.Text()
+		decl.source = text[1 : len(text)-1]
+	}
+	if clause := ast.FirstChildOfKind(imp, "import_require_clause"); clause != nil {
+		decl.raw = imp.Text()
+		if source := ast.FirstChildOfKind(clause, "string"); source != nil {
+			text := source.Text()
+			decl.source = text[1 : len(text)-1]
+		}
+		return decl
+	}
+
+	bindings := analyzer.ImportBindings(imp)
+	used := 0
+	for _, b := range bindings {
+		if unused[b.Node] {
+			continue
+		}
+		used++
+		switch b.Node.SyntaxKind() {
+		case "identifier":
+			decl.defaults = append(decl.defaults, b.Name)
+		case "namespace_import":
+			decl.namespaces = append(decl.namespaces, b.Name)
+		default:
+			decl.names = append(decl.names, strings.Join(strings.Fields(b.Node.Text()), " "))
+		}
+	}
+	if used == 0 {
+		return nil
+	}
+	if ast.FirstChildOfKind(imp, "import_attribute") != nil {
+		decl.raw = imp.Text()
+	}
+	return decl
+}
+
+// format returns the organized text of a run of import declarations.
+func (o *ImportOrder) format(decls []*importDecl, quote, semicolon string) string {
+	// Merge the declarations of each module
+	var merged []*importDecl
+	for _, decl := range decls {
+		i := slices.IndexFunc(merged, func(m *importDecl) bool {
+			return m.raw == "" && decl.raw == "" && m.source == decl.source && m.typeOnly == decl.typeOnly
+		})
+		if i < 0 {
+			copied := *decl
+			merged = append(merged, &copied)
+			continue
+		}
+		m := merged[i]
+		m.defaults = appendNew(m.defaults, decl.defaults...)
+		m.namespaces = appendNew(m.namespaces, decl.namespaces...)
+		m.names = appendNew(m.names, decl.names...)
+	}
+	slices.SortStableFunc(merged, func(a, b *importDecl) int {
+		if c := cmp.Compare(strings.ToLower(a.source), strings.ToLower(b.source)); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(a.source, b.source); c != 0 {
+			return c
+		}
+		if a.typeOnly != b.typeOnly {
+			return boolOrder(a.typeOnly)
+		}
+		return 0
+	})
+
+	groups := o.Groups
+	if len(groups) == 0 {
+		groups = DefaultImportGroups
+	}
+	blocks := make([][]string, len(groups)+1)
+	for _, decl := range merged {
+		i := slices.Index(groups, o.group(decl.source))
+		if i < 0 {
+			i = len(groups)
+		}
+		blocks[i] = append(blocks[i], decl.format(quote, semicolon)...)
+	}
+	var text []string
+	for _, block := range blocks {
+		if len(block) > 0 {
+			text = append(text, strings.Join(block, "\n"))
+		}
+	}
+	return strings.Join(text, "\n\n")
+}
+
+// format returns the text of the declarations importing the bindings of
+// decl. A module imported both with named and namespace imports takes two
+// declarations, as one cannot hold both.
+func (decl *importDecl) format(quote, semicolon string) []string {
+	if decl.raw != "" {
+		return []string{decl.raw}
+	}
+	slices.SortStableFunc(decl.names, func(a, b string) int {
+		a, b = strings.TrimPrefix(a, "type "), strings.TrimPrefix(b, "type ")
+		return cmp.Or(cmp.Compare(strings.ToLower(a), strings.ToLower(b)), cmp.Compare(a, b))
+	})
+
+	keyword := "import "
+	if decl.typeOnly {
+		keyword = "import type "
+	}
+	from := " from " + quote + decl.source + quote + semicolon
+	defaults := decl.defaults
+	var lines []string
+	if len(decl.names) > 0 {
+		clause := "{ " + strings.Join(decl.names, ", ") + " }"
+		if len(defaults) > 0 {
+			clause = defaults[0] + ", " + clause
+			defaults = defaults[1:]
+		}
+		lines = append(lines, keyword+clause+from)
+	}
+	for _, ns := range decl.namespaces {
+		clause := "* as " + ns
+		if len(defaults) > 0 {
+			clause = defaults[0] + ", " + clause
+			defaults = defaults[1:]
+		}
+		lines = append(lines, keyword+clause+from)
+	}
+	for _, d := range defaults {
+		lines = append(lines, keyword+d+from)
+	}
+	return lines
+}
+
+// appendNew appends the values that list does not hold yet.
+func appendNew(list []string, values ...string) []string {
+	for _, v := range values {
+		if !slices.Contains(list, v) {
+			list = append(list, v)
+		}
+	}
+	return list
+}
+
+// boolOrder orders false before true.
+func boolOrder(b bool) int {
+	if b {
+		return 1
+	}
+	return -1
+}
@@ -0,0 +1,123 @@
+package transform
+
+import (
+	"testing"
+)
+
+func TestOrganizeImports(t *testing.T) {
+	tests := []struct {
+		name  string
+		order *ImportOrder
+		src   string
+		want  string
+	}{
+		{
+			name: "groups and sorting",
+			src: `import { z, a } from "./local";
+import path from "node:path";
+import { Button } from "@app/ui";
+import React from "react";
+import { readFile } from "fs/promises";
+import lodash from "lodash";
+
+use(z, a, path, Button, React, readFile, lodash);
+`,
+			order: &ImportOrder{Internal: []string{"@app/"}},
+			want: `import { readFile } from "fs/promises";
+import path from "node:path";
+
+import lodash from "lodash";
+import React from "react";
+
+import { Button } from "@app/ui";
+
+import { a, z } from "./local";
+
+use(z, a, path, Button, React, readFile, lodash);
+`,
+		},
+		{
+			name: "merge and remove unused",
+			src: `import { b } from './util'
+import type { T } from './types'
+import def, { a, unused } from './util'
+import * as ns from './util'
+import type { U } from './types'
+import gone from 'gone'
+
+const x: T & U = def(a, b, ns)
+`,
+			want: `import type { T, U } from './types'
+import def, { a, b } from './util'
+import * as ns from './util'
+
+const x: T & U = def(a, b, ns)
+`,
+		},
+		{
+			name: "side effects split runs",
+			src: `import { b } from "b";
+import { a } from "a";
+import "./polyfill";
+import { d } from "d";
+import { c } from "c";
+f(a, b, c, d);
+`,
+			order: &ImportOrder{Groups: []ImportGroup{GroupRelative}},
+			want: `import { a } from "a";
+import { b } from "b";
+import "./polyfill";
+import { c } from "c";
+import { d } from "d";
+f(a, b, c, d);
+`,
+		},
+		{
+			name: "statements between imports",
+			src: `import { b } from "b";
+const x = 1;
+import { a } from "a";
+f(a, b, x);
+`,
+			want: `import { a } from "a";
+import { b } from "b";
+const x = 1;
+f(a, b, x);
+`,
+		},
+		{
+			name:  "keep unused",
+			order: &ImportOrder{KeepUnused: true},
+			src:   "import { b, a } from \"x\";\n",
+			want:  "import { a, b } from \"x\";\n",
+		},
+		{
+			name: "all unused",
+			src:  "import { a } from \"a\";\nimport b from \"b\";\n\nrun();\n",
+			want: "\nrun();\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := OrganizeImports(parseTree(t, tt.src), tt.order)
+			if err != nil {
+				t.Fatalf("OrganizeImports() error = %v", err)
+			}
+			if got := applyEdits(t, tt.src, result...); got != tt.want {
+				t.Errorf("OrganizeImports() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOrganizeImportsOrganized(t *testing.T) {
+	src := "import fs from \"fs\";\n\nimport { a } from \"./a\";\n\nfs.read(a);\n"
+	result, err := OrganizeImports(parseTree(t, src), nil)
+	if err != nil {
+		t.Fatalf("OrganizeImports() error = %v", err)
+	}
+	if len(result) != 0 {
+		t.Errorf("OrganizeImports() = %v, want no edits", result)
+	}
+}
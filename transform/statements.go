@@ -0,0 +1,274 @@
+package transform
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/ahmadramadhannn/tsgoast"
+	"github.com/ahmadramadhannn/tsgoast/ast"
+	"github.com/ahmadramadhannn/tsgoast/edits"
+	"github.com/ahmadramadhannn/tsgoast/internal/srctext"
+)
+
+// ImportSpec describes an import declaration to add with InsertImport.
+type ImportSpec struct {
+	Source    string   // module specifier, without quotes
+	Default   string   // name of the default import, if any
+	Namespace string   // name of a namespace import (* as Namespace), if any
+	Names     []string // named imports, such as "a" or "a as b"
+}
+
+// InsertImport returns the edits adding an import declaration to tree.
+//
+// Named imports from a module that the file already imports with named
+// imports are merged into that declaration, and names imported already
+// are skipped, so InsertImport returns no edits if there is nothing to
+// add. Other imports are added on a new line after the last import of the
+// file, or before its first statement. The new declaration uses the quotes
+// and semicolon style of the file.
+func InsertImport(tree *tsgoast.Tree, spec ImportSpec) ([]edits.Edit, error) {
+	if tree == nil || tree.Root == nil {
+		return nil, fmt.Errorf("insert import: tree has no root node")
+	}
+	if spec.Source == "" {
+		return nil, fmt.Errorf("insert import: no module specifier")
+	}
+
+	var imports []ast.Node
+	for _, stmt := range tree.Root.Children() {
+		if stmt.SyntaxKind() == "import_statement" {
+			imports = append(imports, stmt)
+		}
+	}
+
+	quote := `"`
+	for _, imp := range imports {
+		source := ast.ChildByField(imp, "source")
+		if source == nil || len(source.Text()) < 2 {
+			continue
+		}
+		text := source.Text()
+		quote = text[:1]
+		if text[1:len(text)-1] != spec.Source {
+			continue
+		}
+		if e, ok := mergeImport(imp, spec); ok {
+			return e, nil
+		}
+	}
+
+	text := importText(spec, quote, usesSemicolons(tree.Root))
+	if len(imports) > 0 {
+		return []edits.Edit{insertAt(imports[len(imports)-1], imports[len(imports)-1].Range().End.Offset, "\n"+text)}, nil
+	}
+	for _, stmt := range tree.Root.Children() {
+		if stmt.SyntaxKind() != "comment" && !isDirective(stmt) {
+			return []edits.Edit{insertAt(stmt, stmt.Range().Start.Offset, text+"\n\n")}, nil
+		}
+	}
+	children := tree.Root.Children()
+	if len(children) == 0 {
+		return []edits.Edit{insertAt(tree.Root, tree.Root.Range().Start.Offset, text+"\n")}, nil
+	}
+	last := children[len(children)-1]
+	return []edits.Edit{insertAt(last, last.Range().End.Offset, "\n\n"+text)}, nil
+}
+
+// mergeImport returns the edits adding the named imports of spec to an
+// existing import declaration, and whether spec could be merged into it.
+func mergeImport(imp ast.Node, spec ImportSpec) ([]edits.Edit, bool) {
+	if spec.Default != "" || spec.Namespace != "" || len(spec.Names) == 0 ||
+		ast.FirstChildOfKind(imp, "type") != nil {
+		return nil, false
+	}
+	named := ast.FindDescendant(imp, func(n ast.Node) bool { return n.SyntaxKind() == "named_imports" })
+	if named == nil {
+		return nil, false
+	}
+	specifiers := ast.ChildrenOfKind(named, "import_specifier")
+	var missing []string
+	for _, name := range spec.Names {
+		found := slices.ContainsFunc(specifiers, func(s ast.Node) bool {
+			return strings.Join(strings.Fields(s.Text()), " ") == strings.Join(strings.Fields(name), " ")
+		})
+		if !found && !slices.Contains(missing, name) {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) == 0 {
+		return nil, true
+	}
+	if len(specifiers) == 0 {
+		r := named.Range()
+		return []edits.Edit{{Range: r, NewText: "{ " + strings.Join(missing, ", ") + " }"}}, true
+	}
+	last := specifiers[len(specifiers)-1]
+	return []edits.Edit{edits.InsertAfter(last, ", "+strings.Join(missing, ", "))}, true
+}
+
+// importText formats the import declaration of spec.
+func importText(spec ImportSpec, quote string, semicolon bool) string {
+	var clause []string
+	if spec.Default != "" {
+		clause = append(clause, spec.Default)
+	}
+	if spec.Namespace != "" {
+		clause = append(clause, "* as "+spec.Namespace)
+	}
+	if len(spec.Names) > 0 {
+		clause = append(clause, "{ "+strings.Join(spec.Names, ", ")+" }")
+	}
+	text := "import " + quote + spec.Source + quote
+	if len(clause) > 0 {
+		text = "import " + strings.Join(clause, ", ") + " from " + quote + spec.Source + quote
+	}
+	if semicolon {
+		text += ";"
+	}
+	return text
+}
+
+// RemoveStatement returns the edit removing stmt. A statement on a line of
+// its own is removed with its line; otherwise the spaces separating it
+// from the code around it go with it. The body of a braceless if or loop
+// is replaced by an empty block, to keep the code valid.
+func RemoveStatement(stmt ast.Node) edits.Edit {
+	if parent := stmt.Parent(); parent != nil {
+		switch parent.SyntaxKind() {
+		case "program", "statement_block", "switch_case", "switch_default", "class_body":
+		default:
+			return edits.Replace(stmt, "{}")
+		}
+	}
+
+	text, base := srctext.RootText(stmt)
+	r := stmt.Range()
+	start, end := int(r.Start.Offset-base), int(r.End.Offset-base)
+	lineStart, lineEnd := start, end
+	for lineStart > 0 && isBlank(text[lineStart-1]) {
+		lineStart--
+	}
+	for lineEnd < len(text) && isBlank(text[lineEnd]) {
+		lineEnd++
+	}
+	atLineStart := lineStart == 0 || text[lineStart-1] == '\n'
+	atLineEnd := lineEnd == len(text) || text[lineEnd] == '\n' || text[lineEnd] == '\r'
+	switch {
+	case atLineStart && atLineEnd:
+		start, end = lineStart, lineEnd
+		if strings.HasPrefix(text[end:], "\r\n") {
+			end += 2
+		} else if strings.HasPrefix(text[end:], "\n") {
+			end++
+		}
+	case atLineEnd:
+		start = lineStart
+	default:
+		end = lineEnd
+	}
+	return edits.Edit{Range: ast.Range{Start: positionAt(text, base, start), End: positionAt(text, base, end)}}
+}
+
+// AppendToBody returns the edit adding stmt at the end of the body of fn, a
+// function, method, or block statement. The statement is indented like
+// the last statement of the body, or one level deeper than the body's
+// opening line if the body is empty, and is terminated with a semicolon if
+// the file uses them.
+func AppendToBody(fn ast.Node, stmt string) (edits.Edit, error) {
+	body := fn
+	if body.SyntaxKind() != "statement_block" {
+		body = ast.ChildByField(fn, "body")
+	}
+	if body == nil || body.SyntaxKind() != "statement_block" {
+		return edits.Edit{}, fmt.Errorf("append to body: %s has no block body", fn.SyntaxKind())
+	}
+
+	text, base := srctext.RootText(body)
+	root := body
+	for root.Parent() != nil {
+		root = root.Parent()
+	}
+	stmt = strings.TrimSpace(stmt)
+	if usesSemicolons(root) && !strings.HasSuffix(stmt, ";") && !strings.HasSuffix(stmt, "}") {
+		stmt += ";"
+	}
+
+	var last ast.Node
+	for _, child := range body.Children() {
+		if kind := child.SyntaxKind(); kind != "{" && kind != "}" {
+			last = child
+		}
+	}
+	if last != nil {
+		indent := srctext.LineIndent(text, int(last.Range().Start.Offset-base))
+		return insertAt(body, last.Range().End.Offset, "\n"+indent+stmt), nil
+	}
+
+	// Empty body: put the statement on its own line between the braces
+	r := body.Range()
+	indent := srctext.LineIndent(text, int(r.Start.Offset-base))
+	start, end := int(r.Start.Offset-base)+1, int(r.End.Offset-base)-1
+	return edits.Edit{
+		Range:   ast.Range{Start: positionAt(text, base, start), End: positionAt(text, base, end)},
+		NewText: "\n" + indent + indentUnit(text) + stmt + "\n" + indent,
+	}, nil
+}
+
+// usesSemicolons reports whether the statements of the tree rooted at
+// root mostly end with semicolons. Files without statements to tell are
+// assumed to use them.
+func usesSemicolons(root ast.Node) bool {
+	with, without := 0, 0
+	for node := range ast.Preorder(root) {
+		switch node.SyntaxKind() {
+		case "expression_statement", "lexical_declaration", "variable_declaration",
+			"import_statement", "return_statement", "throw_statement":
+			if strings.HasSuffix(node.Text(), ";") {
+				with++
+			} else {
+				without++
+			}
+		}
+	}
+	return with >= without
+}
+
+// isDirective reports whether stmt is a directive such as "use strict".
+func isDirective(stmt ast.Node) bool {
+	if stmt.SyntaxKind() != "expression_statement" {
+		return false
+	}
+	children := stmt.Children()
+	return len(children) > 0 && children[0].SyntaxKind() == "string"
+}
+
+// insertAt returns the edit inserting text at offset, an offset in the
+// tree containing node.
+func insertAt(node ast.Node, offset uint32, text string) edits.Edit {
+	source, base := srctext.RootText(node)
+	pos := positionAt(source, base, int(offset-base))
+	return edits.Edit{Range: ast.Range{Start: pos, End: pos}, NewText: text}
+}
+
+// positionAt returns the position of byte i of text, which starts at
+// offset base of the source.
+func positionAt(text string, base uint32, i int) ast.Position {
+	line := strings.Count(text[:i], "\n")
+	column := i - (strings.LastIndexByte(text[:i], '\n') + 1)
+	return ast.Position{Line: uint32(line), Column: uint32(column), Offset: base + uint32(i)}
+}
+
+// indentUnit returns the first indentation found in text, or two spaces.
+func indentUnit(text string) string {
+	for _, line := range strings.Split(text, "\n") {
+		if indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]; indent != "" && indent != line {
+			return indent
+		}
+	}
+	return "  "
+}
+
+func isBlank(c byte) bool {
+	return c == ' ' || c == '\t'
+}
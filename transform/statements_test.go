@@ -0,0 +1,146 @@
+package transform
+
+import (
+	"testing"
+
+	"github.com/ahmadramadhannn/tsgoast/ast"
+	"github.com/ahmadramadhannn/tsgoast/edits"
+)
+
+func applyEdits(t *testing.T, src string, e ...edits.Edit) string {
+	t.Helper()
+	out, err := edits.Apply([]byte(src), e)
+	if err != nil {
+		t.Fatalf("edits.Apply() error = %v", err)
+	}
+	return string(out)
+}
+
+func TestInsertImport(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		spec ImportSpec
+		want string
+	}{
+		{
+			name: "after imports",
+			src:  "import a from 'a';\n\nrun();\n",
+			spec: ImportSpec{Source: "b", Default: "b", Names: []string{"c"}},
+			want: "import a from 'a';\nimport b, { c } from 'b';\n\nrun();\n",
+		},
+		{
+			name: "merge",
+			src:  "import { x } from \"./m\";\n",
+			spec: ImportSpec{Source: "./m", Names: []string{"x", "y as z"}},
+			want: "import { x, y as z } from \"./m\";\n",
+		},
+		{
+			name: "no semicolons",
+			src:  "'use strict'\n// header\nconst a = 1\n",
+			spec: ImportSpec{Source: "fs", Namespace: "fs"},
+			want: "'use strict'\n// header\nimport * as fs from \"fs\"\n\nconst a = 1\n",
+		},
+		{
+			name: "side effect",
+			src:  "// empty\n",
+			spec: ImportSpec{Source: "./setup"},
+			want: "// empty\n\nimport \"./setup\";\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, err := InsertImport(parseTree(t, tt.src), tt.spec)
+			if err != nil {
+				t.Fatalf("InsertImport() error = %v", err)
+			}
+			if got := applyEdits(t, tt.src, e...); got != tt.want {
+				t.Errorf("InsertImport() result = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	e, err := InsertImport(parseTree(t, "import { x } from \"m\";\n"), ImportSpec{Source: "m", Names: []string{"x"}})
+	if err != nil || len(e) != 0 {
+		t.Errorf("InsertImport() of an imported name = %v, %v, want no edits", e, err)
+	}
+}
+
+func TestRemoveStatement(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		kind string
+		want string
+	}{
+		{"own line", "a();\n  debugger;\nb();\n", "debugger_statement", "a();\nb();\n"},
+		{"end of line", "a(); debugger;\n", "debugger_statement", "a();\n"},
+		{"start of line", "debugger; a();\n", "debugger_statement", "a();\n"},
+		{"braceless body", "if (x) debugger;\n", "debugger_statement", "if (x) {}\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tree := parseTree(t, tt.src)
+			stmt := ast.FindDescendant(tree.Root, func(n ast.Node) bool { return n.SyntaxKind() == tt.kind })
+			if got := applyEdits(t, tt.src, RemoveStatement(stmt)); got != tt.want {
+				t.Errorf("RemoveStatement() result = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAppendToBody(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		stmt string
+		want string
+	}{
+		{
+			name: "after last statement",
+			src:  "function f() {\n    a();\n}\n",
+			stmt: "b()",
+			want: "function f() {\n    a();\n    b();\n}\n",
+		},
+		{
+			name: "empty body",
+			src:  "class C {\n  m() {}\n}\n",
+			stmt: "return 1;",
+			want: "class C {\n  m() {\n    return 1;\n  }\n}\n",
+		},
+		{
+			name: "no semicolons",
+			src:  "const f = () => {\n\ta()\n}\n",
+			stmt: "b()",
+			want: "const f = () => {\n\ta()\n\tb()\n}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tree := parseTree(t, tt.src)
+			fn := ast.FindDescendant(tree.Root, func(n ast.Node) bool {
+				switch n.SyntaxKind() {
+				case "function_declaration", "method_definition", "arrow_function":
+					return true
+				}
+				return false
+			})
+			e, err := AppendToBody(fn, tt.stmt)
+			if err != nil {
+				t.Fatalf("AppendToBody() error = %v", err)
+			}
+			if got := applyEdits(t, tt.src, e); got != tt.want {
+				t.Errorf("AppendToBody() result = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	tree := parseTree(t, "const f = () => 1;")
+	arrow := ast.FindDescendant(tree.Root, func(n ast.Node) bool { return n.SyntaxKind() == "arrow_function" })
+	if _, err := AppendToBody(arrow, "g()"); err == nil {
+		t.Error("AppendToBody() on an expression body: expected error")
+	}
+}
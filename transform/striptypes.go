@@ -0,0 +1,206 @@
+package transform
+
+import (
+	"fmt"
+
+	"github.com/ahmadramadhannn/tsgoast"
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+// typeOnlyKinds are declarations that have no runtime code.
+var typeOnlyKinds = map[string]bool{
+	"interface_declaration":     true,
+	"type_alias_declaration":    true,
+	"ambient_declaration":       true,
+	"function_signature":        true,
+	"abstract_method_signature": true,
+	"method_signature":          true,
+	"index_signature":           true,
+}
+
+// typeKinds are type syntax inside runtime code: annotations, type
+// parameters and arguments, and modifiers that only exist in TypeScript.
+var typeKinds = map[string]bool{
+	"type_annotation":        true,
+	"type_parameters":        true,
+	"type_arguments":         true,
+	"implements_clause":      true,
+	"accessibility_modifier": true,
+	"override_modifier":      true,
+}
+
+// typeModifiers are modifier keywords without runtime meaning, and the
+// optional and definite assignment markers of declarations.
+var typeModifiers = map[string]bool{
+	"readonly": true,
+	"abstract": true,
+	"?":        true,
+	"!":        true,
+}
+
+// StripTypes returns source, which tree was parsed from, turned into
+// JavaScript: type annotations, type parameters and arguments, interfaces,
+// type aliases, ambient declarations, overload signatures, type-only
+// imports and exports, and as, satisfies and non-null assertions are
+// removed.
+//
+// Removed code is replaced with spaces, keeping line breaks, so the output
+// has the same length and every remaining token keeps its line, column and
+// byte offset; no source map is needed.
+//
+// TypeScript constructs that generate code, such as enums, namespaces
+// with values and parameter properties, cannot be stripped. StripTypes
+// returns an error for the first one it finds.
+func StripTypes(tree *tsgoast.Tree, source []byte) ([]byte, error) {
+	if tree == nil || tree.Root == nil {
+		return nil, fmt.Errorf("strip types: tree has no root node")
+	}
+	s := &stripper{out: append([]byte(nil), source...)}
+	s.node(tree.Root)
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.out, nil
+}
+
+// stripper blanks the type syntax of a tree in a copy of its source.
+type stripper struct {
+	out []byte
+	err error
+}
+
+func (s *stripper) node(node ast.Node) {
+	if s.err != nil {
+		return
+	}
+	kind := node.SyntaxKind()
+	switch {
+	case isTypeOnly(node):
+		s.blank(node)
+		return
+	case typeKinds[kind]:
+		s.blank(node)
+		return
+	case kind == "enum_declaration":
+		s.fail(node, "enum")
+		return
+	case kind == "internal_module" || kind == "module":
+		s.fail(node, "namespace with values")
+		return
+	case kind == "import_specifier" || kind == "export_specifier":
+		if ast.FirstChildOfKind(node, "type") != nil {
+			s.blankItem(node)
+			return
+		}
+	case kind == "required_parameter" || kind == "optional_parameter":
+		if pattern := ast.ChildByField(node, "pattern"); pattern != nil && pattern.SyntaxKind() == "this" {
+			s.blankItem(node)
+			return
+		}
+		if ast.FirstChildOfKind(node, "accessibility_modifier") != nil || ast.FirstChildOfKind(node, "readonly") != nil {
+			s.fail(node, "parameter property")
+			return
+		}
+	case kind == "public_field_definition":
+		if ast.FirstChildOfKind(node, "declare") != nil || ast.FirstChildOfKind(node, "abstract") != nil {
+			s.blank(node)
+			return
+		}
+	case kind == "as_expression" || kind == "satisfies_expression":
+		// Keep the expression, blank the operator and type after it
+		if children := node.Children(); len(children) > 0 {
+			s.node(children[0])
+			s.blankRange(children[0].Range().End.Offset, node.Range().End.Offset)
+		}
+		return
+	}
+
+	for _, child := range node.Children() {
+		if typeModifiers[child.SyntaxKind()] && isModifierOf(kind, child.SyntaxKind()) {
+			s.blank(child)
+			continue
+		}
+		s.node(child)
+	}
+}
+
+// isModifierOf reports whether the modifier token is type syntax in a node
+// of the given kind, rather than an operator such as the ! of a != b.
+func isModifierOf(kind, modifier string) bool {
+	switch kind {
+	case "optional_parameter", "method_definition":
+		return modifier == "?"
+	case "variable_declarator", "non_null_expression":
+		return modifier == "!"
+	case "public_field_definition", "abstract_class_declaration":
+		return true
+	}
+	return false
+}
+
+// isTypeOnly reports whether node is a statement or member without runtime
+// code.
+func isTypeOnly(node ast.Node) bool {
+	kind := node.SyntaxKind()
+	switch {
+	case typeOnlyKinds[kind]:
+		return true
+	case kind == "import_statement":
+		return ast.FirstChildOfKind(node, "type") != nil
+	case kind == "export_statement":
+		if ast.FirstChildOfKind(node, "type") != nil {
+			return true
+		}
+		decl := ast.ChildByField(node, "declaration")
+		return decl != nil && isTypeOnly(decl)
+	case kind == "expression_statement":
+		children := node.Children()
+		return len(children) > 0 && isTypeOnly(children[0])
+	case kind == "internal_module" || kind == "module":
+		body := ast.ChildByField(node, "body")
+		if body == nil {
+			return true
+		}
+		for _, stmt := range body.Children() {
+			if stmt.SyntaxKind() != "{" && stmt.SyntaxKind() != "}" && !isTypeOnly(stmt) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// blank replaces the text of node with spaces.
+func (s *stripper) blank(node ast.Node) {
+	r := node.Range()
+	s.blankRange(r.Start.Offset, r.End.Offset)
+}
+
+// blankItem blanks a list item along with the comma following it.
+func (s *stripper) blankItem(node ast.Node) {
+	s.blank(node)
+	if parent := node.Parent(); parent != nil {
+		children := parent.Children()
+		for i, child := range children {
+			if child == node && i+1 < len(children) && children[i+1].SyntaxKind() == "," {
+				s.blank(children[i+1])
+			}
+		}
+	}
+}
+
+// blankRange replaces the bytes in [start, end) with spaces, keeping line
+// breaks.
+func (s *stripper) blankRange(start, end uint32) {
+	for i := int(start); i < int(end) && i < len(s.out); i++ {
+		if s.out[i] != '\n' && s.out[i] != '\r' {
+			s.out[i] = ' '
+		}
+	}
+}
+
+func (s *stripper) fail(node ast.Node, what string) {
+	start := node.Range().Start
+	s.err = fmt.Errorf("strip types: %s at %d:%d generates code and cannot be stripped", what, start.Line+1, start.Column+1)
+}
@@ -0,0 +1,78 @@
+package transform
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStripTypes(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{
+			name: "annotations",
+			src:  "function add(a: number, b?: number): number {\n  let c!: number;\n  return a + (b ?? 0);\n}\n",
+			want: "function add(a        , b         )         {\n  let c         ;\n  return a + (b ?? 0);\n}\n",
+		},
+		{
+			name: "declarations",
+			src:  "interface P { x: number }\ntype ID = string;\nexport type { P };\ndeclare const g: number;\nconst p = 1;\n",
+			want: "                         \n                 \n                  \n                        \nconst p = 1;\n",
+		},
+		{
+			name: "imports",
+			src:  "import type { A } from \"a\";\nimport { type B, c } from \"b\";\n",
+			want: "                           \nimport {         c } from \"b\";\n",
+		},
+		{
+			name: "assertions",
+			src:  "const v = f<string>(x as any, y!, z satisfies Z, a != b);\n",
+			want: "const v = f        (x       , y , z            , a != b);\n",
+		},
+		{
+			name: "class",
+			src:  "abstract class K<T> extends B<T> implements I {\n  private readonly x?: number = 1;\n  declare y: string;\n  abstract m(): void;\n  override n(this: K<T>, v: T) {}\n}\n",
+			want: "         class K    extends B    " + strings.Repeat(" ", 12) + " {\n                   x          = 1;\n                   ;\n                    ;\n           n(            v   ) {}\n}\n",
+		},
+		{
+			name: "type-only namespace",
+			src:  "namespace N {\n  export type T = 1;\n}\nrun();\n",
+			want: "             \n                    \n \nrun();\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := StripTypes(parseTree(t, tt.src), []byte(tt.src))
+			if err != nil {
+				t.Fatalf("StripTypes() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("StripTypes() =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStripTypesErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{"enum", "enum E { A }", "enum at 1:1"},
+		{"namespace", "namespace N { export const x = 1; }", "namespace with values"},
+		{"parameter property", "class C {\n  constructor(private p: number) {}\n}", "parameter property at 2:15"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := StripTypes(parseTree(t, tt.src), []byte(tt.src))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("StripTypes() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,183 @@
+// Package transform rewrites syntax trees in place, in the spirit of
+// golang.org/x/tools/go/ast/astutil.Apply.
+//
+// Apply walks a tree and hands each node to a callback through a Cursor,
+// which can replace the node, delete it, or insert siblings around it. The
+// result is printed back to source with the printer package, which keeps
+// the original text and layout of everything that was not touched:
+//
+//	transform.Apply(tree, func(c *transform.Cursor) {
+//		if c.Node().SyntaxKind() == "debugger_statement" {
+//			c.Delete()
+//		}
+//	})
+//	fmt.Print(printer.String(tree.Root))
+//
+// Apply only updates the structure of the tree. The Text and Range of the
+// ancestors of modified nodes still describe the original source; parse
+// the printed source to get an accurate tree.
+//
+// StripTypes works on source text instead: it blanks the TypeScript syntax
+// out of a file, producing JavaScript in which every token keeps its
+// position. InsertImport, RemoveStatement, AppendToBody, OrganizeImports
+// and Instrument likewise return text edits (see the edits package) for
+// common changes, following the layout of the file.
+package transform
+
+import (
+	"slices"
+
+	"github.com/ahmadramadhannn/tsgoast"
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+// Cursor describes the node being visited by Apply and lets the callback
+// modify the tree around it.
+type Cursor struct {
+	parent ast.Node
+	base   *ast.BaseNode // parent's BaseNode, whose children are edited
+	index  int
+	node   ast.Node
+
+	deleted bool
+	after   int // nodes inserted after the current one
+	skip    bool
+}
+
+// Node returns the current node, or its replacement.
+func (c *Cursor) Node() ast.Node {
+	return c.node
+}
+
+// Parent returns the parent of the current node, or nil for the root.
+func (c *Cursor) Parent() ast.Node {
+	return c.parent
+}
+
+// Index returns the position of the current node among the children of
+// its parent.
+func (c *Cursor) Index() int {
+	return c.index
+}
+
+// Field returns the tree-sitter field under which the current node is
+// stored in its parent, such as "body", or an empty string.
+func (c *Cursor) Field() string {
+	if b := baseOf(c.node); b != nil {
+		return b.FieldName
+	}
+	return ""
+}
+
+// Replace replaces the current node with n. The children of n are visited
+// next. n takes the place of the current node in its parent, including its
+// field unless n has one.
+func (c *Cursor) Replace(n ast.Node) {
+	c.editable("Replace")
+	c.adopt(n, c.Field())
+	c.base.ChildNodes[c.index] = n
+	c.node = n
+}
+
+// Delete removes the current node from its parent. Its children are not
+// visited.
+func (c *Cursor) Delete() {
+	c.editable("Delete")
+	c.base.ChildNodes = slices.Delete(c.base.ChildNodes, c.index, c.index+1)
+	c.deleted = true
+}
+
+// InsertBefore inserts n before the current node. Inserted nodes are not
+// visited by Apply.
+func (c *Cursor) InsertBefore(n ast.Node) {
+	c.editable("InsertBefore")
+	c.adopt(n, "")
+	c.base.ChildNodes = slices.Insert(c.base.ChildNodes, c.index, n)
+	c.index++
+}
+
+// InsertAfter inserts n right after the current node, so several calls
+// insert nodes in reverse order. Inserted nodes are not visited by Apply.
+func (c *Cursor) InsertAfter(n ast.Node) {
+	c.editable("InsertAfter")
+	c.adopt(n, "")
+	c.base.ChildNodes = slices.Insert(c.base.ChildNodes, c.index+1, n)
+	c.after++
+}
+
+// SkipChildren prevents Apply from visiting the children of the current
+// node.
+func (c *Cursor) SkipChildren() {
+	c.skip = true
+}
+
+// editable panics if the current node cannot be edited.
+func (c *Cursor) editable(method string) {
+	switch {
+	case c.base == nil:
+		panic("transform: " + method + " called on the root node")
+	case c.deleted:
+		panic("transform: " + method + " called after Delete")
+	}
+}
+
+// adopt makes the parent of the cursor the parent of n.
+func (c *Cursor) adopt(n ast.Node, field string) {
+	if b := baseOf(n); b != nil {
+		b.ParentNode = c.parent
+		if b.FieldName == "" {
+			b.FieldName = field
+		}
+	}
+}
+
+// baseOf returns the BaseNode of n, or nil if n has none.
+func baseOf(n ast.Node) *ast.BaseNode {
+	if b, ok := n.(interface{ Base() *ast.BaseNode }); ok {
+		return b.Base()
+	}
+	return nil
+}
+
+// Apply traverses the tree in depth-first order, calling fn for each node
+// before its children, starting with the root. The root cannot be
+// replaced, deleted or given siblings. Tree.Statements is rebuilt
+// afterwards. Apply returns tree.
+func Apply(tree *tsgoast.Tree, fn func(c *Cursor)) *tsgoast.Tree {
+	if tree == nil || tree.Root == nil {
+		return tree
+	}
+	root := &Cursor{node: tree.Root}
+	fn(root)
+	if !root.skip {
+		applyChildren(tree.Root, fn)
+	}
+	tree.RebuildStatements()
+	return tree
+}
+
+// applyChildren visits the children of parent and their subtrees.
+func applyChildren(parent ast.Node, fn func(c *Cursor)) {
+	base := baseOf(parent)
+	if base == nil {
+		return
+	}
+	base.Children() // load the children of lazily converted nodes
+	for i := 0; i < len(base.ChildNodes); {
+		node := base.ChildNodes[i]
+		if node == nil {
+			i++
+			continue
+		}
+		c := &Cursor{parent: parent, base: base, index: i, node: node}
+		fn(c)
+		if c.deleted {
+			i = c.index
+			continue
+		}
+		if !c.skip {
+			applyChildren(c.node, fn)
+		}
+		i = c.index + c.after + 1
+	}
+}
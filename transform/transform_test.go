@@ -0,0 +1,98 @@
+package transform
+
+import (
+	"testing"
+
+	"github.com/ahmadramadhannn/tsgoast"
+	"github.com/ahmadramadhannn/tsgoast/ast"
+	"github.com/ahmadramadhannn/tsgoast/printer"
+)
+
+func parseTree(t *testing.T, src string) *tsgoast.Tree {
+	t.Helper()
+	parser, err := tsgoast.New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+	defer parser.Close()
+
+	tree, err := parser.ParseTree([]byte(src))
+	if err != nil {
+		t.Fatalf("ParseTree() error = %v", err)
+	}
+	return tree
+}
+
+func TestApplyReplace(t *testing.T) {
+	tree := parseTree(t, "function load(id: string) {\n  return fetch(id);\n}\nload(1);\n")
+
+	Apply(tree, func(c *Cursor) {
+		if c.Node().SyntaxKind() == "identifier" && c.Node().Text() == "load" {
+			c.Replace(&ast.BaseNode{TreeSitterKind: "identifier", Content: "loadUser"})
+		}
+	})
+
+	want := "function loadUser(id: string) {\n  return fetch(id);\n}\nloadUser(1);\n"
+	if got := printer.String(tree.Root); got != want {
+		t.Errorf("printed %q, want %q", got, want)
+	}
+
+	fn := tree.Root.Children()[0]
+	if name := ast.ChildByField(fn, "name"); name == nil || name.Text() != "loadUser" || name.Parent() != fn {
+		t.Errorf("name field = %v, want loadUser with parent set", name)
+	}
+}
+
+func TestApplyDeleteAndInsert(t *testing.T) {
+	tree := parseTree(t, `debugger;
+start();
+console.log("x");
+stop();
+`)
+
+	var visited []string
+	Apply(tree, func(c *Cursor) {
+		if c.Parent() != tree.Root {
+			return
+		}
+		visited = append(visited, c.Node().Text())
+		switch text := c.Node().Text(); {
+		case text == "debugger;" || text == `console.log("x");`:
+			c.Delete()
+		case text == "start();":
+			c.InsertBefore(&ast.ExpressionStatement{Expression: &ast.BaseNode{Content: "init()"}})
+			c.InsertAfter(&ast.BaseNode{Content: "step(2);"})
+			c.InsertAfter(&ast.BaseNode{Content: "step(1);"})
+			c.SkipChildren()
+		}
+	})
+
+	want := "init();\nstart();\nstep(1);\nstep(2);\nstop();\n"
+	if got := printer.String(tree.Root); got != want {
+		t.Errorf("printed %q, want %q", got, want)
+	}
+	if len(visited) != 4 {
+		t.Errorf("visited %q, want the 4 original statements", visited)
+	}
+	if len(tree.Statements) != 5 {
+		t.Fatalf("got %d statements, want 5", len(tree.Statements))
+	}
+	if _, ok := tree.Statements[0].(*ast.ExpressionStatement); !ok || tree.Statements[0].Parent() != tree.Root {
+		t.Errorf("statement 0 = %#v, want the inserted statement", tree.Statements[0])
+	}
+}
+
+func TestApplyRoot(t *testing.T) {
+	tree := parseTree(t, "x;")
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Delete() of the root: expected panic")
+		}
+	}()
+	Apply(tree, func(c *Cursor) {
+		if c.Parent() == nil {
+			c.Delete()
+		}
+	})
+}
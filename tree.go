@@ -1,50 +1,79 @@
 package tsgoast
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/ahmadramadhannn/tsgoast/ast"
+	sitter "github.com/tree-sitter/go-tree-sitter"
 )
 
 // Tree represents the complete AST tree with typed statements.
 type Tree struct {
 	Root       *ast.BaseNode
 	Statements []ast.Statement
+
+	// Diagnostics report the subtrees left out of Root by the limits of
+	// the parser, if any.
+	Diagnostics []Diagnostic
+
+	// The source the nodes share, the tree-sitter tree kept by Reparse
+	// for the next one, whether the nodes were converted lazily, the
+	// timings of the parse and the arena of the nodes
+	source string
+	syntax *sitter.Tree
+	lazy   bool
+	stats  ParseStats
+	slab   *slab
 }
 
 // ParseTree parses TypeScript source code and returns a typed AST tree.
 func (p *Parser) ParseTree(source []byte) (*Tree, error) {
-	root, err := p.Parse(source)
+	return p.parseTree("", source)
+}
+
+// parseTree parses source, the content of the file at path if any, into a
+// typed AST tree.
+func (p *Parser) parseTree(path string, source []byte) (*Tree, error) {
+	result, err := p.parse(path, source)
 	if err != nil {
 		return nil, err
 	}
 
 	tree := &Tree{
-		Root:       root,
-		Statements: make([]ast.Statement, 0),
+		Root:        result.root,
+		Statements:  make([]ast.Statement, 0),
+		Diagnostics: result.diagnostics,
+		source:      result.text,
+		lazy:        p.lazy,
+		stats:       result.stats,
+		slab:        result.slab,
 	}
 
 	// Extract statements from the root
-	tree.Statements = p.extractStatements(root)
+	tree.Statements = p.extractStatements(result.root)
 
 	return tree, nil
 }
 
 // ParseTreeFromFile parses a TypeScript file and returns a typed AST tree.
 func (p *Parser) ParseTreeFromFile(path string) (*Tree, error) {
-	root, err := p.ParseFile(path)
+	source, release, err := p.readFile(path)
 	if err != nil {
+		err = fmt.Errorf("failed to read file: %w", err)
+		p.report(path, nil, ParseStats{}, err)
 		return nil, err
 	}
+	defer release()
 
-	tree := &Tree{
-		Root:       root,
-		Statements: make([]ast.Statement, 0),
-	}
-
-	tree.Statements = p.extractStatements(root)
+	return p.parseTree(path, source)
+}
 
-	return tree, nil
+// RebuildStatements re-extracts Statements from Root, after Root has been
+// modified in place, e.g. by a transformation. Children of Root that are
+// typed statements already are kept as they are.
+func (t *Tree) RebuildStatements() {
+	t.Statements = (&Parser{}).extractStatements(t.Root)
 }
 
 // extractStatements extracts typed statements from the AST.
@@ -70,6 +99,10 @@ func (p *Parser) buildStatement(node ast.Node) ast.Statement {
 		return nil
 	}
 
+	if stmt, ok := node.(ast.Statement); ok {
+		return stmt
+	}
+
 	baseNode, ok := node.(*ast.BaseNode)
 	if !ok {
 		return nil
@@ -255,12 +288,69 @@ func (p *Parser) buildForStatement(node *ast.BaseNode) ast.Statement {
 	}
 }
 
+// BuildSwitchStatement builds the typed form of a switch_statement node, as
+// Tree.Statements holds it, e.g. for a switch nested in a function body.
+func BuildSwitchStatement(node *ast.BaseNode) *ast.SwitchStatement {
+	return (&Parser{}).buildSwitchStatement(node)
+}
+
 // buildSwitchStatement builds a switch statement.
 func (p *Parser) buildSwitchStatement(node *ast.BaseNode) *ast.SwitchStatement {
-	return &ast.SwitchStatement{
+	stmt := &ast.SwitchStatement{
 		BaseNode: *node,
 		Cases:    make([]*ast.SwitchCase, 0),
 	}
+
+	for _, child := range node.Children() {
+		switch child.SyntaxKind() {
+		case "parenthesized_expression":
+			// The discriminant is the expression between the parentheses
+			for _, inner := range child.Children() {
+				if inner.SyntaxKind() != "(" && inner.SyntaxKind() != ")" {
+					stmt.Discriminant = inner
+					break
+				}
+			}
+		case "switch_body":
+			for _, c := range child.Children() {
+				if sc := p.buildSwitchCase(c); sc != nil {
+					stmt.Cases = append(stmt.Cases, sc)
+				}
+			}
+		}
+	}
+
+	return stmt
+}
+
+// buildSwitchCase builds a case or default clause of a switch statement.
+func (p *Parser) buildSwitchCase(node ast.Node) *ast.SwitchCase {
+	baseNode, ok := node.(*ast.BaseNode)
+	if !ok || (node.SyntaxKind() != "switch_case" && node.SyntaxKind() != "switch_default") {
+		return nil
+	}
+
+	sc := &ast.SwitchCase{
+		BaseNode:   *baseNode,
+		Consequent: make([]ast.Statement, 0),
+	}
+
+	inBody := false
+	for _, child := range node.Children() {
+		switch kind := child.SyntaxKind(); {
+		case kind == "case" || kind == "default" || kind == "comment":
+		case kind == ":":
+			inBody = true
+		case !inBody:
+			sc.Test = child
+		default:
+			if stmt := p.buildStatement(child); stmt != nil {
+				sc.Consequent = append(sc.Consequent, stmt)
+			}
+		}
+	}
+
+	return sc
 }
 
 // buildTryStatement builds a try statement.
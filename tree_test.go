@@ -425,3 +425,49 @@ func TestAsyncAndExportedFlags(t *testing.T) {
 		t.Error("Expected at least 1 exported function")
 	}
 }
+
+func TestParseTreeSwitchCases(t *testing.T) {
+	parser, err := New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+	defer parser.Close()
+
+	tree, err := parser.ParseTree([]byte(`switch (shape.kind) {
+	case "circle":
+	case "ellipse":
+		draw();
+		break;
+	default:
+		throw new Error("unknown");
+}`))
+	if err != nil {
+		t.Fatalf("ParseTree() error = %v", err)
+	}
+	if len(tree.Statements) != 1 {
+		t.Fatalf("Expected 1 statement, got %d", len(tree.Statements))
+	}
+
+	sw, ok := tree.Statements[0].(*ast.SwitchStatement)
+	if !ok {
+		t.Fatalf("Expected *ast.SwitchStatement, got %T", tree.Statements[0])
+	}
+	if sw.Discriminant == nil || sw.Discriminant.Text() != "shape.kind" {
+		t.Errorf("Discriminant = %v, want shape.kind", sw.Discriminant)
+	}
+	if len(sw.Cases) != 3 {
+		t.Fatalf("Expected 3 cases, got %d", len(sw.Cases))
+	}
+	if sw.Cases[0].Test.Text() != `"circle"` || len(sw.Cases[0].Consequent) != 0 {
+		t.Errorf("case 0 = %v with %d statements", sw.Cases[0].Test, len(sw.Cases[0].Consequent))
+	}
+	if len(sw.Cases[1].Consequent) != 2 {
+		t.Errorf("case 1 has %d statements, want 2", len(sw.Cases[1].Consequent))
+	}
+	if sw.Cases[2].Test != nil {
+		t.Errorf("default case Test = %v, want nil", sw.Cases[2].Test)
+	}
+	if _, ok := sw.Cases[2].Consequent[0].(*ast.ThrowStatement); !ok {
+		t.Errorf("default case statement = %T, want *ast.ThrowStatement", sw.Cases[2].Consequent[0])
+	}
+}
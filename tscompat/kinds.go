@@ -0,0 +1,375 @@
+package tscompat
+
+import (
+	"github.com/ahmadramadhannn/tsgoast/ast"
+	"github.com/ahmadramadhannn/tsgoast/internal/syntax"
+)
+
+// syntaxKinds maps tree-sitter kinds to the ts.SyntaxKind name of the node
+// the TypeScript parser creates for the same syntax. Kinds whose SyntaxKind
+// depends on context are resolved by KindOf.
+var syntaxKinds = map[string]string{
+	// Statements
+	"program":                               "SourceFile",
+	"statement_block":                       "Block",
+	"expression_statement":                  "ExpressionStatement",
+	"lexical_declaration":                   "VariableStatement",
+	"variable_declaration":                  "VariableStatement",
+	"variable_declarator":                   "VariableDeclaration",
+	"if_statement":                          "IfStatement",
+	"while_statement":                       "WhileStatement",
+	"do_statement":                          "DoStatement",
+	"for_statement":                         "ForStatement",
+	"return_statement":                      "ReturnStatement",
+	"throw_statement":                       "ThrowStatement",
+	"break_statement":                       "BreakStatement",
+	"continue_statement":                    "ContinueStatement",
+	"labeled_statement":                     "LabeledStatement",
+	"debugger_statement":                    "DebuggerStatement",
+	"empty_statement":                       "EmptyStatement",
+	"with_statement":                        "WithStatement",
+	"try_statement":                         "TryStatement",
+	"catch_clause":                          "CatchClause",
+	"switch_statement":                      "SwitchStatement",
+	"switch_body":                           "CaseBlock",
+	"switch_case":                           "CaseClause",
+	"switch_default":                        "DefaultClause",
+	"import_statement":                      "ImportDeclaration",
+	"import_clause":                         "ImportClause",
+	"namespace_import":                      "NamespaceImport",
+	"named_imports":                         "NamedImports",
+	"import_specifier":                      "ImportSpecifier",
+	"export_clause":                         "NamedExports",
+	"export_specifier":                      "ExportSpecifier",
+	"namespace_export":                      "NamespaceExport",
+	"statement_identifier":                  "Identifier",
+	"shorthand_property_identifier_pattern": "BindingElement",
+
+	// Declarations
+	"function_declaration":           "FunctionDeclaration",
+	"generator_function_declaration": "FunctionDeclaration",
+	"function_signature":             "FunctionDeclaration",
+	"class_declaration":              "ClassDeclaration",
+	"abstract_class_declaration":     "ClassDeclaration",
+	"interface_declaration":          "InterfaceDeclaration",
+	"type_alias_declaration":         "TypeAliasDeclaration",
+	"enum_declaration":               "EnumDeclaration",
+	"enum_assignment":                "EnumMember",
+	"internal_module":                "ModuleDeclaration",
+	"module":                         "ModuleDeclaration",
+	"public_field_definition":        "PropertyDeclaration",
+	"class_static_block":             "ClassStaticBlockDeclaration",
+	"abstract_method_signature":      "MethodDeclaration",
+	"method_signature":               "MethodSignature",
+	"property_signature":             "PropertySignature",
+	"call_signature":                 "CallSignature",
+	"construct_signature":            "ConstructSignature",
+	"index_signature":                "IndexSignature",
+	"required_parameter":             "Parameter",
+	"optional_parameter":             "Parameter",
+	"type_parameter":                 "TypeParameter",
+	"decorator":                      "Decorator",
+	"extends_clause":                 "HeritageClause",
+	"implements_clause":              "HeritageClause",
+	"extends_type_clause":            "HeritageClause",
+
+	// Expressions
+	"identifier":                      "Identifier",
+	"property_identifier":             "Identifier",
+	"private_property_identifier":     "PrivateIdentifier",
+	"undefined":                       "Identifier",
+	"this":                            "ThisKeyword",
+	"super":                           "SuperKeyword",
+	"true":                            "TrueKeyword",
+	"false":                           "FalseKeyword",
+	"null":                            "NullKeyword",
+	"string":                          "StringLiteral",
+	"number":                          "NumericLiteral",
+	"regex":                           "RegularExpressionLiteral",
+	"array":                           "ArrayLiteralExpression",
+	"object":                          "ObjectLiteralExpression",
+	"pair":                            "PropertyAssignment",
+	"shorthand_property_identifier":   "ShorthandPropertyAssignment",
+	"computed_property_name":          "ComputedPropertyName",
+	"member_expression":               "PropertyAccessExpression",
+	"subscript_expression":            "ElementAccessExpression",
+	"call_expression":                 "CallExpression",
+	"new_expression":                  "NewExpression",
+	"parenthesized_expression":        "ParenthesizedExpression",
+	"binary_expression":               "BinaryExpression",
+	"assignment_expression":           "BinaryExpression",
+	"augmented_assignment_expression": "BinaryExpression",
+	"sequence_expression":             "BinaryExpression",
+	"ternary_expression":              "ConditionalExpression",
+	"await_expression":                "AwaitExpression",
+	"yield_expression":                "YieldExpression",
+	"function_expression":             "FunctionExpression",
+	"generator_function":              "FunctionExpression",
+	"arrow_function":                  "ArrowFunction",
+	"class":                           "ClassExpression",
+	"as_expression":                   "AsExpression",
+	"satisfies_expression":            "SatisfiesExpression",
+	"non_null_expression":             "NonNullExpression",
+	"type_assertion":                  "TypeAssertionExpression",
+	"template_substitution":           "TemplateSpan",
+
+	// Binding patterns
+	"object_pattern":            "ObjectBindingPattern",
+	"array_pattern":             "ArrayBindingPattern",
+	"pair_pattern":              "BindingElement",
+	"assignment_pattern":        "BindingElement",
+	"object_assignment_pattern": "BindingElement",
+	"rest_pattern":              "BindingElement",
+
+	// Types
+	"generic_type":           "TypeReference",
+	"nested_type_identifier": "QualifiedName",
+	"nested_identifier":      "QualifiedName",
+	"union_type":             "UnionType",
+	"intersection_type":      "IntersectionType",
+	"array_type":             "ArrayType",
+	"tuple_type":             "TupleType",
+	"optional_type":          "OptionalType",
+	"rest_type":              "RestType",
+	"parenthesized_type":     "ParenthesizedType",
+	"function_type":          "FunctionType",
+	"constructor_type":       "ConstructorType",
+	"object_type":            "TypeLiteral",
+	"literal_type":           "LiteralType",
+	"type_query":             "TypeQuery",
+	"index_type_query":       "TypeOperator",
+	"readonly_type":          "TypeOperator",
+	"lookup_type":            "IndexedAccessType",
+	"conditional_type":       "ConditionalType",
+	"infer_type":             "InferType",
+	"template_literal_type":  "TemplateLiteralType",
+	"type_predicate":         "TypePredicate",
+	"asserts":                "TypePredicate",
+	"this_type":              "ThisType",
+}
+
+// keywordKinds maps keywords and punctuation to their token SyntaxKind.
+var keywordKinds = map[string]string{
+	// Modifiers
+	"export":     "ExportKeyword",
+	"default":    "DefaultKeyword",
+	"declare":    "DeclareKeyword",
+	"async":      "AsyncKeyword",
+	"static":     "StaticKeyword",
+	"readonly":   "ReadonlyKeyword",
+	"abstract":   "AbstractKeyword",
+	"override":   "OverrideKeyword",
+	"accessor":   "AccessorKeyword",
+	"const":      "ConstKeyword",
+	"public":     "PublicKeyword",
+	"private":    "PrivateKeyword",
+	"protected":  "ProtectedKeyword",
+	"extends":    "ExtendsKeyword",
+	"implements": "ImplementsKeyword",
+
+	// Types
+	"any":       "AnyKeyword",
+	"unknown":   "UnknownKeyword",
+	"never":     "NeverKeyword",
+	"void":      "VoidKeyword",
+	"string":    "StringKeyword",
+	"number":    "NumberKeyword",
+	"boolean":   "BooleanKeyword",
+	"bigint":    "BigIntKeyword",
+	"symbol":    "SymbolKeyword",
+	"object":    "ObjectKeyword",
+	"undefined": "UndefinedKeyword",
+	"keyof":     "KeyOfKeyword",
+	"unique":    "UniqueKeyword",
+
+	// Operators
+	"+":          "PlusToken",
+	"-":          "MinusToken",
+	"*":          "AsteriskToken",
+	"/":          "SlashToken",
+	"%":          "PercentToken",
+	"**":         "AsteriskAsteriskToken",
+	"++":         "PlusPlusToken",
+	"--":         "MinusMinusToken",
+	"<":          "LessThanToken",
+	">":          "GreaterThanToken",
+	"<=":         "LessThanEqualsToken",
+	">=":         "GreaterThanEqualsToken",
+	"==":         "EqualsEqualsToken",
+	"!=":         "ExclamationEqualsToken",
+	"===":        "EqualsEqualsEqualsToken",
+	"!==":        "ExclamationEqualsEqualsToken",
+	"&&":         "AmpersandAmpersandToken",
+	"||":         "BarBarToken",
+	"??":         "QuestionQuestionToken",
+	"&":          "AmpersandToken",
+	"|":          "BarToken",
+	"^":          "CaretToken",
+	"~":          "TildeToken",
+	"!":          "ExclamationToken",
+	"<<":         "LessThanLessThanToken",
+	">>":         "GreaterThanGreaterThanToken",
+	">>>":        "GreaterThanGreaterThanGreaterThanToken",
+	"in":         "InKeyword",
+	"instanceof": "InstanceOfKeyword",
+	",":          "CommaToken",
+	"=":          "EqualsToken",
+	"+=":         "PlusEqualsToken",
+	"-=":         "MinusEqualsToken",
+	"*=":         "AsteriskEqualsToken",
+	"/=":         "SlashEqualsToken",
+	"%=":         "PercentEqualsToken",
+	"**=":        "AsteriskAsteriskEqualsToken",
+	"&=":         "AmpersandEqualsToken",
+	"|=":         "BarEqualsToken",
+	"^=":         "CaretEqualsToken",
+	"<<=":        "LessThanLessThanEqualsToken",
+	">>=":        "GreaterThanGreaterThanEqualsToken",
+	">>>=":       "GreaterThanGreaterThanGreaterThanEqualsToken",
+	"&&=":        "AmpersandAmpersandEqualsToken",
+	"||=":        "BarBarEqualsToken",
+	"??=":        "QuestionQuestionEqualsToken",
+	"?":          "QuestionToken",
+	":":          "ColonToken",
+	"...":        "DotDotDotToken",
+	"=>":         "EqualsGreaterThanToken",
+}
+
+// modifierKeywords are the keyword tokens TypeScript keeps in a
+// declaration's modifiers.
+var modifierKeywords = map[string]bool{
+	"export":   true,
+	"default":  true,
+	"declare":  true,
+	"async":    true,
+	"static":   true,
+	"readonly": true,
+	"abstract": true,
+	"override": true,
+	"accessor": true,
+}
+
+// KindOf returns the ts.SyntaxKind name of the node TypeScript creates for
+// node, such as "FunctionDeclaration" or "PropertyAccessExpression". Kinds
+// TypeScript does not model as nodes of their own, such as
+// formal_parameters, map to the PascalCase form of the tree-sitter kind.
+func KindOf(node ast.Node) string {
+	kind := node.SyntaxKind()
+	parent := node.Parent()
+
+	switch kind {
+	case "for_in_statement":
+		if op := ast.ChildByField(node, "operator"); op != nil && op.Text() == "of" {
+			return "ForOfStatement"
+		}
+		return "ForInStatement"
+	case "lexical_declaration", "variable_declaration":
+		if parent != nil && parent.SyntaxKind() == "for_statement" {
+			return "VariableDeclarationList"
+		}
+	case "method_definition":
+		switch {
+		case syntax.FieldText(node, "name") == "constructor":
+			return "Constructor"
+		case syntax.HasToken(node, "get"):
+			return "GetAccessor"
+		case syntax.HasToken(node, "set"):
+			return "SetAccessor"
+		}
+		return "MethodDeclaration"
+	case "unary_expression":
+		switch syntax.FieldText(node, "operator") {
+		case "typeof":
+			return "TypeOfExpression"
+		case "void":
+			return "VoidExpression"
+		case "delete":
+			return "DeleteExpression"
+		}
+		return "PrefixUnaryExpression"
+	case "update_expression":
+		if children := node.Children(); len(children) > 0 && syntax.IsToken(children[0]) {
+			return "PrefixUnaryExpression"
+		}
+		return "PostfixUnaryExpression"
+	case "call_expression":
+		if args := ast.ChildByField(node, "arguments"); args != nil && args.SyntaxKind() == "template_string" {
+			return "TaggedTemplateExpression"
+		}
+	case "template_string":
+		if ast.FirstChildOfKind(node, "template_substitution") == nil {
+			return "NoSubstitutionTemplateLiteral"
+		}
+		return "TemplateExpression"
+	case "spread_element":
+		if parent != nil && parent.SyntaxKind() == "object" {
+			return "SpreadAssignment"
+		}
+		return "SpreadElement"
+	case "predefined_type":
+		if k, ok := keywordKinds[node.Text()]; ok {
+			return k
+		}
+	case "type_identifier":
+		if isTypeName(node) {
+			return "Identifier"
+		}
+		return "TypeReference"
+	case "required_parameter", "optional_parameter":
+		if parent != nil && parent.SyntaxKind() == "tuple_type" {
+			return "NamedTupleMember"
+		}
+	case "this":
+		if ast.FieldOf(node) == "pattern" {
+			return "Identifier"
+		}
+	case "shorthand_property_identifier_pattern":
+		if parent != nil && parent.SyntaxKind() == "object_assignment_pattern" {
+			return "Identifier"
+		}
+	case "property_identifier":
+		if parent != nil && parent.SyntaxKind() == "enum_body" {
+			return "EnumMember"
+		}
+	case "statement_block":
+		if parent != nil && (parent.SyntaxKind() == "internal_module" || parent.SyntaxKind() == "module") {
+			return "ModuleBlock"
+		}
+	case "export_statement":
+		switch {
+		case ast.ChildByField(node, "declaration") != nil:
+			return KindOf(ast.ChildByField(node, "declaration"))
+		case ast.ChildByField(node, "value") != nil, syntax.HasToken(node, "="):
+			return "ExportAssignment"
+		}
+		return "ExportDeclaration"
+	case "ambient_declaration":
+		if inner := syntax.FirstNamed(node); inner != nil {
+			return KindOf(inner)
+		}
+	}
+
+	if k, ok := syntaxKinds[kind]; ok {
+		return k
+	}
+	return syntax.PascalCase(kind)
+}
+
+// typeNameParents are the kinds whose type_identifier children name a
+// declaration or form part of a reference, rather than being references.
+var typeNameParents = map[string]bool{
+	"generic_type":           true,
+	"nested_type_identifier": true,
+	"implements_clause":      true,
+	"type_parameter":         true,
+}
+
+// isTypeName reports whether a type_identifier is a name rather than a
+// type reference.
+func isTypeName(node ast.Node) bool {
+	if ast.FieldOf(node) == "name" {
+		return true
+	}
+	parent := node.Parent()
+	return parent == nil || typeNameParents[parent.SyntaxKind()]
+}
@@ -0,0 +1,955 @@
+// Package tscompat converts tsgoast syntax trees into the node structure of
+// the TypeScript compiler API, so that consumers written against tsc's AST
+// can read tsgoast output with little or no change.
+//
+// Each converted node is labelled with the name of its ts.SyntaxKind, such as
+// "FunctionDeclaration" or "PropertyAccessExpression", and sits in the
+// property of its parent that tsc would use, such as "name", "parameters" or
+// "initializer". The tree follows tsc's shape rather than tree-sitter's:
+// export and declare keywords become modifiers, lexical declarations become
+// a VariableStatement holding a VariableDeclarationList, type identifiers in
+// type positions become TypeReference nodes, unions are flattened, and
+// wrapper nodes without a tsc counterpart, like formal_parameters or
+// class_body, are dissolved into their parent. Punctuation is dropped, as
+// it is by ts.forEachChild, but tokens tsc stores as child nodes, such as
+// modifiers, operatorToken and questionToken, are kept.
+//
+// Positions follow tsc's conventions: Pos is the full start of the node,
+// including leading trivia, Start is the start of its first token, as
+// returned by getStart(), and all offsets are UTF-16 code units. Kinds are
+// given by name rather than by numeric ts.SyntaxKind value because those
+// values change between TypeScript releases; consumers can resolve them with
+// ts.SyntaxKind[name] for the compiler version they use.
+package tscompat
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ahmadramadhannn/tsgoast/ast"
+	"github.com/ahmadramadhannn/tsgoast/internal/syntax"
+)
+
+// Node is a node in the TypeScript compiler API shape.
+type Node struct {
+	Kind     string   `json:"kind"`               // ts.SyntaxKind name, e.g. "Identifier"
+	Property string   `json:"property,omitempty"` // property of the parent holding this node
+	Pos      uint32   `json:"pos"`                // full start, including leading trivia
+	Start    uint32   `json:"start"`              // start of the first token
+	End      uint32   `json:"end"`
+	Text     string   `json:"text,omitempty"`     // identifier names and cooked literal values
+	Operator string   `json:"operator,omitempty"` // SyntaxKind of operators stored as kinds, e.g. "PlusPlusToken"
+	Flags    []string `json:"flags,omitempty"`    // ts.NodeFlags names, e.g. "Const"
+	Children []*Node  `json:"children,omitempty"` // in ts.forEachChild order
+
+	parent *Node
+}
+
+// Parent returns the parent node, or nil for the root and for nodes
+// decoded from JSON.
+func (n *Node) Parent() *Node {
+	return n.parent
+}
+
+// Get returns the first child held in the given property, such as "name",
+// or nil.
+func (n *Node) Get(property string) *Node {
+	for _, child := range n.Children {
+		if child.Property == property {
+			return child
+		}
+	}
+	return nil
+}
+
+// GetAll returns the children held in the given property, such as
+// "parameters" or "modifiers".
+func (n *Node) GetAll(property string) []*Node {
+	var nodes []*Node
+	for _, child := range n.Children {
+		if child.Property == property {
+			nodes = append(nodes, child)
+		}
+	}
+	return nodes
+}
+
+// ForEachChild calls fn for each child of n in source order until fn
+// returns false, like ts.forEachChild.
+func (n *Node) ForEachChild(fn func(*Node) bool) {
+	for _, child := range n.Children {
+		if !fn(child) {
+			return
+		}
+	}
+}
+
+// Convert converts the subtree rooted at root into TypeScript compiler API
+// nodes. It returns nil if root is nil.
+func Convert(root ast.Node) *Node {
+	if root == nil {
+		return nil
+	}
+	c := newConverter(root)
+	out := c.convert(root)
+	if out != nil && out.Kind == "SourceFile" {
+		out.Pos = 0
+		eof := c.newNode("EndOfFileToken", root.Range().End.Offset, root.Range().End.Offset)
+		c.add(out, "endOfFileToken", eof)
+	}
+	return out
+}
+
+// Marshal converts root with Convert and encodes the result as JSON.
+func Marshal(root ast.Node) ([]byte, error) {
+	return json.Marshal(Convert(root))
+}
+
+// converter builds compiler API nodes, converting byte offsets to UTF-16.
+type converter struct {
+	index *ast.UTF16Index
+	ends  []uint32 // byte offsets of token ends in source order
+}
+
+// newConverter indexes the text and tokens of root.
+func newConverter(root ast.Node) *converter {
+	c := &converter{index: ast.NewUTF16Index(root)}
+	for node := range ast.Preorder(root) {
+		if len(node.Children()) == 0 && node.SyntaxKind() != "comment" {
+			c.ends = append(c.ends, node.Range().End.Offset)
+		}
+	}
+	return c
+}
+
+// fullStart returns the end of the last token before start, which is
+// where tsc considers a node's leading trivia to begin.
+func (c *converter) fullStart(start uint32) uint32 {
+	i := sort.Search(len(c.ends), func(i int) bool { return c.ends[i] > start })
+	if i == 0 {
+		return 0
+	}
+	return c.ends[i-1]
+}
+
+// newNode creates a node of the given kind spanning the byte range
+// [start, end).
+func (c *converter) newNode(kind string, start, end uint32) *Node {
+	return &Node{
+		Kind:  kind,
+		Pos:   c.index.Offset(c.fullStart(start)),
+		Start: c.index.Offset(start),
+		End:   c.index.Offset(end),
+	}
+}
+
+// node creates a node of the given kind spanning src.
+func (c *converter) node(kind string, src ast.Node) *Node {
+	r := src.Range()
+	return c.newNode(kind, r.Start.Offset, r.End.Offset)
+}
+
+// add appends child to out under the given property.
+func (c *converter) add(out *Node, property string, child *Node) {
+	if child == nil {
+		return
+	}
+	child.Property = property
+	child.parent = out
+	out.Children = append(out.Children, child)
+}
+
+// prepend inserts modifiers before the children of out and extends out to
+// start at src.
+func (c *converter) prepend(out *Node, src ast.Node, modifiers []*Node) {
+	for _, m := range modifiers {
+		m.Property = "modifiers"
+		m.parent = out
+	}
+	out.Children = append(modifiers, out.Children...)
+	start := src.Range().Start.Offset
+	out.Pos = c.index.Offset(c.fullStart(start))
+	out.Start = c.index.Offset(start)
+}
+
+// wrappers are tree-sitter kinds with no compiler API counterpart, whose
+// children belong directly to the parent.
+var wrappers = map[string]bool{
+	"formal_parameters":         true,
+	"arguments":                 true,
+	"type_arguments":            true,
+	"type_parameters":           true,
+	"type_annotation":           true,
+	"asserts_annotation":        true,
+	"type_predicate_annotation": true,
+	"class_body":                true,
+	"interface_body":            true,
+	"enum_body":                 true,
+	"class_heritage":            true,
+	"else_clause":               true,
+	"finally_clause":            true,
+	"constraint":                true,
+	"default_type":              true,
+}
+
+// fieldProperties maps tree-sitter fields to the compiler API property
+// holding the same child. Entries of the form "Kind.field" apply to one
+// SyntaxKind and take precedence.
+var fieldProperties = map[string]string{
+	"name":            "name",
+	"body":            "body",
+	"value":           "initializer",
+	"parameters":      "parameters",
+	"return_type":     "type",
+	"type":            "type",
+	"type_parameters": "typeParameters",
+	"type_arguments":  "typeArguments",
+	"arguments":       "arguments",
+	"function":        "expression",
+	"constructor":     "expression",
+	"object":          "expression",
+	"property":        "name",
+	"index":           "argumentExpression",
+	"argument":        "operand",
+	"condition":       "expression",
+	"consequence":     "thenStatement",
+	"alternative":     "elseStatement",
+	"source":          "moduleSpecifier",
+	"label":           "label",
+	"decorator":       "modifiers",
+	"pattern":         "name",
+	"alias":           "name",
+	"key":             "name",
+	"constraint":      "constraint",
+	"initializer":     "initializer",
+	"increment":       "incrementor",
+	"handler":         "catchClause",
+	"finalizer":       "finallyBlock",
+	"left":            "left",
+	"right":           "right",
+	"optional_chain":  "questionDotToken",
+
+	"ConditionalExpression.condition":    "condition",
+	"ConditionalExpression.consequence":  "whenTrue",
+	"ConditionalExpression.alternative":  "whenFalse",
+	"ForStatement.condition":             "condition",
+	"ForStatement.body":                  "statement",
+	"ForInStatement.right":               "expression",
+	"ForInStatement.body":                "statement",
+	"ForOfStatement.right":               "expression",
+	"ForOfStatement.body":                "statement",
+	"WhileStatement.body":                "statement",
+	"DoStatement.body":                   "statement",
+	"WithStatement.body":                 "statement",
+	"LabeledStatement.body":              "statement",
+	"TryStatement.body":                  "tryBlock",
+	"CatchClause.body":                   "block",
+	"SwitchStatement.value":              "expression",
+	"SwitchStatement.body":               "caseBlock",
+	"CaseClause.value":                   "expression",
+	"CaseClause.body":                    "statements",
+	"DefaultClause.body":                 "statements",
+	"ClassDeclaration.body":              "members",
+	"ClassExpression.body":               "members",
+	"InterfaceDeclaration.body":          "members",
+	"EnumDeclaration.body":               "members",
+	"TypeAliasDeclaration.value":         "type",
+	"TypeParameter.value":                "default",
+	"TypePredicate.name":                 "parameterName",
+	"TaggedTemplateExpression.function":  "tag",
+	"TaggedTemplateExpression.arguments": "template",
+	"TypeOfExpression.argument":          "expression",
+	"VoidExpression.argument":            "expression",
+	"DeleteExpression.argument":          "expression",
+	"ExportAssignment.value":             "expression",
+	"BindingElement.key":                 "propertyName",
+	"BindingElement.value":               "name",
+	"BindingElement.left":                "name",
+	"BindingElement.right":               "initializer",
+	"QualifiedName.module":               "left",
+	"QualifiedName.name":                 "right",
+	"QualifiedName.object":               "left",
+	"QualifiedName.property":             "right",
+	"TypeReference.name":                 "typeName",
+	"ConditionalType.left":               "checkType",
+	"ConditionalType.right":              "extendsType",
+	"ConditionalType.consequence":        "trueType",
+	"ConditionalType.alternative":        "falseType",
+	"Constructor.name":                   "",
+}
+
+// childProperties gives the property of children without a field, by the
+// SyntaxKind of their parent.
+var childProperties = map[string]string{
+	"SourceFile":                  "statements",
+	"Block":                       "statements",
+	"ModuleBlock":                 "statements",
+	"CaseBlock":                   "clauses",
+	"ExpressionStatement":         "expression",
+	"ReturnStatement":             "expression",
+	"ThrowStatement":              "expression",
+	"ParenthesizedExpression":     "expression",
+	"AwaitExpression":             "expression",
+	"YieldExpression":             "expression",
+	"SpreadElement":               "expression",
+	"SpreadAssignment":            "expression",
+	"Decorator":                   "expression",
+	"ComputedPropertyName":        "expression",
+	"NonNullExpression":           "expression",
+	"TemplateSpan":                "expression",
+	"ExportAssignment":            "expression",
+	"VariableDeclarationList":     "declarations",
+	"VariableStatement":           "declarations",
+	"ArrayLiteralExpression":      "elements",
+	"ObjectLiteralExpression":     "properties",
+	"ObjectBindingPattern":        "elements",
+	"ArrayBindingPattern":         "elements",
+	"BindingElement":              "name",
+	"NamedImports":                "elements",
+	"NamedExports":                "elements",
+	"NamespaceImport":             "name",
+	"NamespaceExport":             "name",
+	"ExportDeclaration":           "exportClause",
+	"ImportDeclaration":           "importClause",
+	"ImportClause":                "name",
+	"UnionType":                   "types",
+	"IntersectionType":            "types",
+	"TupleType":                   "elements",
+	"ArrayType":                   "elementType",
+	"OptionalType":                "type",
+	"RestType":                    "type",
+	"ParenthesizedType":           "type",
+	"TypeOperator":                "type",
+	"TypeQuery":                   "exprName",
+	"LiteralType":                 "literal",
+	"TypeLiteral":                 "members",
+	"TypePredicate":               "parameterName",
+	"HeritageClause":              "types",
+	"ClassDeclaration":            "heritageClauses",
+	"ClassExpression":             "heritageClauses",
+	"InterfaceDeclaration":        "heritageClauses",
+	"ClassStaticBlockDeclaration": "body",
+}
+
+// positionalProperties gives the properties of children without a field
+// by position, for kinds whose children tree-sitter does not label.
+var positionalProperties = map[string][]string{
+	"BinaryExpression":        {"left", "right"},
+	"AsExpression":            {"expression", "type"},
+	"SatisfiesExpression":     {"expression", "type"},
+	"TypeAssertionExpression": {"type", "expression"},
+	"IndexedAccessType":       {"objectType", "indexType"},
+}
+
+// unwrapParens lists the properties where tree-sitter keeps the
+// parentheses of the syntax as a parenthesized_expression but tsc does not.
+var unwrapParens = map[string]bool{
+	"IfStatement.condition":    true,
+	"WhileStatement.condition": true,
+	"DoStatement.condition":    true,
+	"SwitchStatement.value":    true,
+}
+
+// property returns the property of out that holds a child in the given
+// field. position counts the preceding unlabelled children.
+func property(out *Node, field string, position int) string {
+	if field != "" {
+		if p, ok := fieldProperties[out.Kind+"."+field]; ok {
+			return p
+		}
+		if p, ok := fieldProperties[field]; ok {
+			return p
+		}
+		return field
+	}
+	if props, ok := positionalProperties[out.Kind]; ok && position < len(props) {
+		return props[position]
+	}
+	return childProperties[out.Kind]
+}
+
+// convert converts n and its children.
+func (c *converter) convert(n ast.Node) *Node {
+	switch n.SyntaxKind() {
+	case "comment":
+		return nil
+	case "export_statement":
+		return c.exportStatement(n)
+	case "ambient_declaration":
+		return c.ambientDeclaration(n)
+	case "expression_statement":
+		// tree-sitter parses `namespace A {}` as an expression statement
+		if inner := syntax.FirstNamed(n); inner != nil && inner.SyntaxKind() == "internal_module" {
+			return c.convert(inner)
+		}
+	case "lexical_declaration", "variable_declaration":
+		return c.variableStatement(n)
+	case "for_in_statement":
+		return c.forInStatement(n)
+	case "catch_clause":
+		return c.catchClause(n)
+	case "template_string":
+		return c.template(n)
+	case "string":
+		out := c.node("StringLiteral", n)
+		text := n.Text()
+		if len(text) >= 2 {
+			out.Text = cook(text[1:len(text)-1], text[0])
+		}
+		return out
+	case "number":
+		return c.number(n)
+	case "union_type", "intersection_type":
+		return c.flatType(n)
+	case "extends_clause":
+		return c.extendsClause(n)
+	case "implements_clause", "extends_type_clause":
+		return c.implementsClause(n)
+	case "index_signature":
+		return c.indexSignature(n)
+	case "infer_type":
+		out := c.node("InferType", n)
+		if name := syntax.FirstNamed(n); name != nil {
+			param := c.node("TypeParameter", name)
+			c.add(param, "name", c.node("Identifier", name))
+			param.Children[0].Text = name.Text()
+			c.add(out, "typeParameter", param)
+		}
+		return out
+	case "accessibility_modifier", "override_modifier":
+		return c.node(keywordKinds[n.Text()], n)
+	case "optional_chain":
+		return c.node("QuestionDotToken", n)
+	case "predefined_type":
+		return c.node(KindOf(n), n)
+	case "literal_type":
+		if n.Text() == "undefined" {
+			return c.node("UndefinedKeyword", n)
+		}
+	}
+
+	kind := KindOf(n)
+	out := c.node(kind, n)
+	switch kind {
+	case "Identifier", "PrivateIdentifier", "RegularExpressionLiteral":
+		out.Text = n.Text()
+		return out
+	case "TypeReference":
+		if n.SyntaxKind() == "type_identifier" || n.SyntaxKind() == "nested_type_identifier" {
+			// A bare name in a type position refers to a type
+			name := c.node(typeNameKind(n), n)
+			if name.Kind == "Identifier" {
+				name.Text = n.Text()
+			} else {
+				c.children(name, n)
+			}
+			c.add(out, "typeName", name)
+			return out
+		}
+	case "ShorthandPropertyAssignment", "BindingElement", "EnumMember":
+		if len(n.Children()) == 0 {
+			name := c.node("Identifier", n)
+			name.Text = n.Text()
+			c.add(out, "name", name)
+			return out
+		}
+	}
+	c.children(out, n)
+	return out
+}
+
+// typeNameKind returns the kind of a type reference's name.
+func typeNameKind(n ast.Node) string {
+	if n.SyntaxKind() == "nested_type_identifier" {
+		return "QualifiedName"
+	}
+	return "Identifier"
+}
+
+// children converts the children of n into children of out.
+func (c *converter) children(out *Node, n ast.Node) {
+	position := 0
+	for _, child := range n.Children() {
+		if child.SyntaxKind() == "comment" {
+			continue
+		}
+		field := ast.FieldOf(child)
+		if syntax.IsToken(child) {
+			c.token(out, n, child, field)
+			continue
+		}
+
+		prop := property(out, field, position)
+		if field == "" {
+			position++
+		}
+		switch {
+		case field == "name" && ast.ChildByField(n, "alias") != nil:
+			prop = "propertyName"
+		case child.SyntaxKind() == "accessibility_modifier", child.SyntaxKind() == "override_modifier", child.SyntaxKind() == "decorator":
+			prop = "modifiers"
+		case out.Kind == "ImportClause" && child.SyntaxKind() != "identifier":
+			prop = "namedBindings"
+		}
+		if unwrapParens[out.Kind+"."+field] && child.SyntaxKind() == "parenthesized_expression" {
+			if inner := syntax.FirstNamed(child); inner != nil {
+				child = inner
+			}
+		}
+		c.child(out, n, child, prop)
+	}
+}
+
+// child converts child into a child of out held in the given property.
+func (c *converter) child(out *Node, parent, child ast.Node, prop string) {
+	kind := child.SyntaxKind()
+	switch {
+	case prop == "" && out.Kind == "Constructor":
+		return
+	case kind == "empty_statement" && parent.SyntaxKind() == "for_statement":
+		// for (;;) has no initializer or condition
+		return
+	case wrappers[kind],
+		kind == "rest_pattern" && out.Kind == "Parameter",
+		kind == "assignment_pattern" && parent.SyntaxKind() == "pair_pattern",
+		kind == "type_predicate" && parent.SyntaxKind() == "asserts":
+		for _, grandchild := range child.Children() {
+			if grandchild.SyntaxKind() == "comment" {
+				continue
+			}
+			field := ast.FieldOf(grandchild)
+			if syntax.IsToken(grandchild) {
+				c.token(out, child, grandchild, field)
+				continue
+			}
+			p := prop
+			if field != "" && !wrappers[kind] {
+				p = property(out, field, 0)
+			}
+			c.child(out, child, grandchild, p)
+		}
+		return
+	}
+
+	if out.Kind == "ArrowFunction" && ast.FieldOf(child) == "parameter" {
+		param := c.node("Parameter", child)
+		c.add(param, "name", c.convert(child))
+		c.add(out, "parameters", param)
+		return
+	}
+	c.add(out, prop, c.convert(child))
+}
+
+// token records the tokens tsc keeps, such as modifiers and operators, and
+// drops punctuation.
+func (c *converter) token(out *Node, parent, tok ast.Node, field string) {
+	text := tok.SyntaxKind()
+	kind := keywordKinds[text]
+	switch {
+	case kind == "":
+		if text == "asserts" {
+			c.add(out, "assertsModifier", c.node("AssertsKeyword", tok))
+		} else if text == "await" && out.Kind == "ForOfStatement" {
+			c.add(out, "awaitModifier", c.node("AwaitKeyword", tok))
+		}
+	case out.Kind == "BinaryExpression" && (field == "operator" || text == ","):
+		c.add(out, "operatorToken", c.node(kind, tok))
+	case out.Kind == "PrefixUnaryExpression" || out.Kind == "PostfixUnaryExpression":
+		if field == "operator" {
+			out.Operator = kind
+		}
+	case out.Kind == "TypeOperator" || out.Kind == "HeritageClause":
+		out.Operator = kind
+	case modifierKeywords[text]:
+		switch parent.SyntaxKind() {
+		case "switch_default", "class_static_block", "export_statement":
+			return
+		}
+		c.add(out, "modifiers", c.node(kind, tok))
+	case text == "const" && parent.SyntaxKind() == "enum_declaration":
+		c.add(out, "modifiers", c.node(kind, tok))
+	case text == "*" && out.Kind != "NamespaceImport" && out.Kind != "NamespaceExport" && out.Kind != "ExportDeclaration":
+		c.add(out, "asteriskToken", c.node(kind, tok))
+	case text == "?" && out.Kind != "ConditionalType":
+		c.add(out, "questionToken", c.node(kind, tok))
+	case text == ":" && out.Kind == "ConditionalExpression":
+		c.add(out, "colonToken", c.node(kind, tok))
+	case text == "!" && out.Kind != "NonNullExpression":
+		c.add(out, "exclamationToken", c.node(kind, tok))
+	case text == "..." && (out.Kind == "Parameter" || out.Kind == "BindingElement"):
+		c.add(out, "dotDotDotToken", c.node(kind, tok))
+	case text == "=>":
+		c.add(out, "equalsGreaterThanToken", c.node(kind, tok))
+	}
+}
+
+// exportStatement converts an export statement. Exported declarations
+// carry export (and default) modifiers, as tsc has no node for the
+// statement itself.
+func (c *converter) exportStatement(n ast.Node) *Node {
+	if decl := ast.ChildByField(n, "declaration"); decl != nil {
+		out := c.convert(decl)
+		var modifiers []*Node
+		for _, child := range n.Children() {
+			switch {
+			case child.SyntaxKind() == "decorator":
+				modifiers = append(modifiers, c.convert(child))
+			case syntax.IsToken(child) && modifierKeywords[child.SyntaxKind()]:
+				modifiers = append(modifiers, c.node(keywordKinds[child.SyntaxKind()], child))
+			}
+		}
+		c.prepend(out, n, modifiers)
+		return out
+	}
+
+	out := c.node(KindOf(n), n)
+	c.children(out, n)
+	return out
+}
+
+// ambientDeclaration converts a declare statement into the declaration it
+// wraps, with a declare modifier.
+func (c *converter) ambientDeclaration(n ast.Node) *Node {
+	inner := syntax.FirstNamed(n)
+	if inner == nil {
+		return c.node("Unknown", n)
+	}
+	out := c.convert(inner)
+	var modifiers []*Node
+	if tok := ast.FirstChildOfKind(n, "declare"); tok != nil {
+		modifiers = append(modifiers, c.node("DeclareKeyword", tok))
+	}
+	c.prepend(out, n, modifiers)
+	return out
+}
+
+// variableStatement converts a lexical or var declaration into a
+// VariableStatement holding a VariableDeclarationList, or just the list
+// inside a for statement.
+func (c *converter) variableStatement(n ast.Node) *Node {
+	declarators := ast.ChildrenOfKind(n, "variable_declarator")
+	start := n.Range().Start.Offset
+	end := start
+	if len(declarators) > 0 {
+		end = declarators[len(declarators)-1].Range().End.Offset
+	}
+
+	list := c.newNode("VariableDeclarationList", start, end)
+	list.Flags = declarationFlags(ast.ChildByField(n, "kind"))
+	for _, d := range declarators {
+		c.add(list, "declarations", c.convert(d))
+	}
+	if KindOf(n) == "VariableDeclarationList" {
+		return list
+	}
+
+	out := c.node("VariableStatement", n)
+	c.add(out, "declarationList", list)
+	return out
+}
+
+// declarationFlags returns the node flags for a let, const or var keyword.
+func declarationFlags(kind ast.Node) []string {
+	if kind == nil {
+		return nil
+	}
+	switch kind.Text() {
+	case "let":
+		return []string{"Let"}
+	case "const":
+		return []string{"Const"}
+	}
+	return nil
+}
+
+// forInStatement converts for-in and for-of loops. A declared loop
+// variable becomes a VariableDeclarationList, as in tsc.
+func (c *converter) forInStatement(n ast.Node) *Node {
+	out := c.node(KindOf(n), n)
+	kind := ast.ChildByField(n, "kind")
+	left := ast.ChildByField(n, "left")
+	if kind != nil && left != nil {
+		list := c.newNode("VariableDeclarationList", kind.Range().Start.Offset, left.Range().End.Offset)
+		list.Flags = declarationFlags(kind)
+		decl := c.node("VariableDeclaration", left)
+		c.add(decl, "name", c.convert(left))
+		c.add(list, "declarations", decl)
+
+		for _, child := range n.Children() {
+			if child == left {
+				c.add(out, "initializer", list)
+				continue
+			}
+			if child == kind || child.SyntaxKind() == "comment" {
+				continue
+			}
+			if syntax.IsToken(child) {
+				c.token(out, n, child, ast.FieldOf(child))
+				continue
+			}
+			c.child(out, n, child, property(out, ast.FieldOf(child), 0))
+		}
+		return out
+	}
+
+	c.children(out, n)
+	return out
+}
+
+// catchClause converts a catch clause, wrapping its parameter in a
+// VariableDeclaration.
+func (c *converter) catchClause(n ast.Node) *Node {
+	out := c.node("CatchClause", n)
+	param := ast.ChildByField(n, "parameter")
+	if param != nil {
+		end := param.Range().End.Offset
+		typ := ast.ChildByField(n, "type")
+		if typ != nil {
+			end = typ.Range().End.Offset
+		}
+		decl := c.newNode("VariableDeclaration", param.Range().Start.Offset, end)
+		c.add(decl, "name", c.convert(param))
+		if typ != nil {
+			c.child(decl, n, typ, "type")
+		}
+		c.add(out, "variableDeclaration", decl)
+	}
+	if body := ast.ChildByField(n, "body"); body != nil {
+		c.add(out, "block", c.convert(body))
+	}
+	return out
+}
+
+// template converts a template string into a NoSubstitutionTemplateLiteral
+// or a TemplateExpression with a head and one span per substitution.
+func (c *converter) template(n ast.Node) *Node {
+	r := n.Range()
+	subs := ast.ChildrenOfKind(n, "template_substitution")
+	if len(subs) == 0 {
+		out := c.node("NoSubstitutionTemplateLiteral", n)
+		out.Text = cook(strings.Trim(n.Text(), "`"), '`')
+		return out
+	}
+
+	source := n.Text()
+	text := func(start, end uint32) string {
+		return cook(source[start-r.Start.Offset:end-r.Start.Offset], '`')
+	}
+
+	out := c.node("TemplateExpression", n)
+	first := subs[0].Range().Start.Offset
+	head := c.newNode("TemplateHead", r.Start.Offset, first+2)
+	head.Text = text(r.Start.Offset+1, first)
+	c.add(out, "head", head)
+
+	for i, sub := range subs {
+		start := sub.Range().End.Offset - 1
+		kind, end, textEnd := "TemplateTail", r.End.Offset, r.End.Offset-1
+		if i+1 < len(subs) {
+			next := subs[i+1].Range().Start.Offset
+			kind, end, textEnd = "TemplateMiddle", next+2, next
+		}
+		literal := c.newNode(kind, start, end)
+		literal.Text = text(start+1, textEnd)
+
+		span := c.newNode("TemplateSpan", sub.Range().Start.Offset+2, end)
+		if expr := syntax.FirstNamed(sub); expr != nil {
+			c.add(span, "expression", c.convert(expr))
+		}
+		c.add(span, "literal", literal)
+		c.add(out, "templateSpans", span)
+	}
+	return out
+}
+
+// number converts a numeric literal. tsc stores the value of numbers in
+// decimal form and keeps bigint literals with their suffix.
+func (c *converter) number(n ast.Node) *Node {
+	raw := strings.ReplaceAll(n.Text(), "_", "")
+	if strings.HasSuffix(raw, "n") {
+		out := c.node("BigIntLiteral", n)
+		out.Text = raw
+		return out
+	}
+
+	out := c.node("NumericLiteral", n)
+	out.Text = raw
+	lower := strings.ToLower(raw)
+	for prefix, base := range map[string]int{"0x": 16, "0o": 8, "0b": 2} {
+		if strings.HasPrefix(lower, prefix) {
+			if v, err := strconv.ParseUint(lower[2:], base, 64); err == nil {
+				out.Text = strconv.FormatUint(v, 10)
+			}
+			return out
+		}
+	}
+	if v, err := strconv.ParseFloat(raw, 64); err == nil && v < 1e21 {
+		out.Text = strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return out
+}
+
+// flatType converts a union or intersection type, flattening the nested
+// binary form tree-sitter produces into a single list of types.
+func (c *converter) flatType(n ast.Node) *Node {
+	out := c.node(KindOf(n), n)
+	var collect func(ast.Node)
+	collect = func(t ast.Node) {
+		for _, child := range syntax.Named(t) {
+			if child.SyntaxKind() == n.SyntaxKind() {
+				collect(child)
+				continue
+			}
+			c.add(out, "types", c.convert(child))
+		}
+	}
+	collect(n)
+	return out
+}
+
+// extendsClause converts a class extends clause into a HeritageClause
+// holding an ExpressionWithTypeArguments.
+func (c *converter) extendsClause(n ast.Node) *Node {
+	out := c.node("HeritageClause", n)
+	out.Operator = "ExtendsKeyword"
+	var current *Node
+	for _, child := range syntax.Named(n) {
+		if ast.FieldOf(child) == "type_arguments" && current != nil {
+			for _, arg := range syntax.Named(child) {
+				c.add(current, "typeArguments", c.convert(arg))
+			}
+			current.End = c.index.Offset(child.Range().End.Offset)
+			continue
+		}
+		current = c.node("ExpressionWithTypeArguments", child)
+		c.add(current, "expression", c.convert(child))
+		c.add(out, "types", current)
+	}
+	return out
+}
+
+// implementsClause converts an implements clause, or the extends clause of
+// an interface, into a HeritageClause.
+func (c *converter) implementsClause(n ast.Node) *Node {
+	out := c.node("HeritageClause", n)
+	out.Operator = keywordKinds[n.Children()[0].Text()]
+	for _, child := range syntax.Named(n) {
+		current := c.node("ExpressionWithTypeArguments", child)
+		name := child
+		if child.SyntaxKind() == "generic_type" {
+			name = ast.ChildByField(child, "name")
+		}
+		if name != nil {
+			c.add(current, "expression", c.entityExpression(name))
+		}
+		if args := ast.ChildByField(child, "type_arguments"); args != nil {
+			for _, arg := range syntax.Named(args) {
+				c.add(current, "typeArguments", c.convert(arg))
+			}
+		}
+		c.add(out, "types", current)
+	}
+	return out
+}
+
+// entityExpression converts a type name used as a heritage expression into
+// an Identifier or PropertyAccessExpression.
+func (c *converter) entityExpression(n ast.Node) *Node {
+	switch n.SyntaxKind() {
+	case "nested_type_identifier", "nested_identifier", "member_expression":
+		out := c.node("PropertyAccessExpression", n)
+		for _, child := range syntax.Named(n) {
+			prop := "expression"
+			if f := ast.FieldOf(child); f == "name" || f == "property" {
+				prop = "name"
+			}
+			c.add(out, prop, c.entityExpression(child))
+		}
+		return out
+	}
+	out := c.node("Identifier", n)
+	out.Text = n.Text()
+	return out
+}
+
+// indexSignature converts an index signature, whose key tsc models as a
+// parameter.
+func (c *converter) indexSignature(n ast.Node) *Node {
+	out := c.node("IndexSignature", n)
+	name := ast.ChildByField(n, "name")
+	for _, child := range n.Children() {
+		switch {
+		case child.SyntaxKind() == "comment":
+		case syntax.IsToken(child):
+			c.token(out, n, child, ast.FieldOf(child))
+		case child == name:
+			end := name.Range().End.Offset
+			keyType := ast.ChildByField(n, "index_type")
+			if keyType != nil {
+				end = keyType.Range().End.Offset
+			}
+			param := c.newNode("Parameter", name.Range().Start.Offset, end)
+			c.add(param, "name", c.convert(name))
+			if keyType != nil {
+				c.add(param, "type", c.convert(keyType))
+			}
+			c.add(out, "parameters", param)
+		case ast.FieldOf(child) == "index_type":
+		default:
+			c.child(out, n, child, property(out, ast.FieldOf(child), 0))
+		}
+	}
+	return out
+}
+
+// cook resolves the escape sequences of a string or template literal body
+// quoted with quote, giving the value tsc stores as the literal's text.
+func cook(s string, quote byte) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var b strings.Builder
+	for len(s) > 0 {
+		if s[0] != '\\' {
+			r, size := utf8.DecodeRuneInString(s)
+			b.WriteRune(r)
+			s = s[size:]
+			continue
+		}
+		if len(s) < 2 {
+			break
+		}
+		switch {
+		case s[1] == '\n':
+			// Line continuation
+			s = s[2:]
+			continue
+		case s[1] == '0' && (len(s) == 2 || s[2] < '0' || s[2] > '9'):
+			b.WriteByte(0)
+			s = s[2:]
+			continue
+		case strings.HasPrefix(s, `\u{`):
+			if end := strings.IndexByte(s, '}'); end > 0 {
+				if v, err := strconv.ParseUint(s[3:end], 16, 32); err == nil {
+					b.WriteRune(rune(v))
+					s = s[end+1:]
+					continue
+				}
+			}
+		}
+		r, _, tail, err := strconv.UnquoteChar(s, quote)
+		if err != nil {
+			// Unknown escapes stand for the escaped character
+			r, size := utf8.DecodeRuneInString(s[1:])
+			b.WriteRune(r)
+			s = s[1+size:]
+			continue
+		}
+		b.WriteRune(r)
+		s = tail
+	}
+	return b.String()
+}
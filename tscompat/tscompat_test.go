@@ -0,0 +1,381 @@
+package tscompat
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/ahmadramadhannn/tsgoast"
+	"github.com/ahmadramadhannn/tsgoast/ast"
+)
+
+func parse(t *testing.T, source string) *ast.BaseNode {
+	t.Helper()
+	parser, err := tsgoast.New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+	defer parser.Close()
+
+	root, err := parser.Parse([]byte(source))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	return root
+}
+
+// get follows a path of properties, with an int selecting among the
+// children held in the preceding property.
+func get(t *testing.T, n *Node, path ...any) *Node {
+	t.Helper()
+	var property string
+	for _, step := range path {
+		switch s := step.(type) {
+		case string:
+			if property != "" {
+				n = n.Get(property)
+			}
+			property = s
+		case int:
+			list := n.GetAll(property)
+			if s >= len(list) {
+				t.Fatalf("path %v: %s has %d nodes", path, property, len(list))
+			}
+			n, property = list[s], ""
+		}
+		if n == nil {
+			t.Fatalf("path %v: missing node", path)
+		}
+	}
+	if property != "" {
+		n = n.Get(property)
+		if n == nil {
+			t.Fatalf("path %v: missing %s", path, property)
+		}
+	}
+	return n
+}
+
+// kinds returns the kinds of nodes.
+func kinds(nodes []*Node) []string {
+	var out []string
+	for _, n := range nodes {
+		out = append(out, n.Kind)
+	}
+	return out
+}
+
+func TestConvertStatements(t *testing.T) {
+	file := Convert(parse(t, `let a = 1;
+export async function f<T>(x?: number, ...rest: T[]): Promise<void> {}
+export { a as b } from "m";
+const { p, q: r = 1 } = o;
+for (const k of ks) {}
+`))
+	if file.Kind != "SourceFile" {
+		t.Fatalf("Kind = %s, want SourceFile", file.Kind)
+	}
+	if got := kinds(file.GetAll("statements")); !reflect.DeepEqual(got, []string{
+		"VariableStatement", "FunctionDeclaration", "ExportDeclaration", "VariableStatement", "ForOfStatement",
+	}) {
+		t.Errorf("statements = %v", got)
+	}
+	if eof := file.Get("endOfFileToken"); eof == nil || eof.End != file.End {
+		t.Errorf("endOfFileToken = %+v, want a token at %d", eof, file.End)
+	}
+
+	list := get(t, file, "statements", 0, "declarationList")
+	if list.Kind != "VariableDeclarationList" || !reflect.DeepEqual(list.Flags, []string{"Let"}) {
+		t.Errorf("declarationList = %s %v, want VariableDeclarationList [Let]", list.Kind, list.Flags)
+	}
+	if got := get(t, list, "declarations", 0, "initializer"); got.Kind != "NumericLiteral" || got.Text != "1" {
+		t.Errorf("initializer = %s %q", got.Kind, got.Text)
+	}
+
+	fn := get(t, file, "statements", 1)
+	if got := kinds(fn.GetAll("modifiers")); !reflect.DeepEqual(got, []string{"ExportKeyword", "AsyncKeyword"}) {
+		t.Errorf("modifiers = %v", got)
+	}
+	if got := get(t, fn, "name"); got.Text != "f" {
+		t.Errorf("name = %q, want f", got.Text)
+	}
+	if got := get(t, fn, "typeParameters", 0, "name"); got.Text != "T" {
+		t.Errorf("type parameter = %q, want T", got.Text)
+	}
+	params := fn.GetAll("parameters")
+	if len(params) != 2 {
+		t.Fatalf("parameters = %d, want 2", len(params))
+	}
+	if params[0].Get("questionToken") == nil || get(t, params[0], "type").Kind != "NumberKeyword" {
+		t.Errorf("first parameter = %v, want optional number", kinds(params[0].Children))
+	}
+	if params[1].Get("dotDotDotToken") == nil || get(t, params[1], "type", "elementType", "typeName").Text != "T" {
+		t.Errorf("rest parameter = %v, want ...T[]", kinds(params[1].Children))
+	}
+	ret := get(t, fn, "type")
+	if ret.Kind != "TypeReference" || get(t, ret, "typeName").Text != "Promise" || get(t, ret, "typeArguments", 0).Kind != "VoidKeyword" {
+		t.Errorf("return type = %s %v", ret.Kind, kinds(ret.Children))
+	}
+
+	spec := get(t, file, "statements", 2, "exportClause", "elements", 0)
+	if get(t, spec, "propertyName").Text != "a" || get(t, spec, "name").Text != "b" {
+		t.Errorf("export specifier = %v", kinds(spec.Children))
+	}
+	if got := get(t, file, "statements", 2, "moduleSpecifier"); got.Kind != "StringLiteral" || got.Text != "m" {
+		t.Errorf("moduleSpecifier = %s %q", got.Kind, got.Text)
+	}
+
+	binding := get(t, file, "statements", 3, "declarationList", "declarations", 0, "name", "elements", 1)
+	if binding.Kind != "BindingElement" || get(t, binding, "propertyName").Text != "q" ||
+		get(t, binding, "name").Text != "r" || get(t, binding, "initializer").Text != "1" {
+		t.Errorf("binding element = %v", kinds(binding.Children))
+	}
+
+	loop := get(t, file, "statements", 4, "initializer")
+	if loop.Kind != "VariableDeclarationList" || !reflect.DeepEqual(loop.Flags, []string{"Const"}) {
+		t.Errorf("for-of initializer = %s %v", loop.Kind, loop.Flags)
+	}
+}
+
+func TestConvertExpressions(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		kind   string
+		check  func(t *testing.T, n *Node)
+	}{
+		{
+			name:   "binary",
+			source: "a + b",
+			kind:   "BinaryExpression",
+			check: func(t *testing.T, n *Node) {
+				if got := get(t, n, "operatorToken").Kind; got != "PlusToken" {
+					t.Errorf("operatorToken = %s", got)
+				}
+			},
+		},
+		{
+			name:   "postfix",
+			source: "i++",
+			kind:   "PostfixUnaryExpression",
+			check: func(t *testing.T, n *Node) {
+				if n.Operator != "PlusPlusToken" || get(t, n, "operand").Text != "i" {
+					t.Errorf("operator = %s", n.Operator)
+				}
+			},
+		},
+		{
+			name:   "typeof",
+			source: "typeof x",
+			kind:   "TypeOfExpression",
+			check: func(t *testing.T, n *Node) {
+				get(t, n, "expression")
+			},
+		},
+		{
+			name:   "optional chain",
+			source: "a?.b",
+			kind:   "PropertyAccessExpression",
+			check: func(t *testing.T, n *Node) {
+				if n.Get("questionDotToken") == nil || get(t, n, "name").Text != "b" {
+					t.Errorf("children = %v", kinds(n.Children))
+				}
+			},
+		},
+		{
+			name:   "call",
+			source: "f<T>(1, x)",
+			kind:   "CallExpression",
+			check: func(t *testing.T, n *Node) {
+				if len(n.GetAll("arguments")) != 2 || len(n.GetAll("typeArguments")) != 1 {
+					t.Errorf("children = %v", kinds(n.Children))
+				}
+			},
+		},
+		{
+			name:   "conditional",
+			source: "a ? b : c",
+			kind:   "ConditionalExpression",
+			check: func(t *testing.T, n *Node) {
+				if get(t, n, "whenTrue").Text != "b" || get(t, n, "whenFalse").Text != "c" {
+					t.Errorf("children = %v", kinds(n.Children))
+				}
+			},
+		},
+		{
+			name:   "template",
+			source: "`a${b}c\\n`",
+			kind:   "TemplateExpression",
+			check: func(t *testing.T, n *Node) {
+				if got := get(t, n, "head").Text; got != "a" {
+					t.Errorf("head = %q", got)
+				}
+				if got := get(t, n, "templateSpans", 0, "literal"); got.Kind != "TemplateTail" || got.Text != "c\n" {
+					t.Errorf("literal = %s %q", got.Kind, got.Text)
+				}
+			},
+		},
+		{
+			name:   "hex number",
+			source: "0x1F",
+			kind:   "NumericLiteral",
+			check: func(t *testing.T, n *Node) {
+				if n.Text != "31" {
+					t.Errorf("Text = %q, want 31", n.Text)
+				}
+			},
+		},
+		{
+			name:   "escaped string",
+			source: `'it\'s!'`,
+			kind:   "StringLiteral",
+			check: func(t *testing.T, n *Node) {
+				if n.Text != "it's!" {
+					t.Errorf("Text = %q, want it's!", n.Text)
+				}
+			},
+		},
+		{
+			name:   "arrow",
+			source: "async x => x",
+			kind:   "ArrowFunction",
+			check: func(t *testing.T, n *Node) {
+				if get(t, n, "parameters", 0, "name").Text != "x" || n.Get("equalsGreaterThanToken") == nil {
+					t.Errorf("children = %v", kinds(n.Children))
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := Convert(parse(t, tt.source+";"))
+			n := get(t, file, "statements", 0, "expression")
+			if n.Kind != tt.kind {
+				t.Fatalf("Kind = %s, want %s", n.Kind, tt.kind)
+			}
+			tt.check(t, n)
+		})
+	}
+}
+
+func TestConvertDeclarations(t *testing.T) {
+	file := Convert(parse(t, `class A extends B<T> implements C {
+  private readonly x?: number = 1;
+  constructor(public y: string) { super(); }
+  get z() { return 1; }
+}
+interface I extends J { [key: string]: number; m(): void }
+type U = "a" | keyof V | W[];
+enum E { A, B = 1 }
+declare module "m" {}
+`))
+
+	class := get(t, file, "statements", 0)
+	if class.Kind != "ClassDeclaration" {
+		t.Fatalf("Kind = %s, want ClassDeclaration", class.Kind)
+	}
+	heritage := class.GetAll("heritageClauses")
+	if len(heritage) != 2 || heritage[0].Operator != "ExtendsKeyword" || heritage[1].Operator != "ImplementsKeyword" {
+		t.Fatalf("heritageClauses = %v", heritage)
+	}
+	if got := get(t, heritage[0], "types", 0, "typeArguments", 0, "typeName").Text; got != "T" {
+		t.Errorf("extends type argument = %q, want T", got)
+	}
+	if got := kinds(class.GetAll("members")); !reflect.DeepEqual(got, []string{"PropertyDeclaration", "Constructor", "GetAccessor"}) {
+		t.Errorf("members = %v", got)
+	}
+	field := get(t, class, "members", 0)
+	if got := kinds(field.GetAll("modifiers")); !reflect.DeepEqual(got, []string{"PrivateKeyword", "ReadonlyKeyword"}) {
+		t.Errorf("field modifiers = %v", got)
+	}
+	if field.Get("questionToken") == nil || field.Get("initializer") == nil {
+		t.Errorf("field = %v", kinds(field.Children))
+	}
+	ctor := get(t, class, "members", 1)
+	if ctor.Get("name") != nil {
+		t.Errorf("constructor has a name")
+	}
+	if got := kinds(get(t, ctor, "parameters", 0).GetAll("modifiers")); !reflect.DeepEqual(got, []string{"PublicKeyword"}) {
+		t.Errorf("parameter property modifiers = %v", got)
+	}
+
+	iface := get(t, file, "statements", 1)
+	if got := kinds(iface.GetAll("members")); !reflect.DeepEqual(got, []string{"IndexSignature", "MethodSignature"}) {
+		t.Errorf("interface members = %v", got)
+	}
+	if got := get(t, iface, "members", 0, "parameters", 0, "type").Kind; got != "StringKeyword" {
+		t.Errorf("index parameter type = %s", got)
+	}
+
+	union := get(t, file, "statements", 2, "type")
+	if got := kinds(union.GetAll("types")); !reflect.DeepEqual(got, []string{"LiteralType", "TypeOperator", "ArrayType"}) {
+		t.Errorf("union types = %v", got)
+	}
+
+	enum := get(t, file, "statements", 3)
+	if got := kinds(enum.GetAll("members")); !reflect.DeepEqual(got, []string{"EnumMember", "EnumMember"}) {
+		t.Errorf("enum members = %v", got)
+	}
+
+	module := get(t, file, "statements", 4)
+	if module.Kind != "ModuleDeclaration" || get(t, module, "modifiers", 0).Kind != "DeclareKeyword" ||
+		get(t, module, "body").Kind != "ModuleBlock" {
+		t.Errorf("module = %s %v", module.Kind, kinds(module.Children))
+	}
+}
+
+func TestPositions(t *testing.T) {
+	// "😀" is two UTF-16 code units and four bytes
+	file := Convert(parse(t, "let a = \"😀\"; // note\nlet b = 2;"))
+	second := get(t, file, "statements", 1)
+	if second.Pos != 13 || second.Start != 22 || second.End != 32 {
+		t.Errorf("second statement = [%d %d %d], want [13 22 32]", second.Pos, second.Start, second.End)
+	}
+	first := get(t, file, "statements", 0)
+	if first.Pos != 0 || first.Start != 0 || first.End != 13 {
+		t.Errorf("first statement = [%d %d %d], want [0 0 13]", first.Pos, first.Start, first.End)
+	}
+	if p := get(t, second, "declarationList").Parent(); p != second {
+		t.Errorf("Parent() = %v, want the statement", p)
+	}
+}
+
+func TestKindOf(t *testing.T) {
+	root := parse(t, "for (x in o) {} class A { static m() {} } let v: T;")
+	found := map[string]string{}
+	for node := range ast.Preorder(root) {
+		found[node.SyntaxKind()] = KindOf(node)
+	}
+
+	tests := map[string]string{
+		"program":             "SourceFile",
+		"for_in_statement":    "ForInStatement",
+		"method_definition":   "MethodDeclaration",
+		"lexical_declaration": "VariableStatement",
+		"formal_parameters":   "FormalParameters",
+	}
+	for kind, want := range tests {
+		if got := found[kind]; got != want {
+			t.Errorf("KindOf(%s) = %q, want %q", kind, got, want)
+		}
+	}
+}
+
+func TestMarshal(t *testing.T) {
+	data, err := Marshal(parse(t, "x = 1;"))
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	var decoded Node
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if got := get(t, &decoded, "statements", 0, "expression", "left").Text; got != "x" {
+		t.Errorf("decoded left = %q, want x", got)
+	}
+
+	if Convert(nil) != nil {
+		t.Error("Convert(nil) != nil")
+	}
+}